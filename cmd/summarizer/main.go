@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"flag"
+	"fmt"
 	"runtime"
 	"time"
 
@@ -37,6 +38,7 @@ type options struct {
 	dashboard   string
 	concurrency int
 	wait        time.Duration
+	compression gcs.Compression
 }
 
 func (o *options) validate() error {
@@ -46,6 +48,11 @@ func (o *options) validate() error {
 	if o.concurrency == 0 {
 		o.concurrency = 4 * runtime.NumCPU()
 	}
+	compression, err := gcs.ParseCompression(string(o.compression))
+	if err != nil {
+		return fmt.Errorf("--compression: %v", err)
+	}
+	o.compression = compression
 	return nil
 }
 
@@ -57,6 +64,7 @@ func gatherOptions() options {
 	flag.StringVar(&o.dashboard, "dashboard", "", "Only update named dashboard if set")
 	flag.IntVar(&o.concurrency, "concurrency", 0, "Manually define the number of dashboards to concurrently update if non-zero")
 	flag.DurationVar(&o.wait, "wait", 0, "Ensure at least this much time has passed since the last loop (exit if zero).")
+	flag.StringVar((*string)(&o.compression), "compression", string(gcs.Gzip), "Compress summaries with gzip or zstd, which costs less CPU but requires readers that detect it")
 	flag.Parse()
 	return o
 }
@@ -68,21 +76,29 @@ func main() {
 		logrus.Fatalf("Invalid flags: %v", err)
 	}
 	if !opt.confirm {
-		logrus.Info("--confirm=false (DRY-RUN): will not write to gcs")
+		logrus.Info("--confirm=false (DRY-RUN): will list what it would write to gcs")
 	}
 
 	ctx := context.Background()
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	client, err := gcs.ClientWithCreds(ctx, opt.creds)
+	storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
 	if err != nil {
 		logrus.Fatalf("Failed to read storage client: %v", err)
 	}
+	client := gcs.NewRetryClient(gcs.NewClient(storageClient), gcs.DefaultRetryOptions)
+	// Dry runs compute every summary and record what they would write.
+	writeClient := gcs.NewWriteClient(client, !opt.confirm)
 
 	updateOnce := func(ctx context.Context) error {
 		ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
 		defer cancel()
-		return summarizer.Update(ctx, client, opt.config, opt.concurrency, opt.dashboard, opt.confirm)
+		err := summarizer.Update(ctx, writeClient, opt.config, opt.concurrency, opt.dashboard, true, opt.compression)
+		writes := writeClient.Writes()
+		if !opt.confirm {
+			fmt.Print(gcs.FormatWrites(writes))
+		}
+		return err
 	}
 
 	if err := updateOnce(ctx); err != nil {
@@ -21,12 +21,17 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"net/http"
+	"regexp"
 	"runtime"
 	"time"
 
+	"github.com/GoogleCloudPlatform/testgrid/pkg/metrics"
 	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 )
 
@@ -43,11 +48,19 @@ type options struct {
 	confirm          bool
 	debug            bool
 	group            string
+	groupAllow       string
+	filter           updater.GroupFilter
+	strict           bool
+	maxGridBytes     int
+	junitCacheBytes  int64
+	compression      gcs.Compression
 	groupConcurrency int
 	buildConcurrency int
 	wait             time.Duration
 	groupTimeout     time.Duration
 	buildTimeout     time.Duration
+	metricsAddress   string
+	report           gcs.Path // gs://path/to/report.json
 }
 
 // validate ensures sane options
@@ -58,6 +71,21 @@ func (o *options) validate() error {
 	if o.config.Bucket() == "k8s-testgrid" && o.config.Object() != "beta/config" && o.confirm { // TODO(fejta): remove
 		return fmt.Errorf("--config=%s cannot write to gs://k8s-testgrid/config", o.config)
 	}
+	compression, err := gcs.ParseCompression(string(o.compression))
+	if err != nil {
+		return fmt.Errorf("--compression: %v", err)
+	}
+	o.compression = compression
+	if o.groupAllow != "" {
+		re, err := regexp.Compile(o.groupAllow)
+		if err != nil {
+			return fmt.Errorf("--test-group-regexp: %v", err)
+		}
+		o.filter.Allow = re
+	}
+	if err := o.filter.Validate(); err != nil {
+		return fmt.Errorf("--shard: %v", err)
+	}
 	if o.groupConcurrency == 0 {
 		o.groupConcurrency = 4 * runtime.NumCPU()
 	}
@@ -76,11 +104,20 @@ func gatherOptions() options {
 	flag.BoolVar(&o.confirm, "confirm", false, "Upload data if set")
 	flag.BoolVar(&o.debug, "debug", false, "Log debug lines if set")
 	flag.StringVar(&o.group, "test-group", "", "Only update named group if set")
+	flag.StringVar(&o.groupAllow, "test-group-regexp", "", "Only update groups whose name matches this regexp if set")
+	flag.IntVar(&o.filter.Shard, "shard", 0, "Only update the groups of this shard, in [0, --shard-count)")
+	flag.IntVar(&o.filter.Shards, "shard-count", 0, "Split groups between this many replicas by hashing their normalized name, if non-zero")
+	flag.BoolVar(&o.strict, "strict", false, "Fail the group update when a build cannot be read, instead of writing an empty column (for debugging)")
+	flag.IntVar(&o.maxGridBytes, "max-grid-bytes", 0, "Drop the oldest columns of grids that serialize into more bytes than this if non-zero")
+	flag.Int64Var(&o.junitCacheBytes, "junit-cache-bytes", 256<<20, "Share the junit files parsed by groups reading the same builds, up to this many bytes of them per loop (disabled if zero)")
+	flag.StringVar((*string)(&o.compression), "compression", string(gcs.Gzip), "Compress grids with gzip or zstd, which costs less CPU but requires readers that detect it")
 	flag.IntVar(&o.groupConcurrency, "group-concurrency", 0, "Manually define the number of groups to concurrently update if non-zero")
 	flag.IntVar(&o.buildConcurrency, "build-concurrency", 0, "Manually define the number of builds to concurrently read if non-zero")
 	flag.DurationVar(&o.wait, "wait", 0, "Ensure at least this much time has passed since the last loop (exit if zero).")
 	flag.DurationVar(&o.groupTimeout, "group-timeout", 10*time.Minute, "Maximum time to wait for each group to update")
 	flag.DurationVar(&o.buildTimeout, "build-timeout", 3*time.Minute, "Maximum time to wait to read each build")
+	flag.StringVar(&o.metricsAddress, "metrics-address", "", "Serve prometheus metrics at host:port/metrics if set")
+	flag.Var(&o.report, "report", "Replace gs://path/to/report.json with the JSON report of each loop if set")
 	flag.Parse()
 	return o
 }
@@ -91,7 +128,7 @@ func main() {
 		logrus.Fatalf("Invalid flags: %v", err)
 	}
 	if !opt.confirm {
-		logrus.Warning("--confirm=false (DRY-RUN): will not write to gcs")
+		logrus.Warning("--confirm=false (DRY-RUN): will list what it would write to gcs")
 	}
 	if opt.debug {
 		logrus.SetLevel(logrus.DebugLevel)
@@ -100,15 +137,59 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	client, err := gcs.ClientWithCreds(ctx, opt.creds)
+	storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
 	if err != nil {
 		logrus.Fatalf("Failed to create storage client: %v", err)
 	}
-	defer client.Close()
+	defer storageClient.Close()
+	client := gcs.NewRetryClient(gcs.NewClient(storageClient), gcs.DefaultRetryOptions)
+	// Dry runs compute every grid and record what they would write.
+	writeClient := gcs.NewWriteClient(client, !opt.confirm)
+
+	var groupMetrics updater.Metrics
+	if opt.metricsAddress != "" {
+		reg := prometheus.NewRegistry()
+		m, err := metrics.NewUpdater(reg)
+		if err != nil {
+			logrus.Fatalf("Failed to create metrics: %v", err)
+		}
+		groupMetrics = m
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+		go func() {
+			logrus.WithError(http.ListenAndServe(opt.metricsAddress, mux)).Fatal("Metrics server exited")
+		}()
+	}
+
+	updateOpts := updater.Options{
+		GroupConcurrency: opt.groupConcurrency,
+		BuildConcurrency: opt.buildConcurrency,
+		GroupTimeout:     opt.groupTimeout,
+		BuildTimeout:     opt.buildTimeout,
+		Group:            opt.group,
+		Filter:           opt.filter,
+		MaxBytes:         opt.maxGridBytes,
+		Strict:           opt.strict,
+		CacheBytes:       opt.junitCacheBytes,
+		Compression:      opt.compression,
+		Metrics:          groupMetrics,
+	}
 
 	updateOnce := func() {
 		start := time.Now()
-		updater.Update(client, ctx, opt.config, opt.groupConcurrency, opt.buildConcurrency, opt.confirm, opt.groupTimeout, opt.buildTimeout, opt.group)
+		report, err := updater.Update(writeClient, ctx, opt.config, updateOpts)
+		if err != nil {
+			logrus.WithError(err).Error("Failed update")
+		}
+		if opt.report.String() != "" {
+			if err := updater.WriteReport(ctx, writeClient, opt.report, report); err != nil {
+				logrus.WithError(err).Error("Failed to write report")
+			}
+		}
+		writes := writeClient.Writes()
+		if !opt.confirm {
+			fmt.Print(gcs.FormatWrites(writes))
+		}
 		logrus.Infof("Update completed in %s", time.Since(start))
 	}
 
@@ -0,0 +1,112 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"cloud.google.com/go/storage"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// Cache holds the config in GCS, only downloading it again after the object generation changes.
+//
+// Cache is safe for concurrent use.
+type Cache struct {
+	client   gcs.Client
+	path     gcs.Path
+	validate bool
+
+	lock   sync.Mutex
+	cfg    *configpb.Configuration
+	meta   *storage.ObjectAttrs
+	hits   int
+	misses int
+}
+
+// NewCache returns a Cache of the config at path, which validates each download when validate is set.
+func NewCache(client gcs.Client, path gcs.Path, validate bool) *Cache {
+	return &Cache{
+		client:   client,
+		path:     path,
+		validate: validate,
+	}
+}
+
+// Get returns the config, downloading it if the object changed since the last Get.
+//
+// Each call checks the object metadata; the cached config is kept after an error.
+func (c *Cache) Get(ctx context.Context) (*configpb.Configuration, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.get(ctx)
+}
+
+func (c *Cache) get(ctx context.Context) (*configpb.Configuration, error) {
+	attrs, err := c.client.Attrs(ctx, c.path)
+	if err == storage.ErrObjectNotExist {
+		return nil, NotFoundError{c.path.String(), err}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat config %s: %v", c.path, err)
+	}
+	if c.cfg != nil && c.meta.Generation == attrs.Generation {
+		c.hits++
+		return c.cfg, nil
+	}
+	c.misses++
+	cfg, _, err := ReadGCS(ctx, c.client, c.path, c.validate)
+	if err != nil {
+		return nil, err
+	}
+	c.cfg = cfg
+	c.meta = attrs
+	return cfg, nil
+}
+
+// ForceRefresh downloads the config again, even if the generation is unchanged.
+//
+// The cached config is kept after an error, like Get.
+func (c *Cache) ForceRefresh(ctx context.Context) (*configpb.Configuration, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	cfg, meta := c.cfg, c.meta
+	c.cfg, c.meta = nil, nil
+	got, err := c.get(ctx)
+	if err != nil {
+		c.cfg, c.meta = cfg, meta
+	}
+	return got, err
+}
+
+// Attrs returns the metadata of the cached config, or nil before the first successful Get.
+func (c *Cache) Attrs() *storage.ObjectAttrs {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.meta
+}
+
+// Stats returns how many times Get used the cached config (hits) or downloaded it (misses).
+func (c *Cache) Stats() (hits, misses int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.hits, c.misses
+}
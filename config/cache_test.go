@@ -0,0 +1,134 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+// configPath returns the path of the cached config.
+func configPath(t *testing.T) gcs.Path {
+	t.Helper()
+	p, err := gcs.ParsePath("gs://bucket/config")
+	if err != nil {
+		t.Fatalf("Failed to parse path: %v", err)
+	}
+	return *p
+}
+
+// putConfig writes a config whose only test group keeps the days of results, which identify each write.
+func putConfig(t *testing.T, client *fake.Client, path gcs.Path, days int32) {
+	t.Helper()
+	buf, err := proto.Marshal(&configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{{Name: "test_group_1", DaysOfResults: days}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal config: %v", err)
+	}
+	client.Put(path, buf)
+}
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+	client := &fake.Client{}
+	path := configPath(t)
+	c := NewCache(client, path, false)
+
+	var notFound NotFoundError
+	if _, err := c.Get(ctx); !errors.As(err, &notFound) {
+		t.Fatalf("Expected NotFoundError, but got: %v", err)
+	}
+	if attrs := c.Attrs(); attrs != nil {
+		t.Errorf("Expected no attrs, but got: %v", attrs)
+	}
+
+	expectGet := func(days int32, hits, misses int) {
+		t.Helper()
+		cfg, err := c.Get(ctx)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got := cfg.TestGroups[0].DaysOfResults; got != days {
+			t.Errorf("Expected %d days of results, but got %d", days, got)
+		}
+		if h, m := c.Stats(); h != hits || m != misses {
+			t.Errorf("Expected %d hits and %d misses, but got %d and %d", hits, misses, h, m)
+		}
+	}
+
+	putConfig(t, client, path, 1)
+	expectGet(1, 0, 1)
+	expectGet(1, 1, 1)
+	generation := c.Attrs().Generation
+	putConfig(t, client, path, 2)
+	expectGet(2, 1, 2)
+	expectGet(2, 2, 2)
+	if got := c.Attrs().Generation; got <= generation {
+		t.Errorf("Expected attrs generation after %d, but got %d", generation, got)
+	}
+
+	if _, err := c.ForceRefresh(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, misses := c.Stats(); misses != 3 {
+		t.Errorf("Expected 3 downloads, but got %d", misses)
+	}
+	expectGet(2, 3, 3)
+
+	client.FailNext(path, errors.New("injected"))
+	if _, err := c.ForceRefresh(ctx); err == nil {
+		t.Fatal("Expected an error, but got none")
+	}
+	if c.Attrs() == nil {
+		t.Error("Expected the attrs of the cached config after a failed refresh, but got none")
+	}
+	expectGet(2, 4, 3)
+}
+
+func TestCache_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	client := &fake.Client{}
+	path := configPath(t)
+	putConfig(t, client, path, 1)
+	c := NewCache(client, path, false)
+
+	const n = 50
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			if _, err := c.Get(ctx); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if hits, misses := c.Stats(); hits != n-1 || misses != 1 {
+		t.Errorf("Expected %d hits and 1 miss, but got %d and %d", n-1, hits, misses)
+	}
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// CheckResult holds what CheckFiles found, for the caller to print.
+type CheckResult struct {
+	// Files lists each file read, sorted.
+	Files []string
+	// Config is the merged configuration.
+	Config *configpb.Configuration
+	// Errors lists the merge and validation errors, such as ConfigError values.
+	Errors []error
+	// Warnings lists the validation warnings, such as ConfigWarning values.
+	Warnings []error
+	// TestGroups counts the Test Groups of Config.
+	TestGroups int
+	// Dashboards counts the Dashboards of Config.
+	Dashboards int
+	// DashboardGroups counts the Dashboard Groups of Config.
+	DashboardGroups int
+}
+
+// OK returns true when the check found no errors.
+func (r CheckResult) OK() bool {
+	return len(r.Errors) == 0
+}
+
+// yamlExts lists the extensions of files parsed as YAML, rather than as a serialized Configuration proto.
+var yamlExts = map[string]bool{
+	".yaml": true,
+	".yml":  true,
+}
+
+// protoExt is the extension of serialized Configuration protos found in directories.
+const protoExt = ".pb"
+
+// CheckFiles reads, merges and validates the config files in paths, reporting the issues in the CheckResult.
+//
+// Each path is a file, a directory holding .yaml, .yml and .pb files at any depth, or a glob of files.
+// Files ending in .yaml or .yml are parsed as YAML, and other files as a serialized Configuration proto.
+// Files are merged in sorted order, so results are the same however the paths are listed.
+// A YAML file may set the default_test_group and default_dashboard_tab, which apply to every file before validating.
+// Returns an error when no file matches or a file cannot be read or parsed.
+func CheckFiles(ctx context.Context, paths []string, opts ValidateOptions) (CheckResult, error) {
+	var result CheckResult
+	files, err := findFiles(paths)
+	if err != nil {
+		return result, err
+	}
+	if len(files) == 0 {
+		return result, fmt.Errorf("no config files in %s", strings.Join(paths, ", "))
+	}
+	configs := make(map[string]*configpb.Configuration, len(files))
+	var defaults *configpb.DefaultConfiguration
+	var defaultsFile string
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		cfg, defs, err := readFile(file)
+		if err != nil {
+			return result, err
+		}
+		if defs != nil {
+			if defaults != nil {
+				return result, fmt.Errorf("defaults set in both %s and %s", defaultsFile, file)
+			}
+			defaults, defaultsFile = defs, file
+		}
+		configs[file] = cfg
+	}
+	result.Files = files
+
+	cfg, err := MergeFiles(configs)
+	result.Errors = append(result.Errors, errorsOf(err)...)
+	ApplyDefaults(cfg, defaults)
+	errs, warnings := ValidateWithOptions(*cfg, opts)
+	result.Errors = append(result.Errors, errorsOf(errs)...)
+	result.Warnings = errorsOf(warnings)
+
+	result.Config = cfg
+	result.TestGroups = len(cfg.TestGroups)
+	result.Dashboards = len(cfg.Dashboards)
+	result.DashboardGroups = len(cfg.DashboardGroups)
+	return result, nil
+}
+
+// findFiles returns the sorted, distinct files that paths name, match or contain.
+func findFiles(paths []string) ([]string, error) {
+	seen := map[string]bool{}
+	var files []string
+	add := func(file string) {
+		file = filepath.Clean(file)
+		if !seen[file] {
+			seen[file] = true
+			files = append(files, file)
+		}
+	}
+	for _, path := range paths {
+		if strings.ContainsAny(path, "*?[") {
+			matches, err := filepath.Glob(path)
+			if err != nil {
+				return nil, fmt.Errorf("bad glob %s: %v", path, err)
+			}
+			for _, match := range matches {
+				if info, err := os.Stat(match); err == nil && !info.IsDir() {
+					add(match)
+				}
+			}
+			continue
+		}
+		info, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			return nil, NotFoundError{path, err}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("stat %s: %v", path, err)
+		}
+		if !info.IsDir() {
+			add(path)
+			continue
+		}
+		err = filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if ext := filepath.Ext(file); !info.IsDir() && (yamlExts[ext] || ext == protoExt) {
+				add(file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("walk %s: %v", path, err)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// yamlFile is a YAML config file, which may also set the defaults of every Test Group and Dashboard Tab.
+type yamlFile struct {
+	*configpb.Configuration
+	*configpb.DefaultConfiguration
+}
+
+// readFile parses the YAML or serialized Configuration proto in the file, without validating it.
+//
+// Also returns the defaults the YAML sets, if any. YAML fields the config does not define are an error.
+func readFile(file string) (*configpb.Configuration, *configpb.DefaultConfiguration, error) {
+	if !yamlExts[filepath.Ext(file)] {
+		cfg, err := ReadPath(file, false)
+		return cfg, nil, err
+	}
+	buf, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read %s: %v", file, err)
+	}
+	var f yamlFile
+	if err := UnmarshalYAML(buf, &f); err != nil {
+		return nil, nil, CorruptError{file, err}
+	}
+	if f.Configuration == nil {
+		f.Configuration = &configpb.Configuration{}
+	}
+	return f.Configuration, f.DefaultConfiguration, nil
+}
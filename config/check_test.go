@@ -0,0 +1,266 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+func TestCheckFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	binary, err := proto.Marshal(&configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{{Name: "tg_c", Query: "bucket/c"}},
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name:         "dash_c",
+				DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "tg_c"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	files := map[string][]byte{
+		"configs/a.yaml": []byte(`
+test_groups:
+- name: tg_a
+  query: bucket/a
+dashboards:
+- name: dash_a
+  dashboard_tab:
+  - name: tab
+    test_group_name: tg_a
+`),
+		"configs/sub/b.yml": []byte(`
+test_groups:
+- name: tg_b
+  query: bucket/b
+dashboards:
+- name: dash_b
+  dashboard_tab:
+  - name: tab
+    test_group_name: tg_b
+`),
+		"configs/c.pb":      binary,
+		"configs/README.md": []byte("# Not a config"),
+		"broken/missing.yaml": []byte(`
+dashboards:
+- name: dash_missing
+  dashboard_tab:
+  - name: tab
+    test_group_name: tg_missing
+dashboard_groups:
+- name: dash
+`),
+		"broken/duplicate.yaml": []byte(`
+test_groups:
+- name: tg_a
+  query: bucket/other
+`),
+		"broken/corrupt.yaml": []byte("test_groups: [unterminated"),
+		"broken/unknown.yaml": []byte(`
+test_groups:
+- name: tg_unknown
+  gcs_prefix: bucket/unknown
+`),
+		"defaults/default.yaml": []byte(`
+default_test_group:
+  days_of_results: -1
+default_dashboard_tab:
+  num_columns_recent: 5
+`),
+		"broken/defaults.yaml": []byte(`
+default_test_group:
+  days_of_results: 7
+`),
+		"defaults/d.yaml": []byte(`
+test_groups:
+- name: tg_d
+  query: bucket/d
+dashboards:
+- name: dash_d
+  dashboard_tab:
+  - name: tab
+    test_group_name: tg_d
+`),
+	}
+	for name, b := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err := ioutil.WriteFile(path, b, 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	in := func(names ...string) []string {
+		var paths []string
+		for _, name := range names {
+			paths = append(paths, filepath.Join(dir, name))
+		}
+		return paths
+	}
+
+	tests := []struct {
+		name           string
+		paths          []string
+		expectedFiles  []string
+		expectedCounts [3]int
+		expectedErrs   []error
+		expectedWarns  []error
+		// expectedTabColumns is the num_columns_recent of the first Dashboard Tab, when set.
+		expectedTabColumns int32
+		errMatches         func(error) bool
+	}{
+		{
+			name:           "Reads each config in a directory",
+			paths:          in("configs"),
+			expectedFiles:  in("configs/a.yaml", "configs/c.pb", "configs/sub/b.yml"),
+			expectedCounts: [3]int{3, 3, 0},
+		},
+		{
+			name:           "Matches globs",
+			paths:          in("configs/*.yaml"),
+			expectedFiles:  in("configs/a.yaml"),
+			expectedCounts: [3]int{1, 1, 0},
+		},
+		{
+			name:           "Sorts files and skips repeats",
+			paths:          in("configs/sub/b.yml", "configs/a.yaml", "configs/sub/../a.yaml", "configs/*.yaml"),
+			expectedFiles:  in("configs/a.yaml", "configs/sub/b.yml"),
+			expectedCounts: [3]int{2, 2, 0},
+		},
+		{
+			name:           "Reports validation errors and warnings",
+			paths:          in("configs/a.yaml", "broken/missing.yaml"),
+			expectedFiles:  in("broken/missing.yaml", "configs/a.yaml"),
+			expectedCounts: [3]int{1, 2, 1},
+			expectedErrs: []error{
+				MissingEntityError{"tg_missing", "TestGroup", "", FieldPath{"dashboards", 0, "dashboard_tab", 0, "test_group_name"}},
+			},
+			expectedWarns: []error{
+				ConfigWarning{"dash", "DashboardGroup", "A Dashboard Group must contain at least one Dashboard.", FieldPath{"dashboard_groups", 0}},
+				ConfigWarning{"dash_missing", "Dashboard", "A Dashboard should be in a Dashboard Group when Dashboard Groups exist.", FieldPath{"dashboards", 0}},
+				ConfigWarning{"dash_a", "Dashboard", "A Dashboard should be in a Dashboard Group when Dashboard Groups exist.", FieldPath{"dashboards", 1}},
+			},
+		},
+		{
+			name:           "Reports names defined in several files",
+			paths:          in("configs/a.yaml", "broken/duplicate.yaml"),
+			expectedFiles:  in("broken/duplicate.yaml", "configs/a.yaml"),
+			expectedCounts: [3]int{2, 1, 0},
+			expectedErrs: []error{
+				DuplicateNameError{"tga", "TestGroup defined in " + filepath.Join(dir, "broken/duplicate.yaml") + " and " + filepath.Join(dir, "configs/a.yaml"), []string{"tg_a", "tg_a"}, nil},
+				DuplicateNameError{"tga", "TestGroup", []string{"tg_a", "tg_a"}, FieldPath{"test_groups", 1, "name"}},
+			},
+		},
+		{
+			name:       "Missing path",
+			paths:      in("configs/missing.yaml"),
+			errMatches: func(err error) bool { return errors.As(err, &NotFoundError{}) },
+		},
+		{
+			name:       "Corrupt file",
+			paths:      in("broken/corrupt.yaml"),
+			errMatches: func(err error) bool { return errors.As(err, &CorruptError{}) },
+		},
+		{
+			name:  "Unknown field",
+			paths: in("broken/unknown.yaml"),
+			errMatches: func(err error) bool {
+				var unknown UnknownFieldError
+				return errors.As(err, &CorruptError{}) && errors.As(err, &unknown) && reflect.DeepEqual(unknown.Path, FieldPath{"test_groups", 0, "gcs_prefix"})
+			},
+		},
+		{
+			name:           "Validates defaulted entities",
+			paths:          in("defaults"),
+			expectedFiles:  in("defaults/d.yaml", "defaults/default.yaml"),
+			expectedCounts: [3]int{1, 1, 0},
+			expectedErrs: []error{
+				ConfigError{"tg_d", "TestGroup", "days_of_results must be >= 0, got -1", FieldPath{"test_groups", 0, "days_of_results"}},
+			},
+			expectedTabColumns: 5,
+		},
+		{
+			name:       "Defaults set twice",
+			paths:      in("defaults", "broken/defaults.yaml"),
+			errMatches: func(err error) bool { return err != nil },
+		},
+		{
+			name:       "No matching files",
+			paths:      in("configs/*.json"),
+			errMatches: func(err error) bool { return err != nil },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := CheckFiles(context.Background(), test.paths, ValidateOptions{})
+			switch {
+			case test.errMatches != nil:
+				if !test.errMatches(err) {
+					t.Fatalf("CheckFiles() got unexpected error: %v", err)
+				}
+				return
+			case err != nil:
+				t.Fatalf("CheckFiles() got unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(result.Files, test.expectedFiles) {
+				t.Errorf("CheckFiles() got files %v, want %v", result.Files, test.expectedFiles)
+			}
+			if got := [3]int{result.TestGroups, result.Dashboards, result.DashboardGroups}; got != test.expectedCounts {
+				t.Errorf("CheckFiles() got counts %v, want %v", got, test.expectedCounts)
+			}
+			if !reflect.DeepEqual(result.Errors, test.expectedErrs) {
+				t.Errorf("CheckFiles() got errors %v, want %v", result.Errors, test.expectedErrs)
+			}
+			if !reflect.DeepEqual(result.Warnings, test.expectedWarns) {
+				t.Errorf("CheckFiles() got warnings %v, want %v", result.Warnings, test.expectedWarns)
+			}
+			if want := test.expectedTabColumns; want != 0 {
+				if got := result.Config.Dashboards[0].DashboardTab[0].NumColumnsRecent; got != want {
+					t.Errorf("CheckFiles() got num_columns_recent %d, want the default %d", got, want)
+				}
+			}
+			if ok := len(test.expectedErrs) == 0; result.OK() != ok {
+				t.Errorf("CheckFiles() got OK() %t, want %t", result.OK(), ok)
+			}
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := CheckFiles(ctx, in("configs"), ValidateOptions{}); err != context.Canceled {
+		t.Errorf("CheckFiles() with a canceled context got %v, want %v", err, context.Canceled)
+	}
+}
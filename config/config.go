@@ -18,17 +18,25 @@ package config
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/mail"
+	"net/url"
 	"os"
 	"regexp"
 	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
 
 	"cloud.google.com/go/storage"
 	"github.com/golang/protobuf/proto"
 
+	"github.com/GoogleCloudPlatform/testgrid/internal/filter"
 	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/pb/test_status"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
 	multierror "github.com/hashicorp/go-multierror"
 )
@@ -36,6 +44,8 @@ import (
 // MissingFieldError is an error that includes the missing field.
 type MissingFieldError struct {
 	Field string
+	// FieldPath locates the field, when known.
+	FieldPath FieldPath
 }
 
 func (e MissingFieldError) Error() string {
@@ -46,98 +56,192 @@ func (e MissingFieldError) Error() string {
 type DuplicateNameError struct {
 	Name   string
 	Entity string
+	// Originals lists the names, before normalizing, that collided.
+	Originals []string
+	// FieldPath locates the first name that collided with an earlier one, when known.
+	FieldPath FieldPath
 }
 
 func (e DuplicateNameError) Error() string {
-	return fmt.Sprintf("found duplicate name after normalizing: (%s) %s", e.Entity, e.Name)
+	return fmt.Sprintf("found duplicate name after normalizing: (%s) %s%s", e.Entity, e.Name, e.from())
 }
 
+// from lists the original names, if known.
+func (e DuplicateNameError) from() string {
+	if len(e.Originals) == 0 {
+		return ""
+	}
+	quoted := make([]string, len(e.Originals))
+	for i, o := range e.Originals {
+		quoted[i] = fmt.Sprintf("%q", o)
+	}
+	return fmt.Sprintf(" (from %s)", strings.Join(quoted, ", "))
+}
+
+// MissingEntityError is an error that includes the missing entity, and possibly the name it resembles.
 type MissingEntityError struct {
-	Name   string
-	Entity string
+	Name       string
+	Entity     string
+	Suggestion string
+	// FieldPath locates the reference, when known.
+	FieldPath FieldPath
 }
 
 func (e MissingEntityError) Error() string {
-	return fmt.Sprintf("could not find the referenced (%s) %s", e.Entity, e.Name)
+	return fmt.Sprintf("could not find the referenced (%s) %s%s", e.Entity, e.Name, e.didYouMean())
+}
+
+func (e MissingEntityError) didYouMean() string {
+	if e.Suggestion == "" {
+		return ""
+	}
+	return fmt.Sprintf("; did you mean %q?", e.Suggestion)
 }
 
 type ConfigError struct {
 	Name    string
 	Entity  string
 	Message string
+	// FieldPath locates the offending field or entity, when known.
+	FieldPath FieldPath
 }
 
 func (e ConfigError) Error() string {
 	return fmt.Sprintf("configuration error for (%s) %s: %s", e.Entity, e.Name, e.Message)
 }
 
-// normalize lowercases, and removes all non-alphanumeric characters from a string.
-func normalize(s string) string {
-	regex := regexp.MustCompile("[^a-zA-Z0-9]+")
-	s = regex.ReplaceAllString(s, "")
-	s = strings.ToLower(s)
-	return s
+// TooManyErrorsError follows the first MaxErrors errors when ValidateWithOptions stops early.
+type TooManyErrorsError struct {
+	MaxErrors int
+	// SkippedRules counts the checks not performed after reaching MaxErrors.
+	SkippedRules int
+}
+
+func (e TooManyErrorsError) Error() string {
+	return fmt.Sprintf("stopped after %d errors; skipped %d remaining rule checks", e.MaxErrors, e.SkippedRules)
+}
+
+// ConfigWarning is a ConfigError reported with SeverityWarning.
+type ConfigWarning struct {
+	Name    string
+	Entity  string
+	Message string
+	// FieldPath locates the offending field or entity, when known.
+	FieldPath FieldPath
+}
+
+func (e ConfigWarning) Error() string {
+	return fmt.Sprintf("configuration warning for (%s) %s: %s", e.Entity, e.Name, e.Message)
+}
+
+// NormalizeOptions selects which characters besides ASCII letters and digits survive normalizing.
+type NormalizeOptions struct {
+	// KeepDashes keeps '-' characters.
+	KeepDashes bool
+	// KeepUnicode keeps non-ASCII letters and digits.
+	KeepUnicode bool
+}
+
+// Normalize lowercases, and removes all non-alphanumeric characters from a string.
+//
+// Names which Normalize to the same string are duplicates.
+func Normalize(s string) string {
+	return NormalizeWith(NormalizeOptions{})(s)
+}
+
+// NormalizeWith returns a function which normalizes strings like Normalize, keeping the additional characters selected by opts.
+func NormalizeWith(opts NormalizeOptions) func(string) string {
+	drop := func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9':
+			return r
+		case opts.KeepDashes && r == '-':
+			return r
+		case opts.KeepUnicode && r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			return r
+		}
+		return -1
+	}
+	return func(s string) string {
+		s = strings.Map(drop, s)
+		s = strings.ToLower(s)
+		// Lowercasing some unicode letters produces combining marks.
+		return strings.Map(drop, s)
+	}
 }
 
 // validateUnique checks that a list has no duplicate normalized entries.
-func validateUnique(items []string, entity string) error {
+//
+// Returns one DuplicateNameError for each normalized name, listing every original that collided.
+// The error is located at path(i) of the first item to collide, unless path is nil.
+func validateUnique(items []string, entity string, path func(i int) FieldPath) error {
 	mErr := &multierror.Error{}
-	set := map[string]bool{}
-	for _, item := range items {
-		s := normalize(item)
-		_, ok := set[s]
-		if ok {
-			mErr = multierror.Append(mErr, DuplicateNameError{s, entity})
-		} else {
-			set[s] = true
+	originals := map[string][]string{}
+	collided := map[string]int{}
+	var order []string
+	for i, item := range items {
+		s := Normalize(item)
+		if _, ok := originals[s]; !ok {
+			order = append(order, s)
+		}
+		originals[s] = append(originals[s], item)
+		if len(originals[s]) == 2 {
+			collided[s] = i
+		}
+	}
+	for _, s := range order {
+		if len(originals[s]) > 1 {
+			var at FieldPath
+			if path != nil {
+				at = path(collided[s])
+			}
+			mErr = multierror.Append(mErr, DuplicateNameError{s, entity, originals[s], at})
 		}
 	}
 	return mErr.ErrorOrNil()
 }
 
+// namePath returns the path to the name of each entity in a repeated field.
+func namePath(field string) func(i int) FieldPath {
+	return func(i int) FieldPath { return FieldPath{field, i, "name"} }
+}
+
 func validateAllUnique(c configpb.Configuration) error {
 	mErr := &multierror.Error{}
-	tgNames := []string{}
-	for _, tg := range c.TestGroups {
-		tgNames = append(tgNames, tg.GetName())
-	}
+	names := map[string][]string{}
+	Walk(&c, func(kind string, _ []string, name string, _ proto.Message) error {
+		names[kind] = append(names[kind], name)
+		return nil
+	})
+	tgNames := names["TestGroup"]
+	dashNames := names["Dashboard"]
+	dgNames := names["DashboardGroup"]
+
 	// Test Group names must be unique.
-	err := validateUnique(tgNames, "TestGroup")
+	err := validateUnique(tgNames, "TestGroup", namePath("test_groups"))
 	if err != nil {
 		mErr = multierror.Append(mErr, err)
 	}
 
-	dashNames := []string{}
-	for _, dash := range c.Dashboards {
-		dashNames = append(dashNames, dash.GetName())
-		tabNames := []string{}
-		for _, tab := range dash.GetDashboardTab() {
-			tabNames = append(tabNames, tab.GetName())
-		}
-		// Dashboard Tab names must be unique within a Dashboard.
-		err = validateUnique(tabNames, "DashboardTab")
-		if err != nil {
-			mErr = multierror.Append(mErr, err)
-		}
-	}
 	// Dashboard names must be unique within Dashboards.
-	err = validateUnique(dashNames, "Dashboard")
+	err = validateUnique(dashNames, "Dashboard", namePath("dashboards"))
 	if err != nil {
 		mErr = multierror.Append(mErr, err)
 	}
 
-	dgNames := []string{}
-	for _, dg := range c.DashboardGroups {
-		dgNames = append(dgNames, dg.GetName())
-	}
 	// Dashboard Group names must be unique within Dashboard Groups.
-	err = validateUnique(dgNames, "DashboardGroup")
+	err = validateUnique(dgNames, "DashboardGroup", namePath("dashboard_groups"))
 	if err != nil {
 		mErr = multierror.Append(mErr, err)
 	}
 
 	// Names must also be unique within DashboardGroups AND Dashbaords.
-	err = validateUnique(append(dashNames, dgNames...), "Dashboard/DashboardGroup")
+	err = validateUnique(append(dashNames, dgNames...), "Dashboard/DashboardGroup", func(i int) FieldPath {
+		if i < len(dashNames) {
+			return namePath("dashboards")(i)
+		}
+		return namePath("dashboard_groups")(i - len(dashNames))
+	})
 	if err != nil {
 		mErr = multierror.Append(mErr, err)
 	}
@@ -145,91 +249,1093 @@ func validateAllUnique(c configpb.Configuration) error {
 	return mErr.ErrorOrNil()
 }
 
+// validateTabNamesUnique checks that Dashboard Tab names are unique within a Dashboard.
+func validateTabNamesUnique(dash *configpb.Dashboard) error {
+	tabNames := []string{}
+	for _, tab := range dash.GetDashboardTab() {
+		tabNames = append(tabNames, tab.GetName())
+	}
+	return validateUnique(tabNames, fmt.Sprintf("DashboardTab in %s", dash.GetName()), namePath("dashboard_tab"))
+}
+
+// validateTestGroupNamespace checks that no Test Group shares a normalized name with a Dashboard Group.
+func validateTestGroupNamespace(c configpb.Configuration) error {
+	var names []string
+	for _, tg := range c.TestGroups {
+		names = append(names, tg.GetName())
+	}
+	for _, dg := range c.DashboardGroups {
+		names = append(names, dg.GetName())
+	}
+	return validateUnique(names, "TestGroup/DashboardGroup", func(i int) FieldPath {
+		if i < len(c.TestGroups) {
+			return namePath("test_groups")(i)
+		}
+		return namePath("dashboard_groups")(i - len(c.TestGroups))
+	})
+}
+
+// validateDashboardGroupsNotEmpty checks that every Dashboard Group contains a Dashboard.
+func validateDashboardGroupsNotEmpty(c configpb.Configuration) error {
+	mErr := &multierror.Error{}
+	for i, dg := range c.DashboardGroups {
+		if len(dg.DashboardNames) == 0 {
+			mErr = multierror.Append(mErr, ConfigError{dg.Name, "DashboardGroup", "A Dashboard Group must contain at least one Dashboard.", FieldPath{"dashboard_groups", i}})
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// validateDashboardsGrouped checks that every Dashboard is in a Dashboard Group, if any Dashboard Group exists.
+func validateDashboardsGrouped(c configpb.Configuration) error {
+	if len(c.DashboardGroups) == 0 {
+		return nil
+	}
+	grouped := map[string]bool{}
+	for _, dg := range c.DashboardGroups {
+		for _, name := range dg.DashboardNames {
+			grouped[name] = true
+		}
+	}
+	mErr := &multierror.Error{}
+	for i, dash := range c.Dashboards {
+		if !grouped[dash.Name] {
+			mErr = multierror.Append(mErr, ConfigError{dash.Name, "Dashboard", "A Dashboard should be in a Dashboard Group when Dashboard Groups exist.", FieldPath{"dashboards", i}})
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// validateDashboardGroupPrefixes checks that each Dashboard starts with the name of its Dashboard Group (after normalizing).
+//
+// When several group names prefix a Dashboard, it belongs in the group with the longest name.
+// Groups in PrefixExemptGroups are not checked.
+func validateDashboardGroupPrefixes(c configpb.Configuration, opts ValidateOptions) error {
+	skip := map[string]bool{}
+	for _, name := range opts.PrefixExemptGroups {
+		skip[Normalize(name)] = true
+	}
+	// longest returns the group with the longest normalized name that prefixes the normalized dash.
+	longest := func(dash string) *configpb.DashboardGroup {
+		var best *configpb.DashboardGroup
+		var bestLen int
+		for _, dg := range c.DashboardGroups {
+			prefix := Normalize(dg.Name)
+			if strings.HasPrefix(dash, prefix) && len(prefix) > bestLen {
+				best = dg
+				bestLen = len(prefix)
+			}
+		}
+		return best
+	}
+	mErr := &multierror.Error{}
+	for i, dg := range c.DashboardGroups {
+		prefix := Normalize(dg.Name)
+		if skip[prefix] {
+			continue
+		}
+		for j, dash := range dg.DashboardNames {
+			norm := Normalize(dash)
+			path := FieldPath{"dashboard_groups", i, "dashboard_names", j}
+			if !strings.HasPrefix(norm, prefix) {
+				mErr = multierror.Append(mErr, ConfigError{dg.Name, "DashboardGroup", fmt.Sprintf("Dashboard %s must start with the Dashboard Group name", dash), path})
+				continue
+			}
+			// A Dashboard in both groups is reported by validateDashboardsInOneGroup instead.
+			if best := longest(norm); best != dg && !containsString(best.DashboardNames, dash) {
+				mErr = multierror.Append(mErr, ConfigError{dg.Name, "DashboardGroup", fmt.Sprintf("Dashboard %s belongs in Dashboard Group %s, the longest matching prefix", dash, best.Name), path})
+			}
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// validateLengths checks that names and descriptions are no longer than the bytes ValidateOptions allows.
+func validateLengths(c configpb.Configuration, opts ValidateOptions) error {
+	mErr := &multierror.Error{}
+	check := func(name, entity, field, value string, max int, path FieldPath) {
+		if len(value) > max {
+			mErr = multierror.Append(mErr, ConfigError{name, entity, fmt.Sprintf("%s is %d bytes, more than %d", field, len(value), max), path})
+		}
+	}
+	maxName, maxDesc := opts.maxNameBytes(), opts.maxDescriptionBytes()
+	for i, tg := range c.TestGroups {
+		check(tg.Name, "TestGroup", "name", tg.Name, maxName, FieldPath{"test_groups", i, "name"})
+	}
+	for i, dash := range c.Dashboards {
+		check(dash.Name, "Dashboard", "name", dash.Name, maxName, FieldPath{"dashboards", i, "name"})
+		for j, tab := range dash.DashboardTab {
+			name := dash.Name + "/" + tab.Name
+			check(name, "DashboardTab", "name", tab.Name, maxName, FieldPath{"dashboards", i, "dashboard_tab", j, "name"})
+			check(name, "DashboardTab", "description", tab.Description, maxDesc, FieldPath{"dashboards", i, "dashboard_tab", j, "description"})
+		}
+	}
+	for i, dg := range c.DashboardGroups {
+		check(dg.Name, "DashboardGroup", "name", dg.Name, maxName, FieldPath{"dashboard_groups", i, "name"})
+	}
+	return mErr.ErrorOrNil()
+}
+
+// validateNamesLinkable checks that names do not normalize to nothing, which no url can reach.
+func validateNamesLinkable(c configpb.Configuration) error {
+	mErr := &multierror.Error{}
+	check := func(name, entity, value string, path FieldPath) {
+		if value != "" && Normalize(value) == "" {
+			mErr = multierror.Append(mErr, ConfigError{name, entity, fmt.Sprintf("name %q must contain a letter or digit", value), path})
+		}
+	}
+	for i, tg := range c.TestGroups {
+		check(tg.Name, "TestGroup", tg.Name, FieldPath{"test_groups", i, "name"})
+	}
+	for i, dash := range c.Dashboards {
+		check(dash.Name, "Dashboard", dash.Name, FieldPath{"dashboards", i, "name"})
+		for j, tab := range dash.DashboardTab {
+			check(dash.Name+"/"+tab.Name, "DashboardTab", tab.Name, FieldPath{"dashboards", i, "dashboard_tab", j, "name"})
+		}
+	}
+	for i, dg := range c.DashboardGroups {
+		check(dg.Name, "DashboardGroup", dg.Name, FieldPath{"dashboard_groups", i, "name"})
+	}
+	return mErr.ErrorOrNil()
+}
+
+// levenshtein returns the minimum number of single-character edits to change a into b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+func minInt(first int, rest ...int) int {
+	m := first
+	for _, v := range rest {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+// suggest returns the candidate closest to name after normalizing, or "" if none is close.
+//
+// A candidate is close when at most a quarter of the normalized name (and at least 1 character) differs.
+// Ties go to the alphabetically first candidate.
+func suggest(name string, candidates []string) string {
+	norm := Normalize(name)
+	threshold := len(norm) / 4
+	if threshold < 1 {
+		threshold = 1
+	}
+	var best string
+	bestDist := threshold + 1
+	for _, c := range candidates {
+		if c == "" {
+			continue
+		}
+		d := levenshtein(norm, Normalize(c))
+		if d < bestDist || d == bestDist && c < best {
+			best = c
+			bestDist = d
+		}
+	}
+	return best
+}
+
+// eachTestGroup returns the errors from validating each Test Group, located within test_groups.
+func eachTestGroup(c configpb.Configuration, validate func(*configpb.TestGroup) error) error {
+	mErr := &multierror.Error{}
+	for i, tg := range c.TestGroups {
+		if err := validate(tg); err != nil {
+			mErr = multierror.Append(mErr, atPath(err, "test_groups", i))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// eachDashboard returns the errors from validating each Dashboard, located within dashboards.
+func eachDashboard(c configpb.Configuration, validate func(*configpb.Dashboard) error) error {
+	mErr := &multierror.Error{}
+	for i, dash := range c.Dashboards {
+		if err := validate(dash); err != nil {
+			mErr = multierror.Append(mErr, atPath(err, "dashboards", i))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
 func validateReferencesExist(c configpb.Configuration) error {
 	mErr := &multierror.Error{}
+	for _, validate := range []func(configpb.Configuration) error{
+		func(c configpb.Configuration) error { return eachDashboard(c, validateTabTestGroupNames) },
+		validateTestGroupsExist,
+		validateTestGroupsReferenced,
+		validateDashboardsExist,
+		validateDashboardsInOneGroup,
+	} {
+		if err := validate(c); err != nil {
+			mErr = multierror.Append(mErr, err)
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// validateTabTestGroupNames checks that each Dashboard Tab names the Test Group it displays.
+func validateTabTestGroupNames(dash *configpb.Dashboard) error {
+	mErr := &multierror.Error{}
+	for i, tab := range dash.GetDashboardTab() {
+		if strings.TrimSpace(tab.TestGroupName) == "" {
+			mErr = multierror.Append(mErr, MissingFieldError{fmt.Sprintf("%s/%s: TestGroupName", dash.GetName(), tab.Name), FieldPath{"dashboard_tab", i, "test_group_name"}})
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// validateTestGroupsExist checks that each Dashboard Tab references an existing Test Group.
+func validateTestGroupsExist(c configpb.Configuration) error {
+	mErr := &multierror.Error{}
 
 	tgNames := map[string]bool{}
+	var candidates []string
 	for _, tg := range c.TestGroups {
 		tgNames[tg.Name] = true
+		candidates = append(candidates, tg.Name)
 	}
-	tgInTabs := map[string]bool{}
-	for _, dash := range c.Dashboards {
-		for _, tab := range dash.DashboardTab {
+	for i, dash := range c.Dashboards {
+		for j, tab := range dash.DashboardTab {
 			tabTg := tab.TestGroupName
-			tgInTabs[tabTg] = true
+			if strings.TrimSpace(tabTg) == "" {
+				continue // Reported by validateTabTestGroupNames
+			}
 			// Verify that each Test Group referenced by a Dashboard Tab exists.
 			if _, ok := tgNames[tabTg]; !ok {
-				mErr = multierror.Append(mErr, MissingEntityError{tabTg, "TestGroup"})
+				mErr = multierror.Append(mErr, MissingEntityError{tabTg, "TestGroup", suggest(tabTg, candidates), FieldPath{"dashboards", i, "dashboard_tab", j, "test_group_name"}})
 			}
 		}
 	}
-	// Likewise, each Test Group must be referenced by a Dashboard Tab, so each Test Group gets displayed.
-	for tgName := range tgNames {
-		if _, ok := tgInTabs[tgName]; !ok {
-			mErr = multierror.Append(mErr, ConfigError{tgName, "TestGroup", "Each Test Group must be referenced by at least 1 Dashboard Tab."})
+	return mErr.ErrorOrNil()
+}
+
+// validateTestGroupsReferenced checks that each Test Group is referenced by a Dashboard Tab, so each Test Group gets displayed.
+func validateTestGroupsReferenced(c configpb.Configuration) error {
+	mErr := &multierror.Error{}
+
+	tgInTabs := map[string]bool{}
+	for _, dash := range c.Dashboards {
+		for _, tab := range dash.DashboardTab {
+			if strings.TrimSpace(tab.TestGroupName) != "" {
+				tgInTabs[tab.TestGroupName] = true
+			}
+		}
+	}
+	reported := map[string]bool{}
+	for i, tg := range c.TestGroups {
+		tgName := tg.Name
+		if _, ok := tgInTabs[tgName]; !ok && !reported[tgName] {
+			reported[tgName] = true
+			mErr = multierror.Append(mErr, ConfigError{tgName, "TestGroup", "Each Test Group must be referenced by at least 1 Dashboard Tab.", FieldPath{"test_groups", i}})
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// validateDashboardsExist checks that the Dashboards each Dashboard Group references exist.
+func validateDashboardsExist(c configpb.Configuration) error {
+	mErr := &multierror.Error{}
+
+	dashNames := map[string]bool{}
+	var candidates []string
+	for _, dash := range c.Dashboards {
+		dashNames[dash.Name] = true
+		candidates = append(candidates, dash.Name)
+	}
+	for i, dg := range c.DashboardGroups {
+		for j, dgDash := range dg.DashboardNames {
+			if _, ok := dashNames[dgDash]; !ok {
+				mErr = multierror.Append(mErr, MissingEntityError{dgDash, "Dashboard", suggest(dgDash, candidates), FieldPath{"dashboard_groups", i, "dashboard_names", j}})
+			}
 		}
 	}
+	return mErr.ErrorOrNil()
+}
+
+// validateDashboardsInOneGroup checks that each Dashboard belongs to at most 1 Dashboard Group.
+func validateDashboardsInOneGroup(c configpb.Configuration) error {
+	mErr := &multierror.Error{}
 
 	dashNames := map[string]bool{}
 	for _, dash := range c.Dashboards {
 		dashNames[dash.Name] = true
 	}
 	dashToDg := map[string]bool{}
-	for _, dg := range c.DashboardGroups {
-		for _, name := range dg.DashboardNames {
-			dgDash := name
+	for i, dg := range c.DashboardGroups {
+		for j, dgDash := range dg.DashboardNames {
 			if _, ok := dashNames[dgDash]; !ok {
-				// The Dashboards each Dashboard Group references must exist.
-				mErr = multierror.Append(mErr, MissingEntityError{dgDash, "Dashboard"})
-			} else if _, ok = dashToDg[dgDash]; ok {
-				mErr = multierror.Append(mErr, ConfigError{dgDash, "Dashboard", "A Dashboard cannot be in more than 1 Dashboard Group."})
-			} else {
-				dashToDg[dgDash] = true
+				continue // Reported by validateDashboardsExist
 			}
+			if _, ok := dashToDg[dgDash]; ok {
+				mErr = multierror.Append(mErr, ConfigError{dgDash, "Dashboard", "A Dashboard cannot be in more than 1 Dashboard Group.", FieldPath{"dashboard_groups", i, "dashboard_names", j}})
+				continue
+			}
+			dashToDg[dgDash] = true
 		}
 	}
 	return mErr.ErrorOrNil()
 }
 
-// Validate checks that a configuration is well-formed.
-func Validate(c configpb.Configuration) error {
+// gcsPrefixPath parses the gcs_prefix (aka query) of a Test Group, returning the normalized bucket/path.
+func gcsPrefixPath(prefix string) (string, error) {
+	if strings.HasPrefix(prefix, "gs://") {
+		return "", errors.New("must not start with gs://")
+	}
+	var path gcs.Path
+	if err := path.Set("gs://" + prefix); err != nil {
+		return "", fmt.Errorf("is invalid: %v", err)
+	}
+	if path.Bucket() == "" {
+		return "", errors.New("is missing a bucket")
+	}
+	obj := strings.Trim(path.Object(), "/")
+	if obj == "" {
+		return "", errors.New("is missing a path")
+	}
+	return path.Bucket() + "/" + obj, nil
+}
+
+// filePrefixPath parses a file:// gcs_prefix (aka query) of a Test Group, returning the normalized file:///path.
+func filePrefixPath(prefix string) (string, error) {
+	path, err := gcs.ParsePath(prefix)
+	if err != nil {
+		return "", fmt.Errorf("is invalid: %v", err)
+	}
+	obj := strings.Trim(path.Object(), "/")
+	if obj == "" {
+		return "", errors.New("is missing a path")
+	}
+	return "file:///" + obj, nil
+}
+
+// validateGcsPrefix checks that the gcs_prefix (aka query) of each Test Group is a bucket/path.
+func validateGcsPrefix(c configpb.Configuration) error {
+	return eachTestGroup(c, validateTestGroupGcsPrefix)
+}
+
+// validateTestGroupGcsPrefix checks that the gcs_prefix (aka query) of a Test Group, if set, is a bucket/path.
+func validateTestGroupGcsPrefix(tg *configpb.TestGroup) error {
+	return validateTestGroupPrefix(tg, false)
+}
+
+// validateTestGroupLocalPrefix checks that the gcs_prefix (aka query) of a Test Group, if set, is a bucket/path or a file:// url.
+func validateTestGroupLocalPrefix(tg *configpb.TestGroup) error {
+	return validateTestGroupPrefix(tg, true)
+}
+
+// validateTestGroupPrefix checks the gcs_prefix and additional_gcs_prefixes of a Test Group, and that none of them contains another.
+func validateTestGroupPrefix(tg *configpb.TestGroup, allowFiles bool) error {
 	mErr := &multierror.Error{}
+	var prefixes, norms []string
+	add := func(prefix string, path FieldPath) {
+		norm, err := normalizePrefix(prefix, allowFiles)
+		if err != nil {
+			mErr = multierror.Append(mErr, ConfigError{tg.GetName(), "TestGroup", fmt.Sprintf("gcs_prefix %q %v", prefix, err), path})
+			return
+		}
+		for i, other := range norms {
+			if norm == other || strings.HasPrefix(norm, other+"/") || strings.HasPrefix(other, norm+"/") {
+				mErr = multierror.Append(mErr, ConfigError{tg.GetName(), "TestGroup", fmt.Sprintf("gcs_prefix %q overlaps %q", prefix, prefixes[i]), path})
+				return
+			}
+		}
+		prefixes = append(prefixes, prefix)
+		norms = append(norms, norm)
+	}
+	if prefix := tg.GetQuery(); prefix != "" {
+		add(prefix, FieldPath{"query"})
+	} else if len(tg.GetAdditionalGcsPrefixes()) > 0 {
+		mErr = multierror.Append(mErr, ConfigError{tg.GetName(), "TestGroup", "additional_gcs_prefixes require a gcs_prefix", FieldPath{"additional_gcs_prefixes"}})
+	}
+	for i, prefix := range tg.GetAdditionalGcsPrefixes() {
+		add(prefix, FieldPath{"additional_gcs_prefixes", i})
+	}
+	return mErr.ErrorOrNil()
+}
 
-	// TestGrid requires at least 1 TestGroup and 1 Dashboard in order to do anything.
-	if len(c.TestGroups) == 0 {
-		return multierror.Append(mErr, MissingFieldError{"TestGroups"})
+// normalizePrefix returns the normalized bucket/path or file:///path of a gcs_prefix, which is only a file:// url when allowFiles.
+func normalizePrefix(prefix string, allowFiles bool) (string, error) {
+	switch {
+	case !strings.HasPrefix(prefix, "file://"):
+		return gcsPrefixPath(prefix)
+	case allowFiles:
+		return filePrefixPath(prefix)
 	}
-	if len(c.Dashboards) == 0 {
-		return multierror.Append(mErr, MissingFieldError{"Dashboards"})
+	return "", errors.New("must not be a local file:// url")
+}
+
+// maxNumColumnsRecent is the largest num_columns_recent allowed without turning off the LargeNumColumnsRecent rule.
+const maxNumColumnsRecent = 10000
+
+// validateTestGroupRetention checks that the retention fields of a Test Group are not negative.
+//
+// Zero means the updater uses its default.
+func validateTestGroupRetention(tg *configpb.TestGroup) error {
+	mErr := &multierror.Error{}
+	if days := tg.GetDaysOfResults(); days < 0 {
+		mErr = multierror.Append(mErr, ConfigError{tg.GetName(), "TestGroup", fmt.Sprintf("days_of_results must be >= 0, got %d", days), FieldPath{"days_of_results"}})
+	}
+	if cols := tg.GetNumColumnsRecent(); cols < 0 {
+		mErr = multierror.Append(mErr, ConfigError{tg.GetName(), "TestGroup", fmt.Sprintf("num_columns_recent must be > 0 when set, got %d", cols), FieldPath{"num_columns_recent"}})
+	}
+	return mErr.ErrorOrNil()
+}
+
+// validateTestGroupNumColumnsRecent checks that a Test Group does not consider an excessive number of recent columns.
+func validateTestGroupNumColumnsRecent(tg *configpb.TestGroup) error {
+	if cols := tg.GetNumColumnsRecent(); cols > maxNumColumnsRecent {
+		return ConfigError{tg.GetName(), "TestGroup", fmt.Sprintf("num_columns_recent must be <= %d, got %d", maxNumColumnsRecent, cols), FieldPath{"num_columns_recent"}}
+	}
+	return nil
+}
+
+// maxColumnHeaders is the most column_header entries a Test Group may configure.
+const maxColumnHeaders = 10
+
+// columnHeaderSource returns the selector field and value of a ColumnHeader, or "" if it selects nothing.
+func columnHeaderSource(hdr *configpb.TestGroup_ColumnHeader) (string, string) {
+	switch src := hdr.GetColumnHeaderSource().(type) {
+	case *configpb.TestGroup_ColumnHeader_Label:
+		return "label", src.Label
+	case *configpb.TestGroup_ColumnHeader_Property:
+		return "property", src.Property
+	case *configpb.TestGroup_ColumnHeader_ConfigurationValue:
+		return "configuration_value", src.ConfigurationValue
+	}
+	return "", ""
+}
+
+// validateTestGroupColumnHeaders checks that each column_header of a Test Group selects a single non-empty value, once.
+//
+// The oneof in the proto already prevents setting more than one selector; an entry must still set one of them.
+func validateTestGroupColumnHeaders(tg *configpb.TestGroup) error {
+	mErr := &multierror.Error{}
+	headers := tg.GetColumnHeader()
+	if len(headers) > maxColumnHeaders {
+		mErr = multierror.Append(mErr, ConfigError{tg.GetName(), "TestGroup", fmt.Sprintf("column_header must have at most %d entries, got %d", maxColumnHeaders, len(headers)), FieldPath{"column_header"}})
+	}
+	seen := map[string]int{}
+	for i, hdr := range headers {
+		at := FieldPath{"column_header", i}
+		field, value := columnHeaderSource(hdr)
+		if field == "" {
+			mErr = multierror.Append(mErr, ConfigError{tg.GetName(), "TestGroup", fmt.Sprintf("column_header[%d] must set one of label, property or configuration_value", i), at})
+			continue
+		}
+		at = append(at, field)
+		if value == "" {
+			mErr = multierror.Append(mErr, ConfigError{tg.GetName(), "TestGroup", fmt.Sprintf("column_header[%d].%s must not be empty", i, field), at})
+			continue
+		}
+		key := field + "=" + Normalize(value)
+		if first, ok := seen[key]; ok {
+			mErr = multierror.Append(mErr, ConfigError{tg.GetName(), "TestGroup", fmt.Sprintf("column_header[%d].%s %q duplicates column_header[%d]", i, field, value, first), at})
+			continue
+		}
+		seen[key] = i
+	}
+	return mErr.ErrorOrNil()
+}
+
+// rowStatuses are the statuses a grid row can hold, which the junit results of a Test Group may map to.
+var rowStatuses = map[test_status.TestStatus]bool{
+	test_status.TestStatus_PASS:             true,
+	test_status.TestStatus_PASS_WITH_ERRORS: true,
+	test_status.TestStatus_PASS_WITH_SKIPS:  true,
+	test_status.TestStatus_FAIL:             true,
+	test_status.TestStatus_FLAKY:            true,
+	test_status.TestStatus_TOOL_FAIL:        true,
+}
+
+// validateTestGroupStatusMapping checks that the status_mapping of a Test Group maps each category to a row status, or leaves it unset.
+func validateTestGroupStatusMapping(tg *configpb.TestGroup) error {
+	m := tg.GetStatusMapping()
+	if m == nil {
+		return nil
 	}
+	mErr := &multierror.Error{}
+	for _, category := range []struct {
+		field  string
+		status test_status.TestStatus
+	}{
+		{"failure", m.GetFailure()},
+		{"error", m.GetError()},
+		{"skipped", m.GetSkipped()},
+	} {
+		if category.status == test_status.TestStatus_NO_RESULT || rowStatuses[category.status] {
+			continue
+		}
+		path := FieldPath{"status_mapping", category.field}
+		mErr = multierror.Append(mErr, ConfigError{tg.GetName(), "TestGroup", fmt.Sprintf("status_mapping.%s cannot be %s, which grid rows do not support", category.field, category.status), path})
+	}
+	return mErr.ErrorOrNil()
+}
+
+// countNameFormatVerbs returns the number of %s conversions in a test_name_config name_format.
+//
+// %% is a literal percent; any other verb is an error.
+func countNameFormatVerbs(format string) (int, error) {
+	var n int
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		i++
+		if i == len(format) {
+			return 0, errors.New("ends with an incomplete verb %")
+		}
+		switch format[i] {
+		case '%':
+		case 's':
+			n++
+		default:
+			return 0, fmt.Errorf("has unsupported verb %%%c at position %d, only %%s and %%%% are allowed", format[i], i-1)
+		}
+	}
+	return n, nil
+}
 
-	// Names have to be unique (after normalizing) within types of entities, to prevent storing
-	// duplicate state on updates and confusion between similar names.
-	err := validateAllUnique(c)
+// validateTestGroupNameFormat checks that the test_name_config name_format of a Test Group has a %s for each name element.
+func validateTestGroupNameFormat(tg *configpb.TestGroup) error {
+	cfg := tg.GetTestNameConfig()
+	if cfg == nil {
+		return nil
+	}
+	at := FieldPath{"test_name_config", "name_format"}
+	verbs, err := countNameFormatVerbs(cfg.NameFormat)
 	if err != nil {
-		mErr = multierror.Append(mErr, err)
+		return ConfigError{tg.GetName(), "TestGroup", fmt.Sprintf("test_name_config.name_format %q %v", cfg.NameFormat, err), at}
+	}
+	if elems := len(cfg.NameElements); verbs != elems {
+		return ConfigError{tg.GetName(), "TestGroup", fmt.Sprintf("test_name_config.name_format %q has %d %%s verbs, but there are %d name_elements", cfg.NameFormat, verbs, elems), at}
+	}
+	return nil
+}
+
+// validateUniqueGcsPrefix checks that no two Test Groups read from the same gcs_prefix.
+func validateUniqueGcsPrefix(c configpb.Configuration) error {
+	mErr := &multierror.Error{}
+	prefixes := map[string]string{}
+	for i, tg := range c.TestGroups {
+		prefix := tg.GetQuery()
+		norm, err := normalizePrefix(prefix, true)
+		if err != nil {
+			continue // Reported by validateGcsPrefix
+		}
+		if other, ok := prefixes[norm]; ok {
+			mErr = multierror.Append(mErr, ConfigError{tg.Name, "TestGroup", fmt.Sprintf("gcs_prefix %q is also used by %s", prefix, other), FieldPath{"test_groups", i, "query"}})
+			continue
+		}
+		prefixes[norm] = tg.Name
+	}
+	return mErr.ErrorOrNil()
+}
+
+// validateDashboardsHaveTabs checks that each Dashboard displays at least one Dashboard Tab.
+func validateDashboardsHaveTabs(c configpb.Configuration) error {
+	return eachDashboard(c, validateDashboardHasTabs)
+}
+
+// validateDashboardHasTabs checks that a Dashboard displays at least one Dashboard Tab.
+func validateDashboardHasTabs(dash *configpb.Dashboard) error {
+	if len(dash.GetDashboardTab()) == 0 {
+		return ConfigError{dash.GetName(), "Dashboard", "A Dashboard must have at least one Dashboard Tab.", nil}
+	}
+	return nil
+}
+
+// validatePauseReason checks that a paused Dashboard has a human-readable reason.
+func validatePauseReason(dash *configpb.Dashboard) error {
+	if dash.GetPaused() && strings.TrimSpace(dash.GetPausedReason()) == "" {
+		return ConfigError{dash.GetName(), "Dashboard", "A paused Dashboard must have a paused_reason.", FieldPath{"paused_reason"}}
 	}
+	return nil
+}
 
-	// The entity that an entity references must exist.
-	err = validateReferencesExist(c)
+// MaxNotificationSummaryBytes is the longest Notification summary that fits in a banner.
+const MaxNotificationSummaryBytes = 512
+
+// validateNotification returns the field of a Notification that would render a blank or broken banner, and why.
+func validateNotification(n *configpb.Notification) (string, error) {
+	switch summary := n.GetSummary(); {
+	case strings.TrimSpace(summary) == "":
+		return "summary", errors.New("summary must not be empty")
+	case len(summary) > MaxNotificationSummaryBytes:
+		return "summary", fmt.Errorf("summary is %d bytes, more than %d", len(summary), MaxNotificationSummaryBytes)
+	}
+	link := n.GetContextLink()
+	if link == "" {
+		return "", nil
+	}
+	u, err := url.Parse(link)
 	if err != nil {
+		return "context_link", fmt.Errorf("context_link %q does not parse: %v", link, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "context_link", fmt.Errorf("context_link %q must be an absolute http or https URL", link)
+	}
+	return "", nil
+}
+
+// validateDashboardNotifications checks the banner of each Notification on a Dashboard.
+func validateDashboardNotifications(dash *configpb.Dashboard) error {
+	mErr := &multierror.Error{}
+	for i, n := range dash.GetNotifications() {
+		if field, err := validateNotification(n); err != nil {
+			msg := fmt.Sprintf("notification %d: %v", i, err)
+			mErr = multierror.Append(mErr, ConfigError{dash.GetName(), "Dashboard", msg, FieldPath{"notifications", i, field}})
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// validateTestGroupNotifications checks the banner of each Notification of a Test Group, which its Dashboard Tabs display.
+func validateTestGroupNotifications(tg *configpb.TestGroup) error {
+	mErr := &multierror.Error{}
+	for i, n := range tg.GetNotifications() {
+		if field, err := validateNotification(n); err != nil {
+			msg := fmt.Sprintf("notification %d: %v", i, err)
+			mErr = multierror.Append(mErr, ConfigError{tg.GetName(), "TestGroup", msg, FieldPath{"notifications", i, field}})
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// validateAddresses checks each address in a comma-separated list, returning the first error.
+//
+// An empty list is valid, but the list may not contain empty addresses.
+func validateAddresses(addresses string) error {
+	if addresses == "" {
+		return nil
+	}
+	for _, addr := range strings.Split(addresses, ",") {
+		addr = strings.TrimSpace(addr)
+		if addr == "" {
+			return errors.New("contains an empty address")
+		}
+		if _, err := mail.ParseAddress(addr); err != nil {
+			return fmt.Errorf("bad address %q: %v", addr, err)
+		}
+	}
+	return nil
+}
+
+// validateAlertMailAddresses checks the alert_mail_to_addresses of each Test Group and Dashboard Tab.
+func validateAlertMailAddresses(c configpb.Configuration) error {
+	mErr := &multierror.Error{}
+	if err := eachTestGroup(c, validateTestGroupAlertMailAddresses); err != nil {
+		mErr = multierror.Append(mErr, err)
+	}
+	if err := eachDashboard(c, validateTabAlertMailAddresses); err != nil {
 		mErr = multierror.Append(mErr, err)
 	}
+	return mErr.ErrorOrNil()
+}
+
+// validateTestGroupAlertMailAddresses checks the alert_mail_to_addresses of a Test Group.
+func validateTestGroupAlertMailAddresses(tg *configpb.TestGroup) error {
+	if err := validateAddresses(tg.GetAlertMailToAddresses()); err != nil {
+		return ConfigError{tg.GetName(), "TestGroup", fmt.Sprintf("alert_mail_to_addresses %v", err), FieldPath{"alert_mail_to_addresses"}}
+	}
+	return nil
+}
 
+// validateTabAlertMailAddresses checks the alert_mail_to_addresses of each Dashboard Tab in a Dashboard.
+func validateTabAlertMailAddresses(dash *configpb.Dashboard) error {
+	mErr := &multierror.Error{}
+	for i, tab := range dash.GetDashboardTab() {
+		if err := validateAddresses(tab.GetAlertOptions().GetAlertMailToAddresses()); err != nil {
+			path := FieldPath{"dashboard_tab", i, "alert_options", "alert_mail_to_addresses"}
+			mErr = multierror.Append(mErr, ConfigError{dash.GetName() + "/" + tab.Name, "DashboardTab", fmt.Sprintf("alert_options.alert_mail_to_addresses %v", err), path})
+		}
+	}
 	return mErr.ErrorOrNil()
 }
 
+// validateAlertThresholds checks that Dashboard Tab alert thresholds agree with the Test Group it displays.
+//
+// A tab alerting sooner than its Test Group produces alerts that appear impossible, and
+// num_passes_to_disable_alert does nothing without num_failures_to_alert.
+func validateAlertThresholds(c configpb.Configuration) error {
+	mErr := &multierror.Error{}
+	tgs := map[string]*configpb.TestGroup{}
+	for i, tg := range c.TestGroups {
+		tgs[tg.Name] = tg
+		if tg.NumPassesToDisableAlert > 0 && tg.NumFailuresToAlert == 0 {
+			mErr = multierror.Append(mErr, ConfigError{tg.Name, "TestGroup", "num_passes_to_disable_alert is set without num_failures_to_alert", FieldPath{"test_groups", i, "num_passes_to_disable_alert"}})
+		}
+	}
+	for i, dash := range c.Dashboards {
+		for j, tab := range dash.DashboardTab {
+			tg, ok := tgs[tab.TestGroupName]
+			if !ok {
+				continue // Reported by validateTestGroupsExist
+			}
+			opts := tab.GetAlertOptions()
+			if opts == nil {
+				continue
+			}
+			name := dash.Name + "/" + tab.Name
+			at := func(field string) FieldPath {
+				return FieldPath{"dashboards", i, "dashboard_tab", j, "alert_options", field}
+			}
+			thresholds := []struct {
+				field    string
+				tab, grp int32
+			}{
+				{"num_failures_to_alert", opts.NumFailuresToAlert, tg.NumFailuresToAlert},
+				{"alert_stale_results_hours", opts.AlertStaleResultsHours, tg.AlertStaleResultsHours},
+				{"num_passes_to_disable_alert", opts.NumPassesToDisableAlert, tg.NumPassesToDisableAlert},
+			}
+			for _, t := range thresholds {
+				if t.tab > 0 && t.grp > 0 && t.tab < t.grp {
+					mErr = multierror.Append(mErr, ConfigError{name, "DashboardTab", fmt.Sprintf("alert_options.%s %d is lower than %d in TestGroup %s", t.field, t.tab, t.grp, tg.Name), at(t.field)})
+				}
+			}
+			if opts.NumPassesToDisableAlert > 0 && opts.NumFailuresToAlert == 0 && tg.NumFailuresToAlert == 0 {
+				mErr = multierror.Append(mErr, ConfigError{name, "DashboardTab", fmt.Sprintf("alert_options.num_passes_to_disable_alert is set without num_failures_to_alert in the tab or TestGroup %s", tg.Name), at("num_passes_to_disable_alert")})
+			}
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// validateTabAlertsEnabled checks that each Dashboard Tab setting num_failures_to_alert displays a Test Group that alerts.
+//
+// The updater only detects failing rows of a Test Group that sets num_failures_to_alert, so the
+// tab threshold would never take effect.
+func validateTabAlertsEnabled(c configpb.Configuration) error {
+	mErr := &multierror.Error{}
+	tgs := map[string]*configpb.TestGroup{}
+	for _, tg := range c.TestGroups {
+		tgs[tg.Name] = tg
+	}
+	for i, dash := range c.Dashboards {
+		for j, tab := range dash.DashboardTab {
+			tg, ok := tgs[tab.TestGroupName]
+			if !ok {
+				continue // Reported by validateTestGroupsExist
+			}
+			if tab.GetAlertOptions().GetNumFailuresToAlert() > 0 && tg.NumFailuresToAlert == 0 {
+				mErr = multierror.Append(mErr, ConfigError{dash.Name + "/" + tab.Name, "DashboardTab", fmt.Sprintf("alert_options.num_failures_to_alert has no effect; TestGroup %s disables alerts without num_failures_to_alert", tg.Name), FieldPath{"dashboards", i, "dashboard_tab", j, "alert_options", "num_failures_to_alert"}})
+			}
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// linkPlaceholders lists the <placeholders> TestGrid expands in a LinkTemplate.
+var linkPlaceholders = map[string]bool{
+	"bug-component":    true,
+	"build-id":         true,
+	"cc":               true,
+	"changelist":       true,
+	"dashboard-name":   true,
+	"end-changelist":   true,
+	"environment":      true,
+	"gcs_prefix":       true,
+	"owner":            true,
+	"start-changelist": true,
+	"tab-name":         true,
+	"test-id":          true,
+	"test-name":        true,
+	"test-status":      true,
+	"test-url":         true,
+	"workflow-id":      true,
+	"workflow-name":    true,
+}
+
+// customPlaceholder matches <custom-N>, <start-custom-N> and <end-custom-N>.
+var customPlaceholder = regexp.MustCompile(`^((start|end)-)?custom-\d+$`)
+
+// validatePlaceholders checks that each <placeholder> in s is closed and known.
+func validatePlaceholders(s string) error {
+	start := -1
+	for i, r := range s {
+		switch r {
+		case '<':
+			if start >= 0 {
+				return fmt.Errorf("nested < at position %d", i)
+			}
+			start = i
+		case '>':
+			if start < 0 {
+				return fmt.Errorf("unmatched > at position %d", i)
+			}
+			name := s[start+1 : i]
+			if !linkPlaceholders[name] && !customPlaceholder.MatchString(name) {
+				return fmt.Errorf("unknown placeholder <%s>", name)
+			}
+			start = -1
+		}
+	}
+	if start >= 0 {
+		return fmt.Errorf("unclosed < at position %d", start)
+	}
+	return nil
+}
+
+// validateLinkTemplate checks the url and options of a LinkTemplate, returning the first error and its path within tmpl.
+func validateLinkTemplate(field string, tmpl *configpb.LinkTemplate) (FieldPath, error) {
+	if tmpl == nil {
+		return nil, nil
+	}
+	if err := validatePlaceholders(tmpl.Url); err != nil {
+		return FieldPath{"url"}, fmt.Errorf("%s.url: %v", field, err)
+	}
+	for i, opt := range tmpl.Options {
+		if err := validatePlaceholders(opt.Value); err != nil {
+			return FieldPath{"options", i, "value"}, fmt.Errorf("%s.options[%s]: %v", field, opt.Key, err)
+		}
+	}
+	return nil, nil
+}
+
+// validateLinkTemplates checks the <placeholders> of each LinkTemplate on every Dashboard Tab.
+func validateLinkTemplates(c configpb.Configuration) error {
+	return eachDashboard(c, validateTabLinkTemplates)
+}
+
+// validateTabLinkTemplates checks the <placeholders> of each LinkTemplate on the Dashboard Tabs of a Dashboard.
+func validateTabLinkTemplates(dash *configpb.Dashboard) error {
+	mErr := &multierror.Error{}
+	for i, tab := range dash.GetDashboardTab() {
+		templates := []struct {
+			field string
+			tmpl  *configpb.LinkTemplate
+		}{
+			{"open_test_template", tab.OpenTestTemplate},
+			{"file_bug_template", tab.FileBugTemplate},
+			{"attach_bug_template", tab.AttachBugTemplate},
+			{"results_url_template", tab.ResultsUrlTemplate},
+			{"code_search_url_template", tab.CodeSearchUrlTemplate},
+			{"open_bug_template", tab.OpenBugTemplate},
+		}
+		for _, t := range templates {
+			if sub, err := validateLinkTemplate(t.field, t.tmpl); err != nil {
+				path := append(FieldPath{"dashboard_tab", i, t.field}, sub...)
+				mErr = multierror.Append(mErr, ConfigError{dash.GetName() + "/" + tab.Name, "DashboardTab", err.Error(), path})
+			}
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// validateBaseOptions checks the row filters in the base_options of every Dashboard Tab.
+func validateBaseOptions(c configpb.Configuration) error {
+	return eachDashboard(c, validateTabBaseOptions)
+}
+
+// validateTabBaseOptions checks the row filters in the base_options of the Dashboard Tabs of a Dashboard.
+func validateTabBaseOptions(dash *configpb.Dashboard) error {
+	mErr := &multierror.Error{}
+	for i, tab := range dash.GetDashboardTab() {
+		if _, err := filter.ParseOptions(tab.BaseOptions); err != nil {
+			path := FieldPath{"dashboard_tab", i, "base_options"}
+			mErr = multierror.Append(mErr, ConfigError{dash.GetName() + "/" + tab.Name, "DashboardTab", err.Error(), path})
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// maxCachedRegexes bounds the memory of regexes; the cache starts over once full.
+const maxCachedRegexes = 10000
+
+// regexCache remembers whether each regex compiles, so a regex shared by many entities compiles once.
+type regexCache struct {
+	lock sync.Mutex
+	errs map[string]error
+}
+
+// compile returns the error compiling expr, if any.
+func (c *regexCache) compile(expr string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if err, ok := c.errs[expr]; ok {
+		return err
+	}
+	if c.errs == nil || len(c.errs) >= maxCachedRegexes {
+		c.errs = map[string]error{}
+	}
+	_, err := regexp.Compile(expr)
+	c.errs[expr] = err
+	return err
+}
+
+// regexes is shared by every validation, which may run concurrently.
+var regexes regexCache
+
+// validateRegexes checks that the regexes of every Test Group and Dashboard Tab compile.
+func validateRegexes(c configpb.Configuration) error {
+	mErr := &multierror.Error{}
+	if err := eachTestGroup(c, validateTestGroupRegexes); err != nil {
+		mErr = multierror.Append(mErr, err)
+	}
+	if err := eachDashboard(c, validateTabRegexes); err != nil {
+		mErr = multierror.Append(mErr, err)
+	}
+	return mErr.ErrorOrNil()
+}
+
+// validateTestGroupRegexes checks that the regexes of a Test Group compile.
+//
+// test_tag_pattern is a list of tags rather than a regex.
+func validateTestGroupRegexes(tg *configpb.TestGroup) error {
+	mErr := &multierror.Error{}
+	check := func(field, expr string, path FieldPath) {
+		if expr == "" {
+			return
+		}
+		if err := regexes.compile(expr); err != nil {
+			mErr = multierror.Append(mErr, ConfigError{tg.GetName(), "TestGroup", fmt.Sprintf("%s %q %v", field, expr, err), path})
+		}
+	}
+	check("commit_override_label_pattern", tg.GetCommitOverrideLabelPattern(), FieldPath{"commit_override_label_pattern"})
+	check("test_method_match_regex", tg.GetTestMethodMatchRegex(), FieldPath{"test_method_match_regex"})
+	for i, opt := range tg.GetTestMetadataOptions() {
+		check(fmt.Sprintf("test_metadata_options[%d].test_name_regex", i), opt.GetTestNameRegex(), FieldPath{"test_metadata_options", i, "test_name_regex"})
+		check(fmt.Sprintf("test_metadata_options[%d].message_regex", i), opt.GetMessageRegex(), FieldPath{"test_metadata_options", i, "message_regex"})
+	}
+	return mErr.ErrorOrNil()
+}
+
+// validateTabRegexes checks that the regexes of each Dashboard Tab in a Dashboard compile.
+//
+// The InvalidBaseOptions rule checks the row filters of base_options.
+func validateTabRegexes(dash *configpb.Dashboard) error {
+	mErr := &multierror.Error{}
+	for i, tab := range dash.GetDashboardTab() {
+		expr := tab.GetTabularNamesRegex()
+		if expr == "" {
+			continue
+		}
+		if err := regexes.compile(expr); err != nil {
+			path := FieldPath{"dashboard_tab", i, "tabular_names_regex"}
+			mErr = multierror.Append(mErr, ConfigError{dash.GetName() + "/" + tab.Name, "DashboardTab", fmt.Sprintf("tabular_names_regex %q %v", expr, err), path})
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// ValidateTestGroup checks the rules which only depend on a single Test Group, using the default severity of each Rule.
+//
+// Rules which cross-reference other entities, such as whether a Dashboard Tab displays it, are skipped.
+func ValidateTestGroup(tg *configpb.TestGroup) error {
+	var f findings
+	for _, chk := range testGroupChecks {
+		validate := chk.validate
+		f.run(chk.rule, func() error { return validate(tg) })
+	}
+	errs, _ := f.result()
+	return errs
+}
+
+// ValidateDashboard checks the rules which only depend on a single Dashboard, using the default severity of each Rule.
+//
+// Rules which cross-reference other entities, such as whether its Test Groups exist, are skipped.
+func ValidateDashboard(dash *configpb.Dashboard) error {
+	var f findings
+	for _, chk := range dashboardChecks {
+		validate := chk.validate
+		f.run(chk.rule, func() error { return validate(dash) })
+	}
+	errs, _ := f.result()
+	return errs
+}
+
+// Validate checks that a configuration is well-formed, using the default severity of each Rule.
+//
+// Warnings are ignored; use ValidateWithOptions to receive them.
+func Validate(c configpb.Configuration) error {
+	errs, _ := ValidateWithOptions(c, ValidateOptions{})
+	return errs
+}
+
+// ValidateWithOptions checks that a configuration is well-formed, returning any errors and warnings separately.
+func ValidateWithOptions(c configpb.Configuration, opts ValidateOptions) (error, error) {
+	mErr := &multierror.Error{}
+
+	// TestGrid requires at least 1 TestGroup and 1 Dashboard in order to do anything.
+	if len(c.TestGroups) == 0 {
+		return wrapErrors(multierror.Append(mErr, MissingFieldError{"TestGroups", FieldPath{"test_groups"}})), nil
+	}
+	if len(c.Dashboards) == 0 {
+		return wrapErrors(multierror.Append(mErr, MissingFieldError{"Dashboards", FieldPath{"dashboards"}})), nil
+	}
+
+	// Rules local to an entity are shared with ValidateTestGroup and ValidateDashboard.
+	var passes []pass
+	for _, chk := range opts.testGroupChecks() {
+		validate := chk.validate
+		passes = append(passes, pass{chk.rule, func() error { return eachTestGroup(c, validate) }})
+	}
+	for _, chk := range dashboardChecks {
+		validate := chk.validate
+		passes = append(passes, pass{chk.rule, func() error { return eachDashboard(c, validate) }})
+	}
+
+	all := checks
+	if opts.StrictNamespace {
+		all = append(append([]check{}, checks...), strictChecks...)
+	}
+	for _, chk := range all {
+		validate := chk.validate
+		passes = append(passes, pass{chk.rule, func() error { return validate(c) }})
+	}
+	for _, chk := range optionChecks {
+		validate := chk.validate
+		passes = append(passes, pass{chk.rule, func() error { return validate(c, opts) }})
+	}
+
+	f := findings{opts: opts, errs: mErr}
+	f.runAll(passes)
+	return f.result()
+}
+
 // Unmarshal reads a protocol buffer into memory
 func Unmarshal(r io.Reader) (*configpb.Configuration, error) {
-	buf, err := ioutil.ReadAll(r)
+	cfg, corrupt, err := unmarshal(r)
+	if corrupt {
+		return nil, fmt.Errorf("failed to parse: %v", err)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config: %v", err)
 	}
+	return cfg, nil
+}
+
+// unmarshal reads a protocol buffer into memory, reporting whether an error means the buffer is corrupt rather than unreadable.
+func unmarshal(r io.Reader) (*configpb.Configuration, bool, error) {
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, false, err
+	}
 	var cfg configpb.Configuration
 	if err = proto.Unmarshal(buf, &cfg); err != nil {
-		return nil, fmt.Errorf("failed to parse: %v", err)
+		return nil, true, err
 	}
-	return &cfg, nil
+	return &cfg, false, nil
 }
 
 // MarshalText writes a text version of the parsed configuration to the supplied io.Writer.
@@ -250,22 +1356,94 @@ func MarshalBytes(c configpb.Configuration) ([]byte, error) {
 	return proto.Marshal(&c)
 }
 
-// ReadGCS reads the config from gcs and unmarshals it into a Configuration struct.
-func ReadGCS(ctx context.Context, obj *storage.ObjectHandle) (*configpb.Configuration, error) {
-	r, err := obj.NewReader(ctx)
+// NotFoundError means the config does not exist.
+type NotFoundError struct {
+	Path string
+	Err  error
+}
+
+func (e NotFoundError) Error() string {
+	return fmt.Sprintf("config %s not found: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e NotFoundError) Unwrap() error { return e.Err }
+
+// CorruptError means the config is not a serialized Configuration proto.
+type CorruptError struct {
+	Path string
+	Err  error
+}
+
+func (e CorruptError) Error() string {
+	return fmt.Sprintf("config %s is corrupt: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e CorruptError) Unwrap() error { return e.Err }
+
+// parse reads the config at path from r, validating it when requested.
+func parse(path string, r io.Reader, validate bool) (*configpb.Configuration, error) {
+	cfg, corrupt, err := unmarshal(r)
+	if corrupt {
+		return nil, CorruptError{path, err}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %v", path, err)
+	}
+	if validate {
+		if err := Validate(*cfg); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// ReadGCS reads the config from gcs and unmarshals it into a Configuration struct, along with its generation.
+//
+// Returns a NotFoundError if the object does not exist, a CorruptError if it cannot be parsed,
+// and the Validate error if validate is set and the config is not valid.
+func ReadGCS(ctx context.Context, client gcs.Client, path gcs.Path, validate bool) (*configpb.Configuration, int64, error) {
+	return readObject(path, func() (io.ReadCloser, int64, error) {
+		r, attrs, err := client.Open(ctx, path)
+		if err != nil {
+			return nil, 0, err
+		}
+		return r, attrs.Generation, nil
+	}, validate)
+}
+
+// readObject parses the config from the reader that open returns, along with its generation.
+func readObject(path gcs.Path, open func() (io.ReadCloser, int64, error), validate bool) (*configpb.Configuration, int64, error) {
+	r, generation, err := open()
+	if err == storage.ErrObjectNotExist {
+		return nil, 0, NotFoundError{path.String(), err}
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to open config %s: %v", path, err)
+	}
+	defer r.Close()
+	cfg, err := parse(path.String(), r, validate)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open config: %v", err)
+		return nil, 0, err
 	}
-	return Unmarshal(r)
+	return cfg, generation, nil
 }
 
 // ReadPath reads the config from the specified local file path.
-func ReadPath(path string) (*configpb.Configuration, error) {
+//
+// Returns a NotFoundError if the file does not exist, a CorruptError if it cannot be parsed,
+// and the Validate error if validate is set and the config is not valid.
+func ReadPath(path string, validate bool) (*configpb.Configuration, error) {
 	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, NotFoundError{path, err}
+	}
 	if err != nil {
 		return nil, fmt.Errorf("open: %v", err)
 	}
-	return Unmarshal(f)
+	defer f.Close()
+	return parse(path, f, validate)
 }
 
 // Read will read the Configuration proto message from a local or gs:// path.
@@ -277,25 +1455,8 @@ func Read(path string, ctx context.Context, client *storage.Client) (*configpb.C
 		if err := gcsPath.Set(path); err != nil {
 			return nil, fmt.Errorf("bad gcs path: %v", err)
 		}
-		return ReadGCS(ctx, client.Bucket(gcsPath.Bucket()).Object(gcsPath.Object()))
+		cfg, _, err := ReadGCS(ctx, gcs.NewClient(client), gcsPath, false)
+		return cfg, err
 	}
-	return ReadPath(path)
-}
-
-func FindTestGroup(name string, cfg *configpb.Configuration) *configpb.TestGroup {
-	for _, tg := range cfg.TestGroups {
-		if tg.Name == name {
-			return tg
-		}
-	}
-	return nil
-}
-
-func FindDashboard(name string, cfg *configpb.Configuration) *configpb.Dashboard {
-	for _, d := range cfg.Dashboards {
-		if d.Name == name {
-			return d
-		}
-	}
-	return nil
+	return ReadPath(path, false)
 }
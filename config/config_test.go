@@ -17,10 +17,27 @@ limitations under the License.
 package config
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
+	"regexp"
+	"strings"
 	"testing"
+	"testing/quick"
+
+	"cloud.google.com/go/storage"
 
 	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+	"github.com/golang/protobuf/proto"
 	multierror "github.com/hashicorp/go-multierror"
 )
 
@@ -45,7 +62,54 @@ func TestNormalize(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.input, func(t *testing.T) {
-			got := normalize(test.input)
+			got := Normalize(test.input)
+			if got != test.expected {
+				t.Fatalf("got %s, want %s", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizeWith(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     NormalizeOptions
+		input    string
+		expected string
+	}{
+		{
+			name:     "Default matches Normalize",
+			input:    "Tést-Group_1",
+			expected: "tstgroup1",
+		},
+		{
+			name:     "Keep dashes",
+			opts:     NormalizeOptions{KeepDashes: true},
+			input:    "Tést-Group_1",
+			expected: "tst-group1",
+		},
+		{
+			name:     "Keep unicode",
+			opts:     NormalizeOptions{KeepUnicode: true},
+			input:    "Tést-Group_1",
+			expected: "téstgroup1",
+		},
+		{
+			name:     "Keep both",
+			opts:     NormalizeOptions{KeepDashes: true, KeepUnicode: true},
+			input:    "Tést-Group_1",
+			expected: "tést-group1",
+		},
+		{
+			name:     "Kelvin sign is not ASCII",
+			input:    "\u212aelvin",
+			expected: "elvin",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := NormalizeWith(test.opts)(test.input)
 			if got != test.expected {
 				t.Fatalf("got %s, want %s", got, test.expected)
 			}
@@ -53,6 +117,48 @@ func TestNormalize(t *testing.T) {
 	}
 }
 
+func TestNormalize_MatchesRegexp(t *testing.T) {
+	regex := regexp.MustCompile("[^a-zA-Z0-9]+")
+	legacy := func(s string) string {
+		return strings.ToLower(regex.ReplaceAllString(s, ""))
+	}
+	same := func(s string) bool {
+		return Normalize(s) == legacy(s)
+	}
+	if err := quick.Check(same, nil); err != nil {
+		t.Error(err)
+	}
+	for _, s := range []string{"\u212aelvin", "\u0130stanbul", "\xff\xfe"} {
+		if !same(s) {
+			t.Errorf("Normalize(%q) = %q, want %q", s, Normalize(s), legacy(s))
+		}
+	}
+}
+
+func TestNormalize_Idempotent(t *testing.T) {
+	opts := []NormalizeOptions{
+		{},
+		{KeepDashes: true},
+		{KeepUnicode: true},
+		{KeepDashes: true, KeepUnicode: true},
+	}
+	for _, opt := range opts {
+		normalize := NormalizeWith(opt)
+		idempotent := func(s string) bool {
+			once := normalize(s)
+			return normalize(once) == once
+		}
+		if err := quick.Check(idempotent, nil); err != nil {
+			t.Errorf("%+v: %v", opt, err)
+		}
+		for _, s := range []string{"\u0130stanbul", "\xff\xfe", "ǅungla"} {
+			if !idempotent(s) {
+				t.Errorf("%+v: normalizing %q is not idempotent", opt, s)
+			}
+		}
+	}
+}
+
 func TestUpdate_validateUnique(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -71,20 +177,35 @@ func TestUpdate_validateUnique(t *testing.T) {
 			name:  "Duplicate name; error",
 			input: []string{"test_group_1", "test_group_1"},
 			expectedErrs: []error{
-				DuplicateNameError{"testgroup1", "TestGroup"},
+				DuplicateNameError{"testgroup1", "TestGroup", []string{"test_group_1", "test_group_1"}, FieldPath{"test_groups", 1, "name"}},
 			},
 		},
 		{
 			name:  "Duplicate name after normalization; error",
 			input: []string{"test_group_1", "TEST GROUP 1"},
 			expectedErrs: []error{
-				DuplicateNameError{"testgroup1", "TestGroup"},
+				DuplicateNameError{"testgroup1", "TestGroup", []string{"test_group_1", "TEST GROUP 1"}, FieldPath{"test_groups", 1, "name"}},
+			},
+		},
+		{
+			name:  "Three-way collision; single error",
+			input: []string{"test_group_1", "test_group_2", "TEST GROUP 1", "test-group-1"},
+			expectedErrs: []error{
+				DuplicateNameError{"testgroup1", "TestGroup", []string{"test_group_1", "TEST GROUP 1", "test-group-1"}, FieldPath{"test_groups", 2, "name"}},
+			},
+		},
+		{
+			name:  "Multiple collisions, ordered by first occurrence; errors",
+			input: []string{"b", "a", "A", "B"},
+			expectedErrs: []error{
+				DuplicateNameError{"b", "TestGroup", []string{"b", "B"}, FieldPath{"test_groups", 3, "name"}},
+				DuplicateNameError{"a", "TestGroup", []string{"a", "A"}, FieldPath{"test_groups", 2, "name"}},
 			},
 		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			err := validateUnique(test.input, "TestGroup")
+			err := validateUnique(test.input, "TestGroup", namePath("test_groups"))
 			if err == nil {
 				if len(test.expectedErrs) > 0 {
 					t.Fatalf("Expected %v, but got no error", test.expectedErrs)
@@ -137,7 +258,86 @@ func TestUpdate_validateReferencesExist(t *testing.T) {
 				},
 			},
 			expectedErrs: []error{
-				MissingEntityError{"test_group_2", "TestGroup"},
+				MissingEntityError{"test_group_2", "TestGroup", "test_group_1", FieldPath{"dashboards", 0, "dashboard_tab", 1, "test_group_name"}},
+			},
+		},
+		{
+			name: "Dashboard Tabs must name a Test Group",
+			input: configpb.Configuration{
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dashboard_1",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:          "tab_1",
+								TestGroupName: "test_group_1",
+							},
+							{
+								Name: "tab_2",
+							},
+						},
+					},
+				},
+				TestGroups: []*configpb.TestGroup{
+					{
+						Name: "test_group_1",
+					},
+				},
+			},
+			expectedErrs: []error{
+				MissingFieldError{"dashboard_1/tab_2: TestGroupName", FieldPath{"dashboards", 0, "dashboard_tab", 1, "test_group_name"}},
+			},
+		},
+		{
+			name: "Whitespace is not a Test Group name",
+			input: configpb.Configuration{
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dashboard_1",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:          "tab_1",
+								TestGroupName: "test_group_1",
+							},
+							{
+								Name:          "tab_2",
+								TestGroupName: " \t",
+							},
+						},
+					},
+				},
+				TestGroups: []*configpb.TestGroup{
+					{
+						Name: "test_group_1",
+					},
+				},
+			},
+			expectedErrs: []error{
+				MissingFieldError{"dashboard_1/tab_2: TestGroupName", FieldPath{"dashboards", 0, "dashboard_tab", 1, "test_group_name"}},
+			},
+		},
+		{
+			name: "An unnamed Dashboard Tab Test Group does not match an unnamed Test Group",
+			input: configpb.Configuration{
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dashboard_1",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name: "tab_1",
+							},
+						},
+					},
+				},
+				TestGroups: []*configpb.TestGroup{
+					{
+						Name: "",
+					},
+				},
+			},
+			expectedErrs: []error{
+				MissingFieldError{"dashboard_1/tab_1: TestGroupName", FieldPath{"dashboards", 0, "dashboard_tab", 0, "test_group_name"}},
+				ConfigError{"", "TestGroup", "Each Test Group must be referenced by at least 1 Dashboard Tab.", FieldPath{"test_groups", 0}},
 			},
 		},
 		{
@@ -156,7 +356,7 @@ func TestUpdate_validateReferencesExist(t *testing.T) {
 				},
 			},
 			expectedErrs: []error{
-				ConfigError{"test_group_1", "TestGroup", "Each Test Group must be referenced by at least 1 Dashboard Tab."},
+				ConfigError{"test_group_1", "TestGroup", "Each Test Group must be referenced by at least 1 Dashboard Tab.", FieldPath{"test_groups", 0}},
 			},
 		},
 		{
@@ -186,8 +386,8 @@ func TestUpdate_validateReferencesExist(t *testing.T) {
 				},
 			},
 			expectedErrs: []error{
-				MissingEntityError{"dashboard_2", "Dashboard"},
-				MissingEntityError{"dashboard_3", "Dashboard"},
+				MissingEntityError{"dashboard_2", "Dashboard", "dashboard_1", FieldPath{"dashboard_groups", 0, "dashboard_names", 1}},
+				MissingEntityError{"dashboard_3", "Dashboard", "dashboard_1", FieldPath{"dashboard_groups", 0, "dashboard_names", 2}},
 			},
 		},
 		{
@@ -221,7 +421,7 @@ func TestUpdate_validateReferencesExist(t *testing.T) {
 				},
 			},
 			expectedErrs: []error{
-				ConfigError{"dashboard_1", "Dashboard", "A Dashboard cannot be in more than 1 Dashboard Group."},
+				ConfigError{"dashboard_1", "Dashboard", "A Dashboard cannot be in more than 1 Dashboard Group.", FieldPath{"dashboard_groups", 1, "dashboard_names", 0}},
 			},
 		},
 	}
@@ -258,7 +458,7 @@ func TestUpdate_Validate(t *testing.T) {
 	}{
 		{
 			name:         "Null input; returns error",
-			expectedErrs: []error{MissingFieldError{"TestGroups"}},
+			expectedErrs: []error{MissingFieldError{"TestGroups", FieldPath{"test_groups"}}},
 		},
 		{
 			name: "Dashboard Only; returns error",
@@ -270,7 +470,7 @@ func TestUpdate_Validate(t *testing.T) {
 				},
 			},
 			expectedErrs: []error{
-				MissingFieldError{"TestGroups"},
+				MissingFieldError{"TestGroups", FieldPath{"test_groups"}},
 			},
 		},
 		{
@@ -283,7 +483,7 @@ func TestUpdate_Validate(t *testing.T) {
 				},
 			},
 			expectedErrs: []error{
-				MissingFieldError{"Dashboards"},
+				MissingFieldError{"Dashboards", FieldPath{"dashboards"}},
 			},
 		},
 		{
@@ -333,7 +533,65 @@ func TestUpdate_Validate(t *testing.T) {
 				},
 			},
 			expectedErrs: []error{
-				DuplicateNameError{"name1", "Dashboard/DashboardGroup"},
+				DuplicateNameError{"name1", "Dashboard/DashboardGroup", []string{"name_1", "name_1"}, FieldPath{"dashboard_groups", 0, "name"}},
+			},
+		},
+		{
+			name: "Dashboard Tabs cannot share names within a Dashboard",
+			input: configpb.Configuration{
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dashboard_1",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:          "Tab 1",
+								TestGroupName: "test_group_1",
+							},
+							{
+								Name:          "tab_1",
+								TestGroupName: "test_group_1",
+							},
+						},
+					},
+				},
+				TestGroups: []*configpb.TestGroup{
+					{
+						Name: "test_group_1",
+					},
+				},
+			},
+			expectedErrs: []error{
+				DuplicateNameError{"tab1", "DashboardTab in dashboard_1", []string{"Tab 1", "tab_1"}, FieldPath{"dashboards", 0, "dashboard_tab", 1, "name"}},
+			},
+		},
+		{
+			name: "Dashboard Tabs can share names across Dashboards",
+			input: configpb.Configuration{
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dashboard_1",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:          "tab_1",
+								TestGroupName: "test_group_1",
+							},
+						},
+					},
+					{
+						Name: "dashboard_2",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:          "tab_1",
+								TestGroupName: "test_group_1",
+							},
+						},
+					},
+				},
+				TestGroups: []*configpb.TestGroup{
+					{
+						Name: "test_group_1",
+					},
+				},
 			},
 		},
 		{
@@ -361,7 +619,7 @@ func TestUpdate_Validate(t *testing.T) {
 				},
 			},
 			expectedErrs: []error{
-				MissingEntityError{"test_group_2", "TestGroup"},
+				MissingEntityError{"test_group_2", "TestGroup", "test_group_1", FieldPath{"dashboards", 0, "dashboard_tab", 1, "test_group_name"}},
 			},
 		},
 		{
@@ -380,7 +638,8 @@ func TestUpdate_Validate(t *testing.T) {
 				},
 			},
 			expectedErrs: []error{
-				ConfigError{"test_group_1", "TestGroup", "Each Test Group must be referenced by at least 1 Dashboard Tab."},
+				ConfigError{"dashboard_1", "Dashboard", "A Dashboard must have at least one Dashboard Tab.", FieldPath{"dashboards", 0}},
+				ConfigError{"test_group_1", "TestGroup", "Each Test Group must be referenced by at least 1 Dashboard Tab.", FieldPath{"test_groups", 0}},
 			},
 		},
 		{
@@ -404,14 +663,14 @@ func TestUpdate_Validate(t *testing.T) {
 				},
 				DashboardGroups: []*configpb.DashboardGroup{
 					{
-						Name:           "dashboard_group_1",
+						Name:           "dashboard",
 						DashboardNames: []string{"dashboard_1", "dashboard_2", "dashboard_3"},
 					},
 				},
 			},
 			expectedErrs: []error{
-				MissingEntityError{"dashboard_2", "Dashboard"},
-				MissingEntityError{"dashboard_3", "Dashboard"},
+				MissingEntityError{"dashboard_2", "Dashboard", "dashboard_1", FieldPath{"dashboard_groups", 0, "dashboard_names", 1}},
+				MissingEntityError{"dashboard_3", "Dashboard", "dashboard_1", FieldPath{"dashboard_groups", 0, "dashboard_names", 2}},
 			},
 		},
 		{
@@ -435,17 +694,17 @@ func TestUpdate_Validate(t *testing.T) {
 				},
 				DashboardGroups: []*configpb.DashboardGroup{
 					{
-						Name:           "dashboard_group_1",
+						Name:           "dash",
 						DashboardNames: []string{"dashboard_1"},
 					},
 					{
-						Name:           "dashboard_group_2",
+						Name:           "dashboard",
 						DashboardNames: []string{"dashboard_1"},
 					},
 				},
 			},
 			expectedErrs: []error{
-				ConfigError{"dashboard_1", "Dashboard", "A Dashboard cannot be in more than 1 Dashboard Group."},
+				ConfigError{"dashboard_1", "Dashboard", "A Dashboard cannot be in more than 1 Dashboard Group.", FieldPath{"dashboard_groups", 1, "dashboard_names", 0}},
 			},
 		},
 	}
@@ -462,7 +721,8 @@ func TestUpdate_Validate(t *testing.T) {
 					t.Fatalf("Expected %v, but got no error", test.expectedErrs)
 				}
 
-				if mErr, ok := err.(*multierror.Error); ok {
+				var mErr *multierror.Error
+				if errors.As(err, &mErr) {
 					if !reflect.DeepEqual(test.expectedErrs, mErr.Errors) {
 						t.Fatalf("Expected %v, but got: %v", test.expectedErrs, mErr.Errors)
 					}
@@ -473,3 +733,1806 @@ func TestUpdate_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdate_validateGcsPrefix(t *testing.T) {
+	tests := []struct {
+		name         string
+		allowFiles   bool
+		input        []*configpb.TestGroup
+		expectedErrs []error
+	}{
+		{
+			name: "Valid prefixes",
+			input: []*configpb.TestGroup{
+				{
+					Name:  "test_group_1",
+					Query: "bucket/logs/job-1",
+				},
+				{
+					Name:  "test_group_2",
+					Query: "bucket/logs/job-2/",
+				},
+			},
+		},
+		{
+			name: "Empty prefix is allowed",
+			input: []*configpb.TestGroup{
+				{
+					Name: "test_group_1",
+				},
+			},
+		},
+		{
+			name: "Prefix must not include the scheme",
+			input: []*configpb.TestGroup{
+				{
+					Name:  "test_group_1",
+					Query: "gs://bucket/logs/job-1",
+				},
+			},
+			expectedErrs: []error{
+				ConfigError{"test_group_1", "TestGroup", `gcs_prefix "gs://bucket/logs/job-1" must not start with gs://`, FieldPath{"test_groups", 0, "query"}},
+			},
+		},
+		{
+			name: "Prefix must include a bucket",
+			input: []*configpb.TestGroup{
+				{
+					Name:  "test_group_1",
+					Query: "/logs/job-1",
+				},
+			},
+			expectedErrs: []error{
+				ConfigError{"test_group_1", "TestGroup", `gcs_prefix "/logs/job-1" is missing a bucket`, FieldPath{"test_groups", 0, "query"}},
+			},
+		},
+		{
+			name: "Prefix must include a path",
+			input: []*configpb.TestGroup{
+				{
+					Name:  "test_group_1",
+					Query: "bucket/",
+				},
+			},
+			expectedErrs: []error{
+				ConfigError{"test_group_1", "TestGroup", `gcs_prefix "bucket/" is missing a path`, FieldPath{"test_groups", 0, "query"}},
+			},
+		},
+		{
+			name: "Local prefixes are not allowed by default",
+			input: []*configpb.TestGroup{
+				{
+					Name:  "test_group_1",
+					Query: "file:///logs/job-1",
+				},
+			},
+			expectedErrs: []error{
+				ConfigError{"test_group_1", "TestGroup", `gcs_prefix "file:///logs/job-1" must not be a local file:// url`, FieldPath{"test_groups", 0, "query"}},
+			},
+		},
+		{
+			name:       "Allow local prefixes",
+			allowFiles: true,
+			input: []*configpb.TestGroup{
+				{
+					Name:  "test_group_1",
+					Query: "file:///logs/job-1",
+				},
+				{
+					Name:  "test_group_2",
+					Query: "bucket/logs/job-2",
+				},
+			},
+		},
+		{
+			name:       "Local prefix must be local",
+			allowFiles: true,
+			input: []*configpb.TestGroup{
+				{
+					Name:  "test_group_1",
+					Query: "file://example.com/logs/job-1",
+				},
+			},
+			expectedErrs: []error{
+				ConfigError{"test_group_1", "TestGroup", `gcs_prefix "file://example.com/logs/job-1" is invalid: file:// url must be local: file://example.com/logs/job-1`, FieldPath{"test_groups", 0, "query"}},
+			},
+		},
+		{
+			name:       "Local prefix must include a path",
+			allowFiles: true,
+			input: []*configpb.TestGroup{
+				{
+					Name:  "test_group_1",
+					Query: "file:///",
+				},
+			},
+			expectedErrs: []error{
+				ConfigError{"test_group_1", "TestGroup", `gcs_prefix "file:///" is missing a path`, FieldPath{"test_groups", 0, "query"}},
+			},
+		},
+		{
+			name: "Valid additional prefixes",
+			input: []*configpb.TestGroup{
+				{
+					Name:                  "test_group_1",
+					Query:                 "bucket/logs/job-1",
+					AdditionalGcsPrefixes: []string{"old-bucket/logs/job-1", "bucket/logs/job-10"},
+				},
+			},
+		},
+		{
+			name: "Additional prefixes must be bucket/paths",
+			input: []*configpb.TestGroup{
+				{
+					Name:                  "test_group_1",
+					Query:                 "bucket/logs/job-1",
+					AdditionalGcsPrefixes: []string{"old-bucket/logs/job-1", "gs://older-bucket/logs/job-1"},
+				},
+			},
+			expectedErrs: []error{
+				ConfigError{"test_group_1", "TestGroup", `gcs_prefix "gs://older-bucket/logs/job-1" must not start with gs://`, FieldPath{"test_groups", 0, "additional_gcs_prefixes", 1}},
+			},
+		},
+		{
+			name: "Additional prefixes require a prefix",
+			input: []*configpb.TestGroup{
+				{
+					Name:                  "test_group_1",
+					AdditionalGcsPrefixes: []string{"old-bucket/logs/job-1"},
+				},
+			},
+			expectedErrs: []error{
+				ConfigError{"test_group_1", "TestGroup", "additional_gcs_prefixes require a gcs_prefix", FieldPath{"test_groups", 0, "additional_gcs_prefixes"}},
+			},
+		},
+		{
+			name: "Prefixes must not overlap",
+			input: []*configpb.TestGroup{
+				{
+					Name:                  "test_group_1",
+					Query:                 "bucket/logs/job-1",
+					AdditionalGcsPrefixes: []string{"bucket/logs/job-1/", "bucket/logs", "bucket/logs/job-1/old"},
+				},
+			},
+			expectedErrs: []error{
+				ConfigError{"test_group_1", "TestGroup", `gcs_prefix "bucket/logs/job-1/" overlaps "bucket/logs/job-1"`, FieldPath{"test_groups", 0, "additional_gcs_prefixes", 0}},
+				ConfigError{"test_group_1", "TestGroup", `gcs_prefix "bucket/logs" overlaps "bucket/logs/job-1"`, FieldPath{"test_groups", 0, "additional_gcs_prefixes", 1}},
+				ConfigError{"test_group_1", "TestGroup", `gcs_prefix "bucket/logs/job-1/old" overlaps "bucket/logs/job-1"`, FieldPath{"test_groups", 0, "additional_gcs_prefixes", 2}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := configpb.Configuration{TestGroups: test.input}
+			err := validateGcsPrefix(c)
+			if test.allowFiles {
+				err = eachTestGroup(c, validateTestGroupLocalPrefix)
+			}
+			if err != nil && len(test.expectedErrs) == 0 {
+				t.Fatalf("Unexpected Error: %v", err)
+			}
+
+			if len(test.expectedErrs) != 0 {
+				if err == nil {
+					t.Fatalf("Expected %v, but got no error", test.expectedErrs)
+				}
+
+				if mErr, ok := err.(*multierror.Error); ok {
+					if !reflect.DeepEqual(test.expectedErrs, mErr.Errors) {
+						t.Fatalf("Expected %v, but got: %v", test.expectedErrs, mErr.Errors)
+					}
+				} else {
+					t.Fatalf("Expected %v, but got: %v", test.expectedErrs, err)
+				}
+			}
+		})
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		candidates []string
+		expected   string
+	}{
+		{
+			name:  "No candidates",
+			input: "test_group_l",
+		},
+		{
+			name:       "Close typo",
+			input:      "test_group_l",
+			candidates: []string{"test_group_1", "something_else"},
+			expected:   "test_group_1",
+		},
+		{
+			name:       "Normalizes before comparing",
+			input:      "Test Group 1",
+			candidates: []string{"test-group-2", "test_group_1"},
+			expected:   "test_group_1",
+		},
+		{
+			name:       "Too far away",
+			input:      "test_group_1",
+			candidates: []string{"sig_release"},
+		},
+		{
+			name:       "Short names need to be very close",
+			input:      "ab",
+			candidates: []string{"xy"},
+		},
+		{
+			name:       "Ties pick the alphabetically first candidate",
+			input:      "dashboard_5",
+			candidates: []string{"dashboard_3", "dashboard_2", "dashboard_4"},
+			expected:   "dashboard_2",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := suggest(test.input, test.candidates); got != test.expected {
+				t.Fatalf("got %q, want %q", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestDuplicateNameError_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    DuplicateNameError
+		expected string
+	}{
+		{
+			name:     "Without originals",
+			input:    DuplicateNameError{"testgroup1", "TestGroup", nil, nil},
+			expected: "found duplicate name after normalizing: (TestGroup) testgroup1",
+		},
+		{
+			name:     "With originals",
+			input:    DuplicateNameError{"testgroup1", "TestGroup", []string{"test_group_1", "TEST GROUP 1", "test-group-1"}, nil},
+			expected: `found duplicate name after normalizing: (TestGroup) testgroup1 (from "test_group_1", "TEST GROUP 1", "test-group-1")`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.input.Error(); got != test.expected {
+				t.Fatalf("got %s, want %s", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestMissingEntityError_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    MissingEntityError
+		expected string
+	}{
+		{
+			name:     "Without suggestion",
+			input:    MissingEntityError{"test_group_l", "TestGroup", "", nil},
+			expected: "could not find the referenced (TestGroup) test_group_l",
+		},
+		{
+			name:     "With suggestion",
+			input:    MissingEntityError{"test_group_l", "TestGroup", "test_group_1", nil},
+			expected: `could not find the referenced (TestGroup) test_group_l; did you mean "test_group_1"?`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.input.Error(); got != test.expected {
+				t.Fatalf("got %s, want %s", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestUpdate_validateDashboardsHaveTabs(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        []*configpb.Dashboard
+		expectedErrs []error
+	}{
+		{
+			name: "Dashboard with tabs",
+			input: []*configpb.Dashboard{
+				{
+					Name: "dashboard_1",
+					DashboardTab: []*configpb.DashboardTab{
+						{
+							Name:          "tab_1",
+							TestGroupName: "test_group_1",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Dashboard without tabs; error",
+			input: []*configpb.Dashboard{
+				{
+					Name: "dashboard_1",
+				},
+			},
+			expectedErrs: []error{
+				ConfigError{"dashboard_1", "Dashboard", "A Dashboard must have at least one Dashboard Tab.", FieldPath{"dashboards", 0}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateDashboardsHaveTabs(configpb.Configuration{Dashboards: test.input})
+			if err != nil && len(test.expectedErrs) == 0 {
+				t.Fatalf("Unexpected Error: %v", err)
+			}
+
+			if len(test.expectedErrs) != 0 {
+				if err == nil {
+					t.Fatalf("Expected %v, but got no error", test.expectedErrs)
+				}
+
+				if mErr, ok := err.(*multierror.Error); ok {
+					if !reflect.DeepEqual(test.expectedErrs, mErr.Errors) {
+						t.Fatalf("Expected %v, but got: %v", test.expectedErrs, mErr.Errors)
+					}
+				} else {
+					t.Fatalf("Expected %v, but got: %v", test.expectedErrs, err)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdate_validateUniqueGcsPrefix(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        []*configpb.TestGroup
+		expectedErrs []error
+	}{
+		{
+			name: "Unique prefixes",
+			input: []*configpb.TestGroup{
+				{
+					Name:  "test_group_1",
+					Query: "bucket/logs/job-1",
+				},
+				{
+					Name:  "test_group_2",
+					Query: "bucket/logs/job-2",
+				},
+			},
+		},
+		{
+			name: "Invalid prefixes are ignored",
+			input: []*configpb.TestGroup{
+				{
+					Name: "test_group_1",
+				},
+				{
+					Name: "test_group_2",
+				},
+			},
+		},
+		{
+			name: "Shared prefixes; error",
+			input: []*configpb.TestGroup{
+				{
+					Name:  "test_group_1",
+					Query: "bucket/logs/job-1",
+				},
+				{
+					Name:  "test_group_2",
+					Query: "bucket/logs/job-1/",
+				},
+			},
+			expectedErrs: []error{
+				ConfigError{"test_group_2", "TestGroup", `gcs_prefix "bucket/logs/job-1/" is also used by test_group_1`, FieldPath{"test_groups", 1, "query"}},
+			},
+		},
+		{
+			name: "Shared local prefixes; error",
+			input: []*configpb.TestGroup{
+				{
+					Name:  "test_group_1",
+					Query: "file:///logs/job-1",
+				},
+				{
+					Name:  "test_group_2",
+					Query: "file://localhost/logs//job-1/",
+				},
+			},
+			expectedErrs: []error{
+				ConfigError{"test_group_2", "TestGroup", `gcs_prefix "file://localhost/logs//job-1/" is also used by test_group_1`, FieldPath{"test_groups", 1, "query"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateUniqueGcsPrefix(configpb.Configuration{TestGroups: test.input})
+			if err != nil && len(test.expectedErrs) == 0 {
+				t.Fatalf("Unexpected Error: %v", err)
+			}
+
+			if len(test.expectedErrs) != 0 {
+				if err == nil {
+					t.Fatalf("Expected %v, but got no error", test.expectedErrs)
+				}
+
+				if mErr, ok := err.(*multierror.Error); ok {
+					if !reflect.DeepEqual(test.expectedErrs, mErr.Errors) {
+						t.Fatalf("Expected %v, but got: %v", test.expectedErrs, mErr.Errors)
+					}
+				} else {
+					t.Fatalf("Expected %v, but got: %v", test.expectedErrs, err)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdate_validateAlertMailAddresses(t *testing.T) {
+	tests := []struct {
+		name         string
+		group        string
+		tab          string
+		expectedErrs []error
+	}{
+		{
+			name: "No alerting",
+		},
+		{
+			name:  "Valid addresses",
+			group: "a@example.com, Someone <b@example.com>",
+			tab:   "c@example.com",
+		},
+		{
+			name:  "Malformed group address; error",
+			group: "a@example.com,not-an-address",
+			expectedErrs: []error{
+				ConfigError{"test_group_1", "TestGroup", `alert_mail_to_addresses bad address "not-an-address": mail: missing '@' or angle-addr`, FieldPath{"test_groups", 0, "alert_mail_to_addresses"}},
+			},
+		},
+		{
+			name: "Malformed tab address; error",
+			tab:  "<a@example.com",
+			expectedErrs: []error{
+				ConfigError{"dashboard_1/tab_1", "DashboardTab", `alert_options.alert_mail_to_addresses bad address "<a@example.com": mail: unclosed angle-addr`, FieldPath{"dashboards", 0, "dashboard_tab", 0, "alert_options", "alert_mail_to_addresses"}},
+			},
+		},
+		{
+			name:  "Trailing comma; error",
+			group: "a@example.com,",
+			expectedErrs: []error{
+				ConfigError{"test_group_1", "TestGroup", "alert_mail_to_addresses contains an empty address", FieldPath{"test_groups", 0, "alert_mail_to_addresses"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := minimalConfig()
+			c.TestGroups[0].AlertMailToAddresses = test.group
+			if test.tab != "" {
+				c.Dashboards[0].DashboardTab[0].AlertOptions = &configpb.DashboardTabAlertOptions{
+					AlertMailToAddresses: test.tab,
+				}
+			}
+			err := validateAlertMailAddresses(c)
+			if got := errorList(err); !reflect.DeepEqual(test.expectedErrs, got) {
+				t.Fatalf("Expected %v, but got: %v", test.expectedErrs, got)
+			}
+		})
+	}
+}
+
+func TestUpdate_validateLinkTemplates(t *testing.T) {
+	tests := []struct {
+		name         string
+		template     *configpb.LinkTemplate
+		expectedErrs []error
+	}{
+		{
+			name: "No template",
+		},
+		{
+			name: "Known placeholders",
+			template: &configpb.LinkTemplate{
+				Url: "https://example.com/<gcs_prefix>/<changelist>?test=<test-name>",
+				Options: []*configpb.LinkOptionsTemplate{
+					{Key: "range", Value: "<start-custom-0>..<end-custom-0>"},
+				},
+			},
+		},
+		{
+			name: "Percent-encoded brackets are not placeholders",
+			template: &configpb.LinkTemplate{
+				Url: "https://example.com/search?q=%3Cnot-a-placeholder%3E&id=<test-id>",
+			},
+		},
+		{
+			name: "Unclosed placeholder; error",
+			template: &configpb.LinkTemplate{
+				Url: "https://example.com/<test-name",
+			},
+			expectedErrs: []error{
+				ConfigError{"dashboard_1/tab_1", "DashboardTab", "open_test_template.url: unclosed < at position 20", FieldPath{"dashboards", 0, "dashboard_tab", 0, "open_test_template", "url"}},
+			},
+		},
+		{
+			name: "Nested placeholder; error",
+			template: &configpb.LinkTemplate{
+				Url: "https://example.com/<<test-name>>",
+			},
+			expectedErrs: []error{
+				ConfigError{"dashboard_1/tab_1", "DashboardTab", "open_test_template.url: nested < at position 21", FieldPath{"dashboards", 0, "dashboard_tab", 0, "open_test_template", "url"}},
+			},
+		},
+		{
+			name: "Unmatched close; error",
+			template: &configpb.LinkTemplate{
+				Url: "https://example.com/test-name>",
+			},
+			expectedErrs: []error{
+				ConfigError{"dashboard_1/tab_1", "DashboardTab", "open_test_template.url: unmatched > at position 29", FieldPath{"dashboards", 0, "dashboard_tab", 0, "open_test_template", "url"}},
+			},
+		},
+		{
+			name: "Unknown placeholder in option; error",
+			template: &configpb.LinkTemplate{
+				Url: "https://example.com",
+				Options: []*configpb.LinkOptionsTemplate{
+					{Key: "title", Value: "<test_name> failed"},
+				},
+			},
+			expectedErrs: []error{
+				ConfigError{"dashboard_1/tab_1", "DashboardTab", "open_test_template.options[title]: unknown placeholder <test_name>", FieldPath{"dashboards", 0, "dashboard_tab", 0, "open_test_template", "options", 0, "value"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := minimalConfig()
+			c.Dashboards[0].DashboardTab[0].OpenTestTemplate = test.template
+			err := validateLinkTemplates(c)
+			if got := errorList(err); !reflect.DeepEqual(test.expectedErrs, got) {
+				t.Fatalf("Expected %v, but got: %v", test.expectedErrs, got)
+			}
+		})
+	}
+}
+
+func TestUpdate_validateBaseOptions(t *testing.T) {
+	tests := []struct {
+		name         string
+		baseOptions  string
+		expectedErrs []error
+	}{
+		{
+			name: "No base options",
+		},
+		{
+			name:        "Valid filters",
+			baseOptions: "include-filter-by-regex=^foo&exclude-filter-by-regex=bar%24&width=10",
+		},
+		{
+			name:        "Invalid query; error",
+			baseOptions: "%z",
+			expectedErrs: []error{
+				ConfigError{"dashboard_1/tab_1", "DashboardTab", `parse "%z": invalid URL escape "%z"`, FieldPath{"dashboards", 0, "dashboard_tab", 0, "base_options"}},
+			},
+		},
+		{
+			name:        "Invalid include regex; error",
+			baseOptions: "include-filter-by-regex=%5Bfoo",
+			expectedErrs: []error{
+				ConfigError{"dashboard_1/tab_1", "DashboardTab", "bad include-filter-by-regex=[foo: error parsing regexp: missing closing ]: `[foo`", FieldPath{"dashboards", 0, "dashboard_tab", 0, "base_options"}},
+			},
+		},
+		{
+			name:        "Invalid exclude regex; error",
+			baseOptions: "exclude-filter-by-regex=foo(",
+			expectedErrs: []error{
+				ConfigError{"dashboard_1/tab_1", "DashboardTab", "bad exclude-filter-by-regex=foo(: error parsing regexp: missing closing ): `foo(`", FieldPath{"dashboards", 0, "dashboard_tab", 0, "base_options"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := minimalConfig()
+			c.Dashboards[0].DashboardTab[0].BaseOptions = test.baseOptions
+			err := validateBaseOptions(c)
+			if got := errorList(err); !reflect.DeepEqual(test.expectedErrs, got) {
+				t.Fatalf("Expected %v, but got: %v", test.expectedErrs, got)
+			}
+		})
+	}
+}
+
+func TestUpdate_validateRegexes(t *testing.T) {
+	tests := []struct {
+		name         string
+		mutate       func(c *configpb.Configuration)
+		expectedErrs []error
+	}{
+		{
+			name: "No regexes",
+		},
+		{
+			name: "Valid regexes",
+			mutate: func(c *configpb.Configuration) {
+				c.TestGroups[0].CommitOverrideLabelPattern = `^commit-(\w+)$`
+				c.TestGroups[0].TestMethodMatchRegex = "^Test"
+				c.TestGroups[0].TestMetadataOptions = []*configpb.TestMetadataOptions{
+					{TestNameRegex: "e2e", MessageRegex: "timed? out"},
+				}
+				c.Dashboards[0].DashboardTab[0].TabularNamesRegex = `^(\w+)\.(\w+)$`
+			},
+		},
+		{
+			name: "Invalid test group regexes; error",
+			mutate: func(c *configpb.Configuration) {
+				c.TestGroups[0].CommitOverrideLabelPattern = "commit-("
+				c.TestGroups[0].TestMethodMatchRegex = "[Test"
+				c.TestGroups[0].TestMetadataOptions = []*configpb.TestMetadataOptions{
+					{TestNameRegex: "fine"},
+					{TestNameRegex: "*bad", MessageRegex: "bad)"},
+				}
+			},
+			expectedErrs: []error{
+				ConfigError{"test_group_1", "TestGroup", `commit_override_label_pattern "commit-(" error parsing regexp: missing closing ): ` + "`commit-(`", FieldPath{"test_groups", 0, "commit_override_label_pattern"}},
+				ConfigError{"test_group_1", "TestGroup", `test_method_match_regex "[Test" error parsing regexp: missing closing ]: ` + "`[Test`", FieldPath{"test_groups", 0, "test_method_match_regex"}},
+				ConfigError{"test_group_1", "TestGroup", `test_metadata_options[1].test_name_regex "*bad" error parsing regexp: missing argument to repetition operator: ` + "`*`", FieldPath{"test_groups", 0, "test_metadata_options", 1, "test_name_regex"}},
+				ConfigError{"test_group_1", "TestGroup", `test_metadata_options[1].message_regex "bad)" error parsing regexp: unexpected ): ` + "`bad)`", FieldPath{"test_groups", 0, "test_metadata_options", 1, "message_regex"}},
+			},
+		},
+		{
+			name: "Invalid tabular names regex; error",
+			mutate: func(c *configpb.Configuration) {
+				c.Dashboards[0].DashboardTab[0].TabularNamesRegex = "(a"
+			},
+			expectedErrs: []error{
+				ConfigError{"dashboard_1/tab_1", "DashboardTab", `tabular_names_regex "(a" error parsing regexp: missing closing ): ` + "`(a`", FieldPath{"dashboards", 0, "dashboard_tab", 0, "tabular_names_regex"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := minimalConfig()
+			if test.mutate != nil {
+				test.mutate(&c)
+			}
+			err := validateRegexes(c)
+			if got := errorList(err); !reflect.DeepEqual(test.expectedErrs, got) {
+				t.Fatalf("Expected %v, but got: %v", test.expectedErrs, got)
+			}
+		})
+	}
+}
+
+func TestRegexCache(t *testing.T) {
+	var cache regexCache
+	for i := 0; i < 2; i++ {
+		if err := cache.compile("^ok$"); err != nil {
+			t.Errorf("compile(^ok$) got unexpected error: %v", err)
+		}
+		if err := cache.compile("(bad"); err == nil {
+			t.Error("compile((bad) did not return an error")
+		}
+	}
+	if n := len(cache.errs); n != 2 {
+		t.Errorf("compile() cached %d regexes, want 2", n)
+	}
+	for i := 0; i < maxCachedRegexes; i++ {
+		cache.compile(fmt.Sprintf("^%d$", i))
+	}
+	if n := len(cache.errs); n > maxCachedRegexes {
+		t.Errorf("compile() cached %d regexes, want at most %d", n, maxCachedRegexes)
+	}
+}
+
+func TestValidateTestGroup(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        *configpb.TestGroup
+		expectedErrs []error
+	}{
+		{
+			name: "Valid Test Group",
+			input: &configpb.TestGroup{
+				Name:  "test_group_1",
+				Query: "bucket/logs/job-1",
+			},
+		},
+		{
+			name: "Unreferenced Test Group is not checked",
+			input: &configpb.TestGroup{
+				Name: "test_group_1",
+			},
+		},
+		{
+			name: "Local rules; errors",
+			input: &configpb.TestGroup{
+				Name:                 "test_group_1",
+				Query:                "gs://bucket/logs/job-1",
+				AlertMailToAddresses: "a@example.com,",
+			},
+			expectedErrs: []error{
+				ConfigError{"test_group_1", "TestGroup", `gcs_prefix "gs://bucket/logs/job-1" must not start with gs://`, FieldPath{"query"}},
+				ConfigError{"test_group_1", "TestGroup", "alert_mail_to_addresses contains an empty address", FieldPath{"alert_mail_to_addresses"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateTestGroup(test.input)
+			if got := errorList(err); !reflect.DeepEqual(test.expectedErrs, got) {
+				t.Fatalf("Expected %v, but got: %v", test.expectedErrs, got)
+			}
+		})
+	}
+}
+
+func TestValidateDashboard(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        *configpb.Dashboard
+		expectedErrs []error
+	}{
+		{
+			name: "Missing Test Group is not checked",
+			input: &configpb.Dashboard{
+				Name: "dashboard_1",
+				DashboardTab: []*configpb.DashboardTab{
+					{
+						Name:          "tab_1",
+						TestGroupName: "does_not_exist",
+					},
+				},
+			},
+		},
+		{
+			name: "No tabs; error",
+			input: &configpb.Dashboard{
+				Name: "dashboard_1",
+			},
+			expectedErrs: []error{
+				ConfigError{"dashboard_1", "Dashboard", "A Dashboard must have at least one Dashboard Tab.", nil},
+			},
+		},
+		{
+			name: "Local rules; errors",
+			input: &configpb.Dashboard{
+				Name: "dashboard_1",
+				DashboardTab: []*configpb.DashboardTab{
+					{
+						Name:          "tab_1",
+						TestGroupName: "test_group_1",
+						OpenTestTemplate: &configpb.LinkTemplate{
+							Url: "https://example.com/<test-name",
+						},
+					},
+					{
+						Name: "Tab 1",
+						AlertOptions: &configpb.DashboardTabAlertOptions{
+							AlertMailToAddresses: "a@example.com,",
+						},
+					},
+				},
+			},
+			expectedErrs: []error{
+				DuplicateNameError{"tab1", "DashboardTab in dashboard_1", []string{"tab_1", "Tab 1"}, FieldPath{"dashboard_tab", 1, "name"}},
+				MissingFieldError{"dashboard_1/Tab 1: TestGroupName", FieldPath{"dashboard_tab", 1, "test_group_name"}},
+				ConfigError{"dashboard_1/Tab 1", "DashboardTab", "alert_options.alert_mail_to_addresses contains an empty address", FieldPath{"dashboard_tab", 1, "alert_options", "alert_mail_to_addresses"}},
+				ConfigError{"dashboard_1/tab_1", "DashboardTab", "open_test_template.url: unclosed < at position 20", FieldPath{"dashboard_tab", 0, "open_test_template", "url"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateDashboard(test.input)
+			if got := errorList(err); !reflect.DeepEqual(test.expectedErrs, got) {
+				t.Fatalf("Expected %v, but got: %v", test.expectedErrs, got)
+			}
+		})
+	}
+}
+
+func TestUpdate_validateTestGroupRetention(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        *configpb.TestGroup
+		expectedErrs []error
+	}{
+		{
+			name:  "Unset fields use defaults",
+			input: &configpb.TestGroup{Name: "test_group_1"},
+		},
+		{
+			name: "Positive values",
+			input: &configpb.TestGroup{
+				Name:             "test_group_1",
+				DaysOfResults:    30,
+				NumColumnsRecent: 10,
+			},
+		},
+		{
+			name: "Negative values; error",
+			input: &configpb.TestGroup{
+				Name:             "test_group_1",
+				DaysOfResults:    -1,
+				NumColumnsRecent: -5,
+			},
+			expectedErrs: []error{
+				ConfigError{"test_group_1", "TestGroup", "days_of_results must be >= 0, got -1", FieldPath{"days_of_results"}},
+				ConfigError{"test_group_1", "TestGroup", "num_columns_recent must be > 0 when set, got -5", FieldPath{"num_columns_recent"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateTestGroupRetention(test.input)
+			if got := errorList(err); !reflect.DeepEqual(test.expectedErrs, got) {
+				t.Fatalf("Expected %v, but got: %v", test.expectedErrs, got)
+			}
+		})
+	}
+}
+
+func TestUpdate_validateTestGroupNumColumnsRecent(t *testing.T) {
+	tests := []struct {
+		name        string
+		cols        int32
+		expectedErr error
+	}{
+		{
+			name: "Unset",
+		},
+		{
+			name: "At limit",
+			cols: 10000,
+		},
+		{
+			name:        "Over limit; error",
+			cols:        10001,
+			expectedErr: ConfigError{"test_group_1", "TestGroup", "num_columns_recent must be <= 10000, got 10001", FieldPath{"num_columns_recent"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateTestGroupNumColumnsRecent(&configpb.TestGroup{Name: "test_group_1", NumColumnsRecent: test.cols})
+			if !reflect.DeepEqual(test.expectedErr, err) {
+				t.Fatalf("Expected %v, but got: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestUpdate_validateTestGroupColumnHeaders(t *testing.T) {
+	label := func(v string) *configpb.TestGroup_ColumnHeader {
+		return &configpb.TestGroup_ColumnHeader{ColumnHeaderSource: &configpb.TestGroup_ColumnHeader_Label{Label: v}}
+	}
+	value := func(v string) *configpb.TestGroup_ColumnHeader {
+		return &configpb.TestGroup_ColumnHeader{ColumnHeaderSource: &configpb.TestGroup_ColumnHeader_ConfigurationValue{ConfigurationValue: v}}
+	}
+	tooMany := make([]*configpb.TestGroup_ColumnHeader, 11)
+	for i := range tooMany {
+		tooMany[i] = value(fmt.Sprintf("key-%d", i))
+	}
+	tests := []struct {
+		name         string
+		headers      []*configpb.TestGroup_ColumnHeader
+		expectedErrs []error
+	}{
+		{
+			name: "No headers",
+		},
+		{
+			name:    "Valid headers",
+			headers: []*configpb.TestGroup_ColumnHeader{value("Commit"), value("infra-commit"), label("Commit")},
+		},
+		{
+			name:    "No selector",
+			headers: []*configpb.TestGroup_ColumnHeader{value("Commit"), {}},
+			expectedErrs: []error{
+				ConfigError{"test_group_1", "TestGroup", "column_header[1] must set one of label, property or configuration_value", FieldPath{"column_header", 1}},
+			},
+		},
+		{
+			name: "Empty value",
+			headers: []*configpb.TestGroup_ColumnHeader{
+				{ColumnHeaderSource: &configpb.TestGroup_ColumnHeader_Property{}},
+			},
+			expectedErrs: []error{
+				ConfigError{"test_group_1", "TestGroup", "column_header[0].property must not be empty", FieldPath{"column_header", 0, "property"}},
+			},
+		},
+		{
+			name:    "Duplicate after normalizing",
+			headers: []*configpb.TestGroup_ColumnHeader{value("infra-commit"), value("Commit"), value("Infra Commit")},
+			expectedErrs: []error{
+				ConfigError{"test_group_1", "TestGroup", `column_header[2].configuration_value "Infra Commit" duplicates column_header[0]`, FieldPath{"column_header", 2, "configuration_value"}},
+			},
+		},
+		{
+			name:    "At limit",
+			headers: tooMany[:10],
+		},
+		{
+			name:    "Over limit",
+			headers: tooMany,
+			expectedErrs: []error{
+				ConfigError{"test_group_1", "TestGroup", "column_header must have at most 10 entries, got 11", FieldPath{"column_header"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateTestGroupColumnHeaders(&configpb.TestGroup{Name: "test_group_1", ColumnHeader: test.headers})
+			if !reflect.DeepEqual(test.expectedErrs, errorList(err)) {
+				t.Fatalf("Expected %v, but got: %v", test.expectedErrs, err)
+			}
+		})
+	}
+}
+
+func TestUpdate_validateTestGroupNameFormat(t *testing.T) {
+	elems := func(n int) []*configpb.TestNameConfig_NameElement {
+		var list []*configpb.TestNameConfig_NameElement
+		for i := 0; i < n; i++ {
+			list = append(list, &configpb.TestNameConfig_NameElement{TargetConfig: "Tests name"})
+		}
+		return list
+	}
+	tests := []struct {
+		name        string
+		config      *configpb.TestNameConfig
+		expectedErr error
+	}{
+		{
+			name: "Unset",
+		},
+		{
+			name:   "Matching counts",
+			config: &configpb.TestNameConfig{NameFormat: "%s [%s]", NameElements: elems(2)},
+		},
+		{
+			name:   "Escaped percent",
+			config: &configpb.TestNameConfig{NameFormat: "%s 100%% %%s", NameElements: elems(1)},
+		},
+		{
+			name:   "Empty",
+			config: &configpb.TestNameConfig{},
+		},
+		{
+			name:        "Empty format with elements",
+			config:      &configpb.TestNameConfig{NameElements: elems(1)},
+			expectedErr: ConfigError{"test_group_1", "TestGroup", `test_name_config.name_format "" has 0 %s verbs, but there are 1 name_elements`, FieldPath{"test_name_config", "name_format"}},
+		},
+		{
+			name:        "Too few elements",
+			config:      &configpb.TestNameConfig{NameFormat: "%s: %s", NameElements: elems(1)},
+			expectedErr: ConfigError{"test_group_1", "TestGroup", `test_name_config.name_format "%s: %s" has 2 %s verbs, but there are 1 name_elements`, FieldPath{"test_name_config", "name_format"}},
+		},
+		{
+			name:        "Too many elements",
+			config:      &configpb.TestNameConfig{NameFormat: "%%s", NameElements: elems(1)},
+			expectedErr: ConfigError{"test_group_1", "TestGroup", `test_name_config.name_format "%%s" has 0 %s verbs, but there are 1 name_elements`, FieldPath{"test_name_config", "name_format"}},
+		},
+		{
+			name:        "Other verb",
+			config:      &configpb.TestNameConfig{NameFormat: "%s-%d", NameElements: elems(2)},
+			expectedErr: ConfigError{"test_group_1", "TestGroup", `test_name_config.name_format "%s-%d" has unsupported verb %d at position 3, only %s and %% are allowed`, FieldPath{"test_name_config", "name_format"}},
+		},
+		{
+			name:        "Trailing percent",
+			config:      &configpb.TestNameConfig{NameFormat: "%s %", NameElements: elems(1)},
+			expectedErr: ConfigError{"test_group_1", "TestGroup", `test_name_config.name_format "%s %" ends with an incomplete verb %`, FieldPath{"test_name_config", "name_format"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateTestGroupNameFormat(&configpb.TestGroup{Name: "test_group_1", TestNameConfig: test.config})
+			if !reflect.DeepEqual(test.expectedErr, err) {
+				t.Fatalf("Expected %v, but got: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestUpdate_validateAlertThresholds(t *testing.T) {
+	tests := []struct {
+		name         string
+		group        *configpb.TestGroup
+		tab          *configpb.DashboardTabAlertOptions
+		expectedErrs []error
+	}{
+		{
+			name:  "No alerting",
+			group: &configpb.TestGroup{},
+		},
+		{
+			name: "Tab alerts later than group",
+			group: &configpb.TestGroup{
+				NumFailuresToAlert:      1,
+				AlertStaleResultsHours:  12,
+				NumPassesToDisableAlert: 1,
+			},
+			tab: &configpb.DashboardTabAlertOptions{
+				NumFailuresToAlert:      3,
+				AlertStaleResultsHours:  24,
+				NumPassesToDisableAlert: 2,
+			},
+		},
+		{
+			name:  "Only the tab alerts",
+			group: &configpb.TestGroup{},
+			tab: &configpb.DashboardTabAlertOptions{
+				NumFailuresToAlert:      1,
+				NumPassesToDisableAlert: 1,
+			},
+		},
+		{
+			name: "Tab alerts sooner than group; error",
+			group: &configpb.TestGroup{
+				NumFailuresToAlert:     5,
+				AlertStaleResultsHours: 24,
+			},
+			tab: &configpb.DashboardTabAlertOptions{
+				NumFailuresToAlert:     1,
+				AlertStaleResultsHours: 12,
+			},
+			expectedErrs: []error{
+				ConfigError{"dashboard_1/tab_1", "DashboardTab", "alert_options.num_failures_to_alert 1 is lower than 5 in TestGroup test_group_1", FieldPath{"dashboards", 0, "dashboard_tab", 0, "alert_options", "num_failures_to_alert"}},
+				ConfigError{"dashboard_1/tab_1", "DashboardTab", "alert_options.alert_stale_results_hours 12 is lower than 24 in TestGroup test_group_1", FieldPath{"dashboards", 0, "dashboard_tab", 0, "alert_options", "alert_stale_results_hours"}},
+			},
+		},
+		{
+			name: "Group passes without failures; error",
+			group: &configpb.TestGroup{
+				NumPassesToDisableAlert: 2,
+			},
+			expectedErrs: []error{
+				ConfigError{"test_group_1", "TestGroup", "num_passes_to_disable_alert is set without num_failures_to_alert", FieldPath{"test_groups", 0, "num_passes_to_disable_alert"}},
+			},
+		},
+		{
+			name:  "Tab passes without failures; error",
+			group: &configpb.TestGroup{},
+			tab: &configpb.DashboardTabAlertOptions{
+				NumPassesToDisableAlert: 2,
+			},
+			expectedErrs: []error{
+				ConfigError{"dashboard_1/tab_1", "DashboardTab", "alert_options.num_passes_to_disable_alert is set without num_failures_to_alert in the tab or TestGroup test_group_1", FieldPath{"dashboards", 0, "dashboard_tab", 0, "alert_options", "num_passes_to_disable_alert"}},
+			},
+		},
+		{
+			name: "Tab passes with group failures",
+			group: &configpb.TestGroup{
+				NumFailuresToAlert: 2,
+			},
+			tab: &configpb.DashboardTabAlertOptions{
+				NumPassesToDisableAlert: 2,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := minimalConfig()
+			test.group.Name = c.TestGroups[0].Name
+			c.TestGroups[0] = test.group
+			c.Dashboards[0].DashboardTab[0].AlertOptions = test.tab
+			err := validateAlertThresholds(c)
+			if got := errorList(err); !reflect.DeepEqual(test.expectedErrs, got) {
+				t.Fatalf("Expected %v, but got: %v", test.expectedErrs, got)
+			}
+		})
+	}
+}
+
+func TestUpdate_validateTabAlertsEnabled(t *testing.T) {
+	tests := []struct {
+		name         string
+		group        *configpb.TestGroup
+		tab          *configpb.DashboardTabAlertOptions
+		expectedErrs []error
+	}{
+		{
+			name:  "No alerting",
+			group: &configpb.TestGroup{},
+		},
+		{
+			name: "Both alert",
+			group: &configpb.TestGroup{
+				NumFailuresToAlert: 1,
+			},
+			tab: &configpb.DashboardTabAlertOptions{
+				NumFailuresToAlert: 3,
+			},
+		},
+		{
+			name: "Only the group alerts",
+			group: &configpb.TestGroup{
+				NumFailuresToAlert: 1,
+			},
+			tab: &configpb.DashboardTabAlertOptions{
+				AlertMailToAddresses: "tab@example.com",
+			},
+		},
+		{
+			name:  "Tab only sets stale hours",
+			group: &configpb.TestGroup{},
+			tab: &configpb.DashboardTabAlertOptions{
+				AlertStaleResultsHours: 12,
+			},
+		},
+		{
+			name:  "Only the tab alerts; warning",
+			group: &configpb.TestGroup{},
+			tab: &configpb.DashboardTabAlertOptions{
+				NumFailuresToAlert:      1,
+				NumPassesToDisableAlert: 1,
+			},
+			expectedErrs: []error{
+				ConfigError{"dashboard_1/tab_1", "DashboardTab", "alert_options.num_failures_to_alert has no effect; TestGroup test_group_1 disables alerts without num_failures_to_alert", FieldPath{"dashboards", 0, "dashboard_tab", 0, "alert_options", "num_failures_to_alert"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := minimalConfig()
+			test.group.Name = c.TestGroups[0].Name
+			c.TestGroups[0] = test.group
+			c.Dashboards[0].DashboardTab[0].AlertOptions = test.tab
+			err := validateTabAlertsEnabled(c)
+			if got := errorList(err); !reflect.DeepEqual(test.expectedErrs, got) {
+				t.Fatalf("Expected %v, but got: %v", test.expectedErrs, got)
+			}
+		})
+	}
+}
+
+func TestUpdate_validateDashboardGroupPrefixes(t *testing.T) {
+	tests := []struct {
+		name         string
+		groups       []*configpb.DashboardGroup
+		exempt       []string
+		expectedErrs []error
+	}{
+		{
+			name: "Dashboards start with the group name",
+			groups: []*configpb.DashboardGroup{
+				{Name: "sig-node", DashboardNames: []string{"sig-node-kubelet", "SIG Node Containerd"}},
+			},
+		},
+		{
+			name: "Dashboard without the group prefix; error",
+			groups: []*configpb.DashboardGroup{
+				{Name: "sig-node", DashboardNames: []string{"sig-node-kubelet", "sig-storage-csi"}},
+			},
+			expectedErrs: []error{
+				ConfigError{"sig-node", "DashboardGroup", "Dashboard sig-storage-csi must start with the Dashboard Group name", FieldPath{"dashboard_groups", 0, "dashboard_names", 1}},
+			},
+		},
+		{
+			name: "Nested groups use the longest prefix",
+			groups: []*configpb.DashboardGroup{
+				{Name: "sig", DashboardNames: []string{"sig-release"}},
+				{Name: "sig-node", DashboardNames: []string{"sig-node-kubelet"}},
+				{Name: "sig-node-e2e", DashboardNames: []string{"sig-node-e2e-gce"}},
+			},
+		},
+		{
+			name: "Dashboard in a shorter nested group; error",
+			groups: []*configpb.DashboardGroup{
+				{Name: "sig", DashboardNames: []string{"sig-release", "sig-node-kubelet"}},
+				{Name: "sig-node", DashboardNames: []string{"sig-node-containerd"}},
+				{Name: "sig-node-e2e"},
+			},
+			expectedErrs: []error{
+				ConfigError{"sig", "DashboardGroup", "Dashboard sig-node-kubelet belongs in Dashboard Group sig-node, the longest matching prefix", FieldPath{"dashboard_groups", 0, "dashboard_names", 1}},
+			},
+		},
+		{
+			name: "Longest prefix skips a group that is a shorter prefix; error",
+			groups: []*configpb.DashboardGroup{
+				{Name: "sig-node", DashboardNames: []string{"sig-node-e2e-gce"}},
+				{Name: "sig-node-e2e", DashboardNames: []string{"sig-node-e2e-aws"}},
+				{Name: "sig", DashboardNames: []string{"sig-node-e2e-kind"}},
+			},
+			expectedErrs: []error{
+				ConfigError{"sig-node", "DashboardGroup", "Dashboard sig-node-e2e-gce belongs in Dashboard Group sig-node-e2e, the longest matching prefix", FieldPath{"dashboard_groups", 0, "dashboard_names", 0}},
+				ConfigError{"sig", "DashboardGroup", "Dashboard sig-node-e2e-kind belongs in Dashboard Group sig-node-e2e, the longest matching prefix", FieldPath{"dashboard_groups", 2, "dashboard_names", 0}},
+			},
+		},
+		{
+			name: "Dashboard in both nested groups is left to DashboardInMultipleGroups",
+			groups: []*configpb.DashboardGroup{
+				{Name: "sig", DashboardNames: []string{"sig-node-kubelet"}},
+				{Name: "sig-node", DashboardNames: []string{"sig-node-kubelet"}},
+			},
+		},
+		{
+			name: "Exempt groups are not checked",
+			groups: []*configpb.DashboardGroup{
+				{Name: "sig", DashboardNames: []string{"sig-node-kubelet"}},
+				{Name: "sig-node"},
+				{Name: "Release Blocking", DashboardNames: []string{"sig-release-master-blocking"}},
+			},
+			exempt: []string{"SIG", "release-blocking"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := configpb.Configuration{DashboardGroups: test.groups}
+			err := validateDashboardGroupPrefixes(c, ValidateOptions{PrefixExemptGroups: test.exempt})
+			if got := errorList(err); !reflect.DeepEqual(test.expectedErrs, got) {
+				t.Fatalf("Expected %v, but got: %v", test.expectedErrs, got)
+			}
+		})
+	}
+}
+
+func TestUpdate_validateLengths(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       configpb.Configuration
+		opts         ValidateOptions
+		expectedErrs []error
+	}{
+		{
+			name: "Names and descriptions within the default limits",
+			config: configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: strings.Repeat("g", DefaultMaxNameBytes)}},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: strings.Repeat("d", DefaultMaxNameBytes),
+						DashboardTab: []*configpb.DashboardTab{
+							{Name: "tab", Description: strings.Repeat("x", DefaultMaxDescriptionBytes)},
+						},
+					},
+				},
+				DashboardGroups: []*configpb.DashboardGroup{{Name: "group"}},
+			},
+		},
+		{
+			name: "Names over the default limit; error",
+			config: configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "tg"}, {Name: strings.Repeat("g", DefaultMaxNameBytes+1)}},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{Name: strings.Repeat("t", DefaultMaxNameBytes+1)},
+						},
+					},
+				},
+				DashboardGroups: []*configpb.DashboardGroup{{Name: strings.Repeat("g", 300)}},
+			},
+			expectedErrs: []error{
+				ConfigError{strings.Repeat("g", DefaultMaxNameBytes+1), "TestGroup", "name is 257 bytes, more than 256", FieldPath{"test_groups", 1, "name"}},
+				ConfigError{"dash/" + strings.Repeat("t", DefaultMaxNameBytes+1), "DashboardTab", "name is 257 bytes, more than 256", FieldPath{"dashboards", 0, "dashboard_tab", 0, "name"}},
+				ConfigError{strings.Repeat("g", 300), "DashboardGroup", "name is 300 bytes, more than 256", FieldPath{"dashboard_groups", 0, "name"}},
+			},
+		},
+		{
+			name: "Description over the default limit; error",
+			config: configpb.Configuration{
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{Name: "tab", Description: strings.Repeat("x", DefaultMaxDescriptionBytes+1)},
+						},
+					},
+				},
+			},
+			expectedErrs: []error{
+				ConfigError{"dash/tab", "DashboardTab", "description is 4097 bytes, more than 4096", FieldPath{"dashboards", 0, "dashboard_tab", 0, "description"}},
+			},
+		},
+		{
+			name: "Limits count bytes, not characters; error",
+			config: configpb.Configuration{
+				Dashboards: []*configpb.Dashboard{{Name: "dashbøard"}},
+			},
+			opts: ValidateOptions{MaxNameBytes: 9, MaxDescriptionBytes: 1},
+			expectedErrs: []error{
+				ConfigError{"dashbøard", "Dashboard", "name is 10 bytes, more than 9", FieldPath{"dashboards", 0, "name"}},
+			},
+		},
+		{
+			name: "Configured limits",
+			config: configpb.Configuration{
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: strings.Repeat("d", 500),
+						DashboardTab: []*configpb.DashboardTab{
+							{Name: "tab", Description: "too long"},
+						},
+					},
+				},
+			},
+			opts: ValidateOptions{MaxNameBytes: 500, MaxDescriptionBytes: 5},
+			expectedErrs: []error{
+				ConfigError{strings.Repeat("d", 500) + "/tab", "DashboardTab", "description is 8 bytes, more than 5", FieldPath{"dashboards", 0, "dashboard_tab", 0, "description"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateLengths(test.config, test.opts)
+			if got := errorList(err); !reflect.DeepEqual(test.expectedErrs, got) {
+				t.Fatalf("Expected %v, but got: %v", test.expectedErrs, got)
+			}
+		})
+	}
+}
+
+func TestUpdate_validateNamesLinkable(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       configpb.Configuration
+		expectedErrs []error
+	}{
+		{
+			name: "Names with letters and digits",
+			config: configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "_tg_"}},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name:         "--dash--",
+						DashboardTab: []*configpb.DashboardTab{{Name: "1"}},
+					},
+				},
+				DashboardGroups: []*configpb.DashboardGroup{{Name: "group"}},
+			},
+		},
+		{
+			name: "Names which normalize to nothing; error",
+			config: configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "tg"}, {Name: "!!!"}},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name:         "!!!",
+						DashboardTab: []*configpb.DashboardTab{{Name: "tab"}, {Name: "- -"}},
+					},
+				},
+				DashboardGroups: []*configpb.DashboardGroup{{Name: "ø"}},
+			},
+			expectedErrs: []error{
+				ConfigError{"!!!", "TestGroup", `name "!!!" must contain a letter or digit`, FieldPath{"test_groups", 1, "name"}},
+				ConfigError{"!!!", "Dashboard", `name "!!!" must contain a letter or digit`, FieldPath{"dashboards", 0, "name"}},
+				ConfigError{"!!!/- -", "DashboardTab", `name "- -" must contain a letter or digit`, FieldPath{"dashboards", 0, "dashboard_tab", 1, "name"}},
+				ConfigError{"ø", "DashboardGroup", `name "ø" must contain a letter or digit`, FieldPath{"dashboard_groups", 0, "name"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateNamesLinkable(test.config)
+			if got := errorList(err); !reflect.DeepEqual(test.expectedErrs, got) {
+				t.Fatalf("Expected %v, but got: %v", test.expectedErrs, got)
+			}
+		})
+	}
+}
+
+func TestUpdate_validateTestGroupStatusMapping(t *testing.T) {
+	tests := []struct {
+		name         string
+		mapping      *configpb.TestGroup_StatusMapping
+		expectedErrs []error
+	}{
+		{
+			name: "No mapping",
+		},
+		{
+			name:    "Unset categories",
+			mapping: &configpb.TestGroup_StatusMapping{},
+		},
+		{
+			name: "Row statuses",
+			mapping: &configpb.TestGroup_StatusMapping{
+				Failure: test_status.TestStatus_FLAKY,
+				Error:   test_status.TestStatus_TOOL_FAIL,
+				Skipped: test_status.TestStatus_PASS,
+			},
+		},
+		{
+			name: "Statuses rows cannot hold; error",
+			mapping: &configpb.TestGroup_StatusMapping{
+				Failure: test_status.TestStatus_BUILD_FAIL,
+				Error:   test_status.TestStatus_TOOL_FAIL,
+				Skipped: test_status.TestStatus_RUNNING,
+			},
+			expectedErrs: []error{
+				ConfigError{"tg", "TestGroup", "status_mapping.failure cannot be BUILD_FAIL, which grid rows do not support", FieldPath{"status_mapping", "failure"}},
+				ConfigError{"tg", "TestGroup", "status_mapping.skipped cannot be RUNNING, which grid rows do not support", FieldPath{"status_mapping", "skipped"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateTestGroupStatusMapping(&configpb.TestGroup{Name: "tg", StatusMapping: test.mapping})
+			if got := errorList(err); !reflect.DeepEqual(test.expectedErrs, got) {
+				t.Fatalf("Expected %v, but got: %v", test.expectedErrs, got)
+			}
+		})
+	}
+}
+
+func TestUpdate_validatePauseReason(t *testing.T) {
+	tests := []struct {
+		name         string
+		dash         *configpb.Dashboard
+		expectedErrs []error
+	}{
+		{
+			name: "Unpaused dashboard",
+			dash: &configpb.Dashboard{Name: "dash"},
+		},
+		{
+			name: "Paused with a reason",
+			dash: &configpb.Dashboard{Name: "dash", Paused: true, PausedReason: "Migrating jobs, see #123"},
+		},
+		{
+			name: "Paused without a reason; error",
+			dash: &configpb.Dashboard{Name: "dash", Paused: true},
+			expectedErrs: []error{
+				ConfigError{"dash", "Dashboard", "A paused Dashboard must have a paused_reason.", FieldPath{"paused_reason"}},
+			},
+		},
+		{
+			name: "Paused with a blank reason; error",
+			dash: &configpb.Dashboard{Name: "dash", Paused: true, PausedReason: " \t"},
+			expectedErrs: []error{
+				ConfigError{"dash", "Dashboard", "A paused Dashboard must have a paused_reason.", FieldPath{"paused_reason"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validatePauseReason(test.dash)
+			if got := errorList(err); !reflect.DeepEqual(test.expectedErrs, got) {
+				t.Fatalf("Expected %v, but got: %v", test.expectedErrs, got)
+			}
+		})
+	}
+}
+
+func TestUpdate_validateNotifications(t *testing.T) {
+	long := strings.Repeat("x", MaxNotificationSummaryBytes+1)
+	tests := []struct {
+		name          string
+		notifications []*configpb.Notification
+		expectedErrs  []string
+	}{
+		{
+			name: "No notifications",
+		},
+		{
+			name: "Valid notifications",
+			notifications: []*configpb.Notification{
+				{Summary: "Tests are migrating"},
+				{Summary: "Flaky until the fix rolls out", ContextLink: "https://github.com/org/repo/issues/123"},
+				{Summary: strings.Repeat("x", MaxNotificationSummaryBytes), ContextLink: "http://example.com"},
+			},
+		},
+		{
+			name: "Several invalid notifications; error",
+			notifications: []*configpb.Notification{
+				{Summary: "fine", ContextLink: "https://example.com"},
+				{ContextLink: "https://example.com"},
+				{Summary: " \t"},
+				{Summary: long},
+				{Summary: "relative", ContextLink: "/issues/123"},
+				{Summary: "not web", ContextLink: "ftp://example.com/file"},
+				{Summary: "no host", ContextLink: "https:///path"},
+			},
+			expectedErrs: []string{
+				"summary must not be empty",
+				"summary must not be empty",
+				fmt.Sprintf("summary is %d bytes, more than %d", len(long), MaxNotificationSummaryBytes),
+				`context_link "/issues/123" must be an absolute http or https URL`,
+				`context_link "ftp://example.com/file" must be an absolute http or https URL`,
+				`context_link "https:///path" must be an absolute http or https URL`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Report the index of each invalid notification, counting from the valid first one.
+			var dashErrs, groupErrs []error
+			for i, msg := range test.expectedErrs {
+				field := "summary"
+				if strings.HasPrefix(msg, "context_link") {
+					field = "context_link"
+				}
+				msg = fmt.Sprintf("notification %d: %s", i+1, msg)
+				dashErrs = append(dashErrs, ConfigError{"dash", "Dashboard", msg, FieldPath{"notifications", i + 1, field}})
+				groupErrs = append(groupErrs, ConfigError{"group", "TestGroup", msg, FieldPath{"notifications", i + 1, field}})
+			}
+			err := validateDashboardNotifications(&configpb.Dashboard{Name: "dash", Notifications: test.notifications})
+			if got := errorList(err); !reflect.DeepEqual(dashErrs, got) {
+				t.Errorf("validateDashboardNotifications() expected %v, but got: %v", dashErrs, got)
+			}
+			err = validateTestGroupNotifications(&configpb.TestGroup{Name: "group", Notifications: test.notifications})
+			if got := errorList(err); !reflect.DeepEqual(groupErrs, got) {
+				t.Errorf("validateTestGroupNotifications() expected %v, but got: %v", groupErrs, got)
+			}
+		})
+	}
+}
+
+func TestReadPath(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	valid := minimalConfig()
+	validBytes, err := MarshalBytes(valid)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	invalid := minimalConfig()
+	invalid.Dashboards = append(invalid.Dashboards, &configpb.Dashboard{Name: "dashboard_2"})
+	invalidBytes, err := proto.Marshal(&invalid)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	files := map[string][]byte{
+		"valid":   validBytes,
+		"invalid": invalidBytes,
+		"corrupt": []byte("not a proto"),
+	}
+	for name, b := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), b, 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		file       string
+		validate   bool
+		expected   *configpb.Configuration
+		errMatches func(error) bool
+	}{
+		{
+			name:     "Reads config",
+			file:     "valid",
+			validate: true,
+			expected: &valid,
+		},
+		{
+			name:     "Skips validation",
+			file:     "invalid",
+			expected: &invalid,
+		},
+		{
+			name:     "Validates; error",
+			file:     "invalid",
+			validate: true,
+			errMatches: func(err error) bool {
+				return errors.Is(err, ConfigError{})
+			},
+		},
+		{
+			name: "Not found; error",
+			file: "missing",
+			errMatches: func(err error) bool {
+				var e NotFoundError
+				return errors.As(err, &e) && os.IsNotExist(e.Err)
+			},
+		},
+		{
+			name: "Corrupt; error",
+			file: "corrupt",
+			errMatches: func(err error) bool {
+				var e CorruptError
+				return errors.As(err, &e)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ReadPath(filepath.Join(dir, test.file), test.validate)
+			if test.errMatches != nil {
+				if !test.errMatches(err) {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !proto.Equal(test.expected, got) {
+				t.Errorf("Expected %v, but got: %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestReadGCS(t *testing.T) {
+	valid := minimalConfig()
+	validBytes, err := MarshalBytes(valid)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	invalid := minimalConfig()
+	invalid.Dashboards = append(invalid.Dashboards, &configpb.Dashboard{Name: "dashboard_2"})
+	invalidBytes, err := proto.Marshal(&invalid)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	path := func(name string) gcs.Path {
+		p, err := gcs.ParsePath("gs://bucket/" + name)
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", name, err)
+		}
+		return *p
+	}
+	client := &fake.Client{}
+	generations := map[string]int64{}
+	for name, b := range map[string][]byte{
+		"valid":   validBytes,
+		"invalid": invalidBytes,
+		"corrupt": []byte("not a proto"),
+		"broken":  validBytes,
+	} {
+		generations[name] = client.Put(path(name), b).Generation
+	}
+	injected := errors.New("injected")
+	client.Fail(path("broken"), injected)
+
+	tests := []struct {
+		name       string
+		object     string
+		validate   bool
+		expected   *configpb.Configuration
+		errMatches func(error) bool
+	}{
+		{
+			name:     "Reads config",
+			object:   "valid",
+			validate: true,
+			expected: &valid,
+		},
+		{
+			name:     "Skips validation",
+			object:   "invalid",
+			expected: &invalid,
+		},
+		{
+			name:     "Validates; error",
+			object:   "invalid",
+			validate: true,
+			errMatches: func(err error) bool {
+				return errors.Is(err, ConfigError{})
+			},
+		},
+		{
+			name:   "Not found; error",
+			object: "missing",
+			errMatches: func(err error) bool {
+				var e NotFoundError
+				return errors.As(err, &e) && e.Err == storage.ErrObjectNotExist
+			},
+		},
+		{
+			name:   "Corrupt; error",
+			object: "corrupt",
+			errMatches: func(err error) bool {
+				var e CorruptError
+				return errors.As(err, &e)
+			},
+		},
+		{
+			name:   "Open fails; error",
+			object: "broken",
+			errMatches: func(err error) bool {
+				return err != nil && strings.Contains(err.Error(), injected.Error())
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, generation, err := ReadGCS(context.Background(), client, path(test.object), test.validate)
+			if test.errMatches != nil {
+				if !test.errMatches(err) {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !proto.Equal(test.expected, got) {
+				t.Errorf("Expected %v, but got: %v", test.expected, got)
+			}
+			if want := generations[test.object]; generation != want {
+				t.Errorf("Expected generation %d, but got: %d", want, generation)
+			}
+		})
+	}
+}
+
+// errReader fails every read with err.
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
+
+func TestParse(t *testing.T) {
+	injected := errors.New("injected read error")
+	tests := []struct {
+		name       string
+		r          io.Reader
+		errMatches func(error) bool
+	}{
+		{
+			name: "Valid",
+			r:    bytes.NewReader(nil),
+		},
+		{
+			name: "Corrupt; CorruptError",
+			r:    strings.NewReader("not a proto"),
+			errMatches: func(err error) bool {
+				var e CorruptError
+				return errors.As(err, &e)
+			},
+		},
+		{
+			name: "Read fails; not a CorruptError",
+			r:    errReader{injected},
+			errMatches: func(err error) bool {
+				var e CorruptError
+				return err != nil && !errors.As(err, &e) && strings.Contains(err.Error(), injected.Error())
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parse("config", test.r, false)
+			if test.errMatches != nil {
+				if !test.errMatches(err) {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
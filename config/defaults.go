@@ -0,0 +1,99 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// ApplyDefaults sets each unset field of the Test Groups and Dashboard Tabs to a copy of the default, when present.
+//
+// Proto3 scalars do not record presence, so a field is unset when it has its zero value:
+// false, 0, the empty string, the first enum value, an empty list, a nil message or an unset oneof.
+// Thus defaults cannot be overridden with zero values, such as setting a default true bool to false.
+// Fields which identify an entity, such as names and the Test Group of a Dashboard Tab, are never copied.
+//
+// Apply defaults before validating, so validation checks the resulting entities.
+func ApplyDefaults(cfg *configpb.Configuration, defaults *configpb.DefaultConfiguration) {
+	if tg := defaults.GetDefaultTestGroup(); tg != nil {
+		for _, cur := range cfg.GetTestGroups() {
+			inherit(cur, tg, "name")
+		}
+	}
+	if tab := defaults.GetDefaultDashboardTab(); tab != nil {
+		for _, dash := range cfg.GetDashboards() {
+			for _, cur := range dash.GetDashboardTab() {
+				inherit(cur, tab, "name", "test_group_name")
+			}
+		}
+	}
+}
+
+// EffectiveAlertOptions returns the alert options of the tab, where each unset (zero) field
+// inherits the value of the test group.
+func EffectiveAlertOptions(tab *configpb.DashboardTab, group *configpb.TestGroup) *configpb.DashboardTabAlertOptions {
+	opts := &configpb.DashboardTabAlertOptions{}
+	if tab.GetAlertOptions() != nil {
+		opts = proto.Clone(tab.GetAlertOptions()).(*configpb.DashboardTabAlertOptions)
+	}
+	if opts.AlertStaleResultsHours == 0 {
+		opts.AlertStaleResultsHours = group.GetAlertStaleResultsHours()
+	}
+	if opts.NumFailuresToAlert == 0 {
+		opts.NumFailuresToAlert = group.GetNumFailuresToAlert()
+	}
+	if opts.NumPassesToDisableAlert == 0 {
+		opts.NumPassesToDisableAlert = group.GetNumPassesToDisableAlert()
+	}
+	if opts.AlertMailToAddresses == "" {
+		opts.AlertMailToAddresses = group.GetAlertMailToAddresses()
+	}
+	return opts
+}
+
+// inherit sets the unset fields of cur to those of a copy of def, except for the skipped proto fields.
+func inherit(cur, def proto.Message, skip ...string) {
+	skipped := map[string]bool{}
+	for _, name := range skip {
+		skipped[name] = true
+	}
+	dst := reflect.ValueOf(cur).Elem()
+	src := reflect.ValueOf(proto.Clone(def)).Elem()
+	t := dst.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" || strings.HasPrefix(f.Name, "XXX_") || skipped[protoName(f)] {
+			continue
+		}
+		if isUnset(dst.Field(i)) {
+			dst.Field(i).Set(src.Field(i))
+		}
+	}
+}
+
+// isUnset returns true when the field has its zero value, or is an empty list.
+func isUnset(v reflect.Value) bool {
+	if v.Kind() == reflect.Slice {
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
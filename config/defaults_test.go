@@ -0,0 +1,438 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+func TestApplyDefaults(t *testing.T) {
+	cases := []struct {
+		name     string
+		cfg      *configpb.Configuration
+		defaults *configpb.DefaultConfiguration
+		want     *configpb.Configuration
+	}{
+		{
+			name: "no defaults",
+			cfg: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "tg"}},
+			},
+			want: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "tg"}},
+			},
+		},
+		{
+			name: "unset fields inherit",
+			cfg: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "tg", Query: "bucket/job"}},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name:         "dash",
+						DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "tg"}},
+					},
+				},
+			},
+			defaults: &configpb.DefaultConfiguration{
+				DefaultTestGroup: &configpb.TestGroup{
+					DaysOfResults:   14,
+					TestsNamePolicy: configpb.TestGroup_TESTS_NAME_REPLACE,
+					IgnorePending:   true,
+					ColumnHeader: []*configpb.TestGroup_ColumnHeader{
+						{ColumnHeaderSource: &configpb.TestGroup_ColumnHeader_ConfigurationValue{ConfigurationValue: "Commit"}},
+					},
+				},
+				DefaultDashboardTab: &configpb.DashboardTab{
+					BugComponent:    123,
+					CodeSearchPath:  "example.com/search",
+					FileBugTemplate: &configpb.LinkTemplate{Url: "https://example.com/new"},
+				},
+			},
+			want: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{
+						Name:            "tg",
+						Query:           "bucket/job",
+						DaysOfResults:   14,
+						TestsNamePolicy: configpb.TestGroup_TESTS_NAME_REPLACE,
+						IgnorePending:   true,
+						ColumnHeader: []*configpb.TestGroup_ColumnHeader{
+							{ColumnHeaderSource: &configpb.TestGroup_ColumnHeader_ConfigurationValue{ConfigurationValue: "Commit"}},
+						},
+					},
+				},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:            "tab",
+								TestGroupName:   "tg",
+								BugComponent:    123,
+								CodeSearchPath:  "example.com/search",
+								FileBugTemplate: &configpb.LinkTemplate{Url: "https://example.com/new"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "set fields keep their values",
+			cfg: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{
+						Name:            "tg",
+						DaysOfResults:   7,
+						TestsNamePolicy: configpb.TestGroup_TESTS_NAME_APPEND,
+						ColumnHeader: []*configpb.TestGroup_ColumnHeader{
+							{ColumnHeaderSource: &configpb.TestGroup_ColumnHeader_Label{Label: "os"}},
+						},
+					},
+				},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:         "tab",
+								AlertOptions: &configpb.DashboardTabAlertOptions{AlertStaleResultsHours: 2},
+							},
+						},
+					},
+				},
+			},
+			defaults: &configpb.DefaultConfiguration{
+				DefaultTestGroup: &configpb.TestGroup{
+					DaysOfResults:   14,
+					TestsNamePolicy: configpb.TestGroup_TESTS_NAME_REPLACE,
+					ColumnHeader: []*configpb.TestGroup_ColumnHeader{
+						{ColumnHeaderSource: &configpb.TestGroup_ColumnHeader_ConfigurationValue{ConfigurationValue: "Commit"}},
+					},
+				},
+				DefaultDashboardTab: &configpb.DashboardTab{
+					AlertOptions: &configpb.DashboardTabAlertOptions{
+						AlertStaleResultsHours: 24,
+						NumFailuresToAlert:     3,
+					},
+				},
+			},
+			want: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{
+						Name:            "tg",
+						DaysOfResults:   7,
+						TestsNamePolicy: configpb.TestGroup_TESTS_NAME_APPEND,
+						ColumnHeader: []*configpb.TestGroup_ColumnHeader{
+							{ColumnHeaderSource: &configpb.TestGroup_ColumnHeader_Label{Label: "os"}},
+						},
+					},
+				},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name: "tab",
+								// Messages are not merged.
+								AlertOptions: &configpb.DashboardTabAlertOptions{AlertStaleResultsHours: 2},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "empty lists are unset",
+			cfg: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{Name: "tg", ColumnHeader: []*configpb.TestGroup_ColumnHeader{}},
+				},
+			},
+			defaults: &configpb.DefaultConfiguration{
+				DefaultTestGroup: &configpb.TestGroup{
+					ColumnHeader: []*configpb.TestGroup_ColumnHeader{
+						{ColumnHeaderSource: &configpb.TestGroup_ColumnHeader_Label{Label: "os"}},
+					},
+				},
+			},
+			want: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{
+						Name: "tg",
+						ColumnHeader: []*configpb.TestGroup_ColumnHeader{
+							{ColumnHeaderSource: &configpb.TestGroup_ColumnHeader_Label{Label: "os"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "zero values cannot override defaults",
+			cfg: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "tg", IgnorePending: false, NumColumnsRecent: 0}},
+			},
+			defaults: &configpb.DefaultConfiguration{
+				DefaultTestGroup: &configpb.TestGroup{IgnorePending: true, NumColumnsRecent: 10},
+			},
+			want: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "tg", IgnorePending: true, NumColumnsRecent: 10}},
+			},
+		},
+		{
+			name: "names are not inherited",
+			cfg: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{}},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name:         "dash",
+						DashboardTab: []*configpb.DashboardTab{{}},
+					},
+				},
+			},
+			defaults: &configpb.DefaultConfiguration{
+				DefaultTestGroup: &configpb.TestGroup{Name: "default", Query: "bucket/default"},
+				DefaultDashboardTab: &configpb.DashboardTab{
+					Name:          "default",
+					TestGroupName: "default",
+					Description:   "inherited",
+				},
+			},
+			want: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Query: "bucket/default"}},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name:         "dash",
+						DashboardTab: []*configpb.DashboardTab{{Description: "inherited"}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ApplyDefaults(tc.cfg, tc.defaults)
+			if !proto.Equal(tc.cfg, tc.want) {
+				t.Errorf("ApplyDefaults() got %s, want %s", proto.MarshalTextString(tc.cfg), proto.MarshalTextString(tc.want))
+			}
+		})
+	}
+}
+
+func TestApplyDefaultsCopies(t *testing.T) {
+	defaults := &configpb.DefaultConfiguration{
+		DefaultTestGroup: &configpb.TestGroup{
+			ColumnHeader: []*configpb.TestGroup_ColumnHeader{
+				{ColumnHeaderSource: &configpb.TestGroup_ColumnHeader_Label{Label: "os"}},
+			},
+		},
+		DefaultDashboardTab: &configpb.DashboardTab{
+			AlertOptions: &configpb.DashboardTabAlertOptions{NumFailuresToAlert: 3},
+		},
+	}
+	cfg := &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{{Name: "a"}, {Name: "b"}},
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name:         "dash",
+				DashboardTab: []*configpb.DashboardTab{{Name: "a"}, {Name: "b"}},
+			},
+		},
+	}
+	ApplyDefaults(cfg, defaults)
+
+	cfg.TestGroups[0].ColumnHeader[0].ColumnHeaderSource = &configpb.TestGroup_ColumnHeader_Label{Label: "changed"}
+	cfg.Dashboards[0].DashboardTab[0].AlertOptions.NumFailuresToAlert = 10
+	defaults.DefaultDashboardTab.AlertOptions.NumFailuresToAlert = 20
+
+	if got := cfg.TestGroups[1].ColumnHeader[0].GetLabel(); got != "os" {
+		t.Errorf("Test Groups share a column_header: got %q, want os", got)
+	}
+	if got := defaults.DefaultTestGroup.ColumnHeader[0].GetLabel(); got != "os" {
+		t.Errorf("Test Groups share the default column_header: got %q, want os", got)
+	}
+	if got := cfg.Dashboards[0].DashboardTab[1].AlertOptions.NumFailuresToAlert; got != 3 {
+		t.Errorf("Dashboard Tabs share alert_options: got %d, want 3", got)
+	}
+}
+
+// fill sets every exported field of the message to a non-zero value.
+func fill(t *testing.T, v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		if f.PkgPath != "" || strings.HasPrefix(f.Name, "XXX_") {
+			continue
+		}
+		field := v.Field(i)
+		switch field.Kind() {
+		case reflect.Bool:
+			field.SetBool(true)
+		case reflect.Int32, reflect.Int64:
+			field.SetInt(1)
+		case reflect.Uint32, reflect.Uint64:
+			field.SetUint(1)
+		case reflect.Float32, reflect.Float64:
+			field.SetFloat(1)
+		case reflect.String:
+			field.SetString("value")
+		case reflect.Ptr:
+			field.Set(reflect.New(field.Type().Elem()))
+		case reflect.Slice:
+			elem := reflect.New(field.Type().Elem()).Elem()
+			if elem.Kind() == reflect.Ptr {
+				elem.Set(reflect.New(elem.Type().Elem()))
+			} else if elem.Kind() == reflect.String {
+				elem.SetString("value")
+			}
+			field.Set(reflect.Append(field, elem))
+		default:
+			t.Fatalf("fill() does not support %s %s", f.Name, field.Kind())
+		}
+	}
+}
+
+func TestApplyDefaultsEveryField(t *testing.T) {
+	var tg configpb.TestGroup
+	var tab configpb.DashboardTab
+	fill(t, reflect.ValueOf(&tg).Elem())
+	fill(t, reflect.ValueOf(&tab).Elem())
+	cfg := &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{{}},
+		Dashboards: []*configpb.Dashboard{{DashboardTab: []*configpb.DashboardTab{{}}}},
+	}
+	ApplyDefaults(cfg, &configpb.DefaultConfiguration{DefaultTestGroup: &tg, DefaultDashboardTab: &tab})
+
+	check := func(got, want proto.Message, skip ...string) {
+		g, w := reflect.ValueOf(got).Elem(), reflect.ValueOf(want).Elem()
+		for i := 0; i < g.NumField(); i++ {
+			f := g.Type().Field(i)
+			if f.PkgPath != "" || strings.HasPrefix(f.Name, "XXX_") {
+				continue
+			}
+			skipped := false
+			for _, s := range skip {
+				skipped = skipped || protoName(f) == s
+			}
+			switch {
+			case skipped && !g.Field(i).IsZero():
+				t.Errorf("ApplyDefaults() inherited %s.%s", g.Type().Name(), f.Name)
+			case !skipped && !reflect.DeepEqual(g.Field(i).Interface(), w.Field(i).Interface()):
+				t.Errorf("ApplyDefaults() got %s.%s %v, want %v", g.Type().Name(), f.Name, g.Field(i), w.Field(i))
+			}
+		}
+	}
+	check(cfg.TestGroups[0], &tg, "name")
+	check(cfg.Dashboards[0].DashboardTab[0], &tab, "name", "test_group_name")
+}
+
+func TestEffectiveAlertOptions(t *testing.T) {
+	cases := []struct {
+		name  string
+		tab   *configpb.DashboardTabAlertOptions
+		group *configpb.TestGroup
+		want  *configpb.DashboardTabAlertOptions
+	}{
+		{
+			name: "nothing set",
+			want: &configpb.DashboardTabAlertOptions{},
+		},
+		{
+			name: "inherit from the group",
+			group: &configpb.TestGroup{
+				AlertStaleResultsHours:  12,
+				NumFailuresToAlert:      3,
+				NumPassesToDisableAlert: 2,
+				AlertMailToAddresses:    "group@example.com",
+			},
+			want: &configpb.DashboardTabAlertOptions{
+				AlertStaleResultsHours:  12,
+				NumFailuresToAlert:      3,
+				NumPassesToDisableAlert: 2,
+				AlertMailToAddresses:    "group@example.com",
+			},
+		},
+		{
+			name: "tab overrides the group",
+			tab: &configpb.DashboardTabAlertOptions{
+				AlertStaleResultsHours:  24,
+				NumFailuresToAlert:      5,
+				NumPassesToDisableAlert: 4,
+				AlertMailToAddresses:    "tab@example.com",
+			},
+			group: &configpb.TestGroup{
+				AlertStaleResultsHours:  12,
+				NumFailuresToAlert:      3,
+				NumPassesToDisableAlert: 2,
+				AlertMailToAddresses:    "group@example.com",
+			},
+			want: &configpb.DashboardTabAlertOptions{
+				AlertStaleResultsHours:  24,
+				NumFailuresToAlert:      5,
+				NumPassesToDisableAlert: 4,
+				AlertMailToAddresses:    "tab@example.com",
+			},
+		},
+		{
+			name: "override some fields",
+			tab: &configpb.DashboardTabAlertOptions{
+				NumFailuresToAlert: 5,
+				Subject:            "uh oh",
+			},
+			group: &configpb.TestGroup{
+				AlertStaleResultsHours:  12,
+				NumFailuresToAlert:      3,
+				NumPassesToDisableAlert: 2,
+			},
+			want: &configpb.DashboardTabAlertOptions{
+				AlertStaleResultsHours:  12,
+				NumFailuresToAlert:      5,
+				NumPassesToDisableAlert: 2,
+				Subject:                 "uh oh",
+			},
+		},
+		{
+			name: "tab alone",
+			tab: &configpb.DashboardTabAlertOptions{
+				AlertStaleResultsHours: 6,
+			},
+			group: &configpb.TestGroup{},
+			want: &configpb.DashboardTabAlertOptions{
+				AlertStaleResultsHours: 6,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tab := &configpb.DashboardTab{AlertOptions: tc.tab}
+			got := EffectiveAlertOptions(tab, tc.group)
+			if !proto.Equal(got, tc.want) {
+				t.Errorf("EffectiveAlertOptions() got %v, want %v", got, tc.want)
+			}
+			if tc.tab != nil && got == tc.tab {
+				t.Errorf("EffectiveAlertOptions() returned the options of the tab, want a copy")
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/golang/protobuf/proto"
+)
+
+// ChangeKind describes how an entity changed between two configurations.
+type ChangeKind int
+
+const (
+	// Added entities only exist in the new configuration.
+	Added ChangeKind = iota
+	// Removed entities only exist in the old configuration.
+	Removed
+	// Modified entities exist in both configurations, with different fields.
+	Modified
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case Added:
+		return "+"
+	case Removed:
+		return "-"
+	case Modified:
+		return "~"
+	}
+	return "?"
+}
+
+// Change describes a single changed entity.
+type Change struct {
+	Kind ChangeKind
+	// Entity is the type of entity, such as "TestGroup" or "DashboardTab".
+	Entity string
+	// Name is the name in the new configuration, or the old one when Removed.
+	//
+	// Dashboard Tabs are named dashboard/tab.
+	Name string
+	// Fields lists the path of each changed field when Modified, such as "alert_options.num_failures_to_alert".
+	Fields []string
+}
+
+func (c Change) String() string {
+	s := fmt.Sprintf("%s %s %s", c.Kind, c.Entity, c.Name)
+	if len(c.Fields) > 0 {
+		s += ": " + strings.Join(c.Fields, ", ")
+	}
+	return s
+}
+
+// DiffResult lists the entities that changed between two configurations.
+type DiffResult struct {
+	// Changes lists Test Groups, then Dashboards, then Dashboard Tabs, then Dashboard Groups.
+	//
+	// Each type lists changes in the order of the old configuration, followed by additions.
+	Changes []Change
+}
+
+// String summarizes each change on its own line.
+func (d DiffResult) String() string {
+	if len(d.Changes) == 0 {
+		return "no changes"
+	}
+	lines := make([]string, 0, len(d.Changes))
+	for _, c := range d.Changes {
+		lines = append(lines, c.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Breaking returns each change that orphans existing state.
+//
+// The updater and summarizer store state by Test Group and Dashboard name, so removing or
+// renaming one of those leaves its state behind. Renames appear as a removal and an addition,
+// or as a modified name when only the unnormalized name changes.
+func (d DiffResult) Breaking() []Change {
+	var breaking []Change
+	for _, c := range d.Changes {
+		if c.Entity != "TestGroup" && c.Entity != "Dashboard" {
+			continue
+		}
+		if c.Kind == Removed || c.Kind == Modified && containsString(c.Fields, "name") {
+			breaking = append(breaking, c)
+		}
+	}
+	return breaking
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// named is an entity with a name, which is matched between configurations after normalizing.
+type named struct {
+	name string
+	msg  proto.Message
+}
+
+// Diff returns the entities added, removed and modified from before to after.
+//
+// Entities match by normalized name.
+func Diff(before, after *configpb.Configuration) DiffResult {
+	var d DiffResult
+
+	var oldTgs, newTgs []named
+	for _, tg := range before.GetTestGroups() {
+		oldTgs = append(oldTgs, named{tg.Name, tg})
+	}
+	for _, tg := range after.GetTestGroups() {
+		newTgs = append(newTgs, named{tg.Name, tg})
+	}
+	d.diff("TestGroup", oldTgs, newTgs)
+
+	var oldDashes, newDashes []named
+	for _, dash := range before.GetDashboards() {
+		oldDashes = append(oldDashes, named{dash.Name, dash})
+	}
+	for _, dash := range after.GetDashboards() {
+		newDashes = append(newDashes, named{dash.Name, dash})
+	}
+	d.diff("Dashboard", oldDashes, newDashes, "dashboard_tab")
+
+	for _, oldDash := range before.GetDashboards() {
+		newDash := FindDashboard(after, oldDash.Name)
+		if newDash == nil {
+			continue // Removing the Dashboard removes its tabs.
+		}
+		var oldTabs, newTabs []named
+		for _, tab := range oldDash.DashboardTab {
+			oldTabs = append(oldTabs, named{oldDash.Name + "/" + tab.Name, tab})
+		}
+		for _, tab := range newDash.DashboardTab {
+			newTabs = append(newTabs, named{newDash.Name + "/" + tab.Name, tab})
+		}
+		d.diff("DashboardTab", oldTabs, newTabs)
+	}
+
+	var oldDgs, newDgs []named
+	for _, dg := range before.GetDashboardGroups() {
+		oldDgs = append(oldDgs, named{dg.Name, dg})
+	}
+	for _, dg := range after.GetDashboardGroups() {
+		newDgs = append(newDgs, named{dg.Name, dg})
+	}
+	d.diff("DashboardGroup", oldDgs, newDgs)
+
+	return d
+}
+
+// diff appends the changes between the before and after entities, ignoring the excluded top-level fields.
+func (d *DiffResult) diff(entity string, before, after []named, exclude ...string) {
+	newByName := map[string]named{}
+	for _, n := range after {
+		if norm := Normalize(n.name); newByName[norm].msg == nil {
+			newByName[norm] = n
+		}
+	}
+	seen := map[string]bool{}
+	for _, o := range before {
+		norm := Normalize(o.name)
+		if seen[norm] {
+			continue
+		}
+		seen[norm] = true
+		n, ok := newByName[norm]
+		if !ok {
+			d.Changes = append(d.Changes, Change{Kind: Removed, Entity: entity, Name: o.name})
+			continue
+		}
+		fields := changedFields("", reflect.ValueOf(o.msg).Elem(), reflect.ValueOf(n.msg).Elem(), exclude)
+		if len(fields) > 0 {
+			d.Changes = append(d.Changes, Change{Kind: Modified, Entity: entity, Name: n.name, Fields: fields})
+		}
+	}
+	for _, n := range after {
+		norm := Normalize(n.name)
+		if seen[norm] {
+			continue
+		}
+		seen[norm] = true
+		d.Changes = append(d.Changes, Change{Kind: Added, Entity: entity, Name: n.name})
+	}
+}
+
+// changedFields returns the path of each proto field that differs between messages a and b.
+//
+// Nested messages report each changed field within them; repeated fields are reported as a whole.
+func changedFields(prefix string, a, b reflect.Value, exclude []string) []string {
+	var paths []string
+	t := a.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := protoFieldName(f)
+		if name == "" || containsString(exclude, name) {
+			continue
+		}
+		fa, fb := a.Field(i), b.Field(i)
+		if equalValues(fa, fb) {
+			continue
+		}
+		path := prefix + name
+		if isMessage(f.Type) && !fa.IsNil() && !fb.IsNil() {
+			paths = append(paths, changedFields(path+".", fa.Elem(), fb.Elem(), nil)...)
+			continue
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// protoFieldName returns the proto name of a generated struct field, or "" for internal fields.
+func protoFieldName(f reflect.StructField) string {
+	if oneof := f.Tag.Get("protobuf_oneof"); oneof != "" {
+		return oneof
+	}
+	for _, part := range strings.Split(f.Tag.Get("protobuf"), ",") {
+		if strings.HasPrefix(part, "name=") {
+			return strings.TrimPrefix(part, "name=")
+		}
+	}
+	return ""
+}
+
+func isMessage(t reflect.Type) bool {
+	return t.Kind() == reflect.Ptr && t.Implements(reflect.TypeOf((*proto.Message)(nil)).Elem())
+}
+
+// equalValues compares field values, using proto semantics for messages.
+func equalValues(a, b reflect.Value) bool {
+	if isMessage(a.Type()) {
+		return proto.Equal(a.Interface().(proto.Message), b.Interface().(proto.Message))
+	}
+	if a.Kind() == reflect.Slice {
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !equalValues(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
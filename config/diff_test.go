@@ -0,0 +1,170 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/golang/protobuf/proto"
+)
+
+func diffConfig() *configpb.Configuration {
+	return &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{
+			{Name: "test_group_1", DaysOfResults: 7},
+			{Name: "test_group_2"},
+		},
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name: "dashboard_1",
+				DashboardTab: []*configpb.DashboardTab{
+					{
+						Name:          "tab_1",
+						TestGroupName: "test_group_1",
+						AlertOptions:  &configpb.DashboardTabAlertOptions{NumFailuresToAlert: 3},
+					},
+					{Name: "tab_2", TestGroupName: "test_group_2"},
+				},
+			},
+		},
+		DashboardGroups: []*configpb.DashboardGroup{
+			{Name: "dashboard_group_1", DashboardNames: []string{"dashboard_1"}},
+		},
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		edit     func(*configpb.Configuration)
+		expected []Change
+		breaking []Change
+	}{
+		{
+			name: "No changes",
+			edit: func(*configpb.Configuration) {},
+		},
+		{
+			name: "Added and removed entities",
+			edit: func(c *configpb.Configuration) {
+				c.TestGroups[1].Name = "test_group_3"
+				c.Dashboards[0].DashboardTab[1].TestGroupName = "test_group_3"
+				c.DashboardGroups = append(c.DashboardGroups, &configpb.DashboardGroup{Name: "dashboard_group_2"})
+			},
+			expected: []Change{
+				{Kind: Removed, Entity: "TestGroup", Name: "test_group_2"},
+				{Kind: Added, Entity: "TestGroup", Name: "test_group_3"},
+				{Kind: Modified, Entity: "DashboardTab", Name: "dashboard_1/tab_2", Fields: []string{"test_group_name"}},
+				{Kind: Added, Entity: "DashboardGroup", Name: "dashboard_group_2"},
+			},
+			breaking: []Change{
+				{Kind: Removed, Entity: "TestGroup", Name: "test_group_2"},
+			},
+		},
+		{
+			name: "Modified fields",
+			edit: func(c *configpb.Configuration) {
+				c.TestGroups[0].DaysOfResults = 14
+				c.Dashboards[0].DashboardTab[0].AlertOptions.NumFailuresToAlert = 5
+				c.Dashboards[0].DashboardTab[0].Description = "hello"
+				c.DashboardGroups[0].DashboardNames = nil
+			},
+			expected: []Change{
+				{Kind: Modified, Entity: "TestGroup", Name: "test_group_1", Fields: []string{"days_of_results"}},
+				{Kind: Modified, Entity: "DashboardTab", Name: "dashboard_1/tab_1", Fields: []string{"description", "alert_options.num_failures_to_alert"}},
+				{Kind: Modified, Entity: "DashboardGroup", Name: "dashboard_group_1", Fields: []string{"dashboard_names"}},
+			},
+		},
+		{
+			name: "Names match after normalizing",
+			edit: func(c *configpb.Configuration) {
+				c.TestGroups[0].Name = "Test Group 1"
+				c.Dashboards[0].DashboardTab[0].Name = "Tab-1"
+			},
+			expected: []Change{
+				{Kind: Modified, Entity: "TestGroup", Name: "Test Group 1", Fields: []string{"name"}},
+				{Kind: Modified, Entity: "DashboardTab", Name: "dashboard_1/Tab-1", Fields: []string{"name"}},
+			},
+			breaking: []Change{
+				{Kind: Modified, Entity: "TestGroup", Name: "Test Group 1", Fields: []string{"name"}},
+			},
+		},
+		{
+			name: "Removed dashboard does not list its tabs",
+			edit: func(c *configpb.Configuration) {
+				c.Dashboards = nil
+				c.DashboardGroups[0].DashboardNames = nil
+			},
+			expected: []Change{
+				{Kind: Removed, Entity: "Dashboard", Name: "dashboard_1"},
+				{Kind: Modified, Entity: "DashboardGroup", Name: "dashboard_group_1", Fields: []string{"dashboard_names"}},
+			},
+			breaking: []Change{
+				{Kind: Removed, Entity: "Dashboard", Name: "dashboard_1"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			before := diffConfig()
+			after := proto.Clone(before).(*configpb.Configuration)
+			test.edit(after)
+			got := Diff(before, after)
+			if !reflect.DeepEqual(got.Changes, test.expected) {
+				t.Errorf("expected changes:\n%v\nbut got:\n%v", test.expected, got.Changes)
+			}
+			if breaking := got.Breaking(); !reflect.DeepEqual(breaking, test.breaking) {
+				t.Errorf("expected breaking %v, but got: %v", test.breaking, breaking)
+			}
+		})
+	}
+}
+
+func TestDiffResult_String(t *testing.T) {
+	tests := []struct {
+		name     string
+		diff     DiffResult
+		expected string
+	}{
+		{
+			name:     "No changes",
+			expected: "no changes",
+		},
+		{
+			name: "Changes",
+			diff: DiffResult{[]Change{
+				{Kind: Added, Entity: "TestGroup", Name: "test_group_3"},
+				{Kind: Removed, Entity: "Dashboard", Name: "dashboard_2"},
+				{Kind: Modified, Entity: "DashboardTab", Name: "dashboard_1/tab_1", Fields: []string{"description", "alert_options.num_failures_to_alert"}},
+			}},
+			expected: "+ TestGroup test_group_3\n" +
+				"- Dashboard dashboard_2\n" +
+				"~ DashboardTab dashboard_1/tab_1: description, alert_options.num_failures_to_alert",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.diff.String(); got != test.expected {
+				t.Errorf("expected %q, but got: %q", test.expected, got)
+			}
+		})
+	}
+}
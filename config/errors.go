@@ -0,0 +1,162 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// ValidationErrors is the multierror.Error returned by Validate and ValidateWithOptions.
+//
+// It supports errors.Is and errors.As (on Go 1.20+) for each collected error:
+//
+//	var missing config.MissingEntityError
+//	if errors.As(err, &missing) { ... }
+//
+// errors.As also accepts a **multierror.Error target.
+type ValidationErrors multierror.Error
+
+// Error lists each collected error.
+func (e *ValidationErrors) Error() string { return (*multierror.Error)(e).Error() }
+
+// Unwrap returns each collected error.
+func (e *ValidationErrors) Unwrap() []error { return e.Errors }
+
+// As sets a **multierror.Error target to the same errors.
+func (e *ValidationErrors) As(target interface{}) bool {
+	if p, ok := target.(**multierror.Error); ok {
+		*p = (*multierror.Error)(e)
+		return true
+	}
+	return false
+}
+
+// wrapErrors returns the collected errors as *ValidationErrors, or nil if there are none.
+func wrapErrors(mErr *multierror.Error) error {
+	if mErr.ErrorOrNil() == nil {
+		return nil
+	}
+	return (*ValidationErrors)(mErr)
+}
+
+// Is matches any MissingFieldError, regardless of field.
+func (e MissingFieldError) Is(target error) bool {
+	switch target.(type) {
+	case MissingFieldError, *MissingFieldError:
+		return true
+	}
+	return false
+}
+
+// As sets a **MissingFieldError target.
+func (e MissingFieldError) As(target interface{}) bool {
+	if p, ok := target.(**MissingFieldError); ok {
+		*p = &e
+		return true
+	}
+	return false
+}
+
+// Is matches any DuplicateNameError, regardless of name.
+func (e DuplicateNameError) Is(target error) bool {
+	switch target.(type) {
+	case DuplicateNameError, *DuplicateNameError:
+		return true
+	}
+	return false
+}
+
+// As sets a **DuplicateNameError target.
+func (e DuplicateNameError) As(target interface{}) bool {
+	if p, ok := target.(**DuplicateNameError); ok {
+		*p = &e
+		return true
+	}
+	return false
+}
+
+// Is matches any MissingEntityError, regardless of name.
+func (e MissingEntityError) Is(target error) bool {
+	switch target.(type) {
+	case MissingEntityError, *MissingEntityError:
+		return true
+	}
+	return false
+}
+
+// As sets a **MissingEntityError target.
+func (e MissingEntityError) As(target interface{}) bool {
+	if p, ok := target.(**MissingEntityError); ok {
+		*p = &e
+		return true
+	}
+	return false
+}
+
+// Is matches any ConfigError, regardless of name.
+func (e ConfigError) Is(target error) bool {
+	switch target.(type) {
+	case ConfigError, *ConfigError:
+		return true
+	}
+	return false
+}
+
+// As sets a **ConfigError target.
+func (e ConfigError) As(target interface{}) bool {
+	if p, ok := target.(**ConfigError); ok {
+		*p = &e
+		return true
+	}
+	return false
+}
+
+// Is matches any ConfigWarning, regardless of name.
+func (e ConfigWarning) Is(target error) bool {
+	switch target.(type) {
+	case ConfigWarning, *ConfigWarning:
+		return true
+	}
+	return false
+}
+
+// As sets a **ConfigWarning target.
+func (e ConfigWarning) As(target interface{}) bool {
+	if p, ok := target.(**ConfigWarning); ok {
+		*p = &e
+		return true
+	}
+	return false
+}
+
+// Is matches any TooManyErrorsError, regardless of counts.
+func (e TooManyErrorsError) Is(target error) bool {
+	switch target.(type) {
+	case TooManyErrorsError, *TooManyErrorsError:
+		return true
+	}
+	return false
+}
+
+// As sets a **TooManyErrorsError target.
+func (e TooManyErrorsError) As(target interface{}) bool {
+	if p, ok := target.(**TooManyErrorsError); ok {
+		*p = &e
+		return true
+	}
+	return false
+}
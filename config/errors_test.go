@@ -0,0 +1,124 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// mixedConfig returns a configuration with several kinds of problems, including two missing entities.
+func mixedConfig() configpb.Configuration {
+	c := minimalConfig()
+	c.TestGroups = append(c.TestGroups, &configpb.TestGroup{Name: "test_group_2"})
+	c.Dashboards[0].DashboardTab = append(c.Dashboards[0].DashboardTab, &configpb.DashboardTab{
+		Name:          "tab_2",
+		TestGroupName: "test_group_3",
+	})
+	c.DashboardGroups = append(c.DashboardGroups, &configpb.DashboardGroup{
+		Name:           "dashboard",
+		DashboardNames: []string{"dashboard_1", "dashboard_2"},
+	})
+	return c
+}
+
+func TestValidationErrors_Filter(t *testing.T) {
+	err := Validate(mixedConfig())
+	var missing []MissingEntityError
+	for _, e := range err.(*ValidationErrors).Unwrap() {
+		var m MissingEntityError
+		if errors.As(e, &m) {
+			missing = append(missing, m)
+		}
+	}
+	expected := []MissingEntityError{
+		{"test_group_3", "TestGroup", "test_group_1", FieldPath{"dashboards", 0, "dashboard_tab", 1, "test_group_name"}},
+		{"dashboard_2", "Dashboard", "dashboard_1", FieldPath{"dashboard_groups", 0, "dashboard_names", 1}},
+	}
+	if !reflect.DeepEqual(expected, missing) {
+		t.Fatalf("Expected %v, but got: %v", expected, missing)
+	}
+}
+
+func TestValidationErrors_Is(t *testing.T) {
+	err := Validate(mixedConfig())
+	tests := []struct {
+		name     string
+		target   error
+		expected bool
+	}{
+		{
+			name:     "Matches MissingEntityError regardless of name",
+			target:   MissingEntityError{},
+			expected: true,
+		},
+		{
+			name:     "Matches ConfigError",
+			target:   ConfigError{},
+			expected: true,
+		},
+		{
+			name:     "Matches pointer target",
+			target:   &ConfigError{},
+			expected: true,
+		},
+		{
+			name:   "Does not match absent kinds",
+			target: DuplicateNameError{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := errors.Is(err, test.target); got != test.expected {
+				t.Fatalf("errors.Is(%v) = %t, want %t", test.target, got, test.expected)
+			}
+		})
+	}
+}
+
+func TestValidationErrors_As(t *testing.T) {
+	err := Validate(mixedConfig())
+
+	var m MissingEntityError
+	if !errors.As(err, &m) {
+		t.Fatalf("errors.As(MissingEntityError) = false, want true")
+	}
+	if expected := (MissingEntityError{"test_group_3", "TestGroup", "test_group_1", FieldPath{"dashboards", 0, "dashboard_tab", 1, "test_group_name"}}); !reflect.DeepEqual(m, expected) {
+		t.Errorf("Expected %v, but got: %v", expected, m)
+	}
+
+	var p *ConfigError
+	if !errors.As(err, &p) {
+		t.Fatalf("errors.As(*ConfigError) = false, want true")
+	}
+	if expected := (ConfigError{"test_group_2", "TestGroup", "Each Test Group must be referenced by at least 1 Dashboard Tab.", FieldPath{"test_groups", 1}}); !reflect.DeepEqual(*p, expected) {
+		t.Errorf("Expected %v, but got: %v", expected, *p)
+	}
+
+	var mErr *multierror.Error
+	if !errors.As(err, &mErr) {
+		t.Fatalf("errors.As(*multierror.Error) = false, want true")
+	}
+	if got := len(mErr.Errors); got != 3 {
+		t.Errorf("Expected 3 errors, but got %d: %v", got, mErr)
+	}
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"sync"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// FindTestGroup returns the Test Group with the same normalized name, or nil if it is missing.
+func FindTestGroup(cfg *configpb.Configuration, name string) *configpb.TestGroup {
+	norm := Normalize(name)
+	for _, tg := range cfg.GetTestGroups() {
+		if Normalize(tg.Name) == norm {
+			return tg
+		}
+	}
+	return nil
+}
+
+// FindDashboard returns the Dashboard with the same normalized name, or nil if it is missing.
+func FindDashboard(cfg *configpb.Configuration, name string) *configpb.Dashboard {
+	norm := Normalize(name)
+	for _, d := range cfg.GetDashboards() {
+		if Normalize(d.Name) == norm {
+			return d
+		}
+	}
+	return nil
+}
+
+// FindDashboardGroup returns the Dashboard Group with the same normalized name, or nil if it is missing.
+func FindDashboardGroup(cfg *configpb.Configuration, name string) *configpb.DashboardGroup {
+	norm := Normalize(name)
+	for _, dg := range cfg.GetDashboardGroups() {
+		if Normalize(dg.Name) == norm {
+			return dg
+		}
+	}
+	return nil
+}
+
+// FindDashboardTab returns the Dashboard Tab with the same normalized name in the named Dashboard, or nil if either is missing.
+func FindDashboardTab(cfg *configpb.Configuration, dashboardName, tabName string) *configpb.DashboardTab {
+	return findTab(FindDashboard(cfg, dashboardName), tabName)
+}
+
+func findTab(dash *configpb.Dashboard, tabName string) *configpb.DashboardTab {
+	norm := Normalize(tabName)
+	for _, tab := range dash.GetDashboardTab() {
+		if Normalize(tab.Name) == norm {
+			return tab
+		}
+	}
+	return nil
+}
+
+// TabLocation identifies a Dashboard Tab.
+type TabLocation struct {
+	Dashboard string
+	Tab       string
+	// DashboardTab is the tab in the Configuration.
+	DashboardTab *configpb.DashboardTab
+	// DashboardGroup names the Dashboard Group holding the Dashboard, or is empty when none does.
+	DashboardGroup string
+}
+
+// TabsForTestGroup returns each Dashboard Tab that displays the Test Group with the same normalized name, in config order.
+func TabsForTestGroup(cfg *configpb.Configuration, groupName string) []TabLocation {
+	norm := Normalize(groupName)
+	dashGroups := dashboardGroupNames(cfg)
+	var locs []TabLocation
+	for _, dash := range cfg.GetDashboards() {
+		for _, tab := range dash.DashboardTab {
+			if Normalize(tab.TestGroupName) == norm {
+				locs = append(locs, TabLocation{dash.Name, tab.Name, tab, dashGroups[dash.Name]})
+			}
+		}
+	}
+	return locs
+}
+
+// dashboardGroupNames maps the name of each Dashboard to the Dashboard Group listing it.
+func dashboardGroupNames(cfg *configpb.Configuration) map[string]string {
+	names := map[string]string{}
+	for _, dg := range cfg.GetDashboardGroups() {
+		for _, name := range dg.DashboardNames {
+			names[name] = dg.Name
+		}
+	}
+	return names
+}
+
+// Index finds entities by normalized name in constant time, for callers that look up many names.
+//
+// The index is built on the first lookup; later changes to the Configuration are not seen.
+// Like the Find functions, the first entity wins when names collide.
+// Index is safe for concurrent use.
+type Index struct {
+	cfg  *configpb.Configuration
+	once sync.Once
+
+	testGroups      map[string]*configpb.TestGroup
+	dashboards      map[string]*configpb.Dashboard
+	dashboardGroups map[string]*configpb.DashboardGroup
+}
+
+// NewIndex returns an Index of cfg.
+func NewIndex(cfg *configpb.Configuration) *Index {
+	return &Index{cfg: cfg}
+}
+
+func (idx *Index) build() {
+	idx.once.Do(func() {
+		idx.testGroups = map[string]*configpb.TestGroup{}
+		for _, tg := range idx.cfg.GetTestGroups() {
+			if norm := Normalize(tg.Name); idx.testGroups[norm] == nil {
+				idx.testGroups[norm] = tg
+			}
+		}
+		idx.dashboards = map[string]*configpb.Dashboard{}
+		for _, d := range idx.cfg.GetDashboards() {
+			if norm := Normalize(d.Name); idx.dashboards[norm] == nil {
+				idx.dashboards[norm] = d
+			}
+		}
+		idx.dashboardGroups = map[string]*configpb.DashboardGroup{}
+		for _, dg := range idx.cfg.GetDashboardGroups() {
+			if norm := Normalize(dg.Name); idx.dashboardGroups[norm] == nil {
+				idx.dashboardGroups[norm] = dg
+			}
+		}
+	})
+}
+
+// TestGroup returns the Test Group with the same normalized name, or nil if it is missing.
+func (idx *Index) TestGroup(name string) *configpb.TestGroup {
+	idx.build()
+	return idx.testGroups[Normalize(name)]
+}
+
+// Dashboard returns the Dashboard with the same normalized name, or nil if it is missing.
+func (idx *Index) Dashboard(name string) *configpb.Dashboard {
+	idx.build()
+	return idx.dashboards[Normalize(name)]
+}
+
+// DashboardGroup returns the Dashboard Group with the same normalized name, or nil if it is missing.
+func (idx *Index) DashboardGroup(name string) *configpb.DashboardGroup {
+	idx.build()
+	return idx.dashboardGroups[Normalize(name)]
+}
+
+// DashboardTab returns the Dashboard Tab with the same normalized name in the named Dashboard, or nil if either is missing.
+//
+// Tabs are searched linearly, since Dashboards only have a few.
+func (idx *Index) DashboardTab(dashboardName, tabName string) *configpb.DashboardTab {
+	return findTab(idx.Dashboard(dashboardName), tabName)
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// findConfig returns minimalConfig with a shadowed Test Group, a second Test Group and Dashboard Tab, and a Dashboard Group.
+func findConfig() *configpb.Configuration {
+	c := minimalConfig()
+	c.TestGroups = append(c.TestGroups,
+		&configpb.TestGroup{Name: "Test Group 1"}, // Shadowed by test_group_1
+		&configpb.TestGroup{Name: "test_group_2"},
+	)
+	c.Dashboards[0].DashboardTab = append(c.Dashboards[0].DashboardTab, &configpb.DashboardTab{Name: "tab-2"})
+	c.DashboardGroups = append(c.DashboardGroups, &configpb.DashboardGroup{Name: "dashboard_group_1"})
+	return &c
+}
+
+func TestFind(t *testing.T) {
+	cfg := findConfig()
+	tgs := cfg.TestGroups
+	dash := cfg.Dashboards[0]
+	tabs := dash.DashboardTab
+	dg := cfg.DashboardGroups[0]
+	idx := NewIndex(cfg)
+
+	tests := []struct {
+		name     string
+		find     func() interface{}
+		index    func() interface{}
+		expected interface{}
+	}{
+		{
+			name:     "Test Group",
+			find:     func() interface{} { return FindTestGroup(cfg, "test_group_2") },
+			index:    func() interface{} { return idx.TestGroup("test_group_2") },
+			expected: tgs[2],
+		},
+		{
+			name:     "Test Group after normalizing; first wins",
+			find:     func() interface{} { return FindTestGroup(cfg, "TEST-GROUP-1") },
+			index:    func() interface{} { return idx.TestGroup("TEST-GROUP-1") },
+			expected: tgs[0],
+		},
+		{
+			name:     "Missing Test Group",
+			find:     func() interface{} { return FindTestGroup(cfg, "test_group_3") },
+			index:    func() interface{} { return idx.TestGroup("test_group_3") },
+			expected: (*configpb.TestGroup)(nil),
+		},
+		{
+			name:     "Dashboard",
+			find:     func() interface{} { return FindDashboard(cfg, "Dashboard 1") },
+			index:    func() interface{} { return idx.Dashboard("Dashboard 1") },
+			expected: dash,
+		},
+		{
+			name:     "Missing Dashboard",
+			find:     func() interface{} { return FindDashboard(cfg, "dashboard_2") },
+			index:    func() interface{} { return idx.Dashboard("dashboard_2") },
+			expected: (*configpb.Dashboard)(nil),
+		},
+		{
+			name:     "Dashboard Group",
+			find:     func() interface{} { return FindDashboardGroup(cfg, "dashboardgroup1") },
+			index:    func() interface{} { return idx.DashboardGroup("dashboardgroup1") },
+			expected: dg,
+		},
+		{
+			name:     "Missing Dashboard Group",
+			find:     func() interface{} { return FindDashboardGroup(cfg, "dashboard_1") },
+			index:    func() interface{} { return idx.DashboardGroup("dashboard_1") },
+			expected: (*configpb.DashboardGroup)(nil),
+		},
+		{
+			name:     "Dashboard Tab",
+			find:     func() interface{} { return FindDashboardTab(cfg, "DASHBOARD_1", "Tab 2") },
+			index:    func() interface{} { return idx.DashboardTab("DASHBOARD_1", "Tab 2") },
+			expected: tabs[1],
+		},
+		{
+			name:     "Missing Dashboard Tab",
+			find:     func() interface{} { return FindDashboardTab(cfg, "dashboard_1", "tab_3") },
+			index:    func() interface{} { return idx.DashboardTab("dashboard_1", "tab_3") },
+			expected: (*configpb.DashboardTab)(nil),
+		},
+		{
+			name:     "Dashboard Tab in missing Dashboard",
+			find:     func() interface{} { return FindDashboardTab(cfg, "dashboard_2", "tab_1") },
+			index:    func() interface{} { return idx.DashboardTab("dashboard_2", "tab_1") },
+			expected: (*configpb.DashboardTab)(nil),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.find(); got != test.expected {
+				t.Errorf("Find: expected %v, but got: %v", test.expected, got)
+			}
+			if got := test.index(); got != test.expected {
+				t.Errorf("Index: expected %v, but got: %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestTabsForTestGroup(t *testing.T) {
+	cfg := &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{
+			{Name: "shared_group"},
+			{Name: "other_group"},
+			{Name: "unused_group"},
+		},
+		DashboardGroups: []*configpb.DashboardGroup{
+			{Name: "dashboard_group", DashboardNames: []string{"dashboard_2"}},
+		},
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name: "dashboard_1",
+				DashboardTab: []*configpb.DashboardTab{
+					{Name: "tab_1", TestGroupName: "shared_group"},
+					{Name: "tab_2", TestGroupName: "other_group"},
+					{Name: "tab_3", TestGroupName: "Shared-Group"},
+				},
+			},
+			{
+				Name: "dashboard_2",
+				DashboardTab: []*configpb.DashboardTab{
+					{Name: "tab_1", TestGroupName: "shared_group"},
+				},
+			},
+		},
+	}
+	tabs1 := cfg.Dashboards[0].DashboardTab
+	tabs2 := cfg.Dashboards[1].DashboardTab
+
+	tests := []struct {
+		name     string
+		group    string
+		expected []TabLocation
+	}{
+		{
+			name:  "Referenced from multiple dashboards",
+			group: "shared_group",
+			expected: []TabLocation{
+				{"dashboard_1", "tab_1", tabs1[0], ""},
+				{"dashboard_1", "tab_3", tabs1[2], ""},
+				{"dashboard_2", "tab_1", tabs2[0], "dashboard_group"},
+			},
+		},
+		{
+			name:  "Normalizes the group name",
+			group: "OTHER GROUP",
+			expected: []TabLocation{
+				{"dashboard_1", "tab_2", tabs1[1], ""},
+			},
+		},
+		{
+			name:  "Referenced by zero tabs",
+			group: "unused_group",
+		},
+		{
+			name:  "Missing group",
+			group: "missing_group",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := TabsForTestGroup(cfg, test.group)
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("expected %v, but got: %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func BenchmarkFindTestGroup(b *testing.B) {
+	cfg := syntheticConfig(5000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		FindTestGroup(&cfg, fmt.Sprintf("test_group_%d", i%5000))
+	}
+}
+
+func BenchmarkIndex_TestGroup(b *testing.B) {
+	cfg := syntheticConfig(5000)
+	idx := NewIndex(&cfg)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		idx.TestGroup(fmt.Sprintf("test_group_%d", i%5000))
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// minimalConfig returns a valid configuration with one of each entity.
+func minimalConfig() configpb.Configuration {
+	return configpb.Configuration{
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name: "dashboard_1",
+				DashboardTab: []*configpb.DashboardTab{
+					{
+						Name:          "tab_1",
+						TestGroupName: "test_group_1",
+					},
+				},
+			},
+		},
+		TestGroups: []*configpb.TestGroup{
+			{
+				Name:  "test_group_1",
+				Query: "bucket/logs/job-1",
+			},
+		},
+	}
+}
+
+// syntheticConfig returns a valid configuration with n Test Groups, displayed by Dashboards of 10 tabs.
+func syntheticConfig(n int) configpb.Configuration {
+	var c configpb.Configuration
+	dg := &configpb.DashboardGroup{Name: "synthetic"}
+	c.DashboardGroups = append(c.DashboardGroups, dg)
+	var dash *configpb.Dashboard
+	for i := 0; i < n; i++ {
+		tg := &configpb.TestGroup{
+			Name:  fmt.Sprintf("test_group_%d", i),
+			Query: fmt.Sprintf("bucket/logs/job-%d", i),
+		}
+		c.TestGroups = append(c.TestGroups, tg)
+		if i%10 == 0 {
+			dash = &configpb.Dashboard{Name: fmt.Sprintf("synthetic_dashboard_%d", i/10)}
+			c.Dashboards = append(c.Dashboards, dash)
+			dg.DashboardNames = append(dg.DashboardNames, dash.Name)
+		}
+		dash.DashboardTab = append(dash.DashboardTab, &configpb.DashboardTab{
+			Name:          fmt.Sprintf("tab_%d", i%10),
+			TestGroupName: tg.Name,
+		})
+	}
+	return c
+}
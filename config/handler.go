@@ -0,0 +1,169 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// NewHandler returns a read-only http.Handler serving the entities of the config from getter as JSON.
+//
+// It serves these paths, so use http.StripPrefix to mount it elsewhere:
+//
+//	/dashboards                  every Dashboard
+//	/dashboards/{name}/tabs      the Dashboard Tabs of the named Dashboard
+//	/test-groups/{name}          the named Test Group
+//	/dashboard-groups            every Dashboard Group
+//
+// Names match by their normalized form, and unknown entities are not found.
+// Each response has an ETag of the whole config, so pollers sending it as
+// If-None-Match are not modified until the config changes.
+func NewHandler(getter func() *configpb.Configuration) http.Handler {
+	return &handler{getter: getter}
+}
+
+type handler struct {
+	getter func() *configpb.Configuration
+
+	lock sync.Mutex
+	cfg  *configpb.Configuration // config of the etag
+	etag string
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "read-only", http.StatusMethodNotAllowed)
+		return
+	}
+	cfg := h.getter()
+	if cfg == nil {
+		http.Error(w, "config unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	msgs, list, err := route(cfg, r.URL)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	etag, err := h.etagOf(cfg)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	body, err := marshalJSON(msgs, list)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// route returns the entities at the path of u, and whether they are a list.
+func route(cfg *configpb.Configuration, u *url.URL) ([]proto.Message, bool, error) {
+	var parts []string
+	for _, p := range strings.Split(strings.Trim(u.EscapedPath(), "/"), "/") {
+		part, err := url.PathUnescape(p)
+		if err != nil {
+			return nil, false, fmt.Errorf("bad path %q: %v", u.Path, err)
+		}
+		parts = append(parts, part)
+	}
+	var msgs []proto.Message
+	switch {
+	case len(parts) == 1 && parts[0] == "dashboards":
+		for _, d := range cfg.Dashboards {
+			msgs = append(msgs, d)
+		}
+		return msgs, true, nil
+	case len(parts) == 3 && parts[0] == "dashboards" && parts[2] == "tabs":
+		d := FindDashboard(cfg, parts[1])
+		if d == nil {
+			return nil, false, fmt.Errorf("dashboard %q not found", parts[1])
+		}
+		for _, tab := range d.DashboardTab {
+			msgs = append(msgs, tab)
+		}
+		return msgs, true, nil
+	case len(parts) == 2 && parts[0] == "test-groups":
+		tg := FindTestGroup(cfg, parts[1])
+		if tg == nil {
+			return nil, false, fmt.Errorf("test group %q not found", parts[1])
+		}
+		return []proto.Message{tg}, false, nil
+	case len(parts) == 1 && parts[0] == "dashboard-groups":
+		for _, dg := range cfg.DashboardGroups {
+			msgs = append(msgs, dg)
+		}
+		return msgs, true, nil
+	}
+	return nil, false, fmt.Errorf("%s not found", u.Path)
+}
+
+// marshalJSON encodes the entities as a JSON array when list is set, or else the only entity.
+func marshalJSON(msgs []proto.Message, list bool) ([]byte, error) {
+	if !list {
+		s, err := canonicalMarshaler.MarshalToString(msgs[0])
+		if err != nil {
+			return nil, fmt.Errorf("marshal: %v", err)
+		}
+		return []byte(s), nil
+	}
+	var buf bytes.Buffer
+	buf.WriteString("[")
+	for i, m := range msgs {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		if err := canonicalMarshaler.Marshal(&buf, m); err != nil {
+			return nil, fmt.Errorf("marshal: %v", err)
+		}
+	}
+	buf.WriteString("]")
+	return buf.Bytes(), nil
+}
+
+// etagOf returns the quoted Hash of the config, computing it again only when the getter returns a different config.
+func (h *handler) etagOf(cfg *configpb.Configuration) (string, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.cfg == cfg {
+		return h.etag, nil
+	}
+	hash, err := Hash(cfg)
+	if err != nil {
+		return "", fmt.Errorf("hash config: %v", err)
+	}
+	h.cfg = cfg
+	h.etag = `"` + hash + `"`
+	return h.etag, nil
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+func TestHandler(t *testing.T) {
+	cfg := &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{
+			{Name: "some-group", DaysOfResults: 7},
+		},
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name: "Some Dashboard",
+				DashboardTab: []*configpb.DashboardTab{
+					{Name: "first", TestGroupName: "some-group"},
+					{Name: "second", TestGroupName: "some-group"},
+				},
+			},
+			{Name: "empty"},
+		},
+		DashboardGroups: []*configpb.DashboardGroup{
+			{Name: "release", DashboardNames: []string{"Some Dashboard"}},
+		},
+	}
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		code   int
+		single bool
+		names  []string
+	}{
+		{
+			name:  "dashboards",
+			path:  "/dashboards",
+			code:  http.StatusOK,
+			names: []string{"Some Dashboard", "empty"},
+		},
+		{
+			name:  "dashboard tabs",
+			path:  "/dashboards/some-dashboard/tabs",
+			code:  http.StatusOK,
+			names: []string{"first", "second"},
+		},
+		{
+			name:  "escaped dashboard name",
+			path:  "/dashboards/Some%20Dashboard/tabs",
+			code:  http.StatusOK,
+			names: []string{"first", "second"},
+		},
+		{
+			name:  "no tabs",
+			path:  "/dashboards/empty/tabs",
+			code:  http.StatusOK,
+			names: []string{},
+		},
+		{
+			name: "unknown dashboard",
+			path: "/dashboards/missing/tabs",
+			code: http.StatusNotFound,
+		},
+		{
+			name:   "test group",
+			path:   "/test-groups/SomeGroup",
+			code:   http.StatusOK,
+			single: true,
+			names:  []string{"some-group"},
+		},
+		{
+			name: "unknown test group",
+			path: "/test-groups/missing",
+			code: http.StatusNotFound,
+		},
+		{
+			name:  "dashboard groups",
+			path:  "/dashboard-groups/",
+			code:  http.StatusOK,
+			names: []string{"release"},
+		},
+		{
+			name: "unknown path",
+			path: "/dashboards/some-dashboard",
+			code: http.StatusNotFound,
+		},
+		{
+			name:   "read-only",
+			method: http.MethodPost,
+			path:   "/dashboards",
+			code:   http.StatusMethodNotAllowed,
+		},
+	}
+
+	h := NewHandler(func() *configpb.Configuration { return cfg })
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			method := tc.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(method, tc.path, nil))
+			if rec.Code != tc.code {
+				t.Fatalf("ServeHTTP(%s %s) got code %d, want %d: %s", method, tc.path, rec.Code, tc.code, rec.Body)
+			}
+			if tc.code != http.StatusOK {
+				return
+			}
+			if got := rec.Header().Get("Content-Type"); got != "application/json" {
+				t.Errorf("ServeHTTP() got Content-Type %q, want application/json", got)
+			}
+			type named struct {
+				Name string `json:"name"`
+			}
+			var entities []named
+			if tc.single {
+				var e named
+				if err := json.Unmarshal(rec.Body.Bytes(), &e); err != nil {
+					t.Fatalf("ServeHTTP() wrote bad JSON %s: %v", rec.Body, err)
+				}
+				entities = append(entities, e)
+			} else if err := json.Unmarshal(rec.Body.Bytes(), &entities); err != nil {
+				t.Fatalf("ServeHTTP() wrote bad JSON %s: %v", rec.Body, err)
+			}
+			got := []string{}
+			for _, e := range entities {
+				got = append(got, e.Name)
+			}
+			if !reflect.DeepEqual(got, tc.names) {
+				t.Errorf("ServeHTTP() got names %v, want %v", got, tc.names)
+			}
+		})
+	}
+}
+
+func TestHandlerETag(t *testing.T) {
+	cfg := &configpb.Configuration{
+		Dashboards: []*configpb.Dashboard{{Name: "dash"}},
+	}
+	h := NewHandler(func() *configpb.Configuration { return cfg })
+	get := func(etag string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/dashboards", nil)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := get("")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" {
+		t.Fatalf("ServeHTTP() got code %d and ETag %q, want 200 with an ETag", first.Code, etag)
+	}
+	if rec := get(etag); rec.Code != http.StatusNotModified || rec.Body.Len() > 0 {
+		t.Errorf("ServeHTTP(If-None-Match: %s) got code %d with %d bytes, want 304 without a body", etag, rec.Code, rec.Body.Len())
+	}
+
+	cfg = &configpb.Configuration{
+		Dashboards: []*configpb.Dashboard{{Name: "changed"}},
+	}
+	rec := get(etag)
+	if rec.Code != http.StatusOK {
+		t.Errorf("ServeHTTP(If-None-Match: %s) got code %d after the config changed, want 200", etag, rec.Code)
+	}
+	if got := rec.Header().Get("ETag"); got == etag {
+		t.Errorf("ServeHTTP() kept ETag %s after the config changed", got)
+	}
+}
+
+func TestHandlerUnavailable(t *testing.T) {
+	h := NewHandler(func() *configpb.Configuration { return nil })
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/dashboards", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("ServeHTTP() got code %d without a config, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// canonicalMarshaler serializes messages canonically: fields in declaration order, map entries sorted by key and without unknown fields.
+var canonicalMarshaler = jsonpb.Marshaler{OrigName: true}
+
+// Hash returns a stable hash of the config, which only changes when a field of the config does.
+//
+// Compare hashes from different cycles to detect changes cheaply. The order of map
+// entries, the order of fields on the wire and unknown fields do not affect the hash.
+func Hash(cfg *configpb.Configuration) (string, error) {
+	return hashMessage(cfg)
+}
+
+// EntityHashes holds the Hash of each Test Group and Dashboard, by normalized name.
+type EntityHashes struct {
+	TestGroups map[string]string
+	Dashboards map[string]string
+}
+
+// HashEntities returns the hash of each Test Group and Dashboard in the config.
+//
+// Like Index, the first entity wins when normalized names collide.
+func HashEntities(cfg *configpb.Configuration) (*EntityHashes, error) {
+	hashes := EntityHashes{
+		TestGroups: map[string]string{},
+		Dashboards: map[string]string{},
+	}
+	for _, tg := range cfg.GetTestGroups() {
+		name := Normalize(tg.Name)
+		if _, ok := hashes.TestGroups[name]; ok {
+			continue
+		}
+		h, err := hashMessage(tg)
+		if err != nil {
+			return nil, fmt.Errorf("test group %s: %w", tg.Name, err)
+		}
+		hashes.TestGroups[name] = h
+	}
+	for _, d := range cfg.GetDashboards() {
+		name := Normalize(d.Name)
+		if _, ok := hashes.Dashboards[name]; ok {
+			continue
+		}
+		h, err := hashMessage(d)
+		if err != nil {
+			return nil, fmt.Errorf("dashboard %s: %w", d.Name, err)
+		}
+		hashes.Dashboards[name] = h
+	}
+	return &hashes, nil
+}
+
+// hashMessage returns the hex sha256 of the canonical serialization of msg.
+func hashMessage(msg proto.Message) (string, error) {
+	hash := sha256.New()
+	if err := canonicalMarshaler.Marshal(hash, msg); err != nil {
+		return "", fmt.Errorf("marshal: %v", err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// hashConfig returns minimalConfig with a second Test Group, where both Test Groups set an option.
+func hashConfig() *configpb.Configuration {
+	c := minimalConfig()
+	c.TestGroups[0].DaysOfResults = 7
+	c.TestGroups = append(c.TestGroups, &configpb.TestGroup{Name: "test_group_2", NumColumnsRecent: 3})
+	return &c
+}
+
+func TestHash(t *testing.T) {
+	want, err := Hash(hashConfig())
+	if err != nil {
+		t.Fatalf("Hash() got unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		name   string
+		mutate func(*configpb.Configuration)
+		same   bool
+	}{
+		{
+			name:   "identical",
+			mutate: func(*configpb.Configuration) {},
+			same:   true,
+		},
+		{
+			name: "fields set in a different order",
+			mutate: func(cfg *configpb.Configuration) {
+				tg := &configpb.TestGroup{}
+				tg.NumColumnsRecent = 3
+				tg.Name = "test_group_2"
+				cfg.TestGroups[1] = tg
+			},
+			same: true,
+		},
+		{
+			name: "unknown fields",
+			mutate: func(cfg *configpb.Configuration) {
+				cfg.XXX_unrecognized = []byte{0xf8, 0x07, 0x01}
+				cfg.TestGroups[0].XXX_unrecognized = []byte{0xf8, 0x07, 0x02}
+			},
+			same: true,
+		},
+		{
+			name: "cached sizes",
+			mutate: func(cfg *configpb.Configuration) {
+				proto.Size(cfg)
+			},
+			same: true,
+		},
+		{
+			name: "roundtrip",
+			mutate: func(cfg *configpb.Configuration) {
+				buf, err := proto.Marshal(cfg)
+				if err != nil {
+					t.Fatalf("Marshal() got unexpected error: %v", err)
+				}
+				if err := proto.Unmarshal(buf, cfg); err != nil {
+					t.Fatalf("Unmarshal() got unexpected error: %v", err)
+				}
+			},
+			same: true,
+		},
+		{
+			name: "changed field",
+			mutate: func(cfg *configpb.Configuration) {
+				cfg.TestGroups[0].DaysOfResults = 8
+			},
+		},
+		{
+			name: "reordered test groups",
+			mutate: func(cfg *configpb.Configuration) {
+				cfg.TestGroups[0], cfg.TestGroups[1] = cfg.TestGroups[1], cfg.TestGroups[0]
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := hashConfig()
+			tc.mutate(cfg)
+			got, err := Hash(cfg)
+			if err != nil {
+				t.Fatalf("Hash() got unexpected error: %v", err)
+			}
+			if same := got == want; same != tc.same {
+				t.Errorf("Hash() got %s, want %s: same %t, want %t", got, want, same, tc.same)
+			}
+		})
+	}
+}
+
+func TestHashEntities(t *testing.T) {
+	before, err := HashEntities(hashConfig())
+	if err != nil {
+		t.Fatalf("HashEntities() got unexpected error: %v", err)
+	}
+	if n := len(before.TestGroups); n != 2 {
+		t.Errorf("HashEntities() got %d test groups, want 2", n)
+	}
+	if n := len(before.Dashboards); n != 1 {
+		t.Errorf("HashEntities() got %d dashboards, want 1", n)
+	}
+
+	cfg := hashConfig()
+	cfg.TestGroups[1].NumColumnsRecent = 4
+	cfg.TestGroups = append(cfg.TestGroups, &configpb.TestGroup{Name: "Test Group 1", DaysOfResults: 1})
+	after, err := HashEntities(cfg)
+	if err != nil {
+		t.Fatalf("HashEntities() got unexpected error: %v", err)
+	}
+	if before.TestGroups["testgroup1"] != after.TestGroups["testgroup1"] {
+		t.Errorf("HashEntities() changed the hash of the unchanged test_group_1, or of its duplicate")
+	}
+	if before.TestGroups["testgroup2"] == after.TestGroups["testgroup2"] {
+		t.Errorf("HashEntities() kept the hash of the changed test_group_2")
+	}
+	if before.Dashboards["dashboard1"] != after.Dashboards["dashboard1"] {
+		t.Errorf("HashEntities() changed the hash of the unchanged dashboard")
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"encoding/json"
+)
+
+// ConfigIssue is a machine-readable problem found while validating a configuration.
+type ConfigIssue interface {
+	error
+	// Path identifies the offending entity, such as TestGroup/foo.
+	Path() string
+	// Kind identifies the type of problem, such as DuplicateName.
+	Kind() string
+	// Description explains the problem, without identifying the entity.
+	Description() string
+}
+
+// IssuesFromError returns every ConfigIssue in the error returned by Validate.
+//
+// Errors which are not a ConfigIssue are ignored.
+func IssuesFromError(err error) []ConfigIssue {
+	var issues []ConfigIssue
+	for _, e := range errorsOf(err) {
+		if issue, ok := e.(ConfigIssue); ok {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// issueJSON is the JSON representation of every ConfigIssue.
+type issueJSON struct {
+	Kind    string `json:"kind"`
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func marshalIssue(issue ConfigIssue) ([]byte, error) {
+	return json.Marshal(issueJSON{
+		Kind:    issue.Kind(),
+		Path:    issue.Path(),
+		Message: issue.Description(),
+	})
+}
+
+func entityPath(entity, name string) string {
+	return entity + "/" + name
+}
+
+// Path returns the missing field.
+func (e MissingFieldError) Path() string { return e.Field }
+
+// Kind returns MissingField.
+func (e MissingFieldError) Kind() string { return "MissingField" }
+
+// Description explains the field is required.
+func (e MissingFieldError) Description() string { return "field missing or unset" }
+
+// MarshalJSON encodes the error as a {kind, path, message} object.
+func (e MissingFieldError) MarshalJSON() ([]byte, error) { return marshalIssue(e) }
+
+// Path returns Entity/Name.
+func (e DuplicateNameError) Path() string { return entityPath(e.Entity, e.Name) }
+
+// Kind returns DuplicateName.
+func (e DuplicateNameError) Kind() string { return "DuplicateName" }
+
+// Description explains the name is not unique, along with the original names.
+func (e DuplicateNameError) Description() string {
+	return "found duplicate name after normalizing" + e.from()
+}
+
+// MarshalJSON encodes the error as a {kind, path, message} object.
+func (e DuplicateNameError) MarshalJSON() ([]byte, error) { return marshalIssue(e) }
+
+// Path returns Entity/Name.
+func (e MissingEntityError) Path() string { return entityPath(e.Entity, e.Name) }
+
+// Kind returns MissingEntity.
+func (e MissingEntityError) Kind() string { return "MissingEntity" }
+
+// Description explains the referenced entity does not exist, along with any suggestion.
+func (e MissingEntityError) Description() string {
+	return "could not find the referenced entity" + e.didYouMean()
+}
+
+// MarshalJSON encodes the error as a {kind, path, message} object.
+func (e MissingEntityError) MarshalJSON() ([]byte, error) { return marshalIssue(e) }
+
+// Path returns Entity/Name.
+func (e ConfigError) Path() string { return entityPath(e.Entity, e.Name) }
+
+// Kind returns ConfigError.
+func (e ConfigError) Kind() string { return "ConfigError" }
+
+// Description returns the Message.
+func (e ConfigError) Description() string { return e.Message }
+
+// MarshalJSON encodes the error as a {kind, path, message} object.
+func (e ConfigError) MarshalJSON() ([]byte, error) { return marshalIssue(e) }
+
+// Path returns Entity/Name.
+func (e ConfigWarning) Path() string { return entityPath(e.Entity, e.Name) }
+
+// Kind returns ConfigWarning.
+func (e ConfigWarning) Kind() string { return "ConfigWarning" }
+
+// Description returns the Message.
+func (e ConfigWarning) Description() string { return e.Message }
+
+// MarshalJSON encodes the error as a {kind, path, message} object.
+func (e ConfigWarning) MarshalJSON() ([]byte, error) { return marshalIssue(e) }
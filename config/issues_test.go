@@ -0,0 +1,131 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+func TestIssuesFromError(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    error
+		expected []ConfigIssue
+	}{
+		{
+			name: "No error",
+		},
+		{
+			name:     "Single issue",
+			input:    MissingFieldError{"TestGroups", nil},
+			expected: []ConfigIssue{MissingFieldError{"TestGroups", nil}},
+		},
+		{
+			name: "Multiple issues",
+			input: multierror.Append(
+				DuplicateNameError{"testgroup1", "TestGroup", nil, nil},
+				MissingEntityError{"dashboard_2", "Dashboard", "", nil},
+				ConfigError{"test_group_1", "TestGroup", "Bad.", nil},
+			),
+			expected: []ConfigIssue{
+				DuplicateNameError{"testgroup1", "TestGroup", nil, nil},
+				MissingEntityError{"dashboard_2", "Dashboard", "", nil},
+				ConfigError{"test_group_1", "TestGroup", "Bad.", nil},
+			},
+		},
+		{
+			name: "Ignores other errors",
+			input: multierror.Append(
+				errors.New("something else"),
+				ConfigWarning{"test_group_1", "TestGroup", "Odd.", nil},
+			),
+			expected: []ConfigIssue{
+				ConfigWarning{"test_group_1", "TestGroup", "Odd.", nil},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := IssuesFromError(test.input)
+			if !reflect.DeepEqual(test.expected, got) {
+				t.Fatalf("Expected %v, but got: %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestConfigIssue_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    ConfigIssue
+		expected string
+	}{
+		{
+			name:     "MissingFieldError",
+			input:    MissingFieldError{"TestGroups", nil},
+			expected: `{"kind":"MissingField","path":"TestGroups","message":"field missing or unset"}`,
+		},
+		{
+			name:     "DuplicateNameError",
+			input:    DuplicateNameError{"testgroup1", "TestGroup", nil, nil},
+			expected: `{"kind":"DuplicateName","path":"TestGroup/testgroup1","message":"found duplicate name after normalizing"}`,
+		},
+		{
+			name:     "DuplicateNameError with originals",
+			input:    DuplicateNameError{"testgroup1", "TestGroup", []string{"test_group_1", "TEST GROUP 1"}, nil},
+			expected: `{"kind":"DuplicateName","path":"TestGroup/testgroup1","message":"found duplicate name after normalizing (from \"test_group_1\", \"TEST GROUP 1\")"}`,
+		},
+		{
+			name:     "MissingEntityError",
+			input:    MissingEntityError{"dashboard_2", "Dashboard", "", nil},
+			expected: `{"kind":"MissingEntity","path":"Dashboard/dashboard_2","message":"could not find the referenced entity"}`,
+		},
+		{
+			name:     "MissingEntityError with suggestion",
+			input:    MissingEntityError{"dashboard_2", "Dashboard", "dashboard_1", nil},
+			expected: `{"kind":"MissingEntity","path":"Dashboard/dashboard_2","message":"could not find the referenced entity; did you mean \"dashboard_1\"?"}`,
+		},
+		{
+			name:     "ConfigError",
+			input:    ConfigError{"test_group_1", "TestGroup", "Bad.", nil},
+			expected: `{"kind":"ConfigError","path":"TestGroup/test_group_1","message":"Bad."}`,
+		},
+		{
+			name:     "ConfigWarning",
+			input:    ConfigWarning{"test_group_1", "TestGroup", "Odd.", nil},
+			expected: `{"kind":"ConfigWarning","path":"TestGroup/test_group_1","message":"Odd."}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := json.Marshal(test.input)
+			if err != nil {
+				t.Fatalf("Unexpected Error: %v", err)
+			}
+			if string(got) != test.expected {
+				t.Fatalf("Expected %s, but got: %s", test.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// MaxLinkLength is the longest link RenderLink returns, which most bug trackers accept.
+const MaxLinkLength = 2000
+
+// LinkValues holds what RenderLink substitutes for the <placeholders> of a LinkTemplate.
+type LinkValues struct {
+	// Dashboard replaces <dashboard-name>.
+	Dashboard string
+	// Tab replaces <tab-name>.
+	Tab string
+	// TestName replaces <test-name>, such as the name of a failing row.
+	TestName string
+	// BuildIDs replace <build-id>, separated by commas, such as the failing builds of a row newest first.
+	BuildIDs []string
+	// GcsPrefix replaces <gcs_prefix>, such as bucket/logs/job, keeping its slashes in the path of the url.
+	GcsPrefix string
+}
+
+func (v LinkValues) placeholders() map[string]string {
+	return map[string]string{
+		"dashboard-name": v.Dashboard,
+		"tab-name":       v.Tab,
+		"test-name":      v.TestName,
+		"build-id":       strings.Join(v.BuildIDs, ","),
+		"gcs_prefix":     v.GcsPrefix,
+	}
+}
+
+// RenderLink expands the LinkTemplate, such as the file_bug_template of a Dashboard Tab, into a link.
+//
+// Values are escaped for the part of the url they appear in, and each option is appended as an
+// escaped query parameter. Other placeholders expand to nothing. Links longer than MaxLinkLength
+// shorten the longest option values, such as the description of a bug, ending them with "...".
+func RenderLink(tmpl *configpb.LinkTemplate, values LinkValues) (string, error) {
+	if tmpl.GetUrl() == "" {
+		return "", fmt.Errorf("missing url")
+	}
+	if _, err := validateLinkTemplate("template", tmpl); err != nil {
+		return "", err
+	}
+	vals := values.placeholders()
+	base := expandURL(tmpl.Url, vals)
+	keys := make([]string, len(tmpl.Options))
+	opts := make([]string, len(tmpl.Options))
+	for i, opt := range tmpl.Options {
+		keys[i] = opt.Key
+		opts[i] = expand(opt.Value, vals, nil)
+	}
+	link := joinQuery(base, keys, opts)
+	for len(link) > MaxLinkLength {
+		i := longest(opts)
+		if i < 0 || opts[i] == "" {
+			return "", fmt.Errorf("link is %d bytes, more than %d without options", len(link), MaxLinkLength)
+		}
+		// Remove the share of the value escaping to the excess bytes, repeating when characters escape unevenly.
+		excess, escaped := len(link)-MaxLinkLength, len(url.QueryEscape(opts[i]))
+		opts[i] = shorten(opts[i], (excess*len(opts[i])+escaped-1)/escaped)
+		link = joinQuery(base, keys, opts)
+	}
+	return link, nil
+}
+
+// expandURL expands the url template, escaping values in the path and query as appropriate.
+func expandURL(tmpl string, vals map[string]string) string {
+	var query bool
+	return expand(tmpl, vals, func(literal, name, val string) string {
+		if strings.Contains(literal, "?") {
+			query = true
+		}
+		if query {
+			return url.QueryEscape(val)
+		}
+		if name == "gcs_prefix" {
+			parts := strings.Split(val, "/")
+			for i, p := range parts {
+				parts[i] = url.PathEscape(p)
+			}
+			return strings.Join(parts, "/")
+		}
+		return url.PathEscape(val)
+	})
+}
+
+// expand replaces each <placeholder> of a validated tmpl, after passing the preceding literal text, its name and value to escape, when set.
+func expand(tmpl string, vals map[string]string, escape func(literal, name, val string) string) string {
+	var out strings.Builder
+	for {
+		start := strings.Index(tmpl, "<")
+		if start < 0 {
+			out.WriteString(tmpl)
+			return out.String()
+		}
+		end := start + strings.Index(tmpl[start:], ">")
+		literal, name := tmpl[:start], tmpl[start+1:end]
+		val := vals[name]
+		if escape != nil {
+			val = escape(literal, name, val)
+		}
+		out.WriteString(literal)
+		out.WriteString(val)
+		tmpl = tmpl[end+1:]
+	}
+}
+
+// joinQuery appends each key=value to the base url as query parameters.
+func joinQuery(base string, keys, vals []string) string {
+	if len(keys) == 0 {
+		return base
+	}
+	var out strings.Builder
+	out.WriteString(base)
+	sep := "?"
+	if strings.Contains(base, "?") {
+		sep = "&"
+	}
+	for i, k := range keys {
+		out.WriteString(sep)
+		out.WriteString(url.QueryEscape(k))
+		out.WriteString("=")
+		out.WriteString(url.QueryEscape(vals[i]))
+		sep = "&"
+	}
+	return out.String()
+}
+
+// longest returns the index of the longest value, or -1 without values.
+func longest(vals []string) int {
+	idx := -1
+	for i, v := range vals {
+		if idx < 0 || len(v) > len(vals[idx]) {
+			idx = i
+		}
+	}
+	return idx
+}
+
+const ellipsis = "..."
+
+// shorten removes at least n bytes from the end of s, without splitting a character, and marks the cut with an ellipsis.
+func shorten(s string, n int) string {
+	n += len(ellipsis)
+	if strings.HasSuffix(s, ellipsis) {
+		s = strings.TrimSuffix(s, ellipsis)
+		n -= len(ellipsis)
+	}
+	if n >= len(s) {
+		return ""
+	}
+	end := len(s) - n
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	return s[:end] + ellipsis
+}
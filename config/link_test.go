@@ -0,0 +1,205 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+func TestRenderLink(t *testing.T) {
+	values := LinkValues{
+		Dashboard: "sig-release",
+		Tab:       "build & test",
+		TestName:  "TestFoo/bar baz?",
+		BuildIDs:  []string{"3", "2"},
+	}
+	cases := []struct {
+		name   string
+		tmpl   *configpb.LinkTemplate
+		values LinkValues
+		want   string
+		err    bool
+	}{
+		{
+			name: "missing url",
+			tmpl: &configpb.LinkTemplate{},
+			err:  true,
+		},
+		{
+			name: "unknown placeholder",
+			tmpl: &configpb.LinkTemplate{Url: "https://bugs.example.com/<bug>"},
+			err:  true,
+		},
+		{
+			name: "no placeholders",
+			tmpl: &configpb.LinkTemplate{Url: "https://bugs.example.com/new"},
+			want: "https://bugs.example.com/new",
+		},
+		{
+			name:   "escape in the path",
+			tmpl:   &configpb.LinkTemplate{Url: "https://example.com/<dashboard-name>/<test-name>/<build-id>"},
+			values: values,
+			want:   "https://example.com/sig-release/TestFoo%2Fbar%20baz%3F/3%2C2",
+		},
+		{
+			name:   "escape in the query",
+			tmpl:   &configpb.LinkTemplate{Url: "https://example.com/new?title=<test-name>&tab=<tab-name>"},
+			values: values,
+			want:   "https://example.com/new?title=TestFoo%2Fbar+baz%3F&tab=build+%26+test",
+		},
+		{
+			name:   "keep the slashes of the gcs_prefix",
+			tmpl:   &configpb.LinkTemplate{Url: "https://example.com/<gcs_prefix>/<build-id>?prefix=<gcs_prefix>"},
+			values: LinkValues{GcsPrefix: "bucket/logs/my job", BuildIDs: []string{"3"}},
+			want:   "https://example.com/bucket/logs/my%20job/3?prefix=bucket%2Flogs%2Fmy+job",
+		},
+		{
+			name: "options",
+			tmpl: &configpb.LinkTemplate{
+				Url: "https://github.com/org/repo/issues/new",
+				Options: []*configpb.LinkOptionsTemplate{
+					{Key: "title", Value: "<test-name> fails"},
+					{Key: "body", Value: "<dashboard-name>#<tab-name> failed in <build-id>"},
+				},
+			},
+			values: values,
+			want:   "https://github.com/org/repo/issues/new?title=TestFoo%2Fbar+baz%3F+fails&body=sig-release%23build+%26+test+failed+in+3%2C2",
+		},
+		{
+			name: "options after a query",
+			tmpl: &configpb.LinkTemplate{
+				Url: "https://example.com/new?component=123",
+				Options: []*configpb.LinkOptionsTemplate{
+					{Key: "title", Value: "<test-name>"},
+				},
+			},
+			values: LinkValues{TestName: "foo"},
+			want:   "https://example.com/new?component=123&title=foo",
+		},
+		{
+			name: "missing values are empty",
+			tmpl: &configpb.LinkTemplate{
+				Url: "https://example.com/<test-name>",
+				Options: []*configpb.LinkOptionsTemplate{
+					{Key: "cc", Value: "<cc>"},
+				},
+			},
+			want: "https://example.com/?cc=",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := RenderLink(tc.tmpl, tc.values)
+			switch {
+			case err != nil && !tc.err:
+				t.Fatalf("RenderLink() got unexpected error: %v", err)
+			case err == nil && tc.err:
+				t.Fatalf("RenderLink() got %q, wanted an error", got)
+			case got != tc.want:
+				t.Errorf("RenderLink() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRenderLinkLength(t *testing.T) {
+	tmpl := &configpb.LinkTemplate{
+		Url: "https://example.com/new",
+		Options: []*configpb.LinkOptionsTemplate{
+			{Key: "title", Value: "<test-name> fails"},
+			{Key: "description", Value: "failed in <build-id>"},
+		},
+	}
+	var builds []string
+	for i := 0; i < 500; i++ {
+		builds = append(builds, "búild")
+	}
+	link, err := RenderLink(tmpl, LinkValues{TestName: "TestFoo", BuildIDs: builds})
+	if err != nil {
+		t.Fatalf("RenderLink() got unexpected error: %v", err)
+	}
+	if len(link) > MaxLinkLength {
+		t.Errorf("RenderLink() got %d bytes, want at most %d", len(link), MaxLinkLength)
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("RenderLink() got a bad url %q: %v", link, err)
+	}
+	q := u.Query()
+	if got, want := q.Get("title"), "TestFoo fails"; got != want {
+		t.Errorf("RenderLink() shortened the title to %q, want %q", got, want)
+	}
+	desc := q.Get("description")
+	if !strings.HasPrefix(desc, "failed in búild,") || !strings.HasSuffix(desc, ellipsis) {
+		t.Errorf("RenderLink() got description %q, want a shortened list of builds", desc)
+	}
+	if !utf8.ValidString(desc) {
+		t.Errorf("RenderLink() split a character of the description: %q", desc)
+	}
+
+	tmpl = &configpb.LinkTemplate{Url: "https://example.com/<build-id>"}
+	if link, err := RenderLink(tmpl, LinkValues{BuildIDs: builds}); err == nil {
+		t.Errorf("RenderLink() got %d bytes without options to shorten, wanted an error", len(link))
+	}
+}
+
+func TestShorten(t *testing.T) {
+	cases := []struct {
+		name string
+		s    string
+		n    int
+		want string
+	}{
+		{
+			name: "basic",
+			s:    "hello world",
+			n:    3,
+			want: "hello...",
+		},
+		{
+			name: "already shortened",
+			s:    "hello...",
+			n:    2,
+			want: "hel...",
+		},
+		{
+			name: "keep characters whole",
+			s:    "héllo",
+			n:    2,
+			want: "h...",
+		},
+		{
+			name: "remove everything",
+			s:    "hi",
+			n:    1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := shorten(tc.s, tc.n); got != tc.want {
+				t.Errorf("shorten(%q, %d) got %q, want %q", tc.s, tc.n, got, tc.want)
+			}
+		})
+	}
+}
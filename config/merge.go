@@ -0,0 +1,118 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// Merge concatenates the TestGroups, Dashboards and DashboardGroups of each config.
+//
+// Returns a DuplicateNameError for each name defined in more than one config.
+// Duplicates within a single config are left for Validate to report.
+// The merged config is returned even when there are errors.
+func Merge(configs ...*configpb.Configuration) (*configpb.Configuration, error) {
+	return merge(make([]string, len(configs)), configs)
+}
+
+// MergeFiles merges the config parsed from each file, ordered by file name.
+//
+// Errors name the files that define each duplicate.
+func MergeFiles(files map[string]*configpb.Configuration) (*configpb.Configuration, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	configs := make([]*configpb.Configuration, len(names))
+	for i, name := range names {
+		configs[i] = files[name]
+	}
+	return merge(names, configs)
+}
+
+// sourceTracker remembers which source first defined each normalized name.
+type sourceTracker struct {
+	entity    string
+	sources   map[string]int
+	originals map[string]string
+	files     []string
+}
+
+// add records the name as coming from the idx source, returning an error if an earlier source defined it.
+func (t *sourceTracker) add(name string, idx int) error {
+	norm := Normalize(name)
+	first, ok := t.sources[norm]
+	if !ok {
+		t.sources[norm] = idx
+		t.originals[norm] = name
+		return nil
+	}
+	if first == idx {
+		return nil
+	}
+	entity := t.entity
+	if a, b := t.files[first], t.files[idx]; a != "" || b != "" {
+		entity = fmt.Sprintf("%s defined in %s and %s", t.entity, a, b)
+	}
+	return DuplicateNameError{norm, entity, []string{t.originals[norm], name}, nil}
+}
+
+func newSourceTracker(entity string, files []string) *sourceTracker {
+	return &sourceTracker{
+		entity:    entity,
+		sources:   map[string]int{},
+		originals: map[string]string{},
+		files:     files,
+	}
+}
+
+func merge(files []string, configs []*configpb.Configuration) (*configpb.Configuration, error) {
+	mErr := &multierror.Error{}
+	var out configpb.Configuration
+	tgs := newSourceTracker("TestGroup", files)
+	dashes := newSourceTracker("Dashboard", files)
+	dgs := newSourceTracker("DashboardGroup", files)
+	for i, c := range configs {
+		if c == nil {
+			continue
+		}
+		for _, tg := range c.TestGroups {
+			if err := tgs.add(tg.Name, i); err != nil {
+				mErr = multierror.Append(mErr, err)
+			}
+			out.TestGroups = append(out.TestGroups, tg)
+		}
+		for _, dash := range c.Dashboards {
+			if err := dashes.add(dash.Name, i); err != nil {
+				mErr = multierror.Append(mErr, err)
+			}
+			out.Dashboards = append(out.Dashboards, dash)
+		}
+		for _, dg := range c.DashboardGroups {
+			if err := dgs.add(dg.Name, i); err != nil {
+				mErr = multierror.Append(mErr, err)
+			}
+			out.DashboardGroups = append(out.DashboardGroups, dg)
+		}
+	}
+	return &out, mErr.ErrorOrNil()
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        []*configpb.Configuration
+		expected     *configpb.Configuration
+		expectedErrs []error
+	}{
+		{
+			name:     "No configs",
+			expected: &configpb.Configuration{},
+		},
+		{
+			name: "Concatenates entities",
+			input: []*configpb.Configuration{
+				{
+					TestGroups: []*configpb.TestGroup{{Name: "test_group_1"}},
+					Dashboards: []*configpb.Dashboard{{Name: "dashboard_1"}},
+				},
+				nil,
+				{
+					TestGroups:      []*configpb.TestGroup{{Name: "test_group_2"}},
+					DashboardGroups: []*configpb.DashboardGroup{{Name: "dashboard_group_1"}},
+				},
+			},
+			expected: &configpb.Configuration{
+				TestGroups:      []*configpb.TestGroup{{Name: "test_group_1"}, {Name: "test_group_2"}},
+				Dashboards:      []*configpb.Dashboard{{Name: "dashboard_1"}},
+				DashboardGroups: []*configpb.DashboardGroup{{Name: "dashboard_group_1"}},
+			},
+		},
+		{
+			name: "Duplicates within a config are left to Validate",
+			input: []*configpb.Configuration{
+				{
+					TestGroups: []*configpb.TestGroup{{Name: "test_group_1"}, {Name: "test_group_1"}},
+				},
+			},
+			expected: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "test_group_1"}, {Name: "test_group_1"}},
+			},
+		},
+		{
+			name: "Duplicates across configs; error",
+			input: []*configpb.Configuration{
+				{
+					TestGroups: []*configpb.TestGroup{{Name: "test_group_1"}},
+					Dashboards: []*configpb.Dashboard{{Name: "dashboard_1"}},
+				},
+				{
+					TestGroups: []*configpb.TestGroup{{Name: "TEST GROUP 1"}},
+					Dashboards: []*configpb.Dashboard{{Name: "dashboard_1"}},
+				},
+			},
+			expected: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "test_group_1"}, {Name: "TEST GROUP 1"}},
+				Dashboards: []*configpb.Dashboard{{Name: "dashboard_1"}, {Name: "dashboard_1"}},
+			},
+			expectedErrs: []error{
+				DuplicateNameError{"testgroup1", "TestGroup", []string{"test_group_1", "TEST GROUP 1"}, nil},
+				DuplicateNameError{"dashboard1", "Dashboard", []string{"dashboard_1", "dashboard_1"}, nil},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Merge(test.input...)
+			if !reflect.DeepEqual(test.expected, got) {
+				t.Errorf("Expected %v, but got: %v", test.expected, got)
+			}
+			if errs := errorList(err); !reflect.DeepEqual(test.expectedErrs, errs) {
+				t.Errorf("Expected %v, but got: %v", test.expectedErrs, errs)
+			}
+		})
+	}
+}
+
+func TestMergeFiles(t *testing.T) {
+	files := map[string]*configpb.Configuration{
+		"b.yaml": {
+			TestGroups: []*configpb.TestGroup{{Name: "test_group_1"}},
+		},
+		"a.yaml": {
+			TestGroups:      []*configpb.TestGroup{{Name: "test_group_1"}},
+			DashboardGroups: []*configpb.DashboardGroup{{Name: "dashboard_group_1"}},
+		},
+		"c.yaml": {
+			DashboardGroups: []*configpb.DashboardGroup{{Name: "dashboard_group_1"}},
+		},
+	}
+	expected := &configpb.Configuration{
+		TestGroups:      []*configpb.TestGroup{{Name: "test_group_1"}, {Name: "test_group_1"}},
+		DashboardGroups: []*configpb.DashboardGroup{{Name: "dashboard_group_1"}, {Name: "dashboard_group_1"}},
+	}
+	expectedErrs := []error{
+		DuplicateNameError{"testgroup1", "TestGroup defined in a.yaml and b.yaml", []string{"test_group_1", "test_group_1"}, nil},
+		DuplicateNameError{"dashboardgroup1", "DashboardGroup defined in a.yaml and c.yaml", []string{"dashboard_group_1", "dashboard_group_1"}, nil},
+	}
+
+	got, err := MergeFiles(files)
+	if !reflect.DeepEqual(expected, got) {
+		t.Errorf("Expected %v, but got: %v", expected, got)
+	}
+	if errs := errorList(err); !reflect.DeepEqual(expectedErrs, errs) {
+		t.Errorf("Expected %v, but got: %v", expectedErrs, errs)
+	}
+}
@@ -0,0 +1,431 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"sync"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// Rule identifies a check performed by ValidateWithOptions.
+type Rule string
+
+const (
+	// DuplicateName requires names to be unique (after normalizing) within each type of entity.
+	DuplicateName Rule = "DuplicateName"
+	// MissingTestGroup requires each Dashboard Tab to reference an existing Test Group.
+	MissingTestGroup Rule = "MissingTestGroup"
+	// UnreferencedTestGroup requires each Test Group to be referenced by a Dashboard Tab.
+	UnreferencedTestGroup Rule = "UnreferencedTestGroup"
+	// MissingDashboard requires each Dashboard Group to reference existing Dashboards.
+	MissingDashboard Rule = "MissingDashboard"
+	// DashboardInMultipleGroups requires each Dashboard to belong to at most 1 Dashboard Group.
+	DashboardInMultipleGroups Rule = "DashboardInMultipleGroups"
+	// EmptyDashboard requires each Dashboard to have at least one Dashboard Tab.
+	EmptyDashboard Rule = "EmptyDashboard"
+	// EmptyDashboardGroup requires each Dashboard Group to contain at least one Dashboard.
+	EmptyDashboardGroup Rule = "EmptyDashboardGroup"
+	// UngroupedDashboard requires each Dashboard to be in a Dashboard Group, when any Dashboard Group exists.
+	UngroupedDashboard Rule = "UngroupedDashboard"
+	// InvalidGcsPrefix requires each Test Group gcs_prefix to be a bucket/path.
+	//
+	// Set AllowFilePrefixes to also accept file:// urls.
+	InvalidGcsPrefix Rule = "InvalidGcsPrefix"
+	// DuplicateGcsPrefix requires each Test Group to read from a different gcs_prefix.
+	DuplicateGcsPrefix Rule = "DuplicateGcsPrefix"
+	// InvalidRetention requires Test Group days_of_results and num_columns_recent to not be negative.
+	InvalidRetention Rule = "InvalidRetention"
+	// LargeNumColumnsRecent requires Test Group num_columns_recent to be at most 10000.
+	//
+	// Turn this rule off to allow larger values.
+	LargeNumColumnsRecent Rule = "LargeNumColumnsRecent"
+	// InvalidColumnHeader requires each Test Group column_header to select a single non-empty value, at most once,
+	// and limits the number of column headers.
+	InvalidColumnHeader Rule = "InvalidColumnHeader"
+	// InvalidTestNameConfig requires the test_name_config name_format of each Test Group to have a %s for each name element.
+	InvalidTestNameConfig Rule = "InvalidTestNameConfig"
+	// InvalidAlertMailAddress requires alert_mail_to_addresses to be a comma-separated list of valid addresses.
+	InvalidAlertMailAddress Rule = "InvalidAlertMailAddress"
+	// InconsistentAlertThresholds requires Dashboard Tab alert thresholds to be no lower than those of its Test Group.
+	InconsistentAlertThresholds Rule = "InconsistentAlertThresholds"
+	// IneffectiveAlertOptions warns when a Dashboard Tab sets alert_options.num_failures_to_alert,
+	// but its Test Group disables alerting without num_failures_to_alert.
+	IneffectiveAlertOptions Rule = "IneffectiveAlertOptions"
+	// DashboardGroupPrefix requires each Dashboard to start with the name of its Dashboard Group, after normalizing.
+	//
+	// Exempt specific groups with PrefixExemptGroups.
+	DashboardGroupPrefix Rule = "DashboardGroupPrefix"
+	// InvalidLinkTemplate requires each Dashboard Tab LinkTemplate to only use known <placeholders>.
+	InvalidLinkTemplate Rule = "InvalidLinkTemplate"
+	// InvalidBaseOptions requires the include-filter-by-regex and exclude-filter-by-regex in Dashboard Tab base_options to compile.
+	InvalidBaseOptions Rule = "InvalidBaseOptions"
+	// InvalidRegex requires the regexes of each Test Group and Dashboard Tab, such as test_method_match_regex, to compile.
+	InvalidRegex Rule = "InvalidRegex"
+	// ExcessiveLength requires the names of Dashboards, Dashboard Tabs and Dashboard Groups to be at most
+	// MaxNameBytes long, and Dashboard Tab descriptions to be at most MaxDescriptionBytes long.
+	ExcessiveLength Rule = "ExcessiveLength"
+	// UnlinkableName requires the names of Dashboards, Dashboard Tabs and Dashboard Groups to keep a character after normalizing.
+	UnlinkableName Rule = "UnlinkableName"
+	// MissingPauseReason requires each paused Dashboard to say why in its paused_reason.
+	MissingPauseReason Rule = "MissingPauseReason"
+	// InvalidStatusMapping requires the status_mapping of each Test Group to only use statuses a grid row can hold.
+	InvalidStatusMapping Rule = "InvalidStatusMapping"
+	// InvalidNotification requires each Notification of a Dashboard or Test Group to have a summary of at most
+	// MaxNotificationSummaryBytes, and any context_link to be an absolute http or https URL.
+	InvalidNotification Rule = "InvalidNotification"
+	// ForeignTestGroup requires the Ownership policy of ValidateOptions, when set, to allow each Dashboard Tab to display its Test Group.
+	ForeignTestGroup Rule = "ForeignTestGroup"
+)
+
+// Severity determines how ValidateWithOptions reports a Rule violation.
+type Severity int
+
+const (
+	// SeverityDefault uses the default severity of the rule.
+	SeverityDefault Severity = iota
+	// SeverityError reports violations as errors.
+	SeverityError
+	// SeverityWarning reports violations as warnings.
+	SeverityWarning
+	// SeverityOff ignores violations.
+	SeverityOff
+)
+
+// defaultSeverity lists rules which are not an error by default.
+var defaultSeverity = map[Rule]Severity{
+	DuplicateGcsPrefix:      SeverityWarning,
+	EmptyDashboardGroup:     SeverityWarning,
+	UngroupedDashboard:      SeverityWarning,
+	IneffectiveAlertOptions: SeverityWarning,
+}
+
+// ValidateOptions customizes the checks performed by ValidateWithOptions.
+type ValidateOptions struct {
+	// Severity overrides the default severity of each Rule.
+	Severity map[Rule]Severity
+	// StrictNamespace additionally requires Test Groups and Dashboard Groups to have unique names.
+	//
+	// Tools that key output by normalized name need this, but existing configs may not comply.
+	StrictNamespace bool
+	// MaxErrors stops validating after this many errors, when positive.
+	//
+	// A TooManyErrorsError follows the first MaxErrors errors.
+	MaxErrors int
+	// Workers performs up to this many rule checks concurrently, when greater than 1.
+	//
+	// Results are identical to validating sequentially; large configurations validate faster.
+	Workers int
+	// PrefixExemptGroups lists the Dashboard Groups whose Dashboards need not start with the group name.
+	PrefixExemptGroups []string
+	// AllowFilePrefixes accepts a Test Group gcs_prefix that is a file:// url of a local directory.
+	//
+	// Only updaters running alongside the builds can read them.
+	AllowFilePrefixes bool
+	// MaxNameBytes limits the names checked by ExcessiveLength, or DefaultMaxNameBytes when zero.
+	MaxNameBytes int
+	// MaxDescriptionBytes limits the descriptions checked by ExcessiveLength, or DefaultMaxDescriptionBytes when zero.
+	MaxDescriptionBytes int
+	// Ownership decides which Test Groups each Dashboard Tab may display, for the ForeignTestGroup rule.
+	//
+	// Nil allows every tab to display any Test Group; see PrefixOwnership for an example policy.
+	Ownership OwnershipPolicy
+}
+
+const (
+	// DefaultMaxNameBytes is the longest name the UI displays without wrapping or truncating it.
+	DefaultMaxNameBytes = 256
+	// DefaultMaxDescriptionBytes is the longest Dashboard Tab description, which summaries copy.
+	DefaultMaxDescriptionBytes = 4096
+)
+
+// maxNameBytes returns the configured limit of MaxNameBytes.
+func (o ValidateOptions) maxNameBytes() int {
+	if o.MaxNameBytes > 0 {
+		return o.MaxNameBytes
+	}
+	return DefaultMaxNameBytes
+}
+
+// maxDescriptionBytes returns the configured limit of MaxDescriptionBytes.
+func (o ValidateOptions) maxDescriptionBytes() int {
+	if o.MaxDescriptionBytes > 0 {
+		return o.MaxDescriptionBytes
+	}
+	return DefaultMaxDescriptionBytes
+}
+
+// severity returns the configured severity of rule.
+func (o ValidateOptions) severity(rule Rule) Severity {
+	if s := o.Severity[rule]; s != SeverityDefault {
+		return s
+	}
+	if s, ok := defaultSeverity[rule]; ok {
+		return s
+	}
+	return SeverityError
+}
+
+type testGroupCheck struct {
+	rule     Rule
+	validate func(*configpb.TestGroup) error
+}
+
+// testGroupChecks lists each Rule which only depends on a single Test Group.
+var testGroupChecks = []testGroupCheck{
+	// Test Groups must point at a readable GCS location.
+	{InvalidGcsPrefix, validateTestGroupGcsPrefix},
+	// Test Groups must keep a sensible amount of history.
+	{InvalidRetention, validateTestGroupRetention},
+	{LargeNumColumnsRecent, validateTestGroupNumColumnsRecent},
+	// Column headers must display something.
+	{InvalidColumnHeader, validateTestGroupColumnHeaders},
+	// Test names must render.
+	{InvalidTestNameConfig, validateTestGroupNameFormat},
+	// Alerts must be deliverable.
+	{InvalidAlertMailAddress, validateTestGroupAlertMailAddresses},
+	// Regexes must compile.
+	{InvalidRegex, validateTestGroupRegexes},
+	// Mapped statuses must fit in the grid.
+	{InvalidStatusMapping, validateTestGroupStatusMapping},
+	// Banners must say something.
+	{InvalidNotification, validateTestGroupNotifications},
+}
+
+// testGroupChecks returns the testGroupChecks to perform with these options.
+func (o ValidateOptions) testGroupChecks() []testGroupCheck {
+	if !o.AllowFilePrefixes {
+		return testGroupChecks
+	}
+	chks := make([]testGroupCheck, 0, len(testGroupChecks))
+	for _, chk := range testGroupChecks {
+		if chk.rule == InvalidGcsPrefix {
+			chk.validate = validateTestGroupLocalPrefix
+		}
+		chks = append(chks, chk)
+	}
+	return chks
+}
+
+type dashboardCheck struct {
+	rule     Rule
+	validate func(*configpb.Dashboard) error
+}
+
+// dashboardChecks lists each Rule which only depends on a single Dashboard.
+var dashboardChecks = []dashboardCheck{
+	// Dashboard Tab names have to be unique (after normalizing) within a Dashboard.
+	{DuplicateName, validateTabNamesUnique},
+	// Each Dashboard Tab must name the Test Group it displays.
+	{MissingTestGroup, validateTabTestGroupNames},
+	// Dashboards must display something.
+	{EmptyDashboard, validateDashboardHasTabs},
+	// Alerts must be deliverable.
+	{InvalidAlertMailAddress, validateTabAlertMailAddresses},
+	// Links must render.
+	{InvalidLinkTemplate, validateTabLinkTemplates},
+	// Row filters must parse.
+	{InvalidBaseOptions, validateTabBaseOptions},
+	// Regexes must compile.
+	{InvalidRegex, validateTabRegexes},
+	// Readers of a paused Dashboard must learn why.
+	{MissingPauseReason, validatePauseReason},
+	// Banners must say something.
+	{InvalidNotification, validateDashboardNotifications},
+}
+
+type check struct {
+	rule     Rule
+	validate func(configpb.Configuration) error
+}
+
+// checks lists each Rule which cross-references entities, in the order ValidateWithOptions performs them.
+//
+// ValidateWithOptions performs testGroupChecks and dashboardChecks first.
+var checks = []check{
+	// Names have to be unique (after normalizing) within types of entities, to prevent storing
+	// duplicate state on updates and confusion between similar names.
+	{DuplicateName, validateAllUnique},
+	// The entity that an entity references must exist.
+	{MissingTestGroup, validateTestGroupsExist},
+	{UnreferencedTestGroup, validateTestGroupsReferenced},
+	{MissingDashboard, validateDashboardsExist},
+	{DashboardInMultipleGroups, validateDashboardsInOneGroup},
+	// Dashboard Groups must be used consistently.
+	{EmptyDashboardGroup, validateDashboardGroupsNotEmpty},
+	{UngroupedDashboard, validateDashboardsGrouped},
+	// Test Groups must not overwrite each other.
+	{DuplicateGcsPrefix, validateUniqueGcsPrefix},
+	// Alerts must be possible.
+	{InconsistentAlertThresholds, validateAlertThresholds},
+	{IneffectiveAlertOptions, validateTabAlertsEnabled},
+	// Names must link to the entity.
+	{UnlinkableName, validateNamesLinkable},
+}
+
+// strictChecks lists the additional checks performed with StrictNamespace.
+var strictChecks = []check{
+	{DuplicateName, validateTestGroupNamespace},
+}
+
+type optionCheck struct {
+	rule     Rule
+	validate func(configpb.Configuration, ValidateOptions) error
+}
+
+// optionChecks lists each Rule which depends on ValidateOptions, performed after checks.
+var optionChecks = []optionCheck{
+	// Dashboard names must show which Dashboard Group they are in.
+	{DashboardGroupPrefix, validateDashboardGroupPrefixes},
+	// Names and descriptions must fit in the UI.
+	{ExcessiveLength, validateLengths},
+	// Tabs must only display the Test Groups their owners allow.
+	{ForeignTestGroup, validateOwnership},
+}
+
+// findings collects the errors and warnings of each check.
+type findings struct {
+	opts     ValidateOptions
+	errs     *multierror.Error
+	warnings *multierror.Error
+	// dropped is set when an error is discarded after reaching MaxErrors.
+	dropped bool
+	// skipped counts the checks not performed after reaching MaxErrors.
+	skipped int
+}
+
+// atMax returns true once MaxErrors errors are collected.
+func (f *findings) atMax() bool {
+	return f.opts.MaxErrors > 0 && f.errs != nil && len(f.errs.Errors) >= f.opts.MaxErrors
+}
+
+// run performs a check of rule, reporting any violation according to its severity.
+func (f *findings) run(rule Rule, validate func() error) {
+	severity := f.opts.severity(rule)
+	if severity == SeverityOff {
+		return
+	}
+	if f.atMax() {
+		f.skipped++
+		return
+	}
+	err := validate()
+	if err == nil {
+		return
+	}
+	switch severity {
+	case SeverityError:
+		for _, e := range errorsOf(withSeverity(err, SeverityError)) {
+			if f.atMax() {
+				f.dropped = true
+				break
+			}
+			f.errs = multierror.Append(f.errs, e)
+		}
+	case SeverityWarning:
+		f.warnings = multierror.Append(f.warnings, withSeverity(err, SeverityWarning))
+	}
+}
+
+// pass is a single rule check of the whole configuration.
+type pass struct {
+	rule     Rule
+	validate func() error
+}
+
+// runAll performs each pass, reporting violations in order.
+//
+// With Workers, passes run concurrently and their results are collected afterwards.
+// Passes are still skipped after reaching MaxErrors, but may have already run.
+func (f *findings) runAll(passes []pass) {
+	if f.opts.Workers <= 1 {
+		for _, p := range passes {
+			f.run(p.rule, p.validate)
+		}
+		return
+	}
+
+	results := make([]error, len(passes))
+	sem := make(chan struct{}, f.opts.Workers)
+	var wg sync.WaitGroup
+	for i, p := range passes {
+		if f.opts.severity(p.rule) == SeverityOff {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, validate func() error) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = validate()
+		}(i, p.validate)
+	}
+	wg.Wait()
+
+	for i, p := range passes {
+		err := results[i]
+		f.run(p.rule, func() error { return err })
+	}
+}
+
+// result returns the errors, including any TooManyErrorsError, and warnings.
+func (f *findings) result() (error, error) {
+	if f.dropped || f.skipped > 0 {
+		f.errs = multierror.Append(f.errs, TooManyErrorsError{f.opts.MaxErrors, f.skipped})
+	}
+	return wrapErrors(f.errs), wrapErrors(f.warnings)
+}
+
+// errorsOf returns each error in a *multierror.Error or ValidationErrors, or just err.
+func errorsOf(err error) []error {
+	switch t := err.(type) {
+	case nil:
+		return nil
+	case *multierror.Error:
+		return t.Errors
+	case *ValidationErrors:
+		return t.Errors
+	}
+	return []error{err}
+}
+
+// withSeverity converts ConfigError and ConfigWarning values to match severity.
+func withSeverity(err error, severity Severity) error {
+	convert := func(e error) error {
+		switch t := e.(type) {
+		case ConfigError:
+			if severity == SeverityWarning {
+				return ConfigWarning(t)
+			}
+		case ConfigWarning:
+			if severity == SeverityError {
+				return ConfigError(t)
+			}
+		}
+		return e
+	}
+	mErr, ok := err.(*multierror.Error)
+	if !ok {
+		return convert(err)
+	}
+	out := &multierror.Error{}
+	for _, e := range mErr.Errors {
+		out = multierror.Append(out, convert(e))
+	}
+	return out
+}
@@ -0,0 +1,616 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+func errorList(err error) []error {
+	if err == nil {
+		return nil
+	}
+	if mErr, ok := err.(*multierror.Error); ok {
+		return mErr.Errors
+	}
+	if vErr, ok := err.(*ValidationErrors); ok {
+		return vErr.Errors
+	}
+	return []error{err}
+}
+
+func TestValidateWithOptions(t *testing.T) {
+	tests := []struct {
+		rule     Rule
+		mutate   func(*configpb.Configuration)
+		issue    error // as an error
+		warning  error // as a warning
+		defWarns bool  // whether the rule defaults to a warning
+	}{
+		{
+			rule: DuplicateName,
+			mutate: func(c *configpb.Configuration) {
+				c.Dashboards[0].DashboardTab = append(c.Dashboards[0].DashboardTab, &configpb.DashboardTab{
+					Name:          "Tab 1",
+					TestGroupName: "test_group_1",
+				})
+			},
+			issue:   DuplicateNameError{"tab1", "DashboardTab in dashboard_1", []string{"tab_1", "Tab 1"}, FieldPath{"dashboards", 0, "dashboard_tab", 1, "name"}},
+			warning: DuplicateNameError{"tab1", "DashboardTab in dashboard_1", []string{"tab_1", "Tab 1"}, FieldPath{"dashboards", 0, "dashboard_tab", 1, "name"}},
+		},
+		{
+			rule: MissingTestGroup,
+			mutate: func(c *configpb.Configuration) {
+				c.Dashboards[0].DashboardTab = append(c.Dashboards[0].DashboardTab, &configpb.DashboardTab{
+					Name:          "tab_2",
+					TestGroupName: "something_else",
+				})
+			},
+			issue:   MissingEntityError{"something_else", "TestGroup", "", FieldPath{"dashboards", 0, "dashboard_tab", 1, "test_group_name"}},
+			warning: MissingEntityError{"something_else", "TestGroup", "", FieldPath{"dashboards", 0, "dashboard_tab", 1, "test_group_name"}},
+		},
+		{
+			rule: UnreferencedTestGroup,
+			mutate: func(c *configpb.Configuration) {
+				c.TestGroups = append(c.TestGroups, &configpb.TestGroup{Name: "test_group_2"})
+			},
+			issue:   ConfigError{"test_group_2", "TestGroup", "Each Test Group must be referenced by at least 1 Dashboard Tab.", FieldPath{"test_groups", 1}},
+			warning: ConfigWarning{"test_group_2", "TestGroup", "Each Test Group must be referenced by at least 1 Dashboard Tab.", FieldPath{"test_groups", 1}},
+		},
+		{
+			rule: MissingDashboard,
+			mutate: func(c *configpb.Configuration) {
+				c.DashboardGroups = append(c.DashboardGroups, &configpb.DashboardGroup{
+					Name:           "dashboard",
+					DashboardNames: []string{"dashboard_1", "dashboard_something_else"},
+				})
+			},
+			issue:   MissingEntityError{"dashboard_something_else", "Dashboard", "", FieldPath{"dashboard_groups", 0, "dashboard_names", 1}},
+			warning: MissingEntityError{"dashboard_something_else", "Dashboard", "", FieldPath{"dashboard_groups", 0, "dashboard_names", 1}},
+		},
+		{
+			rule: DashboardInMultipleGroups,
+			mutate: func(c *configpb.Configuration) {
+				c.DashboardGroups = append(c.DashboardGroups,
+					&configpb.DashboardGroup{
+						Name:           "dash",
+						DashboardNames: []string{"dashboard_1"},
+					},
+					&configpb.DashboardGroup{
+						Name:           "dashboard",
+						DashboardNames: []string{"dashboard_1"},
+					},
+				)
+			},
+			issue:   ConfigError{"dashboard_1", "Dashboard", "A Dashboard cannot be in more than 1 Dashboard Group.", FieldPath{"dashboard_groups", 1, "dashboard_names", 0}},
+			warning: ConfigWarning{"dashboard_1", "Dashboard", "A Dashboard cannot be in more than 1 Dashboard Group.", FieldPath{"dashboard_groups", 1, "dashboard_names", 0}},
+		},
+		{
+			rule: EmptyDashboard,
+			mutate: func(c *configpb.Configuration) {
+				c.Dashboards = append(c.Dashboards, &configpb.Dashboard{Name: "dashboard_2"})
+			},
+			issue:   ConfigError{"dashboard_2", "Dashboard", "A Dashboard must have at least one Dashboard Tab.", FieldPath{"dashboards", 1}},
+			warning: ConfigWarning{"dashboard_2", "Dashboard", "A Dashboard must have at least one Dashboard Tab.", FieldPath{"dashboards", 1}},
+		},
+		{
+			rule: EmptyDashboardGroup,
+			mutate: func(c *configpb.Configuration) {
+				c.DashboardGroups = append(c.DashboardGroups,
+					&configpb.DashboardGroup{
+						Name:           "dashboard",
+						DashboardNames: []string{"dashboard_1"},
+					},
+					&configpb.DashboardGroup{Name: "dashboard_group_2"},
+				)
+			},
+			issue:    ConfigError{"dashboard_group_2", "DashboardGroup", "A Dashboard Group must contain at least one Dashboard.", FieldPath{"dashboard_groups", 1}},
+			warning:  ConfigWarning{"dashboard_group_2", "DashboardGroup", "A Dashboard Group must contain at least one Dashboard.", FieldPath{"dashboard_groups", 1}},
+			defWarns: true,
+		},
+		{
+			rule: UngroupedDashboard,
+			mutate: func(c *configpb.Configuration) {
+				c.Dashboards = append(c.Dashboards, &configpb.Dashboard{
+					Name: "dashboard_2",
+					DashboardTab: []*configpb.DashboardTab{
+						{
+							Name:          "tab_1",
+							TestGroupName: "test_group_1",
+						},
+					},
+				})
+				c.DashboardGroups = append(c.DashboardGroups, &configpb.DashboardGroup{
+					Name:           "dashboard",
+					DashboardNames: []string{"dashboard_1"},
+				})
+			},
+			issue:    ConfigError{"dashboard_2", "Dashboard", "A Dashboard should be in a Dashboard Group when Dashboard Groups exist.", FieldPath{"dashboards", 1}},
+			warning:  ConfigWarning{"dashboard_2", "Dashboard", "A Dashboard should be in a Dashboard Group when Dashboard Groups exist.", FieldPath{"dashboards", 1}},
+			defWarns: true,
+		},
+		{
+			rule: InvalidGcsPrefix,
+			mutate: func(c *configpb.Configuration) {
+				c.TestGroups[0].Query = "gs://bucket/logs/job-1"
+			},
+			issue:   ConfigError{"test_group_1", "TestGroup", `gcs_prefix "gs://bucket/logs/job-1" must not start with gs://`, FieldPath{"test_groups", 0, "query"}},
+			warning: ConfigWarning{"test_group_1", "TestGroup", `gcs_prefix "gs://bucket/logs/job-1" must not start with gs://`, FieldPath{"test_groups", 0, "query"}},
+		},
+		{
+			rule: InvalidRetention,
+			mutate: func(c *configpb.Configuration) {
+				c.TestGroups[0].DaysOfResults = -1
+			},
+			issue:   ConfigError{"test_group_1", "TestGroup", "days_of_results must be >= 0, got -1", FieldPath{"test_groups", 0, "days_of_results"}},
+			warning: ConfigWarning{"test_group_1", "TestGroup", "days_of_results must be >= 0, got -1", FieldPath{"test_groups", 0, "days_of_results"}},
+		},
+		{
+			rule: LargeNumColumnsRecent,
+			mutate: func(c *configpb.Configuration) {
+				c.TestGroups[0].NumColumnsRecent = 10001
+			},
+			issue:   ConfigError{"test_group_1", "TestGroup", "num_columns_recent must be <= 10000, got 10001", FieldPath{"test_groups", 0, "num_columns_recent"}},
+			warning: ConfigWarning{"test_group_1", "TestGroup", "num_columns_recent must be <= 10000, got 10001", FieldPath{"test_groups", 0, "num_columns_recent"}},
+		},
+		{
+			rule: InvalidColumnHeader,
+			mutate: func(c *configpb.Configuration) {
+				c.TestGroups[0].ColumnHeader = []*configpb.TestGroup_ColumnHeader{{}}
+			},
+			issue:   ConfigError{"test_group_1", "TestGroup", "column_header[0] must set one of label, property or configuration_value", FieldPath{"test_groups", 0, "column_header", 0}},
+			warning: ConfigWarning{"test_group_1", "TestGroup", "column_header[0] must set one of label, property or configuration_value", FieldPath{"test_groups", 0, "column_header", 0}},
+		},
+		{
+			rule: InvalidStatusMapping,
+			mutate: func(c *configpb.Configuration) {
+				c.TestGroups[0].StatusMapping = &configpb.TestGroup_StatusMapping{Error: test_status.TestStatus_RUNNING}
+			},
+			issue:   ConfigError{"test_group_1", "TestGroup", "status_mapping.error cannot be RUNNING, which grid rows do not support", FieldPath{"test_groups", 0, "status_mapping", "error"}},
+			warning: ConfigWarning{"test_group_1", "TestGroup", "status_mapping.error cannot be RUNNING, which grid rows do not support", FieldPath{"test_groups", 0, "status_mapping", "error"}},
+		},
+		{
+			rule: InvalidNotification,
+			mutate: func(c *configpb.Configuration) {
+				c.Dashboards[0].Notifications = []*configpb.Notification{{Summary: "Tests are migrating", ContextLink: "example.com/migration"}}
+			},
+			issue:   ConfigError{"dashboard_1", "Dashboard", `notification 0: context_link "example.com/migration" must be an absolute http or https URL`, FieldPath{"dashboards", 0, "notifications", 0, "context_link"}},
+			warning: ConfigWarning{"dashboard_1", "Dashboard", `notification 0: context_link "example.com/migration" must be an absolute http or https URL`, FieldPath{"dashboards", 0, "notifications", 0, "context_link"}},
+		},
+		{
+			rule: InvalidTestNameConfig,
+			mutate: func(c *configpb.Configuration) {
+				c.TestGroups[0].TestNameConfig = &configpb.TestNameConfig{NameFormat: "%s"}
+			},
+			issue:   ConfigError{"test_group_1", "TestGroup", `test_name_config.name_format "%s" has 1 %s verbs, but there are 0 name_elements`, FieldPath{"test_groups", 0, "test_name_config", "name_format"}},
+			warning: ConfigWarning{"test_group_1", "TestGroup", `test_name_config.name_format "%s" has 1 %s verbs, but there are 0 name_elements`, FieldPath{"test_groups", 0, "test_name_config", "name_format"}},
+		},
+		{
+			rule: DuplicateGcsPrefix,
+			mutate: func(c *configpb.Configuration) {
+				c.TestGroups = append(c.TestGroups, &configpb.TestGroup{
+					Name:  "test_group_2",
+					Query: "bucket/logs/job-1",
+				})
+				c.Dashboards[0].DashboardTab = append(c.Dashboards[0].DashboardTab, &configpb.DashboardTab{
+					Name:          "tab_2",
+					TestGroupName: "test_group_2",
+				})
+			},
+			issue:    ConfigError{"test_group_2", "TestGroup", `gcs_prefix "bucket/logs/job-1" is also used by test_group_1`, FieldPath{"test_groups", 1, "query"}},
+			warning:  ConfigWarning{"test_group_2", "TestGroup", `gcs_prefix "bucket/logs/job-1" is also used by test_group_1`, FieldPath{"test_groups", 1, "query"}},
+			defWarns: true,
+		},
+		{
+			rule: InconsistentAlertThresholds,
+			mutate: func(c *configpb.Configuration) {
+				c.TestGroups[0].NumFailuresToAlert = 5
+				c.Dashboards[0].DashboardTab[0].AlertOptions = &configpb.DashboardTabAlertOptions{
+					NumFailuresToAlert: 1,
+				}
+			},
+			issue:   ConfigError{"dashboard_1/tab_1", "DashboardTab", "alert_options.num_failures_to_alert 1 is lower than 5 in TestGroup test_group_1", FieldPath{"dashboards", 0, "dashboard_tab", 0, "alert_options", "num_failures_to_alert"}},
+			warning: ConfigWarning{"dashboard_1/tab_1", "DashboardTab", "alert_options.num_failures_to_alert 1 is lower than 5 in TestGroup test_group_1", FieldPath{"dashboards", 0, "dashboard_tab", 0, "alert_options", "num_failures_to_alert"}},
+		},
+		{
+			rule: IneffectiveAlertOptions,
+			mutate: func(c *configpb.Configuration) {
+				c.Dashboards[0].DashboardTab[0].AlertOptions = &configpb.DashboardTabAlertOptions{
+					NumFailuresToAlert: 1,
+				}
+			},
+			issue:    ConfigError{"dashboard_1/tab_1", "DashboardTab", "alert_options.num_failures_to_alert has no effect; TestGroup test_group_1 disables alerts without num_failures_to_alert", FieldPath{"dashboards", 0, "dashboard_tab", 0, "alert_options", "num_failures_to_alert"}},
+			warning:  ConfigWarning{"dashboard_1/tab_1", "DashboardTab", "alert_options.num_failures_to_alert has no effect; TestGroup test_group_1 disables alerts without num_failures_to_alert", FieldPath{"dashboards", 0, "dashboard_tab", 0, "alert_options", "num_failures_to_alert"}},
+			defWarns: true,
+		},
+		{
+			rule: InvalidLinkTemplate,
+			mutate: func(c *configpb.Configuration) {
+				c.Dashboards[0].DashboardTab[0].FileBugTemplate = &configpb.LinkTemplate{
+					Url: "https://example.com/<bug>",
+				}
+			},
+			issue:   ConfigError{"dashboard_1/tab_1", "DashboardTab", "file_bug_template.url: unknown placeholder <bug>", FieldPath{"dashboards", 0, "dashboard_tab", 0, "file_bug_template", "url"}},
+			warning: ConfigWarning{"dashboard_1/tab_1", "DashboardTab", "file_bug_template.url: unknown placeholder <bug>", FieldPath{"dashboards", 0, "dashboard_tab", 0, "file_bug_template", "url"}},
+		},
+		{
+			rule: InvalidBaseOptions,
+			mutate: func(c *configpb.Configuration) {
+				c.Dashboards[0].DashboardTab[0].BaseOptions = "exclude-filter-by-regex=foo("
+			},
+			issue:   ConfigError{"dashboard_1/tab_1", "DashboardTab", "bad exclude-filter-by-regex=foo(: error parsing regexp: missing closing ): `foo(`", FieldPath{"dashboards", 0, "dashboard_tab", 0, "base_options"}},
+			warning: ConfigWarning{"dashboard_1/tab_1", "DashboardTab", "bad exclude-filter-by-regex=foo(: error parsing regexp: missing closing ): `foo(`", FieldPath{"dashboards", 0, "dashboard_tab", 0, "base_options"}},
+		},
+		{
+			rule: InvalidRegex,
+			mutate: func(c *configpb.Configuration) {
+				c.TestGroups[0].TestMethodMatchRegex = "[Test"
+			},
+			issue:   ConfigError{"test_group_1", "TestGroup", `test_method_match_regex "[Test" error parsing regexp: missing closing ]: ` + "`[Test`", FieldPath{"test_groups", 0, "test_method_match_regex"}},
+			warning: ConfigWarning{"test_group_1", "TestGroup", `test_method_match_regex "[Test" error parsing regexp: missing closing ]: ` + "`[Test`", FieldPath{"test_groups", 0, "test_method_match_regex"}},
+		},
+		{
+			rule: DashboardGroupPrefix,
+			mutate: func(c *configpb.Configuration) {
+				c.DashboardGroups = append(c.DashboardGroups, &configpb.DashboardGroup{
+					Name:           "release",
+					DashboardNames: []string{"dashboard_1"},
+				})
+			},
+			issue:   ConfigError{"release", "DashboardGroup", "Dashboard dashboard_1 must start with the Dashboard Group name", FieldPath{"dashboard_groups", 0, "dashboard_names", 0}},
+			warning: ConfigWarning{"release", "DashboardGroup", "Dashboard dashboard_1 must start with the Dashboard Group name", FieldPath{"dashboard_groups", 0, "dashboard_names", 0}},
+		},
+		{
+			rule: ExcessiveLength,
+			mutate: func(c *configpb.Configuration) {
+				c.Dashboards[0].DashboardTab[0].Description = strings.Repeat("x", DefaultMaxDescriptionBytes+1)
+			},
+			issue:   ConfigError{"dashboard_1/tab_1", "DashboardTab", "description is 4097 bytes, more than 4096", FieldPath{"dashboards", 0, "dashboard_tab", 0, "description"}},
+			warning: ConfigWarning{"dashboard_1/tab_1", "DashboardTab", "description is 4097 bytes, more than 4096", FieldPath{"dashboards", 0, "dashboard_tab", 0, "description"}},
+		},
+		{
+			rule: UnlinkableName,
+			mutate: func(c *configpb.Configuration) {
+				c.Dashboards[0].DashboardTab[0].Name = "***"
+			},
+			issue:   ConfigError{"dashboard_1/***", "DashboardTab", `name "***" must contain a letter or digit`, FieldPath{"dashboards", 0, "dashboard_tab", 0, "name"}},
+			warning: ConfigWarning{"dashboard_1/***", "DashboardTab", `name "***" must contain a letter or digit`, FieldPath{"dashboards", 0, "dashboard_tab", 0, "name"}},
+		},
+		{
+			rule: MissingPauseReason,
+			mutate: func(c *configpb.Configuration) {
+				c.Dashboards[0].Paused = true
+			},
+			issue:   ConfigError{"dashboard_1", "Dashboard", "A paused Dashboard must have a paused_reason.", FieldPath{"dashboards", 0, "paused_reason"}},
+			warning: ConfigWarning{"dashboard_1", "Dashboard", "A paused Dashboard must have a paused_reason.", FieldPath{"dashboards", 0, "paused_reason"}},
+		},
+	}
+
+	for _, test := range tests {
+		severities := []struct {
+			name     string
+			severity Severity
+			errs     []error
+			warnings []error
+		}{
+			{
+				name:     "default",
+				severity: SeverityDefault,
+			},
+			{
+				name:     "error",
+				severity: SeverityError,
+				errs:     []error{test.issue},
+			},
+			{
+				name:     "warning",
+				severity: SeverityWarning,
+				warnings: []error{test.warning},
+			},
+			{
+				name:     "off",
+				severity: SeverityOff,
+			},
+		}
+		if test.defWarns {
+			severities[0].warnings = []error{test.warning}
+		} else {
+			severities[0].errs = []error{test.issue}
+		}
+		for _, sev := range severities {
+			t.Run(string(test.rule)+" "+sev.name, func(t *testing.T) {
+				c := minimalConfig()
+				test.mutate(&c)
+				opts := ValidateOptions{
+					Severity: map[Rule]Severity{test.rule: sev.severity},
+				}
+				errs, warnings := ValidateWithOptions(c, opts)
+				if got := errorList(errs); !reflect.DeepEqual(sev.errs, got) {
+					t.Errorf("Expected errors %v, but got: %v", sev.errs, got)
+				}
+				if got := errorList(warnings); !reflect.DeepEqual(sev.warnings, got) {
+					t.Errorf("Expected warnings %v, but got: %v", sev.warnings, got)
+				}
+			})
+		}
+	}
+}
+
+func TestValidate_IgnoresWarnings(t *testing.T) {
+	c := minimalConfig()
+	c.TestGroups = append(c.TestGroups, &configpb.TestGroup{
+		Name:  "test_group_2",
+		Query: "bucket/logs/job-1",
+	})
+	c.Dashboards[0].DashboardTab = append(c.Dashboards[0].DashboardTab, &configpb.DashboardTab{
+		Name:          "tab_2",
+		TestGroupName: "test_group_2",
+	})
+	if err := Validate(c); err != nil {
+		t.Fatalf("Unexpected Error: %v", err)
+	}
+}
+
+func TestValidateWithOptions_AllowFilePrefixes(t *testing.T) {
+	c := minimalConfig()
+	c.TestGroups[0].Query = "file:///logs/job-1"
+	invalid := ConfigError{"test_group_1", "TestGroup", `gcs_prefix "file:///logs/job-1" must not be a local file:// url`, FieldPath{"test_groups", 0, "query"}}
+
+	tests := []struct {
+		name         string
+		opts         ValidateOptions
+		expectedErrs []error
+	}{
+		{
+			name:         "Default rejects local prefixes",
+			expectedErrs: []error{invalid},
+		},
+		{
+			name: "Allow local prefixes",
+			opts: ValidateOptions{AllowFilePrefixes: true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs, warnings := ValidateWithOptions(c, test.opts)
+			if got := errorList(errs); !reflect.DeepEqual(test.expectedErrs, got) {
+				t.Errorf("Expected errors %v, but got: %v", test.expectedErrs, got)
+			}
+			if warnings != nil {
+				t.Errorf("Unexpected warnings: %v", warnings)
+			}
+		})
+	}
+}
+
+func TestValidateWithOptions_StrictNamespace(t *testing.T) {
+	c := minimalConfig()
+	c.DashboardGroups = append(c.DashboardGroups, &configpb.DashboardGroup{
+		Name:           "Test Group 1",
+		DashboardNames: []string{"dashboard_1"},
+	})
+	// The Dashboard is not named after the group.
+	exempt := []string{"Test Group 1"}
+
+	tests := []struct {
+		name         string
+		opts         ValidateOptions
+		expectedErrs []error
+	}{
+		{
+			name: "Default allows shared names",
+			opts: ValidateOptions{PrefixExemptGroups: exempt},
+		},
+		{
+			name: "Strict; error",
+			opts: ValidateOptions{StrictNamespace: true, PrefixExemptGroups: exempt},
+			expectedErrs: []error{
+				DuplicateNameError{"testgroup1", "TestGroup/DashboardGroup", []string{"test_group_1", "Test Group 1"}, FieldPath{"dashboard_groups", 0, "name"}},
+			},
+		},
+		{
+			name: "Strict with DuplicateName off",
+			opts: ValidateOptions{
+				StrictNamespace:    true,
+				Severity:           map[Rule]Severity{DuplicateName: SeverityOff},
+				PrefixExemptGroups: exempt,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs, warnings := ValidateWithOptions(c, test.opts)
+			if got := errorList(errs); !reflect.DeepEqual(test.expectedErrs, got) {
+				t.Errorf("Expected errors %v, but got: %v", test.expectedErrs, got)
+			}
+			if warnings != nil {
+				t.Errorf("Unexpected warnings: %v", warnings)
+			}
+		})
+	}
+}
+
+func TestValidateWithOptions_MaxErrors(t *testing.T) {
+	c := minimalConfig()
+	for _, name := range []string{"test_group_2", "test_group_3", "test_group_4"} {
+		c.TestGroups = append(c.TestGroups, &configpb.TestGroup{Name: name})
+	}
+	c.Dashboards = append(c.Dashboards, &configpb.Dashboard{Name: "dashboard_2"})
+	unreferenced := func(name string, i int) error {
+		return ConfigError{name, "TestGroup", "Each Test Group must be referenced by at least 1 Dashboard Tab.", FieldPath{"test_groups", i}}
+	}
+	empty := ConfigError{"dashboard_2", "Dashboard", "A Dashboard must have at least one Dashboard Tab.", FieldPath{"dashboards", 1}}
+	// ruleIndex returns how many checks run up to and including rule.
+	ruleIndex := func(rule Rule) int {
+		n := len(testGroupChecks)
+		for _, chk := range dashboardChecks {
+			n++
+			if chk.rule == rule {
+				return n
+			}
+		}
+		for _, chk := range checks {
+			n++
+			if chk.rule == rule {
+				return n
+			}
+		}
+		t.Fatalf("Unknown rule %s", rule)
+		return 0
+	}
+	total := len(testGroupChecks) + len(dashboardChecks) + len(checks) + len(optionChecks)
+
+	tests := []struct {
+		name         string
+		maxErrors    int
+		expectedErrs []error
+	}{
+		{
+			name: "Unlimited",
+			expectedErrs: []error{
+				empty,
+				unreferenced("test_group_2", 1),
+				unreferenced("test_group_3", 2),
+				unreferenced("test_group_4", 3),
+			},
+		},
+		{
+			name:      "Limit is not reached",
+			maxErrors: 5,
+			expectedErrs: []error{
+				empty,
+				unreferenced("test_group_2", 1),
+				unreferenced("test_group_3", 2),
+				unreferenced("test_group_4", 3),
+			},
+		},
+		{
+			name:      "Stops within a rule",
+			maxErrors: 2,
+			expectedErrs: []error{
+				empty,
+				unreferenced("test_group_2", 1),
+				TooManyErrorsError{2, total - ruleIndex(UnreferencedTestGroup)},
+			},
+		},
+		{
+			name:      "Stops between rules",
+			maxErrors: 1,
+			expectedErrs: []error{
+				empty,
+				TooManyErrorsError{1, total - ruleIndex(EmptyDashboard)},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for i := 0; i < 10; i++ {
+				errs, _ := ValidateWithOptions(c, ValidateOptions{MaxErrors: test.maxErrors})
+				if got := errorList(errs); !reflect.DeepEqual(test.expectedErrs, got) {
+					t.Fatalf("Expected %v, but got: %v", test.expectedErrs, got)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateWithOptions_Deterministic(t *testing.T) {
+	c := minimalConfig()
+	// Five problems, several of which are found by checks that need lookups.
+	c.TestGroups = append(c.TestGroups,
+		&configpb.TestGroup{Name: "test_group_2"},
+		&configpb.TestGroup{Name: "Test Group 1"},
+	)
+	c.Dashboards[0].DashboardTab = append(c.Dashboards[0].DashboardTab, &configpb.DashboardTab{
+		Name:          "tab_2",
+		TestGroupName: "test_group_4",
+	})
+	c.DashboardGroups = append(c.DashboardGroups, &configpb.DashboardGroup{
+		Name:           "dashboard",
+		DashboardNames: []string{"dashboard_1", "dashboard_2"},
+	})
+
+	first, firstWarnings := ValidateWithOptions(c, ValidateOptions{})
+	if got := len(errorList(first)); got != 5 {
+		t.Fatalf("Expected 5 errors, but got %d: %v", got, first)
+	}
+	for i := 0; i < 100; i++ {
+		errs, warnings := ValidateWithOptions(c, ValidateOptions{})
+		if !reflect.DeepEqual(errorList(first), errorList(errs)) {
+			t.Fatalf("Run %d: expected %v, but got: %v", i, first, errs)
+		}
+		if !reflect.DeepEqual(errorList(firstWarnings), errorList(warnings)) {
+			t.Fatalf("Run %d: expected warnings %v, but got: %v", i, firstWarnings, warnings)
+		}
+	}
+}
+
+func TestValidateWithOptions_Workers(t *testing.T) {
+	c := syntheticConfig(100)
+	if errs, warnings := ValidateWithOptions(c, ValidateOptions{Workers: 4}); errs != nil || warnings != nil {
+		t.Fatalf("Unexpected errors %v and warnings %v", errs, warnings)
+	}
+
+	c.TestGroups = append(c.TestGroups,
+		&configpb.TestGroup{Name: "test_group_100"},
+		&configpb.TestGroup{Name: "Test Group 1", Query: "bucket/logs/job-1"},
+	)
+	c.Dashboards[0].DashboardTab[1].TestGroupName = "test_group_1000"
+	c.Dashboards = append(c.Dashboards, &configpb.Dashboard{Name: "dashboard_empty"})
+
+	for _, maxErrors := range []int{0, 3} {
+		opts := ValidateOptions{MaxErrors: maxErrors}
+		expectedErrs, expectedWarnings := ValidateWithOptions(c, opts)
+		if expectedErrs == nil {
+			t.Fatalf("Expected errors with MaxErrors %d", maxErrors)
+		}
+		for _, workers := range []int{2, 8, 100} {
+			opts.Workers = workers
+			errs, warnings := ValidateWithOptions(c, opts)
+			if !reflect.DeepEqual(errorList(expectedErrs), errorList(errs)) {
+				t.Errorf("MaxErrors %d, Workers %d: expected errors %v, but got: %v", maxErrors, workers, expectedErrs, errs)
+			}
+			if !reflect.DeepEqual(errorList(expectedWarnings), errorList(warnings)) {
+				t.Errorf("MaxErrors %d, Workers %d: expected warnings %v, but got: %v", maxErrors, workers, expectedWarnings, warnings)
+			}
+		}
+	}
+}
+
+func BenchmarkValidateWithOptions(b *testing.B) {
+	c := syntheticConfig(10000)
+	for _, workers := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("Workers=%d", workers), func(b *testing.B) {
+			opts := ValidateOptions{Workers: workers}
+			for i := 0; i < b.N; i++ {
+				if errs, _ := ValidateWithOptions(c, opts); errs != nil {
+					b.Fatalf("Unexpected errors: %v", errs)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// OwnershipPolicy returns an error when the tab at loc may not display the Test Group, such as when another team owns it.
+//
+// Organizations sharing an instance plug their policy into ValidateOptions.Ownership.
+type OwnershipPolicy func(loc TabLocation, tg *configpb.TestGroup) error
+
+// PrefixOwnership returns an OwnershipPolicy where Dashboard Groups own the Test Groups under a gcs_prefix.
+//
+// owners maps the name of a Dashboard Group to the bucket/path it owns, such as "bucket/team-a".
+// A Test Group under an owned path may only appear on the Dashboards of the Dashboard Group owning
+// the longest matching path. Anyone may display the Test Groups outside of every owned path.
+func PrefixOwnership(owners map[string]string) OwnershipPolicy {
+	return func(loc TabLocation, tg *configpb.TestGroup) error {
+		query := trimScheme(tg.GetQuery())
+		var owner, best string
+		for group, prefix := range owners {
+			prefix = strings.TrimSuffix(trimScheme(prefix), "/")
+			if len(prefix) <= len(best) || (query != prefix && !strings.HasPrefix(query, prefix+"/")) {
+				continue
+			}
+			owner, best = group, prefix
+		}
+		if owner == "" || owner == loc.DashboardGroup {
+			return nil
+		}
+		return fmt.Errorf("gcs_prefix %s belongs to Dashboard Group %s", tg.GetQuery(), owner)
+	}
+}
+
+// trimScheme removes the optional gs:// of a gcs_prefix.
+func trimScheme(prefix string) string {
+	return strings.TrimPrefix(prefix, "gs://")
+}
+
+// validateOwnership checks that the Ownership policy of ValidateOptions allows each Dashboard Tab to display its Test Group.
+//
+// Tabs whose Test Group does not exist are reported by other rules.
+func validateOwnership(c configpb.Configuration, opts ValidateOptions) error {
+	if opts.Ownership == nil {
+		return nil
+	}
+	groups := map[string]*configpb.TestGroup{}
+	for _, tg := range c.TestGroups {
+		groups[tg.Name] = tg
+	}
+	dashGroups := dashboardGroupNames(&c)
+	mErr := &multierror.Error{}
+	for i, dash := range c.Dashboards {
+		for j, tab := range dash.DashboardTab {
+			tg, ok := groups[tab.TestGroupName]
+			if !ok {
+				continue
+			}
+			loc := TabLocation{dash.Name, tab.Name, tab, dashGroups[dash.Name]}
+			if err := opts.Ownership(loc, tg); err != nil {
+				path := FieldPath{"dashboards", i, "dashboard_tab", j, "test_group_name"}
+				msg := fmt.Sprintf("cannot display Test Group %s: %v", tg.Name, err)
+				mErr = multierror.Append(mErr, ConfigError{dash.Name + "/" + tab.Name, "DashboardTab", msg, path})
+			}
+		}
+	}
+	return mErr.ErrorOrNil()
+}
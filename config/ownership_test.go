@@ -0,0 +1,160 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+func TestPrefixOwnership(t *testing.T) {
+	policy := PrefixOwnership(map[string]string{
+		"team-a":      "bucket/team-a",
+		"team-a-core": "gs://bucket/team-a/core/",
+		"team-b":      "bucket/team-b/",
+	})
+
+	tests := []struct {
+		name    string
+		group   string
+		query   string
+		wantErr bool
+	}{
+		{
+			name:  "Owner displays its group",
+			group: "team-a",
+			query: "bucket/team-a/job",
+		},
+		{
+			name:    "Other team cannot display the group",
+			group:   "team-b",
+			query:   "bucket/team-a/job",
+			wantErr: true,
+		},
+		{
+			name:    "Dashboards outside every Dashboard Group cannot display owned groups",
+			query:   "gs://bucket/team-b/job",
+			wantErr: true,
+		},
+		{
+			name:  "Longest owned path wins",
+			group: "team-a-core",
+			query: "bucket/team-a/core/job",
+		},
+		{
+			name:    "Shorter owned path loses",
+			group:   "team-a",
+			query:   "bucket/team-a/core/job",
+			wantErr: true,
+		},
+		{
+			name:  "Prefixes match whole path elements",
+			group: "team-b",
+			query: "bucket/team-ab/job",
+		},
+		{
+			name:  "Anyone displays unowned groups",
+			query: "bucket/shared/job",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			loc := TabLocation{Dashboard: "dash", Tab: "tab", DashboardGroup: test.group}
+			err := policy(loc, &configpb.TestGroup{Name: "tg", Query: test.query})
+			if got := err != nil; got != test.wantErr {
+				t.Errorf("PrefixOwnership() got error %v, want error %t", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateWithOptions_Ownership(t *testing.T) {
+	c := minimalConfig()
+	c.DashboardGroups = append(c.DashboardGroups, &configpb.DashboardGroup{
+		Name:           "group_1",
+		DashboardNames: []string{"dashboard_1"},
+	})
+	c.Dashboards[0].DashboardTab = append(c.Dashboards[0].DashboardTab, &configpb.DashboardTab{
+		Name:          "tab_missing",
+		TestGroupName: "test_group_missing",
+	})
+	tab := c.Dashboards[0].DashboardTab[0]
+	var locs []TabLocation
+	reject := func(loc TabLocation, tg *configpb.TestGroup) error {
+		locs = append(locs, loc)
+		return errors.New("not yours")
+	}
+	foreign := ConfigError{"dashboard_1/tab_1", "DashboardTab", "cannot display Test Group test_group_1: not yours", FieldPath{"dashboards", 0, "dashboard_tab", 0, "test_group_name"}}
+	missing := MissingEntityError{"test_group_missing", "TestGroup", "", FieldPath{"dashboards", 0, "dashboard_tab", 1, "test_group_name"}}
+
+	tests := []struct {
+		name          string
+		opts          ValidateOptions
+		expectedErrs  []error
+		expectedWarns []error
+		expectedLocs  []TabLocation
+	}{
+		{
+			name:         "No policy",
+			expectedErrs: []error{missing},
+		},
+		{
+			name:         "Policy rejects tab",
+			opts:         ValidateOptions{Ownership: reject},
+			expectedErrs: []error{missing, foreign},
+			expectedLocs: []TabLocation{{"dashboard_1", "tab_1", tab, "group_1"}},
+		},
+		{
+			name: "Warning",
+			opts: ValidateOptions{
+				Ownership: reject,
+				Severity:  map[Rule]Severity{ForeignTestGroup: SeverityWarning},
+			},
+			expectedErrs:  []error{missing},
+			expectedWarns: []error{ConfigWarning(foreign)},
+			expectedLocs:  []TabLocation{{"dashboard_1", "tab_1", tab, "group_1"}},
+		},
+		{
+			name: "Policy allows tab",
+			opts: ValidateOptions{
+				Ownership: PrefixOwnership(map[string]string{"group_1": "bucket/logs"}),
+			},
+			expectedErrs: []error{missing},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			locs = nil
+			test.opts.PrefixExemptGroups = []string{"group_1"}
+			errs, warnings := ValidateWithOptions(c, test.opts)
+			if got := errorList(errs); !reflect.DeepEqual(test.expectedErrs, got) {
+				t.Errorf("Expected errors %v, but got: %v", test.expectedErrs, got)
+			}
+			if got := errorList(warnings); !reflect.DeepEqual(test.expectedWarns, got) {
+				t.Errorf("Expected warnings %v, but got: %v", test.expectedWarns, got)
+			}
+			if !reflect.DeepEqual(test.expectedLocs, locs) {
+				t.Errorf("Expected policy calls for %v, but got: %v", test.expectedLocs, locs)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// FieldPath locates a field in a Configuration, such as {"dashboards", 3, "dashboard_tab", 1, "test_group_name"}.
+//
+// Each element is either the JSON name of a field, or the int index of a repeated field.
+type FieldPath []interface{}
+
+// String renders the path like dashboards[3].dashboard_tab[1].test_group_name.
+func (p FieldPath) String() string {
+	var b strings.Builder
+	for _, elem := range p {
+		switch e := elem.(type) {
+		case int:
+			fmt.Fprintf(&b, "[%d]", e)
+		default:
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			fmt.Fprint(&b, e)
+		}
+	}
+	return b.String()
+}
+
+// PathString returns the rendered FieldPath of the first error in err that has one, or "" if none do.
+func PathString(err error) string {
+	for _, e := range errorsOf(err) {
+		var l located
+		if errors.As(e, &l) && len(l.fieldPath()) > 0 {
+			return l.fieldPath().String()
+		}
+	}
+	return ""
+}
+
+// located is an error with a FieldPath.
+type located interface {
+	error
+	fieldPath() FieldPath
+	// withFieldPath returns a copy of the error at path.
+	withFieldPath(path FieldPath) error
+}
+
+// atPath prepends prefix to the FieldPath of each error in err.
+func atPath(err error, prefix ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	prepend := func(e error) error {
+		l, ok := e.(located)
+		if !ok {
+			return e
+		}
+		path := append(append(FieldPath{}, prefix...), l.fieldPath()...)
+		return l.withFieldPath(path)
+	}
+	switch err.(type) {
+	case *multierror.Error, *ValidationErrors:
+	default:
+		return prepend(err)
+	}
+	mErr := &multierror.Error{}
+	for _, e := range errorsOf(err) {
+		mErr = multierror.Append(mErr, prepend(e))
+	}
+	return mErr
+}
+
+func (e MissingFieldError) fieldPath() FieldPath { return e.FieldPath }
+
+func (e MissingFieldError) withFieldPath(path FieldPath) error {
+	e.FieldPath = path
+	return e
+}
+
+func (e DuplicateNameError) fieldPath() FieldPath { return e.FieldPath }
+
+func (e DuplicateNameError) withFieldPath(path FieldPath) error {
+	e.FieldPath = path
+	return e
+}
+
+func (e MissingEntityError) fieldPath() FieldPath { return e.FieldPath }
+
+func (e MissingEntityError) withFieldPath(path FieldPath) error {
+	e.FieldPath = path
+	return e
+}
+
+func (e ConfigError) fieldPath() FieldPath { return e.FieldPath }
+
+func (e ConfigError) withFieldPath(path FieldPath) error {
+	e.FieldPath = path
+	return e
+}
+
+func (e ConfigWarning) fieldPath() FieldPath { return e.FieldPath }
+
+func (e ConfigWarning) withFieldPath(path FieldPath) error {
+	e.FieldPath = path
+	return e
+}
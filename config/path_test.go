@@ -0,0 +1,140 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+func TestFieldPath_String(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     FieldPath
+		expected string
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name:     "Field",
+			path:     FieldPath{"test_groups"},
+			expected: "test_groups",
+		},
+		{
+			name:     "Nested fields and indexes",
+			path:     FieldPath{"dashboards", 3, "dashboard_tab", 1, "test_group_name"},
+			expected: "dashboards[3].dashboard_tab[1].test_group_name",
+		},
+		{
+			name:     "Consecutive indexes",
+			path:     FieldPath{"dashboards", 0, "dashboard_tab", 0, "open_test_template", "options", 2, "value"},
+			expected: "dashboards[0].dashboard_tab[0].open_test_template.options[2].value",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.path.String(); got != test.expected {
+				t.Errorf("expected %q, but got: %q", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestPathString(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name: "No error",
+		},
+		{
+			name: "Error without a path",
+			err:  errors.New("bad"),
+		},
+		{
+			name:     "Error with a path",
+			err:      ConfigError{"test_group_1", "TestGroup", "Bad.", FieldPath{"test_groups", 0, "query"}},
+			expected: "test_groups[0].query",
+		},
+		{
+			name:     "Wrapped error",
+			err:      fmt.Errorf("reading: %w", MissingFieldError{"TestGroups", FieldPath{"test_groups"}}),
+			expected: "test_groups",
+		},
+		{
+			name:     "First error with a path",
+			err:      Validate(mixedConfig()),
+			expected: "dashboards[0].dashboard_tab[1].test_group_name",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := PathString(test.err); got != test.expected {
+				t.Errorf("expected %q, but got: %q", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestAtPath(t *testing.T) {
+	plain := errors.New("bad")
+	tests := []struct {
+		name     string
+		err      error
+		expected []error
+	}{
+		{
+			name: "No error",
+		},
+		{
+			name:     "Single error",
+			err:      ConfigError{"test_group_1", "TestGroup", "Bad.", FieldPath{"query"}},
+			expected: []error{ConfigError{"test_group_1", "TestGroup", "Bad.", FieldPath{"test_groups", 2, "query"}}},
+		},
+		{
+			name: "Each error in a multierror",
+			err: multierror.Append(nil,
+				ConfigError{"test_group_1", "TestGroup", "Bad.", nil},
+				plain,
+				ConfigWarning{"test_group_1", "TestGroup", "Odd.", FieldPath{"days_of_results"}},
+			),
+			expected: []error{
+				ConfigError{"test_group_1", "TestGroup", "Bad.", FieldPath{"test_groups", 2}},
+				plain,
+				ConfigWarning{"test_group_1", "TestGroup", "Odd.", FieldPath{"test_groups", 2, "days_of_results"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := atPath(test.err, "test_groups", 2)
+			if !reflect.DeepEqual(errorList(got), test.expected) {
+				t.Errorf("expected %v, but got: %v", test.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// RenameTestGroup renames the Test Group matching oldName, along with each Dashboard Tab displaying it, in place.
+//
+// Names match after normalizing. RenameTestGroup returns the number of tabs it updated; it changes nothing
+// when the Test Group is missing or another Test Group already has the normalized newName.
+func RenameTestGroup(cfg *configpb.Configuration, oldName, newName string) (int, error) {
+	tg := FindTestGroup(cfg, oldName)
+	if tg == nil {
+		var candidates []string
+		for _, tg := range cfg.GetTestGroups() {
+			candidates = append(candidates, tg.Name)
+		}
+		return 0, MissingEntityError{oldName, "TestGroup", suggest(oldName, candidates), nil}
+	}
+	norm := Normalize(newName)
+	for i, other := range cfg.GetTestGroups() {
+		if other != tg && Normalize(other.Name) == norm {
+			return 0, DuplicateNameError{norm, "TestGroup", []string{other.Name, newName}, FieldPath{"test_groups", i, "name"}}
+		}
+	}
+
+	old := Normalize(tg.Name)
+	tg.Name = newName
+	var refs int
+	for _, dash := range cfg.GetDashboards() {
+		for _, tab := range dash.DashboardTab {
+			if Normalize(tab.TestGroupName) == old {
+				tab.TestGroupName = newName
+				refs++
+			}
+		}
+	}
+	return refs, nil
+}
+
+// RenameDashboard renames the Dashboard matching oldName, along with each Dashboard Group listing it, in place.
+//
+// Names match after normalizing. RenameDashboard returns the number of listings it updated; it changes nothing
+// when the Dashboard is missing or another Dashboard or Dashboard Group already has the normalized newName.
+func RenameDashboard(cfg *configpb.Configuration, oldName, newName string) (int, error) {
+	dash := FindDashboard(cfg, oldName)
+	if dash == nil {
+		var candidates []string
+		for _, dash := range cfg.GetDashboards() {
+			candidates = append(candidates, dash.Name)
+		}
+		return 0, MissingEntityError{oldName, "Dashboard", suggest(oldName, candidates), nil}
+	}
+	norm := Normalize(newName)
+	for i, other := range cfg.GetDashboards() {
+		if other != dash && Normalize(other.Name) == norm {
+			return 0, DuplicateNameError{norm, "Dashboard", []string{other.Name, newName}, FieldPath{"dashboards", i, "name"}}
+		}
+	}
+	// Dashboards share a namespace with Dashboard Groups.
+	for i, dg := range cfg.GetDashboardGroups() {
+		if Normalize(dg.Name) == norm {
+			return 0, DuplicateNameError{norm, "Dashboard/DashboardGroup", []string{dg.Name, newName}, FieldPath{"dashboard_groups", i, "name"}}
+		}
+	}
+
+	old := Normalize(dash.Name)
+	dash.Name = newName
+	var refs int
+	for _, dg := range cfg.GetDashboardGroups() {
+		for j, name := range dg.DashboardNames {
+			if Normalize(name) == old {
+				dg.DashboardNames[j] = newName
+				refs++
+			}
+		}
+	}
+	return refs, nil
+}
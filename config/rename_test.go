@@ -0,0 +1,184 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// renameConfig returns minimalConfig where Dashboard Tabs of two Dashboards reference two Test Groups, which two Dashboard Groups display.
+func renameConfig() *configpb.Configuration {
+	c := minimalConfig()
+	c.TestGroups = append(c.TestGroups, &configpb.TestGroup{Name: "test_group_2", Query: "bucket/logs/job-2"})
+	c.Dashboards[0].DashboardTab = append(c.Dashboards[0].DashboardTab,
+		&configpb.DashboardTab{Name: "tab_2", TestGroupName: "test_group_2"},
+		&configpb.DashboardTab{Name: "tab_3", TestGroupName: "Test-Group-1"},
+	)
+	c.Dashboards = append(c.Dashboards, &configpb.Dashboard{
+		Name: "dashboard_2",
+		DashboardTab: []*configpb.DashboardTab{
+			{Name: "tab_1", TestGroupName: "test_group_1"},
+		},
+	})
+	c.DashboardGroups = append(c.DashboardGroups,
+		&configpb.DashboardGroup{Name: "group_1", DashboardNames: []string{"dashboard_1", "Dashboard-2"}},
+		&configpb.DashboardGroup{Name: "group_2", DashboardNames: []string{"dashboard_2"}},
+	)
+	return &c
+}
+
+func TestRenameTestGroup(t *testing.T) {
+	tests := []struct {
+		name         string
+		old          string
+		new          string
+		expectedRefs int
+		expected     func(*configpb.Configuration)
+		expectedErr  error
+	}{
+		{
+			name:         "Updates references across dashboards",
+			old:          "test_group_1",
+			new:          "renamed_group",
+			expectedRefs: 3,
+			expected: func(cfg *configpb.Configuration) {
+				cfg.TestGroups[0].Name = "renamed_group"
+				cfg.Dashboards[0].DashboardTab[0].TestGroupName = "renamed_group"
+				cfg.Dashboards[0].DashboardTab[2].TestGroupName = "renamed_group"
+				cfg.Dashboards[1].DashboardTab[0].TestGroupName = "renamed_group"
+			},
+		},
+		{
+			name:         "Matches the normalized old name",
+			old:          "Test Group 2",
+			new:          "renamed_group",
+			expectedRefs: 1,
+			expected: func(cfg *configpb.Configuration) {
+				cfg.TestGroups[1].Name = "renamed_group"
+				cfg.Dashboards[0].DashboardTab[1].TestGroupName = "renamed_group"
+			},
+		},
+		{
+			name:         "Allows renaming to the same normalized name",
+			old:          "test_group_2",
+			new:          "Test-Group-2",
+			expectedRefs: 1,
+			expected: func(cfg *configpb.Configuration) {
+				cfg.TestGroups[1].Name = "Test-Group-2"
+				cfg.Dashboards[0].DashboardTab[1].TestGroupName = "Test-Group-2"
+			},
+		},
+		{
+			name:        "Refuses a colliding name",
+			old:         "test_group_1",
+			new:         "TEST_GROUP_2",
+			expectedErr: DuplicateNameError{"testgroup2", "TestGroup", []string{"test_group_2", "TEST_GROUP_2"}, FieldPath{"test_groups", 1, "name"}},
+		},
+		{
+			name:        "Missing group",
+			old:         "test_grop_1",
+			new:         "renamed_group",
+			expectedErr: MissingEntityError{"test_grop_1", "TestGroup", "test_group_1", nil},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := renameConfig()
+			refs, err := RenameTestGroup(cfg, test.old, test.new)
+			if !reflect.DeepEqual(err, test.expectedErr) {
+				t.Fatalf("RenameTestGroup() got error %v, want %v", err, test.expectedErr)
+			}
+			if refs != test.expectedRefs {
+				t.Errorf("RenameTestGroup() got %d references, want %d", refs, test.expectedRefs)
+			}
+			expected := renameConfig()
+			if test.expected != nil {
+				test.expected(expected)
+			}
+			if !proto.Equal(cfg, expected) {
+				t.Errorf("RenameTestGroup() got config %v, want %v", cfg, expected)
+			}
+		})
+	}
+}
+
+func TestRenameDashboard(t *testing.T) {
+	tests := []struct {
+		name         string
+		old          string
+		new          string
+		expectedRefs int
+		expected     func(*configpb.Configuration)
+		expectedErr  error
+	}{
+		{
+			name:         "Updates references across dashboard groups",
+			old:          "dashboard_2",
+			new:          "renamed_dashboard",
+			expectedRefs: 2,
+			expected: func(cfg *configpb.Configuration) {
+				cfg.Dashboards[1].Name = "renamed_dashboard"
+				cfg.DashboardGroups[0].DashboardNames[1] = "renamed_dashboard"
+				cfg.DashboardGroups[1].DashboardNames[0] = "renamed_dashboard"
+			},
+		},
+		{
+			name:        "Refuses a colliding Dashboard",
+			old:         "dashboard_2",
+			new:         "Dashboard 1",
+			expectedErr: DuplicateNameError{"dashboard1", "Dashboard", []string{"dashboard_1", "Dashboard 1"}, FieldPath{"dashboards", 0, "name"}},
+		},
+		{
+			name:        "Refuses a colliding Dashboard Group",
+			old:         "dashboard_2",
+			new:         "group_2",
+			expectedErr: DuplicateNameError{"group2", "Dashboard/DashboardGroup", []string{"group_2", "group_2"}, FieldPath{"dashboard_groups", 1, "name"}},
+		},
+		{
+			name:        "Missing dashboard",
+			old:         "missing",
+			new:         "renamed_dashboard",
+			expectedErr: MissingEntityError{"missing", "Dashboard", "", nil},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := renameConfig()
+			refs, err := RenameDashboard(cfg, test.old, test.new)
+			if !reflect.DeepEqual(err, test.expectedErr) {
+				t.Fatalf("RenameDashboard() got error %v, want %v", err, test.expectedErr)
+			}
+			if refs != test.expectedRefs {
+				t.Errorf("RenameDashboard() got %d references, want %d", refs, test.expectedRefs)
+			}
+			expected := renameConfig()
+			if test.expected != nil {
+				test.expected(expected)
+			}
+			if !proto.Equal(cfg, expected) {
+				t.Errorf("RenameDashboard() got config %v, want %v", cfg, expected)
+			}
+		})
+	}
+}
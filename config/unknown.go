@@ -0,0 +1,236 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// UnmarshalOptions customizes UnmarshalWithOptions.
+type UnmarshalOptions struct {
+	// WarnUnknownFields returns a warning for each message with fields this version of the
+	// Configuration proto does not define, such as ones a newer configurator added.
+	//
+	// Unknown fields are kept either way, so writing the config back out preserves them.
+	WarnUnknownFields bool
+}
+
+// UnmarshalWithOptions reads a protocol buffer into memory, returning any warnings separately.
+func UnmarshalWithOptions(r io.Reader, opts UnmarshalOptions) (*configpb.Configuration, error, error) {
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read config: %v", err)
+	}
+	var cfg configpb.Configuration
+	if err = proto.Unmarshal(buf, &cfg); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse: %v", err)
+	}
+	if !opts.WarnUnknownFields {
+		return &cfg, nil, nil
+	}
+	return &cfg, UnknownFields(&cfg), nil
+}
+
+// UnknownFields returns a ConfigWarning for each message of the configuration holding unknown fields.
+//
+// Each warning names the Test Group, Dashboard, Dashboard Tab or Dashboard Group containing the
+// message, lists the numbers of its unknown fields and locates the message with its FieldPath.
+func UnknownFields(c *configpb.Configuration) error {
+	mErr := &multierror.Error{}
+	walkUnknown(reflect.ValueOf(c), nil, func(path FieldPath, nums []int32, err error) {
+		name, entity := ownerOf(c, path)
+		msg := fmt.Sprintf("unknown fields %s", joinNumbers(nums))
+		switch {
+		case err != nil && len(nums) == 0:
+			msg = fmt.Sprintf("malformed unknown fields: %v", err)
+		case err != nil:
+			msg = fmt.Sprintf("%s before a malformed field: %v", msg, err)
+		}
+		mErr = multierror.Append(mErr, ConfigWarning{name, entity, msg, path})
+	})
+	return mErr.ErrorOrNil()
+}
+
+// walkUnknown calls visit with the path to each message under v with unknown fields, along with their numbers.
+func walkUnknown(v reflect.Value, path FieldPath, visit func(FieldPath, []int32, error)) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			walkUnknown(v.Elem(), path, visit)
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.Ptr {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			walkUnknown(v.Index(i), append(path[:len(path):len(path)], i), visit)
+		}
+	case reflect.Struct:
+		if raw := v.FieldByName("XXX_unrecognized"); raw.IsValid() && raw.Len() > 0 {
+			nums, err := fieldNumbers(raw.Bytes())
+			visit(path, nums, err)
+		}
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if _, ok := f.Tag.Lookup("protobuf_oneof"); ok {
+				// Fields of the oneof wrapper belong to this message.
+				walkUnknown(v.Field(i), path, visit)
+				continue
+			}
+			name := protoName(f)
+			if name == "" {
+				continue
+			}
+			walkUnknown(v.Field(i), append(path[:len(path):len(path)], name), visit)
+		}
+	}
+}
+
+// protoName returns the name of the proto field in the struct tag, or nothing for other fields.
+func protoName(f reflect.StructField) string {
+	for _, part := range strings.Split(f.Tag.Get("protobuf"), ",") {
+		if strings.HasPrefix(part, "name=") {
+			return strings.TrimPrefix(part, "name=")
+		}
+	}
+	return ""
+}
+
+// ownerOf returns the name and type of the entity containing the message at path.
+func ownerOf(c *configpb.Configuration, path FieldPath) (string, string) {
+	if len(path) < 2 {
+		return "", "Configuration"
+	}
+	i, _ := path[1].(int)
+	switch path[0] {
+	case "test_groups":
+		return c.TestGroups[i].GetName(), "TestGroup"
+	case "dashboard_groups":
+		return c.DashboardGroups[i].GetName(), "DashboardGroup"
+	case "dashboards":
+		dash := c.Dashboards[i]
+		if len(path) >= 4 && path[2] == "dashboard_tab" {
+			j, _ := path[3].(int)
+			return dash.GetName() + "/" + dash.DashboardTab[j].GetName(), "DashboardTab"
+		}
+		return dash.GetName(), "Dashboard"
+	}
+	return "", "Configuration"
+}
+
+var errTruncatedField = errors.New("truncated field")
+
+// fieldNumbers returns the sorted, distinct field numbers of wire-encoded fields.
+//
+// An error follows the numbers of the fields before a malformed one.
+func fieldNumbers(buf []byte) ([]int32, error) {
+	seen := map[int32]bool{}
+	var nums []int32
+	add := func(num int32) {
+		if !seen[num] {
+			seen[num] = true
+			nums = append(nums, num)
+		}
+	}
+	var err error
+	var groups []int32 // numbers of the unterminated groups containing the field
+	for len(buf) > 0 && err == nil {
+		var key uint64
+		if key, buf, err = readVarint(buf); err != nil {
+			break
+		}
+		num, wireType := int32(key>>3), key&7
+		if num <= 0 {
+			err = fmt.Errorf("bad field number %d", key>>3)
+			break
+		}
+		if len(groups) == 0 && wireType != 4 {
+			add(num)
+		}
+		switch wireType {
+		case 0: // varint
+			_, buf, err = readVarint(buf)
+		case 1: // fixed64
+			buf, err = skip(buf, 8)
+		case 2: // length-delimited
+			var n uint64
+			if n, buf, err = readVarint(buf); err == nil {
+				if n > uint64(len(buf)) {
+					err = errTruncatedField
+				} else {
+					buf = buf[n:]
+				}
+			}
+		case 3: // start group
+			groups = append(groups, num)
+		case 4: // end group
+			if len(groups) == 0 || groups[len(groups)-1] != num {
+				err = fmt.Errorf("unexpected end of group %d", num)
+			} else {
+				groups = groups[:len(groups)-1]
+			}
+		case 5: // fixed32
+			buf, err = skip(buf, 4)
+		default:
+			err = fmt.Errorf("field %d has unknown wire type %d", num, wireType)
+		}
+	}
+	if err == nil && len(groups) > 0 {
+		err = fmt.Errorf("unterminated group %d", groups[len(groups)-1])
+	}
+	sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
+	return nums, err
+}
+
+// readVarint decodes the varint at the start of buf, returning the rest.
+func readVarint(buf []byte) (uint64, []byte, error) {
+	var v uint64
+	for i := 0; i < len(buf) && i < 10; i++ {
+		v |= uint64(buf[i]&0x7f) << (7 * uint(i))
+		if buf[i] < 0x80 {
+			return v, buf[i+1:], nil
+		}
+	}
+	return 0, nil, errTruncatedField
+}
+
+func skip(buf []byte, n int) ([]byte, error) {
+	if len(buf) < n {
+		return nil, errTruncatedField
+	}
+	return buf[n:], nil
+}
+
+func joinNumbers(nums []int32) string {
+	parts := make([]string, len(nums))
+	for i, n := range nums {
+		parts[i] = fmt.Sprint(n)
+	}
+	return strings.Join(parts, ", ")
+}
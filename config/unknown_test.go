@@ -0,0 +1,227 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+func TestFieldNumbers(t *testing.T) {
+	cases := []struct {
+		name string
+		buf  []byte
+		want []int32
+		err  bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "varint",
+			buf:  []byte{0xf8, 0x07, 0x01}, // 127: 1
+			want: []int32{127},
+		},
+		{
+			name: "every wire type",
+			buf: []byte{
+				0x08, 0x96, 0x01, // 1: 150
+				0x11, 1, 2, 3, 4, 5, 6, 7, 8, // 2: fixed64
+				0x1a, 0x03, 'a', 'b', 'c', // 3: "abc"
+				0x23, 0x08, 0x01, 0x24, // 4: group {1: 1}
+				0x2d, 1, 2, 3, 4, // 5: fixed32
+			},
+			want: []int32{1, 2, 3, 4, 5},
+		},
+		{
+			name: "sorted and distinct",
+			buf: []byte{
+				0x80, 0x0c, 0x01, // 192: 1
+				0x10, 0x01, // 2: 1
+				0x80, 0x0c, 0x02, // 192: 2
+			},
+			want: []int32{2, 192},
+		},
+		{
+			name: "truncated length",
+			buf: []byte{
+				0x10, 0x01, // 2: 1
+				0x1a, 0x05, 'a', // 3: 5 bytes, but only 1
+			},
+			want: []int32{2, 3},
+			err:  true,
+		},
+		{
+			name: "truncated varint",
+			buf:  []byte{0x08, 0x96},
+			want: []int32{1},
+			err:  true,
+		},
+		{
+			name: "unterminated group",
+			buf:  []byte{0x23, 0x08, 0x01},
+			want: []int32{4},
+			err:  true,
+		},
+		{
+			name: "bad wire type",
+			buf:  []byte{0x0e},
+			want: []int32{1},
+			err:  true,
+		},
+		{
+			name: "zero field number",
+			buf:  []byte{0x00, 0x01},
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := fieldNumbers(tc.buf)
+			switch {
+			case err != nil && !tc.err:
+				t.Fatalf("fieldNumbers() got unexpected error: %v", err)
+			case err == nil && tc.err:
+				t.Fatalf("fieldNumbers() got %v, wanted an error", got)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("fieldNumbers() got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestUnknownFields(t *testing.T) {
+	cases := []struct {
+		name   string
+		mutate func(*configpb.Configuration)
+		want   []error
+	}{
+		{
+			name:   "no unknown fields",
+			mutate: func(*configpb.Configuration) {},
+		},
+		{
+			name: "configuration",
+			mutate: func(c *configpb.Configuration) {
+				c.XXX_unrecognized = []byte{0xf8, 0x07, 0x01, 0x20, 0x02}
+			},
+			want: []error{
+				ConfigWarning{"", "Configuration", "unknown fields 4, 127", nil},
+			},
+		},
+		{
+			name: "entities",
+			mutate: func(c *configpb.Configuration) {
+				c.TestGroups[0].XXX_unrecognized = []byte{0xf8, 0x07, 0x02}
+				c.Dashboards[0].XXX_unrecognized = []byte{0x80, 0x0c, 0x01}
+				c.Dashboards[0].DashboardTab[0].XXX_unrecognized = []byte{0xfa, 0x07, 0x00}
+				c.DashboardGroups = []*configpb.DashboardGroup{
+					{
+						Name:             "group",
+						XXX_unrecognized: []byte{0x7d, 1, 2, 3, 4},
+					},
+				}
+			},
+			want: []error{
+				ConfigWarning{"test_group_1", "TestGroup", "unknown fields 127", FieldPath{"test_groups", 0}},
+				ConfigWarning{"dashboard_1", "Dashboard", "unknown fields 192", FieldPath{"dashboards", 0}},
+				ConfigWarning{"dashboard_1/tab_1", "DashboardTab", "unknown fields 127", FieldPath{"dashboards", 0, "dashboard_tab", 0}},
+				ConfigWarning{"group", "DashboardGroup", "unknown fields 15", FieldPath{"dashboard_groups", 0}},
+			},
+		},
+		{
+			name: "nested messages",
+			mutate: func(c *configpb.Configuration) {
+				tg := c.TestGroups[0]
+				tg.ColumnHeader = []*configpb.TestGroup_ColumnHeader{
+					{ColumnHeaderSource: &configpb.TestGroup_ColumnHeader_Label{Label: "foo"}},
+					{
+						ColumnHeaderSource: &configpb.TestGroup_ColumnHeader_Property{Property: "bar"},
+						XXX_unrecognized:   []byte{0x28, 0x01},
+					},
+				}
+				tg.ResultSource = &configpb.TestGroup_ResultSource{
+					ResultSourceConfig: &configpb.TestGroup_ResultSource_JunitConfig{
+						JunitConfig: &configpb.JUnitConfig{XXX_unrecognized: []byte{0x50, 0x01}},
+					},
+				}
+				c.Dashboards[0].DashboardTab[0].AlertOptions = &configpb.DashboardTabAlertOptions{
+					XXX_unrecognized: []byte{0x98, 0x01, 0x01},
+				}
+			},
+			want: []error{
+				ConfigWarning{"test_group_1", "TestGroup", "unknown fields 5", FieldPath{"test_groups", 0, "column_header", 1}},
+				ConfigWarning{"test_group_1", "TestGroup", "unknown fields 10", FieldPath{"test_groups", 0, "result_source", "junit_config"}},
+				ConfigWarning{"dashboard_1/tab_1", "DashboardTab", "unknown fields 19", FieldPath{"dashboards", 0, "dashboard_tab", 0, "alert_options"}},
+			},
+		},
+		{
+			name: "malformed",
+			mutate: func(c *configpb.Configuration) {
+				c.TestGroups[0].XXX_unrecognized = []byte{0x10, 0x01, 0x1a, 0x05}
+				c.Dashboards[0].XXX_unrecognized = []byte{0x0e}
+			},
+			want: []error{
+				ConfigWarning{"test_group_1", "TestGroup", "unknown fields 2, 3 before a malformed field: truncated field", FieldPath{"test_groups", 0}},
+				ConfigWarning{"dashboard_1", "Dashboard", "unknown fields 1 before a malformed field: field 1 has unknown wire type 6", FieldPath{"dashboards", 0}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := minimalConfig()
+			tc.mutate(&c)
+			got := errorList(UnknownFields(&c))
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("UnknownFields() got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalWithOptions(t *testing.T) {
+	c := minimalConfig()
+	buf, err := proto.Marshal(&c)
+	if err != nil {
+		t.Fatalf("Marshal() got unexpected error: %v", err)
+	}
+
+	for _, opts := range []UnmarshalOptions{{}, {WarnUnknownFields: true}} {
+		got, warnings, err := UnmarshalWithOptions(bytes.NewReader(buf), opts)
+		if err != nil {
+			t.Fatalf("UnmarshalWithOptions(%+v) got unexpected error: %v", opts, err)
+		}
+		if warnings != nil {
+			t.Errorf("UnmarshalWithOptions(%+v) got unexpected warnings: %v", opts, warnings)
+		}
+		if !proto.Equal(got, &c) {
+			t.Errorf("UnmarshalWithOptions(%+v) got %v, want %v", opts, got, &c)
+		}
+	}
+
+	if _, _, err := UnmarshalWithOptions(bytes.NewReader([]byte{0x0a, 0x05}), UnmarshalOptions{}); err == nil {
+		t.Errorf("UnmarshalWithOptions() of truncated bytes got no error")
+	}
+}
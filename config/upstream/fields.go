@@ -0,0 +1,252 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstream
+
+// A message lists every field of a message of config.proto, with its number in the upstream proto.
+type message struct {
+	name   string
+	fields []field
+}
+
+// A field maps the number of a field in config.proto to the upstream proto.
+type field struct {
+	name     string
+	ours     int32
+	upstream int32    // Zero when the upstream proto has no equivalent.
+	msg      *message // The type of the field, when it is a message.
+}
+
+// same returns a field whose number matches in both protos.
+func same(name string, num int32) field {
+	return field{name: name, ours: num, upstream: num}
+}
+
+// sameMsg returns a message field whose number matches in both protos.
+func sameMsg(name string, num int32, msg *message) field {
+	return field{name: name, ours: num, upstream: num, msg: msg}
+}
+
+// ours returns a field that only config.proto has.
+func ours(name string, num int32) field {
+	return field{name: name, ours: num}
+}
+
+var configuration = &message{"Configuration", []field{
+	sameMsg("test_groups", 1, testGroup),
+	sameMsg("dashboards", 2, dashboard),
+	sameMsg("dashboard_groups", 3, dashboardGroup),
+}}
+
+// testGroup differs from the upstream TestGroup after ignore_skip, where each proto added fields independently.
+var testGroup = &message{"TestGroup", []field{
+	same("name", 1),
+	same("query", 2),
+	same("days_of_results", 3),
+	same("ignore_pending", 4),
+	same("ignore_built", 5),
+	same("tests_name_policy", 6),
+	same("gather_test_properties", 7),
+	same("ignore_test_substring", 8),
+	sameMsg("column_header", 9, columnHeader),
+	same("fallback_grouping", 10),
+	same("alert_stale_results_hours", 11),
+	same("num_failures_to_alert", 12),
+	same("bug_component", 13),
+	same("code_search_path", 14),
+	same("num_columns_recent", 15),
+	same("use_test_metadata", 16),
+	same("alert_mail_to_addresses", 17),
+	same("alert_mail_subject", 18),
+	same("alert_mail_failure_message", 19),
+	same("alert_mail_debug_url", 20),
+	same("min_elapsed_minutes_between_mails", 21),
+	same("use_configuration_values_as_alert_params", 22),
+	same("enable_flaky_status", 23),
+	same("use_kubernetes_client", 24),
+	same("is_external", 25),
+	sameMsg("test_name_config", 26, testNameConfig),
+	sameMsg("notifications", 27, notification),
+	same("column_sort_by", 28),
+	same("primary_grouping", 29),
+	same("enable_test_methods", 30),
+	sameMsg("test_annotations", 31, testAnnotation),
+	same("max_test_methods_per_test", 32),
+	same("commit_override_label_pattern", 33),
+	sameMsg("test_metadata_options", 34, testMetadataOptions),
+	same("test_tag_pattern", 35),
+	sameMsg("auto_bug_options", 36, autoBugOptions),
+	same("max_test_runtime_hours", 37),
+	same("num_passes_to_disable_alert", 38),
+	same("link_bugs_by_group", 39),
+	same("environment_instance", 40),
+	sameMsg("test_method_properties", 41, keyValue),
+	same("gather_bugs", 42),
+	same("short_text_metric", 43),
+	same("commit_override_configuration_value", 44),
+	same("link_bugs_by_test_methods", 45),
+	same("test_method_match_regex", 46),
+	same("use_full_method_names", 47),
+	same("custom_result_evaluator_rules", 48),
+	same("fallback_grouping_configuration_value", 49),
+	sameMsg("result_source", 50, resultSource),
+	sameMsg("custom_evaluator_rule_set", 51, ruleSet),
+	same("read_state_from_storage", 52),
+	same("ignore_old_results", 53),
+	same("ignore_skip", 54),
+	{name: "max_message_length", ours: 55, upstream: 57},
+	ours("broken_column_threshold", 56),
+	{name: "follow_symlinks", ours: 57, upstream: 55},
+	ours("column_order", 58),
+	ours("exclude_skips_from_pass_rate", 59),
+	ours("hide_skipped_rows", 60),
+	ours("status_mapping", 61),
+	ours("max_rows", 62),
+	ours("column_annotations", 63),
+	ours("additional_gcs_prefixes", 64),
+	ours("minimum_number_of_runs", 65),
+}}
+
+var columnHeader = &message{"TestGroup.ColumnHeader", []field{
+	same("label", 1),
+	same("property", 2),
+	same("configuration_value", 3),
+}}
+
+var testAnnotation = &message{"TestGroup.TestAnnotation", []field{
+	same("short_text", 1),
+	same("property_name", 2),
+}}
+
+var keyValue = &message{"TestGroup.KeyValue", []field{
+	same("key", 1),
+	same("value", 2),
+}}
+
+var resultSource = &message{"TestGroup.ResultSource", []field{
+	sameMsg("junit_config", 2, &message{"JUnitConfig", nil}),
+}}
+
+var testNameConfig = &message{"TestNameConfig", []field{
+	sameMsg("name_elements", 1, &message{"TestNameConfig.NameElement", []field{
+		same("labels", 1),
+		same("target_config", 2),
+		same("build_target", 3),
+		same("tags", 4),
+		same("test_property", 5),
+	}}),
+	same("name_format", 2),
+}}
+
+var notification = &message{"Notification", []field{
+	same("summary", 1),
+	same("context_link", 2),
+}}
+
+var testMetadataOptions = &message{"TestMetadataOptions", []field{
+	same("test_name_regex", 1),
+	same("bug_component", 2),
+	same("owner", 3),
+	same("cc", 4),
+	same("message_regex", 5),
+}}
+
+var autoBugOptions = &message{"AutoBugOptions", []field{
+	same("auto_close", 1),
+	same("hotlist_ids", 2),
+	same("priority", 3),
+	sameMsg("hotlist_ids_from_source", 4, &message{"HotlistIdFromSource", []field{
+		same("value", 1),
+		same("label", 2),
+	}}),
+	same("file_individual", 5),
+	same("singleton_autobug", 6),
+	same("max_allowed_individual_bugs", 7),
+	same("file_overall", 8),
+}}
+
+var ruleSet = &message{"RuleSet", []field{
+	sameMsg("rules", 1, &message{"Rule", []field{
+		sameMsg("test_result_comparisons", 1, &message{"TestResultComparison", []field{
+			sameMsg("comparison", 1, &message{"Comparison", []field{
+				same("op", 1),
+				same("string_value", 2),
+				same("numerical_value", 3),
+			}}),
+			same("property_key", 2),
+			same("test_result_field", 3),
+			same("test_result_error_field", 4),
+		}}),
+		same("computed_status", 3),
+	}}),
+}}
+
+// dashboard pauses are our own; the upstream Dashboard has no equivalent.
+var dashboard = &message{"Dashboard", []field{
+	sameMsg("dashboard_tab", 1, dashboardTab),
+	same("name", 2),
+	sameMsg("notifications", 3, notification),
+	same("default_tab", 5),
+	same("highlight_failing_tabs", 6),
+	same("highlight_today", 7),
+	same("downplay_failing_tabs", 8),
+	ours("paused", 9),
+	ours("paused_reason", 10),
+}}
+
+// dashboardTab differs from the upstream DashboardTab, which took 18 for a field we lack.
+var dashboardTab = &message{"DashboardTab", []field{
+	same("name", 1),
+	same("test_group_name", 2),
+	same("bug_component", 3),
+	same("code_search_path", 4),
+	same("num_columns_recent", 5),
+	same("base_options", 6),
+	sameMsg("open_test_template", 7, linkTemplate),
+	sameMsg("file_bug_template", 8, linkTemplate),
+	sameMsg("attach_bug_template", 9, linkTemplate),
+	same("results_text", 10),
+	sameMsg("results_url_template", 11, linkTemplate),
+	sameMsg("code_search_url_template", 12, linkTemplate),
+	same("description", 13),
+	same("tabular_names_regex", 14),
+	sameMsg("alert_options", 15, &message{"DashboardTabAlertOptions", []field{
+		same("alert_stale_results_hours", 1),
+		same("num_failures_to_alert", 2),
+		same("alert_mail_to_addresses", 3),
+		same("num_passes_to_disable_alert", 4),
+		same("subject", 5),
+		same("debug_url", 6),
+		same("debug_message", 7),
+	}}),
+	same("about_dashboard_url", 16),
+	sameMsg("open_bug_template", 17, linkTemplate),
+	{name: "num_flaky_tests", ours: 18, upstream: 19},
+	ours("minimum_number_of_runs", 19),
+}}
+
+var linkTemplate = &message{"LinkTemplate", []field{
+	same("url", 1),
+	sameMsg("options", 2, &message{"LinkOptionsTemplate", []field{
+		same("key", 1),
+		same("value", 2),
+	}}),
+}}
+
+var dashboardGroup = &message{"DashboardGroup", []field{
+	same("name", 1),
+	same("dashboard_names", 2),
+}}
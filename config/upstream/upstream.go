@@ -0,0 +1,171 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package upstream converts a Configuration to and from the config.proto of the hosted TestGrid instance.
+//
+// The upstream proto numbers a few fields differently, and each proto has fields the other lacks.
+// Conversion maps every field explicitly, failing on any set field without an equivalent
+// rather than silently dropping it.
+package upstream
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// ToUpstream serializes cfg as the Configuration of the upstream proto.
+func ToUpstream(cfg *configpb.Configuration) ([]byte, error) {
+	buf, err := proto.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("marshal: %v", err)
+	}
+	return convert(buf, configuration, toUpstream)
+}
+
+// FromUpstream parses buf, a Configuration of the upstream proto.
+func FromUpstream(buf []byte) (*configpb.Configuration, error) {
+	buf, err := convert(buf, configuration, fromUpstream)
+	if err != nil {
+		return nil, err
+	}
+	var cfg configpb.Configuration
+	if err := proto.Unmarshal(buf, &cfg); err != nil {
+		return nil, fmt.Errorf("unmarshal: %v", err)
+	}
+	return &cfg, nil
+}
+
+// A direction maps a message to its fields by their number in the source proto, and each field to its number in the
+// destination proto.
+type direction struct {
+	name   string
+	fields func(*message) map[int32]field
+	number func(field) int32
+}
+
+var toUpstream = direction{
+	name: "upstream",
+	fields: func(msg *message) map[int32]field {
+		fields := map[int32]field{}
+		for _, f := range msg.fields {
+			fields[f.ours] = f
+		}
+		return fields
+	},
+	number: func(f field) int32 { return f.upstream },
+}
+
+var fromUpstream = direction{
+	name: "our",
+	fields: func(msg *message) map[int32]field {
+		fields := map[int32]field{}
+		for _, f := range msg.fields {
+			if f.upstream != 0 {
+				fields[f.upstream] = f
+			}
+		}
+		return fields
+	},
+	number: func(f field) int32 { return f.ours },
+}
+
+// An encoded field of a message, with the number it has in the destination proto.
+type encoded struct {
+	num int32
+	buf []byte
+}
+
+// convert renumbers each field of the serialized msg in buf, recursing into its message fields.
+//
+// Fields are written in the order of their destination number, like proto.Marshal.
+func convert(buf []byte, msg *message, dir direction) ([]byte, error) {
+	fields := dir.fields(msg)
+	var out []encoded
+	for len(buf) > 0 {
+		key, n := binary.Uvarint(buf)
+		if n <= 0 {
+			return nil, fmt.Errorf("%s: bad field key", msg.name)
+		}
+		buf = buf[n:]
+		num, wire := int32(key>>3), key&7
+		val, n, err := value(buf, wire)
+		if err != nil {
+			return nil, fmt.Errorf("%s: field %d: %v", msg.name, num, err)
+		}
+		buf = buf[n:]
+		f, ok := fields[num]
+		if !ok {
+			return nil, fmt.Errorf("%s: field %d is unknown", msg.name, num)
+		}
+		to := dir.number(f)
+		if to == 0 {
+			return nil, fmt.Errorf("%s.%s has no equivalent in the %s proto", msg.name, f.name, dir.name)
+		}
+		if f.msg != nil {
+			if wire != proto.WireBytes {
+				return nil, fmt.Errorf("%s.%s: wire type %d is not a message", msg.name, f.name, wire)
+			}
+			_, hdr := binary.Uvarint(val)
+			sub, err := convert(val[hdr:], f.msg, dir)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", msg.name, f.name, err)
+			}
+			val = proto.EncodeVarint(uint64(len(sub)))
+			val = append(val, sub...)
+		}
+		enc := proto.EncodeVarint(uint64(to)<<3 | wire)
+		out = append(out, encoded{to, append(enc, val...)})
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].num < out[j].num })
+	var ret []byte
+	for _, e := range out {
+		ret = append(ret, e.buf...)
+	}
+	return ret, nil
+}
+
+// value returns the encoding of the value at the start of buf, including the length of bytes, and its size.
+func value(buf []byte, wire uint64) ([]byte, int, error) {
+	var n int
+	switch wire {
+	case proto.WireVarint:
+		_, n = binary.Uvarint(buf)
+		if n <= 0 {
+			return nil, 0, fmt.Errorf("bad varint")
+		}
+	case proto.WireFixed64:
+		n = 8
+	case proto.WireFixed32:
+		n = 4
+	case proto.WireBytes:
+		size, hdr := binary.Uvarint(buf)
+		if hdr <= 0 || size > uint64(len(buf)-hdr) {
+			return nil, 0, fmt.Errorf("bad length")
+		}
+		n = hdr + int(size)
+	default:
+		return nil, 0, fmt.Errorf("unsupported wire type %d", wire)
+	}
+	if n > len(buf) {
+		return nil, 0, fmt.Errorf("truncated")
+	}
+	return buf[:n], n, nil
+}
@@ -0,0 +1,287 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstream
+
+import (
+	"bytes"
+	"io/ioutil"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	cepb "github.com/GoogleCloudPlatform/testgrid/pb/custom_evaluator"
+	tspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+// golden is the upstream serialization of sample().
+const golden = "testdata/upstream.pb"
+
+// sample returns a configuration setting the fields that upstream numbers differently, and nested messages.
+func sample() *configpb.Configuration {
+	return &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{
+			{
+				Name:           "group",
+				Query:          "bucket/logs/job",
+				DaysOfResults:  7,
+				ColumnHeader:   []*configpb.TestGroup_ColumnHeader{{ColumnHeaderSource: &configpb.TestGroup_ColumnHeader_ConfigurationValue{ConfigurationValue: "Commit"}}},
+				TestNameConfig: &configpb.TestNameConfig{NameFormat: "%s", NameElements: []*configpb.TestNameConfig_NameElement{{TargetConfig: "Tests name"}}},
+				AutoBugOptions: &configpb.AutoBugOptions{
+					HotlistIds:           []int64{1, 300},
+					HotlistIdsFromSource: []*configpb.HotlistIdFromSource{{HotlistIdSource: &configpb.HotlistIdFromSource_Label{Label: "hot"}}},
+				},
+				CustomEvaluatorRuleSet: &cepb.RuleSet{Rules: []*cepb.Rule{{
+					TestResultComparisons: []*cepb.TestResultComparison{{
+						Comparison:     &cepb.Comparison{Op: cepb.Comparison_OP_GT, ComparisonValue: &cepb.Comparison_NumericalValue{NumericalValue: 1.5}},
+						TestResultInfo: &cepb.TestResultComparison_PropertyKey{PropertyKey: "elapsed"},
+					}},
+					ComputedStatus: tspb.TestStatus_FLAKY,
+				}}},
+				IgnoreSkip:       true,
+				MaxMessageLength: 300,
+				FollowSymlinks:   true,
+			},
+		},
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name: "dash",
+				DashboardTab: []*configpb.DashboardTab{
+					{
+						Name:               "tab",
+						TestGroupName:      "group",
+						ResultsUrlTemplate: &configpb.LinkTemplate{Url: "https://example.com/<gcs_prefix>"},
+						AlertOptions:       &configpb.DashboardTabAlertOptions{NumFailuresToAlert: 3},
+						NumFlakyTests:      5,
+					},
+				},
+				HighlightToday: true,
+			},
+		},
+		DashboardGroups: []*configpb.DashboardGroup{
+			{Name: "dashboards", DashboardNames: []string{"dash"}},
+		},
+	}
+}
+
+func TestToUpstream(t *testing.T) {
+	want, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("ReadFile(%q) got unexpected error: %v", golden, err)
+	}
+	got, err := ToUpstream(sample())
+	if err != nil {
+		t.Fatalf("ToUpstream() got unexpected error: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("ToUpstream() got %x, want %x", got, want)
+	}
+
+	cases := []struct {
+		name   string
+		modify func(*configpb.Configuration)
+	}{
+		{
+			name:   "test group max rows",
+			modify: func(cfg *configpb.Configuration) { cfg.TestGroups[0].MaxRows = 10 },
+		},
+		{
+			name: "test group status mapping",
+			modify: func(cfg *configpb.Configuration) {
+				cfg.TestGroups[0].StatusMapping = &configpb.TestGroup_StatusMapping{}
+			},
+		},
+		{
+			name:   "paused dashboard",
+			modify: func(cfg *configpb.Configuration) { cfg.Dashboards[0].Paused = true },
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := sample()
+			tc.modify(cfg)
+			if buf, err := ToUpstream(cfg); err == nil {
+				t.Errorf("ToUpstream() failed to return an error, got %x", buf)
+			}
+		})
+	}
+}
+
+func TestFromUpstream(t *testing.T) {
+	buf, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("ReadFile(%q) got unexpected error: %v", golden, err)
+	}
+	got, err := FromUpstream(buf)
+	if err != nil {
+		t.Fatalf("FromUpstream() got unexpected error: %v", err)
+	}
+	if want := sample(); !proto.Equal(got, want) {
+		t.Errorf("FromUpstream() got %v, want %v", got, want)
+	}
+
+	cases := []struct {
+		name string
+		buf  []byte
+	}{
+		{
+			name: "upstream test group field",
+			// test_groups {56: 1}
+			buf: []byte{0x0a, 0x03, 0xc0, 0x03, 0x01},
+		},
+		{
+			name: "upstream dashboard tab field",
+			// dashboards {dashboard_tab {18: 1}}
+			buf: []byte{0x12, 0x05, 0x0a, 0x03, 0x90, 0x01, 0x01},
+		},
+		{
+			name: "unknown configuration field",
+			buf:  []byte{0x20, 0x01},
+		},
+		{
+			name: "truncated",
+			buf:  buf[:len(buf)-1],
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if cfg, err := FromUpstream(tc.buf); err == nil {
+				t.Errorf("FromUpstream() failed to return an error, got %v", cfg)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	cases := []struct {
+		name   string
+		modify func(*configpb.Configuration)
+	}{
+		{
+			name:   "sample",
+			modify: func(*configpb.Configuration) {},
+		},
+		{
+			name:   "deprecated test group field",
+			modify: func(cfg *configpb.Configuration) { cfg.TestGroups[0].GatherTestProperties = true },
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			want := sample()
+			tc.modify(want)
+			buf, err := ToUpstream(want)
+			if err != nil {
+				t.Fatalf("ToUpstream() got unexpected error: %v", err)
+			}
+			got, err := FromUpstream(buf)
+			if err != nil {
+				t.Fatalf("FromUpstream() got unexpected error: %v", err)
+			}
+			if !proto.Equal(got, want) {
+				t.Errorf("FromUpstream(ToUpstream()) got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// A protoField is a field of a generated message, with the type of its message, if any.
+type protoField struct {
+	name string
+	num  int32
+	msg  reflect.Type
+}
+
+// protoFields returns the tagged fields of the generated message t, including those of its oneofs.
+func protoFields(t reflect.Type) []protoField {
+	var out []protoField
+	add := func(sf reflect.StructField) {
+		parts := strings.Split(sf.Tag.Get("protobuf"), ",")
+		if len(parts) < 2 {
+			return
+		}
+		num, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return
+		}
+		pf := protoField{num: int32(num)}
+		for _, p := range parts {
+			if strings.HasPrefix(p, "name=") {
+				pf.name = strings.TrimPrefix(p, "name=")
+			}
+		}
+		ft := sf.Type
+		if ft.Kind() == reflect.Slice {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Ptr && ft.Elem().Kind() == reflect.Struct {
+			pf.msg = ft.Elem()
+		}
+		out = append(out, pf)
+	}
+	for i := 0; i < t.NumField(); i++ {
+		add(t.Field(i))
+	}
+	if m, ok := reflect.New(t).Interface().(interface{ XXX_OneofWrappers() []interface{} }); ok {
+		for _, w := range m.XXX_OneofWrappers() {
+			add(reflect.TypeOf(w).Elem().Field(0))
+		}
+	}
+	return out
+}
+
+// checkTable reports each field of the generated message t that msg lacks or names differently, recursing into message fields.
+func checkTable(t *testing.T, typ reflect.Type, msg *message, seen map[*message]bool) {
+	t.Helper()
+	if seen[msg] {
+		return
+	}
+	seen[msg] = true
+	table := map[int32]field{}
+	for _, f := range msg.fields {
+		table[f.ours] = f
+	}
+	for _, pf := range protoFields(typ) {
+		f, ok := table[pf.num]
+		if !ok {
+			t.Errorf("%s: missing field %d, %s", msg.name, pf.num, pf.name)
+			continue
+		}
+		delete(table, pf.num)
+		if f.name != pf.name {
+			t.Errorf("%s: field %d is %s, want %s", msg.name, pf.num, f.name, pf.name)
+		}
+		if pf.msg == nil || f.upstream == 0 {
+			continue
+		}
+		if f.msg == nil {
+			t.Errorf("%s.%s: missing the fields of %s", msg.name, f.name, pf.msg.Name())
+			continue
+		}
+		checkTable(t, pf.msg, f.msg, seen)
+	}
+	for num, f := range table {
+		t.Errorf("%s: field %d, %s, is not in config.proto", msg.name, num, f.name)
+	}
+}
+
+func TestFieldsCoverConfigProto(t *testing.T) {
+	checkTable(t, reflect.TypeOf(configpb.Configuration{}), configuration, map[*message]bool{})
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DashboardURL returns the frontend link to a Dashboard, such as https://host/#/my%20dashboard.
+//
+// The host may include a scheme, which defaults to https, and a path prefix.
+func DashboardURL(host, dashboard string) string {
+	return baseURL(host) + "/#/" + url.PathEscape(dashboard)
+}
+
+// TabURL returns the frontend link to a Dashboard Tab, such as https://host/#/dashboard/tab?include-filter-by-regex=foo.
+//
+// Names are escaped so that spaces, #, ?, / and non-ASCII characters survive; the frontend
+// matches them after normalizing, so they are otherwise kept as configured.
+func TabURL(host, dashboard, tab string, params url.Values) string {
+	link := DashboardURL(host, dashboard) + "/" + url.PathEscape(tab)
+	if len(params) > 0 {
+		link += "?" + params.Encode()
+	}
+	return link
+}
+
+// baseURL returns the host with a scheme and without a trailing slash.
+func baseURL(host string) string {
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+	return strings.TrimRight(host, "/")
+}
+
+// ParseTabURL returns the names and params of a link created by TabURL or DashboardURL.
+//
+// The tab is empty for a Dashboard link.
+func ParseTabURL(link string) (string, string, url.Values, error) {
+	// Split the raw fragment, which url.Parse would unescape.
+	idx := strings.Index(link, "#")
+	if idx < 0 {
+		return "", "", nil, errors.New("missing #/ fragment")
+	}
+	frag := link[idx+1:]
+	if !strings.HasPrefix(frag, "/") {
+		return "", "", nil, fmt.Errorf("fragment %q does not start with /", frag)
+	}
+	var query string
+	if q := strings.Index(frag, "?"); q >= 0 {
+		frag, query = frag[:q], frag[q+1:]
+	}
+	parts := strings.Split(frag[1:], "/")
+	if len(parts) > 2 {
+		return "", "", nil, fmt.Errorf("fragment %q has %d names, want a dashboard and at most one tab", frag, len(parts))
+	}
+	names := make([]string, 2)
+	for i, p := range parts {
+		name, err := url.PathUnescape(p)
+		if err != nil {
+			return "", "", nil, fmt.Errorf("unescape %q: %w", p, err)
+		}
+		names[i] = name
+	}
+	if names[0] == "" {
+		return "", "", nil, errors.New("missing dashboard name")
+	}
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("parse params: %w", err)
+	}
+	return names[0], names[1], params, nil
+}
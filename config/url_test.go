@@ -0,0 +1,147 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestTabURL(t *testing.T) {
+	cases := []struct {
+		name      string
+		host      string
+		dashboard string
+		tab       string
+		params    url.Values
+		want      string
+	}{
+		{
+			name:      "basically works",
+			host:      "testgrid.example.com",
+			dashboard: "dash",
+			tab:       "tab",
+			want:      "https://testgrid.example.com/#/dash/tab",
+		},
+		{
+			name:      "keep scheme and path",
+			host:      "http://localhost:8080/testgrid/",
+			dashboard: "dash",
+			tab:       "tab",
+			want:      "http://localhost:8080/testgrid/#/dash/tab",
+		},
+		{
+			name:      "params",
+			host:      "host",
+			dashboard: "dash",
+			tab:       "tab",
+			params:    url.Values{"include-filter-by-regex": []string{"^foo&bar$"}, "width": []string{"20"}},
+			want:      "https://host/#/dash/tab?include-filter-by-regex=%5Efoo%26bar%24&width=20",
+		},
+		{
+			name:      "escape names",
+			host:      "host",
+			dashboard: "sig release/master",
+			tab:       "tab #1?",
+			want:      "https://host/#/sig%20release%2Fmaster/tab%20%231%3F",
+		},
+		{
+			name:      "unicode",
+			host:      "host",
+			dashboard: "café",
+			tab:       "测试",
+			want:      "https://host/#/caf%C3%A9/%E6%B5%8B%E8%AF%95",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := TabURL(tc.host, tc.dashboard, tc.tab, tc.params)
+			if got != tc.want {
+				t.Fatalf("TabURL() got %q, want %q", got, tc.want)
+			}
+			dashboard, tab, params, err := ParseTabURL(got)
+			if err != nil {
+				t.Fatalf("ParseTabURL(%q) got unexpected error: %v", got, err)
+			}
+			if dashboard != tc.dashboard || tab != tc.tab {
+				t.Errorf("ParseTabURL(%q) got %q/%q, want %q/%q", got, dashboard, tab, tc.dashboard, tc.tab)
+			}
+			if len(params) > 0 || len(tc.params) > 0 {
+				if !reflect.DeepEqual(params, tc.params) {
+					t.Errorf("ParseTabURL(%q) got params %v, want %v", got, params, tc.params)
+				}
+			}
+		})
+	}
+}
+
+func TestDashboardURL(t *testing.T) {
+	const dashboard = "a #dashboard? ünïcode"
+	link := DashboardURL("host", dashboard)
+	if want := "https://host/#/a%20%23dashboard%3F%20%C3%BCn%C3%AFcode"; link != want {
+		t.Fatalf("DashboardURL() got %q, want %q", link, want)
+	}
+	dash, tab, _, err := ParseTabURL(link)
+	if err != nil {
+		t.Fatalf("ParseTabURL(%q) got unexpected error: %v", link, err)
+	}
+	if dash != dashboard || tab != "" {
+		t.Errorf("ParseTabURL(%q) got %q/%q, want %q", link, dash, tab, dashboard)
+	}
+}
+
+func TestParseTabURL(t *testing.T) {
+	cases := []struct {
+		name string
+		link string
+	}{
+		{
+			name: "no fragment",
+			link: "https://host/dash",
+		},
+		{
+			name: "no slash",
+			link: "https://host/#dash",
+		},
+		{
+			name: "no dashboard",
+			link: "https://host/#/",
+		},
+		{
+			name: "too many names",
+			link: "https://host/#/dash/tab/extra",
+		},
+		{
+			name: "bad escape",
+			link: "https://host/#/dash/%zz",
+		},
+		{
+			name: "bad params",
+			link: "https://host/#/dash/tab?width=%zz",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if dash, tab, _, err := ParseTabURL(tc.link); err == nil {
+				t.Errorf("ParseTabURL(%q) got %q/%q, wanted an error", tc.link, dash, tab)
+			}
+		})
+	}
+}
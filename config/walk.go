@@ -0,0 +1,59 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"github.com/golang/protobuf/proto"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// WalkFunc visits a named entity of a Configuration.
+//
+// kind matches the entity of the errors describing it: "TestGroup", "Dashboard", "DashboardTab" or "DashboardGroup".
+// path holds the names of the entities containing it, which is the Dashboard name for a tab and empty otherwise.
+// msg is the entity itself, such as a *configpb.DashboardTab; changes to it modify the Configuration.
+type WalkFunc func(kind string, path []string, name string, msg proto.Message) error
+
+// Walk calls fn for each Test Group, Dashboard, Dashboard Tab and Dashboard Group of cfg, stopping at the first error.
+//
+// Entities are visited in declaration order: every Test Group, then each Dashboard followed by its tabs,
+// then every Dashboard Group. Walk returns the error of fn unchanged.
+func Walk(cfg *configpb.Configuration, fn WalkFunc) error {
+	for _, tg := range cfg.GetTestGroups() {
+		if err := fn("TestGroup", nil, tg.GetName(), tg); err != nil {
+			return err
+		}
+	}
+	for _, dash := range cfg.GetDashboards() {
+		if err := fn("Dashboard", nil, dash.GetName(), dash); err != nil {
+			return err
+		}
+		path := []string{dash.GetName()}
+		for _, tab := range dash.GetDashboardTab() {
+			if err := fn("DashboardTab", path, tab.GetName(), tab); err != nil {
+				return err
+			}
+		}
+	}
+	for _, dg := range cfg.GetDashboardGroups() {
+		if err := fn("DashboardGroup", nil, dg.GetName(), dg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+type visit struct {
+	kind string
+	path []string
+	name string
+	msg  proto.Message
+}
+
+func TestWalk(t *testing.T) {
+	cfg := &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{
+			{Name: "test_group_1"},
+			{Name: "test_group_2"},
+		},
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name: "dashboard_1",
+				DashboardTab: []*configpb.DashboardTab{
+					{Name: "tab_1"},
+					{Name: "tab_2"},
+				},
+			},
+			{Name: "dashboard_2"},
+		},
+		DashboardGroups: []*configpb.DashboardGroup{
+			{Name: "dashboard_group_1"},
+		},
+	}
+	all := []visit{
+		{"TestGroup", nil, "test_group_1", cfg.TestGroups[0]},
+		{"TestGroup", nil, "test_group_2", cfg.TestGroups[1]},
+		{"Dashboard", nil, "dashboard_1", cfg.Dashboards[0]},
+		{"DashboardTab", []string{"dashboard_1"}, "tab_1", cfg.Dashboards[0].DashboardTab[0]},
+		{"DashboardTab", []string{"dashboard_1"}, "tab_2", cfg.Dashboards[0].DashboardTab[1]},
+		{"Dashboard", nil, "dashboard_2", cfg.Dashboards[1]},
+		{"DashboardGroup", nil, "dashboard_group_1", cfg.DashboardGroups[0]},
+	}
+	stop := errors.New("stop")
+
+	tests := []struct {
+		name     string
+		stopAt   string
+		expected []visit
+	}{
+		{
+			name:     "Visits every entity in order",
+			expected: all,
+		},
+		{
+			name:     "Stops at the first error",
+			stopAt:   "tab_1",
+			expected: all[:4],
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got []visit
+			err := Walk(cfg, func(kind string, path []string, name string, msg proto.Message) error {
+				got = append(got, visit{kind, path, name, msg})
+				if name == test.stopAt {
+					return stop
+				}
+				return nil
+			})
+			if test.stopAt != "" && err != stop {
+				t.Errorf("Walk() got error %v, want %v", err, stop)
+			} else if test.stopAt == "" && err != nil {
+				t.Errorf("Walk() got unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("Walk() visited %v, want %v", got, test.expected)
+			}
+		})
+	}
+
+	if err := Walk(&configpb.Configuration{}, func(string, []string, string, proto.Message) error {
+		return stop
+	}); err != nil {
+		t.Errorf("Walk() of an empty config got unexpected error: %v", err)
+	}
+}
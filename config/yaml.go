@@ -0,0 +1,125 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// UnknownFieldError means the YAML sets a field that the message does not define.
+type UnknownFieldError struct {
+	Path FieldPath
+}
+
+func (e UnknownFieldError) Error() string {
+	return fmt.Sprintf("unknown field: %s", e.Path)
+}
+
+// UnmarshalYAML parses the YAML into v, such as a Configuration.
+//
+// Returns an UnknownFieldError for the first field, in sorted order, that v does not define.
+func UnmarshalYAML(buf []byte, v interface{}) error {
+	jsonBytes, err := yaml.YAMLToJSON(buf)
+	if err != nil {
+		return err
+	}
+	var raw interface{}
+	if err := json.Unmarshal(jsonBytes, &raw); err != nil {
+		return err
+	}
+	if path := unknownField(raw, reflect.TypeOf(v), nil); path != nil {
+		return UnknownFieldError{path}
+	}
+	return json.Unmarshal(jsonBytes, v)
+}
+
+// unknownField returns the path to the first field in value that t does not define, or nil when t defines them all.
+//
+// Field names match case-insensitively, like encoding/json.
+func unknownField(value interface{}, t reflect.Type, path FieldPath) FieldPath {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if t.Kind() != reflect.Struct {
+			return nil // Leave type mismatches to encoding/json
+		}
+		fields := jsonFields(t)
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			childPath := append(path[:len(path):len(path)], key)
+			ft, ok := fields[strings.ToLower(key)]
+			if !ok {
+				return childPath
+			}
+			if p := unknownField(v[key], ft, childPath); p != nil {
+				return p
+			}
+		}
+	case []interface{}:
+		if t.Kind() != reflect.Slice {
+			return nil
+		}
+		for i, child := range v {
+			if p := unknownField(child, t.Elem(), append(path[:len(path):len(path)], i)); p != nil {
+				return p
+			}
+		}
+	}
+	return nil
+}
+
+// jsonFields maps the lowercase JSON name of each field in struct t, including those of embedded structs, to its type.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		ft := f.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			for k, v := range jsonFields(ft) {
+				fields[k] = v
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = f.Type
+	}
+	return fields
+}
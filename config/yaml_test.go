@@ -0,0 +1,113 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+func TestUnmarshalYAML(t *testing.T) {
+	cases := []struct {
+		name        string
+		yaml        string
+		expected    *configpb.Configuration
+		expectedErr error
+	}{
+		{
+			name:     "empty",
+			expected: &configpb.Configuration{},
+		},
+		{
+			name: "known fields",
+			yaml: `
+test_groups:
+- name: tg
+  query: bucket/tg
+dashboards:
+- name: dash
+  dashboard_tab:
+  - name: tab
+    test_group_name: tg
+    alert_options:
+      num_failures_to_alert: 3
+`,
+			expected: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "tg", Query: "bucket/tg"}},
+				Dashboards: []*configpb.Dashboard{{
+					Name: "dash",
+					DashboardTab: []*configpb.DashboardTab{{
+						Name:          "tab",
+						TestGroupName: "tg",
+						AlertOptions:  &configpb.DashboardTabAlertOptions{NumFailuresToAlert: 3},
+					}},
+				}},
+			},
+		},
+		{
+			name: "match names case-insensitively",
+			yaml: `
+Test_Groups:
+- Name: tg
+`,
+			expected: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "tg"}},
+			},
+		},
+		{
+			name:        "unknown top-level field",
+			yaml:        "test_group: []",
+			expectedErr: UnknownFieldError{FieldPath{"test_group"}},
+		},
+		{
+			name: "unknown nested field",
+			yaml: `
+dashboards:
+- name: dash
+  dashboard_tab:
+  - name: tab
+  - name: other
+    alert_options:
+      num_failures: 3
+`,
+			expectedErr: UnknownFieldError{FieldPath{"dashboards", 0, "dashboard_tab", 1, "alert_options", "num_failures"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got configpb.Configuration
+			err := UnmarshalYAML([]byte(tc.yaml), &got)
+			if tc.expectedErr != nil {
+				if !reflect.DeepEqual(err, tc.expectedErr) {
+					t.Errorf("UnmarshalYAML() got error %v, want %v", err, tc.expectedErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UnmarshalYAML() got unexpected error: %v", err)
+			}
+			if !proto.Equal(&got, tc.expected) {
+				t.Errorf("UnmarshalYAML() got %v, want %v", &got, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package yamlcfg
+
+import (
+	"errors"
+	"fmt"
+
+	cfgutil "github.com/GoogleCloudPlatform/testgrid/config"
+	"github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// UnknownFieldError is an error that includes the path to a YAML field the configuration does not define.
+type UnknownFieldError struct {
+	Path string
+}
+
+func (e UnknownFieldError) Error() string {
+	return fmt.Sprintf("unknown field: %s", e.Path)
+}
+
+// Unmarshal parses YAML into a fully-resolved configuration.
+//
+// Each Test Group and Dashboard Tab inherits unset fields from defaults, when non-nil, see config.ApplyDefaults.
+// Returns an UnknownFieldError for the first field that the configuration does not define.
+func Unmarshal(yamlBytes []byte, defaults *config.DefaultConfiguration) (*config.Configuration, error) {
+	var cfg config.Configuration
+	if err := cfgutil.UnmarshalYAML(yamlBytes, &cfg); err != nil {
+		var unknown cfgutil.UnknownFieldError
+		if errors.As(err, &unknown) {
+			return nil, UnknownFieldError{unknown.Path.String()}
+		}
+		return nil, err
+	}
+
+	cfgutil.ApplyDefaults(&cfg, defaults)
+	return &cfg, nil
+}
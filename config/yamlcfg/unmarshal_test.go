@@ -0,0 +1,150 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package yamlcfg
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+func TestUnmarshal(t *testing.T) {
+	defaults := &config.DefaultConfiguration{
+		DefaultTestGroup: &config.TestGroup{
+			DaysOfResults: 15,
+		},
+		DefaultDashboardTab: &config.DashboardTab{
+			NumColumnsRecent: 10,
+		},
+	}
+
+	tests := []struct {
+		name        string
+		yaml        string
+		defaults    *config.DefaultConfiguration
+		expected    *config.Configuration
+		expectedErr error
+	}{
+		{
+			name:     "Empty",
+			expected: &config.Configuration{},
+		},
+		{
+			name: "Without defaults",
+			yaml: "test_groups:\n- name: tg\n",
+			expected: &config.Configuration{
+				TestGroups: []*config.TestGroup{{Name: "tg"}},
+			},
+		},
+		{
+			name:     "Inherits defaults",
+			yaml:     "test_groups:\n- name: tg\n- name: tg2\n  days_of_results: 3\ndashboards:\n- name: dash\n  dashboard_tab:\n  - name: tab\n",
+			defaults: defaults,
+			expected: &config.Configuration{
+				TestGroups: []*config.TestGroup{
+					{Name: "tg", DaysOfResults: 15},
+					{Name: "tg2", DaysOfResults: 3},
+				},
+				Dashboards: []*config.Dashboard{
+					{
+						Name:         "dash",
+						DashboardTab: []*config.DashboardTab{{Name: "tab", NumColumnsRecent: 10}},
+					},
+				},
+			},
+		},
+		{
+			name: "Inherits every field",
+			yaml: "test_groups:\n- name: tg\n",
+			defaults: &config.DefaultConfiguration{
+				DefaultTestGroup: &config.TestGroup{Name: "default", AlertMailToAddresses: "team@example.com"},
+			},
+			expected: &config.Configuration{
+				TestGroups: []*config.TestGroup{{Name: "tg", AlertMailToAddresses: "team@example.com"}},
+			},
+		},
+		{
+			name:        "Unknown top-level field",
+			yaml:        "test_group:\n- name: tg\n",
+			expectedErr: UnknownFieldError{"test_group"},
+		},
+		{
+			name:        "Unknown nested field",
+			yaml:        "dashboards:\n- name: dash\n  dashboard_tab:\n  - name: tab\n  - name: tab2\n    alert_options:\n      num_failures: 3\n",
+			expectedErr: UnknownFieldError{"dashboards[0].dashboard_tab[1].alert_options.num_failures"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Unmarshal([]byte(test.yaml), test.defaults)
+			if !reflect.DeepEqual(test.expectedErr, err) {
+				t.Fatalf("Expected error %v, but got: %v", test.expectedErr, err)
+			}
+			if !reflect.DeepEqual(test.expected, got) {
+				t.Errorf("Expected %v, but got: %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestUnmarshal_RoundTrip(t *testing.T) {
+	b, err := ioutil.ReadFile("testdata/defaults.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read defaults: %v", err)
+	}
+	defaults, err := LoadDefaults(b)
+	if err != nil {
+		t.Fatalf("Failed to load defaults: %v", err)
+	}
+	pbDefaults := &config.DefaultConfiguration{
+		DefaultTestGroup:    defaults.DefaultTestGroup,
+		DefaultDashboardTab: defaults.DefaultDashboardTab,
+	}
+
+	b, err = ioutil.ReadFile("testdata/sample.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	cfg, err := Unmarshal(b, pbDefaults)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := cfg.TestGroups[0].DaysOfResults; got != 15 {
+		t.Errorf("Expected inherited days_of_results 15, but got %d", got)
+	}
+	if got := cfg.TestGroups[1].DaysOfResults; got != 30 {
+		t.Errorf("Expected explicit days_of_results 30, but got %d", got)
+	}
+	if got := cfg.Dashboards[0].DashboardTab[1].NumColumnsRecent; got != 5 {
+		t.Errorf("Expected explicit num_columns_recent 5, but got %d", got)
+	}
+
+	out, err := MarshalYAML(*cfg)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	again, err := Unmarshal(out, nil)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal marshaled config: %v", err)
+	}
+	if !reflect.DeepEqual(cfg, again) {
+		t.Errorf("Round trip changed config:\nbefore: %v\nafter:  %v", cfg, again)
+	}
+}
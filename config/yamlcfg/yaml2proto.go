@@ -130,6 +130,9 @@ func (e MissingFieldError) Error() string {
 }
 
 // ReconcileTestGroup sets unfilled currentTestGroup fields to the corresponding defaultTestGroup value, if present
+//
+// Deprecated: use config.ApplyDefaults, which inherits every field rather than a fixed list
+// and leaves is_external and use_kubernetes_client as configured.
 func ReconcileTestGroup(currentTestGroup *config.TestGroup, defaultTestGroup *config.TestGroup) {
 	if currentTestGroup.DaysOfResults == 0 {
 		currentTestGroup.DaysOfResults = defaultTestGroup.DaysOfResults
@@ -174,6 +177,8 @@ func ReconcileTestGroup(currentTestGroup *config.TestGroup, defaultTestGroup *co
 }
 
 // ReconcileDashboardTab sets unfilled currentTab fields to the corresponding defaultTab value, if present
+//
+// Deprecated: use config.ApplyDefaults, which inherits every field rather than a fixed list.
 func ReconcileDashboardTab(currentTab *config.DashboardTab, defaultTab *config.DashboardTab) {
 	if currentTab.BugComponent == 0 {
 		currentTab.BugComponent = defaultTab.BugComponent
@@ -231,10 +236,10 @@ func LoadDefaults(yamlData []byte) (DefaultConfiguration, error) {
 	}
 
 	if result.DefaultTestGroup == nil {
-		return result, MissingFieldError{"DefaultTestGroup"}
+		return result, MissingFieldError{Field: "DefaultTestGroup"}
 	}
 	if result.DefaultDashboardTab == nil {
-		return result, MissingFieldError{"DefaultDashboardTab"}
+		return result, MissingFieldError{Field: "DefaultDashboardTab"}
 	}
 	return result, nil
 }
@@ -0,0 +1,98 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package filter selects the rows of a grid displayed by a dashboard tab's base_options.
+package filter
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+const (
+	// IncludeKey is the base_options key of a regex that rows must match.
+	IncludeKey = "include-filter-by-regex"
+	// ExcludeKey is the base_options key of a regex that rows must not match.
+	ExcludeKey = "exclude-filter-by-regex"
+	// TODO(fejta): others, which are not used by testgrid.k8s.io
+)
+
+// Options holds the row filters of a dashboard tab.
+type Options struct {
+	// Include lists regexes which every displayed row name matches.
+	Include []*regexp.Regexp
+	// Exclude lists regexes which no displayed row name matches.
+	Exclude []*regexp.Regexp
+}
+
+// ParseOptions parses the row filters of the base_options query string, such as
+// include-filter-by-regex=foo&exclude-filter-by-regex=bar.
+//
+// Unrecognized keys are ignored.
+func ParseOptions(baseOptions string) (*Options, error) {
+	vals, err := url.ParseQuery(baseOptions)
+	if err != nil {
+		return nil, fmt.Errorf("parse %q: %v", baseOptions, err)
+	}
+	var opts Options
+	for _, include := range vals[IncludeKey] {
+		re, err := regexp.Compile(include)
+		if err != nil {
+			return nil, fmt.Errorf("bad %s=%s: %v", IncludeKey, include, err)
+		}
+		opts.Include = append(opts.Include, re)
+	}
+	for _, exclude := range vals[ExcludeKey] {
+		re, err := regexp.Compile(exclude)
+		if err != nil {
+			return nil, fmt.Errorf("bad %s=%s: %v", ExcludeKey, exclude, err)
+		}
+		opts.Exclude = append(opts.Exclude, re)
+	}
+	return &opts, nil
+}
+
+// FilterRows returns the rows of the grid which match every include regex, and then none of the exclude regexes.
+//
+// A nil opts selects every row.
+func FilterRows(grid *statepb.Grid, opts *Options) []*statepb.Row {
+	rows := grid.GetRows()
+	if opts == nil {
+		return rows
+	}
+	for _, re := range opts.Include {
+		rows = matching(rows, re, true)
+	}
+	for _, re := range opts.Exclude {
+		rows = matching(rows, re, false)
+	}
+	return rows
+}
+
+// matching returns the subset of rows whose name matches (or does not match) the regex.
+func matching(in []*statepb.Row, re *regexp.Regexp, match bool) []*statepb.Row {
+	var rows []*statepb.Row
+	for _, r := range in {
+		if re.MatchString(r.Name) != match {
+			continue
+		}
+		rows = append(rows, r)
+	}
+	return rows
+}
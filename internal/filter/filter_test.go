@@ -0,0 +1,186 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"net/url"
+	"reflect"
+	"regexp"
+	"testing"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+func TestParseOptions(t *testing.T) {
+	cases := []struct {
+		name        string
+		baseOptions string
+		include     []string
+		exclude     []string
+		err         bool
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name:        "bad query errors",
+			baseOptions: "%z",
+			err:         true,
+		},
+		{
+			name: "parse includes and excludes",
+			baseOptions: url.Values{
+				IncludeKey: []string{"foo", "spam"},
+				ExcludeKey: []string{"bar"},
+				"width":    []string{"10"},
+			}.Encode(),
+			include: []string{"foo", "spam"},
+			exclude: []string{"bar"},
+		},
+		{
+			name:        "bad include regex errors",
+			baseOptions: url.Values{IncludeKey: []string{"^[a-z"}}.Encode(),
+			err:         true,
+		},
+		{
+			name:        "bad exclude regex errors",
+			baseOptions: url.Values{ExcludeKey: []string{"this.("}}.Encode(),
+			err:         true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts, err := ParseOptions(tc.baseOptions)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("failed to return expected error")
+			default:
+				if actual := exprs(opts.Include); !reflect.DeepEqual(actual, tc.include) {
+					t.Errorf("include %s != expected %s", actual, tc.include)
+				}
+				if actual := exprs(opts.Exclude); !reflect.DeepEqual(actual, tc.exclude) {
+					t.Errorf("exclude %s != expected %s", actual, tc.exclude)
+				}
+			}
+		})
+	}
+}
+
+func TestFilterRows(t *testing.T) {
+	cases := []struct {
+		name     string
+		names    []string
+		options  url.Values
+		expected []string
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name:     "no filters returns all rows",
+			names:    []string{"hello", "world"},
+			expected: []string{"hello", "world"},
+		},
+		{
+			name:    "return nothing rows when nothing matches",
+			names:   []string{"hello", "world"},
+			options: url.Values{IncludeKey: []string{"dog"}},
+		},
+		{
+			name:     "include only matching rows",
+			options:  url.Values{IncludeKey: []string{"fun"}},
+			names:    []string{"apply", "function", "to", "funny", "bone"},
+			expected: []string{"function", "funny"},
+		},
+		{
+			name:     "must match all includes",
+			options:  url.Values{IncludeKey: []string{"foo", "spam"}},
+			names:    []string{"spam-is-food", "spam-musubi", "unagi-food", "email-spam-is-not-food"},
+			expected: []string{"spam-is-food", "email-spam-is-not-food"},
+		},
+		{
+			name:     "return all rows when no exclusion matches",
+			names:    []string{"hello", "world"},
+			options:  url.Values{ExcludeKey: []string{"dog"}},
+			expected: []string{"hello", "world"},
+		},
+		{
+			name:     "drop matching rows",
+			options:  url.Values{ExcludeKey: []string{"fun"}},
+			names:    []string{"apply", "function", "to", "funny", "bone"},
+			expected: []string{"apply", "to", "bone"},
+		},
+		{
+			name:     "exclude any exclusions",
+			options:  url.Values{ExcludeKey: []string{"not", "nope"}},
+			names:    []string{"yes please", "leslie knope", "not eating", "fluffy waffles"},
+			expected: []string{"yes please", "fluffy waffles"},
+		},
+		{
+			name: "exclude from the included rows",
+			options: url.Values{
+				IncludeKey: []string{"foo"},
+				ExcludeKey: []string{"bar"},
+			},
+			names:    []string{"include-food", "exclude-included-bart", "ignore-bar"},
+			expected: []string{"include-food"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var grid statepb.Grid
+			for _, n := range tc.names {
+				grid.Rows = append(grid.Rows, &statepb.Row{Name: n})
+			}
+			opts, err := ParseOptions(tc.options.Encode())
+			if err != nil {
+				t.Fatalf("ParseOptions() got unexpected error: %v", err)
+			}
+			var actual []string
+			for _, r := range FilterRows(&grid, opts) {
+				actual = append(actual, r.Name)
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("actual %s != expected %s", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestFilterRowsNilOptions(t *testing.T) {
+	grid := &statepb.Grid{Rows: []*statepb.Row{{Name: "hello"}}}
+	if actual := FilterRows(grid, nil); !reflect.DeepEqual(actual, grid.Rows) {
+		t.Errorf("actual %s != expected %s", actual, grid.Rows)
+	}
+	if actual := FilterRows(nil, nil); actual != nil {
+		t.Errorf("actual %s != expected nil", actual)
+	}
+}
+
+func exprs(res []*regexp.Regexp) []string {
+	var out []string
+	for _, re := range res {
+		out = append(out, re.String())
+	}
+	return out
+}
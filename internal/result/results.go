@@ -30,12 +30,14 @@ const (
 )
 
 // Coalesce reduces the result to PASS, NO_RESULT, FAIL or FLAKY.
+//
+// TOOL_FAIL counts as a FAIL.
 func Coalesce(result state.Row_Result, ignoreRunning bool) state.Row_Result {
 	// TODO(fejta): other result types, not used by k8s testgrid
 	if result == state.Row_NO_RESULT || result == state.Row_RUNNING && ignoreRunning {
 		return state.Row_NO_RESULT
 	}
-	if result == state.Row_FAIL || result == state.Row_RUNNING {
+	if result == state.Row_FAIL || result == state.Row_TOOL_FAIL || result == state.Row_RUNNING {
 		return state.Row_FAIL
 	}
 	if result == state.Row_FLAKY {
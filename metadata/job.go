@@ -95,6 +95,22 @@ func (m Metadata) String(name string) (*string, bool) {
 	}
 }
 
+// Int returns the name key if its value is an integer, and true if the key is present.
+//
+// JSON numbers decode as a float64 or json.Number, so accept those as well as numeric strings,
+// provided they have no fractional part.
+func (m Metadata) Int(name string) (*int64, bool) {
+	v, ok := m[name]
+	if !ok {
+		return nil, false
+	}
+	i, err := toInt(v)
+	if err != nil {
+		return nil, true
+	}
+	return &i, true
+}
+
 // Meta returns the name key if its value is a child object, and true if they key is present.
 func (m Metadata) Meta(name string) (*Metadata, bool) {
 	if v, ok := m[name]; !ok {
@@ -24,6 +24,8 @@ import (
 
 func TestMeta(t *testing.T) {
 	world := "world"
+	seven := int64(7)
+	big := int64(1583000000)
 	const key = "target-key"
 	cases := []struct {
 		name    string
@@ -92,6 +94,71 @@ func TestMeta(t *testing.T) {
 			},
 			val: (*string)(nil),
 		},
+		{
+			name: "can match int",
+			in: Metadata{
+				key: 7,
+			},
+			call: func(actual Metadata) (interface{}, bool) {
+				return actual.Int(key)
+			},
+			val:     &seven,
+			present: true,
+		},
+		{
+			name: "can match integral float",
+			in: Metadata{
+				key: 1583000000.0,
+			},
+			call: func(actual Metadata) (interface{}, bool) {
+				return actual.Int(key)
+			},
+			val:     &big,
+			present: true,
+		},
+		{
+			name: "can match numeric string",
+			in: Metadata{
+				key: "7",
+			},
+			call: func(actual Metadata) (interface{}, bool) {
+				return actual.Int(key)
+			},
+			val:     &seven,
+			present: true,
+		},
+		{
+			name: "detect value is not an integer",
+			in: Metadata{
+				key: 1.5,
+			},
+			call: func(actual Metadata) (interface{}, bool) {
+				return actual.Int(key)
+			},
+			val:     (*int64)(nil),
+			present: true,
+		},
+		{
+			name: "detect value is not a number",
+			in: Metadata{
+				key: Metadata{"super": "fancy"},
+			},
+			call: func(actual Metadata) (interface{}, bool) {
+				return actual.Int(key)
+			},
+			val:     (*int64)(nil),
+			present: true,
+		},
+		{
+			name: "detect key absence for int",
+			in: Metadata{
+				"random-key": 7,
+			},
+			call: func(actual Metadata) (interface{}, bool) {
+				return actual.Int(key)
+			},
+			val: (*int64)(nil),
+		},
 		{
 			name: "detect key absence for metadata",
 			in: Metadata{
@@ -21,8 +21,12 @@ package junit
 import (
 	"bytes"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
 // Suites holds a <testsuites/> list of Suite results
@@ -33,16 +37,18 @@ type Suites struct {
 
 // Suite holds <testsuite/> results
 type Suite struct {
-	XMLName  xml.Name `xml:"testsuite"`
+	XMLName xml.Name `xml:"testsuite"`
+	// Suites holds nested <testsuite/> elements when unmarshalled directly, which Parse flattens instead.
 	Suites   []Suite  `xml:"testsuite"`
 	Name     string   `xml:"name,attr"`
 	Time     float64  `xml:"time,attr"` // Seconds
 	Failures int      `xml:"failures,attr"`
 	Tests    int      `xml:"tests,attr"`
 	Results  []Result `xml:"testcase"`
-	/*
-	* <properties><property name="go.version" value="go1.8.3"/></properties>
-	 */
+	// Properties of the suite, such as <properties><property name="go.version" value="go1.8.3"/></properties>.
+	//
+	// Nested suites do not inherit the properties of the suites enclosing them.
+	Properties *Properties `xml:"properties,omitempty"`
 }
 
 // Property defines the xml element that stores additional metrics about each benchmark.
@@ -62,10 +68,16 @@ type Result struct {
 	Time       float64     `xml:"time,attr"`
 	ClassName  string      `xml:"classname,attr"`
 	Failure    *string     `xml:"failure,omitempty"`
+	Errored    *string     `xml:"error,omitempty"`
 	Output     *string     `xml:"system-out,omitempty"`
 	Error      *string     `xml:"system-err,omitempty"`
 	Skipped    *string     `xml:"skipped,omitempty"`
 	Properties *Properties `xml:"properties,omitempty"`
+
+	// FailureMessage is the message attribute of the <failure/>, which often summarizes its body.
+	FailureMessage string `xml:"-"`
+	// ErroredMessage is the message attribute of the <error/>.
+	ErroredMessage string `xml:"-"`
 }
 
 // SetProperty adds the specified property to the Result or replaces the
@@ -92,12 +104,19 @@ func (r *Result) SetProperty(name, value string) {
 
 // Message extracts the message for the junit test case.
 //
-// Will use the first non-empty <failure/>, <skipped/>, <system-err/>, <system-out/> value.
+// Will use the first non-empty <failure/>, <error/>, <skipped/>, <system-err/>, <system-out/> value.
+//
+// A failure or error message is the message attribute followed by the body (unless the body repeats
+// the message), with runs of whitespace collapsed into single spaces, and cut to the first
+// max bytes without splitting a UTF-8 character.
+// Other messages longer than max bytes keep their beginning and end.
 func (jr Result) Message(max int) string {
 	var msg string
 	switch {
-	case jr.Failure != nil && *jr.Failure != "":
-		msg = *jr.Failure
+	case jr.Failure != nil && (*jr.Failure != "" || jr.FailureMessage != ""):
+		return failureMessage(jr.FailureMessage, *jr.Failure, max)
+	case jr.Errored != nil && (*jr.Errored != "" || jr.ErroredMessage != ""):
+		return failureMessage(jr.ErroredMessage, *jr.Errored, max)
 	case jr.Skipped != nil && *jr.Skipped != "":
 		msg = *jr.Skipped
 	case jr.Error != nil && *jr.Error != "":
@@ -113,9 +132,207 @@ func (jr Result) Message(max int) string {
 	return msg[:h] + "..." + msg[l-h-1:]
 }
 
-func unmarshalXML(buf []byte, i interface{}) error {
-	reader := bytes.NewReader(buf)
-	dec := xml.NewDecoder(reader)
+// failureMessage joins the message attribute and body of a <failure/> or <error/>, see Message.
+func failureMessage(attr, body string, max int) string {
+	msg, body := collapse(attr), collapse(body)
+	switch {
+	case msg == "" || strings.HasPrefix(body, msg):
+		msg = body
+	case body != "":
+		msg += ": " + body
+	}
+	return truncate(msg, max)
+}
+
+// collapse replaces each run of whitespace with a single space, and trims the ends.
+func collapse(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// truncate returns the first max bytes of s (or all of s when max is zero), without splitting a UTF-8 character.
+func truncate(s string, max int) string {
+	if max <= 0 || len(s) <= max {
+		return s
+	}
+	cut := max
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut]
+}
+
+// UnmarshalXML decodes a <testcase/>, using the message attribute of a <skipped/> without a body.
+func (jr *Result) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	r, err := decodeResult(d, start, 0)
+	if err != nil {
+		return err
+	}
+	*jr = r
+	return nil
+}
+
+// decodeResult decodes the <testcase/> that starts with start, keeping at most max bytes of each body, see ParseStreamLimit.
+func decodeResult(d *xml.Decoder, start xml.StartElement, max int) (Result, error) {
+	var jr Result
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "name":
+			jr.Name = attr.Value
+		case "classname":
+			jr.ClassName = attr.Value
+		case "time":
+			v := strings.TrimSpace(attr.Value)
+			if v == "" {
+				continue
+			}
+			t, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return jr, fmt.Errorf("bad time: %v", err)
+			}
+			jr.Time = t
+		}
+	}
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return jr, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			name := t.Name.Local
+			switch name {
+			case "failure", "error", "skipped", "system-out", "system-err":
+				msg, body, err := decodeBody(d, t, max)
+				if err != nil {
+					return jr, fmt.Errorf("%s: %v", name, err)
+				}
+				switch name {
+				case "failure":
+					jr.Failure, jr.FailureMessage = &body, msg
+				case "error":
+					jr.Errored, jr.ErroredMessage = &body, msg
+				case "skipped":
+					if body == "" {
+						body = msg
+					}
+					jr.Skipped = &body
+				case "system-out":
+					jr.Output = &body
+				case "system-err":
+					jr.Error = &body
+				}
+			case "properties":
+				if jr.Properties == nil {
+					jr.Properties = &Properties{}
+				}
+				if err := d.DecodeElement(jr.Properties, &t); err != nil {
+					return jr, fmt.Errorf("properties: %v", err)
+				}
+			default:
+				if err := d.Skip(); err != nil {
+					return jr, fmt.Errorf("%s: %v", name, err)
+				}
+			}
+		case xml.EndElement:
+			return jr, nil
+		}
+	}
+}
+
+// decodeBody returns the message attribute and the text of the element that starts with start.
+//
+// Text inside nested elements is skipped.
+// When max is positive, whitespace is collapsed and text after the first max bytes
+// is discarded token by token, so results never retain a huge body.
+func decodeBody(d *xml.Decoder, start xml.StartElement, max int) (string, string, error) {
+	var msg string
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "message" {
+			msg = attr.Value
+		}
+	}
+	b := bodyWriter{max: max}
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return "", "", err
+		}
+		switch t := tok.(type) {
+		case xml.CharData:
+			b.write(t)
+		case xml.StartElement:
+			if err := d.Skip(); err != nil {
+				return "", "", err
+			}
+		case xml.EndElement:
+			if max > 0 {
+				msg = truncate(collapse(msg), max)
+			}
+			return msg, b.String(), nil
+		}
+	}
+}
+
+// bodyWriter accumulates the text of an element, keeping at most max bytes of collapsed whitespace when max is positive.
+type bodyWriter struct {
+	max   int
+	buf   []byte
+	space bool // whitespace follows the last byte in buf
+}
+
+func (b *bodyWriter) write(text []byte) {
+	if b.max <= 0 {
+		b.buf = append(b.buf, text...)
+		return
+	}
+	for _, c := range text {
+		if len(b.buf) > b.max {
+			return // Enough to truncate at a character boundary.
+		}
+		switch c {
+		case ' ', '\t', '\n', '\r', '\v', '\f':
+			b.space = len(b.buf) > 0
+			continue
+		}
+		if b.space {
+			b.buf = append(b.buf, ' ')
+			b.space = false
+		}
+		b.buf = append(b.buf, c)
+	}
+}
+
+func (b *bodyWriter) String() string {
+	return truncate(string(b.buf), b.max)
+}
+
+// PropertyMap returns the <properties/> of the test case by name, or nil when it has none.
+//
+// The last value wins when a name repeats.
+func (jr Result) PropertyMap() map[string]string {
+	return jr.Properties.propertyMap()
+}
+
+// PropertyMap returns the <properties/> of the suite by name, or nil when it has none.
+//
+// The last value wins when a name repeats.
+func (s Suite) PropertyMap() map[string]string {
+	return s.Properties.propertyMap()
+}
+
+func (p *Properties) propertyMap() map[string]string {
+	if p == nil || len(p.PropertyList) == 0 {
+		return nil
+	}
+	props := make(map[string]string, len(p.PropertyList))
+	for _, prop := range p.PropertyList {
+		props[prop.Name] = prop.Value
+	}
+	return props
+}
+
+func newDecoder(r io.Reader) *xml.Decoder {
+	dec := xml.NewDecoder(r)
 	dec.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
 		switch charset {
 		case "UTF-8", "utf8", "":
@@ -125,21 +342,132 @@ func unmarshalXML(buf []byte, i interface{}) error {
 			return nil, fmt.Errorf("unknown charset: %s", charset)
 		}
 	}
-	return dec.Decode(i)
+	return dec
 }
 
+// Parse parses a <testsuites/> or <testsuite/> document.
+//
+// See ParseStream for how nested suites are returned.
 func Parse(buf []byte) (Suites, error) {
+	return ParseStream(bytes.NewReader(buf))
+}
+
+// ParseStream parses a <testsuites/> or <testsuite/> document from r, decoding one test case at a time.
+//
+// Nested suites are flattened into Suites.Suites in document order, each named by the dotted
+// path of its own and enclosing suite names, such as "outer.inner". Suites with an empty name
+// do not add to the path. The Suites field of each returned Suite is always empty.
+func ParseStream(r io.Reader) (Suites, error) {
+	return ParseStreamLimit(r, 0)
+}
+
+// ParseStreamLimit parses a document like ParseStream, but keeps at most max bytes of each
+// <failure/>, <skipped/>, <system-out/> and <system-err/> body, and failure message attribute.
+//
+// Whitespace in those values is collapsed into single spaces, and the text after the first max
+// bytes (without splitting a UTF-8 character) is discarded while decoding. A max of zero keeps everything.
+func ParseStreamLimit(r io.Reader, max int) (Suites, error) {
 	var suites Suites
-	// Try to parse it as a <testsuites/> object
-	err := unmarshalXML(buf, &suites)
-	if err != nil {
-		// Maybe it is a <testsuite/> object instead
-		suites.Suites = append([]Suite(nil), Suite{})
-		ie := unmarshalXML(buf, &suites.Suites[0])
-		if ie != nil {
-			// Nope, it just doesn't parse
-			return suites, fmt.Errorf("not valid testsuites: %v nor testsuite: %v", err, ie)
+	dec := newDecoder(r)
+	var open []int // Index of each enclosing <testsuite/>, innermost last.
+	var started bool
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF && started {
+			break
+		}
+		if err != nil {
+			return suites, fmt.Errorf("not valid testsuites nor testsuite: %v", err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if !started {
+				if t.Name.Local != "testsuites" && t.Name.Local != "testsuite" {
+					return suites, fmt.Errorf("not valid testsuites nor testsuite: unexpected <%s>", t.Name.Local)
+				}
+				started = true
+				if t.Name.Local == "testsuites" {
+					suites.XMLName = t.Name
+				}
+			}
+			switch t.Name.Local {
+			case "testsuites":
+			case "testsuite":
+				suite, err := suiteAttrs(t)
+				if err != nil {
+					return suites, fmt.Errorf("testsuite %q: %v", suite.Name, err)
+				}
+				if n := len(open); n > 0 {
+					suite.Name = joinSuite(suites.Suites[open[n-1]].Name, suite.Name)
+				}
+				suites.Suites = append(suites.Suites, suite)
+				open = append(open, len(suites.Suites)-1)
+			case "testcase":
+				if len(open) == 0 {
+					return suites, errors.New("testcase outside of a testsuite")
+				}
+				result, err := decodeResult(dec, t, max)
+				if err != nil {
+					return suites, fmt.Errorf("testcase: %v", err)
+				}
+				cur := &suites.Suites[open[len(open)-1]]
+				cur.Results = append(cur.Results, result)
+			case "properties":
+				if len(open) == 0 {
+					if err := dec.Skip(); err != nil {
+						return suites, fmt.Errorf("properties: %v", err)
+					}
+					continue
+				}
+				cur := &suites.Suites[open[len(open)-1]]
+				if cur.Properties == nil {
+					cur.Properties = &Properties{}
+				}
+				if err := dec.DecodeElement(cur.Properties, &t); err != nil {
+					return suites, fmt.Errorf("testsuite %q properties: %v", cur.Name, err)
+				}
+			default:
+				if err := dec.Skip(); err != nil {
+					return suites, fmt.Errorf("%s: %v", t.Name.Local, err)
+				}
+			}
+		case xml.EndElement:
+			if t.Name.Local == "testsuite" {
+				open = open[:len(open)-1]
+			}
 		}
 	}
 	return suites, nil
 }
+
+// suiteAttrs returns a Suite with the attributes of a <testsuite/> element.
+func suiteAttrs(start xml.StartElement) (Suite, error) {
+	suite := Suite{XMLName: start.Name}
+	for _, attr := range start.Attr {
+		var err error
+		switch attr.Name.Local {
+		case "name":
+			suite.Name = attr.Value
+		case "time":
+			suite.Time, err = strconv.ParseFloat(attr.Value, 64)
+		case "failures":
+			suite.Failures, err = strconv.Atoi(attr.Value)
+		case "tests":
+			suite.Tests, err = strconv.Atoi(attr.Value)
+		}
+		if err != nil {
+			return suite, fmt.Errorf("bad %s: %v", attr.Name.Local, err)
+		}
+	}
+	return suite, nil
+}
+
+func joinSuite(parent, name string) string {
+	switch {
+	case parent == "":
+		return name
+	case name == "":
+		return parent
+	}
+	return parent + "." + name
+}
@@ -0,0 +1,457 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package junit
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func pstr(s string) *string {
+	return &s
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name     string
+		buf      string
+		expected Suites
+		err      bool
+	}{
+		{
+			name: "not xml",
+			buf:  "hello",
+			err:  true,
+		},
+		{
+			name: "empty",
+			err:  true,
+		},
+		{
+			name: "wrong root",
+			buf:  `<testcase name="bad"/>`,
+			err:  true,
+		},
+		{
+			name: "bad suite attribute",
+			buf:  `<testsuite name="hi" tests="many"/>`,
+			err:  true,
+		},
+		{
+			name: "truncated",
+			buf:  `<testsuites><testsuite name="hi"><testcase name="good"/>`,
+			err:  true,
+		},
+		{
+			name: "testsuite",
+			buf: `<?xml version="1.0" encoding="utf8"?>
+			<testsuite name="hello" time="1.5" failures="1" tests="2">
+			  <testcase name="good" classname="world" time="0.5"/>
+			  <testcase name="bad"><failure>boom</failure></testcase>
+			</testsuite>`,
+			expected: Suites{
+				Suites: []Suite{
+					{
+						XMLName:  xml.Name{Local: "testsuite"},
+						Name:     "hello",
+						Time:     1.5,
+						Failures: 1,
+						Tests:    2,
+						Results: []Result{
+							{Name: "good", ClassName: "world", Time: 0.5},
+							{Name: "bad", Failure: pstr("boom")},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "errors",
+			buf: `
+			<testsuite name="hello">
+			  <testcase name="infra"><error message="no quota">could not start vm</error></testcase>
+			  <testcase name="both"><failure>boom</failure><error/></testcase>
+			</testsuite>`,
+			expected: Suites{
+				Suites: []Suite{
+					{
+						XMLName: xml.Name{Local: "testsuite"},
+						Name:    "hello",
+						Results: []Result{
+							{Name: "infra", Errored: pstr("could not start vm"), ErroredMessage: "no quota"},
+							{Name: "both", Failure: pstr("boom"), Errored: pstr("")},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "nested suites",
+			buf: `
+			<testsuites>
+			  <testsuite name="outer">
+			    <testcase name="before"/>
+			    <testsuite name="inner">
+			      <testsuite name="deep"><testcase name="deepest"/></testsuite>
+			      <testcase name="middle"/>
+			    </testsuite>
+			    <testsuite><testcase name="anonymous"/></testsuite>
+			    <testcase name="after"/>
+			  </testsuite>
+			  <testsuites>
+			    <testsuite name="other"><testcase name="sibling"/></testsuite>
+			  </testsuites>
+			</testsuites>`,
+			expected: Suites{
+				XMLName: xml.Name{Local: "testsuites"},
+				Suites: []Suite{
+					{
+						XMLName: xml.Name{Local: "testsuite"},
+						Name:    "outer",
+						Results: []Result{{Name: "before"}, {Name: "after"}},
+					},
+					{
+						XMLName: xml.Name{Local: "testsuite"},
+						Name:    "outer.inner",
+						Results: []Result{{Name: "middle"}},
+					},
+					{
+						XMLName: xml.Name{Local: "testsuite"},
+						Name:    "outer.inner.deep",
+						Results: []Result{{Name: "deepest"}},
+					},
+					{
+						XMLName: xml.Name{Local: "testsuite"},
+						Name:    "outer",
+						Results: []Result{{Name: "anonymous"}},
+					},
+					{
+						XMLName: xml.Name{Local: "testsuite"},
+						Name:    "other",
+						Results: []Result{{Name: "sibling"}},
+					},
+				},
+			},
+		},
+		{
+			name: "edge cases",
+			buf: `
+			<testsuite name="edges">
+			  <testcase name="empty failure"><failure/></testcase>
+			  <testcase name="skipped without reason"><skipped/></testcase>
+			  <testcase name="skipped with message"><skipped message="not today"/></testcase>
+			  <testcase name="skipped with body"><skipped message="ignored">because</skipped></testcase>
+			  <testcase name="cdata"><system-out><![CDATA[<hello> & world]]></system-out></testcase>
+			  <testcase name="properties">
+			    <properties>
+			      <property name="go.version" value="go1.8.3"/>
+			      <property name="flaky" value="true"/>
+			    </properties>
+			  </testcase>
+			</testsuite>`,
+			expected: Suites{
+				Suites: []Suite{
+					{
+						XMLName: xml.Name{Local: "testsuite"},
+						Name:    "edges",
+						Results: []Result{
+							{Name: "empty failure", Failure: pstr("")},
+							{Name: "skipped without reason", Skipped: pstr("")},
+							{Name: "skipped with message", Skipped: pstr("not today")},
+							{Name: "skipped with body", Skipped: pstr("because")},
+							{Name: "cdata", Output: pstr("<hello> & world")},
+							{
+								Name: "properties",
+								Properties: &Properties{
+									PropertyList: []Property{
+										{Name: "go.version", Value: "go1.8.3"},
+										{Name: "flaky", Value: "true"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "suite properties",
+			buf: `
+			  <testsuites>
+			    <testsuite name="outer">
+			      <properties><property name="Platform" value="linux"/></properties>
+			      <testsuite name="inner">
+			        <testcase name="case"/>
+			      </testsuite>
+			    </testsuite>
+			  </testsuites>`,
+			expected: Suites{
+				XMLName: xml.Name{Local: "testsuites"},
+				Suites: []Suite{
+					{
+						XMLName: xml.Name{Local: "testsuite"},
+						Name:    "outer",
+						Properties: &Properties{
+							PropertyList: []Property{{Name: "Platform", Value: "linux"}},
+						},
+					},
+					{
+						XMLName: xml.Name{Local: "testsuite"},
+						Name:    "outer.inner",
+						Results: []Result{{Name: "case"}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := Parse([]byte(tc.buf))
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Errorf("failed to receive expected error, got %#v", actual)
+			case !reflect.DeepEqual(actual, tc.expected):
+				t.Errorf("actual %#v != expected %#v", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestPropertyMap(t *testing.T) {
+	cases := []struct {
+		name     string
+		result   Result
+		expected map[string]string
+	}{
+		{
+			name: "no properties",
+		},
+		{
+			name:   "empty properties",
+			result: Result{Properties: &Properties{}},
+		},
+		{
+			name: "last value wins",
+			result: Result{
+				Properties: &Properties{
+					PropertyList: []Property{
+						{Name: "hello", Value: "world"},
+						{Name: "flaky", Value: "false"},
+						{Name: "flaky", Value: "true"},
+					},
+				},
+			},
+			expected: map[string]string{
+				"hello": "world",
+				"flaky": "true",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := tc.result.PropertyMap(); !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("actual %v != expected %v", actual, tc.expected)
+			}
+			suite := Suite{Properties: tc.result.Properties}
+			if actual := suite.PropertyMap(); !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("suite actual %v != expected %v", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestParseStream(t *testing.T) {
+	// Generate a large document without holding it in memory.
+	const n = 100000
+	readers := []io.Reader{strings.NewReader(`<testsuites><testsuite name="big">`)}
+	for i := 0; i < n; i++ {
+		readers = append(readers, strings.NewReader(fmt.Sprintf(`<testcase name="case-%d"><system-out>output</system-out></testcase>`, i)))
+	}
+	readers = append(readers, strings.NewReader(`</testsuite></testsuites>`))
+
+	suites, err := ParseStream(io.MultiReader(readers...))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(suites.Suites) != 1 || len(suites.Suites[0].Results) != n {
+		t.Fatalf("expected %d results in 1 suite, got %d suites", n, len(suites.Suites))
+	}
+	if last := suites.Suites[0].Results[n-1].Name; last != fmt.Sprintf("case-%d", n-1) {
+		t.Errorf("expected the last case to be case-%d, got %s", n-1, last)
+	}
+}
+
+func TestParseStreamLimit(t *testing.T) {
+	huge := strings.Repeat("stack frame\n\t", 400000) // About 5MB.
+	cases := []struct {
+		name     string
+		buf      string
+		max      int
+		expected Result
+	}{
+		{
+			name: "failure message and body",
+			buf: `<testcase name="fail"><failure message="expected 1,
+				got 2">  at foo.go:12
+				at bar.go:34  </failure></testcase>`,
+			max: 100,
+			expected: Result{
+				Name:           "fail",
+				Failure:        pstr("at foo.go:12 at bar.go:34"),
+				FailureMessage: "expected 1, got 2",
+			},
+		},
+		{
+			name: "truncate huge cdata",
+			buf:  `<testcase name="big"><failure><![CDATA[` + huge + `]]></failure><system-out><![CDATA[` + huge + `]]></system-out></testcase>`,
+			max:  20,
+			expected: Result{
+				Name:    "big",
+				Failure: pstr("stack frame stack fr"),
+				Output:  pstr("stack frame stack fr"),
+			},
+		},
+		{
+			name: "do not split characters",
+			buf:  `<testcase name="utf8"><failure>ñññ</failure></testcase>`,
+			max:  5,
+			expected: Result{
+				Name:    "utf8",
+				Failure: pstr("ññ"),
+			},
+		},
+		{
+			name: "skip nested elements",
+			buf:  `<testcase name="nested"><failure>before<pre>inside</pre> after</failure></testcase>`,
+			max:  100,
+			expected: Result{
+				Name:    "nested",
+				Failure: pstr("before after"),
+			},
+		},
+		{
+			name: "unlimited",
+			buf:  `<testcase name="all"><failure message="msg">  keep
+  everything  </failure></testcase>`,
+			expected: Result{
+				Name:           "all",
+				Failure:        pstr("  keep\n  everything  "),
+				FailureMessage: "msg",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			suites, err := ParseStreamLimit(strings.NewReader("<testsuite>"+tc.buf+"</testsuite>"), tc.max)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(suites.Suites) != 1 || len(suites.Suites[0].Results) != 1 {
+				t.Fatalf("expected 1 result in 1 suite, got %v", suites)
+			}
+			if actual := suites.Suites[0].Results[0]; !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("%#v != expected %#v", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestMessage(t *testing.T) {
+	cases := []struct {
+		name     string
+		result   Result
+		max      int
+		expected string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:     "failure message and body",
+			result:   Result{Failure: pstr("at foo.go:12\n  at bar.go:34"), FailureMessage: "expected 1, got 2"},
+			max:      140,
+			expected: "expected 1, got 2: at foo.go:12 at bar.go:34",
+		},
+		{
+			name:     "failure body repeats message",
+			result:   Result{Failure: pstr("expected 1, got 2\nat foo.go:12"), FailureMessage: "expected 1, got 2"},
+			max:      140,
+			expected: "expected 1, got 2 at foo.go:12",
+		},
+		{
+			name:     "failure message without body",
+			result:   Result{Failure: pstr(""), FailureMessage: "timed out"},
+			max:      140,
+			expected: "timed out",
+		},
+		{
+			name:     "failure keeps the beginning",
+			result:   Result{Failure: pstr("0123456789"), FailureMessage: "boom"},
+			max:      8,
+			expected: "boom: 01",
+		},
+		{
+			name:     "failure does not split characters",
+			result:   Result{Failure: pstr("日本語")},
+			max:      7,
+			expected: "日本",
+		},
+		{
+			name:     "error message and body",
+			result:   Result{Errored: pstr("could not start vm"), ErroredMessage: "no quota"},
+			max:      140,
+			expected: "no quota: could not start vm",
+		},
+		{
+			name:     "failure before error",
+			result:   Result{Failure: pstr("boom"), Errored: pstr("crash")},
+			max:      140,
+			expected: "boom",
+		},
+		{
+			name:     "skipped keeps the beginning and end",
+			result:   Result{Skipped: pstr("0123456789")},
+			max:      4,
+			expected: "01...789",
+		},
+		{
+			name:     "output",
+			result:   Result{Failure: pstr(""), Output: pstr("hello")},
+			max:      140,
+			expected: "hello",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := tc.result.Message(tc.max); actual != tc.expected {
+				t.Errorf("Message(%d) got %q, expected %q", tc.max, actual, tc.expected)
+			}
+		})
+	}
+}
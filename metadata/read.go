@@ -0,0 +1,114 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+)
+
+// ReadStarted decodes started.json from r.
+//
+// The timestamp may be an integer, a float without a fractional part or a numeric string.
+func ReadStarted(r io.Reader) (*Started, error) {
+	type started Started // Without the Timestamp field conflict.
+	var aux struct {
+		started
+		Timestamp interface{} `json:"timestamp"`
+	}
+	if err := decode(r, &aux); err != nil {
+		return nil, err
+	}
+	out := Started(aux.started)
+	if aux.Timestamp != nil {
+		ts, err := toInt(aux.Timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("timestamp: %v", err)
+		}
+		out.Timestamp = ts
+	}
+	return &out, nil
+}
+
+// ReadFinished decodes finished.json from r.
+//
+// The timestamp may be an integer, a float without a fractional part or a numeric string.
+// Numbers in Metadata decode as a json.Number; use Metadata.Int to read them.
+//
+// A missing finished.json means the build is still running, which callers such as
+// gcs.Build.Finished report without calling ReadFinished.
+func ReadFinished(r io.Reader) (*Finished, error) {
+	type finished Finished
+	var aux struct {
+		finished
+		Timestamp interface{} `json:"timestamp,omitempty"`
+	}
+	if err := decode(r, &aux); err != nil {
+		return nil, err
+	}
+	out := Finished(aux.finished)
+	if aux.Timestamp != nil {
+		ts, err := toInt(aux.Timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("timestamp: %v", err)
+		}
+		out.Timestamp = &ts
+	}
+	return &out, nil
+}
+
+func decode(r io.Reader, i interface{}) error {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	if err := dec.Decode(i); err != nil {
+		return fmt.Errorf("decode: %v", err)
+	}
+	return nil
+}
+
+// toInt converts a decoded JSON value to an integer.
+func toInt(v interface{}) (int64, error) {
+	switch t := v.(type) {
+	case int:
+		return int64(t), nil
+	case int64:
+		return t, nil
+	case float64:
+		return floatToInt(t)
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i, nil
+		}
+		f, err := t.Float64()
+		if err != nil {
+			return 0, err
+		}
+		return floatToInt(f)
+	case string:
+		return toInt(json.Number(t))
+	}
+	return 0, fmt.Errorf("%T is not a number", v)
+}
+
+func floatToInt(f float64) (int64, error) {
+	if f != math.Trunc(f) || f > math.MaxInt64 || f < math.MinInt64 {
+		return 0, fmt.Errorf("%v is not an integer", f)
+	}
+	return int64(f), nil
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadStarted(t *testing.T) {
+	cases := []struct {
+		name     string
+		in       string
+		expected *Started
+		err      bool
+	}{
+		{
+			name: "not json",
+			in:   "hello",
+			err:  true,
+		},
+		{
+			name:     "empty",
+			in:       "{}",
+			expected: &Started{},
+		},
+		{
+			name: "basically works",
+			in:   `{"timestamp": 1583000000, "node": "fancy", "repo-commit": "deadbeef", "repos": {"org/repo": "main"}}`,
+			expected: &Started{
+				Timestamp:  1583000000,
+				Node:       "fancy",
+				RepoCommit: "deadbeef",
+				Repos:      map[string]string{"org/repo": "main"},
+			},
+		},
+		{
+			name:     "float timestamp",
+			in:       `{"timestamp": 1583000000.0}`,
+			expected: &Started{Timestamp: 1583000000},
+		},
+		{
+			name:     "string timestamp",
+			in:       `{"timestamp": "1583000000"}`,
+			expected: &Started{Timestamp: 1583000000},
+		},
+		{
+			name: "fractional timestamp",
+			in:   `{"timestamp": 1583000000.5}`,
+			err:  true,
+		},
+		{
+			name: "bad timestamp",
+			in:   `{"timestamp": "yesterday"}`,
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := ReadStarted(strings.NewReader(tc.in))
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Errorf("failed to receive expected error, got %#v", actual)
+			case !reflect.DeepEqual(actual, tc.expected):
+				t.Errorf("%#v != expected %#v", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestReadFinished(t *testing.T) {
+	yes := true
+	ts := int64(1583000000)
+	cases := []struct {
+		name     string
+		in       string
+		expected *Finished
+		err      bool
+	}{
+		{
+			name: "not json",
+			in:   "hello",
+			err:  true,
+		},
+		{
+			name:     "empty means incomplete",
+			in:       "{}",
+			expected: &Finished{},
+		},
+		{
+			name: "basically works",
+			in:   `{"timestamp": 1583000000, "passed": true, "metadata": {"job-version": "v1.2.3", "count": 3}}`,
+			expected: &Finished{
+				Timestamp: &ts,
+				Passed:    &yes,
+				Metadata: Metadata{
+					"job-version": "v1.2.3",
+					"count":       json.Number("3"),
+				},
+			},
+		},
+		{
+			name:     "float timestamp",
+			in:       `{"timestamp": 1.583e9}`,
+			expected: &Finished{Timestamp: &ts},
+		},
+		{
+			name:     "null timestamp",
+			in:       `{"timestamp": null}`,
+			expected: &Finished{},
+		},
+		{
+			name: "bad timestamp",
+			in:   `{"timestamp": true}`,
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := ReadFinished(strings.NewReader(tc.in))
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Errorf("failed to receive expected error, got %#v", actual)
+			case !reflect.DeepEqual(actual, tc.expected):
+				t.Errorf("%#v != expected %#v", actual, tc.expected)
+			}
+		})
+	}
+
+	finished, err := ReadFinished(strings.NewReader(`{"metadata": {"count": 3, "big": 12345678901234567}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for key, want := range map[string]int64{"count": 3, "big": 12345678901234567} {
+		if got, ok := finished.Metadata.Int(key); !ok || got == nil || *got != want {
+			t.Errorf("Int(%q) got %v, %t, want %d", key, got, ok, want)
+		}
+	}
+}
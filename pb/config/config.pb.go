@@ -22,6 +22,7 @@ package config
 import (
 	fmt "fmt"
 	custom_evaluator "github.com/GoogleCloudPlatform/testgrid/pb/custom_evaluator"
+	test_status "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
 	proto "github.com/golang/protobuf/proto"
 	math "math"
 )
@@ -183,6 +184,31 @@ func (TestGroup_Environment) EnumDescriptor() ([]byte, []int) {
 }
 
 // Scale of issue priority, used to indicate importance of issue.
+type TestGroup_ColumnOrder int32
+
+const (
+	TestGroup_COLUMN_ORDER_BUILD_ID TestGroup_ColumnOrder = 0
+	TestGroup_COLUMN_ORDER_STARTED  TestGroup_ColumnOrder = 1
+)
+
+var TestGroup_ColumnOrder_name = map[int32]string{
+	0: "COLUMN_ORDER_BUILD_ID",
+	1: "COLUMN_ORDER_STARTED",
+}
+
+var TestGroup_ColumnOrder_value = map[string]int32{
+	"COLUMN_ORDER_BUILD_ID": 0,
+	"COLUMN_ORDER_STARTED":  1,
+}
+
+func (x TestGroup_ColumnOrder) String() string {
+	return proto.EnumName(TestGroup_ColumnOrder_name, int32(x))
+}
+
+func (TestGroup_ColumnOrder) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 5}
+}
+
 type AutoBugOptions_Priority int32
 
 const (
@@ -579,7 +605,48 @@ type TestGroup struct {
 	// for a test with the same name are encountered.
 	IgnoreOldResults bool `protobuf:"varint,53,opt,name=ignore_old_results,json=ignoreOldResults,proto3" json:"ignore_old_results,omitempty"`
 	// If True, ignore the 'pass with skips' status (show as a blank cell).
-	IgnoreSkip           bool     `protobuf:"varint,54,opt,name=ignore_skip,json=ignoreSkip,proto3" json:"ignore_skip,omitempty"`
+	IgnoreSkip bool `protobuf:"varint,54,opt,name=ignore_skip,json=ignoreSkip,proto3" json:"ignore_skip,omitempty"`
+	// Maximum number of bytes of a test result message (such as the failure
+	// message) shown in a cell. Defaults to 140 when unset.
+	MaxMessageLength int32 `protobuf:"varint,55,opt,name=max_message_length,json=maxMessageLength,proto3" json:"max_message_length,omitempty"`
+	// Fraction of failing cells above which a column is broken, such as when
+	// the infrastructure failed. Broken columns do not count towards alerts or
+	// summaries. Defaults to 0.9 when unset; 1 never breaks a column. Columns
+	// with fewer than 5 test results are never broken.
+	BrokenColumnThreshold float32 `protobuf:"fixed32,56,opt,name=broken_column_threshold,json=brokenColumnThreshold,proto3" json:"broken_column_threshold,omitempty"`
+	// If True, builds listed as objects ending in .txt holding the gs:// url of
+	// the build, such as pr-logs/directory/job/123.txt, are read from that url.
+	// The build keeps the name of the object, such as 123.
+	FollowSymlinks bool `protobuf:"varint,57,opt,name=follow_symlinks,json=followSymlinks,proto3" json:"follow_symlinks,omitempty"`
+	// Specifies the order of columns in the grid written by the updater. The
+	// default orders columns by build ID, newest first. Groups whose builds can
+	// complete out of order may order them by started time instead, breaking
+	// ties by build ID.
+	ColumnOrder TestGroup_ColumnOrder `protobuf:"varint,58,opt,name=column_order,json=columnOrder,proto3,enum=TestGroup_ColumnOrder" json:"column_order,omitempty"`
+	// If True, the summarizer leaves 'pass with skips' results out of the
+	// passing cells and columns of each tab, instead of counting them as passes.
+	ExcludeSkipsFromPassRate bool `protobuf:"varint,59,opt,name=exclude_skips_from_pass_rate,json=excludeSkipsFromPassRate,proto3" json:"exclude_skips_from_pass_rate,omitempty"`
+	// If True, the summarizer ignores rows whose recent results are all
+	// 'pass with skips', such as tests disabled for this job.
+	HideSkippedRows bool `protobuf:"varint,60,opt,name=hide_skipped_rows,json=hideSkippedRows,proto3" json:"hide_skipped_rows,omitempty"`
+	// Overrides the status of the cells of junit results in each category, such
+	// as rendering errors as TOOL_FAIL rather than FAIL.
+	StatusMapping *TestGroup_StatusMapping `protobuf:"bytes,61,opt,name=status_mapping,json=statusMapping,proto3" json:"status_mapping,omitempty"`
+	// Maximum number of rows the updater keeps in the grid, keeping those that
+	// failed most recently. Defaults to 100,000 when unset.
+	MaxRows int32 `protobuf:"varint,62,opt,name=max_rows,json=maxRows,proto3" json:"max_rows,omitempty"`
+	// Keys of the build metadata to copy into the metadata of each column, such
+	// as the cluster or zone that ran the build, without displaying them as
+	// column headers.
+	ColumnAnnotations []string `protobuf:"bytes,63,rep,name=column_annotations,json=columnAnnotations,proto3" json:"column_annotations,omitempty"`
+	// Additional bucket/paths to read builds from, such as where the job wrote
+	// its results before moving buckets. Builds under every prefix merge into
+	// one grid; of the builds sharing an ID, the one under the earliest prefix
+	// (starting with query) wins. No prefix may contain another.
+	AdditionalGcsPrefixes []string `protobuf:"bytes,64,rep,name=additional_gcs_prefixes,json=additionalGcsPrefixes,proto3" json:"additional_gcs_prefixes,omitempty"`
+	// Number of columns the grid needs before its tabs compute a status, which
+	// is PENDING with fewer, unless the results are stale. None when unset.
+	MinimumNumberOfRuns  int32    `protobuf:"varint,65,opt,name=minimum_number_of_runs,json=minimumNumberOfRuns,proto3" json:"minimum_number_of_runs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -990,6 +1057,83 @@ func (m *TestGroup) GetIgnoreSkip() bool {
 	return false
 }
 
+func (m *TestGroup) GetMaxMessageLength() int32 {
+	if m != nil {
+		return m.MaxMessageLength
+	}
+	return 0
+}
+
+func (m *TestGroup) GetBrokenColumnThreshold() float32 {
+	if m != nil {
+		return m.BrokenColumnThreshold
+	}
+	return 0
+}
+
+func (m *TestGroup) GetFollowSymlinks() bool {
+	if m != nil {
+		return m.FollowSymlinks
+	}
+	return false
+}
+
+func (m *TestGroup) GetColumnOrder() TestGroup_ColumnOrder {
+	if m != nil {
+		return m.ColumnOrder
+	}
+	return TestGroup_COLUMN_ORDER_BUILD_ID
+}
+
+func (m *TestGroup) GetExcludeSkipsFromPassRate() bool {
+	if m != nil {
+		return m.ExcludeSkipsFromPassRate
+	}
+	return false
+}
+
+func (m *TestGroup) GetHideSkippedRows() bool {
+	if m != nil {
+		return m.HideSkippedRows
+	}
+	return false
+}
+
+func (m *TestGroup) GetStatusMapping() *TestGroup_StatusMapping {
+	if m != nil {
+		return m.StatusMapping
+	}
+	return nil
+}
+
+func (m *TestGroup) GetMaxRows() int32 {
+	if m != nil {
+		return m.MaxRows
+	}
+	return 0
+}
+
+func (m *TestGroup) GetColumnAnnotations() []string {
+	if m != nil {
+		return m.ColumnAnnotations
+	}
+	return nil
+}
+
+func (m *TestGroup) GetAdditionalGcsPrefixes() []string {
+	if m != nil {
+		return m.AdditionalGcsPrefixes
+	}
+	return nil
+}
+
+func (m *TestGroup) GetMinimumNumberOfRuns() int32 {
+	if m != nil {
+		return m.MinimumNumberOfRuns
+	}
+	return 0
+}
+
 // Custom column headers for defining extra column-heading rows from values in
 // the test result.
 type TestGroup_ColumnHeader struct {
@@ -1275,6 +1419,67 @@ func (*TestGroup_ResultSource) XXX_OneofWrappers() []interface{} {
 	}
 }
 
+// Maps each category of junit result to the status of its cell.
+//
+// Unset categories keep their default status.
+type TestGroup_StatusMapping struct {
+	// The status of a test case with a <failure/>, FAIL by default.
+	Failure test_status.TestStatus `protobuf:"varint,1,opt,name=failure,proto3,enum=TestStatus" json:"failure,omitempty"`
+	// The status of a test case with an <error/>, FAIL by default.
+	Error test_status.TestStatus `protobuf:"varint,2,opt,name=error,proto3,enum=TestStatus" json:"error,omitempty"`
+	// The status of a test case with a <skipped/>, PASS_WITH_SKIPS by default.
+	Skipped              test_status.TestStatus `protobuf:"varint,3,opt,name=skipped,proto3,enum=TestStatus" json:"skipped,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *TestGroup_StatusMapping) Reset()         { *m = TestGroup_StatusMapping{} }
+func (m *TestGroup_StatusMapping) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_StatusMapping) ProtoMessage()    {}
+func (*TestGroup_StatusMapping) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 4}
+}
+
+func (m *TestGroup_StatusMapping) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_StatusMapping.Unmarshal(m, b)
+}
+func (m *TestGroup_StatusMapping) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_StatusMapping.Marshal(b, m, deterministic)
+}
+func (m *TestGroup_StatusMapping) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_StatusMapping.Merge(m, src)
+}
+func (m *TestGroup_StatusMapping) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_StatusMapping.Size(m)
+}
+func (m *TestGroup_StatusMapping) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_StatusMapping.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestGroup_StatusMapping proto.InternalMessageInfo
+
+func (m *TestGroup_StatusMapping) GetFailure() test_status.TestStatus {
+	if m != nil {
+		return m.Failure
+	}
+	return test_status.TestStatus_NO_RESULT
+}
+
+func (m *TestGroup_StatusMapping) GetError() test_status.TestStatus {
+	if m != nil {
+		return m.Error
+	}
+	return test_status.TestStatus_NO_RESULT
+}
+
+func (m *TestGroup_StatusMapping) GetSkipped() test_status.TestStatus {
+	if m != nil {
+		return m.Skipped
+	}
+	return test_status.TestStatus_NO_RESULT
+}
+
 type JUnitConfig struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -1593,7 +1798,12 @@ type Dashboard struct {
 	HighlightFailingTabs bool `protobuf:"varint,6,opt,name=highlight_failing_tabs,json=highlightFailingTabs,proto3" json:"highlight_failing_tabs,omitempty"` // Deprecated: Do not use.
 	// Controls whether to apply special highlighting to result header columns for
 	// the current day.
-	HighlightToday       bool     `protobuf:"varint,7,opt,name=highlight_today,json=highlightToday,proto3" json:"highlight_today,omitempty"`
+	HighlightToday bool `protobuf:"varint,7,opt,name=highlight_today,json=highlightToday,proto3" json:"highlight_today,omitempty"`
+	// Stops summarizing the dashboard, such as while its tests are migrating.
+	// Each tab summary is PAUSED and shows the paused_reason instead.
+	Paused bool `protobuf:"varint,9,opt,name=paused,proto3" json:"paused,omitempty"`
+	// Required when paused: why the dashboard is paused, for its readers.
+	PausedReason         string   `protobuf:"bytes,10,opt,name=paused_reason,json=pausedReason,proto3" json:"paused_reason,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1674,6 +1884,20 @@ func (m *Dashboard) GetHighlightToday() bool {
 	return false
 }
 
+func (m *Dashboard) GetPaused() bool {
+	if m != nil {
+		return m.Paused
+	}
+	return false
+}
+
+func (m *Dashboard) GetPausedReason() string {
+	if m != nil {
+		return m.PausedReason
+	}
+	return ""
+}
+
 type LinkTemplate struct {
 	// The URL template.
 	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
@@ -1816,10 +2040,16 @@ type DashboardTab struct {
 	// A URL for the "About this Dashboard" menu option
 	AboutDashboardUrl string `protobuf:"bytes,16,opt,name=about_dashboard_url,json=aboutDashboardUrl,proto3" json:"about_dashboard_url,omitempty"`
 	// The URL template to visit when viewing an associated bug.
-	OpenBugTemplate      *LinkTemplate `protobuf:"bytes,17,opt,name=open_bug_template,json=openBugTemplate,proto3" json:"open_bug_template,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	OpenBugTemplate *LinkTemplate `protobuf:"bytes,17,opt,name=open_bug_template,json=openBugTemplate,proto3" json:"open_bug_template,omitempty"`
+	// Number of the flakiest tests to include in the summary of the tab, or none
+	// when unset.
+	NumFlakyTests int32 `protobuf:"varint,18,opt,name=num_flaky_tests,json=numFlakyTests,proto3" json:"num_flaky_tests,omitempty"`
+	// Number of columns the grid needs before the tab computes a status, or the
+	// minimum_number_of_runs of the test group when unset.
+	MinimumNumberOfRuns  int32    `protobuf:"varint,19,opt,name=minimum_number_of_runs,json=minimumNumberOfRuns,proto3" json:"minimum_number_of_runs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *DashboardTab) Reset()         { *m = DashboardTab{} }
@@ -1966,6 +2196,20 @@ func (m *DashboardTab) GetOpenBugTemplate() *LinkTemplate {
 	return nil
 }
 
+func (m *DashboardTab) GetNumFlakyTests() int32 {
+	if m != nil {
+		return m.NumFlakyTests
+	}
+	return 0
+}
+
+func (m *DashboardTab) GetMinimumNumberOfRuns() int32 {
+	if m != nil {
+		return m.MinimumNumberOfRuns
+	}
+	return 0
+}
+
 // Configuration options for dashboard tab alerts.
 type DashboardTabAlertOptions struct {
 	// Time in hours before an alert will be added to a test results table if the
@@ -2233,6 +2477,7 @@ func init() {
 	proto.RegisterEnum("TestGroup_ColumnSortBy", TestGroup_ColumnSortBy_name, TestGroup_ColumnSortBy_value)
 	proto.RegisterEnum("TestGroup_PrimaryGrouping", TestGroup_PrimaryGrouping_name, TestGroup_PrimaryGrouping_value)
 	proto.RegisterEnum("TestGroup_Environment", TestGroup_Environment_name, TestGroup_Environment_value)
+	proto.RegisterEnum("TestGroup_ColumnOrder", TestGroup_ColumnOrder_name, TestGroup_ColumnOrder_value)
 	proto.RegisterEnum("AutoBugOptions_Priority", AutoBugOptions_Priority_name, AutoBugOptions_Priority_value)
 	proto.RegisterType((*TestNameConfig)(nil), "TestNameConfig")
 	proto.RegisterType((*TestNameConfig_NameElement)(nil), "TestNameConfig.NameElement")
@@ -2242,6 +2487,7 @@ func init() {
 	proto.RegisterType((*TestGroup_TestAnnotation)(nil), "TestGroup.TestAnnotation")
 	proto.RegisterType((*TestGroup_KeyValue)(nil), "TestGroup.KeyValue")
 	proto.RegisterType((*TestGroup_ResultSource)(nil), "TestGroup.ResultSource")
+	proto.RegisterType((*TestGroup_StatusMapping)(nil), "TestGroup.StatusMapping")
 	proto.RegisterType((*JUnitConfig)(nil), "JUnitConfig")
 	proto.RegisterType((*TestMetadataOptions)(nil), "TestMetadataOptions")
 	proto.RegisterType((*AutoBugOptions)(nil), "AutoBugOptions")
@@ -2259,194 +2505,217 @@ func init() {
 func init() { proto.RegisterFile("config.proto", fileDescriptor_3eaf2c85e69e9ea4) }
 
 var fileDescriptor_3eaf2c85e69e9ea4 = []byte{
-	// 3013 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x59, 0xcd, 0x76, 0x1b, 0xc7,
-	0xb1, 0x16, 0x00, 0x92, 0x02, 0x0b, 0x00, 0x39, 0x6c, 0x80, 0xd4, 0x90, 0x94, 0x2c, 0x0a, 0xba,
-	0xb2, 0x68, 0xc9, 0x97, 0xb6, 0x28, 0xfb, 0x1e, 0xeb, 0x5a, 0xba, 0xd7, 0x20, 0x09, 0x8a, 0x90,
-	0xf8, 0x03, 0x0f, 0x40, 0x9f, 0xe3, 0xbb, 0xe9, 0xd3, 0x00, 0x9a, 0xc0, 0x98, 0xf3, 0x83, 0x3b,
-	0xdd, 0x23, 0x89, 0x4f, 0x91, 0x07, 0x48, 0x96, 0x39, 0xd9, 0x65, 0x99, 0x57, 0xc8, 0x22, 0x9b,
-	0xac, 0xfd, 0x36, 0x39, 0x5d, 0xdd, 0x33, 0x18, 0x90, 0x90, 0xe2, 0x64, 0x05, 0x74, 0xfd, 0xf4,
-	0x4f, 0x55, 0xf5, 0x57, 0xd5, 0x35, 0x50, 0xee, 0x87, 0xc1, 0x85, 0x3b, 0xdc, 0x19, 0x47, 0xa1,
-	0x0c, 0x37, 0x9e, 0x8c, 0x7b, 0x5f, 0xf5, 0x63, 0x21, 0x43, 0x9f, 0xf2, 0x77, 0xcc, 0x8b, 0x99,
-	0x0c, 0xa3, 0x1b, 0x04, 0x2d, 0x5b, 0xff, 0x43, 0x1e, 0x96, 0xba, 0x5c, 0xc8, 0x53, 0xe6, 0xf3,
-	0x7d, 0x9c, 0x84, 0xfc, 0x00, 0x95, 0x80, 0xf9, 0x9c, 0x72, 0x8f, 0xfb, 0x3c, 0x90, 0xc2, 0xce,
-	0x6d, 0x15, 0xb6, 0x4b, 0xbb, 0x9b, 0x3b, 0xd3, 0x72, 0x3b, 0xea, 0x6f, 0x53, 0xcb, 0x38, 0xe5,
-	0x60, 0x32, 0x10, 0xe4, 0x3e, 0x94, 0x70, 0x86, 0x8b, 0x30, 0xf2, 0x99, 0xb4, 0xf3, 0x5b, 0xb9,
-	0xed, 0x45, 0x07, 0x14, 0xe9, 0x10, 0x29, 0x1b, 0x7f, 0xca, 0x41, 0x29, 0xa3, 0x4e, 0xd6, 0x60,
-	0xc1, 0x63, 0x3d, 0xee, 0xa9, 0xb5, 0x94, 0xac, 0x19, 0x91, 0x87, 0x50, 0x91, 0x2c, 0x1a, 0x72,
-	0x49, 0xf5, 0x01, 0xcd, 0x54, 0x65, 0x4d, 0x34, 0xfb, 0x7d, 0x00, 0xe5, 0x5e, 0xec, 0x7a, 0x03,
-	0xaa, 0xa9, 0x76, 0x61, 0x2b, 0xb7, 0x5d, 0x74, 0x4a, 0x48, 0xeb, 0x22, 0x89, 0x10, 0x98, 0x93,
-	0x6c, 0x28, 0xec, 0x39, 0x54, 0xc7, 0xff, 0x38, 0x37, 0x17, 0x92, 0x8e, 0xa3, 0x70, 0xcc, 0x23,
-	0x79, 0x65, 0xcf, 0x9b, 0xb9, 0xb9, 0x90, 0x6d, 0x43, 0xab, 0xbf, 0x85, 0xf2, 0x69, 0x28, 0xdd,
-	0x0b, 0xb7, 0xcf, 0xa4, 0x1b, 0x06, 0xc4, 0x86, 0xdb, 0x22, 0xf6, 0x7d, 0x16, 0x5d, 0x99, 0x9d,
-	0x26, 0x43, 0xb5, 0x8b, 0x7e, 0x18, 0x48, 0xfe, 0x41, 0x52, 0xcf, 0x0d, 0x2e, 0xcd, 0x4e, 0x4b,
-	0x86, 0x76, 0xec, 0x06, 0x97, 0xf5, 0xbf, 0x7c, 0x06, 0x8b, 0xca, 0x86, 0xaf, 0xa3, 0x30, 0x1e,
-	0xab, 0x3d, 0x29, 0x8b, 0x98, 0x79, 0xf0, 0x3f, 0xa9, 0xc1, 0xfc, 0xff, 0xc7, 0x3c, 0xba, 0x32,
-	0xda, 0x7a, 0x40, 0x3e, 0x87, 0xe5, 0x01, 0xbb, 0x12, 0x34, 0xbc, 0xa0, 0x11, 0x17, 0xb1, 0x27,
-	0x05, 0x9e, 0x71, 0xde, 0xa9, 0x28, 0xf2, 0xd9, 0x85, 0xa3, 0x89, 0xe4, 0x11, 0x2c, 0xb9, 0xc3,
-	0x20, 0x8c, 0x38, 0x1d, 0xf3, 0x60, 0xe0, 0x06, 0x43, 0x3c, 0x6f, 0xd1, 0xa9, 0x68, 0x6a, 0x5b,
-	0x13, 0xd5, 0x4e, 0x8d, 0x98, 0x32, 0x91, 0xc4, 0x73, 0x17, 0x9d, 0x92, 0xa6, 0xed, 0x29, 0x12,
-	0xf9, 0x01, 0x56, 0x94, 0x19, 0x04, 0x45, 0x37, 0x8e, 0x43, 0xcf, 0xed, 0x5f, 0xd9, 0x0b, 0x5b,
-	0xb9, 0xed, 0xa5, 0xdd, 0xda, 0x4e, 0x7a, 0x04, 0xfc, 0x27, 0x94, 0x1f, 0x9d, 0x65, 0x99, 0xfc,
-	0x6d, 0xa3, 0x30, 0xf9, 0x0e, 0xd6, 0x86, 0x4c, 0x8e, 0x78, 0x44, 0xb3, 0x46, 0x76, 0xb9, 0xb0,
-	0x6f, 0xab, 0xe5, 0xf6, 0xf2, 0x76, 0xce, 0xa9, 0x69, 0x89, 0xee, 0xc4, 0xe0, 0x2e, 0x17, 0x64,
-	0x17, 0x56, 0xcd, 0xf6, 0x50, 0x53, 0xc4, 0x3d, 0x21, 0x23, 0x75, 0x98, 0xe2, 0x56, 0x61, 0x7b,
-	0xd1, 0xa9, 0x6a, 0xa6, 0x52, 0xea, 0x24, 0x2c, 0xf2, 0x12, 0x2a, 0xfd, 0xd0, 0x8b, 0xfd, 0x80,
-	0x8e, 0x38, 0x1b, 0xf0, 0xc8, 0x5e, 0xc4, 0x90, 0xbd, 0x93, 0xd9, 0xeb, 0x3e, 0xf2, 0x8f, 0x90,
-	0xed, 0x94, 0xfb, 0x99, 0x11, 0x39, 0x82, 0x95, 0x0b, 0xe6, 0x79, 0x3d, 0xd6, 0xbf, 0xa4, 0x43,
-	0x25, 0xac, 0x56, 0x03, 0x3c, 0xed, 0x66, 0x66, 0x86, 0x43, 0x23, 0xf3, 0xda, 0x88, 0x38, 0xd6,
-	0xc5, 0x35, 0x0a, 0x79, 0x01, 0xeb, 0xcc, 0xe3, 0x91, 0xa4, 0x42, 0x32, 0x8f, 0x27, 0xde, 0xa2,
-	0xa3, 0x30, 0x8e, 0x84, 0x5d, 0x42, 0x9f, 0xad, 0xa1, 0x40, 0x47, 0xf1, 0x8d, 0xdf, 0x8e, 0x14,
-	0x97, 0x3c, 0x83, 0xd5, 0x20, 0xf6, 0xe9, 0x05, 0x73, 0xbd, 0x38, 0xe2, 0x82, 0xca, 0x90, 0xa2,
-	0xa4, 0x5d, 0x46, 0x35, 0x12, 0xc4, 0xfe, 0xa1, 0xe1, 0x75, 0xc3, 0x86, 0xe2, 0xa8, 0x08, 0xee,
-	0xc5, 0x43, 0xda, 0x0f, 0xfd, 0x71, 0x18, 0xf0, 0x40, 0xda, 0x15, 0x14, 0x2d, 0xf7, 0xe2, 0xe1,
-	0x7e, 0x42, 0x23, 0xdb, 0x60, 0xf5, 0xc3, 0x01, 0xa7, 0x82, 0xb3, 0xa8, 0x3f, 0xa2, 0x63, 0x26,
-	0x47, 0xf6, 0x12, 0x46, 0xd7, 0x92, 0xa2, 0x77, 0x90, 0xdc, 0x66, 0x72, 0x44, 0xbe, 0x04, 0xb5,
-	0x08, 0xd5, 0xa6, 0x11, 0x34, 0xe2, 0x7d, 0x35, 0xe7, 0x32, 0xce, 0x69, 0x05, 0xb1, 0xaf, 0x2d,
-	0x28, 0x1c, 0xa4, 0x93, 0x27, 0xb0, 0x12, 0x0b, 0xe3, 0x23, 0x9f, 0x4b, 0x36, 0x60, 0x92, 0xd9,
-	0x16, 0x86, 0xd2, 0x72, 0x2c, 0xd0, 0x3f, 0x27, 0x86, 0x4c, 0xbe, 0x85, 0x3b, 0xda, 0x2c, 0x3e,
-	0x73, 0x3d, 0x3c, 0xd9, 0x60, 0x10, 0x71, 0x21, 0xb8, 0xb0, 0x57, 0x70, 0x2b, 0x35, 0x64, 0x9f,
-	0x30, 0xd7, 0xeb, 0x86, 0x8d, 0x84, 0xa7, 0x36, 0x94, 0x51, 0x13, 0x71, 0xef, 0x17, 0xde, 0x97,
-	0x36, 0x41, 0x0d, 0x2b, 0xd5, 0xe8, 0x68, 0x3a, 0xf9, 0x1e, 0x36, 0x32, 0xd2, 0xc6, 0x8e, 0xd4,
-	0xe7, 0x42, 0xb0, 0x21, 0xb7, 0xab, 0xa8, 0x75, 0x27, 0xd5, 0x32, 0xb6, 0x3c, 0xd1, 0x6c, 0xf2,
-	0x15, 0xd4, 0x32, 0xca, 0x03, 0xae, 0xec, 0x1a, 0x47, 0x9e, 0x5d, 0x43, 0xb5, 0x95, 0x54, 0xed,
-	0x40, 0x71, 0xce, 0x23, 0x8f, 0x1c, 0xc1, 0x03, 0xdf, 0x0d, 0x28, 0xf7, 0xd8, 0x58, 0xf0, 0x01,
-	0xf5, 0xdd, 0x20, 0x96, 0x5c, 0xd0, 0x1e, 0x97, 0xef, 0x39, 0x0f, 0x70, 0x1a, 0x61, 0xaf, 0xa2,
-	0xed, 0xee, 0xf9, 0x6e, 0xd0, 0xd4, 0x72, 0x27, 0x5a, 0x6c, 0x4f, 0x4b, 0xa9, 0x09, 0x05, 0x39,
-	0x87, 0x6d, 0x65, 0x48, 0x0d, 0x70, 0x71, 0x84, 0x38, 0x43, 0x15, 0x4a, 0x73, 0x41, 0x99, 0xd0,
-	0x41, 0x40, 0xc7, 0x2c, 0x62, 0xbe, 0xb0, 0xd7, 0xd0, 0xbe, 0x0f, 0x63, 0x61, 0x30, 0xd8, 0x88,
-	0xff, 0x84, 0xd2, 0x0d, 0x81, 0x61, 0xd1, 0x46, 0x51, 0xb2, 0x03, 0x55, 0x1e, 0xb0, 0x9e, 0xc7,
-	0xe9, 0x85, 0xc7, 0x2e, 0xaf, 0x54, 0x44, 0xca, 0x58, 0xd8, 0x77, 0x70, 0x86, 0x15, 0xcd, 0x3a,
-	0x54, 0x9c, 0x0e, 0x32, 0xd4, 0xb5, 0x53, 0xdb, 0xb8, 0x8c, 0x7b, 0x3c, 0x0a, 0xb8, 0x3a, 0x4b,
-	0xdf, 0x73, 0x55, 0x00, 0xd8, 0xa8, 0x51, 0x8d, 0x05, 0x7f, 0x9b, 0xf2, 0xf6, 0x91, 0xa5, 0x70,
-	0xde, 0x15, 0x94, 0x7f, 0x90, 0x3c, 0x0a, 0x98, 0x67, 0xaf, 0xa3, 0x24, 0xb8, 0xa2, 0x69, 0x28,
-	0xe4, 0x05, 0x58, 0x18, 0x20, 0x08, 0x23, 0x06, 0xc2, 0x37, 0xb6, 0x72, 0xdb, 0xa5, 0xdd, 0xe5,
-	0x6b, 0xd9, 0xc4, 0x59, 0x92, 0xd3, 0x59, 0xe8, 0x39, 0x54, 0x82, 0x0c, 0xf2, 0x0a, 0x7b, 0x13,
-	0xaf, 0x74, 0x65, 0x27, 0x8b, 0xc7, 0xce, 0xb4, 0x0c, 0x79, 0x05, 0x4b, 0x06, 0x07, 0x44, 0x18,
-	0x49, 0xda, 0xbb, 0xb2, 0xef, 0xe2, 0x35, 0xbe, 0x09, 0x04, 0x9d, 0x30, 0x92, 0x7b, 0x57, 0x09,
-	0x10, 0xe8, 0x11, 0x69, 0x82, 0x35, 0x8e, 0x5c, 0x05, 0xe7, 0x13, 0x1c, 0xb8, 0x87, 0x13, 0x6c,
-	0x64, 0x26, 0x68, 0x6b, 0x91, 0x14, 0x06, 0x96, 0xc7, 0xd3, 0x84, 0x8c, 0xe9, 0x93, 0xdb, 0x31,
-	0x0a, 0x07, 0xc2, 0xfe, 0x2c, 0x6b, 0x7a, 0x73, 0x3f, 0x14, 0x83, 0x1c, 0x18, 0x2b, 0xb1, 0x20,
-	0x08, 0xa5, 0x39, 0xed, 0x7d, 0x3c, 0xed, 0xfa, 0x35, 0xb0, 0x6d, 0xa4, 0x12, 0x1a, 0x71, 0x27,
-	0x63, 0x41, 0xbe, 0x83, 0x75, 0x9f, 0x7d, 0x98, 0x5a, 0x92, 0x8e, 0x0d, 0xfe, 0xda, 0x5b, 0x18,
-	0x89, 0xab, 0x3e, 0xfb, 0x90, 0x59, 0xb8, 0xad, 0xb1, 0x97, 0x34, 0xe0, 0x5e, 0x3f, 0xf4, 0x7d,
-	0x57, 0xd2, 0xf0, 0x1d, 0x8f, 0x22, 0x77, 0xc0, 0x29, 0xe6, 0x5f, 0x05, 0x16, 0xca, 0x91, 0xf6,
-	0x03, 0xbc, 0x05, 0x1b, 0x5a, 0xe8, 0xcc, 0xc8, 0x1c, 0x2b, 0x91, 0xb6, 0x96, 0x20, 0x47, 0xb0,
-	0x3a, 0x85, 0x04, 0x34, 0x1c, 0xeb, 0x73, 0xd4, 0xf1, 0x1c, 0x3a, 0x69, 0x24, 0x78, 0x70, 0xa6,
-	0x79, 0x4e, 0x55, 0xde, 0x24, 0x2a, 0xbc, 0xc2, 0x99, 0x24, 0x1b, 0xa6, 0xeb, 0x3f, 0xd4, 0x78,
-	0xa5, 0xe8, 0x5d, 0x36, 0x4c, 0xd6, 0x7c, 0x01, 0x16, 0x8b, 0x65, 0x48, 0xd5, 0x5d, 0x4d, 0x96,
-	0xfb, 0x0f, 0x13, 0x5c, 0x8d, 0x58, 0x86, 0x7b, 0xf1, 0x30, 0x59, 0x69, 0x89, 0x4d, 0x8d, 0xc9,
-	0x73, 0x58, 0x4b, 0x6d, 0x15, 0xc5, 0x81, 0x74, 0x7d, 0x6e, 0x40, 0xfa, 0x11, 0x1a, 0xaa, 0x6a,
-	0x0c, 0xe5, 0x68, 0x9e, 0x46, 0xe8, 0x97, 0xb0, 0xa9, 0xf0, 0x71, 0xcc, 0x14, 0x38, 0x29, 0x14,
-	0x1b, 0xb8, 0x02, 0xbd, 0xac, 0x71, 0xfa, 0x73, 0xd4, 0xbc, 0x13, 0xc4, 0x7e, 0x1b, 0x25, 0xba,
-	0xe1, 0x81, 0xe6, 0x6b, 0xb0, 0x7e, 0x0a, 0x44, 0xd5, 0x05, 0x6a, 0xb7, 0x82, 0xf6, 0x4c, 0x80,
-	0xd9, 0x8f, 0x35, 0x60, 0x2a, 0xce, 0x5e, 0x3c, 0x14, 0x7b, 0x3a, 0x88, 0x48, 0x0b, 0x6a, 0x3c,
-	0x78, 0xe7, 0x46, 0x61, 0xa0, 0xca, 0x23, 0xea, 0x06, 0x42, 0xb2, 0xa0, 0xcf, 0xed, 0x6d, 0x0c,
-	0xc6, 0xb5, 0x4c, 0x54, 0x34, 0x27, 0x62, 0x4e, 0x35, 0xa3, 0xd3, 0x32, 0x2a, 0xa4, 0x05, 0x6b,
-	0x99, 0x90, 0xc8, 0x26, 0xe2, 0x2f, 0xd0, 0x35, 0xd5, 0xcc, 0x64, 0x6f, 0xf9, 0x15, 0x42, 0x89,
-	0x53, 0x93, 0x69, 0x94, 0x64, 0x32, 0xf3, 0x7d, 0x28, 0x99, 0x9c, 0xae, 0x0e, 0x61, 0x3f, 0xd1,
-	0xd7, 0x5d, 0x93, 0xd4, 0xee, 0x55, 0x4e, 0x10, 0x23, 0x75, 0xf1, 0xb0, 0x0c, 0xf2, 0xb9, 0x8c,
-	0xdc, 0xbe, 0xfd, 0x14, 0x9d, 0xb7, 0x8c, 0x8c, 0x2e, 0xff, 0xa0, 0xa6, 0x8d, 0xdc, 0x3e, 0x39,
-	0x81, 0x87, 0xd7, 0x83, 0x6e, 0x06, 0x04, 0xda, 0x5f, 0xa2, 0xf6, 0xd6, 0x74, 0xe8, 0xdd, 0x04,
-	0x3f, 0x15, 0xfd, 0x53, 0xe6, 0x9d, 0xba, 0x79, 0xff, 0x89, 0x3b, 0x5d, 0x9d, 0x58, 0x39, 0x7b,
-	0xfb, 0xbe, 0x85, 0x3b, 0x59, 0x03, 0xf9, 0x4c, 0xf6, 0x47, 0x34, 0xe2, 0x43, 0xfe, 0xc1, 0xde,
-	0xd1, 0xc9, 0x69, 0x62, 0x8c, 0x13, 0xc5, 0x74, 0x14, 0x4f, 0xe5, 0x6b, 0x85, 0x97, 0x17, 0xb1,
-	0xe7, 0x25, 0xaa, 0x0a, 0xe5, 0x84, 0xfd, 0x15, 0x2e, 0x46, 0x62, 0xc1, 0x0f, 0x63, 0xcf, 0xd3,
-	0x7a, 0x0a, 0xd7, 0x04, 0x69, 0xc2, 0x3d, 0x53, 0x85, 0xeb, 0xc2, 0x60, 0x52, 0x8c, 0xd3, 0x28,
-	0xf6, 0xb8, 0xb0, 0xbf, 0x56, 0x15, 0x0e, 0x96, 0x46, 0x1b, 0x5a, 0x50, 0x57, 0x08, 0xcd, 0x44,
-	0xcc, 0x51, 0x52, 0xe4, 0x47, 0x78, 0x74, 0xa3, 0x5c, 0x99, 0x69, 0xbb, 0x67, 0xb8, 0xfd, 0xfa,
-	0xf5, 0x2a, 0x65, 0x86, 0xf5, 0x5e, 0x42, 0xc5, 0x6c, 0x49, 0x84, 0x71, 0xd4, 0xe7, 0xf6, 0x2e,
-	0xde, 0xa3, 0x2c, 0x6c, 0xea, 0xad, 0x74, 0x90, 0xed, 0x94, 0xa3, 0xcc, 0x88, 0xec, 0xc3, 0xfa,
-	0xf5, 0xd7, 0x05, 0x1e, 0x88, 0x0a, 0x2e, 0xed, 0xe7, 0x38, 0x53, 0x71, 0x47, 0xed, 0xbd, 0xc3,
-	0xa5, 0xb3, 0xa6, 0x45, 0xa7, 0xce, 0xd4, 0xe1, 0x52, 0xb9, 0x21, 0xe2, 0x6c, 0x80, 0x79, 0x8a,
-	0xd3, 0x8b, 0x28, 0xf4, 0xa9, 0x90, 0x61, 0xa4, 0x72, 0xf7, 0x37, 0x68, 0xd1, 0x9a, 0x62, 0xab,
-	0x64, 0xc5, 0x0f, 0xa3, 0xd0, 0xef, 0x68, 0x9e, 0xaa, 0x11, 0x4c, 0xb5, 0x18, 0x7a, 0x83, 0xb4,
-	0x3c, 0xfe, 0x16, 0x35, 0x2c, 0xcd, 0x39, 0xf3, 0x06, 0x49, 0x85, 0xac, 0x12, 0x96, 0x96, 0x16,
-	0x97, 0xee, 0xd8, 0xfe, 0x2f, 0x93, 0xb0, 0x90, 0xd4, 0xb9, 0x74, 0xc7, 0x1b, 0xbf, 0xcb, 0x41,
-	0x39, 0x5b, 0x29, 0x92, 0x35, 0x98, 0x47, 0x2c, 0xd4, 0x65, 0xfa, 0xd1, 0x2d, 0x47, 0x0f, 0xc9,
-	0x5d, 0x28, 0xa6, 0x0f, 0x87, 0xbc, 0x61, 0xa5, 0x14, 0xf2, 0x0c, 0xaa, 0xb3, 0x1c, 0x52, 0x30,
-	0x82, 0xa4, 0x7f, 0xc3, 0x05, 0x7b, 0x6b, 0x50, 0x9b, 0x2a, 0x61, 0x8d, 0x27, 0x36, 0x84, 0x7e,
-	0x9f, 0x4d, 0x90, 0x9e, 0xdc, 0x03, 0x98, 0xdc, 0x32, 0xf3, 0x7c, 0x58, 0x4c, 0xaf, 0x17, 0x79,
-	0x04, 0x95, 0x64, 0x1f, 0x18, 0x91, 0xe9, 0xf6, 0xca, 0x09, 0x59, 0x45, 0xe3, 0xde, 0x26, 0xac,
-	0x4f, 0xdd, 0x55, 0xac, 0x83, 0x92, 0x45, 0x77, 0xa1, 0x98, 0x60, 0x01, 0xb1, 0xa0, 0x70, 0xc9,
-	0x93, 0xe7, 0x8e, 0xfa, 0xab, 0x5e, 0x29, 0xfa, 0x3c, 0xe6, 0x95, 0x82, 0x83, 0x0d, 0x0e, 0xe5,
-	0x6c, 0x8c, 0x90, 0x67, 0x50, 0xfe, 0x25, 0x0e, 0xdc, 0xa9, 0xa7, 0x5b, 0x69, 0xb7, 0xbc, 0xf3,
-	0xe6, 0x3c, 0x70, 0xcd, 0xd3, 0xed, 0xe8, 0x96, 0x53, 0x42, 0x19, 0x3d, 0x54, 0x36, 0x98, 0x0a,
-	0x43, 0xa3, 0xfa, 0x66, 0xae, 0x98, 0xb3, 0xf2, 0x6f, 0xe6, 0x8a, 0x05, 0x6b, 0xae, 0xee, 0xeb,
-	0x37, 0x14, 0xbe, 0x35, 0xc8, 0x06, 0xac, 0x75, 0x9b, 0x9d, 0x6e, 0x87, 0x9e, 0x36, 0x4e, 0x9a,
-	0xf4, 0xfc, 0xb4, 0xd3, 0x6e, 0xee, 0xb7, 0x0e, 0x5b, 0xcd, 0x03, 0xeb, 0x16, 0x59, 0x85, 0x95,
-	0x0c, 0xaf, 0xf5, 0xfa, 0xf4, 0xcc, 0x69, 0x5a, 0x39, 0xb2, 0x06, 0x24, 0x43, 0x76, 0x9a, 0xed,
-	0xe3, 0xc6, 0x7e, 0xd3, 0xca, 0x5f, 0x13, 0x6f, 0xb4, 0xdb, 0xcd, 0xd3, 0x03, 0xab, 0x50, 0xff,
-	0x7b, 0x0e, 0xac, 0xeb, 0x85, 0xbf, 0x5a, 0xf6, 0xb0, 0x71, 0x7c, 0xbc, 0xd7, 0xd8, 0x7f, 0x4b,
-	0x5f, 0x3b, 0x67, 0xe7, 0xed, 0xd6, 0xe9, 0x6b, 0x7a, 0x7a, 0x76, 0xda, 0xb4, 0x6e, 0xcd, 0xe6,
-	0x1d, 0x34, 0xba, 0x6a, 0xed, 0xbb, 0x60, 0xdf, 0xe4, 0x1d, 0x37, 0xf6, 0x9a, 0xc7, 0x1d, 0x2b,
-	0x4f, 0x6c, 0xa8, 0xdd, 0xe4, 0xb6, 0x0e, 0xac, 0x02, 0xd9, 0x82, 0xbb, 0x37, 0x39, 0xfb, 0x67,
-	0x27, 0x27, 0xad, 0x2e, 0x3d, 0x3d, 0x3f, 0xb1, 0xe6, 0xc8, 0x17, 0xf0, 0x68, 0x96, 0xc4, 0xe9,
-	0x61, 0xeb, 0xf5, 0xb9, 0xd3, 0xe8, 0xb6, 0xce, 0x4e, 0xe9, 0x4f, 0x8d, 0xe3, 0xf3, 0xa6, 0x35,
-	0x5f, 0xff, 0x21, 0x89, 0x70, 0x53, 0xf4, 0xd4, 0xc0, 0xda, 0x3f, 0x3b, 0x3e, 0x3f, 0x39, 0xa5,
-	0x9d, 0x33, 0xa7, 0xab, 0xb7, 0x8a, 0xc7, 0xc8, 0x52, 0x33, 0x8b, 0xe5, 0xea, 0x27, 0xb0, 0x7c,
-	0xad, 0x06, 0x22, 0xeb, 0xb0, 0xda, 0x76, 0x5a, 0x27, 0x0d, 0xe7, 0xe7, 0x1b, 0x06, 0xb9, 0x0f,
-	0x9b, 0x37, 0x58, 0x53, 0xd3, 0xdd, 0x87, 0x52, 0x26, 0x8b, 0x91, 0x22, 0xcc, 0xb5, 0x9d, 0x33,
-	0xe5, 0xc1, 0x05, 0xc8, 0xff, 0xd8, 0xb0, 0x72, 0xf5, 0x0a, 0x94, 0x32, 0x41, 0x53, 0xff, 0x73,
-	0x0e, 0xaa, 0x33, 0xca, 0x09, 0xf5, 0x4c, 0x9e, 0x14, 0x9b, 0x1a, 0xc0, 0x75, 0xd0, 0x56, 0x92,
-	0xd2, 0x52, 0x23, 0xf7, 0x8d, 0x67, 0x53, 0x7e, 0xc6, 0xb3, 0xa9, 0x06, 0xf3, 0xe1, 0xfb, 0x80,
-	0x47, 0xfa, 0xce, 0x3a, 0x7a, 0x40, 0x96, 0x20, 0xdf, 0xef, 0xdb, 0x73, 0xf8, 0x10, 0xcd, 0xf7,
-	0xfb, 0x6a, 0xaa, 0xe4, 0xe6, 0xe8, 0x05, 0x4d, 0x0f, 0xc1, 0x10, 0x71, 0xbd, 0xfa, 0xaf, 0x05,
-	0x58, 0x9a, 0xae, 0x47, 0xd4, 0x15, 0xc6, 0xd2, 0xa5, 0xef, 0x85, 0x42, 0x77, 0x00, 0x8a, 0xce,
-	0xa2, 0xa2, 0xec, 0x2b, 0x82, 0x82, 0xa9, 0x51, 0x28, 0x3d, 0x57, 0x48, 0xea, 0x0e, 0x84, 0x9d,
-	0xdf, 0x2a, 0x6c, 0x17, 0x1c, 0x30, 0xa4, 0xd6, 0x40, 0x90, 0x6f, 0x14, 0xfa, 0xb8, 0x61, 0xe4,
-	0xca, 0x2b, 0xdc, 0xe0, 0xd2, 0xae, 0x7d, 0xad, 0xe4, 0x51, 0x55, 0x2a, 0xf2, 0x9d, 0x54, 0x92,
-	0xbc, 0x85, 0x3b, 0x99, 0x69, 0x0d, 0xc6, 0x6a, 0xbc, 0x9f, 0x33, 0x65, 0xda, 0x51, 0xb2, 0x06,
-	0x62, 0xac, 0x06, 0xfb, 0xda, 0x64, 0xe1, 0x09, 0x95, 0x3c, 0x86, 0xe5, 0x0b, 0xd7, 0xe3, 0xd4,
-	0x0d, 0x06, 0xee, 0x3b, 0x77, 0x10, 0x33, 0xcf, 0x34, 0x12, 0x96, 0x14, 0xb9, 0x95, 0x52, 0xc9,
-	0x53, 0x58, 0x11, 0x6e, 0x30, 0xf4, 0xb8, 0x0c, 0x03, 0xaa, 0xce, 0xd8, 0x8b, 0x87, 0xd8, 0x4b,
-	0x28, 0x3a, 0x56, 0xca, 0x68, 0x68, 0x3a, 0x79, 0x05, 0x9b, 0xaa, 0x30, 0x63, 0x9e, 0x17, 0xbe,
-	0xe7, 0x83, 0xcc, 0xe4, 0xba, 0xe4, 0xb8, 0x8d, 0x9e, 0xb2, 0x7d, 0xf6, 0xa1, 0xa1, 0x25, 0x26,
-	0xeb, 0x60, 0x01, 0xf2, 0x00, 0xca, 0xb8, 0x29, 0x55, 0x52, 0x30, 0xcf, 0xb3, 0x8b, 0xba, 0xb5,
-	0xa1, 0x68, 0x67, 0x9a, 0x54, 0x3f, 0x86, 0x62, 0x62, 0x1a, 0x75, 0xe3, 0xda, 0x4e, 0xeb, 0xcc,
-	0x69, 0x75, 0x7f, 0xbe, 0x06, 0x1e, 0x0b, 0x90, 0x6f, 0x7f, 0x6d, 0xe5, 0xf0, 0xf7, 0x99, 0x95,
-	0xc7, 0xdf, 0x5d, 0xab, 0x80, 0xbf, 0xcf, 0xad, 0x39, 0xfc, 0xfd, 0xc6, 0x9a, 0xaf, 0xff, 0x1f,
-	0x54, 0x67, 0x98, 0x4c, 0x65, 0x0d, 0x8d, 0x90, 0xca, 0xb5, 0x05, 0x95, 0x35, 0x70, 0x38, 0xc9,
-	0x26, 0xf9, 0xa9, 0x6c, 0xb2, 0x57, 0x85, 0x95, 0x89, 0x67, 0x8c, 0x4f, 0xea, 0x7f, 0xcd, 0xc3,
-	0xe2, 0x01, 0x13, 0xa3, 0x5e, 0xc8, 0xa2, 0x01, 0xd9, 0x85, 0xca, 0x20, 0x19, 0x50, 0xc9, 0x7a,
-	0xa6, 0x2b, 0x57, 0xd9, 0x49, 0x45, 0xba, 0xac, 0xe7, 0x94, 0x07, 0x99, 0x51, 0xda, 0x62, 0xca,
-	0x67, 0x5a, 0x4c, 0x37, 0xde, 0x55, 0x85, 0xdf, 0xf0, 0xae, 0xba, 0x0f, 0xa5, 0x01, 0xbf, 0x60,
-	0x0a, 0x99, 0xd5, 0xd2, 0x3a, 0xca, 0xc1, 0x90, 0xd4, 0x4a, 0xbb, 0xb0, 0x3a, 0x08, 0xdf, 0x07,
-	0x63, 0x8f, 0x5d, 0xe1, 0xd3, 0x5b, 0x95, 0x24, 0x92, 0xf5, 0x84, 0xf1, 0x40, 0x35, 0x61, 0x1e,
-	0x6a, 0x5e, 0x97, 0xf5, 0xd4, 0x83, 0x65, 0x6d, 0xe4, 0x0e, 0x47, 0x9e, 0x3b, 0x1c, 0xc9, 0x69,
-	0xa5, 0x85, 0x49, 0x8b, 0x28, 0x95, 0xc8, 0x6a, 0x3e, 0x86, 0xe5, 0x89, 0xa6, 0x0c, 0x07, 0xec,
-	0x4a, 0x77, 0x95, 0x9c, 0xa5, 0x94, 0xdc, 0x55, 0xd4, 0x37, 0x73, 0xc5, 0x39, 0x6b, 0xbe, 0xde,
-	0x86, 0xf2, 0xb1, 0x1b, 0x5c, 0x76, 0xb9, 0x3f, 0xf6, 0x98, 0xc4, 0x8c, 0xa6, 0xde, 0xf6, 0x26,
-	0xa3, 0xc5, 0x91, 0x47, 0x76, 0xe0, 0x76, 0xf2, 0x82, 0xc8, 0x9b, 0x9b, 0xa0, 0x34, 0xcc, 0x5d,
-	0x4a, 0x14, 0x9d, 0x44, 0xa8, 0xfe, 0x0a, 0xaa, 0x33, 0xf8, 0xbf, 0x35, 0x55, 0xd6, 0xff, 0xb6,
-	0x00, 0xe5, 0x83, 0x59, 0x8e, 0xca, 0xf6, 0x02, 0x13, 0x38, 0xc3, 0x12, 0x2f, 0x93, 0xc9, 0x35,
-	0x9c, 0x21, 0xf2, 0x62, 0x0e, 0xbc, 0x01, 0x67, 0x85, 0xdf, 0xd8, 0x05, 0x9a, 0xfb, 0x17, 0xba,
-	0x40, 0xf3, 0x1f, 0xe9, 0x02, 0x3d, 0x80, 0x72, 0x8f, 0x09, 0x9e, 0xbe, 0xbf, 0x16, 0x74, 0xd7,
-	0x53, 0xd1, 0x12, 0xac, 0xfb, 0x1e, 0x48, 0x38, 0xe6, 0x81, 0xae, 0xc8, 0xa5, 0x31, 0x15, 0xfa,
-	0x4b, 0x45, 0x5d, 0xd6, 0x31, 0x8e, 0xa5, 0x04, 0x15, 0xb4, 0xa7, 0x16, 0x7d, 0x01, 0x2b, 0x78,
-	0xa1, 0xd5, 0x09, 0x53, 0xdd, 0xe2, 0x2c, 0x5d, 0x44, 0xa3, 0xbd, 0x78, 0x98, 0xaa, 0xbe, 0x82,
-	0x2a, 0x93, 0x92, 0xf5, 0x47, 0xd3, 0xca, 0x8b, 0xb3, 0x94, 0x57, 0xb4, 0x64, 0x56, 0xfd, 0x01,
-	0x94, 0x93, 0xf6, 0x1d, 0xd6, 0x59, 0xa0, 0x4f, 0x66, 0x68, 0x58, 0x69, 0xfd, 0x6f, 0x52, 0xae,
-	0x08, 0x1a, 0x47, 0xde, 0x64, 0x89, 0xd2, 0xac, 0x25, 0x88, 0x11, 0x3d, 0x8f, 0xbc, 0x74, 0x8d,
-	0x43, 0xb0, 0xb3, 0x5e, 0x99, 0x9a, 0xa4, 0x3c, 0x6b, 0x92, 0xd5, 0x89, 0xb3, 0xb2, 0xf3, 0x6c,
-	0xa9, 0xeb, 0x29, 0xfa, 0x91, 0x8b, 0x26, 0xc7, 0x36, 0xe0, 0xa2, 0x93, 0x25, 0x91, 0x1d, 0xa8,
-	0x4a, 0xd6, 0x8b, 0x3d, 0x16, 0xe9, 0x57, 0x8a, 0x49, 0x57, 0xba, 0x11, 0xb8, 0x62, 0x58, 0xf8,
-	0x4a, 0xd1, 0x39, 0xf2, 0x7f, 0xa0, 0xa2, 0x1b, 0x4f, 0x89, 0x63, 0x97, 0x71, 0x3b, 0xeb, 0x53,
-	0x68, 0x83, 0x0f, 0xdb, 0xe4, 0x89, 0x5d, 0x66, 0x99, 0x91, 0x5a, 0x8f, 0xf5, 0xc2, 0x58, 0xd2,
-	0x09, 0x66, 0xa9, 0x2b, 0x67, 0x99, 0x76, 0x9a, 0x62, 0xa5, 0x33, 0x9d, 0x47, 0x9e, 0xf2, 0x33,
-	0x06, 0xc9, 0x94, 0xab, 0x56, 0x66, 0xfa, 0x59, 0xc9, 0x65, 0x1c, 0x55, 0xff, 0x35, 0x0f, 0xf6,
-	0xc7, 0x76, 0xf5, 0xe9, 0x86, 0x6c, 0xee, 0xdf, 0x6b, 0xc8, 0xe6, 0x3f, 0xda, 0x90, 0xfd, 0x44,
-	0x9f, 0xb3, 0xf0, 0x89, 0x3e, 0xe7, 0x3f, 0x69, 0x2c, 0xcc, 0x7d, 0xba, 0xb1, 0x80, 0x9f, 0x24,
-	0x74, 0x6b, 0x74, 0x3e, 0xf9, 0x24, 0xa1, 0x3b, 0xa2, 0x9b, 0xb0, 0x38, 0xe9, 0x64, 0xea, 0x9b,
-	0x59, 0x1c, 0x24, 0x0d, 0xcc, 0x87, 0x50, 0xd1, 0xcc, 0xa4, 0x43, 0x7a, 0x5b, 0x97, 0x2e, 0x48,
-	0x34, 0x6d, 0xd1, 0xfa, 0x09, 0x2c, 0xa5, 0xa6, 0xfd, 0xf8, 0x57, 0x8b, 0xc7, 0xb0, 0x3c, 0x71,
-	0xb3, 0x7e, 0x04, 0xe7, 0xb1, 0x44, 0x5a, 0x4a, 0xc9, 0x18, 0x5a, 0xf5, 0x3f, 0xe6, 0xa0, 0x32,
-	0xf5, 0xfa, 0x24, 0x4f, 0xa1, 0x34, 0x01, 0xb9, 0xe4, 0x4b, 0x13, 0x4c, 0x9e, 0x9d, 0x0e, 0xa4,
-	0x60, 0x27, 0xc8, 0x13, 0x80, 0x74, 0xc2, 0x04, 0xa8, 0x61, 0x12, 0x91, 0x4e, 0x86, 0x4b, 0xfe,
-	0x1b, 0xac, 0xc9, 0x9e, 0xcc, 0xec, 0x3a, 0xd3, 0x2d, 0xef, 0x4c, 0x1f, 0xc9, 0x99, 0x6c, 0x5e,
-	0xaf, 0x53, 0xff, 0x7d, 0x0e, 0x6a, 0x07, 0x3a, 0xb7, 0x4d, 0xef, 0xf6, 0x25, 0x90, 0x34, 0x0d,
-	0xa6, 0xbb, 0x46, 0x53, 0x4c, 0x6d, 0x1a, 0x33, 0x97, 0x95, 0x64, 0xc7, 0xf4, 0x83, 0x4f, 0x13,
-	0x56, 0x13, 0xed, 0xe9, 0x4c, 0x9e, 0x37, 0x71, 0x9e, 0x8d, 0x62, 0x9c, 0xa3, 0x6a, 0xe4, 0xb3,
-	0x8c, 0xde, 0x02, 0x7e, 0xb8, 0x7b, 0xfe, 0x8f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x5e, 0x31, 0xea,
-	0x12, 0xf4, 0x1b, 0x00, 0x00,
+	// 3378 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9d, 0x59, 0x59, 0x73, 0x1b, 0xc7,
+	0x11, 0x36, 0x41, 0x52, 0x24, 0x07, 0x07, 0xc1, 0x01, 0x8f, 0x25, 0x29, 0xc5, 0x14, 0x64, 0xd9,
+	0xf2, 0x45, 0xdb, 0x94, 0xed, 0xf8, 0x90, 0x0f, 0x90, 0x04, 0x29, 0x58, 0x04, 0x01, 0x2f, 0x40,
+	0x57, 0x39, 0x2f, 0x5b, 0x0b, 0x60, 0x09, 0xae, 0xb9, 0xd8, 0x45, 0xf6, 0x90, 0xc4, 0xb7, 0x54,
+	0xe5, 0x07, 0xe4, 0x07, 0x24, 0x8f, 0xa9, 0xa4, 0x2a, 0x55, 0xf9, 0x2d, 0x79, 0xce, 0xbf, 0x49,
+	0x1f, 0xb3, 0x8b, 0x5d, 0x02, 0x52, 0x9c, 0x3c, 0x48, 0xc4, 0x74, 0xf7, 0xcc, 0xf4, 0xf4, 0x74,
+	0x7f, 0xdd, 0x3d, 0x2b, 0x0a, 0x7d, 0xcf, 0xbd, 0xb4, 0x87, 0xfb, 0x63, 0xdf, 0x0b, 0xbd, 0x9d,
+	0xf7, 0xc6, 0xbd, 0x8f, 0xfa, 0x51, 0x10, 0x7a, 0x23, 0xc3, 0x7a, 0x6e, 0x3a, 0x91, 0x19, 0x7a,
+	0xfe, 0x14, 0x41, 0xc9, 0xee, 0x81, 0x6c, 0x68, 0x05, 0xa1, 0x11, 0x84, 0x66, 0x18, 0x05, 0xe9,
+	0xdf, 0x2c, 0x51, 0xfd, 0x4b, 0x4e, 0x94, 0xba, 0x40, 0x3d, 0x37, 0x47, 0xd6, 0x11, 0x6d, 0x23,
+	0xbf, 0x17, 0x45, 0x17, 0x46, 0x86, 0xe5, 0x58, 0x23, 0xcb, 0x0d, 0x03, 0x6d, 0x6e, 0x6f, 0xfe,
+	0x51, 0xfe, 0x60, 0x77, 0x3f, 0x2b, 0xb7, 0x8f, 0x3f, 0xeb, 0x2c, 0xa3, 0x17, 0xdc, 0xc9, 0x20,
+	0x90, 0x6f, 0x8a, 0x3c, 0xad, 0x70, 0xe9, 0xf9, 0x23, 0x33, 0xd4, 0x72, 0x7b, 0x73, 0x8f, 0x56,
+	0x74, 0x81, 0xa4, 0x13, 0xa2, 0xec, 0xfc, 0x6d, 0x4e, 0xe4, 0x53, 0xd3, 0xe5, 0xa6, 0xb8, 0xe3,
+	0x98, 0x3d, 0xcb, 0xc1, 0xbd, 0x50, 0x56, 0x8d, 0xe4, 0x03, 0x51, 0x0c, 0x4d, 0x7f, 0x68, 0x85,
+	0x06, 0x9b, 0x40, 0x2d, 0x55, 0x60, 0xa2, 0xd2, 0xf7, 0xbe, 0x28, 0xf4, 0x22, 0xdb, 0x19, 0x18,
+	0x4c, 0xd5, 0xe6, 0x41, 0x66, 0x59, 0xcf, 0x13, 0xad, 0x4b, 0x24, 0x29, 0xc5, 0x42, 0x68, 0x0e,
+	0x03, 0x6d, 0x81, 0xa6, 0xd3, 0x6f, 0x5a, 0x1b, 0xcd, 0x01, 0x76, 0x18, 0x5b, 0x7e, 0x78, 0xa3,
+	0x2d, 0xaa, 0xb5, 0x81, 0xd8, 0x56, 0xb4, 0xea, 0x33, 0x51, 0x38, 0xf7, 0x42, 0xfb, 0xd2, 0xee,
+	0x9b, 0xa1, 0xed, 0xb9, 0x52, 0x13, 0x4b, 0x41, 0x34, 0x1a, 0x99, 0xfe, 0x8d, 0xd2, 0x34, 0x1e,
+	0xa2, 0x16, 0xa0, 0x63, 0x68, 0xbd, 0x0c, 0x0d, 0xc7, 0x76, 0xaf, 0x95, 0xa6, 0x79, 0x45, 0x3b,
+	0x03, 0x52, 0xf5, 0x1f, 0x6f, 0x89, 0x15, 0xb4, 0xe1, 0xa9, 0xef, 0x45, 0x63, 0xd4, 0x09, 0x2d,
+	0xa2, 0xd6, 0xa1, 0xdf, 0x72, 0x5d, 0x2c, 0xfe, 0x3e, 0xb2, 0x60, 0x71, 0x9e, 0xcd, 0x03, 0xf9,
+	0xb6, 0x58, 0x1d, 0x98, 0x37, 0x81, 0xe1, 0x5d, 0x1a, 0xbe, 0x15, 0x44, 0x0e, 0x5c, 0x09, 0x9e,
+	0x71, 0x51, 0x2f, 0x22, 0xb9, 0x75, 0xa9, 0x33, 0x51, 0x3e, 0x14, 0x25, 0x7b, 0xe8, 0x7a, 0xbe,
+	0x65, 0x8c, 0x2d, 0x77, 0x60, 0xbb, 0x43, 0x3a, 0xef, 0xb2, 0x5e, 0x64, 0x6a, 0x9b, 0x89, 0xa8,
+	0xa9, 0x12, 0x43, 0x13, 0x85, 0x74, 0x6e, 0xb0, 0x17, 0xd3, 0x0e, 0x91, 0x04, 0x2e, 0xb0, 0x86,
+	0x66, 0x08, 0x0c, 0xba, 0xc6, 0xb1, 0xe7, 0xd8, 0xfd, 0x1b, 0xed, 0x0e, 0xc8, 0x95, 0x0e, 0xd6,
+	0xf7, 0x93, 0x23, 0xd0, 0xaf, 0x00, 0xef, 0x51, 0x5f, 0x0d, 0xe3, 0x9f, 0x6d, 0x12, 0x96, 0x5f,
+	0x88, 0xcd, 0xa1, 0x19, 0x5e, 0x59, 0xbe, 0x91, 0x36, 0xb2, 0x6d, 0x05, 0xda, 0x12, 0x6e, 0x77,
+	0x98, 0xd3, 0xe6, 0xf4, 0x75, 0x96, 0xe8, 0x4e, 0x0c, 0x0e, 0x7c, 0x79, 0x20, 0x36, 0x94, 0x7a,
+	0xec, 0xad, 0x51, 0x2f, 0x08, 0x7d, 0x3c, 0xcc, 0x32, 0xb8, 0xe1, 0x8a, 0x5e, 0x61, 0x26, 0x4e,
+	0xea, 0xc4, 0x2c, 0xf9, 0x44, 0x14, 0xfb, 0x9e, 0x13, 0x8d, 0x5c, 0xe3, 0xca, 0x32, 0x07, 0x96,
+	0xaf, 0xad, 0x90, 0xcb, 0x6e, 0xa5, 0x74, 0x3d, 0x22, 0xfe, 0x53, 0x62, 0xeb, 0x85, 0x7e, 0x6a,
+	0x24, 0x9f, 0x8a, 0xb5, 0x4b, 0xd3, 0x71, 0x7a, 0x66, 0xff, 0xda, 0x18, 0xa2, 0x30, 0xee, 0x26,
+	0xe8, 0xb4, 0xbb, 0xa9, 0x15, 0x4e, 0x94, 0xcc, 0xa9, 0x12, 0xd1, 0xcb, 0x97, 0xb7, 0x28, 0xf2,
+	0x4b, 0xb1, 0x6d, 0x3a, 0x70, 0x0e, 0x8c, 0x31, 0xc7, 0x8a, 0x6f, 0xcb, 0xb8, 0xf2, 0x22, 0x3f,
+	0xd0, 0xf2, 0x74, 0x67, 0x9b, 0x24, 0xd0, 0x41, 0xbe, 0xba, 0xb7, 0xa7, 0xc8, 0x95, 0x9f, 0x88,
+	0x0d, 0x37, 0x1a, 0x19, 0x97, 0xa6, 0xed, 0x44, 0x30, 0xcf, 0x08, 0x3d, 0x83, 0x24, 0xb5, 0x02,
+	0x4d, 0x93, 0xc0, 0x3c, 0x51, 0xbc, 0xae, 0x57, 0x43, 0x0e, 0x7a, 0x70, 0x2f, 0x1a, 0x42, 0x68,
+	0x8c, 0xc6, 0x9e, 0x0b, 0x61, 0xa4, 0x15, 0x49, 0x14, 0xa2, 0x61, 0x78, 0x14, 0xd3, 0xe4, 0x23,
+	0x51, 0xee, 0x7b, 0x03, 0xcb, 0x08, 0x2c, 0xd3, 0xef, 0x5f, 0x19, 0x63, 0x30, 0xb9, 0x56, 0x22,
+	0xef, 0x2a, 0x21, 0xbd, 0x43, 0xe4, 0x36, 0x50, 0xe5, 0x07, 0x02, 0x37, 0x31, 0xd8, 0x34, 0x01,
+	0x28, 0xdf, 0xc7, 0x35, 0x57, 0x69, 0xcd, 0x32, 0x70, 0xd8, 0x82, 0x81, 0x4e, 0x74, 0xf9, 0x9e,
+	0x58, 0x8b, 0x02, 0x75, 0x47, 0x23, 0x2b, 0x34, 0x07, 0x66, 0x68, 0x6a, 0x65, 0x72, 0xa5, 0x55,
+	0x60, 0xa0, 0xd9, 0x9a, 0x8a, 0x2c, 0x3f, 0x13, 0x5b, 0x6c, 0x96, 0x11, 0x9c, 0x80, 0x4e, 0x36,
+	0x18, 0xc0, 0x39, 0x02, 0xf0, 0x86, 0x35, 0x52, 0x65, 0x9d, 0xd8, 0x4d, 0xe0, 0xc2, 0xd9, 0x62,
+	0x1e, 0x2a, 0x94, 0x9a, 0x06, 0x8e, 0xf0, 0x8b, 0xd5, 0x0f, 0x35, 0x49, 0x33, 0xca, 0xc9, 0x8c,
+	0x0e, 0xd3, 0xe5, 0xd7, 0x62, 0x27, 0x25, 0xad, 0xec, 0x08, 0xaa, 0x05, 0x81, 0x39, 0xb4, 0xb4,
+	0x0a, 0xcd, 0xda, 0x4a, 0x66, 0x29, 0x5b, 0x36, 0x99, 0x2d, 0x3f, 0x12, 0xeb, 0xa9, 0xc9, 0x03,
+	0x0b, 0xed, 0x1a, 0xf9, 0x8e, 0xb6, 0x4e, 0xd3, 0xd6, 0x92, 0x69, 0xc7, 0xc8, 0xb9, 0xf0, 0x1d,
+	0xf0, 0x99, 0xfb, 0x23, 0xdb, 0x05, 0x8c, 0x34, 0xc7, 0x81, 0x35, 0x30, 0xe0, 0x77, 0x04, 0xa6,
+	0x30, 0x7a, 0x56, 0xf8, 0xc2, 0xb2, 0x5c, 0x5a, 0x26, 0xd0, 0x36, 0xc8, 0x76, 0xf7, 0x80, 0x59,
+	0x67, 0xb9, 0x26, 0x8b, 0x1d, 0xb2, 0x14, 0x2e, 0x18, 0xc8, 0x0b, 0xf1, 0x08, 0x0d, 0xc9, 0x00,
+	0x17, 0xf9, 0x84, 0x33, 0x06, 0xe2, 0x38, 0x2c, 0x67, 0x06, 0xec, 0x04, 0x70, 0x6d, 0xbe, 0x39,
+	0x0a, 0xb4, 0x4d, 0xb2, 0xef, 0x03, 0x90, 0x3f, 0x4a, 0x8b, 0xff, 0x44, 0xd2, 0xb5, 0x80, 0xdc,
+	0xa2, 0x4d, 0xa2, 0x72, 0x5f, 0x54, 0x2c, 0xd7, 0xec, 0x81, 0x17, 0x5e, 0x3a, 0xe6, 0xf5, 0x8d,
+	0x42, 0x7d, 0x6d, 0x8b, 0x56, 0x58, 0x63, 0xd6, 0x09, 0x72, 0x3a, 0xc4, 0xc0, 0xb0, 0x43, 0x35,
+	0xae, 0xa3, 0x9e, 0xe5, 0xbb, 0x16, 0x9e, 0xa5, 0xef, 0xd8, 0xe8, 0x00, 0x1a, 0xcd, 0xa8, 0x00,
+	0xf3, 0x59, 0xc2, 0x3b, 0x22, 0x16, 0xe2, 0xbc, 0x1d, 0x18, 0x00, 0x6f, 0x40, 0x36, 0x1d, 0x6d,
+	0x9b, 0x24, 0x85, 0x1d, 0xd4, 0x15, 0x05, 0xe2, 0xa1, 0x4c, 0x0e, 0x42, 0x30, 0xa2, 0x20, 0x7c,
+	0x07, 0xa4, 0xf2, 0x07, 0xab, 0xb7, 0xb2, 0x89, 0x5e, 0x0a, 0xb3, 0x59, 0xe8, 0x31, 0x64, 0xa1,
+	0x14, 0xf2, 0x06, 0xda, 0x2e, 0x85, 0x74, 0x71, 0x3f, 0x8d, 0xc7, 0x7a, 0x56, 0x46, 0x7e, 0x23,
+	0x4a, 0x0a, 0x07, 0x02, 0x0f, 0xac, 0xd6, 0xbb, 0xd1, 0xee, 0x52, 0x18, 0x4f, 0x03, 0x41, 0x07,
+	0xf8, 0x87, 0x37, 0x31, 0x10, 0xf0, 0x48, 0xd6, 0x45, 0x79, 0xec, 0xdb, 0x08, 0xe7, 0x13, 0x1c,
+	0xb8, 0x47, 0x0b, 0xec, 0xa4, 0x16, 0x68, 0xb3, 0x48, 0x02, 0x03, 0xab, 0xe3, 0x2c, 0x21, 0x65,
+	0xfa, 0x38, 0x3a, 0xae, 0xbc, 0x41, 0xa0, 0xfd, 0x26, 0x6d, 0x7a, 0x15, 0x1f, 0xc8, 0x90, 0xc7,
+	0xca, 0x4a, 0xa6, 0x0b, 0xa7, 0x51, 0xa7, 0x7d, 0x93, 0x4e, 0xbb, 0x7d, 0x0b, 0x6c, 0x6b, 0x89,
+	0x04, 0x23, 0xee, 0x64, 0x1c, 0x00, 0xe2, 0x6e, 0x8f, 0xcc, 0x97, 0x99, 0x2d, 0x21, 0x0f, 0x30,
+	0xfe, 0x6a, 0x7b, 0xe4, 0x89, 0x1b, 0x20, 0x90, 0xda, 0xb8, 0xcd, 0xd8, 0x2b, 0x6b, 0xe2, 0x1e,
+	0x60, 0xc8, 0xc8, 0x0e, 0x0d, 0xef, 0xb9, 0xe5, 0xfb, 0x36, 0xa0, 0x05, 0xe5, 0x5f, 0x04, 0x0b,
+	0xbc, 0x48, 0xed, 0x3e, 0x45, 0xc1, 0x0e, 0x0b, 0xb5, 0x94, 0xcc, 0x19, 0x8a, 0xb4, 0x59, 0x02,
+	0xc2, 0x61, 0x23, 0x83, 0x04, 0x86, 0x37, 0xe6, 0x73, 0x54, 0xe9, 0x1c, 0x9c, 0x34, 0x62, 0x3c,
+	0x68, 0x31, 0x4f, 0xaf, 0x84, 0xd3, 0x44, 0xc4, 0x2b, 0x5a, 0x09, 0x72, 0x74, 0xb2, 0xff, 0x03,
+	0xc6, 0x2b, 0xa4, 0x77, 0xcd, 0x61, 0xbc, 0x27, 0x38, 0x97, 0x19, 0x01, 0x98, 0x60, 0xac, 0xc6,
+	0xdb, 0xbd, 0xa5, 0x9c, 0xab, 0x06, 0x8c, 0xc3, 0x68, 0x18, 0xef, 0x54, 0x32, 0x33, 0x63, 0x70,
+	0xae, 0xcd, 0xc4, 0x56, 0x7e, 0xe4, 0x86, 0x36, 0xb8, 0x27, 0x83, 0xf4, 0x43, 0x32, 0x54, 0x45,
+	0x19, 0x4a, 0x67, 0x1e, 0x23, 0xf4, 0x13, 0xb1, 0x8b, 0xf8, 0x38, 0x36, 0x11, 0x9c, 0x10, 0xc5,
+	0x06, 0x76, 0x40, 0xb7, 0xcc, 0x38, 0xfd, 0x36, 0xcd, 0xdc, 0x02, 0x91, 0x36, 0x49, 0x74, 0xbd,
+	0x63, 0xe6, 0x33, 0x58, 0xbf, 0x2f, 0x24, 0xd6, 0x05, 0xa8, 0x2d, 0xc0, 0x84, 0x72, 0x30, 0xed,
+	0x1d, 0x06, 0x4c, 0xe4, 0x80, 0x7a, 0xc1, 0x21, 0x3b, 0x91, 0x6c, 0x88, 0x75, 0xcb, 0x7d, 0x6e,
+	0xfb, 0x9e, 0x8b, 0xe5, 0x91, 0x61, 0xbb, 0x10, 0xbd, 0x6e, 0xdf, 0xd2, 0x1e, 0x91, 0x33, 0x6e,
+	0xa6, 0xbc, 0xa2, 0x3e, 0x11, 0xd3, 0x2b, 0xa9, 0x39, 0x0d, 0x35, 0x05, 0x96, 0xda, 0x4c, 0xb9,
+	0x44, 0x3a, 0x11, 0xbf, 0x4b, 0x57, 0x53, 0x49, 0x2d, 0xf6, 0xcc, 0xba, 0x21, 0x28, 0xd1, 0xd7,
+	0xc3, 0xc4, 0x4b, 0x52, 0x99, 0x19, 0xc2, 0x5d, 0xe5, 0x74, 0x3c, 0x84, 0xf6, 0x1e, 0x87, 0x3b,
+	0x93, 0x50, 0x7b, 0xcc, 0x09, 0xc1, 0x15, 0x06, 0x1e, 0x95, 0x41, 0xb0, 0xa3, 0x6f, 0xf7, 0xb5,
+	0xf7, 0xe9, 0xf2, 0x56, 0x89, 0xd1, 0x05, 0x7a, 0x93, 0xc8, 0xb2, 0x29, 0x1e, 0xdc, 0x76, 0xba,
+	0x19, 0x10, 0xa8, 0x7d, 0x40, 0xb3, 0xf7, 0xb2, 0xae, 0x37, 0x0d, 0x7e, 0xe8, 0xfd, 0x19, 0xf3,
+	0x66, 0x22, 0xef, 0x43, 0xd2, 0x74, 0x63, 0x62, 0xe5, 0x74, 0xf4, 0x41, 0x72, 0x4a, 0x1b, 0x08,
+	0xca, 0x53, 0x48, 0x93, 0xbe, 0x35, 0xb4, 0x5e, 0x6a, 0xfb, 0x9c, 0x9c, 0x26, 0xc6, 0x68, 0x22,
+	0x53, 0x47, 0x1e, 0xe6, 0x6b, 0xc4, 0xcb, 0xcb, 0xc8, 0x71, 0xe2, 0xa9, 0x88, 0x72, 0x81, 0xf6,
+	0x11, 0x6d, 0x26, 0x81, 0x79, 0x02, 0x3c, 0x9e, 0x87, 0xb8, 0x16, 0x00, 0xbc, 0xdc, 0x53, 0x75,
+	0x3a, 0x17, 0x06, 0x93, 0x72, 0x1d, 0x9c, 0xd0, 0x81, 0xa9, 0x1f, 0x63, 0x85, 0x43, 0xa5, 0xd1,
+	0x0e, 0x0b, 0x72, 0x85, 0x50, 0x8f, 0xc5, 0x74, 0x94, 0x92, 0x3f, 0x8a, 0x87, 0x53, 0xe5, 0xca,
+	0x4c, 0xdb, 0x7d, 0x42, 0xea, 0x57, 0x6f, 0x57, 0x29, 0x33, 0xac, 0x07, 0xf5, 0x93, 0x52, 0x29,
+	0x00, 0x57, 0x07, 0x47, 0x3b, 0xa0, 0x38, 0x4a, 0xc3, 0x26, 0xab, 0xd2, 0x21, 0xb6, 0x5e, 0xf0,
+	0x53, 0x23, 0x79, 0x24, 0xb6, 0x6f, 0xf7, 0x1f, 0x74, 0x20, 0xa8, 0x39, 0x42, 0xed, 0x31, 0xad,
+	0xb4, 0xbc, 0x8f, 0xba, 0x77, 0xac, 0x50, 0xdf, 0x64, 0xd1, 0xcc, 0x99, 0x80, 0x8e, 0xd7, 0xe0,
+	0x43, 0x39, 0x46, 0x79, 0x0a, 0xcc, 0xea, 0xc3, 0x6a, 0x20, 0xe7, 0x63, 0xee, 0xfe, 0x94, 0x2c,
+	0xba, 0x8e, 0x6c, 0x4c, 0x56, 0xd6, 0x09, 0x30, 0x3b, 0xcc, 0xc3, 0x1a, 0x41, 0x55, 0x8b, 0x1e,
+	0x74, 0x00, 0x71, 0x79, 0xfc, 0x19, 0xcd, 0x28, 0x33, 0xa7, 0xe5, 0x0c, 0xe2, 0x0a, 0x19, 0x13,
+	0x16, 0x4b, 0x07, 0xd7, 0xf6, 0x58, 0xfb, 0x5c, 0x25, 0x2c, 0x22, 0x75, 0x80, 0x82, 0xcb, 0x21,
+	0x30, 0xa8, 0xaa, 0xc1, 0x70, 0x2c, 0x77, 0x08, 0xf5, 0xd2, 0x6f, 0xb9, 0x06, 0x02, 0x8e, 0xaa,
+	0x17, 0xce, 0x88, 0x2e, 0x3f, 0x17, 0x5b, 0x3d, 0xdf, 0xbb, 0x86, 0x7c, 0xaf, 0xb2, 0x4e, 0x78,
+	0x05, 0x1a, 0x5c, 0x81, 0x26, 0xda, 0x17, 0x30, 0x25, 0xa7, 0x6f, 0x30, 0x9b, 0x53, 0x4e, 0x37,
+	0x66, 0xca, 0x77, 0xc4, 0xea, 0xa5, 0xe7, 0x38, 0xde, 0x0b, 0x23, 0xb8, 0x19, 0xa1, 0x57, 0x06,
+	0xda, 0x97, 0xa4, 0x4a, 0x89, 0xc9, 0x1d, 0x45, 0x05, 0x88, 0x53, 0x09, 0xca, 0xf0, 0x7c, 0x2c,
+	0x6b, 0xbf, 0x9a, 0x8a, 0x7f, 0x5e, 0xba, 0x85, 0x5c, 0x6c, 0x36, 0x92, 0x81, 0xfc, 0x56, 0xdc,
+	0xb5, 0x5e, 0xf6, 0x9d, 0x68, 0xc0, 0x67, 0x0d, 0xd8, 0xa4, 0x08, 0x5e, 0x06, 0xdc, 0xbc, 0xa5,
+	0x7d, 0x4d, 0x1b, 0x6a, 0x4a, 0x06, 0x0f, 0x1f, 0xa0, 0x5d, 0x11, 0xbb, 0x74, 0xe0, 0x63, 0x2c,
+	0x5f, 0xd9, 0x6a, 0xf2, 0x18, 0x2a, 0x1c, 0xdf, 0x7b, 0x11, 0x68, 0x4f, 0x18, 0xae, 0x90, 0xd1,
+	0x61, 0xba, 0x0e, 0x64, 0xf9, 0x9d, 0x28, 0x71, 0x79, 0x01, 0xd1, 0x33, 0xa6, 0xac, 0xf9, 0x0d,
+	0xdd, 0xba, 0x96, 0x52, 0x94, 0xcb, 0x8c, 0x26, 0xf3, 0xf5, 0x62, 0x90, 0x1e, 0xca, 0x6d, 0xb1,
+	0x8c, 0x66, 0xa7, 0x3d, 0xbe, 0x25, 0x63, 0x2f, 0xc1, 0x98, 0xd6, 0xfe, 0x50, 0x48, 0x65, 0x82,
+	0x74, 0x7a, 0xfc, 0x8e, 0x7a, 0x81, 0x35, 0xe6, 0xa4, 0xb3, 0x20, 0x5c, 0x09, 0x14, 0x97, 0x36,
+	0x0e, 0x4c, 0xc7, 0x18, 0xf6, 0x21, 0x07, 0xfa, 0xd6, 0xa5, 0xfd, 0x12, 0xa2, 0xeb, 0x7b, 0x9a,
+	0xb3, 0x31, 0x61, 0x9f, 0xf6, 0x83, 0xb6, 0x62, 0x52, 0x46, 0xb0, 0x5d, 0x7b, 0x04, 0x00, 0x0f,
+	0x08, 0x0e, 0x75, 0x0e, 0x75, 0x5b, 0x11, 0x6c, 0x55, 0x53, 0x19, 0x81, 0xb9, 0xe7, 0xc4, 0x84,
+	0x9e, 0x0b, 0x58, 0x3b, 0x7f, 0x9a, 0x13, 0x85, 0x74, 0x5f, 0x01, 0x7d, 0xec, 0x22, 0x65, 0x4e,
+	0x6e, 0xea, 0x9e, 0xbe, 0xa1, 0xf3, 0x50, 0xde, 0x15, 0xcb, 0x49, 0x9b, 0x99, 0x53, 0xac, 0x84,
+	0x02, 0x50, 0x52, 0x99, 0x15, 0xbe, 0xf3, 0x4a, 0x50, 0xf6, 0xa7, 0x02, 0xf6, 0x70, 0x53, 0xac,
+	0x67, 0x1a, 0x1e, 0x15, 0xb7, 0x3b, 0x01, 0x77, 0xf3, 0x13, 0x8b, 0xc8, 0x7b, 0x42, 0x4c, 0x30,
+	0x59, 0x35, 0x9b, 0x2b, 0x09, 0x18, 0x43, 0xcf, 0x58, 0x8c, 0xf5, 0x20, 0xfc, 0x4a, 0xd4, 0x2b,
+	0xc4, 0x64, 0xc4, 0xae, 0xc3, 0x5d, 0xb1, 0x9d, 0x41, 0x76, 0x0e, 0x0f, 0xb5, 0xe9, 0x81, 0x58,
+	0x8e, 0x33, 0x87, 0x2c, 0x8b, 0xf9, 0x6b, 0x2b, 0x6e, 0x8e, 0xf1, 0x27, 0xf6, 0xb4, 0x7c, 0x1e,
+	0xd5, 0xd3, 0xd2, 0x60, 0xc7, 0x12, 0x85, 0x34, 0xa2, 0x80, 0x0d, 0x0a, 0xbf, 0x44, 0xae, 0x9d,
+	0x69, 0xf4, 0xf3, 0x07, 0x85, 0xfd, 0x1f, 0x2e, 0x80, 0xc8, 0x88, 0x05, 0x4a, 0xe5, 0x49, 0x86,
+	0x87, 0x68, 0x83, 0x0c, 0x68, 0xa9, 0xa9, 0x3f, 0x2c, 0x2c, 0xcf, 0x95, 0x73, 0xf0, 0xff, 0x7c,
+	0x79, 0x61, 0xe7, 0x0f, 0x73, 0xa2, 0x98, 0xf1, 0x3c, 0x38, 0xf0, 0x92, 0xea, 0x0d, 0x48, 0xc9,
+	0xd2, 0x41, 0x9e, 0x9c, 0x94, 0x85, 0xf4, 0x98, 0x07, 0x4d, 0xf2, 0x22, 0xe4, 0x1a, 0xcf, 0x27,
+	0x45, 0x6e, 0x09, 0x31, 0x07, 0x57, 0x52, 0xc1, 0x41, 0x57, 0x75, 0x7b, 0x25, 0xc5, 0xab, 0x8e,
+	0xb8, 0xe9, 0xa7, 0xe6, 0x58, 0xee, 0x88, 0xcd, 0x6e, 0xbd, 0xd3, 0xed, 0x18, 0xe7, 0xb5, 0x66,
+	0xdd, 0xb8, 0x38, 0xef, 0xb4, 0xeb, 0x47, 0x8d, 0x93, 0x46, 0xfd, 0xb8, 0xfc, 0x86, 0xdc, 0x10,
+	0x6b, 0x29, 0x5e, 0xe3, 0xf4, 0xbc, 0xa5, 0xd7, 0xcb, 0x73, 0xe0, 0x53, 0x32, 0x45, 0xd6, 0xeb,
+	0xed, 0xb3, 0xda, 0x51, 0xbd, 0x9c, 0xbb, 0x25, 0x5e, 0x6b, 0xb7, 0xeb, 0xe7, 0xc7, 0xe5, 0xf9,
+	0xea, 0xbf, 0xe6, 0x44, 0xf9, 0x76, 0xa7, 0x8a, 0xdb, 0x9e, 0xd4, 0xce, 0xce, 0x0e, 0x6b, 0x47,
+	0xcf, 0x8c, 0x53, 0xbd, 0x75, 0xd1, 0x6e, 0x9c, 0x9f, 0x1a, 0xe7, 0xad, 0xf3, 0x3a, 0x6c, 0x3b,
+	0x93, 0x77, 0x5c, 0xeb, 0xe2, 0xde, 0x77, 0x85, 0x36, 0xcd, 0x3b, 0xab, 0x1d, 0xd6, 0xcf, 0x3a,
+	0xa0, 0x81, 0x26, 0xd6, 0xa7, 0xb9, 0x0d, 0x50, 0x42, 0xee, 0x89, 0xbb, 0xd3, 0x9c, 0xa3, 0x56,
+	0xb3, 0xd9, 0xe8, 0x1a, 0xe7, 0x17, 0xcd, 0xf2, 0x82, 0x7c, 0x57, 0x3c, 0x9c, 0x25, 0x71, 0x7e,
+	0xd2, 0x38, 0xbd, 0xd0, 0x6b, 0xdd, 0x46, 0xeb, 0xdc, 0xf8, 0xa9, 0x76, 0x76, 0x51, 0x2f, 0x2f,
+	0x56, 0xbf, 0x8f, 0x83, 0x4c, 0x55, 0xe9, 0xeb, 0xa2, 0x7c, 0xd4, 0x3a, 0xbb, 0x68, 0x9e, 0x1b,
+	0x9d, 0x96, 0xde, 0x65, 0x55, 0xe9, 0x18, 0x69, 0x6a, 0x6a, 0xb3, 0xb9, 0x6a, 0x53, 0xac, 0xde,
+	0x2a, 0xda, 0x01, 0x71, 0x36, 0xda, 0x7a, 0xa3, 0x59, 0xd3, 0x7f, 0x9e, 0x32, 0xc8, 0x9b, 0x62,
+	0x77, 0x8a, 0x95, 0x59, 0x0e, 0xb2, 0x48, 0xaa, 0xec, 0x92, 0xcb, 0x62, 0xa1, 0xad, 0xb7, 0xf0,
+	0x06, 0xef, 0x88, 0xdc, 0x8f, 0x35, 0x10, 0x38, 0x14, 0xf9, 0x14, 0x2e, 0xe3, 0x5e, 0x4a, 0xb5,
+	0x96, 0x7e, 0x5c, 0xd7, 0x8d, 0xc3, 0x8b, 0xc6, 0xd9, 0x31, 0x1a, 0xea, 0x0d, 0x34, 0x61, 0x86,
+	0xd5, 0xe9, 0xd6, 0xf4, 0x2e, 0x78, 0xc3, 0x5c, 0xb5, 0x28, 0xf2, 0x29, 0xdf, 0xaf, 0xfe, 0x73,
+	0x4e, 0x54, 0x66, 0xd4, 0xd0, 0xf8, 0x36, 0x34, 0xe9, 0xb0, 0xb8, 0x6a, 0xe1, 0xd8, 0x2b, 0xc6,
+	0xfd, 0x14, 0x97, 0x2b, 0x53, 0x6f, 0x05, 0xb9, 0x19, 0x6f, 0x05, 0x10, 0xaa, 0xde, 0x0b, 0x17,
+	0xf2, 0xcc, 0x3c, 0x87, 0x2a, 0x0d, 0x64, 0x49, 0xe4, 0xfa, 0x7d, 0x6d, 0x81, 0xd0, 0x13, 0x7e,
+	0xe1, 0x52, 0x31, 0x00, 0xf0, 0x86, 0xea, 0xe1, 0x4c, 0x11, 0x69, 0xbf, 0xea, 0xbf, 0xe7, 0x45,
+	0x29, 0x5b, 0x84, 0x23, 0x12, 0x51, 0xbd, 0xde, 0x77, 0xbc, 0x80, 0x83, 0x6f, 0x59, 0x5f, 0x41,
+	0xca, 0x11, 0x12, 0x30, 0x37, 0x5f, 0x79, 0xa1, 0x63, 0xc3, 0x61, 0x6c, 0xa8, 0xd9, 0x72, 0xb0,
+	0xdf, 0xbc, 0x2e, 0x14, 0xa9, 0x01, 0x85, 0xda, 0xa7, 0x08, 0xa2, 0xb6, 0xe7, 0xdb, 0x00, 0xa2,
+	0x1c, 0x70, 0xda, 0xad, 0x3a, 0x1f, 0x5b, 0x33, 0xe2, 0xeb, 0x89, 0xa4, 0x7c, 0x26, 0xb6, 0x52,
+	0xcb, 0xaa, 0xc2, 0x82, 0x8b, 0x9c, 0x05, 0xd5, 0x9b, 0x3c, 0x8d, 0xf7, 0xa0, 0xc2, 0x82, 0x2b,
+	0x9c, 0xf5, 0xc9, 0xc6, 0x13, 0x2a, 0x25, 0x6e, 0x1b, 0x0a, 0x1b, 0xdb, 0x1d, 0xd8, 0xcf, 0xed,
+	0x41, 0x04, 0x4d, 0xef, 0xa2, 0x4a, 0xdc, 0x40, 0x6e, 0x24, 0x54, 0xa8, 0xf6, 0xd7, 0x02, 0x70,
+	0x33, 0xc7, 0x0a, 0x01, 0xce, 0xf1, 0x8c, 0x60, 0x67, 0x7a, 0x40, 0x83, 0xaa, 0x24, 0x61, 0xd4,
+	0x98, 0x0e, 0x5d, 0xeb, 0x2e, 0x66, 0x3f, 0x13, 0x53, 0x3f, 0x64, 0xda, 0xc9, 0xe2, 0x5c, 0x67,
+	0x2f, 0xd1, 0x4d, 0x69, 0x20, 0x52, 0x63, 0x89, 0xc9, 0x3e, 0x54, 0x75, 0xdf, 0x17, 0x05, 0x52,
+	0x0a, 0xeb, 0x68, 0x58, 0x43, 0x5b, 0xe6, 0xf7, 0x3c, 0xa4, 0xb5, 0x98, 0x54, 0x3d, 0x13, 0xcb,
+	0xb1, 0x69, 0xd0, 0xe5, 0xc0, 0xbd, 0x5b, 0x7a, 0xa3, 0xfb, 0xf3, 0x2d, 0x00, 0x02, 0xf7, 0x6d,
+	0x7f, 0x0c, 0x51, 0x8f, 0x7f, 0x3f, 0x81, 0xf8, 0xc6, 0xbf, 0x07, 0x10, 0xcd, 0xf8, 0xf7, 0x31,
+	0xc4, 0x2c, 0xfe, 0xfd, 0x14, 0x02, 0xf2, 0x77, 0xa2, 0x32, 0xc3, 0x64, 0x98, 0xfc, 0x18, 0xe8,
+	0xf1, 0x6a, 0xe7, 0x31, 0xf9, 0xd1, 0x70, 0x92, 0x14, 0x73, 0x99, 0xa4, 0x78, 0x58, 0x81, 0x0a,
+	0x23, 0xb9, 0x19, 0x75, 0x27, 0xd5, 0x3f, 0xce, 0x8b, 0x95, 0x63, 0x33, 0xb8, 0xea, 0x79, 0xa6,
+	0x3f, 0x90, 0x07, 0xa2, 0x38, 0x88, 0x07, 0xd0, 0x11, 0xf6, 0xd4, 0x53, 0x74, 0x71, 0x3f, 0x11,
+	0xe9, 0x9a, 0x3d, 0xbd, 0x30, 0x48, 0x8d, 0x92, 0x77, 0xd5, 0x5c, 0xea, 0x5d, 0x75, 0xea, 0x31,
+	0x61, 0xfe, 0x57, 0x3c, 0x26, 0x80, 0x43, 0x0e, 0xac, 0x4b, 0x13, 0x13, 0x0c, 0x6e, 0xcd, 0x5e,
+	0x2e, 0x14, 0x09, 0x77, 0x3a, 0x10, 0x1b, 0x03, 0x08, 0x91, 0xb1, 0x63, 0xde, 0xd0, 0x7b, 0x13,
+	0xd6, 0xe1, 0x20, 0x19, 0xa8, 0x1b, 0xa8, 0xc4, 0xcc, 0x13, 0xe6, 0xc1, 0x14, 0xec, 0xd2, 0x37,
+	0xaf, 0xec, 0xe1, 0x95, 0x03, 0xff, 0xc2, 0xec, 0xa4, 0x3b, 0x93, 0x77, 0xd1, 0x44, 0x22, 0x3d,
+	0x13, 0x7c, 0x6f, 0x32, 0x33, 0xf4, 0x06, 0xe6, 0x0d, 0x3f, 0xa5, 0xea, 0xa5, 0x84, 0xdc, 0x45,
+	0x2a, 0x3e, 0xa6, 0x8f, 0x4d, 0x68, 0x3f, 0x06, 0xda, 0x0a, 0xf1, 0xd5, 0x08, 0xe3, 0x96, 0x7f,
+	0x41, 0xd8, 0x9a, 0x81, 0xe7, 0xd2, 0x13, 0x27, 0xc4, 0x2d, 0x13, 0x75, 0xa2, 0x41, 0xda, 0x5c,
+	0x80, 0x1b, 0x6e, 0x8b, 0x02, 0xbe, 0x58, 0x77, 0xad, 0x11, 0xe8, 0x1f, 0x52, 0x56, 0xc7, 0xd7,
+	0x30, 0x95, 0xd5, 0xe1, 0xa7, 0xdc, 0x17, 0x4b, 0x71, 0xcf, 0x9d, 0x53, 0x61, 0x84, 0x33, 0x54,
+	0x20, 0xc6, 0x13, 0xf5, 0x58, 0xa8, 0xfa, 0x8d, 0xa8, 0xcc, 0xe0, 0xff, 0xda, 0x72, 0xa1, 0xfa,
+	0xf7, 0x25, 0x51, 0x38, 0x9e, 0x75, 0xcb, 0xe9, 0xd7, 0xf3, 0x18, 0x0b, 0xa9, 0x29, 0x4a, 0x55,
+	0x33, 0x8c, 0x85, 0x04, 0xfd, 0x94, 0x84, 0xa7, 0xb0, 0x70, 0xfe, 0x57, 0xbe, 0x9b, 0x2e, 0xfc,
+	0x0f, 0xef, 0xa6, 0x8b, 0xaf, 0x78, 0x37, 0xc5, 0xaf, 0x15, 0x26, 0x34, 0x8e, 0xb1, 0xf5, 0xee,
+	0xf0, 0x77, 0x02, 0xa4, 0xc5, 0x40, 0xf9, 0xb5, 0x90, 0x50, 0x7a, 0xb9, 0xdc, 0xc3, 0x86, 0xca,
+	0x54, 0x74, 0xd9, 0xe8, 0xb2, 0xe9, 0x8b, 0xd1, 0xcb, 0x28, 0x88, 0x79, 0x21, 0xb1, 0xe8, 0x97,
+	0x62, 0x8d, 0xd0, 0x00, 0x4f, 0x98, 0xcc, 0x5d, 0x9e, 0x35, 0x97, 0xa0, 0x0c, 0x10, 0x24, 0x99,
+	0x0a, 0x77, 0x64, 0x86, 0xa1, 0x09, 0xa7, 0xcd, 0x4c, 0x5e, 0x99, 0x35, 0x79, 0x8d, 0x25, 0xd3,
+	0xd3, 0xe1, 0x64, 0xf1, 0x83, 0x37, 0xd5, 0x9a, 0xec, 0x5e, 0x79, 0x45, 0xa3, 0x6a, 0xf3, 0xbb,
+	0xb8, 0x64, 0x0b, 0xf0, 0x75, 0x75, 0xb2, 0x45, 0x7e, 0xd6, 0x16, 0x52, 0x89, 0x5e, 0xf8, 0x4e,
+	0xb2, 0xc7, 0x89, 0xd0, 0xd2, 0xb7, 0x92, 0x59, 0xa4, 0x30, 0x6b, 0x91, 0x8d, 0xc9, 0x65, 0xa5,
+	0xd7, 0xd9, 0xc3, 0xd8, 0x0e, 0xfa, 0xbe, 0x4d, 0x26, 0xa7, 0x87, 0x73, 0x50, 0x35, 0x45, 0xc2,
+	0x47, 0x3c, 0x08, 0xcb, 0xc8, 0x31, 0x7d, 0xee, 0xeb, 0x55, 0xae, 0xe3, 0xa7, 0xf3, 0x35, 0xc5,
+	0xa2, 0xbe, 0x9e, 0x13, 0xec, 0xb7, 0xa2, 0xc8, 0x4f, 0xb5, 0xf1, 0xc5, 0xae, 0x92, 0x3a, 0xdb,
+	0x19, 0xa8, 0xa2, 0xa7, 0xa0, 0xf8, 0x51, 0xaa, 0x60, 0xa6, 0x46, 0xb8, 0x9f, 0xd9, 0xf3, 0xa2,
+	0xd0, 0x98, 0x00, 0x1e, 0x86, 0x5c, 0x59, 0x3d, 0x40, 0x23, 0x2b, 0x59, 0x09, 0x1f, 0xa0, 0xe1,
+	0x9e, 0xc9, 0x49, 0x32, 0x57, 0xb5, 0x36, 0xf3, 0x9e, 0x51, 0x2e, 0x7d, 0x51, 0x10, 0x27, 0xf4,
+	0xa9, 0x81, 0xde, 0x85, 0xe9, 0xc3, 0x0d, 0x3d, 0xaa, 0x2f, 0x02, 0x00, 0x46, 0x23, 0x7a, 0x13,
+	0xa6, 0x82, 0xf5, 0x35, 0x3d, 0x51, 0xe5, 0x95, 0x3d, 0x51, 0xf5, 0xdf, 0x39, 0xa1, 0xbd, 0xea,
+	0xc8, 0xaf, 0xff, 0x3e, 0x32, 0xf7, 0xff, 0x7d, 0x1f, 0xc9, 0xbd, 0xf2, 0xfb, 0xc8, 0x6b, 0x3e,
+	0x3b, 0xcc, 0xbf, 0xe6, 0xb3, 0xc3, 0x7f, 0x79, 0xe7, 0x5b, 0x78, 0xfd, 0x3b, 0x1f, 0x7d, 0x21,
+	0xe4, 0x2f, 0x15, 0x8b, 0xf1, 0x17, 0x42, 0xfe, 0x40, 0xb1, 0x2b, 0x56, 0x26, 0x1f, 0x16, 0x38,
+	0xec, 0x97, 0x07, 0xf1, 0xf7, 0x04, 0xc0, 0x24, 0x66, 0xc6, 0x1f, 0x2c, 0x96, 0x18, 0x9c, 0x89,
+	0xa8, 0x5e, 0x1d, 0xa0, 0x8e, 0x2d, 0x25, 0xa6, 0x7d, 0xf5, 0x47, 0xc4, 0x77, 0xf0, 0x73, 0x61,
+	0xec, 0x43, 0xfc, 0x26, 0x95, 0xa3, 0xe2, 0xad, 0x94, 0x90, 0xc9, 0x6f, 0xab, 0x7f, 0x85, 0xe6,
+	0x28, 0xf3, 0x18, 0x04, 0x65, 0x4b, 0x7e, 0x82, 0xa0, 0xf1, 0x87, 0x5f, 0x31, 0xe9, 0xe2, 0x75,
+	0x91, 0x20, 0x29, 0xbe, 0xf6, 0x89, 0x64, 0xc1, 0x38, 0x0b, 0x88, 0x89, 0xbb, 0xeb, 0x29, 0xae,
+	0xfc, 0x4a, 0x94, 0x27, 0x3a, 0xa9, 0xd5, 0x39, 0x07, 0xaf, 0xee, 0x67, 0x8f, 0xa4, 0x4f, 0x94,
+	0xe7, 0x7d, 0xaa, 0x7f, 0x9e, 0x13, 0xeb, 0xc7, 0x9c, 0x75, 0xb3, 0xda, 0x3e, 0x11, 0x32, 0x49,
+	0xd0, 0x89, 0xd6, 0x64, 0x8a, 0x8c, 0xd2, 0x94, 0x53, 0xcb, 0x71, 0xde, 0x4e, 0xbe, 0xbf, 0xd6,
+	0x21, 0x7b, 0xab, 0xd9, 0xd9, 0x1a, 0x23, 0xa7, 0x82, 0x28, 0xed, 0xc5, 0xb4, 0x46, 0x45, 0xc9,
+	0xa7, 0x19, 0xbd, 0x3b, 0xf4, 0x1d, 0xfd, 0xf1, 0x7f, 0x00, 0xc2, 0x9e, 0x5f, 0x6a, 0xa5, 0x1f,
+	0x00, 0x00,
 }
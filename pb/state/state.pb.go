@@ -48,6 +48,8 @@ const (
 	Row_RUNNING          Row_Result = 4
 	Row_FAIL             Row_Result = 12
 	Row_FLAKY            Row_Result = 13
+	// Failed because of the test infrastructure rather than the code under test.
+	Row_TOOL_FAIL Row_Result = 14
 )
 
 var Row_Result_name = map[int32]string{
@@ -58,6 +60,7 @@ var Row_Result_name = map[int32]string{
 	4:  "RUNNING",
 	12: "FAIL",
 	13: "FLAKY",
+	14: "TOOL_FAIL",
 }
 
 var Row_Result_value = map[string]int32{
@@ -68,6 +71,7 @@ var Row_Result_value = map[string]int32{
 	"RUNNING":          4,
 	"FAIL":             12,
 	"FLAKY":            13,
+	"TOOL_FAIL":        14,
 }
 
 func (x Row_Result) String() string {
@@ -445,10 +449,18 @@ type Column struct {
 	// headers of build_id, date, and time.
 	Extra []string `protobuf:"bytes,4,rep,name=extra,proto3" json:"extra,omitempty"`
 	// Custom hotlist ids.
-	HotlistIds           string   `protobuf:"bytes,5,opt,name=hotlist_ids,json=hotlistIds,proto3" json:"hotlist_ids,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	HotlistIds string `protobuf:"bytes,5,opt,name=hotlist_ids,json=hotlistIds,proto3" json:"hotlist_ids,omitempty"`
+	// Counts of the results in this column, and whether it is broken.
+	Stats *Stats `protobuf:"bytes,6,opt,name=stats,proto3" json:"stats,omitempty"`
+	// Why the build of this column could not be read, which leaves its cells
+	// empty.
+	Error string `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	// Annotations of the build, such as the cluster that ran it, copied from
+	// the metadata keys in the column_annotations of the test group.
+	Metadata             map[string]string `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *Column) Reset()         { *m = Column{} }
@@ -511,6 +523,27 @@ func (m *Column) GetHotlistIds() string {
 	return ""
 }
 
+func (m *Column) GetStats() *Stats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+func (m *Column) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *Column) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
 // TestGrid rows (also known as TestRow)
 type Row struct {
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -536,10 +569,17 @@ type Row struct {
 	// IDs for bugs associated with results in this test case.
 	BugId []string `protobuf:"bytes,10,rep,name=bug_id,json=bugId,proto3" json:"bug_id,omitempty"`
 	// An alert for the failure if there's a recent failure for this test case.
-	AlertInfo            *AlertInfo `protobuf:"bytes,11,opt,name=alert_info,json=alertInfo,proto3" json:"alert_info,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	AlertInfo *AlertInfo `protobuf:"bytes,11,opt,name=alert_info,json=alertInfo,proto3" json:"alert_info,omitempty"`
+	// Additional information about the row, such as the parent row that groups
+	// test methods under their class.
+	Metadata map[string]string `protobuf:"bytes,12,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Properties for each test result in this test case, such as a link for the
+	// cell. When used, there is one per cycle with a non-empty status (where
+	// status is not NO_RESULT), like messages.
+	Properties           []*Property `protobuf:"bytes,13,rep,name=properties,proto3" json:"properties,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *Row) Reset()         { *m = Row{} }
@@ -637,6 +677,20 @@ func (m *Row) GetAlertInfo() *AlertInfo {
 	return nil
 }
 
+func (m *Row) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *Row) GetProperties() []*Property {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
 // A single table of test results backing a dashboard tab.
 type Grid struct {
 	// A cycle of test results, not including the results. In the TestGrid client,
@@ -658,10 +712,16 @@ type Grid struct {
 	// Clusters of failures for a TestResultTable instance.
 	Cluster []*Cluster `protobuf:"bytes,10,rep,name=cluster,proto3" json:"cluster,omitempty"`
 	// Most recent timestamp that clusters have processed.
-	MostRecentClusterTimestamp float64  `protobuf:"fixed64,11,opt,name=most_recent_cluster_timestamp,json=mostRecentClusterTimestamp,proto3" json:"most_recent_cluster_timestamp,omitempty"`
-	XXX_NoUnkeyedLiteral       struct{} `json:"-"`
-	XXX_unrecognized           []byte   `json:"-"`
-	XXX_sizecache              int32    `json:"-"`
+	MostRecentClusterTimestamp float64 `protobuf:"fixed64,11,opt,name=most_recent_cluster_timestamp,json=mostRecentClusterTimestamp,proto3" json:"most_recent_cluster_timestamp,omitempty"`
+	// Number of the oldest columns the last update dropped to fit the grid within
+	// the size budget of the updater.
+	DroppedColumns int32 `protobuf:"varint,12,opt,name=dropped_columns,json=droppedColumns,proto3" json:"dropped_columns,omitempty"`
+	// Number of rows the last update dropped to keep at most the max_rows of the
+	// test group, which omits the rows that stopped failing longest ago.
+	DroppedRows          int32    `protobuf:"varint,13,opt,name=dropped_rows,json=droppedRows,proto3" json:"dropped_rows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Grid) Reset()         { *m = Grid{} }
@@ -752,6 +812,20 @@ func (m *Grid) GetMostRecentClusterTimestamp() float64 {
 	return 0
 }
 
+func (m *Grid) GetDroppedColumns() int32 {
+	if m != nil {
+		return m.DroppedColumns
+	}
+	return 0
+}
+
+func (m *Grid) GetDroppedRows() int32 {
+	if m != nil {
+		return m.DroppedRows
+	}
+	return 0
+}
+
 // A cluster of failures grouped by test status and message for a test results
 // table.
 type Cluster struct {
@@ -862,6 +936,115 @@ func (m *ClusterRow) GetIndex() []int32 {
 	return nil
 }
 
+// Key/value properties of a test result.
+type Property struct {
+	Property             map[string]string `protobuf:"bytes,1,rep,name=property,proto3" json:"property,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Property) Reset()         { *m = Property{} }
+func (m *Property) String() string { return proto.CompactTextString(m) }
+func (*Property) ProtoMessage()    {}
+func (*Property) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{10}
+}
+
+func (m *Property) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Property.Unmarshal(m, b)
+}
+func (m *Property) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Property.Marshal(b, m, deterministic)
+}
+func (m *Property) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Property.Merge(m, src)
+}
+func (m *Property) XXX_Size() int {
+	return xxx_messageInfo_Property.Size(m)
+}
+func (m *Property) XXX_DiscardUnknown() {
+	xxx_messageInfo_Property.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Property proto.InternalMessageInfo
+
+func (m *Property) GetProperty() map[string]string {
+	if m != nil {
+		return m.Property
+	}
+	return nil
+}
+
+// Result counts of a column.
+type Stats struct {
+	// Number of failing cells.
+	FailCount int32 `protobuf:"varint,1,opt,name=fail_count,json=failCount,proto3" json:"fail_count,omitempty"`
+	// Number of passing cells.
+	PassCount int32 `protobuf:"varint,2,opt,name=pass_count,json=passCount,proto3" json:"pass_count,omitempty"`
+	// Number of cells with a result.
+	TotalCount int32 `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// True when the failing fraction of the cells exceeds the broken column
+	// threshold of the test group, such as when the infrastructure failed.
+	Broken               bool     `protobuf:"varint,4,opt,name=broken,proto3" json:"broken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Stats) Reset()         { *m = Stats{} }
+func (m *Stats) String() string { return proto.CompactTextString(m) }
+func (*Stats) ProtoMessage()    {}
+func (*Stats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{11}
+}
+
+func (m *Stats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Stats.Unmarshal(m, b)
+}
+func (m *Stats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Stats.Marshal(b, m, deterministic)
+}
+func (m *Stats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Stats.Merge(m, src)
+}
+func (m *Stats) XXX_Size() int {
+	return xxx_messageInfo_Stats.Size(m)
+}
+func (m *Stats) XXX_DiscardUnknown() {
+	xxx_messageInfo_Stats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Stats proto.InternalMessageInfo
+
+func (m *Stats) GetFailCount() int32 {
+	if m != nil {
+		return m.FailCount
+	}
+	return 0
+}
+
+func (m *Stats) GetPassCount() int32 {
+	if m != nil {
+		return m.PassCount
+	}
+	return 0
+}
+
+func (m *Stats) GetTotalCount() int32 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+func (m *Stats) GetBroken() bool {
+	if m != nil {
+		return m.Broken
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterEnum("Row_Result", Row_Result_name, Row_Result_value)
 	proto.RegisterType((*Metric)(nil), "Metric")
@@ -870,80 +1053,98 @@ func init() {
 	proto.RegisterType((*AlertInfo)(nil), "AlertInfo")
 	proto.RegisterType((*TestMetadata)(nil), "TestMetadata")
 	proto.RegisterType((*Column)(nil), "Column")
+	proto.RegisterMapType((map[string]string)(nil), "Column.MetadataEntry")
 	proto.RegisterType((*Row)(nil), "Row")
+	proto.RegisterMapType((map[string]string)(nil), "Row.MetadataEntry")
 	proto.RegisterType((*Grid)(nil), "Grid")
 	proto.RegisterType((*Cluster)(nil), "Cluster")
 	proto.RegisterType((*ClusterRow)(nil), "ClusterRow")
+	proto.RegisterType((*Property)(nil), "Property")
+	proto.RegisterType((*Stats)(nil), "Stats")
+	proto.RegisterMapType((map[string]string)(nil), "Property.PropertyEntry")
 }
 
 func init() { proto.RegisterFile("state.proto", fileDescriptor_a888679467bb7853) }
 
 var fileDescriptor_a888679467bb7853 = []byte{
-	// 1056 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x55, 0xdf, 0x6f, 0xe3, 0x44,
-	0x10, 0xc6, 0xf9, 0x65, 0x7b, 0x9c, 0x34, 0xbe, 0xa5, 0x9c, 0x4c, 0x51, 0x75, 0x39, 0x83, 0x20,
-	0x20, 0xe4, 0x4a, 0xe1, 0x81, 0x17, 0x5e, 0x4a, 0xe9, 0x95, 0xf4, 0xda, 0x5c, 0xb5, 0x49, 0x85,
-	0x78, 0xb2, 0x1c, 0x7b, 0x9b, 0xb3, 0xce, 0xb1, 0x23, 0xef, 0x9a, 0xb4, 0xcf, 0x3c, 0xf1, 0x07,
-	0xf0, 0x80, 0xc4, 0x1f, 0x8b, 0x66, 0xd6, 0x4e, 0x52, 0x84, 0xc4, 0x53, 0x76, 0xbe, 0x19, 0xcf,
-	0xcc, 0xee, 0xcc, 0xf7, 0x05, 0x1c, 0xa9, 0x22, 0x25, 0x82, 0x4d, 0x59, 0xa8, 0xe2, 0xe4, 0xd5,
-	0xaa, 0x28, 0x56, 0x99, 0x38, 0x23, 0x6b, 0x59, 0x3d, 0x9c, 0xa9, 0x74, 0x2d, 0xa4, 0x8a, 0xd6,
-	0x9b, 0x3a, 0xe0, 0xe5, 0x66, 0x79, 0x16, 0x17, 0xf9, 0x43, 0xba, 0xaa, 0x7f, 0x34, 0xee, 0xcf,
-	0xa0, 0x77, 0x2b, 0x54, 0x99, 0xc6, 0x8c, 0x41, 0x27, 0x8f, 0xd6, 0xc2, 0x33, 0x46, 0xc6, 0xd8,
-	0xe6, 0x74, 0x66, 0x1e, 0x98, 0x69, 0x9e, 0xa4, 0xb1, 0x90, 0x5e, 0x6b, 0xd4, 0x1e, 0x77, 0x79,
-	0x63, 0xb2, 0x97, 0xd0, 0xfb, 0x2d, 0xca, 0x2a, 0x21, 0xbd, 0xf6, 0xa8, 0x3d, 0x36, 0x78, 0x6d,
-	0xf9, 0xf7, 0x30, 0xbc, 0xdf, 0x24, 0x91, 0x12, 0x77, 0xef, 0x23, 0x29, 0x7e, 0x8a, 0x54, 0xc4,
-	0x4e, 0x01, 0x36, 0x68, 0x84, 0x07, 0xe9, 0x6d, 0x42, 0x66, 0x58, 0xe3, 0x73, 0x18, 0x68, 0xb7,
-	0x14, 0x71, 0x91, 0x27, 0x58, 0xc9, 0x18, 0x1b, 0xbc, 0x4f, 0xe0, 0x5c, 0x63, 0xfe, 0x35, 0x80,
-	0x4e, 0x3b, 0xcd, 0x1f, 0x0a, 0xf6, 0x03, 0xbc, 0xa8, 0xc8, 0x0a, 0xf5, 0x97, 0x49, 0xa4, 0x22,
-	0xcf, 0x18, 0xb5, 0xc7, 0xce, 0xc4, 0x0d, 0xfe, 0x55, 0x9e, 0x0f, 0xab, 0xe7, 0x80, 0xff, 0x77,
-	0x1b, 0xec, 0xf3, 0x4c, 0x94, 0x8a, 0x72, 0x9d, 0x02, 0x3c, 0x44, 0x69, 0x16, 0xc6, 0x45, 0x95,
-	0x2b, 0xea, 0xae, 0xcb, 0x6d, 0x44, 0x2e, 0x10, 0x60, 0x3e, 0x0c, 0xc8, 0xbd, 0xac, 0xd2, 0x2c,
-	0x09, 0xd3, 0x84, 0xba, 0xb3, 0xb9, 0x83, 0xe0, 0x8f, 0x88, 0x4d, 0x13, 0xf6, 0x3d, 0xd0, 0x07,
-	0x21, 0xbe, 0xb9, 0xd7, 0x1e, 0x19, 0x63, 0x67, 0x72, 0x12, 0xe8, 0x81, 0x04, 0xcd, 0x40, 0x82,
-	0x45, 0x33, 0x10, 0x6e, 0x61, 0x30, 0x9a, 0x6c, 0x04, 0x7d, 0xfd, 0xa1, 0x90, 0x0a, 0x73, 0x77,
-	0x28, 0x37, 0xf5, 0xb3, 0x10, 0x52, 0x4d, 0x13, 0x2c, 0xbf, 0x89, 0xa4, 0xdc, 0x97, 0xef, 0xea,
-	0xf2, 0x08, 0x1e, 0x94, 0xa7, 0x18, 0x2a, 0xdf, 0xfb, 0xff, 0xf2, 0x18, 0x4c, 0xe5, 0xbf, 0x82,
-	0x21, 0x96, 0xaa, 0x4a, 0x11, 0xae, 0x85, 0x94, 0xd1, 0x4a, 0x78, 0x26, 0xa5, 0x3f, 0xaa, 0xe1,
-	0x5b, 0x8d, 0xe2, 0x1b, 0xe9, 0x06, 0xb2, 0x34, 0xff, 0xe0, 0x59, 0x7a, 0x82, 0x84, 0xdc, 0xa4,
-	0xf9, 0x07, 0xf6, 0x25, 0x0c, 0xf7, 0xee, 0x50, 0x89, 0x47, 0xe5, 0xd9, 0x14, 0x33, 0xd8, 0xc5,
-	0x2c, 0xc4, 0xa3, 0x62, 0x5f, 0xc0, 0x91, 0x8e, 0xab, 0xca, 0x4c, 0x87, 0x01, 0x85, 0xf5, 0x09,
-	0xbd, 0x2f, 0x33, 0x8c, 0xf2, 0xff, 0x34, 0xa0, 0x8f, 0xb7, 0xbf, 0x15, 0x2a, 0xc2, 0xc1, 0xb2,
-	0xcf, 0xc0, 0xa6, 0x07, 0x3a, 0x58, 0x1f, 0x0b, 0x81, 0x66, 0x7b, 0x96, 0xd5, 0x2a, 0x8c, 0x8b,
-	0xf5, 0xa6, 0xc8, 0x45, 0xae, 0x68, 0x3e, 0x5d, 0x4c, 0xb9, 0xba, 0x68, 0x30, 0x76, 0x0c, 0xdd,
-	0x62, 0x9b, 0x8b, 0x92, 0x86, 0x63, 0x73, 0x6d, 0xb0, 0x23, 0x68, 0xc5, 0xb1, 0xd7, 0x19, 0xb5,
-	0xc7, 0x36, 0x6f, 0xc5, 0x31, 0xde, 0x52, 0x94, 0x65, 0x51, 0x86, 0xea, 0x69, 0x23, 0xea, 0x87,
-	0xb6, 0x09, 0x59, 0x3c, 0x6d, 0x84, 0xff, 0xbb, 0x01, 0xbd, 0x8b, 0x22, 0xab, 0xd6, 0x39, 0xe6,
-	0xa3, 0x96, 0xeb, 0x6e, 0xb4, 0xb1, 0x23, 0x50, 0xeb, 0x39, 0x81, 0xa4, 0x8a, 0x4a, 0x25, 0x12,
-	0xaa, 0x6d, 0xf0, 0xc6, 0xc4, 0x1c, 0xe2, 0x51, 0x95, 0x51, 0xdd, 0x80, 0x36, 0xd8, 0x2b, 0x70,
-	0xde, 0x17, 0x2a, 0x4b, 0x69, 0x1f, 0x64, 0xdd, 0x04, 0xd4, 0xd0, 0x34, 0x91, 0xfe, 0x1f, 0x6d,
-	0x68, 0xf3, 0x62, 0xfb, 0x9f, 0x6c, 0x3d, 0x82, 0xd6, 0x6e, 0x41, 0x5b, 0x69, 0x82, 0xc5, 0x4b,
-	0x21, 0xab, 0x4c, 0x69, 0x92, 0x76, 0x79, 0x63, 0xb2, 0x4f, 0xc1, 0x8a, 0x45, 0x96, 0x51, 0x0d,
-	0x5d, 0xdf, 0x44, 0x7b, 0x9a, 0x48, 0x76, 0x02, 0x56, 0xbd, 0x0c, 0x58, 0x1e, 0x5d, 0x3b, 0x1b,
-	0x49, 0xbf, 0x26, 0xb1, 0xf0, 0x4c, 0xf2, 0xd4, 0x16, 0x7b, 0x0d, 0xa6, 0x3e, 0x49, 0xcf, 0x22,
-	0x16, 0x9a, 0x81, 0x16, 0x15, 0xde, 0xe0, 0x78, 0xdd, 0x34, 0x2e, 0x72, 0xe9, 0xd9, 0xfa, 0xba,
-	0x64, 0xb0, 0x4f, 0xa0, 0x87, 0xd3, 0x4b, 0x13, 0x0f, 0x34, 0xbc, 0xac, 0x56, 0xd3, 0x84, 0x7d,
-	0x0d, 0x10, 0x21, 0x41, 0xc3, 0x34, 0x7f, 0x28, 0x3c, 0x87, 0x56, 0x1a, 0x82, 0x1d, 0x67, 0xb9,
-	0x1d, 0x35, 0x47, 0x5f, 0x41, 0x8f, 0xd3, 0xa5, 0xd8, 0x00, 0xec, 0xd9, 0xbb, 0x90, 0x5f, 0xce,
-	0xef, 0x6f, 0x16, 0xee, 0x47, 0xcc, 0x82, 0xce, 0xdd, 0xf9, 0x7c, 0xee, 0x1a, 0xec, 0x18, 0x5c,
-	0x3c, 0x85, 0xbf, 0x4c, 0x17, 0x3f, 0x87, 0x97, 0x9c, 0xbf, 0xe3, 0x73, 0xb7, 0xc5, 0x3e, 0x86,
-	0xe1, 0x1e, 0x9d, 0xbf, 0x9d, 0xde, 0xcd, 0xdd, 0x36, 0x73, 0xc0, 0xe4, 0xf7, 0xb3, 0xd9, 0x74,
-	0x76, 0xe5, 0x76, 0x30, 0xc3, 0x9b, 0xf3, 0xe9, 0x8d, 0xdb, 0x67, 0x36, 0x74, 0xdf, 0xdc, 0x9c,
-	0xbf, 0xfd, 0xd5, 0x1d, 0xf8, 0x1d, 0xab, 0xeb, 0x3a, 0xd7, 0x1d, 0xab, 0xe7, 0x9a, 0xfe, 0x5f,
-	0x6d, 0xe8, 0x5c, 0x95, 0x69, 0x82, 0xf7, 0x8f, 0x69, 0x33, 0x64, 0xad, 0x42, 0x66, 0xa0, 0x37,
-	0x85, 0x37, 0x38, 0xf3, 0xa0, 0x53, 0x16, 0x5b, 0x2d, 0xa3, 0xce, 0xa4, 0x13, 0xf0, 0x62, 0xcb,
-	0x09, 0x61, 0x67, 0x70, 0x9c, 0x45, 0x52, 0x85, 0xfa, 0xc6, 0xeb, 0x67, 0x42, 0x62, 0xf0, 0x17,
-	0xe8, 0xa3, 0x9b, 0xdf, 0x36, 0xaa, 0xe1, 0x43, 0x4f, 0x4b, 0x38, 0xe9, 0x05, 0xbe, 0x0c, 0xd2,
-	0xe5, 0xaa, 0x2c, 0xaa, 0x0d, 0xaf, 0x3d, 0xec, 0x1b, 0xa0, 0x0f, 0x29, 0x53, 0xa8, 0x05, 0x30,
-	0x21, 0x6d, 0x30, 0xf8, 0x10, 0x1d, 0x98, 0x48, 0x0b, 0x65, 0xc2, 0xbe, 0x05, 0xa7, 0x56, 0x53,
-	0x7a, 0x6e, 0x3d, 0x41, 0x27, 0xd8, 0xeb, 0x2d, 0x87, 0x6a, 0xaf, 0xbd, 0x13, 0x18, 0x10, 0x1b,
-	0xd7, 0x35, 0x3d, 0x69, 0xa0, 0xce, 0x64, 0x10, 0x1c, 0x72, 0x96, 0xf7, 0xd5, 0x21, 0x83, 0x7d,
-	0x30, 0xe3, 0xac, 0x92, 0x4a, 0x94, 0x34, 0x67, 0x67, 0x62, 0x05, 0x17, 0xda, 0xe6, 0x8d, 0x83,
-	0x9d, 0xc3, 0xe9, 0xba, 0x90, 0x2a, 0x2c, 0x45, 0x2c, 0x72, 0x15, 0xd6, 0x70, 0xb8, 0xfb, 0x1f,
-	0xa3, 0x35, 0x30, 0xf8, 0x09, 0x06, 0x71, 0x8a, 0xa9, 0x53, 0xec, 0x94, 0xed, 0x1a, 0x67, 0xd3,
-	0xbb, 0xee, 0x58, 0xa6, 0x6b, 0xf9, 0x25, 0x98, 0xb5, 0x1f, 0x39, 0x45, 0x1d, 0xe3, 0xff, 0x65,
-	0x25, 0x6b, 0x89, 0x07, 0x84, 0xe6, 0x84, 0x20, 0x4f, 0x1a, 0xfd, 0xd3, 0xe4, 0x69, 0x4c, 0x7c,
-	0x9a, 0xa6, 0x91, 0xb2, 0xd8, 0x12, 0x8b, 0xf0, 0x69, 0x9a, 0xe6, 0x8b, 0x2d, 0x87, 0x78, 0x77,
-	0xf6, 0x2f, 0x01, 0xf6, 0x1e, 0xf6, 0x1a, 0xfa, 0x49, 0x2a, 0x37, 0x59, 0xf4, 0x74, 0xa8, 0x5c,
-	0x4e, 0x8d, 0x91, 0x78, 0x21, 0x29, 0xf2, 0x44, 0x3c, 0xd6, 0x7f, 0xae, 0xda, 0x58, 0xf6, 0x48,
-	0xb4, 0xbf, 0xfb, 0x27, 0x00, 0x00, 0xff, 0xff, 0x01, 0x9c, 0xd5, 0x09, 0xe1, 0x07, 0x00, 0x00,
+	// 1262 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa5, 0x56, 0xcd, 0x6f, 0xe3, 0x54,
+	0x10, 0xc7, 0xf9, 0xb4, 0x27, 0x49, 0xe3, 0x7d, 0xec, 0x2e, 0xa1, 0xb0, 0xda, 0xae, 0x17, 0x41,
+	0x41, 0xc8, 0x15, 0xdd, 0x03, 0x08, 0xb8, 0x94, 0xd2, 0x2d, 0xe9, 0xb6, 0x69, 0xf5, 0x92, 0x0a,
+	0x71, 0xb2, 0x9c, 0xd8, 0xcd, 0x5a, 0x75, 0xe2, 0xc8, 0x1f, 0xec, 0x46, 0x42, 0x5c, 0xf8, 0x1b,
+	0xb8, 0x71, 0xe7, 0xcc, 0x1f, 0xc5, 0xff, 0xc1, 0xcc, 0xbc, 0x67, 0x27, 0x45, 0xa0, 0x15, 0xe2,
+	0x92, 0xbc, 0xf9, 0xcd, 0xbc, 0x99, 0x79, 0xf3, 0x69, 0xe8, 0x64, 0xb9, 0x9f, 0x87, 0xee, 0x2a,
+	0x4d, 0xf2, 0x64, 0xf7, 0xf1, 0x3c, 0x49, 0xe6, 0x71, 0x78, 0xc0, 0xd4, 0xb4, 0xb8, 0x39, 0xc8,
+	0xa3, 0x45, 0x88, 0x02, 0x8b, 0x95, 0x16, 0x78, 0xb8, 0x9a, 0x1e, 0xcc, 0x92, 0xe5, 0x4d, 0x34,
+	0xd7, 0x7f, 0x0a, 0x77, 0x46, 0xd0, 0xba, 0x08, 0xf3, 0x34, 0x9a, 0x09, 0x01, 0x8d, 0xa5, 0xbf,
+	0x08, 0x07, 0xc6, 0x9e, 0xb1, 0x6f, 0x49, 0x3e, 0x8b, 0x01, 0xb4, 0xa3, 0x65, 0x10, 0xcd, 0xc2,
+	0x6c, 0x50, 0xdb, 0xab, 0xef, 0x37, 0x65, 0x49, 0x8a, 0x87, 0xd0, 0xfa, 0xd1, 0x8f, 0x0b, 0x64,
+	0xd4, 0x91, 0x61, 0x48, 0x4d, 0x39, 0xd7, 0xd0, 0xbf, 0x5e, 0x05, 0xe8, 0xd8, 0xd5, 0x4b, 0x3f,
+	0x0b, 0xbf, 0xf5, 0x73, 0x5f, 0x3c, 0x02, 0x58, 0x11, 0xe1, 0x6d, 0xa9, 0xb7, 0x18, 0x19, 0x91,
+	0x8d, 0xa7, 0xd0, 0x53, 0xec, 0x2c, 0x44, 0xcf, 0x02, 0xb2, 0x64, 0xa0, 0xc2, 0x2e, 0x83, 0x63,
+	0x85, 0x39, 0x67, 0x00, 0x4a, 0xed, 0x70, 0x79, 0x93, 0x88, 0xaf, 0xe1, 0x5e, 0xc1, 0x94, 0xa7,
+	0x6e, 0xe2, 0xd1, 0x47, 0xc5, 0xf5, 0xfd, 0xce, 0xa1, 0xed, 0xfe, 0xcd, 0xbc, 0xec, 0x17, 0x77,
+	0x01, 0xe7, 0xb7, 0x3a, 0x58, 0x47, 0x71, 0x98, 0xe6, 0xac, 0x0b, 0xbd, 0xbb, 0xf1, 0xa3, 0xd8,
+	0x9b, 0x25, 0xc5, 0x32, 0x67, 0xef, 0x9a, 0xd2, 0x22, 0xe4, 0x98, 0x00, 0xe1, 0x40, 0x8f, 0xd9,
+	0xd3, 0x22, 0x8a, 0x03, 0x2f, 0x0a, 0xd8, 0x3b, 0x4b, 0x76, 0x08, 0xfc, 0x86, 0xb0, 0x61, 0x20,
+	0x3e, 0x07, 0xbe, 0xe0, 0x51, 0xcc, 0x31, 0x1c, 0x06, 0xba, 0xb1, 0xeb, 0xaa, 0x84, 0xb8, 0x65,
+	0x42, 0xdc, 0x49, 0x99, 0x10, 0x69, 0x92, 0x30, 0x91, 0x62, 0x0f, 0xba, 0xea, 0x22, 0x72, 0x48,
+	0x77, 0x83, 0x75, 0xb3, 0x3f, 0x13, 0x84, 0x50, 0x35, 0x9a, 0x5f, 0xf9, 0x59, 0xb6, 0x31, 0xdf,
+	0x54, 0xe6, 0x09, 0xdc, 0x32, 0xcf, 0x32, 0x6c, 0xbe, 0xf5, 0x66, 0xf3, 0x24, 0xcc, 0xe6, 0x3f,
+	0x82, 0x3e, 0x99, 0x2a, 0xd2, 0xd0, 0x43, 0x66, 0xe6, 0xcf, 0xc3, 0x41, 0x9b, 0xd5, 0xef, 0x68,
+	0xf8, 0x42, 0xa1, 0x14, 0x23, 0xe5, 0x40, 0x1c, 0x2d, 0x6f, 0x07, 0xa6, 0xca, 0x20, 0x23, 0xe7,
+	0x08, 0x88, 0x0f, 0xa1, 0xbf, 0x61, 0xe3, 0x63, 0x5e, 0xe7, 0x03, 0x8b, 0x65, 0x7a, 0x95, 0xcc,
+	0x04, 0x41, 0xf1, 0x01, 0xec, 0x28, 0xb9, 0x22, 0x8d, 0x95, 0x18, 0xb0, 0x58, 0x97, 0xd1, 0xeb,
+	0x34, 0x26, 0x29, 0xe7, 0x57, 0x03, 0xba, 0xf4, 0x7a, 0x2c, 0x4b, 0x9f, 0x12, 0x2b, 0xde, 0x03,
+	0x8b, 0x03, 0xb4, 0x55, 0x3e, 0x26, 0x01, 0x65, 0xf5, 0x4c, 0x8b, 0x39, 0x66, 0x6f, 0xb1, 0x4a,
+	0x96, 0x21, 0x66, 0xb0, 0xc6, 0x19, 0x44, 0x95, 0xf3, 0xe3, 0x12, 0x13, 0xf7, 0xa1, 0x99, 0xbc,
+	0x5a, 0x86, 0x29, 0x27, 0xc7, 0x92, 0x8a, 0x10, 0x3b, 0x50, 0x9b, 0xcd, 0x30, 0xe6, 0x75, 0x84,
+	0xf0, 0x44, 0xaf, 0x0c, 0xd3, 0x34, 0x49, 0xbd, 0x7c, 0xbd, 0x0a, 0x75, 0xa0, 0x2d, 0x46, 0x26,
+	0x08, 0x38, 0xbf, 0xd7, 0xa0, 0x75, 0x9c, 0xc4, 0xc5, 0x62, 0x49, 0xfa, 0xd8, 0x65, 0xed, 0x8d,
+	0x22, 0xaa, 0x06, 0xaa, 0xdd, 0x6d, 0x20, 0x8c, 0x7a, 0x9a, 0x87, 0x01, 0xdb, 0x36, 0x64, 0x49,
+	0x92, 0x0e, 0x7c, 0x6d, 0xea, 0x6b, 0x07, 0x14, 0x21, 0x1e, 0x43, 0xe7, 0x65, 0x92, 0xc7, 0x11,
+	0xd7, 0x43, 0xa6, 0x9d, 0x00, 0x0d, 0x0d, 0x83, 0x4c, 0xbc, 0x0f, 0x4d, 0xea, 0xfb, 0x4c, 0x27,
+	0xba, 0xe5, 0x8e, 0x89, 0x92, 0x0a, 0x64, 0xa5, 0xe4, 0xb0, 0xce, 0xa3, 0x22, 0xc4, 0x67, 0x60,
+	0x2e, 0x74, 0x30, 0x31, 0x79, 0xd4, 0x25, 0x0f, 0x5c, 0xf5, 0x12, 0xb7, 0x0c, 0xf2, 0xc9, 0x32,
+	0x4f, 0xd7, 0xb2, 0x12, 0xdb, 0xfd, 0x0a, 0x7a, 0x77, 0x58, 0xc2, 0x86, 0xfa, 0x6d, 0xb8, 0xd6,
+	0x0f, 0xa6, 0x23, 0xd9, 0xe2, 0x9e, 0xd7, 0xef, 0x55, 0xc4, 0x97, 0xb5, 0x2f, 0x0c, 0xe7, 0x8f,
+	0x06, 0xd4, 0x65, 0xf2, 0xea, 0x1f, 0x27, 0x0a, 0x06, 0xbd, 0x6a, 0x22, 0x3c, 0x51, 0x80, 0xd2,
+	0x30, 0x2b, 0xe2, 0x5c, 0x0d, 0x12, 0x9c, 0x30, 0x9a, 0x14, 0xef, 0x82, 0x39, 0x0b, 0xe3, 0x98,
+	0xe3, 0xa0, 0x62, 0xd4, 0x26, 0x9a, 0x82, 0xb0, 0x4b, 0x0f, 0xe2, 0xd2, 0xa4, 0x10, 0x11, 0xab,
+	0xa2, 0x69, 0x30, 0x2d, 0x78, 0xa0, 0x61, 0x0c, 0x88, 0xa3, 0x29, 0xf1, 0x04, 0xda, 0xea, 0x94,
+	0xe9, 0x18, 0xb4, 0x5d, 0x35, 0xf8, 0x64, 0x89, 0xd3, 0x8b, 0x22, 0x1c, 0x37, 0x19, 0x56, 0x2f,
+	0xa7, 0x84, 0x09, 0xf1, 0x00, 0x5a, 0x54, 0x61, 0xe8, 0x35, 0x28, 0x18, 0x29, 0xec, 0xba, 0x8f,
+	0x01, 0x7c, 0x1a, 0x22, 0x5e, 0x84, 0x53, 0x64, 0xd0, 0xe1, 0x6c, 0x80, 0x5b, 0xcd, 0x15, 0x69,
+	0xf9, 0xd5, 0x88, 0x71, 0xb7, 0xe2, 0xdf, 0x65, 0xdb, 0xc2, 0xc5, 0xf8, 0xfc, 0x5b, 0xf0, 0x49,
+	0x35, 0xf6, 0xed, 0x0a, 0xaf, 0x47, 0xf8, 0xc0, 0x1e, 0xdf, 0xb0, 0xdc, 0x2b, 0x05, 0xad, 0xe5,
+	0x16, 0xf3, 0xff, 0xe5, 0xe9, 0x17, 0x03, 0x5a, 0x92, 0xa3, 0x2d, 0x7a, 0x60, 0x8d, 0x2e, 0x3d,
+	0x79, 0x32, 0xbe, 0x3e, 0x9f, 0xd8, 0x6f, 0x09, 0x13, 0x1a, 0x57, 0x47, 0xe3, 0xb1, 0x6d, 0xe0,
+	0x6d, 0x9b, 0x4e, 0xde, 0xf7, 0xc3, 0xc9, 0x77, 0xde, 0x89, 0x94, 0x97, 0x72, 0x6c, 0xd7, 0xc4,
+	0xdb, 0xd0, 0xdf, 0xa0, 0xe3, 0x17, 0xc3, 0xab, 0xb1, 0x5d, 0x17, 0x1d, 0x68, 0xcb, 0xeb, 0xd1,
+	0x68, 0x38, 0x3a, 0xb5, 0x1b, 0xa4, 0xe1, 0xf9, 0xd1, 0xf0, 0xdc, 0xee, 0x0a, 0x0b, 0x9a, 0xcf,
+	0xcf, 0x8f, 0x5e, 0xfc, 0x60, 0xf7, 0xc8, 0xca, 0xe4, 0xf2, 0xf2, 0xdc, 0x63, 0xce, 0x8e, 0xd3,
+	0x30, 0x9b, 0x76, 0xe7, 0xac, 0x61, 0xb6, 0xec, 0xb6, 0xf3, 0x67, 0x1d, 0x1a, 0xa7, 0x29, 0x16,
+	0x04, 0xe6, 0x69, 0xc6, 0xb5, 0x99, 0xe9, 0x89, 0xde, 0xd6, 0xb5, 0x2a, 0x4b, 0x1c, 0x6b, 0xa6,
+	0x91, 0x26, 0xaf, 0xd4, 0x4a, 0xea, 0x1c, 0x36, 0x28, 0x96, 0x92, 0x11, 0x71, 0x00, 0xf7, 0x63,
+	0x1f, 0x7b, 0x47, 0x65, 0x66, 0x71, 0x67, 0x28, 0x1b, 0xf2, 0x1e, 0xf1, 0x38, 0x43, 0x17, 0xe5,
+	0x04, 0x76, 0xa0, 0xa5, 0xd6, 0x21, 0xcf, 0x5e, 0xca, 0x20, 0x8d, 0x9e, 0xd3, 0x34, 0x29, 0x56,
+	0x52, 0x73, 0xc4, 0x27, 0xc0, 0x17, 0x59, 0x93, 0xa7, 0x96, 0x49, 0xc0, 0xed, 0x67, 0xc8, 0x3e,
+	0x31, 0x48, 0x91, 0x5a, 0x3a, 0x81, 0xf8, 0x14, 0x3a, 0x7a, 0x33, 0x71, 0x59, 0xa8, 0x4a, 0xeb,
+	0xb8, 0x9b, 0xdd, 0x25, 0xa1, 0xd8, 0xec, 0xb1, 0x43, 0xe8, 0xf1, 0x64, 0xab, 0xaa, 0xc3, 0x62,
+	0xf9, 0x9e, 0xbb, 0x3d, 0xff, 0x64, 0x37, 0xdf, 0x9e, 0x86, 0x0e, 0xc6, 0x27, 0x2e, 0xb2, 0x1c,
+	0xa7, 0x19, 0xb0, 0xb4, 0xe9, 0x1e, 0x2b, 0x5a, 0x96, 0x0c, 0x71, 0x04, 0x8f, 0x16, 0x09, 0xea,
+	0x4d, 0xc3, 0x19, 0x8e, 0x3f, 0x4f, 0xc3, 0x5e, 0xf5, 0x4d, 0xc0, 0xe5, 0x6a, 0xc8, 0x5d, 0x12,
+	0x92, 0x2c, 0xa3, 0x55, 0x54, 0x5b, 0x82, 0x76, 0x43, 0x80, 0x65, 0xb6, 0x0a, 0x03, 0xaf, 0x4c,
+	0x47, 0x97, 0x27, 0xeb, 0x8e, 0x86, 0x8f, 0x75, 0x32, 0x9e, 0x40, 0xb7, 0x14, 0xe4, 0xa4, 0xf4,
+	0x58, 0xaa, 0xa3, 0x31, 0x4c, 0x4d, 0x76, 0x46, 0x79, 0x6e, 0xe1, 0x6f, 0xdb, 0x36, 0x9d, 0x14,
+	0xda, 0xda, 0x16, 0xcd, 0x3a, 0x7e, 0x3d, 0x8d, 0xae, 0x22, 0xd3, 0xab, 0x17, 0x08, 0x1a, 0x33,
+	0x42, 0xb3, 0xa1, 0xdc, 0x4b, 0xaa, 0x76, 0x4b, 0x92, 0xc2, 0x5c, 0x3e, 0x0a, 0x8d, 0xf2, 0xe4,
+	0xa0, 0x30, 0x97, 0x81, 0xc0, 0x7a, 0x80, 0x59, 0x75, 0x76, 0x4e, 0x00, 0x36, 0x1c, 0x76, 0x38,
+	0xca, 0x56, 0xb1, 0xbf, 0xde, 0xde, 0x28, 0x1d, 0x8d, 0xf1, 0x52, 0xa1, 0x41, 0xb0, 0x0c, 0xc2,
+	0xd7, 0xfa, 0xa3, 0x47, 0x11, 0xce, 0x4f, 0x60, 0x96, 0x3d, 0x28, 0x9e, 0x81, 0xa9, 0xbb, 0x70,
+	0xad, 0xcb, 0xf4, 0x9d, 0xaa, 0x41, 0xab, 0x83, 0xee, 0xeb, 0x52, 0x90, 0x9a, 0xf5, 0x0e, 0xeb,
+	0x3f, 0x35, 0xeb, 0xcf, 0xd0, 0xe4, 0x51, 0xff, 0xa6, 0x0f, 0x16, 0xfa, 0xda, 0xa2, 0xaf, 0x01,
+	0xc5, 0x56, 0xdb, 0x90, 0xbf, 0x0f, 0x14, 0x9b, 0x82, 0x9e, 0xe4, 0x7e, 0x79, 0xbd, 0xae, 0x83,
+	0x4e, 0x90, 0x12, 0xc0, 0xf9, 0x39, 0x4d, 0x93, 0xdb, 0x70, 0xc9, 0x1d, 0x61, 0x4a, 0x4d, 0x4d,
+	0x5b, 0xfc, 0x29, 0xf1, 0xec, 0x2f, 0xfd, 0xde, 0x5c, 0xc0, 0x77, 0x0a, 0x00, 0x00,
 }
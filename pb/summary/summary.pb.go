@@ -45,6 +45,13 @@ const (
 	DashboardTabSummary_FAIL    DashboardTabSummary_TabStatus = 3
 	DashboardTabSummary_FLAKY   DashboardTabSummary_TabStatus = 4
 	DashboardTabSummary_STALE   DashboardTabSummary_TabStatus = 5
+	// The tab cannot summarize because of its configuration, such as a
+	// missing test group.
+	DashboardTabSummary_BROKEN DashboardTabSummary_TabStatus = 6
+	// The dashboard is paused, so the tab is not summarized.
+	DashboardTabSummary_PAUSED DashboardTabSummary_TabStatus = 7
+	// The tab has fewer columns than its minimum_number_of_runs.
+	DashboardTabSummary_PENDING DashboardTabSummary_TabStatus = 8
 )
 
 var DashboardTabSummary_TabStatus_name = map[int32]string{
@@ -54,6 +61,9 @@ var DashboardTabSummary_TabStatus_name = map[int32]string{
 	3: "FAIL",
 	4: "FLAKY",
 	5: "STALE",
+	6: "BROKEN",
+	7: "PAUSED",
+	8: "PENDING",
 }
 
 var DashboardTabSummary_TabStatus_value = map[string]int32{
@@ -63,6 +73,9 @@ var DashboardTabSummary_TabStatus_value = map[string]int32{
 	"FAIL":    3,
 	"FLAKY":   4,
 	"STALE":   5,
+	"BROKEN":  6,
+	"PAUSED":  7,
+	"PENDING": 8,
 }
 
 func (x DashboardTabSummary_TabStatus) String() string {
@@ -100,7 +113,13 @@ type FailingTestSummary struct {
 	// List of bug IDs for bugs associated with this test.
 	LinkedBugs []string `protobuf:"bytes,12,rep,name=linked_bugs,json=linkedBugs,proto3" json:"linked_bugs,omitempty"`
 	// A link to the first build in which the test failed.
-	FailTestLink         string   `protobuf:"bytes,13,opt,name=fail_test_link,json=failTestLink,proto3" json:"fail_test_link,omitempty"`
+	FailTestLink string `protobuf:"bytes,13,opt,name=fail_test_link,json=failTestLink,proto3" json:"fail_test_link,omitempty"`
+	// Build ID of the newest build in which the test failed.
+	LatestFailBuildId string `protobuf:"bytes,14,opt,name=latest_fail_build_id,json=latestFailBuildId,proto3" json:"latest_fail_build_id,omitempty"`
+	// Timestamp for the start of the newest build in which the test failed.
+	LatestFailTimestamp float64 `protobuf:"fixed64,15,opt,name=latest_fail_timestamp,json=latestFailTimestamp,proto3" json:"latest_fail_timestamp,omitempty"`
+	// A link to the artifacts of the newest build in which the test failed, when known.
+	LatestFailLink       string   `protobuf:"bytes,16,opt,name=latest_fail_link,json=latestFailLink,proto3" json:"latest_fail_link,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -222,6 +241,27 @@ func (m *FailingTestSummary) GetFailTestLink() string {
 	return ""
 }
 
+func (m *FailingTestSummary) GetLatestFailBuildId() string {
+	if m != nil {
+		return m.LatestFailBuildId
+	}
+	return ""
+}
+
+func (m *FailingTestSummary) GetLatestFailTimestamp() float64 {
+	if m != nil {
+		return m.LatestFailTimestamp
+	}
+	return 0
+}
+
+func (m *FailingTestSummary) GetLatestFailLink() string {
+	if m != nil {
+		return m.LatestFailLink
+	}
+	return ""
+}
+
 // Summary of a dashboard tab.
 type DashboardTabSummary struct {
 	// The name of the dashboard.
@@ -243,10 +283,12 @@ type DashboardTabSummary struct {
 	// Seconds since epoch at which tests last ran.
 	LastRunTimestamp float64 `protobuf:"fixed64,9,opt,name=last_run_timestamp,json=lastRunTimestamp,proto3" json:"last_run_timestamp,omitempty"`
 	// String indicating the URL for linking to a bug.
-	BugUrl               string   `protobuf:"bytes,10,opt,name=bug_url,json=bugUrl,proto3" json:"bug_url,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	BugUrl string `protobuf:"bytes,10,opt,name=bug_url,json=bugUrl,proto3" json:"bug_url,omitempty"`
+	// The flakiest tests in recent columns, most flaky first.
+	FlakyTests           []*FlakyTestSummary `protobuf:"bytes,11,rep,name=flaky_tests,json=flakyTests,proto3" json:"flaky_tests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
 func (m *DashboardTabSummary) Reset()         { *m = DashboardTabSummary{} }
@@ -344,6 +386,13 @@ func (m *DashboardTabSummary) GetBugUrl() string {
 	return ""
 }
 
+func (m *DashboardTabSummary) GetFlakyTests() []*FlakyTestSummary {
+	if m != nil {
+		return m.FlakyTests
+	}
+	return nil
+}
+
 // Summary state of a dashboard.
 type DashboardSummary struct {
 	// Summary of a dashboard tab; see config.proto.
@@ -385,54 +434,120 @@ func (m *DashboardSummary) GetTabSummaries() []*DashboardTabSummary {
 	return nil
 }
 
+// Summary of a flaky test.
+type FlakyTestSummary struct {
+	// Display name of the test.
+	DisplayName string `protobuf:"bytes,1,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	// Percentage of recent results that flipped between passing and failing.
+	Flakiness float32 `protobuf:"fixed32,2,opt,name=flakiness,proto3" json:"flakiness,omitempty"`
+	// Number of times recent results flipped between passing and failing.
+	Transitions          int32    `protobuf:"varint,3,opt,name=transitions,proto3" json:"transitions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FlakyTestSummary) Reset()         { *m = FlakyTestSummary{} }
+func (m *FlakyTestSummary) String() string { return proto.CompactTextString(m) }
+func (*FlakyTestSummary) ProtoMessage()    {}
+func (*FlakyTestSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{3}
+}
+
+func (m *FlakyTestSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FlakyTestSummary.Unmarshal(m, b)
+}
+func (m *FlakyTestSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FlakyTestSummary.Marshal(b, m, deterministic)
+}
+func (m *FlakyTestSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FlakyTestSummary.Merge(m, src)
+}
+func (m *FlakyTestSummary) XXX_Size() int {
+	return xxx_messageInfo_FlakyTestSummary.Size(m)
+}
+func (m *FlakyTestSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_FlakyTestSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FlakyTestSummary proto.InternalMessageInfo
+
+func (m *FlakyTestSummary) GetDisplayName() string {
+	if m != nil {
+		return m.DisplayName
+	}
+	return ""
+}
+
+func (m *FlakyTestSummary) GetFlakiness() float32 {
+	if m != nil {
+		return m.Flakiness
+	}
+	return 0
+}
+
+func (m *FlakyTestSummary) GetTransitions() int32 {
+	if m != nil {
+		return m.Transitions
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("DashboardTabSummary_TabStatus", DashboardTabSummary_TabStatus_name, DashboardTabSummary_TabStatus_value)
 	proto.RegisterType((*FailingTestSummary)(nil), "FailingTestSummary")
 	proto.RegisterType((*DashboardTabSummary)(nil), "DashboardTabSummary")
 	proto.RegisterType((*DashboardSummary)(nil), "DashboardSummary")
+	proto.RegisterType((*FlakyTestSummary)(nil), "FlakyTestSummary")
 }
 
 func init() { proto.RegisterFile("summary.proto", fileDescriptor_f7168d0e3f3f5589) }
 
 var fileDescriptor_f7168d0e3f3f5589 = []byte{
-	// 610 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x94, 0xdf, 0x6e, 0xd3, 0x4a,
-	0x10, 0xc6, 0x8f, 0x9b, 0x3f, 0xad, 0x27, 0x71, 0x6a, 0x6d, 0x7b, 0x7a, 0x2c, 0x1d, 0x01, 0x21,
-	0xa2, 0x90, 0x0b, 0x94, 0x8b, 0x72, 0xc5, 0x65, 0x0a, 0x2d, 0xaa, 0x9a, 0xa6, 0xc8, 0x71, 0x84,
-	0xb8, 0xb2, 0xd6, 0x78, 0x6b, 0xac, 0xae, 0x9d, 0xc8, 0xbb, 0x8b, 0xda, 0x47, 0xe1, 0x05, 0x79,
-	0x0e, 0x34, 0xb3, 0x4e, 0x1c, 0x41, 0xef, 0x76, 0x7f, 0xf3, 0x79, 0x66, 0x76, 0xbe, 0x49, 0xc0,
-	0x53, 0xa6, 0x28, 0x78, 0xf5, 0x38, 0x59, 0x57, 0x2b, 0xbd, 0x1a, 0xfd, 0x6a, 0x01, 0xbb, 0xe4,
-	0xb9, 0xcc, 0xcb, 0x2c, 0x12, 0x4a, 0x2f, 0x6c, 0x90, 0xbd, 0x84, 0x7e, 0x9a, 0xab, 0xb5, 0xe4,
-	0x8f, 0x71, 0xc9, 0x0b, 0x11, 0x38, 0x43, 0x67, 0xec, 0x86, 0xbd, 0x9a, 0xcd, 0x79, 0x21, 0xd8,
-	0xff, 0xe0, 0x6a, 0xa1, 0xb4, 0x8d, 0xef, 0x51, 0xfc, 0x00, 0x01, 0x05, 0x47, 0xe0, 0xdd, 0xf1,
-	0x5c, 0xc6, 0x89, 0xc9, 0x65, 0x1a, 0xe7, 0x69, 0xd0, 0xb2, 0x09, 0x10, 0x9e, 0x23, 0xbb, 0x4a,
-	0xd9, 0x29, 0x0c, 0x48, 0xa3, 0xf3, 0x42, 0x28, 0xcd, 0x8b, 0x75, 0xd0, 0x1e, 0x3a, 0x63, 0x27,
-	0xa4, 0x2f, 0xa3, 0x0d, 0xc4, 0x54, 0x6b, 0xae, 0x54, 0x93, 0xaa, 0x63, 0x53, 0x21, 0xdc, 0x49,
-	0x45, 0x9a, 0x26, 0x55, 0xd7, 0xa6, 0x42, 0xda, 0xa4, 0x7a, 0x06, 0x40, 0x15, 0xbf, 0xad, 0x4c,
-	0xa9, 0x83, 0xfd, 0xa1, 0x33, 0xee, 0x84, 0x2e, 0x92, 0x0f, 0x08, 0x30, 0x6c, 0x8b, 0xc8, 0xbc,
-	0xbc, 0x0f, 0x0e, 0xa8, 0x8c, 0x4b, 0x64, 0x96, 0x97, 0xf7, 0xec, 0x35, 0x1c, 0x36, 0xe1, 0x58,
-	0x8b, 0x07, 0x1d, 0xb8, 0xa4, 0xf1, 0xb6, 0x9a, 0x48, 0x3c, 0x68, 0xf6, 0x0a, 0x06, 0x56, 0x67,
-	0x2a, 0x69, 0x65, 0x40, 0xb2, 0x3e, 0xd1, 0x65, 0x25, 0x49, 0xf5, 0x06, 0x0e, 0xb1, 0xb2, 0xa9,
-	0x44, 0x5c, 0x08, 0xa5, 0x78, 0x26, 0x82, 0x1e, 0xc9, 0x06, 0x35, 0xbe, 0xb1, 0x94, 0xbd, 0x80,
-	0x1e, 0x16, 0x14, 0x69, 0x9c, 0x98, 0x4c, 0x05, 0xfd, 0x61, 0x6b, 0xec, 0x86, 0x60, 0xd1, 0xb9,
-	0xc9, 0x14, 0xd6, 0xb3, 0x73, 0x44, 0x37, 0xa8, 0x75, 0xcf, 0xd6, 0xa3, 0x39, 0x0a, 0xa5, 0xb1,
-	0xb3, 0xd1, 0xcf, 0x36, 0x1c, 0x7d, 0xe4, 0xea, 0x7b, 0xb2, 0xe2, 0x55, 0x1a, 0xf1, 0x64, 0xe3,
-	0xf4, 0x29, 0x0c, 0xd2, 0x0d, 0xde, 0xf5, 0xda, 0xdb, 0x52, 0x32, 0xf4, 0x2d, 0xb0, 0x46, 0xa6,
-	0x79, 0xb2, 0x6b, 0xbb, 0x9f, 0xee, 0xe4, 0x25, 0xf5, 0x31, 0x74, 0xb8, 0x14, 0x95, 0xae, 0x6d,
-	0xb7, 0x17, 0x76, 0x05, 0x27, 0x77, 0x76, 0xd5, 0x6c, 0xaf, 0x76, 0x13, 0x73, 0xa1, 0x82, 0xf6,
-	0xb0, 0x35, 0xee, 0x9d, 0x1d, 0x4d, 0xfe, 0xde, 0xc4, 0xf0, 0xf8, 0xee, 0x4f, 0x96, 0x0b, 0xc5,
-	0xce, 0xe0, 0x5f, 0xc9, 0x95, 0x8e, 0xcd, 0x3a, 0xe5, 0x5a, 0xec, 0xf8, 0xde, 0x21, 0xdf, 0x8f,
-	0x30, 0xb8, 0xa4, 0x58, 0xe3, 0xfe, 0x09, 0x74, 0x95, 0xe6, 0xda, 0x28, 0x5a, 0x0e, 0x37, 0xac,
-	0x6f, 0xec, 0x02, 0x06, 0xab, 0x1f, 0xa2, 0xe2, 0x52, 0xc6, 0x75, 0x1c, 0x37, 0x63, 0x70, 0xf6,
-	0x7c, 0xf2, 0xc4, 0xbc, 0x26, 0x78, 0x24, 0x55, 0xe8, 0xd5, 0x5f, 0xd9, 0x2b, 0xfe, 0x64, 0x24,
-	0xa7, 0x77, 0x65, 0x95, 0x10, 0x65, 0xbd, 0x3f, 0x3d, 0xcb, 0x3e, 0x21, 0xc2, 0x21, 0x52, 0xd7,
-	0x95, 0x29, 0x77, 0x5a, 0x76, 0xa9, 0x65, 0x1f, 0x23, 0xa1, 0x29, 0x9b, 0x7e, 0xff, 0x83, 0xfd,
-	0xc4, 0x64, 0xb8, 0x45, 0xf5, 0x02, 0x75, 0x13, 0x93, 0x2d, 0x2b, 0x39, 0xba, 0x05, 0x77, 0xdb,
-	0x05, 0xeb, 0xc1, 0xfe, 0xfc, 0x36, 0x8a, 0x17, 0x17, 0x91, 0xff, 0x0f, 0x5e, 0x96, 0xf3, 0xeb,
-	0xf9, 0xed, 0x97, 0xb9, 0xef, 0xb0, 0x03, 0x68, 0x7f, 0x9e, 0x2e, 0x16, 0xfe, 0x1e, 0x9e, 0x2e,
-	0xa7, 0x57, 0x33, 0xbf, 0xc5, 0x5c, 0xe8, 0x5c, 0xce, 0xa6, 0xd7, 0x5f, 0xfd, 0x36, 0x1e, 0x17,
-	0xd1, 0x74, 0x76, 0xe1, 0x77, 0x46, 0x37, 0xe0, 0x6f, 0x9f, 0xba, 0xd9, 0x8b, 0xf7, 0xe0, 0xa1,
-	0xcd, 0x8d, 0x47, 0x0e, 0x79, 0x74, 0xfc, 0xd4, 0x50, 0xc2, 0xbe, 0xde, 0x9c, 0x73, 0xa1, 0x92,
-	0x2e, 0xfd, 0xb5, 0xbc, 0xfb, 0x1d, 0x00, 0x00, 0xff, 0xff, 0xd7, 0x49, 0xcd, 0xf5, 0x6b, 0x04,
-	0x00, 0x00,
+	// 719 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8d, 0x54, 0xcb, 0x6e, 0xda, 0x40,
+	0x14, 0x2d, 0xe1, 0xe9, 0x6b, 0x20, 0xce, 0x84, 0xa6, 0x96, 0xfa, 0x4a, 0x51, 0x1f, 0x59, 0x54,
+	0x54, 0x4a, 0x57, 0x5d, 0x92, 0x06, 0x22, 0x14, 0xe2, 0x44, 0x06, 0x54, 0x75, 0x85, 0x86, 0xda,
+	0x50, 0x2b, 0xc6, 0x20, 0xcf, 0xb8, 0x6a, 0x7e, 0xbb, 0x52, 0xf7, 0x9d, 0x7b, 0xc7, 0xc6, 0x6e,
+	0x9a, 0x45, 0x77, 0x33, 0xe7, 0x9e, 0xfb, 0x98, 0x33, 0x67, 0x06, 0x5a, 0x22, 0x59, 0xaf, 0x79,
+	0x7c, 0xd7, 0xdb, 0xc6, 0x1b, 0xb9, 0xe9, 0xfe, 0xae, 0x00, 0x1b, 0xf2, 0x20, 0x0c, 0xa2, 0xd5,
+	0xd4, 0x17, 0x72, 0xa2, 0x83, 0xec, 0x15, 0x34, 0xbd, 0x40, 0x6c, 0x43, 0x7e, 0x37, 0x8f, 0xf8,
+	0xda, 0xb7, 0x4b, 0xc7, 0xa5, 0x13, 0xc3, 0x35, 0x53, 0xcc, 0x51, 0x10, 0x7b, 0x0a, 0x86, 0x54,
+	0x19, 0x3a, 0xbe, 0x47, 0xf1, 0x06, 0x02, 0x14, 0xec, 0x42, 0x6b, 0xa9, 0xaa, 0xce, 0x17, 0x49,
+	0x10, 0x7a, 0xf3, 0xc0, 0xb3, 0xcb, 0xba, 0x00, 0x82, 0x67, 0x88, 0x8d, 0x3c, 0xf6, 0x06, 0xda,
+	0xc4, 0x91, 0xc1, 0x5a, 0xa5, 0xf1, 0xf5, 0xd6, 0xae, 0x28, 0x52, 0xc9, 0xa5, 0xcc, 0x69, 0x06,
+	0x62, 0xa9, 0x2d, 0x17, 0x22, 0x2f, 0x55, 0xd5, 0xa5, 0x10, 0x2c, 0x94, 0x22, 0x4e, 0x5e, 0xaa,
+	0xa6, 0x4b, 0x21, 0x9a, 0x97, 0x7a, 0x0e, 0x40, 0x1d, 0xbf, 0x6d, 0x92, 0x48, 0xda, 0x75, 0x45,
+	0xa9, 0xba, 0x06, 0x22, 0x9f, 0x11, 0xc0, 0xb0, 0x6e, 0xa2, 0xd4, 0xb8, 0xb5, 0x1b, 0xd4, 0xc6,
+	0x20, 0x64, 0xac, 0x00, 0xf6, 0x16, 0xf6, 0xf3, 0xf0, 0x5c, 0xfa, 0x3f, 0xa5, 0x6d, 0x10, 0xa7,
+	0xb5, 0xe3, 0x4c, 0x15, 0xc8, 0x5e, 0x43, 0x5b, 0xf3, 0x92, 0x38, 0xd4, 0x34, 0x20, 0x5a, 0x93,
+	0xd0, 0x59, 0x1c, 0x12, 0xeb, 0x1d, 0xec, 0x63, 0xe7, 0x24, 0xf6, 0xe7, 0x6a, 0x3c, 0xc1, 0x57,
+	0xbe, 0x6d, 0x12, 0xad, 0x9d, 0xc2, 0x57, 0x1a, 0x65, 0x2f, 0xc1, 0xc4, 0x86, 0xbe, 0xa7, 0x14,
+	0x58, 0x09, 0xbb, 0x79, 0x5c, 0x56, 0x24, 0xd0, 0xd0, 0x99, 0x42, 0xb0, 0x9f, 0xd6, 0x11, 0x6f,
+	0x83, 0x46, 0x6f, 0xe9, 0x7e, 0xa4, 0xa3, 0x02, 0x69, 0xfa, 0x0f, 0xd0, 0x09, 0x39, 0x51, 0xfe,
+	0xbe, 0x98, 0x36, 0x71, 0x0f, 0x74, 0x6c, 0x58, 0xb8, 0x9e, 0x53, 0x78, 0x5c, 0x4c, 0xc8, 0xa5,
+	0xdd, 0x27, 0x69, 0x0f, 0xf3, 0x8c, 0x5c, 0xe0, 0x13, 0xb0, 0x8a, 0x39, 0x34, 0x8c, 0xa5, 0x4f,
+	0x95, 0xd3, 0x71, 0x9c, 0xee, 0xaf, 0x0a, 0x1c, 0x9e, 0x73, 0xf1, 0x7d, 0xb1, 0xe1, 0xb1, 0x37,
+	0xe5, 0x8b, 0xcc, 0x78, 0xea, 0x26, 0xbd, 0x0c, 0x2e, 0x5a, 0xaf, 0xb5, 0x43, 0xc9, 0x5f, 0xef,
+	0x81, 0xe5, 0x34, 0xc9, 0x17, 0x45, 0x17, 0x5a, 0x5e, 0xa1, 0x2e, 0xb1, 0x3b, 0x50, 0xe5, 0xa1,
+	0x1f, 0xcb, 0xd4, 0x85, 0x7a, 0xc3, 0x46, 0x70, 0xb4, 0xd4, 0xce, 0xd7, 0xd2, 0xe9, 0x87, 0x11,
+	0xf8, 0x42, 0xf9, 0xb0, 0x7c, 0x62, 0x9e, 0x1e, 0xf6, 0xfe, 0x7d, 0x18, 0x6e, 0x67, 0x79, 0x1f,
+	0x53, 0x09, 0x5a, 0x2b, 0x55, 0x22, 0xd9, 0x7a, 0xea, 0x98, 0x05, 0xad, 0xaa, 0x99, 0x56, 0x42,
+	0xce, 0x28, 0x96, 0x6b, 0x75, 0x04, 0x35, 0xb5, 0x90, 0x89, 0x20, 0xaf, 0x1a, 0x6e, 0xba, 0x63,
+	0x03, 0x68, 0x6f, 0x7e, 0xf8, 0x31, 0x0f, 0xc3, 0x79, 0x1a, 0x47, 0xa3, 0xb6, 0x4f, 0x5f, 0xf4,
+	0x1e, 0xd0, 0xab, 0x87, 0x4b, 0x62, 0xb9, 0xad, 0x34, 0x4b, 0x6f, 0xf1, 0x05, 0xa7, 0x57, 0xb1,
+	0x8a, 0x7d, 0x3f, 0x4a, 0xed, 0x6c, 0x6a, 0xec, 0x02, 0x21, 0x14, 0x91, 0xa6, 0x8e, 0x93, 0xa8,
+	0x30, 0xb2, 0x41, 0x23, 0x5b, 0x18, 0x71, 0x93, 0x28, 0x9f, 0xf7, 0x09, 0xd4, 0x95, 0x01, 0xd1,
+	0xd4, 0xa9, 0x9f, 0x6b, 0x6a, 0xab, 0xdc, 0xac, 0x0e, 0x6f, 0x2e, 0x43, 0x7e, 0x7b, 0x47, 0x2a,
+	0x0a, 0xe5, 0x62, 0x14, 0xef, 0xa0, 0x37, 0x44, 0xac, 0x28, 0x1d, 0x2c, 0x33, 0x44, 0x74, 0x25,
+	0x18, 0xbb, 0xc9, 0x99, 0x09, 0x75, 0xe7, 0x7a, 0x3a, 0x9f, 0x0c, 0xa6, 0xd6, 0x23, 0xdc, 0xcc,
+	0x9c, 0x4b, 0xe7, 0xfa, 0x8b, 0x63, 0x95, 0x58, 0x03, 0x2a, 0x37, 0xfd, 0xc9, 0xc4, 0xda, 0xc3,
+	0xd5, 0xb0, 0x3f, 0x1a, 0x5b, 0x65, 0x66, 0x40, 0x75, 0x38, 0xee, 0x5f, 0x7e, 0xb5, 0x2a, 0xb8,
+	0x9c, 0x4c, 0xfb, 0xe3, 0x81, 0x55, 0x65, 0x00, 0xb5, 0x33, 0xf7, 0xfa, 0x72, 0xe0, 0x58, 0x35,
+	0x5c, 0xdf, 0xf4, 0x67, 0x93, 0xc1, 0xb9, 0x55, 0xc7, 0x72, 0x37, 0x03, 0xe7, 0x7c, 0xe4, 0x5c,
+	0x58, 0x8d, 0xee, 0x15, 0x58, 0x3b, 0x0d, 0x33, 0xc3, 0x7d, 0x82, 0x16, 0xfa, 0x27, 0xbf, 0xfc,
+	0x12, 0xcd, 0xdf, 0x79, 0x48, 0x6d, 0xb7, 0x29, 0xb3, 0xb5, 0x62, 0x76, 0x13, 0xb0, 0xee, 0x1f,
+	0xf2, 0x7f, 0x3e, 0xce, 0x67, 0x60, 0xa0, 0x12, 0x41, 0xa4, 0x1e, 0x38, 0x59, 0x76, 0xcf, 0xcd,
+	0x01, 0x76, 0x0c, 0xa6, 0x8c, 0x79, 0x24, 0x02, 0x19, 0x6c, 0x22, 0x41, 0x8e, 0xad, 0xba, 0x45,
+	0x68, 0x51, 0xa3, 0x9f, 0xfb, 0xe3, 0x1f, 0x43, 0x45, 0x48, 0xac, 0xca, 0x05, 0x00, 0x00,
 }
@@ -0,0 +1,236 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package alerter mails the alerts that open and resolve between dashboard tab summaries.
+//
+// The package is a library: no command constructs an Alerter yet. A caller, such as the
+// summarizer, passes Alerter.Update the previous and current summary of each tab along with its grid.
+package alerter
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+// Options configures when an alert opens and resolves.
+type Options struct {
+	// FailuresToAlert is the number of failures that open an alert, or zero to never alert.
+	FailuresToAlert int
+	// PassesToResolve is the number of consecutive passes that resolve an alert.
+	PassesToResolve int
+}
+
+// TabOptions returns the alert options of the tab, where unset fields inherit those of the group.
+//
+// An alert resolves after one pass unless the tab or group requires more.
+func TabOptions(tab *configpb.DashboardTab, group *configpb.TestGroup) Options {
+	alert := config.EffectiveAlertOptions(tab, group)
+	opts := Options{
+		FailuresToAlert: int(alert.GetNumFailuresToAlert()),
+		PassesToResolve: int(alert.GetNumPassesToDisableAlert()),
+	}
+	if opts.PassesToResolve < 1 {
+		opts.PassesToResolve = 1
+	}
+	return opts
+}
+
+// Changes lists the alerts that opened and resolved since the previous summary.
+type Changes struct {
+	Opened   []*summarypb.FailingTestSummary
+	Resolved []*summarypb.FailingTestSummary
+}
+
+// Empty returns true when no alert changed.
+func (c Changes) Empty() bool {
+	return len(c.Opened) == 0 && len(c.Resolved) == 0
+}
+
+// diff returns the alerts that opened and resolved from prev to cur, updating open.
+//
+// The open set holds the display name of each test with an open alert, which
+// dedupes mails for a row that flaps between failing and passing: a test only
+// reopens after it resolves, and only resolves after passesToResolve consecutive
+// passes in the grid rows, even when the summary no longer lists it.
+// Broken columns of the grid neither pass nor break a run of passes.
+// Tests failing in prev without an open alert, such as after a restart, are
+// considered mailed.
+func diff(prev, cur *summarypb.DashboardTabSummary, grid *statepb.Grid, opts Options, open map[string]*summarypb.FailingTestSummary) Changes {
+	var changes Changes
+	if opts.FailuresToAlert < 1 {
+		return changes
+	}
+	wasFailing := failing(prev, opts)
+	nowFailing := failing(cur, opts)
+	for _, f := range cur.GetFailingTestSummaries() {
+		if !nowFailing[f.DisplayName] {
+			continue
+		}
+		if _, ok := open[f.DisplayName]; !ok && !wasFailing[f.DisplayName] {
+			changes.Opened = append(changes.Opened, f)
+		}
+		open[f.DisplayName] = f
+	}
+	for _, f := range prev.GetFailingTestSummaries() {
+		if _, ok := open[f.DisplayName]; !ok && wasFailing[f.DisplayName] && !nowFailing[f.DisplayName] {
+			open[f.DisplayName] = f
+		}
+	}
+
+	passes := map[string]int{}
+	for _, r := range grid.GetRows() {
+		passes[r.Name] = leadingPasses(grid.GetColumns(), r.Results)
+	}
+	names := make([]string, 0, len(open))
+	for name := range open {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if nowFailing[name] {
+			continue
+		}
+		// Rows missing from the grid resolve immediately.
+		if n, found := passes[name]; found && n < opts.PassesToResolve {
+			continue
+		}
+		changes.Resolved = append(changes.Resolved, open[name])
+		delete(open, name)
+	}
+	return changes
+}
+
+// failing returns the display name of each test in the summary with at least FailuresToAlert failures.
+func failing(sum *summarypb.DashboardTabSummary, opts Options) map[string]bool {
+	out := map[string]bool{}
+	for _, f := range sum.GetFailingTestSummaries() {
+		if int(f.FailCount) >= opts.FailuresToAlert {
+			out[f.DisplayName] = true
+		}
+	}
+	return out
+}
+
+// leadingPasses returns the number of consecutive passes in the most recent results, ignoring empty and running cells.
+//
+// Cells of broken columns are also ignored.
+func leadingPasses(cols []*statepb.Column, results []int32) int {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var n, col int
+	for res := range result.Iter(ctx, results) {
+		broken := col < len(cols) && cols[col].GetStats().GetBroken()
+		col++
+		if broken {
+			continue
+		}
+		res = result.Coalesce(res, result.IgnoreRunning)
+		if res == statepb.Row_NO_RESULT {
+			continue
+		}
+		if res != statepb.Row_PASS {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+// Alerter mails alert changes for dashboard tabs.
+//
+// Alerter remembers which alerts it mailed in memory, and is safe for concurrent use.
+type Alerter struct {
+	mailer Mailer
+	from   string
+
+	lock sync.Mutex
+	open map[string]map[string]*summarypb.FailingTestSummary // dashboard/tab => display name => open alert
+}
+
+// New returns an Alerter that sends mail from the address using mailer.
+func New(mailer Mailer, from string) *Alerter {
+	return &Alerter{
+		mailer: mailer,
+		from:   from,
+		open:   map[string]map[string]*summarypb.FailingTestSummary{},
+	}
+}
+
+// Update mails the alerts that opened or resolved between the prev and cur summaries of the tab.
+//
+// The grid is the current grid of the tab, whose rows determine when an alert resolves.
+// The group provides the gcs_prefix for links, along with any alert options the tab leaves unset.
+// Tabs without any alert_mail_to_addresses, even from the group, never mail.
+// Changes count as mailed even when sending fails, so the next update does not mail them again.
+func (a *Alerter) Update(ctx context.Context, dashboard string, tab *configpb.DashboardTab, group *configpb.TestGroup, prev, cur *summarypb.DashboardTabSummary, grid *statepb.Grid) (Changes, error) {
+	a.lock.Lock()
+	key := dashboard + "/" + tab.Name
+	open, ok := a.open[key]
+	if !ok {
+		open = map[string]*summarypb.FailingTestSummary{}
+		a.open[key] = open
+	}
+	changes := diff(prev, cur, grid, TabOptions(tab, group), open)
+	a.lock.Unlock()
+
+	if changes.Empty() {
+		return changes, nil
+	}
+	to := recipients(config.EffectiveAlertOptions(tab, group).GetAlertMailToAddresses())
+	log := logrus.WithFields(logrus.Fields{
+		"dashboard": dashboard,
+		"tab":       tab.Name,
+		"opened":    len(changes.Opened),
+		"resolved":  len(changes.Resolved),
+	})
+	if len(to) == 0 {
+		log.Debug("No alert recipients")
+		return changes, nil
+	}
+	msg, err := render(dashboard, tab, group, changes)
+	if err != nil {
+		return changes, fmt.Errorf("render: %w", err)
+	}
+	msg.From = a.from
+	msg.To = to
+	if err := a.mailer.Send(ctx, *msg); err != nil {
+		return changes, fmt.Errorf("send: %w", err)
+	}
+	log.WithField("to", to).Info("Mailed alerts")
+	return changes, nil
+}
+
+// recipients splits the comma-separated addresses.
+func recipients(addresses string) []string {
+	var out []string
+	for _, addr := range strings.Split(addresses, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			out = append(out, addr)
+		}
+	}
+	return out
+}
@@ -0,0 +1,373 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerter
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func summary(failures map[string]int32) *summarypb.DashboardTabSummary {
+	var sum summarypb.DashboardTabSummary
+	for _, name := range []string{"a", "b", "c"} {
+		if n, ok := failures[name]; ok {
+			sum.FailingTestSummaries = append(sum.FailingTestSummaries, &summarypb.FailingTestSummary{
+				DisplayName: name,
+				TestName:    "//" + name,
+				FailCount:   n,
+			})
+		}
+	}
+	return &sum
+}
+
+func row(name string, results ...int32) *statepb.Row {
+	return &statepb.Row{Name: name, Results: results}
+}
+
+var (
+	pass    = int32(statepb.Row_PASS)
+	fail    = int32(statepb.Row_FAIL)
+	running = int32(statepb.Row_RUNNING)
+	none    = int32(statepb.Row_NO_RESULT)
+
+	broken = &statepb.Column{Stats: &statepb.Stats{Broken: true}}
+)
+
+func names(fs []*summarypb.FailingTestSummary) []string {
+	var out []string
+	for _, f := range fs {
+		out = append(out, f.DisplayName)
+	}
+	return out
+}
+
+func TestDiff(t *testing.T) {
+	opts := Options{FailuresToAlert: 3, PassesToResolve: 2}
+	cases := []struct {
+		name     string
+		opts     *Options
+		open     []string
+		prev     *summarypb.DashboardTabSummary
+		cur      *summarypb.DashboardTabSummary
+		cols     []*statepb.Column
+		rows     []*statepb.Row
+		opened   []string
+		resolved []string
+		wantOpen []string
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name:     "newly failing",
+			prev:     summary(map[string]int32{"a": 2}),
+			cur:      summary(map[string]int32{"a": 3, "b": 4}),
+			rows:     []*statepb.Row{row("a", fail, 3), row("b", fail, 4)},
+			opened:   []string{"a", "b"},
+			wantOpen: []string{"a", "b"},
+		},
+		{
+			name:     "below the tab threshold",
+			cur:      summary(map[string]int32{"a": 2}),
+			rows:     []*statepb.Row{row("a", fail, 2)},
+			wantOpen: []string{},
+		},
+		{
+			name: "disabled",
+			opts: &Options{PassesToResolve: 1},
+			cur:  summary(map[string]int32{"a": 10}),
+		},
+		{
+			name:     "still failing",
+			open:     []string{"a"},
+			prev:     summary(map[string]int32{"a": 3}),
+			cur:      summary(map[string]int32{"a": 4}),
+			rows:     []*statepb.Row{row("a", fail, 4)},
+			wantOpen: []string{"a"},
+		},
+		{
+			name:     "failing before a restart",
+			prev:     summary(map[string]int32{"a": 3}),
+			cur:      summary(map[string]int32{"a": 4}),
+			rows:     []*statepb.Row{row("a", fail, 4)},
+			wantOpen: []string{"a"},
+		},
+		{
+			name:     "not enough passes to resolve",
+			open:     []string{"a"},
+			prev:     summary(map[string]int32{"a": 3}),
+			cur:      summary(nil),
+			rows:     []*statepb.Row{row("a", pass, 1, running, 1, fail, 3)},
+			wantOpen: []string{"a"},
+		},
+		{
+			name:     "resolved",
+			open:     []string{"a", "b"},
+			prev:     summary(map[string]int32{"a": 3, "b": 3}),
+			cur:      summary(map[string]int32{"b": 3}),
+			rows:     []*statepb.Row{row("a", pass, 1, none, 2, pass, 1, fail, 3), row("b", fail, 3)},
+			resolved: []string{"a"},
+			wantOpen: []string{"b"},
+		},
+		{
+			name:     "resolve rows no longer in the grid",
+			open:     []string{"a"},
+			cur:      summary(nil),
+			resolved: []string{"a"},
+			wantOpen: []string{},
+		},
+		{
+			name:     "broken columns do not count as passes",
+			open:     []string{"a"},
+			prev:     summary(map[string]int32{"a": 3}),
+			cur:      summary(nil),
+			cols:     []*statepb.Column{{}, broken},
+			rows:     []*statepb.Row{row("a", pass, 2, fail, 3)},
+			wantOpen: []string{"a"},
+		},
+		{
+			name:     "flapping row does not reopen",
+			open:     []string{"a"},
+			prev:     summary(nil),
+			cur:      summary(map[string]int32{"a": 3}),
+			rows:     []*statepb.Row{row("a", fail, 1, pass, 1, fail, 2)},
+			wantOpen: []string{"a"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			o := opts
+			if tc.opts != nil {
+				o = *tc.opts
+			}
+			open := map[string]*summarypb.FailingTestSummary{}
+			for _, name := range tc.open {
+				open[name] = &summarypb.FailingTestSummary{DisplayName: name}
+			}
+			got := diff(tc.prev, tc.cur, &statepb.Grid{Columns: tc.cols, Rows: tc.rows}, o, open)
+			if !reflect.DeepEqual(names(got.Opened), tc.opened) {
+				t.Errorf("diff() opened %v, want %v", names(got.Opened), tc.opened)
+			}
+			if !reflect.DeepEqual(names(got.Resolved), tc.resolved) {
+				t.Errorf("diff() resolved %v, want %v", names(got.Resolved), tc.resolved)
+			}
+			if tc.wantOpen != nil {
+				var gotOpen []string
+				for _, name := range []string{"a", "b", "c"} {
+					if _, ok := open[name]; ok {
+						gotOpen = append(gotOpen, name)
+					}
+				}
+				if len(gotOpen) == 0 {
+					gotOpen = []string{}
+				}
+				if !reflect.DeepEqual(gotOpen, tc.wantOpen) {
+					t.Errorf("diff() left %v open, want %v", gotOpen, tc.wantOpen)
+				}
+			}
+		})
+	}
+}
+
+func TestTabOptions(t *testing.T) {
+	cases := []struct {
+		name  string
+		tab   *configpb.DashboardTabAlertOptions
+		group *configpb.TestGroup
+		want  Options
+	}{
+		{
+			name: "unset",
+			want: Options{PassesToResolve: 1},
+		},
+		{
+			name: "tab",
+			tab:  &configpb.DashboardTabAlertOptions{NumFailuresToAlert: 3, NumPassesToDisableAlert: 2},
+			want: Options{FailuresToAlert: 3, PassesToResolve: 2},
+		},
+		{
+			name:  "inherit the group",
+			group: &configpb.TestGroup{NumFailuresToAlert: 4, NumPassesToDisableAlert: 3},
+			want:  Options{FailuresToAlert: 4, PassesToResolve: 3},
+		},
+		{
+			name:  "tab overrides the group",
+			tab:   &configpb.DashboardTabAlertOptions{NumFailuresToAlert: 3, NumPassesToDisableAlert: 2},
+			group: &configpb.TestGroup{NumFailuresToAlert: 4, NumPassesToDisableAlert: 3},
+			want:  Options{FailuresToAlert: 3, PassesToResolve: 2},
+		},
+		{
+			name:  "tab overrides some of the group",
+			tab:   &configpb.DashboardTabAlertOptions{NumFailuresToAlert: 3},
+			group: &configpb.TestGroup{NumFailuresToAlert: 4, NumPassesToDisableAlert: 3},
+			want:  Options{FailuresToAlert: 3, PassesToResolve: 3},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tab := &configpb.DashboardTab{AlertOptions: tc.tab}
+			if got := TabOptions(tab, tc.group); got != tc.want {
+				t.Errorf("TabOptions() got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLeadingPasses(t *testing.T) {
+	cases := []struct {
+		name    string
+		cols    []*statepb.Column
+		results []int32
+		want    int
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:    "passes",
+			results: []int32{pass, 3, fail, 1, pass, 2},
+			want:    3,
+		},
+		{
+			name:    "skip empty and running cells",
+			results: []int32{running, 1, pass, 1, none, 4, pass, 1, fail, 1},
+			want:    2,
+		},
+		{
+			name:    "failing",
+			results: []int32{fail, 1, pass, 5},
+		},
+		{
+			name:    "skip broken columns",
+			cols:    []*statepb.Column{{}, broken, broken, {}},
+			results: []int32{pass, 1, fail, 2, pass, 1, fail, 1},
+			want:    2,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := leadingPasses(tc.cols, tc.results); got != tc.want {
+				t.Errorf("leadingPasses() got %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+type fakeMailer struct {
+	sent []Message
+	err  error
+}
+
+func (m *fakeMailer) Send(_ context.Context, msg Message) error {
+	m.sent = append(m.sent, msg)
+	return m.err
+}
+
+func TestAlerterUpdate(t *testing.T) {
+	tab := &configpb.DashboardTab{
+		Name: "tab",
+		AlertOptions: &configpb.DashboardTabAlertOptions{
+			NumFailuresToAlert:   3,
+			AlertMailToAddresses: "a@example.com, b@example.com",
+		},
+	}
+	group := &configpb.TestGroup{Query: "bucket/logs/job"}
+	ctx := context.Background()
+	mailer := &fakeMailer{}
+	a := New(mailer, "testgrid@example.com")
+
+	failing := summary(map[string]int32{"a": 3})
+	grid := &statepb.Grid{Rows: []*statepb.Row{row("a", fail, 3)}}
+	if _, err := a.Update(ctx, "dash", tab, group, nil, failing, grid); err != nil {
+		t.Fatalf("Update() got unexpected error: %v", err)
+	}
+	if n := len(mailer.sent); n != 1 {
+		t.Fatalf("Update() sent %d mails, want 1", n)
+	}
+	msg := mailer.sent[0]
+	if msg.From != "testgrid@example.com" || !reflect.DeepEqual(msg.To, []string{"a@example.com", "b@example.com"}) {
+		t.Errorf("Update() sent from %q to %q", msg.From, msg.To)
+	}
+	if !strings.Contains(msg.Text, "a: 3 failures") {
+		t.Errorf("Update() sent text without the failing test:\n%s", msg.Text)
+	}
+
+	// Unchanged, so nothing to mail.
+	if _, err := a.Update(ctx, "dash", tab, group, failing, failing, grid); err != nil {
+		t.Fatalf("Update() got unexpected error: %v", err)
+	}
+	// Another tab has its own alerts.
+	other := *tab
+	other.Name = "other"
+	if _, err := a.Update(ctx, "dash", &other, group, failing, failing, grid); err != nil {
+		t.Fatalf("Update() got unexpected error: %v", err)
+	}
+	if n := len(mailer.sent); n != 1 {
+		t.Fatalf("Update() sent %d mails, want 1", n)
+	}
+
+	mailer.err = errors.New("injected")
+	changes, err := a.Update(ctx, "dash", tab, group, failing, summary(nil), &statepb.Grid{Rows: []*statepb.Row{row("a", pass, 1, fail, 3)}})
+	if err == nil {
+		t.Error("Update() failed to return an error")
+	}
+	if got := names(changes.Resolved); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Errorf("Update() resolved %v, want [a]", got)
+	}
+	if n := len(mailer.sent); n != 2 {
+		t.Fatalf("Update() sent %d mails, want 2", n)
+	}
+	if !strings.Contains(mailer.sent[1].Subject, "1 resolved") {
+		t.Errorf("Update() sent subject %q, want resolved", mailer.sent[1].Subject)
+	}
+
+	// No recipients.
+	tab.AlertOptions.AlertMailToAddresses = ""
+	if _, err := a.Update(ctx, "dash", tab, group, summary(nil), failing, grid); err != nil {
+		t.Fatalf("Update() got unexpected error: %v", err)
+	}
+	if n := len(mailer.sent); n != 2 {
+		t.Errorf("Update() sent %d mails without recipients, want 2", n)
+	}
+
+	// The group provides the recipients and alert threshold the tab leaves unset.
+	mailer.err = nil
+	inherited := &configpb.DashboardTab{Name: "inherited"}
+	group = &configpb.TestGroup{
+		Query:                "bucket/logs/job",
+		NumFailuresToAlert:   3,
+		AlertMailToAddresses: "group@example.com",
+	}
+	if _, err := a.Update(ctx, "dash", inherited, group, nil, failing, grid); err != nil {
+		t.Fatalf("Update() got unexpected error: %v", err)
+	}
+	if n := len(mailer.sent); n != 3 {
+		t.Fatalf("Update() sent %d mails, want 3", n)
+	}
+	if to := mailer.sent[2].To; !reflect.DeepEqual(to, []string{"group@example.com"}) {
+		t.Errorf("Update() sent to %q, want the group recipients", to)
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/smtp"
+	"reflect"
+	"testing"
+)
+
+func TestSMTPMailerSend(t *testing.T) {
+	msg := Message{
+		From:    "testgrid@example.com",
+		To:      []string{"a@example.com", "b@example.com"},
+		Subject: "[testgrid] dash/tab: 1 new failing test",
+		Text:    "plain",
+		HTML:    "<b>html</b>",
+	}
+	cases := []struct {
+		name    string
+		msg     func(Message) Message
+		ctx     func() context.Context
+		sendErr error
+		err     bool
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "missing sender",
+			msg: func(m Message) Message {
+				m.From = ""
+				return m
+			},
+			err: true,
+		},
+		{
+			name: "missing recipients",
+			msg: func(m Message) Message {
+				m.To = nil
+				return m
+			},
+			err: true,
+		},
+		{
+			name: "reject header injection",
+			msg: func(m Message) Message {
+				m.To = []string{"a@example.com\r\nBcc: evil@example.com"}
+				return m
+			},
+			err: true,
+		},
+		{
+			name: "done context",
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			},
+			err: true,
+		},
+		{
+			name:    "send error",
+			sendErr: errors.New("injected"),
+			err:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := msg
+			if tc.msg != nil {
+				m = tc.msg(m)
+			}
+			ctx := context.Background()
+			if tc.ctx != nil {
+				ctx = tc.ctx()
+			}
+			var gotTo []string
+			var gotBuf []byte
+			mailer := NewSMTPMailer("smtp.example.com:25", nil)
+			mailer.send = func(addr string, _ smtp.Auth, from string, to []string, buf []byte) error {
+				gotTo = to
+				gotBuf = buf
+				return tc.sendErr
+			}
+			err := mailer.Send(ctx, m)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("Send() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("Send() failed to return an error")
+			default:
+				if !reflect.DeepEqual(gotTo, m.To) {
+					t.Errorf("Send() sent to %v, want %v", gotTo, m.To)
+				}
+				parsed, err := mail.ReadMessage(bytes.NewReader(gotBuf))
+				if err != nil {
+					t.Fatalf("mail.ReadMessage() got unexpected error: %v", err)
+				}
+				if got := parsed.Header.Get("Subject"); got != m.Subject {
+					t.Errorf("Send() got subject %q, want %q", got, m.Subject)
+				}
+				_, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+				if err != nil {
+					t.Fatalf("mime.ParseMediaType() got unexpected error: %v", err)
+				}
+				r := multipart.NewReader(parsed.Body, params["boundary"])
+				for _, want := range []string{m.Text, m.HTML} {
+					part, err := r.NextPart()
+					if err != nil {
+						t.Fatalf("NextPart() got unexpected error: %v", err)
+					}
+					buf, err := ioutil.ReadAll(part)
+					if err != nil {
+						t.Fatalf("ReadAll() got unexpected error: %v", err)
+					}
+					if string(buf) != want {
+						t.Errorf("Send() got part %q, want %q", buf, want)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerter
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+// alertTest is a test listed in an alert mail.
+type alertTest struct {
+	Name     string
+	Failures int32
+	BuildID  string
+	Message  string
+	Link     string
+}
+
+// alertMail holds the values for the mail templates.
+type alertMail struct {
+	Dashboard    string
+	Tab          string
+	ResultsLink  string
+	DebugLink    string
+	DebugMessage string
+	Opened       []alertTest
+	Resolved     []alertTest
+}
+
+var textMail = texttemplate.Must(texttemplate.New("text").Parse(`TestGrid alerts for {{.Dashboard}}/{{.Tab}}
+{{if .Opened}}
+Newly failing tests:
+{{range .Opened}}
+* {{.Name}}: {{.Failures}} failures, first in {{.BuildID}}
+{{- if .Message}}
+  {{.Message}}
+{{- end}}
+{{- if .Link}}
+  {{.Link}}
+{{- end}}
+{{end}}{{end}}{{if .Resolved}}
+Resolved tests:
+{{range .Resolved}}
+* {{.Name}}
+{{- if .Link}}
+  {{.Link}}
+{{- end}}
+{{end}}{{end}}{{if .ResultsLink}}
+Results: {{.ResultsLink}}
+{{end}}{{if .DebugLink}}
+{{or .DebugMessage "Debugging help"}}: {{.DebugLink}}
+{{end}}`))
+
+var htmlMail = htmltemplate.Must(htmltemplate.New("html").Parse(`<html><body>
+<p>TestGrid alerts for <b>{{.Dashboard}}/{{.Tab}}</b></p>
+{{if .Opened}}<p>Newly failing tests:</p>
+<ul>
+{{range .Opened}}<li>{{if .Link}}<a href="{{.Link}}">{{.Name}}</a>{{else}}{{.Name}}{{end}}: {{.Failures}} failures, first in {{.BuildID}}{{if .Message}}<pre>{{.Message}}</pre>{{end}}</li>
+{{end}}</ul>
+{{end}}{{if .Resolved}}<p>Resolved tests:</p>
+<ul>
+{{range .Resolved}}<li>{{if .Link}}<a href="{{.Link}}">{{.Name}}</a>{{else}}{{.Name}}{{end}}</li>
+{{end}}</ul>
+{{end}}{{if .ResultsLink}}<p><a href="{{.ResultsLink}}">Results</a></p>
+{{end}}{{if .DebugLink}}<p><a href="{{.DebugLink}}">{{or .DebugMessage "Debugging help"}}</a></p>
+{{end}}</body></html>
+`))
+
+// render returns the message for the changes, without its sender or recipients.
+func render(dashboard string, tab *configpb.DashboardTab, group *configpb.TestGroup, changes Changes) (*Message, error) {
+	values := config.LinkValues{
+		Dashboard: dashboard,
+		Tab:       tab.Name,
+		GcsPrefix: strings.Trim(strings.TrimPrefix(group.GetQuery(), "gs://"), "/"),
+	}
+	mail := alertMail{
+		Dashboard:    dashboard,
+		Tab:          tab.Name,
+		ResultsLink:  renderLink(tab.ResultsUrlTemplate, values),
+		DebugLink:    tab.GetAlertOptions().GetDebugUrl(),
+		DebugMessage: tab.GetAlertOptions().GetDebugMessage(),
+	}
+	convert := func(f *summarypb.FailingTestSummary) alertTest {
+		test := values
+		test.TestName = f.TestName
+		test.BuildIDs = []string{f.FailBuildId}
+		return alertTest{
+			Name:     f.DisplayName,
+			Failures: f.FailCount,
+			BuildID:  f.FailBuildId,
+			Message:  f.FailureMessage,
+			Link:     renderLink(tab.OpenTestTemplate, test),
+		}
+	}
+	for _, f := range changes.Opened {
+		mail.Opened = append(mail.Opened, convert(f))
+	}
+	for _, f := range changes.Resolved {
+		mail.Resolved = append(mail.Resolved, convert(f))
+	}
+
+	var text, html bytes.Buffer
+	if err := textMail.Execute(&text, mail); err != nil {
+		return nil, err
+	}
+	if err := htmlMail.Execute(&html, mail); err != nil {
+		return nil, err
+	}
+	return &Message{
+		Subject: subject(dashboard, tab, changes),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+// subject returns the configured subject of the tab, or else summarizes the changes.
+func subject(dashboard string, tab *configpb.DashboardTab, changes Changes) string {
+	if s := tab.GetAlertOptions().GetSubject(); s != "" {
+		return s
+	}
+	var parts []string
+	if n := len(changes.Opened); n == 1 {
+		parts = append(parts, "1 new failing test")
+	} else if n > 1 {
+		parts = append(parts, fmt.Sprintf("%d new failing tests", n))
+	}
+	if n := len(changes.Resolved); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d resolved", n))
+	}
+	return "[testgrid] " + dashboard + "/" + tab.Name + ": " + strings.Join(parts, ", ")
+}
+
+// renderLink expands the template with config.RenderLink.
+//
+// Returns an empty string when the template has no url or is invalid.
+func renderLink(tmpl *configpb.LinkTemplate, values config.LinkValues) string {
+	if tmpl.GetUrl() == "" {
+		return ""
+	}
+	link, err := config.RenderLink(tmpl, values)
+	if err != nil {
+		return "" // Config validation reports invalid templates.
+	}
+	return link
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestRenderLink(t *testing.T) {
+	values := config.LinkValues{
+		Tab:       "tab",
+		TestName:  "//foo:bar",
+		BuildIDs:  []string{"123"},
+		GcsPrefix: "bucket/logs/job",
+	}
+	cases := []struct {
+		name string
+		tmpl *configpb.LinkTemplate
+		want string
+	}{
+		{
+			name: "no template",
+		},
+		{
+			name: "no url",
+			tmpl: &configpb.LinkTemplate{},
+		},
+		{
+			name: "replace values",
+			tmpl: &configpb.LinkTemplate{Url: "https://prow.k8s.io/view/gcs/<gcs_prefix>/<build-id>"},
+			want: "https://prow.k8s.io/view/gcs/bucket/logs/job/123",
+		},
+		{
+			name: "escape values",
+			tmpl: &configpb.LinkTemplate{Url: "https://example.com/<tab-name>/<test-name>"},
+			want: "https://example.com/tab/%2F%2Ffoo:bar",
+		},
+		{
+			name: "options",
+			tmpl: &configpb.LinkTemplate{
+				Url: "https://example.com/new",
+				Options: []*configpb.LinkOptionsTemplate{
+					{Key: "title", Value: "E2E: <test-name>"},
+					{Key: "build", Value: "<build-id>"},
+				},
+			},
+			want: "https://example.com/new?title=E2E%3A+%2F%2Ffoo%3Abar&build=123",
+		},
+		{
+			name: "invalid template",
+			tmpl: &configpb.LinkTemplate{Url: "https://example.com/<unknown>"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := renderLink(tc.tmpl, values); got != tc.want {
+				t.Errorf("renderLink() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	tab := &configpb.DashboardTab{
+		Name:               "tab",
+		OpenTestTemplate:   &configpb.LinkTemplate{Url: "https://prow.k8s.io/view/gcs/<gcs_prefix>/<build-id>"},
+		ResultsUrlTemplate: &configpb.LinkTemplate{Url: "https://prow.k8s.io/job-history/<gcs_prefix>"},
+		AlertOptions: &configpb.DashboardTabAlertOptions{
+			DebugUrl: "https://example.com/debug",
+		},
+	}
+	group := &configpb.TestGroup{Query: "bucket/logs/job"}
+	changes := Changes{
+		Opened: []*summarypb.FailingTestSummary{{
+			DisplayName:    "<script>",
+			FailCount:      4,
+			FailBuildId:    "123",
+			FailureMessage: "timed out",
+		}},
+		Resolved: []*summarypb.FailingTestSummary{{
+			DisplayName: "fixed",
+			FailBuildId: "100",
+		}},
+	}
+	msg, err := render("dash", tab, group, changes)
+	if err != nil {
+		t.Fatalf("render() got unexpected error: %v", err)
+	}
+	if want := "[testgrid] dash/tab: 1 new failing test, 1 resolved"; msg.Subject != want {
+		t.Errorf("render() got subject %q, want %q", msg.Subject, want)
+	}
+	for _, want := range []string{
+		"* <script>: 4 failures, first in 123",
+		"timed out",
+		"https://prow.k8s.io/view/gcs/bucket/logs/job/123",
+		"* fixed",
+		"https://prow.k8s.io/view/gcs/bucket/logs/job/100",
+		"Results: https://prow.k8s.io/job-history/bucket/logs/job",
+		"Debugging help: https://example.com/debug",
+	} {
+		if !strings.Contains(msg.Text, want) {
+			t.Errorf("render() text missing %q:\n%s", want, msg.Text)
+		}
+	}
+	for _, want := range []string{
+		`<a href="https://prow.k8s.io/view/gcs/bucket/logs/job/123">&lt;script&gt;</a>`,
+		`<a href="https://example.com/debug">Debugging help</a>`,
+	} {
+		if !strings.Contains(msg.HTML, want) {
+			t.Errorf("render() html missing %q:\n%s", want, msg.HTML)
+		}
+	}
+
+	tab.AlertOptions.Subject = "custom"
+	if msg, err = render("dash", tab, group, changes); err != nil {
+		t.Fatalf("render() got unexpected error: %v", err)
+	}
+	if msg.Subject != "custom" {
+		t.Errorf("render() got subject %q, want custom", msg.Subject)
+	}
+}
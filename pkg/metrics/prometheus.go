@@ -0,0 +1,172 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics exports TestGrid component metrics to Prometheus.
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// Updater records updater.GroupReports as Prometheus metrics, labeled by group.
+type Updater struct {
+	duration *prometheus.HistogramVec
+	updates  *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	columns  *prometheus.CounterVec
+	cells    *prometheus.GaugeVec
+	builds   *prometheus.CounterVec
+	dropped  *prometheus.CounterVec
+	capped   *prometheus.CounterVec
+	rows     *prometheus.GaugeVec
+	filled   *prometheus.GaugeVec
+	bytes    *prometheus.GaugeVec
+
+	cacheHits   prometheus.Counter
+	cacheMisses prometheus.Counter
+	cacheBytes  prometheus.Gauge
+}
+
+// NewUpdater registers the updater metrics with reg.
+func NewUpdater(reg prometheus.Registerer) (*Updater, error) {
+	groups := []string{"group"}
+	u := Updater{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "testgrid",
+			Subsystem: "updater",
+			Name:      "group_update_duration_seconds",
+			Help:      "Seconds to update the grid of each test group.",
+			Buckets:   prometheus.ExponentialBuckets(0.5, 2, 12), // 0.5s to ~17m
+		}, groups),
+		updates: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "testgrid",
+			Subsystem: "updater",
+			Name:      "group_updates_total",
+			Help:      "Number of attempts to update each test group.",
+		}, groups),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "testgrid",
+			Subsystem: "updater",
+			Name:      "group_update_errors_total",
+			Help:      "Number of failed updates of each test group.",
+		}, groups),
+		columns: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "testgrid",
+			Subsystem: "updater",
+			Name:      "group_columns_read_total",
+			Help:      "Number of columns read from the builds of each test group.",
+		}, groups),
+		cells: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "testgrid",
+			Subsystem: "updater",
+			Name:      "group_cells",
+			Help:      "Number of cells in the grid last written for each test group.",
+		}, groups),
+		builds: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "testgrid",
+			Subsystem: "updater",
+			Name:      "group_build_errors_total",
+			Help:      "Number of builds of each test group that could not be read.",
+		}, groups),
+		dropped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "testgrid",
+			Subsystem: "updater",
+			Name:      "group_dropped_columns_total",
+			Help:      "Number of the oldest columns dropped to fit the grid of each test group within its size budget.",
+		}, groups),
+		capped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "testgrid",
+			Subsystem: "updater",
+			Name:      "group_dropped_rows_total",
+			Help:      "Number of rows dropped to keep the grid of each test group within its max_rows.",
+		}, groups),
+		rows: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "testgrid",
+			Subsystem: "updater",
+			Name:      "group_rows",
+			Help:      "Number of rows in the grid last written for each test group.",
+		}, groups),
+		filled: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "testgrid",
+			Subsystem: "updater",
+			Name:      "group_filled_cells",
+			Help:      "Number of cells with a result in the grid last written for each test group.",
+		}, groups),
+		bytes: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "testgrid",
+			Subsystem: "updater",
+			Name:      "group_grid_bytes",
+			Help:      "Uncompressed size in bytes of the grid last written for each test group.",
+		}, groups),
+		cacheHits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "testgrid",
+			Subsystem: "updater",
+			Name:      "junit_cache_hits_total",
+			Help:      "Number of junit files reused after another group parsed them.",
+		}),
+		cacheMisses: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "testgrid",
+			Subsystem: "updater",
+			Name:      "junit_cache_misses_total",
+			Help:      "Number of junit files parsed because no group had parsed them yet.",
+		}),
+		cacheBytes: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "testgrid",
+			Subsystem: "updater",
+			Name:      "junit_cache_bytes",
+			Help:      "Size in bytes of the junit files cached at the end of the last update.",
+		}),
+	}
+	for _, c := range []prometheus.Collector{u.duration, u.updates, u.errors, u.columns, u.cells, u.builds, u.dropped, u.capped, u.rows, u.filled, u.bytes, u.cacheHits, u.cacheMisses, u.cacheBytes} {
+		if err := reg.Register(c); err != nil {
+			return nil, fmt.Errorf("register: %w", err)
+		}
+	}
+	return &u, nil
+}
+
+// ObserveUpdate records the report.
+//
+// Failed updates do not change the cells or stats of the group.
+func (u *Updater) ObserveUpdate(report updater.GroupReport) {
+	g := report.Group
+	u.duration.WithLabelValues(g).Observe(report.Duration.Seconds())
+	u.updates.WithLabelValues(g).Inc()
+	u.columns.WithLabelValues(g).Add(float64(report.Columns))
+	u.builds.WithLabelValues(g).Add(float64(len(report.BuildErrors)))
+	u.dropped.WithLabelValues(g).Add(float64(report.DroppedColumns))
+	u.capped.WithLabelValues(g).Add(float64(report.DroppedRows))
+	if report.Err != nil {
+		u.errors.WithLabelValues(g).Inc()
+		return
+	}
+	u.cells.WithLabelValues(g).Set(float64(report.Cells))
+	u.rows.WithLabelValues(g).Set(float64(report.Stats.Rows))
+	u.filled.WithLabelValues(g).Set(float64(report.Stats.Cells))
+	u.bytes.WithLabelValues(g).Set(float64(report.Stats.Bytes))
+}
+
+// ObserveSuitesCache records the junit cache lookups of an update.
+func (u *Updater) ObserveSuitesCache(stats gcs.SuitesCacheStats) {
+	u.cacheHits.Add(float64(stats.Hits))
+	u.cacheMisses.Add(float64(stats.Misses))
+	u.cacheBytes.Set(float64(stats.Bytes))
+}
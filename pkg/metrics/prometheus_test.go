@@ -0,0 +1,116 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	tgstate "github.com/GoogleCloudPlatform/testgrid/util/state"
+)
+
+var _ updater.Metrics = &Updater{}
+
+func TestUpdater(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	u, err := NewUpdater(reg)
+	if err != nil {
+		t.Fatalf("NewUpdater() got unexpected error: %v", err)
+	}
+
+	// A fake update cycle, where the second group fails.
+	cycle := func() {
+		u.ObserveUpdate(updater.GroupReport{Group: "pass", Duration: time.Second, Columns: 3, Cells: 30, BuildErrors: []error{errors.New("corrupt")}, DroppedColumns: 4, DroppedRows: 5, Stats: tgstate.GridStats{Rows: 10, Columns: 3, Cells: 25, Bytes: 1000}})
+		u.ObserveUpdate(updater.GroupReport{Group: "fail", Duration: time.Minute, Columns: 1, Err: errors.New("injected")})
+	}
+	cycle()
+	cycle()
+
+	cases := []struct {
+		name   string
+		metric prometheus.Collector
+		want   float64
+	}{
+		{"pass updates", u.updates.WithLabelValues("pass"), 2},
+		{"pass errors", u.errors.WithLabelValues("pass"), 0},
+		{"pass columns", u.columns.WithLabelValues("pass"), 6},
+		{"pass cells", u.cells.WithLabelValues("pass"), 30},
+		{"pass build errors", u.builds.WithLabelValues("pass"), 2},
+		{"pass dropped columns", u.dropped.WithLabelValues("pass"), 8},
+		{"pass dropped rows", u.capped.WithLabelValues("pass"), 10},
+		{"pass rows", u.rows.WithLabelValues("pass"), 10},
+		{"pass filled cells", u.filled.WithLabelValues("pass"), 25},
+		{"pass bytes", u.bytes.WithLabelValues("pass"), 1000},
+		{"fail updates", u.updates.WithLabelValues("fail"), 2},
+		{"fail errors", u.errors.WithLabelValues("fail"), 2},
+		{"fail columns", u.columns.WithLabelValues("fail"), 2},
+		{"fail cells", u.cells.WithLabelValues("fail"), 0},
+		{"fail build errors", u.builds.WithLabelValues("fail"), 0},
+		{"fail dropped columns", u.dropped.WithLabelValues("fail"), 0},
+		{"fail dropped rows", u.capped.WithLabelValues("fail"), 0},
+		{"fail rows", u.rows.WithLabelValues("fail"), 0},
+		{"fail bytes", u.bytes.WithLabelValues("fail"), 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := testutil.ToFloat64(tc.metric); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestUpdaterSuitesCache(t *testing.T) {
+	u, err := NewUpdater(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatalf("NewUpdater() got unexpected error: %v", err)
+	}
+	u.ObserveSuitesCache(gcs.SuitesCacheStats{Hits: 3, Misses: 4, Bytes: 100})
+	u.ObserveSuitesCache(gcs.SuitesCacheStats{Hits: 5, Misses: 1, Bytes: 50})
+	cases := []struct {
+		name   string
+		metric prometheus.Collector
+		want   float64
+	}{
+		{"hits", u.cacheHits, 8},
+		{"misses", u.cacheMisses, 5},
+		{"bytes", u.cacheBytes, 50},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := testutil.ToFloat64(tc.metric); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewUpdaterRegistersOnce(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	if _, err := NewUpdater(reg); err != nil {
+		t.Fatalf("NewUpdater() got unexpected error: %v", err)
+	}
+	if _, err := NewUpdater(reg); err == nil {
+		t.Error("NewUpdater() failed to return an error for duplicate metrics")
+	}
+}
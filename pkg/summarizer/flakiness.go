@@ -0,0 +1,91 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"sort"
+
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	tgstate "github.com/GoogleCloudPlatform/testgrid/util/state"
+)
+
+// Flakiness returns the percentage of results in the first window columns that flipped, and the number of transitions.
+//
+// The results are run-length encoded, newest first, like statepb.Row.Results.
+// A result flips when it is flaky, or passes and fails unlike the next older result.
+// Skips, running and missing results do not count, neither as a result nor to break a
+// run of passes. Windows larger than the results use every column.
+func Flakiness(results []int32, window int) (float32, int) {
+	if window <= 0 {
+		return 0, 0
+	}
+	var counted, transitions int
+	var newer statepb.Row_Result // result of the newer counted column.
+	it := tgstate.DecodeResults(results, window)
+	for r, ok := it.Next(); ok; r, ok = it.Next() {
+		res := statepb.Row_Result(r)
+		if res == statepb.Row_PASS_WITH_SKIPS {
+			continue
+		}
+		res = coalesceResult(res, result.IgnoreRunning)
+		if res == statepb.Row_NO_RESULT {
+			continue
+		}
+		counted++
+		if res == statepb.Row_FLAKY {
+			transitions++
+		} else if newer != statepb.Row_NO_RESULT && newer != statepb.Row_FLAKY && newer != res {
+			// The newer column flipped from this older one.
+			transitions++
+		}
+		newer = res
+	}
+	if counted == 0 {
+		return 0, 0
+	}
+	return 100 * float32(transitions) / float32(counted), transitions
+}
+
+// flakyTests returns the k rows with the highest Flakiness over the first window columns, most flaky first.
+//
+// Rows that never flip are not flaky. Ties keep the order of the rows.
+func flakyTests(rows []*statepb.Row, window, k int) []*summarypb.FlakyTestSummary {
+	if k <= 0 {
+		return nil
+	}
+	var out []*summarypb.FlakyTestSummary
+	for _, row := range rows {
+		flakiness, transitions := Flakiness(row.Results, window)
+		if transitions == 0 {
+			continue
+		}
+		out = append(out, &summarypb.FlakyTestSummary{
+			DisplayName: row.Name,
+			Flakiness:   flakiness,
+			Transitions: int32(transitions),
+		})
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Flakiness > out[j].Flakiness
+	})
+	if len(out) > k {
+		out = out[:k]
+	}
+	return out
+}
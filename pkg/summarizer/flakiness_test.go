@@ -0,0 +1,214 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestFlakiness(t *testing.T) {
+	pass := int32(statepb.Row_PASS)
+	fail := int32(statepb.Row_FAIL)
+	flaky := int32(statepb.Row_FLAKY)
+	skip := int32(statepb.Row_PASS_WITH_SKIPS)
+	running := int32(statepb.Row_RUNNING)
+	empty := int32(statepb.Row_NO_RESULT)
+	cases := []struct {
+		name        string
+		results     []int32
+		window      int
+		flakiness   float32
+		transitions int
+	}{
+		{
+			name:   "basically works",
+			window: 10,
+		},
+		{
+			name:    "always passing",
+			results: []int32{pass, 10},
+			window:  10,
+		},
+		{
+			name:        "alternating",
+			results:     []int32{pass, 1, fail, 1, pass, 1, fail, 1},
+			window:      4,
+			flakiness:   75,
+			transitions: 3,
+		},
+		{
+			name:        "run length encoded",
+			results:     []int32{fail, 2, pass, 6},
+			window:      8,
+			flakiness:   12.5,
+			transitions: 1,
+		},
+		{
+			name:        "only the window counts",
+			results:     []int32{pass, 4, fail, 1, pass, 1, fail, 1},
+			window:      5,
+			flakiness:   20,
+			transitions: 1,
+		},
+		{
+			name:        "window larger than the history",
+			results:     []int32{pass, 1, fail, 1},
+			window:      100,
+			flakiness:   50,
+			transitions: 1,
+		},
+		{
+			name:        "skips do not count",
+			results:     []int32{pass, 1, skip, 3, empty, 2, running, 1, fail, 1},
+			window:      8,
+			flakiness:   50,
+			transitions: 1,
+		},
+		{
+			name:    "skips do not break a run",
+			results: []int32{fail, 1, skip, 1, fail, 1},
+			window:  3,
+		},
+		{
+			name:        "flaky results flip",
+			results:     []int32{pass, 2, flaky, 2},
+			window:      4,
+			flakiness:   50,
+			transitions: 2,
+		},
+		{
+			name:    "only skips",
+			results: []int32{skip, 3, empty, 1},
+			window:  4,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			flakiness, transitions := Flakiness(tc.results, tc.window)
+			if flakiness != tc.flakiness || transitions != tc.transitions {
+				t.Errorf("Flakiness(%v, %d) got %f, %d, want %f, %d", tc.results, tc.window, flakiness, transitions, tc.flakiness, tc.transitions)
+			}
+		})
+	}
+}
+
+func TestFlakyTests(t *testing.T) {
+	pass := int32(statepb.Row_PASS)
+	fail := int32(statepb.Row_FAIL)
+	rows := []*statepb.Row{
+		{Name: "stable", Results: []int32{pass, 4}},
+		{Name: "once", Results: []int32{fail, 1, pass, 3}},
+		{Name: "often", Results: []int32{fail, 1, pass, 1, fail, 1, pass, 1}},
+		{Name: "also-once", Results: []int32{pass, 3, fail, 1}},
+	}
+	cases := []struct {
+		name   string
+		window int
+		k      int
+		want   []*summarypb.FlakyTestSummary
+	}{
+		{
+			name:   "disabled",
+			window: 4,
+		},
+		{
+			name:   "flakiest first",
+			window: 4,
+			k:      10,
+			want: []*summarypb.FlakyTestSummary{
+				{DisplayName: "often", Flakiness: 75, Transitions: 3},
+				{DisplayName: "once", Flakiness: 25, Transitions: 1},
+				{DisplayName: "also-once", Flakiness: 25, Transitions: 1},
+			},
+		},
+		{
+			name:   "top k",
+			window: 4,
+			k:      2,
+			want: []*summarypb.FlakyTestSummary{
+				{DisplayName: "often", Flakiness: 75, Transitions: 3},
+				{DisplayName: "once", Flakiness: 25, Transitions: 1},
+			},
+		},
+		{
+			name:   "smaller window",
+			window: 2,
+			k:      10,
+			want: []*summarypb.FlakyTestSummary{
+				{DisplayName: "once", Flakiness: 50, Transitions: 1},
+				{DisplayName: "often", Flakiness: 50, Transitions: 1},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := flakyTests(rows, tc.window, tc.k)
+			if len(got) != len(tc.want) {
+				t.Fatalf("flakyTests() got %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if !proto.Equal(got[i], tc.want[i]) {
+					t.Errorf("flakyTests()[%d] got %v, want %v", i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSummarizeTabFlakyTests(t *testing.T) {
+	now := float64(time.Now().Unix())
+	pass := int32(statepb.Row_PASS)
+	fail := int32(statepb.Row_FAIL)
+	var cols []*statepb.Column
+	for _, b := range []string{"4", "3", "2", "1"} {
+		cols = append(cols, &statepb.Column{Build: b, Started: now})
+	}
+	grid := &statepb.Grid{
+		Columns: cols,
+		Rows: []*statepb.Row{
+			{Name: "stable", Results: []int32{pass, 4}},
+			{Name: "flaky", Results: []int32{pass, 1, fail, 1, pass, 2}},
+		},
+	}
+	tab := &configpb.DashboardTab{
+		Name:             "tab",
+		NumColumnsRecent: 4,
+		NumFlakyTests:    5,
+	}
+	var got []string
+	for _, f := range summarizeTab(grid, tab, nil).FlakyTests {
+		got = append(got, f.DisplayName)
+	}
+	if want := []string{"flaky"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("summarizeTab() got flaky tests %v, want %v", got, want)
+	}
+
+	tab.NumFlakyTests = 0
+	if got := summarizeTab(grid, tab, nil).FlakyTests; len(got) > 0 {
+		t.Errorf("summarizeTab() got flaky tests %v without num_flaky_tests", got)
+	}
+}
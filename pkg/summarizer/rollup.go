@@ -0,0 +1,152 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"cloud.google.com/go/storage"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// RollupName is the name of the health rollup, next to the config and dashboard summaries.
+//
+// Normalized dashboard names never contain a dot, so it cannot collide with a summary.
+const RollupName = "summary-rollup.json"
+
+// Rollup is the health of every summarized dashboard and dashboard group.
+type Rollup struct {
+	// Dashboards are sorted by name.
+	Dashboards []DashboardRollup `json:"dashboards"`
+	// Groups are in config order.
+	Groups []GroupRollup `json:"groups"`
+}
+
+// DashboardRollup is the overall status of a dashboard: the worst status of its tabs.
+type DashboardRollup struct {
+	Name string `json:"name"`
+	// Status is a TabStatus name, such as FAIL.
+	Status string `json:"status"`
+	// Broken is the number of tabs skipped because of their configuration.
+	Broken int `json:"broken,omitempty"`
+	// Paused is the number of tabs skipped because their dashboard is paused.
+	Paused int `json:"paused,omitempty"`
+}
+
+// GroupRollup counts the dashboards of a dashboard group by status.
+type GroupRollup struct {
+	Name string `json:"name"`
+	// Statuses maps each TabStatus name to the number of dashboards with that status.
+	Statuses map[string]int `json:"statuses"`
+}
+
+// statusRank orders tab statuses from best to worst, for the worst status of a dashboard to win.
+func statusRank(status summarypb.DashboardTabSummary_TabStatus) int {
+	switch status {
+	case summarypb.DashboardTabSummary_PASS:
+		return 1
+	case summarypb.DashboardTabSummary_PENDING:
+		return 2
+	case summarypb.DashboardTabSummary_UNKNOWN:
+		return 3
+	case summarypb.DashboardTabSummary_FLAKY:
+		return 4
+	case summarypb.DashboardTabSummary_STALE:
+		return 5
+	case summarypb.DashboardTabSummary_FAIL:
+		return 6
+	}
+	return 0
+}
+
+// rollupDashboard returns the worst status of the dashboard tabs, skipping broken and paused tabs.
+//
+// A dashboard with only paused tabs, besides broken ones, is PAUSED.
+func rollupDashboard(name string, sum *summarypb.DashboardSummary) DashboardRollup {
+	out := DashboardRollup{Name: name}
+	status := summarypb.DashboardTabSummary_NOT_SET
+	for _, tab := range sum.GetTabSummaries() {
+		if tab.OverallStatus == summarypb.DashboardTabSummary_BROKEN {
+			out.Broken++
+			continue
+		}
+		if tab.OverallStatus == summarypb.DashboardTabSummary_PAUSED {
+			out.Paused++
+			continue
+		}
+		if statusRank(tab.OverallStatus) > statusRank(status) {
+			status = tab.OverallStatus
+		}
+	}
+	if status == summarypb.DashboardTabSummary_NOT_SET && out.Paused > 0 {
+		status = summarypb.DashboardTabSummary_PAUSED
+	}
+	out.Status = status.String()
+	return out
+}
+
+// rollup aggregates the summary of each dashboard up to the dashboard groups.
+//
+// Groups only count the dashboards that have a summary.
+func rollup(groups []*configpb.DashboardGroup, sums map[string]*summarypb.DashboardSummary) Rollup {
+	var out Rollup
+	dashboards := map[string]DashboardRollup{}
+	for name, sum := range sums {
+		d := rollupDashboard(name, sum)
+		dashboards[name] = d
+		out.Dashboards = append(out.Dashboards, d)
+	}
+	sort.Slice(out.Dashboards, func(i, j int) bool {
+		return out.Dashboards[i].Name < out.Dashboards[j].Name
+	})
+	for _, g := range groups {
+		gr := GroupRollup{
+			Name:     g.Name,
+			Statuses: map[string]int{},
+		}
+		for _, name := range g.DashboardNames {
+			if d, ok := dashboards[name]; ok {
+				gr.Statuses[d.Status]++
+			}
+		}
+		out.Groups = append(out.Groups, gr)
+	}
+	return out
+}
+
+// writeRollup writes the rollup as JSON next to the config at configPath.
+func writeRollup(ctx context.Context, client gcs.Client, configPath gcs.Path, r Rollup) error {
+	path, err := configPath.ResolveReference(&url.URL{Path: RollupName})
+	if err != nil {
+		return fmt.Errorf("resolve rollup: %w", err)
+	}
+	buf, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("marshal: %v", err)
+	}
+	if _, err := client.Upload(ctx, *path, buf, gcs.DefaultAcl, summaryCacheControl, storage.Conditions{}); err != nil {
+		return fmt.Errorf("upload %s: %w", path, err)
+	}
+	return nil
+}
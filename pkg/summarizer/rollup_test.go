@@ -0,0 +1,180 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func tabs(statuses ...summarypb.DashboardTabSummary_TabStatus) *summarypb.DashboardSummary {
+	var sum summarypb.DashboardSummary
+	for _, s := range statuses {
+		sum.TabSummaries = append(sum.TabSummaries, &summarypb.DashboardTabSummary{OverallStatus: s})
+	}
+	return &sum
+}
+
+func TestRollup(t *testing.T) {
+	const (
+		pass    = summarypb.DashboardTabSummary_PASS
+		fail    = summarypb.DashboardTabSummary_FAIL
+		flaky   = summarypb.DashboardTabSummary_FLAKY
+		stale   = summarypb.DashboardTabSummary_STALE
+		broken  = summarypb.DashboardTabSummary_BROKEN
+		paused  = summarypb.DashboardTabSummary_PAUSED
+		pending = summarypb.DashboardTabSummary_PENDING
+	)
+	cases := []struct {
+		name   string
+		groups []*configpb.DashboardGroup
+		sums   map[string]*summarypb.DashboardSummary
+		want   Rollup
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "worst status wins",
+			sums: map[string]*summarypb.DashboardSummary{
+				"passing": tabs(pass, pass),
+				"flaky":   tabs(pass, flaky),
+				"stale":   tabs(flaky, stale, pass),
+				"failing": tabs(pass, fail, stale, flaky),
+				"new":     tabs(pass, pending),
+				"mixed":   tabs(pending, flaky),
+				"empty":   tabs(),
+			},
+			want: Rollup{
+				Dashboards: []DashboardRollup{
+					{Name: "empty", Status: "NOT_SET"},
+					{Name: "failing", Status: "FAIL"},
+					{Name: "flaky", Status: "FLAKY"},
+					{Name: "mixed", Status: "FLAKY"},
+					{Name: "new", Status: "PENDING"},
+					{Name: "passing", Status: "PASS"},
+					{Name: "stale", Status: "STALE"},
+				},
+			},
+		},
+		{
+			name: "skip broken tabs",
+			sums: map[string]*summarypb.DashboardSummary{
+				"mostly-broken": tabs(broken, pass, broken),
+				"all-broken":    tabs(broken),
+			},
+			want: Rollup{
+				Dashboards: []DashboardRollup{
+					{Name: "all-broken", Status: "NOT_SET", Broken: 1},
+					{Name: "mostly-broken", Status: "PASS", Broken: 2},
+				},
+			},
+		},
+		{
+			name: "skip paused tabs",
+			sums: map[string]*summarypb.DashboardSummary{
+				"paused":        tabs(paused, paused),
+				"paused-broken": tabs(paused, broken),
+				"some-paused":   tabs(paused, fail),
+			},
+			want: Rollup{
+				Dashboards: []DashboardRollup{
+					{Name: "paused", Status: "PAUSED", Paused: 2},
+					{Name: "paused-broken", Status: "PAUSED", Broken: 1, Paused: 1},
+					{Name: "some-paused", Status: "FAIL", Paused: 1},
+				},
+			},
+		},
+		{
+			name: "count group dashboards",
+			groups: []*configpb.DashboardGroup{
+				{Name: "release", DashboardNames: []string{"a", "b", "c", "unsummarized"}},
+				{Name: "empty"},
+			},
+			sums: map[string]*summarypb.DashboardSummary{
+				"a":     tabs(pass),
+				"b":     tabs(fail, broken),
+				"c":     tabs(pass, flaky),
+				"other": tabs(fail),
+			},
+			want: Rollup{
+				Dashboards: []DashboardRollup{
+					{Name: "a", Status: "PASS"},
+					{Name: "b", Status: "FAIL", Broken: 1},
+					{Name: "c", Status: "FLAKY"},
+					{Name: "other", Status: "FAIL"},
+				},
+				Groups: []GroupRollup{
+					{Name: "release", Statuses: map[string]int{"PASS": 1, "FAIL": 1, "FLAKY": 1}},
+					{Name: "empty", Statuses: map[string]int{}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := rollup(tc.groups, tc.sums); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("rollup() got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWriteRollup(t *testing.T) {
+	ctx := context.Background()
+	var client fake.Client
+	configPath, err := gcs.NewPath("gs://bucket/path/config")
+	if err != nil {
+		t.Fatalf("NewPath() got unexpected error: %v", err)
+	}
+	want := Rollup{
+		Dashboards: []DashboardRollup{{Name: "a", Status: "FAIL", Broken: 1}},
+		Groups:     []GroupRollup{{Name: "g", Statuses: map[string]int{"FAIL": 1}}},
+	}
+	if err := writeRollup(ctx, &client, *configPath, want); err != nil {
+		t.Fatalf("writeRollup() got unexpected error: %v", err)
+	}
+	path, err := gcs.NewPath("gs://bucket/path/" + RollupName)
+	if err != nil {
+		t.Fatalf("NewPath() got unexpected error: %v", err)
+	}
+	r, _, err := client.Open(ctx, *path)
+	if err != nil {
+		t.Fatalf("writeRollup() did not write %s: %v", path, err)
+	}
+	defer r.Close()
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll() got unexpected error: %v", err)
+	}
+	var got Rollup
+	if err := json.Unmarshal(buf, &got); err != nil {
+		t.Fatalf("writeRollup() wrote bad JSON %s: %v", buf, err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("writeRollup() wrote %+v, want %+v", got, want)
+	}
+}
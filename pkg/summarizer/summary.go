@@ -18,14 +18,13 @@ limitations under the License.
 package summarizer
 
 import (
-	"compress/zlib"
 	"context"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/url"
-	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -35,11 +34,13 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/GoogleCloudPlatform/testgrid/config"
+	"github.com/GoogleCloudPlatform/testgrid/internal/filter"
 	"github.com/GoogleCloudPlatform/testgrid/internal/result"
 	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
 	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
 	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	tgstate "github.com/GoogleCloudPlatform/testgrid/util/state"
 )
 
 // gridReader returns the grid content and metadata (last updated time, generation id)
@@ -52,12 +53,13 @@ type groupFinder func(string) (*configpb.TestGroup, gridReader, error)
 //
 // Will use concurrency go routines to update dashboards in parallel.
 // Setting dashboard will limit update to this dashboard.
-// Will write summary proto when confirm is set.
-func Update(ctx context.Context, client *storage.Client, path gcs.Path, concurrency int, dashboard string, confirm bool) error {
+// Will write summary proto when confirm is set, as well as the Rollup of every
+// dashboard when not limited to one. Summaries upload with the compression, such as gcs.Gzip.
+func Update(ctx context.Context, client gcs.Client, path gcs.Path, concurrency int, dashboard string, confirm bool, compression gcs.Compression) error {
 	if concurrency < 1 {
 		return fmt.Errorf("concurrency must be positive, got: %d", concurrency)
 	}
-	cfg, err := config.ReadGCS(ctx, client.Bucket(path.Bucket()).Object(path.Object()))
+	cfg, _, err := config.ReadGCS(ctx, client, path, false)
 	if err != nil {
 		return fmt.Errorf("Failed to read config: %w", err)
 	}
@@ -67,11 +69,11 @@ func Update(ctx context.Context, client *storage.Client, path gcs.Path, concurre
 	var wg sync.WaitGroup
 
 	groupFinder := func(name string) (*configpb.TestGroup, gridReader, error) {
-		group := config.FindTestGroup(name, cfg)
+		group := config.FindTestGroup(cfg, name)
 		if group == nil {
 			return nil, nil, nil
 		}
-		path, err := path.ResolveReference(&url.URL{Path: name})
+		path, err := path.ResolveReference(&url.URL{Path: group.Name})
 		if err != nil {
 			return group, nil, err
 		}
@@ -82,6 +84,8 @@ func Update(ctx context.Context, client *storage.Client, path gcs.Path, concurre
 	}
 
 	errCh := make(chan error)
+	var lock sync.Mutex
+	sums := map[string]*summarypb.DashboardSummary{}
 
 	for i := 0; i < concurrency; i++ {
 		wg.Add(1)
@@ -90,6 +94,9 @@ func Update(ctx context.Context, client *storage.Client, path gcs.Path, concurre
 				log := logrus.WithField("dashboard", dash.Name)
 				log.Info("Summarizing dashboard")
 				sum, err := updateDashboard(ctx, dash, groupFinder)
+				lock.Lock()
+				sums[dash.Name] = sum
+				lock.Unlock()
 				if err != nil {
 					log.WithError(err).Error("Cannot summarize dashboard")
 					errCh <- errors.New(dash.Name)
@@ -99,13 +106,12 @@ func Update(ctx context.Context, client *storage.Client, path gcs.Path, concurre
 				if !confirm {
 					continue
 				}
-				path, err := path.ResolveReference(&url.URL{Path: summaryPath(dash.Name)})
-				if err != nil {
-					log.WithError(err).Error("Cannot resolve summary path")
+				if err := ctx.Err(); err != nil {
+					log.WithError(err).Error("Interrupted before writing summary")
 					errCh <- errors.New(dash.Name)
 					continue
 				}
-				if err := writeSummary(ctx, client, *path, sum); err != nil {
+				if err := WriteSummary(ctx, client, path, dash.Name, sum.TabSummaries, compression); err != nil {
 					log.WithError(err).Error("Cannot write summary")
 					errCh <- errors.New(dash.Name)
 					continue
@@ -132,43 +138,114 @@ func Update(ctx context.Context, client *storage.Client, path gcs.Path, concurre
 		close(resultCh)
 	}()
 
+send:
 	for _, d := range cfg.Dashboards {
 		if dashboard != "" && dashboard != d.Name {
 			logrus.WithField("dashboard", d.Name).Info("Skipping")
 			continue
 		}
-		dashboards <- d
+		select {
+		case <-ctx.Done():
+			break send
+		case dashboards <- d:
+		}
 	}
 	close(dashboards)
 	wg.Wait()
 	close(errCh)
-	return <-resultCh
+	err = <-resultCh
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		// A rollup of the dashboards summarized so far would be misleading.
+		return fmt.Errorf("interrupted: %w", ctxErr)
+	}
+	if !confirm || dashboard != "" {
+		return err
+	}
+	if rerr := writeRollup(ctx, client, path, rollup(cfg.DashboardGroups, sums)); rerr != nil {
+		logrus.WithError(rerr).Error("Cannot write rollup")
+		if err == nil {
+			err = rerr
+		}
+	}
+	return err
 }
 
-var (
-	normalizer = regexp.MustCompile(`[^a-z0-9]+`)
-)
+// summaryCacheControl is the Cache-Control of summaries, which change with every update.
+const summaryCacheControl = "no-cache"
 
+// summaryPath returns the name of the summary of the dashboard, next to the config.
+//
+// Like the frontend, the name uses the config.Normalize form of the dashboard name.
 func summaryPath(name string) string {
-	// ''.join(c for c in n.lower() if c is alphanumeric
-	return "summary-" + normalizer.ReplaceAllString(strings.ToLower(name), "")
+	return "summary-" + config.Normalize(name)
 }
 
-func writeSummary(ctx context.Context, client *storage.Client, path gcs.Path, sum *summarypb.DashboardSummary) error {
-	buf, err := proto.Marshal(sum)
+// SummaryPath returns the path of the summary of the dashboard, next to the config at configPath.
+func SummaryPath(configPath gcs.Path, dashboard string) (*gcs.Path, error) {
+	path, err := configPath.ResolveReference(&url.URL{Path: summaryPath(dashboard)})
+	if err != nil {
+		return nil, fmt.Errorf("resolve %s summary: %w", dashboard, err)
+	}
+	return path, nil
+}
+
+// WriteSummary writes the summary of the dashboard tabs next to the config at configPath, compressed with the compression.
+func WriteSummary(ctx context.Context, client gcs.Client, configPath gcs.Path, dashboard string, tabs []*summarypb.DashboardTabSummary, compression gcs.Compression) error {
+	path, err := SummaryPath(configPath, dashboard)
+	if err != nil {
+		return err
+	}
+	buf, err := proto.Marshal(&summarypb.DashboardSummary{TabSummaries: tabs})
 	if err != nil {
 		return fmt.Errorf("marshal: %v", err)
 	}
-	return gcs.Upload(ctx, client, path, buf, gcs.DefaultAcl, "no-cache") // TODO(fejta): configurable cache value
+	buf, err = compression.Compress(func(w io.Writer) error {
+		_, err := w.Write(buf)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("compress: %v", err)
+	}
+	if _, err := gcs.UploadEncoded(ctx, client, *path, buf, gcs.DefaultAcl, summaryCacheControl, compression.ContentEncoding(), storage.Conditions{}); err != nil {
+		return fmt.Errorf("upload %s: %w", path, err)
+	}
+	return nil
+}
+
+// ReadSummary reads the summary of the dashboard that WriteSummary wrote next to the config at configPath.
+//
+// The summary may be gzip or zstd compressed, or an uncompressed proto.
+func ReadSummary(ctx context.Context, client gcs.Client, configPath gcs.Path, dashboard string) (*summarypb.DashboardSummary, error) {
+	path, err := SummaryPath(configPath, dashboard)
+	if err != nil {
+		return nil, err
+	}
+	r, _, err := client.Open(ctx, *path)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer r.Close()
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %v", path, err)
+	}
+	if buf, err = gcs.Decompress(buf); err != nil {
+		return nil, fmt.Errorf("decompress %s: %v", path, err)
+	}
+	var sum summarypb.DashboardSummary
+	if err := proto.Unmarshal(buf, &sum); err != nil {
+		return nil, fmt.Errorf("unmarshal %s: %v", path, err)
+	}
+	return &sum, nil
 }
 
 // pathReader returns a reader for the specified path and last modified, generation metadata.
-func pathReader(ctx context.Context, client *storage.Client, path gcs.Path) (io.ReadCloser, time.Time, int64, error) {
-	r, err := client.Bucket(path.Bucket()).Object(path.Object()).NewReader(ctx)
+func pathReader(ctx context.Context, client gcs.Client, path gcs.Path) (io.ReadCloser, time.Time, int64, error) {
+	r, attrs, err := client.Open(ctx, path)
 	if err != nil {
 		return nil, time.Time{}, 0, fmt.Errorf("read %s: %w", path, err)
 	}
-	return r, r.Attrs.LastModified, r.Attrs.Generation, nil
+	return r, attrs.LastModified, attrs.Generation, nil
 }
 
 // updateDashboard will summarize all the tabs (through errors), returning an error if any fail to summarize.
@@ -176,14 +253,28 @@ func updateDashboard(ctx context.Context, dash *configpb.Dashboard, finder group
 	log := logrus.WithField("dashboard", dash.Name)
 	var badTabs []string
 	var sum summarypb.DashboardSummary
+	if dash.Paused {
+		log.WithField("reason", dash.PausedReason).Info("Skipping paused dashboard")
+		for _, tab := range dash.DashboardTab {
+			sum.TabSummaries = append(sum.TabSummaries, pausedTab(tab.Name, dash.PausedReason))
+		}
+		return &sum, nil
+	}
 	for _, tab := range dash.DashboardTab {
+		if err := ctx.Err(); err != nil {
+			return &sum, fmt.Errorf("interrupted after %d of %d tabs: %w", len(sum.TabSummaries), len(dash.DashboardTab), err)
+		}
 		log := log.WithField("tab", tab.Name)
 		log.Info("Summarizing tab")
 		s, err := updateTab(ctx, tab, finder)
 		if err != nil {
 			log.WithError(err).Error("Cannot summarize tab")
 			badTabs = append(badTabs, tab.Name)
-			sum.TabSummaries = append(sum.TabSummaries, problemTab(tab.Name))
+			if errors.Is(err, errMissingGroup) {
+				sum.TabSummaries = append(sum.TabSummaries, brokenTab(tab.Name))
+			} else {
+				sum.TabSummaries = append(sum.TabSummaries, problemTab(tab.Name))
+			}
 			continue
 		}
 		sum.TabSummaries = append(sum.TabSummaries, s)
@@ -203,12 +294,50 @@ func problemTab(name string) *summarypb.DashboardTabSummary {
 	}
 }
 
-// staleHours returns the configured number of stale hours for the tab.
+// brokenTab summarizes a tab that cannot summarize because of its configuration.
+func brokenTab(name string) *summarypb.DashboardTabSummary {
+	sum := problemTab(name)
+	sum.OverallStatus = summarypb.DashboardTabSummary_BROKEN
+	return sum
+}
+
+// pausedTab summarizes a tab of a paused dashboard, which does not summarize.
+func pausedTab(name, reason string) *summarypb.DashboardTabSummary {
+	return &summarypb.DashboardTabSummary{
+		DashboardTabName: name,
+		OverallStatus:    summarypb.DashboardTabSummary_PAUSED,
+		Status:           "Paused: " + reason,
+	}
+}
+
+// errMissingGroup means the config does not have the test group of a tab.
+var errMissingGroup = errors.New("test group not found")
+
+// DefaultStaleHours is the age of the latest column after which a tab is stale,
+// unless its alert_stale_results_hours says otherwise.
+const DefaultStaleHours = 24 * 7
+
+// staleHours returns the configured number of stale hours for the tab, or DefaultStaleHours when unset.
 func staleHours(tab *configpb.DashboardTab) time.Duration {
-	if tab.AlertOptions == nil {
-		return 0
+	hours := tab.GetAlertOptions().GetAlertStaleResultsHours()
+	if hours == 0 {
+		hours = DefaultStaleHours
 	}
-	return time.Duration(tab.AlertOptions.AlertStaleResultsHours) * time.Hour
+	return time.Duration(hours) * time.Hour
+}
+
+// StaleSince returns when the newest column of the grid started, and whether that is longer than threshold ago.
+//
+// A zero threshold means DefaultStaleHours. A grid without a started column is never stale.
+func StaleSince(grid *statepb.Grid, threshold time.Duration) (time.Time, bool) {
+	latest, _ := latestRun(grid.GetColumns())
+	if latest.IsZero() {
+		return latest, false
+	}
+	if threshold == 0 {
+		threshold = DefaultStaleHours * time.Hour
+	}
+	return latest, time.Since(latest) > threshold
 }
 
 // updateTab reads the latest grid state for the tab and summarizes it.
@@ -219,7 +348,7 @@ func updateTab(ctx context.Context, tab *configpb.DashboardTab, findGroup groupF
 		return nil, fmt.Errorf("find group: %v", err)
 	}
 	if group == nil {
-		return nil, fmt.Errorf("not found: %q", groupName)
+		return nil, fmt.Errorf("%w: %q", errMissingGroup, groupName)
 	}
 	grid, mod, _, err := readGrid(ctx, groupReader) // TODO(fejta): track gen
 	if err != nil && errors.Is(err, storage.ErrObjectNotExist) {
@@ -235,29 +364,230 @@ func updateTab(ctx context.Context, tab *configpb.DashboardTab, findGroup groupF
 		return nil, fmt.Errorf("load %s: %v", groupName, err)
 	}
 
-	recent := recentColumns(tab, group)
-	grid.Rows, err = filterGrid(tab.BaseOptions, grid.Rows, recent)
+	recentTab := proto.Clone(tab).(*configpb.DashboardTab)
+	recentTab.NumColumnsRecent = int32(recentColumns(tab, group))
+	recentTab.MinimumNumberOfRuns = int32(minimumRuns(tab, group))
+	recentTab.AlertOptions = config.EffectiveAlertOptions(tab, group)
+	sum := summarizeTab(grid, recentTab, group)
+	sum.LastUpdateTimestamp = float64(mod.Unix())
+	latest, _ := latestRun(grid.Columns)
+	if alert := staleAlert(mod, latest, staleHours(recentTab)); alert != "" && (mod.IsZero() || sum.Alert == "") {
+		sum.Alert = alert
+		sum.OverallStatus = summarypb.DashboardTabSummary_STALE
+	}
+	if group.UseKubernetesClient {
+		sum.LatestGreen = firstExtra(grid.Columns, sum.LatestGreen)
+	}
+	// TODO(fejta): BugUrl
+	return sum, nil
+}
+
+const noMatchingRows = "no rows match the base_options filters"
+
+// summarizeTab summarizes the grid for the tab, after filtering its rows with the tab's base_options.
+//
+// This includes the latest green column, and the tab's health over its NumColumnsRecent (or 5) columns.
+// Pending columns, whose builds are still running, do not count towards the recent columns.
+// The tab is stale when its latest column started longer than AlertStaleResultsHours (or DefaultStaleHours) ago.
+// It lists the failing rows with at least NumFailuresToAlert consecutive failures, when set.
+// It also lists the NumFlakyTests flakiest rows over the recent columns.
+// Broken columns, where most of the cells failed, do not count towards any of these.
+//
+// Skipped results count as passes, unless the group sets exclude_skips_from_pass_rate, and rows of
+// only skipped recent results are summarized, unless the group sets hide_skipped_rows.
+// When the group sets column_annotations, the status groups the recent failing columns by the first one.
+// Tabs with fewer columns than MinimumNumberOfRuns are PENDING without failing tests, unless they are stale.
+func summarizeTab(grid *statepb.Grid, tab *configpb.DashboardTab, group *configpb.TestGroup) *summarypb.DashboardTabSummary {
+	// Broken columns still ran, but their failures do not count.
+	latest, latestSeconds := latestRun(grid.GetColumns())
+	runs := len(grid.GetColumns())
+	grid = dropBroken(grid)
+	recent := recentColumns(tab, nil)
+	span := spanRecent(grid, recent)
+	rows, err := filterGrid(tab.BaseOptions, grid.GetRows(), span)
 	if err != nil {
-		return nil, fmt.Errorf("filter: %v", err)
+		logrus.WithError(err).WithField("tab", tab.Name).Error("Invalid base_options")
+		return &summarypb.DashboardTabSummary{
+			DashboardTabName: tab.Name,
+			Alert:            fmt.Sprintf("invalid base_options: %v", err),
+			OverallStatus:    summarypb.DashboardTabSummary_UNKNOWN,
+			LatestGreen:      noGreens,
+		}
+	}
+	matched := len(rows)
+	if group.GetHideSkippedRows() {
+		rows = hideSkipped(rows, span)
 	}
+	filtered := &statepb.Grid{Columns: grid.GetColumns(), Rows: rows}
 
-	latest, latestSeconds := latestRun(grid.Columns)
-	alert := staleAlert(mod, latest, staleHours(tab))
-	failures := failingTestSummaries(grid.Rows)
+	alert := runAlert(latest, staleHours(tab))
+	failures := failingTestSummaries(filtered.Columns, rows, tab.GetAlertOptions().GetNumFailuresToAlert(), artifactLinker(tab, group))
+	status := statusMessage(len(filtered.Columns), rows, span, group.GetExcludeSkipsFromPassRate())
+	if matched == 0 && len(grid.GetRows()) > 0 {
+		status = noMatchingRows
+	} else if h := healthOf(rows, span); h.tests > 0 {
+		status += "; " + h.String()
+	}
+	if keys := group.GetColumnAnnotations(); len(keys) > 0 {
+		if msg := failuresByAnnotation(filtered.Columns, rows, span, keys[0]); msg != "" {
+			status += "; " + msg
+		}
+	}
+	overall := overallStatus(filtered, recent, alert, failures)
+	if msg, ok := pendingRuns(runs, int(tab.GetMinimumNumberOfRuns()), alert); ok {
+		// Too few runs to alert on.
+		overall, status, failures = summarypb.DashboardTabSummary_PENDING, msg, nil
+	}
 	return &summarypb.DashboardTabSummary{
 		DashboardTabName:     tab.Name,
-		LastUpdateTimestamp:  float64(mod.Unix()),
 		LastRunTimestamp:     float64(latestSeconds),
 		Alert:                alert,
 		FailingTestSummaries: failures,
-		OverallStatus:        overallStatus(grid, recent, alert, failures),
-		Status:               statusMessage(len(grid.Columns), grid.Rows, recent),
-		LatestGreen:          latestGreen(grid, group.UseKubernetesClient),
-		// TODO(fejta): BugUrl
-	}, nil
+		OverallStatus:        overall,
+		Status:               status,
+		LatestGreen:          latestGreen(filtered, false),
+		FlakyTests:           flakyTests(rows, span, int(tab.NumFlakyTests)),
+	}
+}
+
+// pendingRuns returns a message counting the runs when there are fewer than min, unless the alert says they are stale.
+//
+// A grid without runs has none to be stale, so it is pending too.
+func pendingRuns(runs, min int, alert string) (string, bool) {
+	if runs >= min || (alert != "" && alert != noRuns) {
+		return "", false
+	}
+	return fmt.Sprintf("waiting for %d runs before computing a status, found %d", min, runs), true
+}
+
+// failuresByAnnotation counts the recent columns with a failing cell by their value of the annotation key.
+//
+// The result lists the most common values first, such as "failing runs by cluster: a (2), b (1)",
+// or is empty when no failing column has the annotation.
+func failuresByAnnotation(cols []*statepb.Column, rows []*statepb.Row, recent int, key string) string {
+	if recent > len(cols) {
+		recent = len(cols)
+	}
+	failed := make([]bool, recent)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for _, row := range rows {
+		var i int
+		for res := range resultIter(ctx, row.Results) {
+			if i == recent {
+				break
+			}
+			if coalesceResult(res, result.IgnoreRunning) == statepb.Row_FAIL {
+				failed[i] = true
+			}
+			i++
+		}
+	}
+	counts := map[string]int{}
+	var values []string
+	for i, f := range failed {
+		if !f {
+			continue
+		}
+		v, ok := tgstate.Annotation(cols[i], key)
+		if !ok {
+			continue
+		}
+		if counts[v] == 0 {
+			values = append(values, v)
+		}
+		counts[v]++
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if counts[values[i]] != counts[values[j]] {
+			return counts[values[i]] > counts[values[j]]
+		}
+		return values[i] < values[j]
+	})
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = fmt.Sprintf("%s (%d)", v, counts[v])
+	}
+	return fmt.Sprintf("failing runs by %s: %s", key, strings.Join(parts, ", "))
+}
+
+// firstExtra returns the first column header of the column with the build ID, or else the build ID.
+func firstExtra(cols []*statepb.Column, build string) string {
+	for _, col := range cols {
+		if col.Build == build && len(col.Extra) > 0 {
+			return col.Extra[0]
+		}
+	}
+	return build
+}
+
+// health counts the failing and flaky tests in recent columns.
+type health struct {
+	// tests with a result other than a skip in a recent column.
+	tests int
+	// failing tests only failed.
+	failing int
+	// flaky tests both passed and failed, or had a flaky result.
+	flaky int
+}
+
+// flakiness returns the percentage of tests that are flaky.
+func (h health) flakiness() float64 {
+	if h.tests == 0 {
+		return 0
+	}
+	return 100 * float64(h.flaky) / float64(h.tests)
+}
+
+func (h health) String() string {
+	return fmt.Sprintf("%d of %d tests failing, %d flaky (%.1f%%)", h.failing, h.tests, h.flaky, h.flakiness())
+}
+
+// healthOf returns the health of the rows in the recent columns.
+//
+// Skipped and running results are ignored, so rows of only skips do not count as tests.
+func healthOf(rows []*statepb.Row, recent int) health {
+	var h health
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for _, row := range rows {
+		var passes, failures, flakes int
+		var cols int
+		for res := range resultIter(ctx, row.Results) {
+			if cols == recent {
+				break
+			}
+			cols++
+			if res == statepb.Row_PASS_WITH_SKIPS {
+				continue
+			}
+			switch coalesceResult(res, result.IgnoreRunning) {
+			case statepb.Row_PASS:
+				passes++
+			case statepb.Row_FAIL:
+				failures++
+			case statepb.Row_FLAKY:
+				flakes++
+			}
+		}
+		if passes+failures+flakes == 0 {
+			continue
+		}
+		h.tests++
+		switch {
+		case flakes > 0, passes > 0 && failures > 0:
+			h.flaky++
+		case failures > 0:
+			h.failing++
+		}
+	}
+	return h
 }
 
-// readGrid downloads and deserializes the current test group state.
+// readGrid downloads and deserializes the current test group state, which may be gzip or zlib compressed.
 func readGrid(ctx context.Context, reader gridReader) (*statepb.Grid, time.Time, int64, error) {
 	var t time.Time
 	r, mod, gen, err := reader(ctx)
@@ -265,24 +595,95 @@ func readGrid(ctx context.Context, reader gridReader) (*statepb.Grid, time.Time,
 		return nil, t, 0, fmt.Errorf("open: %w", err)
 	}
 	defer r.Close()
-	zlibReader, err := zlib.NewReader(r)
-	if err != nil {
-		return nil, t, 0, fmt.Errorf("decompress: %v", err)
-	}
-	buf, err := ioutil.ReadAll(zlibReader)
+	buf, err := ioutil.ReadAll(r)
 	if err != nil {
 		return nil, t, 0, fmt.Errorf("read: %v", err)
 	}
-	var g statepb.Grid
-	if err = proto.Unmarshal(buf, &g); err != nil {
-		return nil, t, 0, fmt.Errorf("parse: %v", err)
+	g, err := tgstate.DecodeGrid(buf)
+	if err != nil {
+		return nil, t, 0, fmt.Errorf("decode: %v", err)
 	}
-	return &g, mod, gen, nil
+	return g, mod, gen, nil
 }
 
 // recentColumns returns the configured number of recent columns to summarize, or 5.
 func recentColumns(tab *configpb.DashboardTab, group *configpb.TestGroup) int {
-	return firstFilled(tab.NumColumnsRecent, group.NumColumnsRecent, 5)
+	return firstFilled(tab.NumColumnsRecent, group.GetNumColumnsRecent(), 5)
+}
+
+// minimumRuns returns the configured number of columns a grid needs before the tab computes a status, or 0.
+func minimumRuns(tab *configpb.DashboardTab, group *configpb.TestGroup) int {
+	return firstFilled(tab.GetMinimumNumberOfRuns(), group.GetMinimumNumberOfRuns())
+}
+
+// spanRecent returns the number of leading columns that include recent finished columns.
+//
+// A column is pending when its Overall row (whose ID is always Overall) is still running.
+func spanRecent(grid *statepb.Grid, recent int) int {
+	var overall *statepb.Row
+	for _, r := range grid.GetRows() {
+		if r.Id == "Overall" {
+			overall = r
+			break
+		}
+	}
+	if overall == nil {
+		return recent
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := resultIter(ctx, overall.Results)
+	var span, finished int
+	for range grid.Columns {
+		if finished == recent {
+			break
+		}
+		span++
+		if <-ch != statepb.Row_RUNNING {
+			finished++
+		}
+	}
+	return span + recent - finished
+}
+
+// dropBroken returns the grid without its broken columns, or the grid itself when none are broken.
+//
+// Only the results of the rows drop the cells of broken columns, as the messages and other cell fields are not summarized.
+func dropBroken(grid *statepb.Grid) *statepb.Grid {
+	var broken []bool
+	var cols []*statepb.Column
+	for i, col := range grid.GetColumns() {
+		if !col.GetStats().GetBroken() {
+			cols = append(cols, col)
+			continue
+		}
+		if broken == nil {
+			broken = make([]bool, len(grid.Columns))
+		}
+		broken[i] = true
+	}
+	if broken == nil {
+		return grid
+	}
+	out := *grid
+	out.Columns = cols
+	out.Rows = make([]*statepb.Row, 0, len(grid.Rows))
+	for _, row := range grid.Rows {
+		healthy := *row
+		healthy.Results = nil
+		it := tgstate.DecodeResults(row.Results, 0)
+		for col := 0; ; col++ {
+			res, ok := it.Next()
+			if !ok {
+				break
+			}
+			if col >= len(broken) || !broken[col] {
+				healthy.Results = tgstate.AppendResult(healthy.Results, res, 1)
+			}
+		}
+		out.Rows = append(out.Rows, &healthy)
+	}
+	return &out
 }
 
 // firstFilled returns the first non-empty value, or zero.
@@ -295,33 +696,15 @@ func firstFilled(values ...int32) int {
 	return 0
 }
 
-const (
-	includeFilter = "include-filter-by-regex"
-	excludeFilter = "exclude-filter-by-regex"
-	// TODO(fejta): others, which are not used by testgrid.k8s.io
-)
-
 // filterGrid truncates the grid to rows with recent results and matching the white/blacklist.
 func filterGrid(baseOptions string, rows []*statepb.Row, recent int) ([]*statepb.Row, error) {
-
-	vals, err := url.ParseQuery(baseOptions)
+	opts, err := filter.ParseOptions(baseOptions)
 	if err != nil {
-		return nil, fmt.Errorf("parse %q: %v", baseOptions, err)
+		return nil, err
 	}
 
 	rows = recentRows(rows, recent)
-
-	for _, include := range vals[includeFilter] {
-		if rows, err = includeRows(rows, include); err != nil {
-			return nil, fmt.Errorf("bad %s=%s: %v", includeFilter, include, err)
-		}
-	}
-
-	for _, exclude := range vals[excludeFilter] {
-		if rows, err = excludeRows(rows, exclude); err != nil {
-			return nil, fmt.Errorf("bad %s=%s: %v", excludeFilter, exclude, err)
-		}
-	}
+	rows = filter.FilterRows(&statepb.Grid{Rows: rows}, opts)
 
 	// TODO(fejta): grouping, which is not used by testgrid.k8s.io
 	// TODO(fejta): sorting, unused by testgrid.k8s.io
@@ -330,51 +713,40 @@ func filterGrid(baseOptions string, rows []*statepb.Row, recent int) ([]*statepb
 	return rows, nil
 }
 
-// recentRows returns the subset of rows with at least one recent result
-func recentRows(in []*statepb.Row, recent int) []*statepb.Row {
+// hideSkipped returns the subset of rows with a result other than a skip in the recent columns.
+func hideSkipped(in []*statepb.Row, recent int) []*statepb.Row {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 	var rows []*statepb.Row
 	for _, r := range in {
-		if r.Results == nil {
-			continue
-		}
-		if statepb.Row_Result(r.Results[0]) == statepb.Row_NO_RESULT && int(r.Results[1]) >= recent {
-			continue
+		var cols int
+		for res := range resultIter(ctx, r.Results) {
+			if cols == recent {
+				break
+			}
+			cols++
+			if res != statepb.Row_NO_RESULT && res != statepb.Row_PASS_WITH_SKIPS {
+				rows = append(rows, r)
+				break
+			}
 		}
-		rows = append(rows, r)
 	}
 	return rows
 }
 
-// includeRows returns the subset of rows that match the regex
-func includeRows(in []*statepb.Row, include string) ([]*statepb.Row, error) {
-	re, err := regexp.Compile(include)
-	if err != nil {
-		return nil, err
-	}
+// recentRows returns the subset of rows with at least one recent result
+func recentRows(in []*statepb.Row, recent int) []*statepb.Row {
 	var rows []*statepb.Row
 	for _, r := range in {
-		if !re.MatchString(r.Name) {
+		if r.Results == nil {
 			continue
 		}
-		rows = append(rows, r)
-	}
-	return rows, nil
-}
-
-// excludeRows returns the subset of rows that do not match the regex
-func excludeRows(in []*statepb.Row, exclude string) ([]*statepb.Row, error) {
-	re, err := regexp.Compile(exclude)
-	if err != nil {
-		return nil, err
-	}
-	var rows []*statepb.Row
-	for _, r := range in {
-		if re.MatchString(r.Name) {
+		if statepb.Row_Result(r.Results[0]) == statepb.Row_NO_RESULT && int(r.Results[1]) >= recent {
 			continue
 		}
 		rows = append(rows, r)
 	}
-	return rows, nil
+	return rows
 }
 
 // latestRun returns the Time (and seconds-since-epoch) of the most recent run.
@@ -402,21 +774,46 @@ func staleAlert(mod, ran time.Time, stale time.Duration) string {
 	if stale == 0 {
 		return ""
 	}
-	now := time.Now()
-	if dur := now.Sub(mod); dur > stale {
-		return fmt.Sprintf("data has not changed since %s (%s old)", mod, dur.Truncate(15*time.Minute))
+	if dur := time.Since(mod); dur > stale {
+		return fmt.Sprintf("data has not changed since %s (%s old)", mod, age(dur))
 	}
-	if dur := now.Sub(ran); dur > stale {
-		return fmt.Sprintf("latest column from %s (%s old)", ran, dur.Truncate(15*time.Minute))
+	return runAlert(ran, stale)
+}
+
+// runAlert returns an explanatory message if the latest column is missing or older than stale (when non-zero).
+func runAlert(ran time.Time, stale time.Duration) string {
+	if ran.IsZero() {
+		return noRuns
+	}
+	if stale == 0 {
+		return ""
+	}
+	if dur := time.Since(ran); dur > stale {
+		return fmt.Sprintf("latest column from %s (%s old, more than %s)", ran, age(dur), age(stale))
 	}
 	return ""
 }
 
-// failingTestSummaries returns details for every row with an active alert.
-func failingTestSummaries(rows []*statepb.Row) []*summarypb.FailingTestSummary {
+// age describes the duration in days and hours, such as 3d4h.
+func age(dur time.Duration) string {
+	days := int(dur / (24 * time.Hour))
+	hours := int(dur%(24*time.Hour)) / int(time.Hour)
+	switch {
+	case days == 0:
+		return fmt.Sprintf("%dh", hours)
+	case hours == 0:
+		return fmt.Sprintf("%dd", days)
+	}
+	return fmt.Sprintf("%dd%dh", days, hours)
+}
+
+// failingTestSummaries returns details for every row with an active alert of at least minFailures failures.
+//
+// Each summary also describes the newest column in which the row failed, linking to its build with link.
+func failingTestSummaries(cols []*statepb.Column, rows []*statepb.Row, minFailures int32, link func(build string) string) []*summarypb.FailingTestSummary {
 	var failures []*summarypb.FailingTestSummary
 	for _, row := range rows {
-		if row.AlertInfo == nil {
+		if row.AlertInfo == nil || row.AlertInfo.FailCount < minFailures {
 			continue
 		}
 		alert := row.AlertInfo
@@ -440,12 +837,64 @@ func failingTestSummaries(rows []*statepb.Row) []*summarypb.FailingTestSummary {
 		if alert.FailTime != nil {
 			sum.FailTimestamp = float64(alert.FailTime.Seconds)
 		}
+		if col := latestFailure(cols, row); col != nil {
+			sum.LatestFailBuildId = col.Build
+			sum.LatestFailTimestamp = col.Started
+			sum.LatestFailLink = link(col.Build)
+		}
 
 		failures = append(failures, &sum)
 	}
 	return failures
 }
 
+// latestFailure returns the newest column in which the row failed, or nil.
+func latestFailure(cols []*statepb.Column, row *statepb.Row) *statepb.Column {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var i int
+	for res := range resultIter(ctx, row.Results) {
+		if i == len(cols) {
+			break
+		}
+		if coalesceResult(res, result.IgnoreRunning) == statepb.Row_FAIL {
+			return cols[i]
+		}
+		i++
+	}
+	return nil
+}
+
+// artifactsURL browses the artifacts that builds upload to GCS.
+const artifactsURL = "https://console.cloud.google.com/storage/browser/"
+
+// artifactLinker returns a function linking to the artifacts of a build of the group.
+//
+// Links expand the results_url_template of the tab when set, and otherwise browse the build under
+// the gcs_prefix of the group. Groups reading local file:// results have no links.
+func artifactLinker(tab *configpb.DashboardTab, group *configpb.TestGroup) func(build string) string {
+	prefix := group.GetQuery()
+	if prefix == "" || strings.HasPrefix(prefix, "file://") {
+		return func(string) string { return "" }
+	}
+	prefix = strings.Trim(strings.TrimPrefix(prefix, "gs://"), "/")
+	tmpl := tab.GetResultsUrlTemplate()
+	return func(build string) string {
+		if tmpl.GetUrl() == "" {
+			return artifactsURL + prefix + "/" + url.PathEscape(build) + "/"
+		}
+		link, err := config.RenderLink(tmpl, config.LinkValues{
+			Tab:       tab.Name,
+			BuildIDs:  []string{build},
+			GcsPrefix: prefix,
+		})
+		if err != nil {
+			return "" // Config validation reports invalid templates.
+		}
+		return link
+	}
+}
+
 // overallStatus determines whether the tab is stale, failing, flaky or healthy.
 func overallStatus(grid *statepb.Grid, recent int, stale string, alerts []*summarypb.FailingTestSummary) summarypb.DashboardTabSummary_TabStatus {
 	if stale != "" {
@@ -490,7 +939,10 @@ func fmtStatus(passCols, cols, passCells, cells int) string {
 	return fmt.Sprintf("%d of %d (%.1f%%) recent columns passed (%d of %d or %.1f%% cells)", passCols, cols, colCent, passCells, cells, cellCent)
 }
 
-func statusMessage(cols int, rows []*statepb.Row, recent int) string {
+// statusMessage describes the passing columns and cells of the recent columns.
+//
+// Skipped results count as passing cells, unless excludeSkips is set.
+func statusMessage(cols int, rows []*statepb.Row, recent int, excludeSkips bool) string {
 	//  2483 of 115784 tests (2.1%) and 163 of 164 runs (99.4%) failed in the past 7 days
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -507,8 +959,12 @@ func statusMessage(cols int, rows []*statepb.Row, recent int) string {
 		var passes bool
 		var failures bool
 		for _, ch := range results {
+			res := <-ch
+			if excludeSkips && res == statepb.Row_PASS_WITH_SKIPS {
+				res = statepb.Row_NO_RESULT
+			}
 			// TODO(fejta): fail old running cols
-			switch coalesceResult(<-ch, result.IgnoreRunning) {
+			switch coalesceResult(res, result.IgnoreRunning) {
 			case statepb.Row_PASS:
 				if !failures {
 					passes = true
@@ -549,6 +1005,7 @@ func latestGreen(grid *statepb.Grid, useFirstExtra bool) string {
 	for _, col := range grid.Columns {
 		var failures bool
 		var passes bool
+		// Read every row, even after a failure, so each channel stays on this column.
 		for _, resultCh := range results {
 			result := coalesceResult(<-resultCh, result.FailRunning)
 			if result == statepb.Row_PASS {
@@ -556,7 +1013,6 @@ func latestGreen(grid *statepb.Grid, useFirstExtra bool) string {
 			}
 			if result == statepb.Row_FLAKY || result == statepb.Row_FAIL {
 				failures = true
-				break
 			}
 		}
 		if failures || !passes {
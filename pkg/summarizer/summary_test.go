@@ -18,6 +18,7 @@ package summarizer
 
 import (
 	"bytes"
+	"compress/gzip"
 	"compress/zlib"
 	"context"
 	"errors"
@@ -26,16 +27,20 @@ import (
 	"io/ioutil"
 	"net/url"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"cloud.google.com/go/storage"
 	"github.com/golang/protobuf/proto"
 
+	"github.com/GoogleCloudPlatform/testgrid/internal/filter"
 	"github.com/GoogleCloudPlatform/testgrid/internal/result"
 	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
 	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
 	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
 )
 
 type fakeGroup struct {
@@ -122,7 +127,7 @@ func TestUpdateDashboard(t *testing.T) {
 						OverallStatus:       summarypb.DashboardTabSummary_STALE,
 						LatestGreen:         noGreens,
 					},
-					problemTab("missing-tab"),
+					brokenTab("missing-tab"),
 					problemTab("error-tab"),
 					{
 						DashboardTabName:    "still-working",
@@ -136,6 +141,42 @@ func TestUpdateDashboard(t *testing.T) {
 			},
 			err: true,
 		},
+		{
+			name: "paused dashboards skip every tab",
+			dash: &configpb.Dashboard{
+				Paused:       true,
+				PausedReason: "migrating to new jobs",
+				DashboardTab: []*configpb.DashboardTab{
+					{
+						Name:          "working",
+						TestGroupName: "working-group",
+					},
+					{
+						Name:          "missing-tab",
+						TestGroupName: "group-not-present",
+					},
+				},
+			},
+			groups: map[string]fakeGroup{
+				"working-group": {
+					mod: time.Unix(1000, 0),
+				},
+			},
+			expected: &summarypb.DashboardSummary{
+				TabSummaries: []*summarypb.DashboardTabSummary{
+					{
+						DashboardTabName: "working",
+						Status:           "Paused: migrating to new jobs",
+						OverallStatus:    summarypb.DashboardTabSummary_PAUSED,
+					},
+					{
+						DashboardTabName: "missing-tab",
+						Status:           "Paused: migrating to new jobs",
+						OverallStatus:    summarypb.DashboardTabSummary_PAUSED,
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -167,6 +208,32 @@ func TestUpdateDashboard(t *testing.T) {
 	}
 }
 
+func TestUpdateDashboardCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	dash := &configpb.Dashboard{
+		Name: "dash",
+		DashboardTab: []*configpb.DashboardTab{
+			{Name: "first", TestGroupName: "group"},
+			{Name: "second", TestGroupName: "group"},
+		},
+	}
+	var found int
+	finder := func(name string) (*configpb.TestGroup, gridReader, error) {
+		found++
+		cancel() // Stop after summarizing this tab.
+		return &configpb.TestGroup{Name: name}, func(context.Context) (io.ReadCloser, time.Time, int64, error) {
+			return nil, time.Time{}, 0, storage.ErrObjectNotExist
+		}, nil
+	}
+	sum, err := updateDashboard(ctx, dash, finder)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("updateDashboard() got %v, want %v", err, context.Canceled)
+	}
+	if found != 1 || len(sum.TabSummaries) != 1 {
+		t.Errorf("updateDashboard() summarized %d tabs after cancelling, want 1", len(sum.TabSummaries))
+	}
+}
+
 func TestStaleHours(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -174,8 +241,15 @@ func TestStaleHours(t *testing.T) {
 		expected time.Duration
 	}{
 		{
-			name:     "zero without an alert",
-			expected: 0,
+			name:     "default without an alert",
+			expected: DefaultStaleHours * time.Hour,
+		},
+		{
+			name: "default when unset",
+			tab: &configpb.DashboardTab{
+				AlertOptions: &configpb.DashboardTabAlertOptions{},
+			},
+			expected: DefaultStaleHours * time.Hour,
 		},
 		{
 			name: "use defined hours when set",
@@ -200,6 +274,93 @@ func TestStaleHours(t *testing.T) {
 	}
 }
 
+func TestStaleSince(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	grid := func(started ...time.Time) *statepb.Grid {
+		var g statepb.Grid
+		for _, s := range started {
+			g.Columns = append(g.Columns, &statepb.Column{Started: float64(s.Unix())})
+		}
+		return &g
+	}
+	cases := []struct {
+		name      string
+		grid      *statepb.Grid
+		threshold time.Duration
+		since     time.Time
+		stale     bool
+	}{
+		{
+			name: "nil grid is not stale",
+		},
+		{
+			name: "no columns is not stale",
+			grid: grid(),
+		},
+		{
+			name:  "recent column",
+			grid:  grid(now.Add(-time.Hour)),
+			since: now.Add(-time.Hour),
+		},
+		{
+			name:  "default threshold",
+			grid:  grid(now.Add(-DefaultStaleHours*time.Hour - time.Hour)),
+			since: now.Add(-DefaultStaleHours*time.Hour - time.Hour),
+			stale: true,
+		},
+		{
+			name:      "custom threshold",
+			grid:      grid(now.Add(-3*time.Hour), now.Add(-time.Hour)),
+			threshold: 2 * time.Hour,
+			since:     now.Add(-3 * time.Hour),
+			stale:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			since, stale := StaleSince(tc.grid, tc.threshold)
+			if !since.Equal(tc.since) {
+				t.Errorf("StaleSince() got time %v, want %v", since, tc.since)
+			}
+			if stale != tc.stale {
+				t.Errorf("StaleSince() got stale %t, want %t", stale, tc.stale)
+			}
+		})
+	}
+}
+
+func TestAge(t *testing.T) {
+	cases := []struct {
+		dur  time.Duration
+		want string
+	}{
+		{
+			want: "0h",
+		},
+		{
+			dur:  5*time.Hour + 20*time.Minute,
+			want: "5h",
+		},
+		{
+			dur:  48 * time.Hour,
+			want: "2d",
+		},
+		{
+			dur:  7*24*time.Hour + 3*time.Hour,
+			want: "7d3h",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.want, func(t *testing.T) {
+			if got := age(tc.dur); got != tc.want {
+				t.Errorf("age(%v) got %q, want %q", tc.dur, got, tc.want)
+			}
+		})
+	}
+}
+
 func gridBuf(grid *statepb.Grid) []byte {
 	buf, err := proto.Marshal(grid)
 	if err != nil {
@@ -220,6 +381,18 @@ func compress(buf []byte) []byte {
 	return zbuf.Bytes()
 }
 
+func gzipped(buf []byte) []byte {
+	var zbuf bytes.Buffer
+	zw := gzip.NewWriter(&zbuf)
+	if _, err := zw.Write(buf); err != nil {
+		panic(err)
+	}
+	if err := zw.Close(); err != nil {
+		panic(err)
+	}
+	return zbuf.Bytes()
+}
+
 func TestUpdateTab(t *testing.T) {
 	now := time.Now()
 	cases := []struct {
@@ -325,6 +498,640 @@ func TestUpdateTab(t *testing.T) {
 	}
 }
 
+func TestUpdateTabAlertOptions(t *testing.T) {
+	now := time.Now()
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{
+			{
+				Build:   "1",
+				Started: float64(now.Add(-3 * time.Hour).Unix()),
+			},
+		},
+		Rows: []*statepb.Row{
+			{
+				Name:      "flaky",
+				Id:        "flaky",
+				Results:   []int32{int32(statepb.Row_FAIL), 1},
+				AlertInfo: &statepb.AlertInfo{FailCount: 2},
+			},
+			{
+				Name:      "broken",
+				Id:        "broken",
+				Results:   []int32{int32(statepb.Row_FAIL), 1},
+				AlertInfo: &statepb.AlertInfo{FailCount: 4},
+			},
+		},
+	}
+	cases := []struct {
+		name    string
+		tab     *configpb.DashboardTabAlertOptions
+		group   *configpb.TestGroup
+		stale   bool
+		failing int
+	}{
+		{
+			name:    "defaults",
+			group:   &configpb.TestGroup{},
+			failing: 2,
+		},
+		{
+			name: "inherit from the group",
+			group: &configpb.TestGroup{
+				AlertStaleResultsHours: 1,
+				NumFailuresToAlert:     3,
+			},
+			stale:   true,
+			failing: 1,
+		},
+		{
+			name: "tab overrides the group",
+			tab: &configpb.DashboardTabAlertOptions{
+				AlertStaleResultsHours: 6,
+				NumFailuresToAlert:     5,
+			},
+			group: &configpb.TestGroup{
+				AlertStaleResultsHours: 1,
+				NumFailuresToAlert:     3,
+			},
+		},
+		{
+			name: "tab overrides only stale hours",
+			tab: &configpb.DashboardTabAlertOptions{
+				AlertStaleResultsHours: 2,
+			},
+			group: &configpb.TestGroup{
+				AlertStaleResultsHours: 6,
+				NumFailuresToAlert:     3,
+			},
+			stale:   true,
+			failing: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tab := &configpb.DashboardTab{
+				Name:          "tab",
+				TestGroupName: "group",
+				AlertOptions:  tc.tab,
+			}
+			finder := func(string) (*configpb.TestGroup, gridReader, error) {
+				reader := func(_ context.Context) (io.ReadCloser, time.Time, int64, error) {
+					return ioutil.NopCloser(bytes.NewBuffer(compress(gridBuf(grid)))), now, 1, nil
+				}
+				return tc.group, reader, nil
+			}
+			sum, err := updateTab(context.Background(), tab, finder)
+			if err != nil {
+				t.Fatalf("updateTab() got unexpected error: %v", err)
+			}
+			if stale := sum.OverallStatus == summarypb.DashboardTabSummary_STALE; stale != tc.stale {
+				t.Errorf("updateTab() got overall status %s, want stale=%t", sum.OverallStatus, tc.stale)
+			}
+			if n := len(sum.FailingTestSummaries); n != tc.failing {
+				t.Errorf("updateTab() got %d failing tests, want %d", n, tc.failing)
+			}
+		})
+	}
+}
+
+func TestSummarizeTab(t *testing.T) {
+	now := float64(time.Now().Unix())
+	old := float64(time.Now().Add(-48 * time.Hour).Unix())
+	cols := func(started float64, builds ...string) []*statepb.Column {
+		var out []*statepb.Column
+		for _, b := range builds {
+			out = append(out, &statepb.Column{Build: b, Started: started})
+		}
+		return out
+	}
+	broken := func(cols []*statepb.Column, idx ...int) []*statepb.Column {
+		for _, i := range idx {
+			cols[i].Stats = &statepb.Stats{Broken: true}
+		}
+		return cols
+	}
+	pass := int32(statepb.Row_PASS)
+	fail := int32(statepb.Row_FAIL)
+	skip := int32(statepb.Row_PASS_WITH_SKIPS)
+	running := int32(statepb.Row_RUNNING)
+	cases := []struct {
+		name        string
+		tab         *configpb.DashboardTab
+		group       *configpb.TestGroup
+		grid        *statepb.Grid
+		alert       string
+		status      string
+		overall     summarypb.DashboardTabSummary_TabStatus
+		latestGreen string
+		failing     int
+	}{
+		{
+			name:        "zero columns",
+			grid:        &statepb.Grid{},
+			alert:       noRuns,
+			status:      noRuns,
+			overall:     summarypb.DashboardTabSummary_STALE,
+			latestGreen: noGreens,
+		},
+		{
+			name: "passing",
+			grid: &statepb.Grid{
+				Columns: cols(now, "3", "2", "1"),
+				Rows: []*statepb.Row{
+					{Name: "a", Results: []int32{pass, 3}},
+				},
+			},
+			status:      "3 of 3 (100.0%) recent columns passed (3 of 3 or 100.0% cells); 0 of 1 tests failing, 0 flaky (0.0%)",
+			overall:     summarypb.DashboardTabSummary_PASS,
+			latestGreen: "3",
+		},
+		{
+			name: "failing and flaky",
+			tab:  &configpb.DashboardTab{NumColumnsRecent: 2},
+			grid: &statepb.Grid{
+				Columns: cols(now, "4", "3", "2", "1"),
+				Rows: []*statepb.Row{
+					{Name: "passing", Results: []int32{pass, 4}},
+					{Name: "failing", Results: []int32{fail, 2, pass, 2}, AlertInfo: &statepb.AlertInfo{FailCount: 2}},
+					{Name: "flaky", Results: []int32{pass, 1, fail, 1, pass, 2}},
+					{Name: "skipped", Results: []int32{skip, 4}},
+				},
+			},
+			status:      "0 of 2 (0.0%) recent columns passed (5 of 8 or 62.5% cells); 1 of 3 tests failing, 1 flaky (33.3%)",
+			overall:     summarypb.DashboardTabSummary_FAIL,
+			latestGreen: "2",
+			failing:     1,
+		},
+		{
+			name:  "group failing columns by annotation",
+			group: &configpb.TestGroup{ColumnAnnotations: []string{"cluster", "zone"}},
+			grid: &statepb.Grid{
+				Columns: func() []*statepb.Column {
+					out := cols(now, "3", "2", "1")
+					for i, c := range []string{"east", "west", "east"} {
+						out[i].Metadata = map[string]string{"cluster": c, "zone": "z"}
+					}
+					return out
+				}(),
+				Rows: []*statepb.Row{
+					{Name: "a", Results: []int32{fail, 1, pass, 1, fail, 1}},
+				},
+			},
+			status:      "1 of 3 (33.3%) recent columns passed (1 of 3 or 33.3% cells); 0 of 1 tests failing, 1 flaky (100.0%); failing runs by cluster: east (2)",
+			overall:     summarypb.DashboardTabSummary_FLAKY,
+			latestGreen: "2",
+		},
+		{
+			name: "entirely skips",
+			grid: &statepb.Grid{
+				Columns: cols(now, "1"),
+				Rows: []*statepb.Row{
+					{Name: "skipped", Results: []int32{skip, 1}},
+				},
+			},
+			status:      "1 of 1 (100.0%) recent columns passed (1 of 1 or 100.0% cells)",
+			overall:     summarypb.DashboardTabSummary_PASS,
+			latestGreen: "1",
+		},
+		{
+			name:  "exclude skips from pass rate",
+			tab:   &configpb.DashboardTab{NumColumnsRecent: 2},
+			group: &configpb.TestGroup{ExcludeSkipsFromPassRate: true},
+			grid: &statepb.Grid{
+				Columns: cols(now, "4", "3", "2", "1"),
+				Rows: []*statepb.Row{
+					{Name: "passing", Results: []int32{pass, 4}},
+					{Name: "failing", Results: []int32{fail, 2, pass, 2}, AlertInfo: &statepb.AlertInfo{FailCount: 2}},
+					{Name: "flaky", Results: []int32{pass, 1, fail, 1, pass, 2}},
+					{Name: "skipped", Results: []int32{skip, 4}},
+				},
+			},
+			status:      "0 of 2 (0.0%) recent columns passed (3 of 6 or 50.0% cells); 1 of 3 tests failing, 1 flaky (33.3%)",
+			overall:     summarypb.DashboardTabSummary_FAIL,
+			latestGreen: "2",
+			failing:     1,
+		},
+		{
+			name:  "exclude entirely skips",
+			group: &configpb.TestGroup{ExcludeSkipsFromPassRate: true},
+			grid: &statepb.Grid{
+				Columns: cols(now, "1"),
+				Rows: []*statepb.Row{
+					{Name: "skipped", Results: []int32{skip, 1}},
+				},
+			},
+			status:      noRuns,
+			overall:     summarypb.DashboardTabSummary_PASS,
+			latestGreen: "1",
+		},
+		{
+			name:  "hide entirely skips",
+			group: &configpb.TestGroup{HideSkippedRows: true},
+			grid: &statepb.Grid{
+				Columns: cols(now, "1"),
+				Rows: []*statepb.Row{
+					{Name: "skipped", Results: []int32{skip, 1}},
+				},
+			},
+			status:      noRuns,
+			overall:     summarypb.DashboardTabSummary_UNKNOWN,
+			latestGreen: noGreens,
+		},
+		{
+			name:  "hide rows recently skipped after failing",
+			tab:   &configpb.DashboardTab{NumColumnsRecent: 2},
+			group: &configpb.TestGroup{HideSkippedRows: true},
+			grid: &statepb.Grid{
+				Columns: cols(now, "4", "3", "2", "1"),
+				Rows: []*statepb.Row{
+					{Name: "passing", Results: []int32{pass, 4}},
+					{Name: "disabled", Results: []int32{skip, 2, fail, 2}, AlertInfo: &statepb.AlertInfo{FailCount: 2}},
+				},
+			},
+			status:      "2 of 2 (100.0%) recent columns passed (2 of 2 or 100.0% cells); 0 of 1 tests failing, 0 flaky (0.0%)",
+			overall:     summarypb.DashboardTabSummary_PASS,
+			latestGreen: "4",
+		},
+		{
+			name:  "show rows recently failing after skips",
+			tab:   &configpb.DashboardTab{NumColumnsRecent: 2},
+			group: &configpb.TestGroup{HideSkippedRows: true},
+			grid: &statepb.Grid{
+				Columns: cols(now, "4", "3", "2", "1"),
+				Rows: []*statepb.Row{
+					{Name: "passing", Results: []int32{pass, 4}},
+					{Name: "enabled", Results: []int32{fail, 2, skip, 2}, AlertInfo: &statepb.AlertInfo{FailCount: 2}},
+				},
+			},
+			status:      "0 of 2 (0.0%) recent columns passed (2 of 4 or 50.0% cells); 1 of 2 tests failing, 0 flaky (0.0%)",
+			overall:     summarypb.DashboardTabSummary_FAIL,
+			latestGreen: "2",
+			failing:     1,
+		},
+		{
+			name: "broken columns do not count",
+			tab:  &configpb.DashboardTab{NumColumnsRecent: 2},
+			grid: &statepb.Grid{
+				Columns: broken(cols(now, "4", "3", "2", "1"), 0, 1),
+				Rows: []*statepb.Row{
+					{Name: "a", Results: []int32{fail, 2, pass, 2}},
+					{Name: "b", Results: []int32{fail, 2, pass, 2}},
+				},
+			},
+			status:      "2 of 2 (100.0%) recent columns passed (4 of 4 or 100.0% cells); 0 of 2 tests failing, 0 flaky (0.0%)",
+			overall:     summarypb.DashboardTabSummary_PASS,
+			latestGreen: "2",
+		},
+		{
+			name: "pending columns are not recent",
+			tab:  &configpb.DashboardTab{NumColumnsRecent: 1},
+			grid: &statepb.Grid{
+				Columns: cols(now, "3", "2", "1"),
+				Rows: []*statepb.Row{
+					{Name: "Overall", Id: "Overall", Results: []int32{running, 1, pass, 2}},
+					{Name: "a", Id: "a", Results: []int32{running, 1, pass, 1, fail, 1}},
+				},
+			},
+			status:      "1 of 1 (100.0%) recent columns passed (2 of 2 or 100.0% cells); 0 of 2 tests failing, 0 flaky (0.0%)",
+			overall:     summarypb.DashboardTabSummary_PASS,
+			latestGreen: "2",
+		},
+		{
+			name: "stale",
+			tab: &configpb.DashboardTab{
+				AlertOptions: &configpb.DashboardTabAlertOptions{AlertStaleResultsHours: 24},
+			},
+			grid: &statepb.Grid{
+				Columns: cols(old, "1"),
+				Rows: []*statepb.Row{
+					{Name: "a", Results: []int32{pass, 1}},
+				},
+			},
+			alert:       "latest column from",
+			status:      "1 of 1 (100.0%) recent columns passed",
+			overall:     summarypb.DashboardTabSummary_STALE,
+			latestGreen: "1",
+		},
+		{
+			name: "fewer runs than the minimum are pending",
+			tab:  &configpb.DashboardTab{MinimumNumberOfRuns: 3},
+			grid: &statepb.Grid{
+				Columns: cols(now, "2", "1"),
+				Rows: []*statepb.Row{
+					{Name: "flaky", Results: []int32{pass, 1, fail, 1}},
+				},
+			},
+			status:      "waiting for 3 runs before computing a status, found 2",
+			overall:     summarypb.DashboardTabSummary_PENDING,
+			latestGreen: "2",
+		},
+		{
+			name: "exactly the minimum runs",
+			tab:  &configpb.DashboardTab{MinimumNumberOfRuns: 2},
+			grid: &statepb.Grid{
+				Columns: cols(now, "2", "1"),
+				Rows: []*statepb.Row{
+					{Name: "flaky", Results: []int32{pass, 1, fail, 1}},
+				},
+			},
+			status:      "1 of 2 (50.0%) recent columns passed",
+			overall:     summarypb.DashboardTabSummary_FLAKY,
+			latestGreen: "2",
+		},
+		{
+			name:        "zero columns below the minimum",
+			tab:         &configpb.DashboardTab{MinimumNumberOfRuns: 3},
+			grid:        &statepb.Grid{},
+			alert:       noRuns,
+			status:      "waiting for 3 runs before computing a status, found 0",
+			overall:     summarypb.DashboardTabSummary_PENDING,
+			latestGreen: noGreens,
+		},
+		{
+			name: "minimum above the recent columns",
+			tab:  &configpb.DashboardTab{NumColumnsRecent: 2, MinimumNumberOfRuns: 5},
+			grid: &statepb.Grid{
+				Columns: cols(now, "4", "3", "2", "1"),
+				Rows: []*statepb.Row{
+					{Name: "failing", Results: []int32{fail, 2, pass, 2}, AlertInfo: &statepb.AlertInfo{FailCount: 2}},
+				},
+			},
+			status:      "waiting for 5 runs before computing a status, found 4",
+			overall:     summarypb.DashboardTabSummary_PENDING,
+			latestGreen: "2",
+		},
+		{
+			name: "broken columns count as runs",
+			tab:  &configpb.DashboardTab{MinimumNumberOfRuns: 2},
+			grid: &statepb.Grid{
+				Columns: broken(cols(now, "2", "1"), 0),
+				Rows: []*statepb.Row{
+					{Name: "a", Results: []int32{fail, 1, pass, 1}},
+				},
+			},
+			status:      "1 of 1 (100.0%) recent columns passed",
+			overall:     summarypb.DashboardTabSummary_PASS,
+			latestGreen: "1",
+		},
+		{
+			name: "stale below the minimum",
+			tab: &configpb.DashboardTab{
+				AlertOptions:        &configpb.DashboardTabAlertOptions{AlertStaleResultsHours: 24},
+				MinimumNumberOfRuns: 3,
+			},
+			grid: &statepb.Grid{
+				Columns: cols(old, "1"),
+				Rows: []*statepb.Row{
+					{Name: "a", Results: []int32{pass, 1}},
+				},
+			},
+			alert:       "latest column from",
+			status:      "1 of 1 (100.0%) recent columns passed",
+			overall:     summarypb.DashboardTabSummary_STALE,
+			latestGreen: "1",
+		},
+		{
+			name: "filter out every row",
+			tab:  &configpb.DashboardTab{BaseOptions: "include-filter-by-regex=missing"},
+			grid: &statepb.Grid{
+				Columns: cols(now, "1"),
+				Rows: []*statepb.Row{
+					{Name: "a", Results: []int32{pass, 1}},
+				},
+			},
+			status:      noMatchingRows,
+			overall:     summarypb.DashboardTabSummary_UNKNOWN,
+			latestGreen: noGreens,
+		},
+		{
+			name: "invalid base options",
+			tab:  &configpb.DashboardTab{BaseOptions: "include-filter-by-regex=("},
+			grid: &statepb.Grid{
+				Columns: cols(now, "1"),
+				Rows: []*statepb.Row{
+					{Name: "a", Results: []int32{pass, 1}},
+				},
+			},
+			alert:       "invalid base_options",
+			overall:     summarypb.DashboardTabSummary_UNKNOWN,
+			latestGreen: noGreens,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.tab == nil {
+				tc.tab = &configpb.DashboardTab{}
+			}
+			tc.tab.Name = "tab"
+			sum := summarizeTab(tc.grid, tc.tab, tc.group)
+			if sum.DashboardTabName != "tab" {
+				t.Errorf("summarizeTab() got name %q, want tab", sum.DashboardTabName)
+			}
+			if !strings.HasPrefix(sum.Alert, tc.alert) || tc.alert == "" && sum.Alert != "" {
+				t.Errorf("summarizeTab() got alert %q, want %q", sum.Alert, tc.alert)
+			}
+			if !strings.HasPrefix(sum.Status, tc.status) || tc.status == "" && sum.Status != "" {
+				t.Errorf("summarizeTab() got status %q, want %q", sum.Status, tc.status)
+			}
+			if sum.OverallStatus != tc.overall {
+				t.Errorf("summarizeTab() got overall status %s, want %s", sum.OverallStatus, tc.overall)
+			}
+			if sum.LatestGreen != tc.latestGreen {
+				t.Errorf("summarizeTab() got latest green %q, want %q", sum.LatestGreen, tc.latestGreen)
+			}
+			if n := len(sum.FailingTestSummaries); n != tc.failing {
+				t.Errorf("summarizeTab() got %d failing tests, want %d", n, tc.failing)
+			}
+		})
+	}
+}
+
+func TestSpanRecent(t *testing.T) {
+	pass := int32(statepb.Row_PASS)
+	running := int32(statepb.Row_RUNNING)
+	grid := func(overall ...int32) *statepb.Grid {
+		var g statepb.Grid
+		var n int32
+		for i := 1; i < len(overall); i += 2 {
+			n += overall[i]
+		}
+		for i := int32(0); i < n; i++ {
+			g.Columns = append(g.Columns, &statepb.Column{})
+		}
+		g.Rows = append(g.Rows, &statepb.Row{Name: "renamed", Id: "Overall", Results: overall})
+		return &g
+	}
+	cases := []struct {
+		name   string
+		grid   *statepb.Grid
+		recent int
+		want   int
+	}{
+		{
+			name:   "empty",
+			grid:   &statepb.Grid{},
+			recent: 5,
+			want:   5,
+		},
+		{
+			name:   "no pending columns",
+			grid:   grid(pass, 10),
+			recent: 5,
+			want:   5,
+		},
+		{
+			name:   "leading pending columns",
+			grid:   grid(running, 2, pass, 10),
+			recent: 5,
+			want:   7,
+		},
+		{
+			name:   "interleaved pending columns",
+			grid:   grid(pass, 1, running, 1, pass, 1, running, 1, pass, 10),
+			recent: 3,
+			want:   5,
+		},
+		{
+			name:   "older pending columns",
+			grid:   grid(pass, 3, running, 1, pass, 1),
+			recent: 3,
+			want:   3,
+		},
+		{
+			name:   "fewer columns than recent",
+			grid:   grid(running, 1, pass, 1),
+			recent: 5,
+			want:   6,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := spanRecent(tc.grid, tc.recent); got != tc.want {
+				t.Errorf("spanRecent() got %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDropBroken(t *testing.T) {
+	pass := int32(statepb.Row_PASS)
+	fail := int32(statepb.Row_FAIL)
+	broken := &statepb.Stats{Broken: true}
+	cases := []struct {
+		name string
+		grid *statepb.Grid
+		want *statepb.Grid
+	}{
+		{
+			name: "basically works",
+			grid: &statepb.Grid{},
+			want: &statepb.Grid{},
+		},
+		{
+			name: "keep healthy columns",
+			grid: &statepb.Grid{
+				Columns: []*statepb.Column{{Build: "2", Stats: &statepb.Stats{FailCount: 1}}, {Build: "1"}},
+				Rows:    []*statepb.Row{{Name: "a", Results: []int32{fail, 1, pass, 1}}},
+			},
+			want: &statepb.Grid{
+				Columns: []*statepb.Column{{Build: "2", Stats: &statepb.Stats{FailCount: 1}}, {Build: "1"}},
+				Rows:    []*statepb.Row{{Name: "a", Results: []int32{fail, 1, pass, 1}}},
+			},
+		},
+		{
+			name: "drop broken columns",
+			grid: &statepb.Grid{
+				Columns: []*statepb.Column{{Build: "4"}, {Build: "3", Stats: broken}, {Build: "2"}, {Build: "1", Stats: broken}},
+				Rows: []*statepb.Row{
+					{Name: "a", Results: []int32{pass, 1, fail, 3}},
+					{Name: "b", Results: []int32{fail, 4}},
+					{Name: "short", Results: []int32{pass, 2}},
+				},
+			},
+			want: &statepb.Grid{
+				Columns: []*statepb.Column{{Build: "4"}, {Build: "2"}},
+				Rows: []*statepb.Row{
+					{Name: "a", Results: []int32{pass, 1, fail, 1}},
+					{Name: "b", Results: []int32{fail, 2}},
+					{Name: "short", Results: []int32{pass, 1}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := dropBroken(tc.grid); !proto.Equal(got, tc.want) {
+				t.Errorf("dropBroken() got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHealthOf(t *testing.T) {
+	pass := int32(statepb.Row_PASS)
+	fail := int32(statepb.Row_FAIL)
+	flaky := int32(statepb.Row_FLAKY)
+	running := int32(statepb.Row_RUNNING)
+	skip := int32(statepb.Row_PASS_WITH_SKIPS)
+	none := int32(statepb.Row_NO_RESULT)
+	cases := []struct {
+		name      string
+		rows      []*statepb.Row
+		recent    int
+		want      health
+		flakiness float64
+	}{
+		{
+			name:   "empty",
+			recent: 5,
+		},
+		{
+			name:   "basically works",
+			recent: 3,
+			rows: []*statepb.Row{
+				{Results: []int32{pass, 5}},
+				{Results: []int32{fail, 3, pass, 2}},
+				{Results: []int32{fail, 1, pass, 4}},
+				{Results: []int32{flaky, 1}},
+			},
+			want:      health{tests: 4, failing: 1, flaky: 2},
+			flakiness: 50,
+		},
+		{
+			name:   "only count recent columns",
+			recent: 2,
+			rows: []*statepb.Row{
+				{Results: []int32{pass, 2, fail, 1}},
+				{Results: []int32{none, 2, fail, 1}},
+			},
+			want: health{tests: 1},
+		},
+		{
+			name:   "ignore skips and running results",
+			recent: 5,
+			rows: []*statepb.Row{
+				{Results: []int32{skip, 5}},
+				{Results: []int32{running, 1, fail, 1}},
+			},
+			want: health{tests: 1, failing: 1},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := healthOf(tc.rows, tc.recent)
+			if got != tc.want {
+				t.Errorf("healthOf() got %+v, want %+v", got, tc.want)
+			}
+			if f := got.flakiness(); f != tc.flakiness {
+				t.Errorf("flakiness() got %f, want %f", f, tc.flakiness)
+			}
+		})
+	}
+}
+
 func TestReadGrid(t *testing.T) {
 	cases := []struct {
 		name         string
@@ -339,11 +1146,22 @@ func TestReadGrid(t *testing.T) {
 			expectErr: true,
 		},
 		{
-			name: "return error when state is not compressed",
+			name: "parse grid decompressed while downloading",
 			reader: bytes.NewBuffer(gridBuf(&statepb.Grid{
 				LastTimeUpdated: 444,
 			})),
-			expectErr: true,
+			expectedGrid: &statepb.Grid{
+				LastTimeUpdated: 444,
+			},
+		},
+		{
+			name: "parse gzipped grid",
+			reader: bytes.NewBuffer(gzipped(gridBuf(&statepb.Grid{
+				LastTimeUpdated: 666,
+			}))),
+			expectedGrid: &statepb.Grid{
+				LastTimeUpdated: 666,
+			},
 		},
 		{
 			name:      "return error when compressed object is not a grid proto",
@@ -445,6 +1263,40 @@ func TestRecentColumns(t *testing.T) {
 	}
 }
 
+func TestMinimumRuns(t *testing.T) {
+	cases := []struct {
+		name  string
+		tab   int32
+		group int32
+		want  int
+	}{
+		{
+			name:  "prefer tab over group",
+			tab:   2,
+			group: 10,
+			want:  2,
+		},
+		{
+			name:  "use group if tab is empty",
+			group: 10,
+			want:  10,
+		},
+		{
+			name: "none when both are empty",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tab := &configpb.DashboardTab{MinimumNumberOfRuns: tc.tab}
+			group := &configpb.TestGroup{MinimumNumberOfRuns: tc.group}
+			if got := minimumRuns(tab, group); got != tc.want {
+				t.Errorf("minimumRuns() got %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestFirstFilled(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -490,8 +1342,8 @@ func TestFilterGrid(t *testing.T) {
 		{
 			name: "everything works",
 			baseOptions: url.Values{
-				includeFilter: []string{"foo"},
-				excludeFilter: []string{"bar"},
+				filter.IncludeKey: []string{"foo"},
+				filter.ExcludeKey: []string{"bar"},
 			}.Encode(),
 			rows: []*statepb.Row{
 				{
@@ -521,7 +1373,7 @@ func TestFilterGrid(t *testing.T) {
 		{
 			name: "must match all includes",
 			baseOptions: url.Values{
-				includeFilter: []string{"foo", "spam"},
+				filter.IncludeKey: []string{"foo", "spam"},
 			}.Encode(),
 			rows: []*statepb.Row{
 				{
@@ -549,7 +1401,7 @@ func TestFilterGrid(t *testing.T) {
 		{
 			name: "exclude any exclusions",
 			baseOptions: url.Values{
-				excludeFilter: []string{"not", "nope"},
+				filter.ExcludeKey: []string{"not", "nope"},
 			}.Encode(),
 			rows: []*statepb.Row{
 				{
@@ -577,14 +1429,14 @@ func TestFilterGrid(t *testing.T) {
 		{
 			name: "bad inclusion regexp errors",
 			baseOptions: url.Values{
-				includeFilter: []string{"this.("},
+				filter.IncludeKey: []string{"this.("},
 			}.Encode(),
 			err: true,
 		},
 		{
 			name: "bad exclude regexp errors",
 			baseOptions: url.Values{
-				excludeFilter: []string{"this.("},
+				filter.ExcludeKey: []string{"this.("},
 			}.Encode(),
 			err: true,
 		},
@@ -704,114 +1556,24 @@ func TestRecentRows(t *testing.T) {
 	}
 }
 
-func TestIncludeRows(t *testing.T) {
-	cases := []struct {
-		name     string
-		names    []string
-		include  string
-		expected []string
-		err      bool
-	}{
-		{
-			name: "basically works",
-		},
-		{
-			name:    "bad regex errors",
-			include: "^[a-z",
-			err:     true,
-		},
-		{
-			name:    "return nothing rows when nothing matches",
-			names:   []string{"hello", "world"},
-			include: "dog",
-		},
-		{
-			name:     "include only matching rows",
-			include:  "fun",
-			names:    []string{"apply", "function", "to", "funny", "bone"},
-			expected: []string{"function", "funny"},
-		},
-	}
-
-	for _, tc := range cases {
-		t.Run(tc.name, func(t *testing.T) {
-			var rows []*statepb.Row
-			for _, n := range tc.names {
-				rows = append(rows, &statepb.Row{Name: n})
-			}
-			actualRows, err := includeRows(rows, tc.include)
-			var actual []string
-			for _, r := range actualRows {
-				actual = append(actual, r.Name)
-			}
-			switch {
-			case err != nil:
-				if !tc.err {
-					t.Errorf("unexpected error: %v", err)
-				}
-			case tc.err:
-				t.Error("failed to return expected error")
-			case !reflect.DeepEqual(actual, tc.expected):
-				t.Errorf("actual %s != expected %s", actual, tc.expected)
-			}
-		})
+func TestHideSkipped(t *testing.T) {
+	const recent = 3
+	skip := int32(statepb.Row_PASS_WITH_SKIPS)
+	rows := []*statepb.Row{
+		{Name: "skips", Results: []int32{skip, 5}},
+		{Name: "skips then fails", Results: []int32{skip, recent, int32(statepb.Row_FAIL), 2}},
+		{Name: "fails then skips", Results: []int32{skip, recent - 1, int32(statepb.Row_FAIL), 1, skip, 1}},
+		{Name: "empty and skips", Results: []int32{int32(statepb.Row_NO_RESULT), 1, skip, 4}},
+		{Name: "running", Results: []int32{int32(statepb.Row_RUNNING), 1, skip, 4}},
+		{Name: "passes", Results: []int32{int32(statepb.Row_PASS), 5}},
 	}
-}
-
-func TestExcludeRows(t *testing.T) {
-	cases := []struct {
-		name     string
-		names    []string
-		exclude  string
-		expected []string
-		err      bool
-	}{
-		{
-			name: "basically works",
-		},
-		{
-			name:    "bad regex errors",
-			exclude: "^[a-z",
-			err:     true,
-		},
-		{
-			name:     "return all rows when nothing matches",
-			names:    []string{"hello", "world"},
-			exclude:  "dog",
-			expected: []string{"hello", "world"},
-		},
-		{
-			name:     "drop matching rows",
-			exclude:  "fun",
-			names:    []string{"apply", "function", "to", "funny", "bone"},
-			expected: []string{"apply", "to", "bone"},
-		},
+	var got []string
+	for _, r := range hideSkipped(rows, recent) {
+		got = append(got, r.Name)
 	}
-
-	for _, tc := range cases {
-		t.Run(tc.name, func(t *testing.T) {
-			var rows []*statepb.Row
-			for _, n := range tc.names {
-				rows = append(rows, &statepb.Row{Name: n})
-			}
-			actualRows, err := excludeRows(rows, tc.exclude)
-			var actual []string
-			for _, r := range actualRows {
-				actual = append(actual, r.Name)
-			}
-			switch {
-			case err != nil:
-				if !tc.err {
-					t.Errorf("unexpected error: %v", err)
-				}
-			case tc.err:
-				t.Error("failed to return expected error")
-			case !reflect.DeepEqual(actual, tc.expected):
-				t.Errorf("actual %s != expected %s", actual, tc.expected)
-			}
-		})
+	if want := []string{"fails then skips", "running", "passes"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("hideSkipped() got %v, want %v", got, want)
 	}
-
 }
 
 func TestLatestRun(t *testing.T) {
@@ -903,9 +1665,11 @@ func TestStaleAlert(t *testing.T) {
 
 func TestFailingTestSummaries(t *testing.T) {
 	cases := []struct {
-		name     string
-		rows     []*statepb.Row
-		expected []*summarypb.FailingTestSummary
+		name        string
+		cols        []*statepb.Column
+		rows        []*statepb.Row
+		minFailures int32
+		expected    []*summarypb.FailingTestSummary
 	}{
 		{
 			name: "do not alert by default",
@@ -972,17 +1736,156 @@ func TestFailingTestSummaries(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "skip alerts with fewer than the minimum failures",
+			rows: []*statepb.Row{
+				{
+					Name:      "flaky",
+					AlertInfo: &statepb.AlertInfo{FailCount: 2},
+				},
+				{
+					Name:      "broken",
+					AlertInfo: &statepb.AlertInfo{FailCount: 3},
+				},
+			},
+			minFailures: 3,
+			expected: []*summarypb.FailingTestSummary{
+				{
+					DisplayName: "broken",
+					FailCount:   3,
+				},
+			},
+		},
+		{
+			name: "describe the latest failing column",
+			cols: []*statepb.Column{
+				{Build: "4", Started: 400},
+				{Build: "3", Started: 300},
+				{Build: "2", Started: 200},
+				{Build: "1", Started: 100},
+			},
+			rows: []*statepb.Row{
+				{
+					Name: "failing",
+					Results: []int32{
+						int32(statepb.Row_RUNNING), 1,
+						int32(statepb.Row_NO_RESULT), 1,
+						int32(statepb.Row_FAIL), 2,
+					},
+					AlertInfo: &statepb.AlertInfo{FailBuildId: "1", FailCount: 2},
+				},
+				{
+					Name: "passing since",
+					Results: []int32{
+						int32(statepb.Row_PASS), 3,
+						int32(statepb.Row_FAIL), 1,
+					},
+					AlertInfo: &statepb.AlertInfo{FailBuildId: "1", FailCount: 1},
+				},
+				{
+					Name: "beyond the columns",
+					Results: []int32{
+						int32(statepb.Row_PASS), 4,
+						int32(statepb.Row_FAIL), 1,
+					},
+					AlertInfo: &statepb.AlertInfo{FailCount: 1},
+				},
+			},
+			expected: []*summarypb.FailingTestSummary{
+				{
+					DisplayName:         "failing",
+					FailBuildId:         "1",
+					FailCount:           2,
+					LatestFailBuildId:   "2",
+					LatestFailTimestamp: 200,
+					LatestFailLink:      "link to 2",
+				},
+				{
+					DisplayName:         "passing since",
+					FailBuildId:         "1",
+					FailCount:           1,
+					LatestFailBuildId:   "1",
+					LatestFailTimestamp: 100,
+					LatestFailLink:      "link to 1",
+				},
+				{
+					DisplayName: "beyond the columns",
+					FailCount:   1,
+				},
+			},
+		},
 	}
 
+	link := func(build string) string { return "link to " + build }
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			if actual := failingTestSummaries(tc.rows); !reflect.DeepEqual(actual, tc.expected) {
+			if actual := failingTestSummaries(tc.cols, tc.rows, tc.minFailures, link); !reflect.DeepEqual(actual, tc.expected) {
 				t.Errorf("%v != expected %v", actual, tc.expected)
 			}
 		})
 	}
 }
 
+func TestArtifactLinker(t *testing.T) {
+	cases := []struct {
+		name     string
+		tab      *configpb.DashboardTab
+		group    *configpb.TestGroup
+		expected string
+	}{
+		{
+			name:     "browse the gcs_prefix",
+			tab:      &configpb.DashboardTab{Name: "tab"},
+			group:    &configpb.TestGroup{Query: "bucket/logs/job/"},
+			expected: "https://console.cloud.google.com/storage/browser/bucket/logs/job/123/",
+		},
+		{
+			name:     "trim gs://",
+			tab:      &configpb.DashboardTab{Name: "tab"},
+			group:    &configpb.TestGroup{Query: "gs://bucket/logs/job"},
+			expected: "https://console.cloud.google.com/storage/browser/bucket/logs/job/123/",
+		},
+		{
+			name: "expand results_url_template",
+			tab: &configpb.DashboardTab{
+				Name:               "tab",
+				ResultsUrlTemplate: &configpb.LinkTemplate{Url: "https://prow.example.com/view/gs/<gcs_prefix>/<build-id>"},
+			},
+			group:    &configpb.TestGroup{Query: "bucket/logs/job"},
+			expected: "https://prow.example.com/view/gs/bucket/logs/job/123",
+		},
+		{
+			name: "omit invalid results_url_template",
+			tab: &configpb.DashboardTab{
+				Name:               "tab",
+				ResultsUrlTemplate: &configpb.LinkTemplate{Url: "https://prow.example.com/<unknown>"},
+			},
+			group: &configpb.TestGroup{Query: "bucket/logs/job"},
+		},
+		{
+			name: "omit local prefixes",
+			tab: &configpb.DashboardTab{
+				Name:               "tab",
+				ResultsUrlTemplate: &configpb.LinkTemplate{Url: "https://prow.example.com/view/gs/<gcs_prefix>/<build-id>"},
+			},
+			group: &configpb.TestGroup{Query: "file:///var/logs/job"},
+		},
+		{
+			name:  "omit missing prefixes",
+			tab:   &configpb.DashboardTab{Name: "tab"},
+			group: &configpb.TestGroup{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := artifactLinker(tc.tab, tc.group)("123"); actual != tc.expected {
+				t.Errorf("got link %q, want %q", actual, tc.expected)
+			}
+		})
+	}
+}
+
 func TestOverallStatus(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -1100,12 +2003,70 @@ func TestOverallStatus(t *testing.T) {
 	}
 }
 
+func TestFailuresByAnnotation(t *testing.T) {
+	pass := int32(statepb.Row_PASS)
+	fail := int32(statepb.Row_FAIL)
+	annotated := func(cluster string) *statepb.Column {
+		if cluster == "" {
+			return &statepb.Column{}
+		}
+		return &statepb.Column{Metadata: map[string]string{"cluster": cluster}}
+	}
+	cols := []*statepb.Column{annotated("b"), annotated("a"), annotated(""), annotated("a"), annotated("b"), annotated("c")}
+	cases := []struct {
+		name     string
+		rows     []*statepb.Row
+		recent   int
+		key      string
+		expected string
+	}{
+		{
+			name:   "no failures",
+			rows:   []*statepb.Row{{Results: []int32{pass, 6}}},
+			recent: 6,
+			key:    "cluster",
+		},
+		{
+			name: "most failures first",
+			rows: []*statepb.Row{
+				{Results: []int32{fail, 1, pass, 5}},
+				{Results: []int32{pass, 1, fail, 4, pass, 1}},
+			},
+			recent:   6,
+			key:      "cluster",
+			expected: "failing runs by cluster: a (2), b (2)",
+		},
+		{
+			name:     "only recent columns",
+			rows:     []*statepb.Row{{Results: []int32{pass, 3, fail, 3}}},
+			recent:   4,
+			key:      "cluster",
+			expected: "failing runs by cluster: a (1)",
+		},
+		{
+			name:   "other annotation",
+			rows:   []*statepb.Row{{Results: []int32{fail, 6}}},
+			recent: 6,
+			key:    "zone",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := failuresByAnnotation(cols, tc.rows, tc.recent, tc.key); actual != tc.expected {
+				t.Errorf("got %q, want %q", actual, tc.expected)
+			}
+		})
+	}
+}
+
 func TestStatusMessage(t *testing.T) {
 	cases := []struct {
 		name             string
 		cols             int
 		rows             []*statepb.Row
 		recent           int
+		excludeSkips     bool
 		passingCols      int
 		filledCols       int
 		passingCells     int
@@ -1282,6 +2243,58 @@ func TestStatusMessage(t *testing.T) {
 			passingCells: 2,
 			filledCells:  2,
 		},
+		{
+			name:   "skips count as passes",
+			cols:   2,
+			recent: 2,
+			rows: []*statepb.Row{
+				{
+					Name:    "skip then fail",
+					Results: []int32{int32(statepb.Row_PASS_WITH_SKIPS), 1, int32(statepb.Row_FAIL), 1},
+				},
+				{
+					Name:    "two passes",
+					Results: []int32{int32(statepb.Row_PASS), 2},
+				},
+			},
+			passingCols:  1,
+			filledCols:   2,
+			passingCells: 3,
+			filledCells:  4,
+		},
+		{
+			name:   "exclude skips",
+			cols:   2,
+			recent: 2,
+			rows: []*statepb.Row{
+				{
+					Name:    "skip then fail",
+					Results: []int32{int32(statepb.Row_PASS_WITH_SKIPS), 1, int32(statepb.Row_FAIL), 1},
+				},
+				{
+					Name:    "two passes",
+					Results: []int32{int32(statepb.Row_PASS), 2},
+				},
+			},
+			excludeSkips: true,
+			passingCols:  1,
+			filledCols:   2,
+			passingCells: 2,
+			filledCells:  3,
+		},
+		{
+			name:   "exclude only skips",
+			cols:   2,
+			recent: 2,
+			rows: []*statepb.Row{
+				{
+					Name:    "skips",
+					Results: []int32{int32(statepb.Row_PASS_WITH_SKIPS), 2},
+				},
+			},
+			excludeSkips:     true,
+			expectedOverride: noRuns,
+		},
 	}
 
 	for _, tc := range cases {
@@ -1290,7 +2303,7 @@ func TestStatusMessage(t *testing.T) {
 			if expected == "" {
 				expected = fmtStatus(tc.passingCols, tc.filledCols, tc.passingCells, tc.filledCells)
 			}
-			if actual := statusMessage(tc.cols, tc.rows, tc.recent); actual != expected {
+			if actual := statusMessage(tc.cols, tc.rows, tc.recent, tc.excludeSkips); actual != expected {
 				t.Errorf("%s != expected %s", actual, expected)
 			}
 		})
@@ -1526,6 +2539,11 @@ func TestCoalesceResult(t *testing.T) {
 			result:   statepb.Row_FAIL,
 			expected: statepb.Row_FAIL,
 		},
+		{
+			name:     "tool fail is fail",
+			result:   statepb.Row_TOOL_FAIL,
+			expected: statepb.Row_FAIL,
+		},
 		{
 			name:     "flaky is flaky",
 			result:   statepb.Row_FLAKY,
@@ -1613,3 +2631,91 @@ func TestResultIter(t *testing.T) {
 		})
 	}
 }
+
+func TestSummaryPath(t *testing.T) {
+	cases := map[string]string{
+		"simple":             "summary-simple",
+		"Mixed Case & -_.AB": "summary-mixedcaseab",
+		"ünicode-1":          "summary-nicode1",
+	}
+	for name, want := range cases {
+		if got := summaryPath(name); got != want {
+			t.Errorf("summaryPath(%q) got %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestWriteSummary(t *testing.T) {
+	ctx := context.Background()
+	var client fake.Client
+	configPath, err := gcs.NewPath("gs://bucket/path/config")
+	if err != nil {
+		t.Fatalf("NewPath() got unexpected error: %v", err)
+	}
+	tabs := []*summarypb.DashboardTabSummary{
+		{
+			DashboardName:    "My Dashboard",
+			DashboardTabName: "first",
+			OverallStatus:    summarypb.DashboardTabSummary_PASS,
+		},
+		{
+			DashboardName:    "My Dashboard",
+			DashboardTabName: "second",
+			OverallStatus:    summarypb.DashboardTabSummary_FAIL,
+			FailingTestSummaries: []*summarypb.FailingTestSummary{
+				{DisplayName: "broken", FailCount: 3},
+			},
+		},
+	}
+
+	cases := []struct {
+		compression gcs.Compression
+		compressed  func([]byte) bool
+	}{
+		{
+			compression: gcs.Gzip,
+			compressed:  gcs.IsGzip,
+		},
+		{
+			compression: gcs.Zstd,
+			compressed:  gcs.IsZstd,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(string(tc.compression), func(t *testing.T) {
+			if err := WriteSummary(ctx, &client, *configPath, "My Dashboard", tabs, tc.compression); err != nil {
+				t.Fatalf("WriteSummary() got unexpected error: %v", err)
+			}
+			path, err := gcs.NewPath("gs://bucket/path/summary-mydashboard")
+			if err != nil {
+				t.Fatalf("NewPath() got unexpected error: %v", err)
+			}
+			obj, ok := client.Lookup(*path)
+			if !ok {
+				t.Fatalf("WriteSummary() did not write %s", path)
+			}
+			if obj.Attrs.CacheControl != "no-cache" {
+				t.Errorf("WriteSummary() wrote Cache-Control %q, want no-cache", obj.Attrs.CacheControl)
+			}
+			if enc := obj.Attrs.ContentEncoding; enc != string(tc.compression) {
+				t.Errorf("WriteSummary() wrote Content-Encoding %q, want %s", enc, tc.compression)
+			}
+			if !tc.compressed(obj.Data) {
+				t.Errorf("WriteSummary() did not %s the summary", tc.compression)
+			}
+
+			// Lookups normalize the name like writes.
+			got, err := ReadSummary(ctx, &client, *configPath, "my-dashboard")
+			if err != nil {
+				t.Fatalf("ReadSummary() got unexpected error: %v", err)
+			}
+			if want := (&summarypb.DashboardSummary{TabSummaries: tabs}); !proto.Equal(got, want) {
+				t.Errorf("ReadSummary() got %v, want %v", got, want)
+			}
+		})
+	}
+
+	if _, err := ReadSummary(ctx, &client, *configPath, "missing"); !errors.Is(err, storage.ErrObjectNotExist) {
+		t.Errorf("ReadSummary() of a missing summary got %v, want %v", err, storage.ErrObjectNotExist)
+	}
+}
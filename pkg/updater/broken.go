@@ -0,0 +1,86 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+// DefaultBrokenThreshold is the fraction of failing cells above which a column is broken, unless the group sets one.
+const DefaultBrokenThreshold = 0.9
+
+// minBrokenCells is the fewest test cells of a broken column.
+//
+// Failures in smaller columns are too few to tell a broken infrastructure from failing tests.
+const minBrokenCells = 5
+
+// brokenThreshold returns the broken column threshold of the group, or DefaultBrokenThreshold.
+func brokenThreshold(tg configpb.TestGroup) float32 {
+	if tg.BrokenColumnThreshold > 0 {
+		return tg.BrokenColumnThreshold
+	}
+	return DefaultBrokenThreshold
+}
+
+// columnStats counts the results of each column and marks the ones failing more than threshold of their cells as broken.
+//
+// Cells that are skipped, running or empty do not count, nor does the Overall row
+// (whose ID is always Overall), which fails whenever a test does. Columns with fewer
+// than minBrokenCells counted cells are never broken.
+func columnStats(grid *state.Grid, threshold float32) {
+	stats := make([]*state.Stats, len(grid.Columns))
+	for i := range stats {
+		stats[i] = &state.Stats{}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for _, r := range grid.Rows {
+		if r.Id == "Overall" {
+			continue
+		}
+		ch := result.Iter(ctx, r.Results)
+		for i := range stats {
+			res := <-ch
+			if res == state.Row_PASS_WITH_SKIPS {
+				continue
+			}
+			switch result.Coalesce(res, result.IgnoreRunning) {
+			case state.Row_NO_RESULT:
+				continue
+			case state.Row_PASS:
+				stats[i].PassCount++
+			case state.Row_FAIL:
+				stats[i].FailCount++
+			}
+			stats[i].TotalCount++
+		}
+	}
+	for i, col := range grid.Columns {
+		s := stats[i]
+		s.Broken = s.TotalCount >= minBrokenCells && float32(s.FailCount) > threshold*float32(s.TotalCount)
+		col.Stats = s
+	}
+}
+
+// isBroken returns true when the column is broken, see columnStats.
+func isBroken(col *state.Column) bool {
+	return col.GetStats().GetBroken()
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+func TestBrokenThreshold(t *testing.T) {
+	if got := brokenThreshold(configpb.TestGroup{}); got != DefaultBrokenThreshold {
+		t.Errorf("brokenThreshold() got %f, want default %f", got, DefaultBrokenThreshold)
+	}
+	if got := brokenThreshold(configpb.TestGroup{BrokenColumnThreshold: 0.5}); got != 0.5 {
+		t.Errorf("brokenThreshold() got %f, want 0.5", got)
+	}
+}
+
+func TestColumnStats(t *testing.T) {
+	pass := int32(state.Row_PASS)
+	fail := int32(state.Row_FAIL)
+	skip := int32(state.Row_PASS_WITH_SKIPS)
+	running := int32(state.Row_RUNNING)
+	empty := int32(state.Row_NO_RESULT)
+	rows := func(n int, results ...int32) []*state.Row {
+		var out []*state.Row
+		for i := 0; i < n; i++ {
+			out = append(out, &state.Row{Results: results})
+		}
+		return out
+	}
+	cases := []struct {
+		name      string
+		rows      []*state.Row
+		threshold float32
+		want      []*state.Stats
+	}{
+		{
+			name:      "basically works",
+			threshold: 0.9,
+		},
+		{
+			name: "count results",
+			rows: []*state.Row{
+				{Results: []int32{pass, 1, fail, 1, empty, 1}},
+				{Results: []int32{fail, 2, running, 1}},
+				{Results: []int32{pass, 3}},
+			},
+			threshold: 0.9,
+			want: []*state.Stats{
+				{PassCount: 2, FailCount: 1, TotalCount: 3},
+				{PassCount: 1, FailCount: 2, TotalCount: 3},
+				{PassCount: 1, TotalCount: 1},
+			},
+		},
+		{
+			name:      "mostly failing columns are broken",
+			rows:      append(rows(4, fail, 2), rows(2, fail, 1, pass, 1)...),
+			threshold: 0.5,
+			want: []*state.Stats{
+				{FailCount: 6, TotalCount: 6, Broken: true},
+				{FailCount: 4, PassCount: 2, TotalCount: 6, Broken: true},
+			},
+		},
+		{
+			name:      "threshold must be exceeded",
+			rows:      append(rows(3, fail, 1), rows(3, pass, 1)...),
+			threshold: 0.5,
+			want: []*state.Stats{
+				{FailCount: 3, PassCount: 3, TotalCount: 6},
+			},
+		},
+		{
+			name:      "threshold of 1 never breaks",
+			rows:      rows(10, fail, 1),
+			threshold: 1,
+			want: []*state.Stats{
+				{FailCount: 10, TotalCount: 10},
+			},
+		},
+		{
+			name:      "small columns are never broken",
+			rows:      rows(minBrokenCells-1, fail, 1),
+			threshold: 0.1,
+			want: []*state.Stats{
+				{FailCount: minBrokenCells - 1, TotalCount: minBrokenCells - 1},
+			},
+		},
+		{
+			name:      "overall row does not count",
+			rows:      append(rows(5, pass, 1), &state.Row{Name: "Overall", Id: "Overall", Results: []int32{fail, 1}}),
+			threshold: 0.1,
+			want: []*state.Stats{
+				{PassCount: 5, TotalCount: 5},
+			},
+		},
+		{
+			name:      "skipped columns are not broken",
+			rows:      rows(5, skip, 1, fail, 1),
+			threshold: 0.1,
+			want: []*state.Stats{
+				{},
+				{FailCount: 5, TotalCount: 5, Broken: true},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			grid := state.Grid{Rows: tc.rows}
+			for range tc.want {
+				grid.Columns = append(grid.Columns, &state.Column{})
+			}
+			columnStats(&grid, tc.threshold)
+			for i, col := range grid.Columns {
+				if !proto.Equal(col.Stats, tc.want[i]) {
+					t.Errorf("columnStats() column %d got %v, want %v", i, col.Stats, tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAlertRowBrokenColumns(t *testing.T) {
+	var columns []*state.Column
+	for i, id := range []string{"a", "b", "c", "d"} {
+		columns = append(columns, &state.Column{
+			Build:   id,
+			Started: 100 - float64(i),
+		})
+	}
+	columns[0].Stats = &state.Stats{Broken: true}
+	columns[1].Stats = &state.Stats{Broken: true}
+	row := state.Row{
+		Results: []int32{
+			int32(state.Row_FAIL), 3,
+			int32(state.Row_PASS), 1,
+		},
+		Messages: []string{"infra", "infra again", "real", "fine"},
+		CellIds:  []string{"a", "b", "c", "d"},
+	}
+
+	if got := alertRow(columns, &row, 2, 1); got != nil {
+		t.Errorf("alertRow() got %v, broken columns must not count as failures", got)
+	}
+	want := alertInfo(1, "real", "c", columns[2], columns[3])
+	if got := alertRow(columns, &row, 1, 1); !proto.Equal(got, want) {
+		t.Errorf("alertRow() got %v, want %v", got, want)
+	}
+}
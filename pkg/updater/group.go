@@ -0,0 +1,159 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"time"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+// columnGrouping returns a function that returns the value shared by columns in the same group, or nil if columns are not grouped.
+//
+// The primary grouping takes precedence over the fallback grouping.
+// Columns with an empty value are not grouped with any other column.
+func columnGrouping(group configpb.TestGroup) func(Column) string {
+	if group.PrimaryGrouping == configpb.TestGroup_PRIMARY_GROUPING_COMMIT_NUM {
+		return func(c Column) string { return c.Version }
+	}
+	switch group.FallbackGrouping {
+	case configpb.TestGroup_FALLBACK_GROUPING_COMMIT_NUM:
+		return func(c Column) string { return c.Version }
+	case configpb.TestGroup_FALLBACK_GROUPING_DATE:
+		return func(c Column) string { return time.Unix(c.Started, 0).UTC().Format("2006-01-02") }
+	case configpb.TestGroup_FALLBACK_GROUPING_CONFIGURATION_VALUE:
+		key := group.FallbackGroupingConfigurationValue
+		if key == "" {
+			return nil
+		}
+		return func(c Column) string { return c.Metadata[key] }
+	}
+	// TODO(fejta): support FALLBACK_GROUPING_LABELS
+	return nil
+}
+
+// groupColumns merges the columns that share the same value into one column, whose ID is that value.
+//
+// Columns remain in order, with each group at the position of its first column.
+// Nil columns are skipped, and columns without a value stay as they are.
+// Since only the columns that were read are grouped, a group spanning the
+// oldest column read is missing any earlier builds in that group.
+func groupColumns(cols []*Column, value func(Column) string) []*Column {
+	var out []*Column
+	groups := map[string][]*Column{}
+	var order []string
+	positions := map[string]int{}
+	for _, c := range cols {
+		if c == nil {
+			continue
+		}
+		v := value(*c)
+		if v == "" {
+			out = append(out, c)
+			continue
+		}
+		if _, ok := groups[v]; !ok {
+			positions[v] = len(out)
+			order = append(order, v)
+			out = append(out, nil) // placeholder for the merged column
+		}
+		groups[v] = append(groups[v], c)
+	}
+	for _, v := range order {
+		out[positions[v]] = mergeColumns(v, groups[v])
+	}
+	return out
+}
+
+// mergeColumns combines the columns in a group into a single column with the specified ID.
+//
+// The merged column started and finished with its most recent column, and only
+// finishes once every column finishes. It passes when every column passes.
+// Results for the same target combine with the worst result winning; when results
+// tie, the one from the earlier column in the list wins.
+func mergeColumns(id string, cols []*Column) *Column {
+	first := cols[0]
+	out := Column{
+		ID:       id,
+		Started:  first.Started,
+		Finished: first.Finished,
+		Passed:   first.Passed,
+		Rows:     map[string][]Row{},
+		Metadata: ColumnMetadata{},
+		Version:  first.Version,
+	}
+	for k, v := range first.Metadata {
+		out.Metadata[k] = v
+	}
+	var running bool
+	for _, c := range cols {
+		if c.Started > out.Started {
+			out.Started = c.Started
+		}
+		if c.Finished == 0 {
+			running = true
+		} else if c.Finished > out.Finished {
+			out.Finished = c.Finished
+		}
+		out.Passed = out.Passed && c.Passed
+		for target, rows := range c.Rows {
+			out.Rows[target] = worstRows(out.Rows[target], rows)
+		}
+	}
+	if running {
+		out.Finished = 0
+	}
+	return &out
+}
+
+// worstRows returns the worst of each pair of results, followed by any extra results in either slice.
+func worstRows(current, other []Row) []Row {
+	out := make([]Row, 0, len(current))
+	for i := 0; i < len(current) || i < len(other); i++ {
+		switch {
+		case i >= len(current):
+			out = append(out, other[i])
+		case i >= len(other), severity(current[i].Result) >= severity(other[i].Result):
+			out = append(out, current[i])
+		default:
+			out = append(out, other[i])
+		}
+	}
+	return out
+}
+
+// severity ranks results from NO_RESULT (least severe) to FAIL (most severe).
+func severity(res state.Row_Result) int {
+	switch res {
+	case state.Row_NO_RESULT:
+		return 0
+	case state.Row_PASS:
+		return 1
+	case state.Row_PASS_WITH_SKIPS:
+		return 2
+	case state.Row_PASS_WITH_ERRORS:
+		return 3
+	case state.Row_RUNNING:
+		return 4
+	case state.Row_FLAKY:
+		return 5
+	case state.Row_FAIL, state.Row_TOOL_FAIL:
+		return 6
+	}
+	return 1
+}
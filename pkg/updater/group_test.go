@@ -0,0 +1,236 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+func TestColumnGrouping(t *testing.T) {
+	col := Column{
+		ID:       "10",
+		Started:  time.Date(2020, 5, 4, 23, 0, 0, 0, time.UTC).Unix(),
+		Version:  "v1.2.3",
+		Metadata: ColumnMetadata{"infra-commit": "deadbeef"},
+	}
+	cases := []struct {
+		name  string
+		group configpb.TestGroup
+		want  string
+		none  bool
+	}{
+		{
+			name: "not grouped by default",
+			none: true,
+		},
+		{
+			name: "group by id is the default",
+			group: configpb.TestGroup{
+				FallbackGrouping: configpb.TestGroup_FALLBACK_GROUPING_ID,
+			},
+			none: true,
+		},
+		{
+			name: "primary commit",
+			group: configpb.TestGroup{
+				PrimaryGrouping:  configpb.TestGroup_PRIMARY_GROUPING_COMMIT_NUM,
+				FallbackGrouping: configpb.TestGroup_FALLBACK_GROUPING_DATE,
+			},
+			want: "v1.2.3",
+		},
+		{
+			name: "fallback commit",
+			group: configpb.TestGroup{
+				FallbackGrouping: configpb.TestGroup_FALLBACK_GROUPING_COMMIT_NUM,
+			},
+			want: "v1.2.3",
+		},
+		{
+			name: "date",
+			group: configpb.TestGroup{
+				FallbackGrouping: configpb.TestGroup_FALLBACK_GROUPING_DATE,
+			},
+			want: "2020-05-04",
+		},
+		{
+			name: "configuration value",
+			group: configpb.TestGroup{
+				FallbackGrouping:                   configpb.TestGroup_FALLBACK_GROUPING_CONFIGURATION_VALUE,
+				FallbackGroupingConfigurationValue: "infra-commit",
+			},
+			want: "deadbeef",
+		},
+		{
+			name: "configuration value without a key",
+			group: configpb.TestGroup{
+				FallbackGrouping: configpb.TestGroup_FALLBACK_GROUPING_CONFIGURATION_VALUE,
+			},
+			none: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			value := columnGrouping(tc.group)
+			if tc.none {
+				if value != nil {
+					t.Errorf("columnGrouping() got %q, want no grouping", value(col))
+				}
+				return
+			}
+			if value == nil {
+				t.Fatalf("columnGrouping() got no grouping, want %q", tc.want)
+			}
+			if got := value(col); got != tc.want {
+				t.Errorf("columnGrouping() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGroupColumns(t *testing.T) {
+	commit := func(c Column) string { return c.Metadata["commit"] }
+	pass := func(msg string) Row { return Row{Result: state.Row_PASS, Message: msg} }
+	fail := func(msg string) Row { return Row{Result: state.Row_FAIL, Message: msg} }
+	flaky := func(msg string) Row { return Row{Result: state.Row_FLAKY, Message: msg} }
+	cases := []struct {
+		name string
+		cols []*Column
+		want []*Column
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "missing commits stay separate",
+			cols: []*Column{
+				{ID: "3", Started: 300, Finished: 301},
+				nil,
+				{ID: "1", Started: 100, Finished: 101, Metadata: ColumnMetadata{"commit": ""}},
+			},
+			want: []*Column{
+				{ID: "3", Started: 300, Finished: 301},
+				{ID: "1", Started: 100, Finished: 101, Metadata: ColumnMetadata{"commit": ""}},
+			},
+		},
+		{
+			name: "worst result wins",
+			cols: []*Column{
+				{
+					ID:       "4",
+					Started:  400,
+					Finished: 401,
+					Passed:   true,
+					Metadata: ColumnMetadata{"commit": "abc"},
+					Rows: map[string][]Row{
+						"a": {pass("a4")},
+						"b": {fail("b4")},
+						"c": {pass("c4")},
+					},
+				},
+				{
+					ID:       "3",
+					Started:  300,
+					Finished: 305,
+					Passed:   false,
+					Metadata: ColumnMetadata{"commit": "abc", "extra": "old"},
+					Rows: map[string][]Row{
+						"a": {fail("a3"), pass("a3 [1]")},
+						"b": {flaky("b3")},
+						"d": {pass("d3")},
+					},
+				},
+			},
+			want: []*Column{
+				{
+					ID:       "abc",
+					Started:  400,
+					Finished: 401,
+					Passed:   false,
+					Metadata: ColumnMetadata{"commit": "abc"},
+					Rows: map[string][]Row{
+						"a": {fail("a3"), pass("a3 [1]")},
+						"b": {fail("b4")},
+						"c": {pass("c4")},
+						"d": {pass("d3")},
+					},
+				},
+			},
+		},
+		{
+			name: "ties keep the earlier column",
+			cols: []*Column{
+				{ID: "2", Started: 100, Finished: 101, Metadata: ColumnMetadata{"commit": "abc"}, Rows: map[string][]Row{"a": {fail("first")}}},
+				{ID: "1", Started: 100, Finished: 101, Metadata: ColumnMetadata{"commit": "abc"}, Rows: map[string][]Row{"a": {fail("second")}}},
+			},
+			want: []*Column{
+				{ID: "abc", Started: 100, Finished: 101, Metadata: ColumnMetadata{"commit": "abc"}, Rows: map[string][]Row{"a": {fail("first")}}},
+			},
+		},
+		{
+			name: "group is running until every column finishes",
+			cols: []*Column{
+				{ID: "2", Started: 200, Finished: 0, Metadata: ColumnMetadata{"commit": "abc"}},
+				{ID: "1", Started: 100, Finished: 101, Passed: true, Metadata: ColumnMetadata{"commit": "abc"}},
+			},
+			want: []*Column{
+				{ID: "abc", Started: 200, Finished: 0, Metadata: ColumnMetadata{"commit": "abc"}, Rows: map[string][]Row{}},
+			},
+		},
+		{
+			name: "groups take the position of their first column",
+			cols: []*Column{
+				{ID: "5", Started: 500, Finished: 501, Metadata: ColumnMetadata{"commit": "new"}},
+				{ID: "4", Started: 400, Finished: 401, Metadata: ColumnMetadata{"commit": "old"}},
+				{ID: "3", Started: 300, Finished: 301},
+				{ID: "2", Started: 200, Finished: 201, Metadata: ColumnMetadata{"commit": "new"}},
+				{ID: "1", Started: 100, Finished: 101, Metadata: ColumnMetadata{"commit": "old"}},
+			},
+			want: []*Column{
+				{ID: "new", Started: 500, Finished: 501, Metadata: ColumnMetadata{"commit": "new"}, Rows: map[string][]Row{}},
+				{ID: "old", Started: 400, Finished: 401, Metadata: ColumnMetadata{"commit": "old"}, Rows: map[string][]Row{}},
+				{ID: "3", Started: 300, Finished: 301},
+			},
+		},
+		{
+			// readBuilds keeps columns until the first that started before the stop time,
+			// so a group with a recent column is kept along with older columns that were read.
+			name: "groups spanning the retention boundary start with their newest column",
+			cols: []*Column{
+				{ID: "2", Started: 100, Finished: 101, Metadata: ColumnMetadata{"commit": "abc"}},
+				{ID: "3", Started: 300, Finished: 301, Metadata: ColumnMetadata{"commit": "abc"}},
+			},
+			want: []*Column{
+				{ID: "abc", Started: 300, Finished: 301, Metadata: ColumnMetadata{"commit": "abc"}, Rows: map[string][]Row{}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := groupColumns(tc.cols, commit)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("groupColumns() got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
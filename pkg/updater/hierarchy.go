@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+// ParentKey is the row metadata key holding the name of the row's parent,
+// which the frontend uses to collapse test methods under their class.
+const ParentKey = "parent"
+
+// parentName returns the grouping key of a row name, or "" when the row has no parent.
+//
+// The parent of pkg.Class.method is pkg.Class: everything before the last dot.
+// Dots inside brackets or parentheses, such as method[a.b] or method(1.5), are
+// part of the method, so pkg.Class.method[a.b] [1] also groups under pkg.Class.
+// Names whose parent would contain whitespace, such as sentences, have no parent.
+func parentName(name string) string {
+	var depth int
+	last := -1
+	for i, r := range name {
+		switch r {
+		case '[', '(':
+			depth++
+		case ']', ')':
+			if depth > 0 {
+				depth--
+			}
+		case '.':
+			if depth == 0 {
+				last = i
+			}
+		}
+	}
+	if last <= 0 || last == len(name)-1 {
+		return ""
+	}
+	parent := name[:last]
+	if strings.IndexFunc(parent, unicode.IsSpace) >= 0 {
+		return ""
+	}
+	return parent
+}
+
+// groupRows records the parent of each row in its metadata when grouping is enabled, and removes it otherwise.
+func groupRows(rows []*state.Row, enabled bool) {
+	for _, r := range rows {
+		var parent string
+		if enabled {
+			parent = parentName(r.Name)
+		}
+		if parent == "" {
+			delete(r.Metadata, ParentKey)
+			if len(r.Metadata) == 0 {
+				r.Metadata = nil
+			}
+			continue
+		}
+		if r.Metadata == nil {
+			r.Metadata = map[string]string{}
+		}
+		r.Metadata[ParentKey] = parent
+	}
+}
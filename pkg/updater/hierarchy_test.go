@@ -0,0 +1,186 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+	tgstate "github.com/GoogleCloudPlatform/testgrid/util/state"
+)
+
+func TestParentName(t *testing.T) {
+	cases := []struct {
+		name string
+		want string
+	}{
+		{
+			name: "",
+		},
+		{
+			name: "Overall",
+		},
+		{
+			name: "pkg.Class.method",
+			want: "pkg.Class",
+		},
+		{
+			name: "Class.method",
+			want: "Class",
+		},
+		{
+			name: "test[a.b]",
+		},
+		{
+			name: "pkg.Class.test[a.b]",
+			want: "pkg.Class",
+		},
+		{
+			name: "pkg.Class.test[a.b][c.d]",
+			want: "pkg.Class",
+		},
+		{
+			name: "pkg.Class.test[nested[a.b].c]",
+			want: "pkg.Class",
+		},
+		{
+			name: "pkg.Class.test(1.5, 2.5)",
+			want: "pkg.Class",
+		},
+		{
+			name: "pkg.Class.method [1]",
+			want: "pkg.Class",
+		},
+		{
+			name: "pkg.Class.method[a.b] [2]",
+			want: "pkg.Class",
+		},
+		{
+			name: "[sig-node] Pods should run. [Conformance]",
+		},
+		{
+			name: ".hidden",
+		},
+		{
+			name: "trailing.",
+		},
+		{
+			name: "unbalanced]].method",
+			want: "unbalanced]]",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := parentName(tc.name); got != tc.want {
+				t.Errorf("parentName(%q) got %q, want %q", tc.name, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGroupRows(t *testing.T) {
+	rows := func() []*state.Row {
+		return []*state.Row{
+			{Name: "pkg.Class.method"},
+			{Name: "Overall"},
+			{Name: "moved", Metadata: map[string]string{ParentKey: "old", "other": "value"}},
+			{Name: "stale.parent", Metadata: map[string]string{ParentKey: "stale"}},
+		}
+	}
+	metadata := func(rows []*state.Row) []map[string]string {
+		var out []map[string]string
+		for _, r := range rows {
+			out = append(out, r.Metadata)
+		}
+		return out
+	}
+
+	enabled := rows()
+	groupRows(enabled, true)
+	want := []map[string]string{
+		{ParentKey: "pkg.Class"},
+		nil,
+		{"other": "value"},
+		{ParentKey: "stale"},
+	}
+	if got := metadata(enabled); !reflect.DeepEqual(got, want) {
+		t.Errorf("groupRows(true) got %v, want %v", got, want)
+	}
+
+	disabled := rows()
+	groupRows(disabled, false)
+	want = []map[string]string{
+		nil,
+		nil,
+		{"other": "value"},
+		nil,
+	}
+	if got := metadata(disabled); !reflect.DeepEqual(got, want) {
+		t.Errorf("groupRows(false) got %v, want %v", got, want)
+	}
+}
+
+func TestUpdateGroupHierarchy(t *testing.T) {
+	ctx := context.Background()
+	var client fake.Client
+	now := time.Now().Unix()
+	build := "gs://bucket/logs/job/1/"
+	client.Put(mustPath(t, build+"started.json"), []byte(fmt.Sprintf(`{"timestamp": %d}`, now)))
+	client.Put(mustPath(t, build+"finished.json"), []byte(fmt.Sprintf(`{"timestamp": %d, "passed": true}`, now+1)))
+	client.Put(mustPath(t, build+"artifacts/junit_01.xml"), []byte(`<testsuite name="pkg">
+		<testcase name="Class.first"/>
+		<testcase name="Class.second[a.b]"/>
+		<testcase name="lonely"/>
+	</testsuite>`))
+
+	tg := configpb.TestGroup{
+		Name:              "job",
+		Query:             "bucket/logs/job",
+		DaysOfResults:     1,
+		EnableTestMethods: true,
+	}
+	gridPath := mustPath(t, "gs://bucket/job")
+	var report GroupReport
+	if err := updateGroup(ctx, &client, tg, gridPath, Options{BuildConcurrency: 1, GroupTimeout: time.Minute, BuildTimeout: time.Minute, Compression: gcs.Gzip}, nil, &report); err != nil {
+		t.Fatalf("updateGroup() got unexpected error: %v", err)
+	}
+	grid, _, err := tgstate.ReadGrid(ctx, &client, gridPath)
+	if err != nil {
+		t.Fatalf("ReadGrid() got unexpected error: %v", err)
+	}
+	got := map[string]string{}
+	for _, row := range grid.Rows {
+		got[row.Name] = row.Metadata[ParentKey]
+	}
+	want := map[string]string{
+		"Overall":               "",
+		"pkg.Class.first":       "pkg.Class",
+		"pkg.Class.second[a.b]": "pkg.Class",
+		"pkg.lonely":            "pkg",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("updateGroup() wrote parents %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,395 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/pb/state"
+	tgstate "github.com/GoogleCloudPlatform/testgrid/util/state"
+)
+
+// incrementalBase returns the stored grid to prepend newer columns to, or else nil and the reason to rebuild the grid.
+//
+// The stored grid is the result of reading it, which may have failed with readErr.
+// Columns through the oldest one that was still running are dropped, so that their builds are read again.
+func incrementalBase(stored *state.Grid, readErr error, tg configpb.TestGroup) (*state.Grid, string) {
+	var corrupt tgstate.ErrCorruptGrid
+	switch {
+	case errors.Is(readErr, storage.ErrObjectNotExist):
+		return nil, "no stored grid"
+	case errors.As(readErr, &corrupt):
+		return nil, fmt.Sprintf("stored grid is corrupt: %v", corrupt.Err)
+	case readErr != nil:
+		return nil, fmt.Sprintf("failed to read stored grid: %v", readErr)
+	case stored.Config == nil:
+		return nil, "stored grid does not record its config"
+	case columnGrouping(tg) != nil:
+		return nil, "columns are grouped, so newer builds may belong to existing columns"
+	}
+	if changed := groupingChanges(stored.Config, &tg); len(changed) > 0 {
+		return nil, "grouping settings changed: " + strings.Join(changed, ", ")
+	}
+	base := dropRunning(stored)
+	if len(base.Columns) == 0 {
+		return nil, "stored grid has no finished columns"
+	}
+	return base, ""
+}
+
+// groupingChanges returns the fields that changed from before to after which invalidate existing columns and rows.
+//
+// Fewer days of results or recent columns just trim the grid, but more of them require reading older builds.
+func groupingChanges(before, after *configpb.TestGroup) []string {
+	var changed []string
+	if before.Query != after.Query {
+		changed = append(changed, "gcs_prefix")
+	}
+	if !appendedHeaders(before.ColumnHeader, after.ColumnHeader) {
+		changed = append(changed, "column_header")
+	}
+	if !proto.Equal(before.TestNameConfig, after.TestNameConfig) {
+		changed = append(changed, "test_name_config")
+	}
+	if before.PrimaryGrouping != after.PrimaryGrouping {
+		changed = append(changed, "primary_grouping")
+	}
+	if before.FallbackGrouping != after.FallbackGrouping {
+		changed = append(changed, "fallback_grouping")
+	}
+	if before.FallbackGroupingConfigurationValue != after.FallbackGroupingConfigurationValue {
+		changed = append(changed, "fallback_grouping_configuration_value")
+	}
+	if before.ColumnOrder != after.ColumnOrder {
+		changed = append(changed, "column_order")
+	}
+	if after.DaysOfResults > before.DaysOfResults {
+		changed = append(changed, "days_of_results")
+	}
+	if after.NumColumnsRecent > before.NumColumnsRecent {
+		changed = append(changed, "num_columns_recent")
+	}
+	return changed
+}
+
+// appendedHeaders returns true when the after headers start with the before headers.
+//
+// Existing columns remain valid when headers are only appended, see padHeaders.
+func appendedHeaders(before, after []*configpb.TestGroup_ColumnHeader) bool {
+	if len(before) > len(after) {
+		return false
+	}
+	for i := range before {
+		if !proto.Equal(before[i], after[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// padHeaders appends an empty value to each column for each of the n headers it is missing.
+//
+// Columns stored before a header was appended to the config have no value for it.
+func padHeaders(grid *state.Grid, n int) {
+	for _, col := range grid.Columns {
+		for len(col.Extra) < n {
+			col.Extra = append(col.Extra, "")
+		}
+	}
+}
+
+// dropRunning returns the grid without the columns up to and including the oldest pending one.
+//
+// Reading those builds again replaces each pending column once its build finishes.
+func dropRunning(grid *state.Grid) *state.Grid {
+	var running int
+	for i, p := range pendingColumns(grid) {
+		if p {
+			running = i + 1
+		}
+	}
+	if running == 0 {
+		return grid
+	}
+	return sliceGrid(grid, running, len(grid.Columns))
+}
+
+// pendingColumns returns whether each column is pending, which is when its Overall row (whose ID is always Overall) is still running.
+func pendingColumns(grid *state.Grid) []bool {
+	pending := make([]bool, len(grid.Columns))
+	var overall *state.Row
+	for _, r := range grid.Rows {
+		if r.Id == "Overall" {
+			overall = r
+			break
+		}
+	}
+	if overall == nil {
+		return pending
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := result.Iter(ctx, overall.Results)
+	for i := range pending {
+		pending[i] = <-ch == state.Row_RUNNING
+	}
+	return pending
+}
+
+// newerBuilds returns the builds listed before the first one with any of the specified column IDs.
+//
+// Builds are sorted newest first, so these are the builds that are newer than the columns.
+// Returns false when no build has any of the IDs.
+func newerBuilds(builds Builds, ids ...string) (Builds, bool) {
+	known := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		known[id] = true
+	}
+	for i, b := range builds {
+		if known[b.ID()] {
+			return builds[:i], true
+		}
+	}
+	return nil, false
+}
+
+// mergeGrids returns a grid with the newer columns followed by the older ones.
+//
+// Rows match by name; a row missing from either grid has no result for those columns.
+// Alerts are not merged, so callers must recompute them.
+func mergeGrids(newer, older *state.Grid) *state.Grid {
+	grid := &state.Grid{
+		Columns: append(append([]*state.Column{}, newer.Columns...), older.Columns...),
+	}
+	olderRows := make(map[string]*state.Row, len(older.Rows))
+	for _, r := range older.Rows {
+		olderRows[r.Name] = r
+	}
+	merged := map[string]bool{}
+	for _, r := range newer.Rows {
+		o := olderRows[r.Name]
+		merged[r.Name] = true
+		grid.Rows = append(grid.Rows, concatRows(r, len(newer.Columns), o, len(older.Columns)))
+	}
+	for _, o := range older.Rows {
+		if merged[o.Name] {
+			continue
+		}
+		grid.Rows = append(grid.Rows, concatRows(nil, len(newer.Columns), o, len(older.Columns)))
+	}
+	return grid
+}
+
+// concatRows returns a row with the cells of a (or aCells of no result if nil) followed by those of b.
+func concatRows(a *state.Row, aCells int, b *state.Row, bCells int) *state.Row {
+	out := &state.Row{}
+	for _, r := range []*state.Row{a, b} {
+		if r == nil {
+			continue
+		}
+		out.Name, out.Id = r.Name, r.Id
+		if len(out.BugId) == 0 {
+			out.BugId = r.BugId
+		}
+		break
+	}
+	appendCells(out, a, aCells)
+	appendCells(out, b, bCells)
+	return out
+}
+
+// appendCells appends the cells of src to dst, or count cells of no result when src is nil.
+//
+// Metric indices count the messages in the row, matching appendColumn, so they shift by the messages already in dst.
+func appendCells(dst, src *state.Row, count int) {
+	if src == nil {
+		if count > 0 {
+			AppendResult(dst, noResult, count)
+		}
+		return
+	}
+	offset := int32(len(dst.Messages))
+	for i := 0; i+1 < len(src.Results); i += 2 {
+		dst.Results = tgstate.AppendResult(dst.Results, src.Results[i], src.Results[i+1])
+	}
+	dst.CellIds = append(dst.CellIds, src.CellIds...)
+	dst.Messages = append(dst.Messages, src.Messages...)
+	dst.Icons = append(dst.Icons, src.Icons...)
+	if len(src.Properties) > 0 || len(dst.Properties) > 0 {
+		for len(dst.Properties) < int(offset) {
+			dst.Properties = append(dst.Properties, &state.Property{})
+		}
+		dst.Properties = append(dst.Properties, src.Properties...)
+		for len(dst.Properties) < len(dst.Messages) {
+			dst.Properties = append(dst.Properties, &state.Property{})
+		}
+	}
+	for _, m := range src.Metrics {
+		dm := FindMetric(dst, m.Name)
+		if dm == nil {
+			dm = &state.Metric{Name: m.Name}
+			dst.Metrics = append(dst.Metrics, dm)
+		}
+		var v int
+		for i := 0; i+1 < len(m.Indices); i += 2 {
+			for j := int32(0); j < m.Indices[i+1] && v < len(m.Values); j++ {
+				AppendMetric(dm, m.Indices[i]+j+offset, m.Values[v])
+				v++
+			}
+		}
+	}
+}
+
+// sliceGrid returns a grid with the columns from start up to, but not including, end.
+//
+// Rows without any results in those columns are dropped.
+func sliceGrid(grid *state.Grid, start, end int) *state.Grid {
+	out := &state.Grid{
+		Columns:           grid.Columns[start:end],
+		LastAlertMailTime: grid.LastAlertMailTime,
+		Config:            grid.Config,
+	}
+	for _, r := range grid.Rows {
+		if sr := sliceRow(r, start, end); sr != nil {
+			out.Rows = append(out.Rows, sr)
+		}
+	}
+	return out
+}
+
+// hasProperties returns true when any of the cell properties is not empty.
+func hasProperties(props []*state.Property) bool {
+	for _, p := range props {
+		if len(p.Property) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// sliceRow returns a row with the cells from start up to, but not including, end, or nil if all of them have no result.
+func sliceRow(row *state.Row, start, end int) *state.Row {
+	out := &state.Row{
+		Name:      row.Name,
+		Id:        row.Id,
+		BugId:     row.BugId,
+		AlertInfo: row.AlertInfo,
+	}
+	var cell int             // cells before the current run
+	var msgStart, msgEnd int // messages before start and end
+	var found bool
+	for i := 0; i+1 < len(row.Results); i += 2 {
+		res, n := row.Results[i], int(row.Results[i+1])
+		lo, hi := cell, cell+n
+		if lo < start {
+			lo = start
+		}
+		if hi > end {
+			hi = end
+		}
+		if lo < hi {
+			out.Results = tgstate.AppendResult(out.Results, res, int32(hi-lo))
+		}
+		if res != int32(state.Row_NO_RESULT) {
+			found = found || lo < hi
+			msgStart += clamp(start-cell, 0, n)
+			msgEnd += clamp(end-cell, 0, n)
+		}
+		cell += n
+	}
+	if !found {
+		return nil
+	}
+	if end <= len(row.CellIds) {
+		out.CellIds = append(out.CellIds, row.CellIds[start:end]...)
+	}
+	if msgEnd <= len(row.Messages) {
+		out.Messages = append(out.Messages, row.Messages[msgStart:msgEnd]...)
+	}
+	if msgEnd <= len(row.Icons) {
+		out.Icons = append(out.Icons, row.Icons[msgStart:msgEnd]...)
+	}
+	if msgEnd <= len(row.Properties) && hasProperties(row.Properties[msgStart:msgEnd]) {
+		out.Properties = append(out.Properties, row.Properties[msgStart:msgEnd]...)
+	}
+	for _, m := range row.Metrics {
+		sm := &state.Metric{Name: m.Name}
+		var v int
+		for i := 0; i+1 < len(m.Indices); i += 2 {
+			for j := int32(0); j < m.Indices[i+1] && v < len(m.Values); j++ {
+				// Metric indices count messages, so a metric for message k has index k+1.
+				if idx := int(m.Indices[i] + j); idx > msgStart && idx <= msgEnd {
+					AppendMetric(sm, int32(idx-msgStart), m.Values[v])
+				}
+				v++
+			}
+		}
+		if len(sm.Values) > 0 {
+			out.Metrics = append(out.Metrics, sm)
+		}
+	}
+	return out
+}
+
+// truncateGrid drops columns after the first max, or after the first one that started before stop.
+//
+// Like readBuilds, the first column that started before stop is kept.
+// The first recent finished columns are kept regardless of when they started,
+// so pending columns do not count towards recent. However max always wins.
+// Rows without any result in the remaining columns are dropped.
+func truncateGrid(grid *state.Grid, max int, stop time.Time, recent int) *state.Grid {
+	end := len(grid.Columns)
+	if end > max {
+		end = max
+	}
+	stopMillis := float64(stop.Unix() * 1000)
+	pending := pendingColumns(grid)
+	var finished int
+	for i, c := range grid.Columns[:end] {
+		if !pending[i] {
+			finished++
+		}
+		if finished >= recent && c.Started < stopMillis {
+			end = i + 1
+			break
+		}
+	}
+	if end == len(grid.Columns) {
+		return grid
+	}
+	return sliceGrid(grid, 0, end)
+}
+
+// clamp returns v limited to the range from lo to hi.
+func clamp(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
@@ -0,0 +1,476 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/pb/state"
+	tgstate "github.com/GoogleCloudPlatform/testgrid/util/state"
+)
+
+func testResult(name string, res state.Row_Result, elapsed float64) Row {
+	r := Row{
+		Result:   res,
+		Metadata: map[string]string{"Tests name": name},
+		Message:  fmt.Sprintf("%s %s", name, res),
+		Icon:     res.String()[:1],
+	}
+	if elapsed > 0 {
+		r.Metrics = map[string]float64{elapsedKey: elapsed}
+	}
+	return r
+}
+
+// withLink returns the result with a link for its cell.
+func withLink(r Row, link string) Row {
+	r.Metadata[LinkKey] = link
+	return r
+}
+
+// testColumns returns columns, newest first, with rows that appear and disappear.
+func testColumns() []Column {
+	cols := []Column{
+		{
+			ID:      "5",
+			Started: 500,
+			Rows: map[string][]Row{
+				"Overall": {testResult("Overall", state.Row_FAIL, 50)},
+				"a":       {withLink(testResult("a", state.Row_FAIL, 5), "https://a/5")},
+				"new":     {testResult("new", state.Row_PASS, 0)},
+			},
+		},
+		{
+			ID:      "4",
+			Started: 400,
+			Rows: map[string][]Row{
+				"Overall": {testResult("Overall", state.Row_PASS, 40)},
+				"a":       {testResult("a", state.Row_PASS, 4)},
+				"b":       {testResult("b", state.Row_PASS, 0)},
+			},
+		},
+		{
+			ID:      "3",
+			Started: 300,
+			Rows: map[string][]Row{
+				"Overall": {testResult("Overall", state.Row_PASS, 30)},
+				"a":       {testResult("a", state.Row_PASS, 0), testResult("a", state.Row_FAIL, 3)},
+			},
+		},
+		{
+			ID:      "2",
+			Started: 200,
+			Rows: map[string][]Row{
+				"Overall": {testResult("Overall", state.Row_FAIL, 20)},
+				"b":       {withLink(testResult("b", state.Row_FAIL, 2), "https://b/2")},
+				"gone":    {testResult("gone", state.Row_PASS, 2)},
+			},
+		},
+		{
+			ID:      "1",
+			Started: 100,
+			Rows: map[string][]Row{
+				"Overall": {testResult("Overall", state.Row_PASS, 10)},
+				"a":       {testResult("a", state.Row_PASS, 1)},
+				"b":       {testResult("b", state.Row_PASS, 1)},
+			},
+		},
+	}
+	for i := range cols {
+		cols[i].Finished = cols[i].Started + 1
+	}
+	return cols
+}
+
+// buildGrid appends each column to a grid, like readBuilds.
+func buildGrid(cols []Column) *state.Grid {
+	grid := &state.Grid{}
+	rows := map[string]*state.Row{}
+	for _, c := range cols {
+		appendColumn(grid, nil, makeNameConfig(nil), rows, c, false)
+	}
+	return normalize(grid)
+}
+
+// normalize sorts rows by name and metrics by name, which may otherwise be in random order.
+func normalize(grid *state.Grid) *state.Grid {
+	sort.Stable(Rows(grid.Rows))
+	for _, r := range grid.Rows {
+		sort.Slice(r.Metrics, func(i, j int) bool { return r.Metrics[i].Name < r.Metrics[j].Name })
+	}
+	return grid
+}
+
+func TestMergeGrids(t *testing.T) {
+	cols := testColumns()
+	want := buildGrid(cols)
+	for split := 0; split <= len(cols); split++ {
+		t.Run(fmt.Sprintf("split at %d", split), func(t *testing.T) {
+			got := normalize(mergeGrids(buildGrid(cols[:split]), buildGrid(cols[split:])))
+			if !proto.Equal(got, want) {
+				t.Errorf("mergeGrids() got %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestSliceGrid(t *testing.T) {
+	cols := testColumns()
+	full := buildGrid(cols)
+	for start := 0; start <= len(cols); start++ {
+		for end := start; end <= len(cols); end++ {
+			t.Run(fmt.Sprintf("%d to %d", start, end), func(t *testing.T) {
+				want := buildGrid(cols[start:end])
+				got := normalize(sliceGrid(full, start, end))
+				if !proto.Equal(got, want) {
+					t.Errorf("sliceGrid() got %s, want %s", got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestTruncateGrid(t *testing.T) {
+	cols := testColumns()
+	cases := []struct {
+		name   string
+		max    int
+		stop   time.Time
+		recent int
+		want   int
+	}{
+		{
+			name: "keep everything",
+			max:  10,
+			want: 5,
+		},
+		{
+			name: "truncate to max",
+			max:  2,
+			want: 2,
+		},
+		{
+			name: "keep first column before stop",
+			max:  10,
+			stop: time.Unix(350, 0),
+			want: 3,
+		},
+		{
+			name:   "keep recent columns before stop",
+			max:    10,
+			stop:   time.Unix(1000, 0),
+			recent: 4,
+			want:   4,
+		},
+		{
+			name:   "recent does not exceed max",
+			max:    2,
+			recent: 4,
+			want:   2,
+		},
+		{
+			name:   "stop keeps more than recent",
+			max:    10,
+			stop:   time.Unix(350, 0),
+			recent: 2,
+			want:   3,
+		},
+		{
+			name:   "recent keeps more than stop",
+			max:    10,
+			stop:   time.Unix(350, 0),
+			recent: 4,
+			want:   4,
+		},
+		{
+			name:   "max wins over stop and recent",
+			max:    3,
+			stop:   time.Unix(150, 0),
+			recent: 5,
+			want:   3,
+		},
+		{
+			name:   "drop rows without results", // gone only has a result in column 2
+			max:    10,
+			stop:   time.Unix(1000, 0),
+			recent: 3,
+			want:   3,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			want := buildGrid(cols[:tc.want])
+			got := normalize(truncateGrid(buildGrid(cols), tc.max, tc.stop, tc.recent))
+			if !proto.Equal(got, want) {
+				t.Errorf("truncateGrid() got %d columns %s, want %d %s", len(got.Columns), got, len(want.Columns), want)
+			}
+		})
+	}
+}
+
+func TestTruncateGridPending(t *testing.T) {
+	cols := append([]Column{{
+		ID:      "6",
+		Started: 600,
+		Rows: map[string][]Row{
+			"Overall": {testResult("Overall", state.Row_RUNNING, 0)},
+		},
+	}}, testColumns()...)
+	got := truncateGrid(buildGrid(cols), 10, time.Unix(1000, 0), 2)
+	var builds []string
+	for _, c := range got.Columns {
+		builds = append(builds, c.Build)
+	}
+	if want := []string{"6", "5", "4"}; !reflect.DeepEqual(builds, want) {
+		t.Errorf("truncateGrid() kept columns %v, want %v", builds, want)
+	}
+}
+
+func TestIncrementalBase(t *testing.T) {
+	cols := testColumns()
+	running := append([]Column{{
+		ID:      "6",
+		Started: 600,
+		Rows: map[string][]Row{
+			"Overall":      {testResult("Overall", state.Row_RUNNING, 0)},
+			"a":            {testResult("a", state.Row_PASS, 6)},
+			"only-running": {testResult("only-running", state.Row_PASS, 6)},
+		},
+	}}, cols...)
+	interleaved := append([]Column{cols[0], {
+		ID:      "4.5",
+		Started: 450,
+		Rows: map[string][]Row{
+			"Overall": {testResult("Overall", state.Row_RUNNING, 0)},
+		},
+	}}, cols[1:]...)
+	tg := configpb.TestGroup{
+		Name:          "foo",
+		Query:         "bucket/logs/foo",
+		DaysOfResults: 7,
+		ColumnHeader: []*configpb.TestGroup_ColumnHeader{
+			{ColumnHeaderSource: &configpb.TestGroup_ColumnHeader_ConfigurationValue{ConfigurationValue: "node_os_image"}},
+		},
+	}
+	withConfig := func(grid *state.Grid, change func(*configpb.TestGroup)) *state.Grid {
+		cfg := tg
+		if change != nil {
+			change(&cfg)
+		}
+		grid.Config = &cfg
+		return grid
+	}
+
+	cases := []struct {
+		name    string
+		stored  *state.Grid
+		err     error
+		grouped bool
+		want    *state.Grid
+		reasons []string
+	}{
+		{
+			name:    "missing",
+			err:     fmt.Errorf("open gs://bucket/foo: %w", storage.ErrObjectNotExist),
+			reasons: []string{"no stored grid"},
+		},
+		{
+			name:    "corrupt",
+			err:     tgstate.ErrCorruptGrid{Path: "gs://bucket/foo", Err: errors.New("bad zlib")},
+			reasons: []string{"corrupt", "bad zlib"},
+		},
+		{
+			name:    "read error",
+			err:     errors.New("connection reset"),
+			reasons: []string{"connection reset"},
+		},
+		{
+			name:    "no config",
+			stored:  buildGrid(cols),
+			reasons: []string{"config"},
+		},
+		{
+			name: "changed headers",
+			stored: withConfig(buildGrid(cols), func(cfg *configpb.TestGroup) {
+				cfg.ColumnHeader = []*configpb.TestGroup_ColumnHeader{{ColumnHeaderSource: &configpb.TestGroup_ColumnHeader_Label{Label: "os"}}}
+			}),
+			reasons: []string{"column_header"},
+		},
+		{
+			name: "appended headers",
+			stored: withConfig(buildGrid(cols), func(cfg *configpb.TestGroup) {
+				cfg.ColumnHeader = nil
+			}),
+			want: withConfig(buildGrid(cols), func(cfg *configpb.TestGroup) {
+				cfg.ColumnHeader = nil
+			}),
+		},
+		{
+			name: "removed headers",
+			stored: withConfig(buildGrid(cols), func(cfg *configpb.TestGroup) {
+				cfg.ColumnHeader = append(cfg.ColumnHeader, &configpb.TestGroup_ColumnHeader{
+					ColumnHeaderSource: &configpb.TestGroup_ColumnHeader_ConfigurationValue{ConfigurationValue: "k8s-version"},
+				})
+			}),
+			reasons: []string{"column_header"},
+		},
+		{
+			name: "changed name config and prefix",
+			stored: withConfig(buildGrid(cols), func(cfg *configpb.TestGroup) {
+				cfg.Query = "bucket/logs/bar"
+				cfg.TestNameConfig = &configpb.TestNameConfig{NameFormat: "%s"}
+			}),
+			reasons: []string{"gcs_prefix, test_name_config"},
+		},
+		{
+			name: "grouped",
+			stored: withConfig(buildGrid(cols), func(cfg *configpb.TestGroup) {
+				cfg.FallbackGrouping = configpb.TestGroup_FALLBACK_GROUPING_COMMIT_NUM
+			}),
+			grouped: true,
+			reasons: []string{"grouped"},
+		},
+		{
+			name: "no longer grouped",
+			stored: withConfig(buildGrid(cols), func(cfg *configpb.TestGroup) {
+				cfg.PrimaryGrouping = configpb.TestGroup_PRIMARY_GROUPING_COMMIT_NUM
+			}),
+			reasons: []string{"primary_grouping"},
+		},
+		{
+			name: "column order changed",
+			stored: withConfig(buildGrid(cols), func(cfg *configpb.TestGroup) {
+				cfg.ColumnOrder = configpb.TestGroup_COLUMN_ORDER_STARTED
+			}),
+			reasons: []string{"column_order"},
+		},
+		{
+			name: "more days of results",
+			stored: withConfig(buildGrid(cols), func(cfg *configpb.TestGroup) {
+				cfg.DaysOfResults = 3
+			}),
+			reasons: []string{"days_of_results"},
+		},
+		{
+			name: "fewer days of results",
+			stored: withConfig(buildGrid(cols), func(cfg *configpb.TestGroup) {
+				cfg.DaysOfResults = 14
+				cfg.NumFailuresToAlert = 3
+			}),
+			want: withConfig(buildGrid(cols), func(cfg *configpb.TestGroup) {
+				cfg.DaysOfResults = 14
+				cfg.NumFailuresToAlert = 3
+			}),
+		},
+		{
+			name:   "drop running columns",
+			stored: withConfig(buildGrid(running), nil),
+			want:   withConfig(buildGrid(cols), nil),
+		},
+		{
+			name:   "drop through the oldest running column",
+			stored: withConfig(buildGrid(interleaved), nil),
+			want:   withConfig(buildGrid(cols[1:]), nil),
+		},
+		{
+			name:    "only running columns",
+			stored:  withConfig(buildGrid(running[:1]), nil),
+			reasons: []string{"no finished columns"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			current := tg
+			if tc.grouped {
+				current.FallbackGrouping = configpb.TestGroup_FALLBACK_GROUPING_COMMIT_NUM
+			}
+			got, reason := incrementalBase(tc.stored, tc.err, current)
+			if got != nil {
+				got = normalize(got)
+			}
+			if !proto.Equal(got, tc.want) {
+				t.Errorf("incrementalBase() got grid %s, want %s", got, tc.want)
+			}
+			if tc.want != nil && reason != "" {
+				t.Errorf("incrementalBase() got unexpected reason %q", reason)
+			}
+			for _, r := range tc.reasons {
+				if !strings.Contains(reason, r) {
+					t.Errorf("incrementalBase() reason %q does not contain %q", reason, r)
+				}
+			}
+		})
+	}
+}
+
+func TestPadHeaders(t *testing.T) {
+	grid := &state.Grid{
+		Columns: []*state.Column{
+			{Build: "3", Extra: []string{"a", "b"}},
+			{Build: "2", Extra: []string{"a"}},
+			{Build: "1"},
+		},
+	}
+	padHeaders(grid, 2)
+	want := [][]string{{"a", "b"}, {"a", ""}, {"", ""}}
+	for i, col := range grid.Columns {
+		if !reflect.DeepEqual(col.Extra, want[i]) {
+			t.Errorf("padHeaders() column %s got %q, want %q", col.Build, col.Extra, want[i])
+		}
+	}
+}
+
+func TestNewerBuilds(t *testing.T) {
+	builds := Builds{
+		{Prefix: "logs/foo/30/"},
+		{Prefix: "logs/foo/20/"},
+		{Prefix: "logs/foo/10/"},
+	}
+	cases := []struct {
+		id   string
+		want int
+		ok   bool
+	}{
+		{id: "30", want: 0, ok: true},
+		{id: "20", want: 1, ok: true},
+		{id: "10", want: 2, ok: true},
+		{id: "5"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.id, func(t *testing.T) {
+			got, ok := newerBuilds(builds, tc.id)
+			if ok != tc.ok {
+				t.Fatalf("newerBuilds() got ok %t, want %t", ok, tc.ok)
+			}
+			if len(got) != tc.want {
+				t.Errorf("newerBuilds() got %d builds, want %d", len(got), tc.want)
+			}
+		})
+	}
+}
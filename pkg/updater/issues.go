@@ -0,0 +1,161 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"cloud.google.com/go/storage"
+
+	"github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// IssuesKey is the row metadata key holding the issues linked to the row, separated by commas,
+// which the frontend marks as linked bugs.
+const IssuesKey = "issues"
+
+// IssuesFile names the file linking tests to issues, which is read from the gcs_prefix of
+// the group, or else from the artifacts of its newest build.
+//
+// The file holds a JSON list of Issues, such as:
+//
+//	[{"test_name_regex": "^TestFoo", "url": "https://github.com/org/repo/issues/123"}]
+const IssuesFile = "issues.json"
+
+// maxIssuesBytes is the most bytes of an IssuesFile to read.
+const maxIssuesBytes = 1 << 20
+
+// Issue links the rows whose names match TestNameRegex to the issue at URL.
+type Issue struct {
+	TestNameRegex string `json:"test_name_regex"`
+	URL           string `json:"url"`
+}
+
+// issueMatcher is an Issue with its compiled regex.
+type issueMatcher struct {
+	re  *regexp.Regexp
+	url string
+}
+
+// parseIssues decodes an IssuesFile, returning an error for each entry it ignores.
+//
+// Entries ignored for an invalid regex or a missing url do not prevent linking the rest,
+// whereas a file that is not a list of issues returns err.
+func parseIssues(buf []byte) (issues []issueMatcher, entryErrs []error, err error) {
+	var entries []Issue
+	if err := json.Unmarshal(buf, &entries); err != nil {
+		return nil, nil, fmt.Errorf("decode: %w", err)
+	}
+	for i, e := range entries {
+		if e.URL == "" {
+			entryErrs = append(entryErrs, fmt.Errorf("issue %d: missing url", i))
+			continue
+		}
+		re, err := regexp.Compile(e.TestNameRegex)
+		if err != nil {
+			entryErrs = append(entryErrs, fmt.Errorf("issue %d (%s): bad test_name_regex: %v", i, e.URL, err))
+			continue
+		}
+		issues = append(issues, issueMatcher{re, e.URL})
+	}
+	return issues, entryErrs, nil
+}
+
+// readIssues reads and parses the IssuesFile of the group at prefix, or else the one in the artifacts of the newest build, when set.
+//
+// Groups with neither file have no issues.
+func readIssues(ctx context.Context, client gcs.Client, prefix gcs.Path, newest *gcs.Build) ([]issueMatcher, []error, error) {
+	paths := []gcs.Path{prefix.Join(IssuesFile)}
+	if newest != nil {
+		build, err := newest.Resolve(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("resolve %s: %v", newest, err)
+		}
+		artifact, err := gcs.ParsePath(build.String() + "artifacts/" + IssuesFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("artifact path: %v", err)
+		}
+		paths = append(paths, *artifact)
+	}
+	for _, p := range paths {
+		buf, err := readIssuesFile(ctx, client, p)
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("read %s: %w", p, err)
+		}
+		issues, entryErrs, err := parseIssues(buf)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parse %s: %w", p, err)
+		}
+		return issues, entryErrs, nil
+	}
+	return nil, nil, nil
+}
+
+// readIssuesFile returns up to maxIssuesBytes of the object at path.
+func readIssuesFile(ctx context.Context, client gcs.Client, path gcs.Path) ([]byte, error) {
+	r, _, err := client.Open(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(io.LimitReader(r, maxIssuesBytes))
+}
+
+// linkIssues records the issues whose regex matches the name of each row in its metadata, returning the urls of issues that matched no row.
+//
+// Rows list their issues in the order of the file, replacing any issues a previous update recorded.
+func linkIssues(rows []*state.Row, issues []issueMatcher) []string {
+	matched := make([]bool, len(issues))
+	for _, r := range rows {
+		var urls []string
+		for i, issue := range issues {
+			if issue.re.MatchString(r.Name) {
+				urls = append(urls, issue.url)
+				matched[i] = true
+			}
+		}
+		if len(urls) == 0 {
+			delete(r.Metadata, IssuesKey)
+			if len(r.Metadata) == 0 {
+				r.Metadata = nil
+			}
+			continue
+		}
+		if r.Metadata == nil {
+			r.Metadata = map[string]string{}
+		}
+		r.Metadata[IssuesKey] = strings.Join(urls, ",")
+	}
+	var stale []string
+	for i, m := range matched {
+		if !m {
+			stale = append(stale, issues[i].url)
+		}
+	}
+	return stale
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+// issueURLs returns the url of each issue.
+func issueURLs(issues []issueMatcher) []string {
+	var urls []string
+	for _, issue := range issues {
+		urls = append(urls, issue.url)
+	}
+	return urls
+}
+
+func TestParseIssues(t *testing.T) {
+	cases := []struct {
+		name     string
+		buf      string
+		want     []string
+		wantErrs int
+		err      bool
+	}{
+		{
+			name: "empty list",
+			buf:  `[]`,
+		},
+		{
+			name: "issues",
+			buf:  `[{"test_name_regex": "^TestFoo", "url": "issue/1"}, {"test_name_regex": "Bar$", "url": "issue/2"}]`,
+			want: []string{"issue/1", "issue/2"},
+		},
+		{
+			name:     "skip invalid entries",
+			buf:      `[{"test_name_regex": "(", "url": "issue/1"}, {"test_name_regex": "Bar"}, {"test_name_regex": "Baz", "url": "issue/3"}]`,
+			want:     []string{"issue/3"},
+			wantErrs: 2,
+		},
+		{
+			name: "not a list",
+			buf:  `{"test_name_regex": "Foo", "url": "issue/1"}`,
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			issues, entryErrs, err := parseIssues([]byte(tc.buf))
+			switch {
+			case err != nil && !tc.err:
+				t.Fatalf("parseIssues() got unexpected error: %v", err)
+			case err == nil && tc.err:
+				t.Fatal("parseIssues() failed to return an error")
+			}
+			if got := issueURLs(issues); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("parseIssues() got issues %v, want %v", got, tc.want)
+			}
+			if len(entryErrs) != tc.wantErrs {
+				t.Errorf("parseIssues() got entry errors %v, want %d", entryErrs, tc.wantErrs)
+			}
+		})
+	}
+}
+
+func TestReadIssues(t *testing.T) {
+	ctx := context.Background()
+	prefix := mustPath(t, "gs://bucket/logs/job")
+	cases := []struct {
+		name  string
+		files map[string]string
+		want  []string
+		err   bool
+	}{
+		{
+			name: "no issues",
+		},
+		{
+			name: "read the group prefix",
+			files: map[string]string{
+				"gs://bucket/logs/job/issues.json":             `[{"test_name_regex": ".", "url": "prefix"}]`,
+				"gs://bucket/logs/job/2/artifacts/issues.json": `[{"test_name_regex": ".", "url": "artifact"}]`,
+			},
+			want: []string{"prefix"},
+		},
+		{
+			name: "else read the newest build",
+			files: map[string]string{
+				"gs://bucket/logs/job/1/artifacts/issues.json": `[{"test_name_regex": ".", "url": "old"}]`,
+				"gs://bucket/logs/job/2/artifacts/issues.json": `[{"test_name_regex": ".", "url": "artifact"}]`,
+			},
+			want: []string{"artifact"},
+		},
+		{
+			name: "corrupt file",
+			files: map[string]string{
+				"gs://bucket/logs/job/issues.json": `[{`,
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var client fake.Client
+			for _, build := range []string{"1", "2"} {
+				client.Put(mustPath(t, "gs://bucket/logs/job/"+build+"/started.json"), []byte(`{"timestamp": 1}`))
+			}
+			for path, content := range tc.files {
+				client.Put(mustPath(t, path), []byte(content))
+			}
+			builds, err := gcs.ListBuilds(ctx, &client, prefix, gcs.ListOptions{})
+			if err != nil {
+				t.Fatalf("ListBuilds() got unexpected error: %v", err)
+			}
+			issues, _, err := readIssues(ctx, &client, prefix, &builds[0])
+			switch {
+			case err != nil && !tc.err:
+				t.Fatalf("readIssues() got unexpected error: %v", err)
+			case err == nil && tc.err:
+				t.Fatal("readIssues() failed to return an error")
+			}
+			if got := issueURLs(issues); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("readIssues() got issues %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLinkIssues(t *testing.T) {
+	issues := []issueMatcher{
+		{regexp.MustCompile("^TestFoo"), "issue/foo"},
+		{regexp.MustCompile("Bar"), "issue/bar"},
+		{regexp.MustCompile("^TestMissing$"), "issue/stale"},
+	}
+	rows := []*state.Row{
+		{Name: "TestFoo/Bar"},
+		{Name: "TestBar", Metadata: map[string]string{ParentKey: "Test"}},
+		{Name: "TestBaz", Metadata: map[string]string{IssuesKey: "issue/removed"}},
+		{Name: "TestQux", Metadata: map[string]string{IssuesKey: "issue/removed", ParentKey: "Test"}},
+	}
+	want := []*state.Row{
+		{Name: "TestFoo/Bar", Metadata: map[string]string{IssuesKey: "issue/foo,issue/bar"}},
+		{Name: "TestBar", Metadata: map[string]string{IssuesKey: "issue/bar", ParentKey: "Test"}},
+		{Name: "TestBaz"},
+		{Name: "TestQux", Metadata: map[string]string{ParentKey: "Test"}},
+	}
+	stale := linkIssues(rows, issues)
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("linkIssues() got rows %v, want %v", rows, want)
+	}
+	if wantStale := []string{"issue/stale"}; !reflect.DeepEqual(stale, wantStale) {
+		t.Errorf("linkIssues() got stale issues %v, want %v", stale, wantStale)
+	}
+}
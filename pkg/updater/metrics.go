@@ -0,0 +1,55 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"time"
+
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	tgstate "github.com/GoogleCloudPlatform/testgrid/util/state"
+)
+
+// GroupReport describes one update of a test group.
+type GroupReport struct {
+	// Group is the name of the test group.
+	Group string
+	// Duration is how long the update took.
+	Duration time.Duration
+	// Columns is the number of columns read from builds.
+	Columns int
+	// Cells is the number of cells in the grid the update wrote, or would write without --confirm.
+	Cells int
+	// Stats describe the size of the grid the update wrote, or would write without --confirm.
+	Stats tgstate.GridStats
+	// DroppedColumns is the number of the oldest columns dropped to fit the grid within its size budget.
+	DroppedColumns int
+	// DroppedRows is the number of rows dropped to keep at most the max_rows of the group.
+	DroppedRows int
+	// BuildErrors are why builds could not be read, each of which is an empty column of the grid.
+	BuildErrors []error
+	// Err is the reason the update failed, if any.
+	Err error
+}
+
+// Metrics records the outcome of each test group update.
+//
+// Implementations must be safe for concurrent use, as groups update concurrently.
+type Metrics interface {
+	ObserveUpdate(GroupReport)
+	// ObserveSuitesCache records how often the groups of an Update reused the junit files they parsed.
+	ObserveSuitesCache(gcs.SuitesCacheStats)
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"sort"
+
+	"vbom.ml/util/sortorder"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+// orderByStarted returns true when the group orders its columns by started time rather than build ID.
+func orderByStarted(group configpb.TestGroup) bool {
+	return group.ColumnOrder == configpb.TestGroup_COLUMN_ORDER_STARTED
+}
+
+// startedAfter returns true when column a started after column b, or at the same time with a larger build ID.
+func startedAfter(aStarted float64, aBuild string, bStarted float64, bBuild string) bool {
+	if aStarted != bStarted {
+		return aStarted > bStarted
+	}
+	return sortorder.NaturalLess(bBuild, aBuild)
+}
+
+// sortColumns orders the columns by started time, newest first, moving nil columns to the end.
+func sortColumns(cols []*Column) {
+	sort.SliceStable(cols, func(i, j int) bool {
+		a, b := cols[i], cols[j]
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		return startedAfter(float64(a.Started), a.ID, float64(b.Started), b.ID)
+	})
+}
+
+// interleaveGrids returns a grid with the columns of both grids ordered by started time, newest first.
+//
+// Both grids must already be in that order, so a late build older than the
+// newest columns of the other grid lands at its position rather than in front.
+// Like mergeGrids, alerts are not merged.
+func interleaveGrids(a, b *state.Grid) *state.Grid {
+	out := &state.Grid{}
+	var i, j int
+	for i < len(a.Columns) || j < len(b.Columns) {
+		start := i
+		for i < len(a.Columns) && (j == len(b.Columns) || !startedAfter(b.Columns[j].Started, b.Columns[j].Build, a.Columns[i].Started, a.Columns[i].Build)) {
+			i++
+		}
+		if i > start {
+			out = mergeGrids(out, sliceGrid(a, start, i))
+		}
+		start = j
+		for j < len(b.Columns) && (i == len(a.Columns) || startedAfter(b.Columns[j].Started, b.Columns[j].Build, a.Columns[i].Started, a.Columns[i].Build)) {
+			j++
+		}
+		if j > start {
+			out = mergeGrids(out, sliceGrid(b, start, j))
+		}
+	}
+	return out
+}
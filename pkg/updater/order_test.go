@@ -0,0 +1,141 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+	tgstate "github.com/GoogleCloudPlatform/testgrid/util/state"
+)
+
+func TestSortColumns(t *testing.T) {
+	cols := []*Column{
+		{ID: "1", Started: 300},
+		nil,
+		{ID: "9", Started: 100},
+		{ID: "10", Started: 100},
+		{ID: "2", Started: 200},
+	}
+	sortColumns(cols)
+	var got []string
+	for _, c := range cols {
+		if c == nil {
+			got = append(got, "nil")
+			continue
+		}
+		got = append(got, c.ID)
+	}
+	if want := []string{"1", "2", "10", "9", "nil"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sortColumns() got %v, want %v", got, want)
+	}
+}
+
+func TestInterleaveGrids(t *testing.T) {
+	cols := testColumns()
+	want := buildGrid(cols)
+	for mask := 0; mask < 1<<len(cols); mask++ {
+		var a, b []Column
+		for i, c := range cols {
+			if mask&(1<<i) != 0 {
+				a = append(a, c)
+			} else {
+				b = append(b, c)
+			}
+		}
+		t.Run(fmt.Sprintf("mask %b", mask), func(t *testing.T) {
+			got := normalize(interleaveGrids(buildGrid(a), buildGrid(b)))
+			if !proto.Equal(got, want) {
+				t.Errorf("interleaveGrids() got %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestUpdateGroupColumnOrder(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Unix()
+	put := func(client *fake.Client, id int, started int64) {
+		build := fmt.Sprintf("gs://bucket/logs/job/%d/", id)
+		client.Put(mustPath(t, build+"started.json"), []byte(fmt.Sprintf(`{"timestamp": %d}`, started)))
+		client.Put(mustPath(t, build+"finished.json"), []byte(fmt.Sprintf(`{"timestamp": %d, "passed": true}`, started+1)))
+		client.Put(mustPath(t, build+"artifacts/junit_01.xml"), []byte(`<testsuite><testcase name="test"/></testsuite>`))
+	}
+	cases := []struct {
+		name  string
+		order configpb.TestGroup_ColumnOrder
+		want  []string
+	}{
+		{
+			name: "order by build id",
+			want: []string{"3", "2", "1"},
+		},
+		{
+			name:  "order by started time",
+			order: configpb.TestGroup_COLUMN_ORDER_STARTED,
+			want:  []string{"2", "3", "1"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var client fake.Client
+			tg := configpb.TestGroup{
+				Name:          "job",
+				Query:         "bucket/logs/job",
+				DaysOfResults: 1,
+				ColumnOrder:   tc.order,
+			}
+			gridPath := mustPath(t, "gs://bucket/job")
+			update := func() []string {
+				var report GroupReport
+				if err := updateGroup(ctx, &client, tg, gridPath, Options{BuildConcurrency: 1, GroupTimeout: time.Minute, BuildTimeout: time.Minute, Compression: gcs.Gzip}, nil, &report); err != nil {
+					t.Fatalf("updateGroup() got unexpected error: %v", err)
+				}
+				grid, _, err := tgstate.ReadGrid(ctx, &client, gridPath)
+				if err != nil {
+					t.Fatalf("ReadGrid() got unexpected error: %v", err)
+				}
+				var builds []string
+				for _, c := range grid.Columns {
+					builds = append(builds, c.Build)
+				}
+				return builds
+			}
+
+			put(&client, 1, now-30)
+			put(&client, 2, now-10)
+			update()
+			// Backfill a build that started before the head of the grid.
+			put(&client, 3, now-20)
+			if got := update(); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("updateGroup() wrote columns %v, want %v", got, tc.want)
+			}
+			if got := update(); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("updateGroup() rewrote columns %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
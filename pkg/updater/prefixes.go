@@ -0,0 +1,71 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// groupPaths returns the locations of the builds of the test group: its gcs_prefix, then each of its additional_gcs_prefixes.
+func groupPaths(tg configpb.TestGroup) ([]gcs.Path, error) {
+	paths := make([]gcs.Path, 0, 1+len(tg.AdditionalGcsPrefixes))
+	for _, prefix := range append([]string{tg.Query}, tg.AdditionalGcsPrefixes...) {
+		p, err := prefixPath(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("gcs_prefix %s: %v", prefix, err)
+		}
+		paths = append(paths, *p)
+	}
+	return paths, nil
+}
+
+// listBuilds returns the newest opts.Max builds under all the paths, sorted newest first.
+//
+// Builds under several paths with the same ID are one build, such as one job uploading
+// to its old and new bucket during a move, so only the build under the earliest path remains.
+func listBuilds(ctx context.Context, client gcs.Client, paths []gcs.Path, opts gcs.ListOptions) (Builds, error) {
+	var all Builds
+	seen := map[string]bool{}
+	for _, p := range paths {
+		c := client
+		if p.IsLocal() {
+			c = gcs.NewLocalClient()
+		}
+		builds, err := gcs.ListBuilds(ctx, c, p, opts)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", p, err)
+		}
+		for _, b := range builds {
+			if id := b.ID(); !seen[id] {
+				seen[id] = true
+				all = append(all, b)
+			}
+		}
+	}
+	if len(paths) > 1 {
+		sort.Stable(sort.Reverse(all))
+	}
+	if opts.Max > 0 && len(all) > opts.Max {
+		all = all[:opts.Max]
+	}
+	return all, nil
+}
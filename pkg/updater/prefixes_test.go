@@ -0,0 +1,153 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestGroupPaths(t *testing.T) {
+	cases := []struct {
+		name string
+		tg   configpb.TestGroup
+		want []string
+		err  bool
+	}{
+		{
+			name: "gcs prefix",
+			tg:   configpb.TestGroup{Query: "bucket/logs/job"},
+			want: []string{"gs://bucket/logs/job"},
+		},
+		{
+			name: "additional prefixes follow the gcs prefix",
+			tg: configpb.TestGroup{
+				Query:                 "bucket/logs/job",
+				AdditionalGcsPrefixes: []string{"old-bucket/logs/job", "file:///logs/job"},
+			},
+			want: []string{"gs://bucket/logs/job", "gs://old-bucket/logs/job", "file:///logs/job"},
+		},
+		{
+			name: "invalid additional prefix",
+			tg: configpb.TestGroup{
+				Query:                 "bucket/logs/job",
+				AdditionalGcsPrefixes: []string{"old bucket/%%"},
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			paths, err := groupPaths(tc.tg)
+			switch {
+			case err != nil && !tc.err:
+				t.Fatalf("groupPaths() got unexpected error: %v", err)
+			case err == nil && tc.err:
+				t.Fatalf("groupPaths() failed to return an error, got %v", paths)
+			}
+			var got []string
+			for _, p := range paths {
+				got = append(got, p.String())
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("groupPaths() got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestListBuilds(t *testing.T) {
+	ctx := context.Background()
+	cases := []struct {
+		name  string
+		paths []string
+		max   int
+		want  []string
+	}{
+		{
+			name:  "one prefix",
+			paths: []string{"gs://bucket/logs/job"},
+			want:  []string{"gs://bucket/logs/job/30/", "gs://bucket/logs/job/10/"},
+		},
+		{
+			name:  "merge prefixes into one timeline",
+			paths: []string{"gs://bucket/logs/job", "gs://old-bucket/logs/job"},
+			want: []string{
+				"gs://bucket/logs/job/30/",
+				"gs://old-bucket/logs/job/20/",
+				"gs://bucket/logs/job/10/",
+				"gs://old-bucket/logs/job/5/",
+			},
+		},
+		{
+			name:  "the earliest prefix wins an id collision",
+			paths: []string{"gs://old-bucket/logs/job", "gs://bucket/logs/job"},
+			want: []string{
+				"gs://bucket/logs/job/30/",
+				"gs://old-bucket/logs/job/20/",
+				"gs://old-bucket/logs/job/10/",
+				"gs://old-bucket/logs/job/5/",
+			},
+		},
+		{
+			name:  "keep the newest max builds",
+			paths: []string{"gs://bucket/logs/job", "gs://old-bucket/logs/job"},
+			max:   3,
+			want: []string{
+				"gs://bucket/logs/job/30/",
+				"gs://old-bucket/logs/job/20/",
+				"gs://bucket/logs/job/10/",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var client fake.Client
+			for _, build := range []string{
+				"gs://bucket/logs/job/10/",
+				"gs://bucket/logs/job/30/",
+				"gs://old-bucket/logs/job/5/",
+				"gs://old-bucket/logs/job/10/",
+				"gs://old-bucket/logs/job/20/",
+			} {
+				client.Put(mustPath(t, build+"started.json"), []byte(`{"timestamp": 1}`))
+			}
+			var paths []gcs.Path
+			for _, p := range tc.paths {
+				paths = append(paths, mustPath(t, p))
+			}
+			builds, err := listBuilds(ctx, &client, paths, gcs.ListOptions{Max: tc.max})
+			if err != nil {
+				t.Fatalf("listBuilds() got unexpected error: %v", err)
+			}
+			var got []string
+			for _, b := range builds {
+				got = append(got, b.String())
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("listBuilds() got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/storage"
+
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// UpdateReport is the machine-readable record of one Update cycle.
+type UpdateReport struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	// ConfigGeneration is the generation of the configuration object the cycle read.
+	ConfigGeneration int64 `json:"config_generation"`
+	// Groups are sorted by name.
+	Groups []GroupResult `json:"groups"`
+	// Error is why the cycle could not update its groups, such as an unreadable configuration.
+	Error string `json:"error,omitempty"`
+}
+
+// GroupResult is the serializable outcome of one update of a test group.
+type GroupResult struct {
+	Name string `json:"name"`
+	// Seconds is how long the update took.
+	Seconds float64 `json:"seconds"`
+	// Columns is the number of columns the update added, read from builds.
+	Columns int `json:"columns"`
+	// SkippedBuilds is the number of builds that could not be read, each of which is an empty column.
+	SkippedBuilds int `json:"skipped_builds,omitempty"`
+	// Errors are why builds could not be read, followed by why the update failed, if it did.
+	Errors []string `json:"errors,omitempty"`
+	// Failed is true when the update did not write a grid.
+	Failed bool `json:"failed,omitempty"`
+}
+
+// add records the outcome of a group update.
+func (r *UpdateReport) add(gr GroupReport) {
+	res := GroupResult{
+		Name:          gr.Group,
+		Seconds:       gr.Duration.Seconds(),
+		Columns:       gr.Columns,
+		SkippedBuilds: len(gr.BuildErrors),
+		Failed:        gr.Err != nil,
+	}
+	for _, err := range gr.BuildErrors {
+		res.Errors = append(res.Errors, err.Error())
+	}
+	if gr.Err != nil {
+		res.Errors = append(res.Errors, gr.Err.Error())
+	}
+	r.Groups = append(r.Groups, res)
+}
+
+// finish ends the cycle, recording err when it prevented updating the groups.
+func (r *UpdateReport) finish(err error) {
+	r.End = time.Now()
+	if err != nil {
+		r.Error = err.Error()
+	}
+	sort.SliceStable(r.Groups, func(i, j int) bool {
+		return r.Groups[i].Name < r.Groups[j].Name
+	})
+}
+
+// WriteReport uploads the report as JSON to path, replacing the report of the previous cycle.
+func WriteReport(ctx context.Context, client gcs.Client, path gcs.Path, r *UpdateReport) error {
+	buf, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("marshal: %v", err)
+	}
+	if _, err := client.Upload(ctx, path, buf, gcs.DefaultAcl, "no-cache", storage.Conditions{}); err != nil {
+		return fmt.Errorf("upload %s: %w", path, err)
+	}
+	return nil
+}
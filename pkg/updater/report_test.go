@@ -0,0 +1,122 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestUpdateReport(t *testing.T) {
+	now := time.Now().Unix()
+	configPath := mustPath(t, "gs://bucket/config")
+	cfg := configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{
+			{Name: "good", Query: "bucket/logs/good", DaysOfResults: 1},
+			{Name: "bad", Query: "gs://bucket/logs/bad"},
+		},
+	}
+	buf, err := proto.Marshal(&cfg)
+	if err != nil {
+		t.Fatalf("marshal config: %v", err)
+	}
+	var client fake.Client
+	for _, build := range []string{"1", "2"} {
+		prefix := "gs://bucket/logs/good/" + build + "/"
+		client.Put(mustPath(t, prefix+"started.json"), []byte(fmt.Sprintf(`{"timestamp": %d}`, now)))
+		client.Put(mustPath(t, prefix+"finished.json"), []byte(fmt.Sprintf(`{"timestamp": %d, "passed": true, "result": "SUCCESS"}`, now+1)))
+	}
+	generation := client.Put(configPath, buf).Generation
+
+	report, err := Update(&client, context.Background(), configPath, Options{GroupConcurrency: 1, BuildConcurrency: 1, GroupTimeout: time.Minute, BuildTimeout: time.Minute, Compression: gcs.Gzip})
+	if err == nil {
+		t.Error("Update() failed to return the error of the bad group")
+	}
+	if report.ConfigGeneration != generation {
+		t.Errorf("Update() reported config generation %d, want %d", report.ConfigGeneration, generation)
+	}
+	if report.Start.IsZero() || report.End.Before(report.Start) {
+		t.Errorf("Update() reported a cycle from %v to %v", report.Start, report.End)
+	}
+	if report.Error != "" {
+		t.Errorf("Update() reported unexpected cycle error %q", report.Error)
+	}
+	var names []string
+	for _, g := range report.Groups {
+		names = append(names, g.Name)
+	}
+	if want := []string{"bad", "good"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("Update() reported groups %v, want %v", names, want)
+	}
+	if bad := report.Groups[0]; !bad.Failed || len(bad.Errors) != 1 {
+		t.Errorf("Update() reported %+v, want the failure of the bad group", bad)
+	}
+	if good := report.Groups[1]; good.Failed || good.Columns != 2 || len(good.Errors) != 0 {
+		t.Errorf("Update() reported %+v, want two columns for the good group", good)
+	}
+
+	report, err = Update(&client, context.Background(), configPath, Options{GroupConcurrency: 1, BuildConcurrency: 1, GroupTimeout: time.Minute, BuildTimeout: time.Minute, Group: "missing", Compression: gcs.Gzip})
+	if err == nil {
+		t.Error("Update(missing) failed to return an error")
+	}
+	if report.Error == "" || len(report.Groups) != 0 {
+		t.Errorf("Update(missing) reported %+v, want only the cycle error", report)
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	ctx := context.Background()
+	var client fake.Client
+	path := mustPath(t, "gs://bucket/updater/report.json")
+	start := time.Date(2020, 9, 1, 12, 0, 0, 0, time.UTC)
+	want := UpdateReport{
+		Start:            start,
+		End:              start.Add(time.Minute),
+		ConfigGeneration: 7,
+		Groups: []GroupResult{
+			{Name: "bad", Seconds: 1.5, Errors: []string{"oops"}, Failed: true},
+			{Name: "good", Seconds: 30, Columns: 3, SkippedBuilds: 1, Errors: []string{"unreadable"}},
+		},
+	}
+	if err := WriteReport(ctx, &client, path, &want); err != nil {
+		t.Fatalf("WriteReport() got unexpected error: %v", err)
+	}
+	obj, ok := client.Lookup(path)
+	if !ok {
+		t.Fatalf("WriteReport() did not write %s", path)
+	}
+	if obj.Attrs.CacheControl != "no-cache" {
+		t.Errorf("WriteReport() wrote Cache-Control %q, want no-cache", obj.Attrs.CacheControl)
+	}
+	var got UpdateReport
+	if err := json.Unmarshal(obj.Data, &got); err != nil {
+		t.Fatalf("WriteReport() wrote bad JSON %s: %v", obj.Data, err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WriteReport() wrote %+v, want %+v", got, want)
+	}
+}
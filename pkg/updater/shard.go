@@ -0,0 +1,80 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// GroupFilter selects the test groups an updater handles, such as one replica of several sharing a configuration.
+//
+// The zero value selects every group.
+type GroupFilter struct {
+	// Allow, when set, selects the groups whose name it matches.
+	Allow *regexp.Regexp
+	// Shard selects the groups that ShardOf assigns it among Shards, when Shards is positive.
+	Shard, Shards int
+}
+
+// Validate returns an error when the filter has a shard outside of its shards.
+func (f GroupFilter) Validate() error {
+	if f.Shards < 0 {
+		return fmt.Errorf("shard count must be >= 0, got %d", f.Shards)
+	}
+	if f.Shards > 0 && (f.Shard < 0 || f.Shard >= f.Shards) {
+		return fmt.Errorf("shard index must be in [0, %d), got %d", f.Shards, f.Shard)
+	}
+	return nil
+}
+
+// Match returns true when the filter selects the group.
+func (f GroupFilter) Match(name string) bool {
+	if f.Allow != nil && !f.Allow.MatchString(name) {
+		return false
+	}
+	return f.Shards <= 0 || ShardOf(name, f.Shards) == f.Shard
+}
+
+// filter returns the groups the filter selects, in order.
+func (f GroupFilter) filter(groups []*configpb.TestGroup) []*configpb.TestGroup {
+	if f.Allow == nil && f.Shards <= 0 {
+		return groups
+	}
+	var out []*configpb.TestGroup
+	for _, tg := range groups {
+		if f.Match(tg.Name) {
+			out = append(out, tg)
+		}
+	}
+	return out
+}
+
+// ShardOf returns the shard in [0, shards) of the named group.
+//
+// The shard is the 32-bit FNV-1a hash of the config.Normalize form of the name, modulo shards,
+// so replicas agree on the owner of every group without coordinating. Changing the algorithm
+// would move groups between replicas running different releases, so it must remain stable.
+func ShardOf(name string, shards int) int {
+	h := fnv.New32a()
+	h.Write([]byte(config.Normalize(name)))
+	return int(h.Sum32() % uint32(shards))
+}
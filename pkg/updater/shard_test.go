@@ -0,0 +1,145 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+func TestShardOf(t *testing.T) {
+	// Replicas of different releases must agree, so these never change.
+	cases := []struct {
+		name   string
+		shards int
+		want   int
+	}{
+		{"ci-kubernetes-e2e", 3, 1},
+		{"ci-kubernetes-e2e", 10, 5},
+		{"CI Kubernetes E2E", 10, 5},
+		{"pull-kubernetes-unit", 3, 1},
+		{"pull-kubernetes-unit", 10, 0},
+		{"testgroup", 10, 6},
+		{"testgroup", 1, 0},
+	}
+	for _, tc := range cases {
+		if got := ShardOf(tc.name, tc.shards); got != tc.want {
+			t.Errorf("ShardOf(%q, %d) got %d, want %d", tc.name, tc.shards, got, tc.want)
+		}
+	}
+}
+
+func TestGroupFilterShards(t *testing.T) {
+	var names []string
+	for i := 0; i < 200; i++ {
+		names = append(names, fmt.Sprintf("group-%d", i))
+	}
+	for _, shards := range []int{1, 2, 3, 7, 16} {
+		t.Run(fmt.Sprintf("%d shards", shards), func(t *testing.T) {
+			owners := map[string]int{}
+			for shard := 0; shard < shards; shard++ {
+				f := GroupFilter{Shard: shard, Shards: shards}
+				if err := f.Validate(); err != nil {
+					t.Fatalf("Validate() got unexpected error: %v", err)
+				}
+				for _, name := range names {
+					if f.Match(name) {
+						owners[name]++
+					}
+				}
+			}
+			for _, name := range names {
+				if n := owners[name]; n != 1 {
+					t.Errorf("%s is on %d of %d shards, want exactly one", name, n, shards)
+				}
+			}
+		})
+	}
+}
+
+func TestGroupFilter(t *testing.T) {
+	groups := []*configpb.TestGroup{
+		{Name: "ci-foo"},
+		{Name: "ci-bar"},
+		{Name: "pull-foo"},
+		{Name: "testgroup"},
+	}
+	cases := []struct {
+		name   string
+		filter GroupFilter
+		want   []string
+	}{
+		{
+			name: "zero selects every group",
+			want: []string{"ci-foo", "ci-bar", "pull-foo", "testgroup"},
+		},
+		{
+			name:   "allow list",
+			filter: GroupFilter{Allow: regexp.MustCompile("^ci-")},
+			want:   []string{"ci-foo", "ci-bar"},
+		},
+		{
+			name:   "shard",
+			filter: GroupFilter{Shard: ShardOf("testgroup", 10), Shards: 10},
+			want:   []string{"testgroup"},
+		},
+		{
+			name:   "allow list and shard",
+			filter: GroupFilter{Allow: regexp.MustCompile("^ci-"), Shard: ShardOf("testgroup", 10), Shards: 10},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, tg := range tc.filter.filter(groups) {
+				got = append(got, tg.Name)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("filter() got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGroupFilterValidate(t *testing.T) {
+	cases := []struct {
+		name   string
+		filter GroupFilter
+		err    bool
+	}{
+		{name: "zero"},
+		{name: "last shard", filter: GroupFilter{Shard: 2, Shards: 3}},
+		{name: "negative shard count", filter: GroupFilter{Shards: -1}, err: true},
+		{name: "shard past count", filter: GroupFilter{Shard: 3, Shards: 3}, err: true},
+		{name: "negative shard", filter: GroupFilter{Shard: -1, Shards: 3}, err: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.filter.Validate()
+			switch {
+			case err != nil && !tc.err:
+				t.Errorf("Validate() got unexpected error: %v", err)
+			case err == nil && tc.err:
+				t.Error("Validate() failed to return an error")
+			}
+		})
+	}
+}
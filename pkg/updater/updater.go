@@ -17,14 +17,15 @@ limitations under the License.
 package updater
 
 import (
-	"bytes"
-	"compress/zlib"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"math"
-	"net/url"
 	"path"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -34,11 +35,14 @@ import (
 	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
 	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
 	"github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/pb/test_status"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	tgstate "github.com/GoogleCloudPlatform/testgrid/util/state"
 
 	"cloud.google.com/go/storage"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/timestamp"
+	multierror "github.com/hashicorp/go-multierror"
 	"github.com/sirupsen/logrus"
 	"vbom.ml/util/sortorder"
 )
@@ -51,19 +55,83 @@ type Builds = gcs.Builds
 
 // testGroupPath() returns the path to a test_group proto given this proto
 func testGroupPath(g gcs.Path, name string) (*gcs.Path, error) {
-	u, err := url.Parse(name)
-	if err != nil {
-		return nil, fmt.Errorf("invalid url %s: %v", name, err)
+	dir := g.Parent()
+	if dir == nil {
+		return nil, fmt.Errorf("config %s must be an object", g)
+	}
+	np := dir.Join(name)
+	return &np, nil
+}
+
+// defaultMaxMessage is the number of bytes of a message a cell shows when the group does not configure it.
+const defaultMaxMessage = 140
+
+// maxMessage returns the number of bytes of a message cells in the group show.
+func maxMessage(tg configpb.TestGroup) int {
+	if n := tg.MaxMessageLength; n > 0 {
+		return int(n)
+	}
+	return defaultMaxMessage
+}
+
+// statuses are the results of rows with a failure, error or skipped junit result.
+type statuses struct {
+	failure state.Row_Result
+	errored state.Row_Result
+	skipped state.Row_Result
+}
+
+// defaultStatuses are the results of each category when the group does not map it.
+var defaultStatuses = statuses{
+	failure: state.Row_FAIL,
+	errored: state.Row_FAIL,
+	skipped: state.Row_PASS_WITH_SKIPS,
+}
+
+// statusMapping returns the result of each category of junit result in the group, see status_mapping.
+//
+// Config validation ensures each mapped TestStatus is also a Row result.
+func statusMapping(tg configpb.TestGroup) statuses {
+	s := defaultStatuses
+	m := tg.GetStatusMapping()
+	if v := m.GetFailure(); v != test_status.TestStatus_NO_RESULT {
+		s.failure = state.Row_Result(v)
+	}
+	if v := m.GetError(); v != test_status.TestStatus_NO_RESULT {
+		s.errored = state.Row_Result(v)
 	}
-	np, err := g.ResolveReference(u)
-	if err == nil && np.Bucket() != g.Bucket() {
-		return nil, fmt.Errorf("testGroup %s should not change bucket", name)
+	if v := m.GetSkipped(); v != test_status.TestStatus_NO_RESULT {
+		s.skipped = state.Row_Result(v)
 	}
-	return np, nil
+	return s
+}
+
+const (
+	// ShortTextProperty is the junit property of a test case that overrides the icon of its cell, such as "T".
+	ShortTextProperty = "testgrid-short-text"
+	// LinkProperty is the junit property of a test case with a link for its cell, stored in the cell metadata under LinkKey.
+	LinkProperty = "testgrid-link"
+	// LinkKey is the cell metadata key holding the link of the cell, which the state keeps in the properties of the cell.
+	LinkKey = "link"
+)
+
+// maxShortText is the most runes of a ShortTextProperty a cell shows.
+const maxShortText = 2
+
+// shortText returns up to the first maxShortText runes of text.
+func shortText(text string) string {
+	var runes int
+	for i := range text {
+		if runes == maxShortText {
+			return text[:i]
+		}
+		runes++
+	}
+	return text
 }
 
 // Row converts the junit result into a Row result, prepending the suite name.
-func row(jr junit.Result, suite string) (string, Row) {
+func row(jr junit.Result, suite string, suiteProps map[string]string, max int, results statuses) (string, Row) {
 	n := jr.Name
 	if suite != "" {
 		n = suite + "." + n
@@ -77,36 +145,57 @@ func row(jr junit.Result, suite string) (string, Row) {
 	if jr.Time > 0 {
 		r.Metrics[elapsedKey] = jr.Time
 	}
-	const max = 140
 	if msg := jr.Message(max); msg != "" {
 		r.Message = msg
 	}
 	switch {
 	case jr.Failure != nil:
-		r.Result = state.Row_FAIL
+		r.Result = results.failure
 		if r.Message != "" {
 			r.Icon = "F"
 		}
+	case jr.Errored != nil:
+		r.Result = results.errored
+		if r.Message != "" {
+			r.Icon = "E"
+		}
 	case jr.Skipped != nil:
-		r.Result = state.Row_PASS_WITH_SKIPS
+		r.Result = results.skipped
 		if r.Message != "" {
 			r.Icon = "S"
 		}
 	default:
 		r.Result = state.Row_PASS
 	}
+	props := jr.PropertyMap()
+	if text := shortText(strings.TrimSpace(props[ShortTextProperty])); text != "" {
+		r.Icon = text
+	}
+	if link := props[LinkProperty]; link != "" {
+		r.Metadata[LinkKey] = link
+	}
+	if len(props)+len(suiteProps) > 0 {
+		r.Properties = make(map[string]string, len(props)+len(suiteProps))
+		for k, v := range suiteProps {
+			r.Properties[k] = v
+		}
+		for k, v := range props {
+			r.Properties[k] = v
+		}
+	}
 	return n, r
 }
 
-func extractRows(suites junit.Suites, meta map[string]string) map[string][]Row {
+func extractRows(suites junit.Suites, meta map[string]string, max int, results statuses) map[string][]Row {
 	rows := map[string][]Row{}
 	for _, suite := range suites.Suites {
+		props := suite.PropertyMap()
 		for _, sr := range suite.Results {
 			if sr.Skipped != nil && len(*sr.Skipped) == 0 {
 				continue
 			}
 
-			n, r := row(sr, suite.Name)
+			n, r := row(sr, suite.Name, props, max, results)
 			for k, v := range meta {
 				r.Metadata[k] = v
 			}
@@ -116,6 +205,24 @@ func extractRows(suites junit.Suites, meta map[string]string) map[string][]Row {
 	return rows
 }
 
+// artifactRows extracts the rows of each artifact, ordering the results of each target by artifact path.
+//
+// Artifacts are read concurrently, so sorting ensures the attempts of a test
+// are in the same order however the artifacts arrive, such as junit_01.xml
+// before junit_02.xml.
+func artifactRows(parts []gcs.SuitesMeta, max int, results statuses) map[string][]Row {
+	sort.SliceStable(parts, func(i, j int) bool {
+		return sortorder.NaturalLess(parts[i].Path, parts[j].Path)
+	})
+	rows := map[string][]Row{}
+	for _, part := range parts {
+		for t, rs := range extractRows(part.Suites, part.Metadata, max, results) {
+			rows[t] = append(rows[t], rs...)
+		}
+	}
+	return rows
+}
+
 // ColumnMetadata holds key => value mapping of metadata info.
 type ColumnMetadata map[string]string
 
@@ -128,6 +235,16 @@ type Column struct {
 	Rows     map[string][]Row
 	Metadata ColumnMetadata
 	Version  string
+	// Error is why the build could not be read, leaving every cell of the column empty.
+	Error string
+}
+
+// errorColumn returns an empty column for the build, noting why it could not be read.
+func errorColumn(build Build, err error) *Column {
+	return &Column{
+		ID:    build.ID(),
+		Error: err.Error(),
+	}
 }
 
 // Row holds results for a piece of a build run, such as a test result.
@@ -137,6 +254,8 @@ type Row struct {
 	Metadata map[string]string
 	Message  string
 	Icon     string
+	// Properties holds the junit properties of the test case, along with those of its suite the case does not set.
+	Properties map[string]string
 }
 
 // Overall calculates the generated-overall row value for the current column
@@ -168,6 +287,16 @@ func (br Column) Overall() Row {
 	return r
 }
 
+// pending returns true when the Overall row of the column is still running, see Overall.
+func (br Column) pending() bool {
+	for _, r := range br.Rows["Overall"] {
+		if r.Result == state.Row_RUNNING {
+			return true
+		}
+	}
+	return false
+}
+
 // AppendMetric adds the value at index to metric.
 //
 // Handles the details of sparse-encoding the results.
@@ -195,18 +324,14 @@ func FindMetric(row *state.Row, name string) *state.Metric {
 
 var noResult = Row{Result: state.Row_NO_RESULT}
 
+// pendingResult fills the cells of a pending column, where each row is still running.
+var pendingResult = Row{Result: state.Row_RUNNING}
+
 // AppendResult adds the rowResult column to the row.
 //
 // Handles the details like missing fields and run-length-encoding the result.
 func AppendResult(row *state.Row, rowResult Row, count int) {
-	latest := int32(rowResult.Result)
-	n := len(row.Results)
-	switch {
-	case n == 0, row.Results[n-2] != latest:
-		row.Results = append(row.Results, latest, int32(count))
-	default:
-		row.Results[n-1] += int32(count)
-	}
+	row.Results = tgstate.AppendResult(row.Results, int32(rowResult.Result), int32(count))
 
 	for i := 0; i < count; i++ { // TODO(fejta): update server to allow empty cellids
 		row.CellIds = append(row.CellIds, "")
@@ -219,12 +344,43 @@ func AppendResult(row *state.Row, rowResult Row, count int) {
 			row.Messages = append(row.Messages, rowResult.Message)
 			row.Icons = append(row.Icons, rowResult.Icon)
 		}
+		appendProperties(row, rowResult, count)
+	}
+}
+
+// cellPropertyKeys are the cell metadata keys the state keeps in the properties of each cell.
+var cellPropertyKeys = []string{LinkKey, AttemptsKey}
+
+// appendProperties adds the cell properties of the result to count cells just appended to row.
+//
+// Rows without any properties have none, otherwise each cell with a message has a property.
+func appendProperties(row *state.Row, rowResult Row, count int) {
+	var props map[string]string
+	for _, k := range cellPropertyKeys {
+		if v := rowResult.Metadata[k]; v != "" {
+			if props == nil {
+				props = map[string]string{}
+			}
+			props[k] = v
+		}
+	}
+	if props == nil && len(row.Properties) == 0 {
+		return
+	}
+	for len(row.Properties) < len(row.Messages)-count {
+		row.Properties = append(row.Properties, &state.Property{})
+	}
+	for i := 0; i < count; i++ {
+		row.Properties = append(row.Properties, &state.Property{Property: props})
 	}
 }
 
+// MissingNameElement replaces each element of a TestNameConfig whose key no source defines.
+const MissingNameElement = "<missing>"
+
 type nameConfig struct {
 	format string
-	parts  []string
+	parts  []string // The key of each element
 }
 
 func makeNameConfig(tnc *configpb.TestNameConfig) nameConfig {
@@ -240,34 +396,56 @@ func makeNameConfig(tnc *configpb.TestNameConfig) nameConfig {
 	}
 	for i, e := range tnc.NameElements {
 		nc.parts[i] = e.TargetConfig
+		if nc.parts[i] == "" {
+			nc.parts[i] = e.TestProperty
+		}
 	}
 	return nc
 }
 
 // Format renders any requested metadata into the name
+//
+// Each element of the name uses the first source defining its key: the junit properties of the
+// test case, then those of its suite, then the build metadata, which is the result metadata such as
+// Tests name and Context followed by the finished metadata of the build in meta.
+// Otherwise the element is the literal MissingNameElement.
+//
+// Results which render to the same name share a row, see mergeResults.
 func (r Row) Format(config nameConfig, meta map[string]string) string {
 	parsed := make([]interface{}, len(config.parts))
 	for i, p := range config.parts {
-		if v, ok := r.Metadata[p]; ok {
-			parsed[i] = v
-			continue
-		}
-		parsed[i] = meta[p] // "" if missing
+		parsed[i] = r.nameElement(p, meta)
 	}
 	return fmt.Sprintf(config.format, parsed...)
 }
 
+// nameElement returns the value of key for Format.
+func (r Row) nameElement(key string, meta map[string]string) string {
+	if v, ok := r.Properties[key]; ok {
+		return v
+	}
+	if v, ok := r.Metadata[key]; ok {
+		return v
+	}
+	if v, ok := meta[key]; ok {
+		return v
+	}
+	return MissingNameElement
+}
+
 // appendColumn adds the build column to the grid.
 //
 // This handles details like:
 // * rows appearing/disappearing in the middle of the run.
 // * adding auto metadata like duration, commit as well as any user-added metadata
 // * extracting build metadata into the appropriate column header
-// * Ensuring row names are unique and formatted with metadata
-func appendColumn(grid *state.Grid, headers []string, format nameConfig, rows map[string]*state.Row, build Column) {
+// * formatting row names with metadata, and merging results with the same name into one cell (see mergeResults)
+// * filling every cell of a pending column with a running result
+func appendColumn(grid *state.Grid, headers []string, format nameConfig, rows map[string]*state.Row, build Column, lastWins bool) {
 	c := state.Column{
 		Build:   build.ID,
 		Started: float64(build.Started * 1000),
+		Error:   build.Error,
 	}
 	for _, h := range headers {
 		if build.Finished == 0 {
@@ -278,69 +456,134 @@ func appendColumn(grid *state.Grid, headers []string, format nameConfig, rows ma
 			c.Extra = append(c.Extra, build.Version)
 			continue
 		}
-		val, ok := build.Metadata[h]
-		if !ok {
-			val = "missing"
-		}
-		c.Extra = append(c.Extra, val)
+		c.Extra = append(c.Extra, build.Metadata[h]) // "" if missing
 	}
 	grid.Columns = append(grid.Columns, &c)
 
 	missing := map[string]*state.Row{}
+	var overall *state.Row
 	for name, row := range rows {
 		missing[name] = row
+		if row.Id == "Overall" {
+			overall = row
+		}
+	}
+	missingResult := noResult
+	if build.pending() {
+		missingResult = pendingResult
+	}
+
+	// Group the results by name, visiting targets in order so the first target of a name is deterministic.
+	targets := make([]string, 0, len(build.Rows))
+	for target := range build.Rows {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+	type cell struct {
+		target  string
+		results []Row
+	}
+	cells := map[string]*cell{}
+	var names []string
+	for _, target := range targets {
+		for _, br := range build.Rows[target] {
+			name := br.Format(format, build.Metadata)
+			c, ok := cells[name]
+			if !ok {
+				c = &cell{target: target}
+				cells[name] = c
+				names = append(names, name)
+			}
+			c.results = append(c.results, br)
+		}
 	}
 
-	found := map[string]bool{}
-
-	for target, results := range build.Rows {
-		for _, br := range results {
-			prefix := br.Format(format, build.Metadata)
-			name := prefix
-			// Ensure each name is unique
-			// If we have multiple results with the same name foo
-			// then append " [n]" to the name so we wind up with:
-			//   foo
-			//   foo [1]
-			//   foo [2]
-			//   etc
-			for idx := 1; found[name]; idx++ {
-				// found[name] exists, so try foo [n+1]
-				name = fmt.Sprintf("%s [%d]", prefix, idx)
+	for _, name := range names {
+		c := cells[name]
+		br := mergeResults(c.results, lastWins)
+		delete(missing, name)
+
+		// Does this row already exist?
+		r, ok := rows[name]
+		if !ok { // New row
+			r = &state.Row{
+				Name: name,
+				Id:   c.target,
 			}
-			// hooray, name not in found
-			found[name] = true
-			delete(missing, name)
-
-			// Does this row already exist?
-			r, ok := rows[name]
-			if !ok { // New row
-				r = &state.Row{
-					Name: name,
-					Id:   target,
-				}
-				rows[name] = r
-				grid.Rows = append(grid.Rows, r)
-				if n := len(grid.Columns); n > 1 {
-					// Add missing entries for more recent builds (aka earlier columns)
-					AppendResult(r, noResult, n-1)
-				}
+			rows[name] = r
+			grid.Rows = append(grid.Rows, r)
+			if n := len(grid.Columns); n > 1 {
+				// Add missing entries for more recent builds (aka earlier columns)
+				backfill(r, overall, n-1)
 			}
+		}
 
-			AppendResult(r, br, 1)
-			for k, v := range br.Metrics {
-				m := FindMetric(r, k)
-				if m == nil {
-					m = &state.Metric{Name: k}
-					r.Metrics = append(r.Metrics, m)
-				}
-				AppendMetric(m, int32(len(r.Messages)), v)
+		AppendResult(r, br, 1)
+		for k, v := range br.Metrics {
+			m := FindMetric(r, k)
+			if m == nil {
+				m = &state.Metric{Name: k}
+				r.Metrics = append(r.Metrics, m)
 			}
+			AppendMetric(m, int32(len(r.Messages)), v)
 		}
 	}
 
 	for _, row := range missing {
-		AppendResult(row, noResult, 1)
+		AppendResult(row, missingResult, 1)
+	}
+}
+
+// AttemptsKey is the cell metadata key holding the number of results merged into the cell,
+// when a test ran more than once in a build.
+const AttemptsKey = "attempts"
+
+// mergeResults combines the attempts of a test in one column into a single cell, from the first attempt to the last.
+//
+// The most severe result wins, such as a failure over a pass, unless lastWins
+// selects the last attempt. Among equally severe results the later attempt wins.
+// Cells merging several attempts record their number in the metadata under AttemptsKey.
+func mergeResults(results []Row, lastWins bool) Row {
+	if len(results) == 1 {
+		return results[0]
+	}
+	best := results[len(results)-1]
+	if !lastWins {
+		for _, r := range results {
+			if severity(r.Result) >= severity(best.Result) {
+				best = r
+			}
+		}
+	}
+	md := make(map[string]string, len(best.Metadata)+1)
+	for k, v := range best.Metadata {
+		md[k] = v
+	}
+	md[AttemptsKey] = strconv.Itoa(len(results))
+	best.Metadata = md
+	return best
+}
+
+// backfill appends n cells to a new row for the earlier columns, using the Overall row to find pending columns.
+//
+// Cells of pending columns are running, and the rest have no result.
+func backfill(row, overall *state.Row, n int) {
+	if overall != nil {
+		for i := 0; n > 0 && i+1 < len(overall.Results); i += 2 {
+			count := int(overall.Results[i+1])
+			if count > n {
+				count = n
+			}
+			res := noResult
+			if state.Row_Result(overall.Results[i]) == state.Row_RUNNING {
+				res = pendingResult
+			}
+			AppendResult(row, res, count)
+			n -= count
+		}
+	}
+	if n > 0 {
+		AppendResult(row, noResult, n)
 	}
 }
 
@@ -352,6 +595,9 @@ func alertRows(cols []*state.Column, rows []*state.Row, openFailures, closePasse
 }
 
 // alertRow returns an AlertInfo proto if there have been failuresToOpen consecutive failures more recently than passesToClose.
+//
+// The alert links the first failure of the outage and the last pass before it.
+// Broken columns and skipped results count as neither a pass nor a failure.
 func alertRow(cols []*state.Column, row *state.Row, failuresToOpen, passesToClose int) *state.AlertInfo {
 	if failuresToOpen == 0 {
 		return nil
@@ -372,8 +618,8 @@ func alertRow(cols []*state.Column, row *state.Row, failuresToOpen, passesToClos
 		// TODO(fejta): ignore old running
 		rawRes := <-ch
 		res := result.Coalesce(rawRes, result.IgnoreRunning)
-		if res == state.Row_NO_RESULT {
-			if rawRes == state.Row_RUNNING {
+		if res == state.Row_NO_RESULT || rawRes == state.Row_PASS_WITH_SKIPS || isBroken(col) {
+			if rawRes != state.Row_NO_RESULT {
 				compressedIdx++
 			}
 			continue
@@ -393,9 +639,7 @@ func alertRow(cols []*state.Column, row *state.Row, failuresToOpen, passesToClos
 			passes = 0
 			failures++
 			totalFailures++
-			if failures == 1 { // note most recent failure for this outage
-				failIdx = compressedIdx
-			}
+			failIdx = compressedIdx // note the first failure of this outage
 			lastFail = col
 		}
 		if res == state.Row_FLAKY {
@@ -415,6 +659,60 @@ func alertRow(cols []*state.Column, row *state.Row, failuresToOpen, passesToClos
 	return alertInfo(totalFailures, msg, id, lastFail, latestPass)
 }
 
+// rowAlerts returns the alert of each row of the grid that has one.
+func rowAlerts(grid *state.Grid) map[string]*state.AlertInfo {
+	alerts := map[string]*state.AlertInfo{}
+	for _, r := range grid.GetRows() {
+		if r.AlertInfo != nil {
+			alerts[r.Name] = r.AlertInfo
+		}
+	}
+	return alerts
+}
+
+// rememberAlerts keeps the first failure of the previous alert of each row whose outage began before its oldest column.
+//
+// Truncating the grid drops the column where such an outage began, along with the last pass before it.
+// The remembered alert counts the failures of the fresh columns, which are newer than the previous alert,
+// on top of those it already counted. Fresh columns need not lead cols, such as after interleaving them by start time.
+func rememberAlerts(cols []*state.Column, rows []*state.Row, previous map[string]*state.AlertInfo, fresh []*state.Column) {
+	isFresh := make(map[*state.Column]bool, len(fresh))
+	for _, c := range fresh {
+		isFresh[c] = true
+	}
+	for _, r := range rows {
+		alert, prev := r.AlertInfo, previous[r.Name]
+		if alert == nil || prev == nil || alert.PassTime != nil || !earlier(prev.FailTime, alert.FailTime) {
+			continue
+		}
+		remembered := proto.Clone(prev).(*state.AlertInfo)
+		remembered.FailCount += failures(cols, r, isFresh)
+		r.AlertInfo = remembered
+	}
+}
+
+// failures counts the failing results of the row in the selected columns, ignoring broken columns.
+func failures(cols []*state.Column, row *state.Row, selected map[*state.Column]bool) int32 {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := result.Iter(ctx, row.Results)
+	var count int32
+	for _, col := range cols {
+		if result.Coalesce(<-ch, result.IgnoreRunning) == state.Row_FAIL && selected[col] && !isBroken(col) {
+			count++
+		}
+	}
+	return count
+}
+
+// earlier returns true when a is before b.
+func earlier(a, b *timestamp.Timestamp) bool {
+	if a.GetSeconds() != b.GetSeconds() {
+		return a.GetSeconds() < b.GetSeconds()
+	}
+	return a.GetNanos() < b.GetNanos()
+}
+
 // alertInfo returns an alert proto with the configured fields
 func alertInfo(failures int32, msg, cellId string, fail, pass *state.Column) *state.AlertInfo {
 	return &state.AlertInfo{
@@ -433,7 +731,7 @@ func buildID(col *state.Column) string {
 	if col == nil {
 		return ""
 	}
-	if len(col.Extra) > 0 {
+	if len(col.Extra) > 0 && col.Extra[0] != "" {
 		return col.Extra[0]
 	}
 	return col.Build
@@ -458,10 +756,16 @@ func stamp(col *state.Column) *timestamp.Timestamp {
 const elapsedKey = "seconds-elapsed"
 
 // readBuild asynchronously downloads the files in build from gcs and converts them into a build.
-func readBuild(parent context.Context, build Build, timeout time.Duration) (*Column, error) {
+//
+// Symlinked builds are read from their target, keeping the ID of the symlink.
+func readBuild(parent context.Context, build Build, results statuses, timeout time.Duration) (*Column, error) {
 	var wg sync.WaitGroup                               // Each subtask does wg.Add(1), then we wg.Wait() for them to finish
 	ctx, cancel := context.WithTimeout(parent, timeout) // Allows aborting after first error
 	defer cancel()
+	build, err := build.Resolve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %v", build, err)
+	}
 	ec := make(chan error) // Receives errors from anyone
 
 	// Download started.json, send to sc
@@ -533,16 +837,13 @@ func readBuild(parent context.Context, build Build, timeout time.Duration) (*Col
 		}
 	}()
 
-	// Append each row into the column
-	rows := map[string][]Row{}
+	// Collect the suites of every artifact
+	var parts []gcs.SuitesMeta
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
 		for suitesMeta := range suitesChan {
-			rowsPart := extractRows(suitesMeta.Suites, suitesMeta.Metadata)
-			for name, results := range rowsPart {
-				rows[name] = append(rows[name], results...)
-			}
+			parts = append(parts, suitesMeta)
 		}
 	}()
 
@@ -576,8 +877,11 @@ func readBuild(parent context.Context, build Build, timeout time.Duration) (*Col
 		break
 	case finished = <-fc:
 	}
+	if started.Pending && !finished.Running {
+		return nil, fmt.Errorf("failed to read %s: finished without a started.json", build)
+	}
 	br := Column{
-		ID:      path.Base(build.Prefix),
+		ID:      build.ID(),
 		Started: started.Timestamp,
 	}
 	// Has the build finished?
@@ -590,7 +894,8 @@ func readBuild(parent context.Context, build Build, timeout time.Duration) (*Col
 	if finished.Timestamp != nil {
 		br.Finished = *finished.Timestamp
 	}
-	br.Metadata = finished.Metadata.Strings()
+	br.Metadata = columnMetadata(started.Started, finished.Finished)
+	locationMetadata(br.Metadata, build)
 	if finished.Passed != nil {
 		br.Passed = *finished.Passed
 	}
@@ -608,7 +913,7 @@ func readBuild(parent context.Context, build Build, timeout time.Duration) (*Col
 		}
 	}
 
-	for t, rs := range rows {
+	for t, rs := range artifactRows(parts, build.MaxMessage, results) {
 		br.Rows[t] = append(br.Rows[t], rs...)
 	}
 	if or.Result == state.Row_FAIL { // Ensure failing build has a failing row
@@ -618,7 +923,7 @@ func readBuild(parent context.Context, build Build, timeout time.Duration) (*Col
 				continue
 			}
 			for _, r := range rs {
-				if r.Result == state.Row_FAIL {
+				if result.Coalesce(r.Result, result.IgnoreRunning) == state.Row_FAIL {
 					ft = true // Failing test, huzzah!
 					break
 				}
@@ -636,11 +941,79 @@ func readBuild(parent context.Context, build Build, timeout time.Duration) (*Col
 	return &br, nil
 }
 
-// Headers returns the list of ColumnHeader ConfigurationValues for this group.
+// columnMetadata returns the string values in the metadata of the build.
+//
+// A key in finished.json takes precedence over the same key in the deprecated started.json metadata.
+func columnMetadata(started metadata.Started, finished metadata.Finished) ColumnMetadata {
+	meta := ColumnMetadata{}
+	for k, v := range started.Metadata.Strings() {
+		meta[k] = v
+	}
+	for k, v := range finished.Metadata.Strings() {
+		meta[k] = v
+	}
+	return meta
+}
+
+// maxAnnotationBytes limits the combined size of the keys and values annotating each column.
+const maxAnnotationBytes = 1024
+
+// annotateColumn copies the value of each key in the build metadata into the metadata of the column.
+//
+// Keys are copied in order while they fit within maxAnnotationBytes, skipping those the build
+// does not set. The first key that does not fit drops it along with every later key.
+func annotateColumn(col *state.Column, meta ColumnMetadata, keys []string) {
+	budget := maxAnnotationBytes
+	for _, k := range keys {
+		v, ok := meta[k]
+		if !ok {
+			continue
+		}
+		if budget -= len(k) + len(v); budget < 0 {
+			return
+		}
+		if col.Metadata == nil {
+			col.Metadata = map[string]string{}
+		}
+		col.Metadata[k] = v
+	}
+}
+
+// JobKey and BuildKey are the column metadata keys of the job and build a column was read from,
+// after following any symlink, such as job and 456 for pr-logs/pull/org_repo/123/job/456/.
+const (
+	JobKey   = "job"
+	BuildKey = "build"
+)
+
+// locationMetadata records the job and build of the resolved build, unless the metadata of the build sets them.
+func locationMetadata(meta ColumnMetadata, build Build) {
+	if _, ok := meta[JobKey]; !ok {
+		if job := build.Job(); job != "" {
+			meta[JobKey] = job
+		}
+	}
+	if _, ok := meta[BuildKey]; !ok {
+		meta[BuildKey] = path.Base(build.Prefix)
+	}
+}
+
+// Headers returns the metadata key of each ColumnHeader for this group, in order.
+//
+// GCS builds have no separate labels or properties, so each source is a metadata key.
 func Headers(group configpb.TestGroup) []string {
 	var extra []string
 	for _, h := range group.ColumnHeader {
-		extra = append(extra, h.String())
+		var key string
+		switch {
+		case h.GetConfigurationValue() != "":
+			key = h.GetConfigurationValue()
+		case h.GetProperty() != "":
+			key = h.GetProperty()
+		default:
+			key = h.GetLabel()
+		}
+		extra = append(extra, key)
 	}
 	return extra
 }
@@ -654,25 +1027,46 @@ func (r Rows) Less(i, j int) bool {
 	return sortorder.NaturalLess(r[i].Name, r[j].Name)
 }
 
+// alertThresholds returns the consecutive failures that open an alert, and the passes that close it.
+func alertThresholds(group configpb.TestGroup) (int, int) {
+	failsOpen := int(group.NumFailuresToAlert)
+	passesClose := int(group.NumPassesToDisableAlert)
+	if failsOpen > 0 && passesClose == 0 {
+		passesClose = 1
+	}
+	return failsOpen, passesClose
+}
+
 // readBuilds will asynchronously construct a Grid for the group out of the specified builds.
-func readBuilds(parent context.Context, group configpb.TestGroup, builds Builds, max int, dur time.Duration, concurrency int, timeout time.Duration) (*state.Grid, error) {
+//
+// Reading stops after the first of the num_columns_recent builds (or later) that started more
+// than dur ago, but builds read concurrently are included, so truncate the grid afterwards.
+//
+// A build that cannot be read, such as one with a corrupt junit file, becomes an empty column
+// noting the error, which readBuilds also returns. In strict mode such a build fails the grid.
+// An empty column takes the start time of the newer column, as its own may be unknown.
+// Junit files parsed by earlier reads come from the cache, when set.
+func readBuilds(parent context.Context, group configpb.TestGroup, builds Builds, max int, dur time.Duration, concurrency int, timeout time.Duration, strict bool, cache *gcs.SuitesCache) (*state.Grid, []error, error) {
 	// Spawn build readers
 	if concurrency == 0 {
-		return nil, fmt.Errorf("zero readers for %s", group.Name)
+		return nil, nil, fmt.Errorf("zero readers for %s", group.Name)
 	}
-	log := logrus.WithField("group", group.Name).WithField("prefix", "gs://"+group.Query)
+	log := logrus.WithField("group", group.Name).WithField("prefix", group.Query)
 	ctx, cancel := context.WithCancel(parent)
 	defer cancel()
 	var stop time.Time
 	if dur != 0 {
 		stop = time.Now().Add(-dur)
 	}
+	recent := int(group.NumColumnsRecent)
+	results := statusMapping(group)
 	lb := len(builds)
 	if lb > max {
 		log.WithField("total", lb).WithField("max", max).Debug("Truncating")
 		lb = max
 	}
 	cols := make([]*Column, lb)
+	buildErrs := make([]error, lb)
 	log.WithField("duration", dur).Debug("Updating")
 	ec := make(chan error)
 	old := make(chan int)
@@ -711,10 +1105,12 @@ func readBuilds(parent context.Context, group configpb.TestGroup, builds Builds,
 						return
 					}
 					b := builds[i]
+					b.MaxMessage = maxMessage(group)
+					b.Cache = cache
 
 					// use ctx so we finish reading, even if buildCtx is done
-					c, err := readBuild(ctx, b, timeout)
-					if err != nil {
+					c, err := readBuild(ctx, b, results, timeout)
+					if err != nil && (strict || ctx.Err() != nil) {
 						select {
 						case <-buildCtx.Done():
 							return
@@ -722,8 +1118,13 @@ func readBuilds(parent context.Context, group configpb.TestGroup, builds Builds,
 						}
 						continue
 					}
+					if err != nil {
+						log.WithError(err).WithField("prefix", b.Prefix).Warning("Failed to read build")
+						c = errorColumn(b, err)
+						buildErrs[i] = err
+					}
 					cols[i] = c
-					if c.Started < stop.Unix() {
+					if c.Error == "" && c.Started < stop.Unix() && i+1 >= recent {
 						select {
 						case <-buildCtx.Done():
 							return
@@ -756,46 +1157,60 @@ func readBuilds(parent context.Context, group configpb.TestGroup, builds Builds,
 	// Determine if we got an error
 	select {
 	case <-ctx.Done():
-		return nil, fmt.Errorf("interrupted reading %s", group.Name)
+		return nil, nil, fmt.Errorf("interrupted reading %s", group.Name)
 	case err := <-ec:
 		if err != nil {
-			return nil, fmt.Errorf("error reading %s: %v", group.Name, err)
+			return nil, nil, fmt.Errorf("error reading %s: %v", group.Name, err)
 		}
 	}
 
+	if value := columnGrouping(group); value != nil {
+		cols = groupColumns(cols, value)
+	}
+
 	// Add the columns into a grid message
 	grid := &state.Grid{}
 	rows := map[string]*state.Row{} // For fast target => row lookup
 	heads := Headers(group)
 	nameCfg := makeNameConfig(group.TestNameConfig)
-	failsOpen := int(group.NumFailuresToAlert)
-	passesClose := int(group.NumPassesToDisableAlert)
-	if failsOpen > 0 && passesClose == 0 {
-		passesClose = 1
+
+	newer := time.Now().Unix()
+	for _, c := range cols {
+		if c == nil || group.IgnorePending && c.pending() {
+			continue
+		}
+		if c.Error != "" && c.Started == 0 {
+			c.Started = newer
+		}
+		newer = c.Started
+	}
+	if orderByStarted(group) {
+		sortColumns(cols)
 	}
 
 	for _, c := range cols {
 		select {
 		case <-ctx.Done():
-			return nil, fmt.Errorf("interrupted appending columns to %s", group.Name)
+			return nil, nil, fmt.Errorf("interrupted appending columns to %s", group.Name)
 		default:
 		}
 		if c == nil {
 			continue
 		}
-		appendColumn(grid, heads, nameCfg, rows, *c)
-		alertRows(grid.Columns, grid.Rows, failsOpen, passesClose)
-		if c.Started < stop.Unix() { // There may be concurrency results < stop.Unix()
-			logrus.WithFields(logrus.Fields{
-				"group": group.Name,
-				"id":    c.ID,
-				"stop":  stop,
-			}).Debug("Column started before oldest allowed time, stopping processing earlier columns")
-			break // Just process the first result < stop.Unix()
+		if group.IgnorePending && c.pending() {
+			continue
 		}
+		appendColumn(grid, heads, nameCfg, rows, *c, group.IgnoreOldResults)
+		annotateColumn(grid.Columns[len(grid.Columns)-1], c.Metadata, group.ColumnAnnotations)
 	}
 	sort.Stable(Rows(grid.Rows))
-	return grid, nil
+	var errs []error
+	for _, err := range buildErrs {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return grid, errs, nil
 }
 
 // Days converts days float into a time.Duration, assuming a 24 hour day.
@@ -806,52 +1221,149 @@ func Days(d float64) time.Duration {
 	return time.Duration(24*d) * time.Hour // Close enough
 }
 
-func Update(client *storage.Client, ctx context.Context, path gcs.Path, groupConcurrency int, buildConcurrency int, confirm bool, groupTimeout time.Duration, buildTimeout time.Duration, group string) {
-	cfg, err := config.ReadGCS(ctx, client.Bucket(path.Bucket()).Object(path.Object()))
+// Options configures an Update.
+type Options struct {
+	// GroupConcurrency is the number of groups to update at a time.
+	GroupConcurrency int
+	// BuildConcurrency is the number of builds each group reads at a time.
+	BuildConcurrency int
+	// GroupTimeout limits the time to update each group.
+	GroupTimeout time.Duration
+	// BuildTimeout limits the time to read each build.
+	BuildTimeout time.Duration
+	// Group names the only group to update, when set, instead of those Filter selects.
+	Group string
+	// Filter selects the groups to update.
+	Filter GroupFilter
+	// MaxBytes drops the oldest columns of larger grids when positive, see fitGrid.
+	MaxBytes int
+	// Strict fails the group update when a build cannot be read, see readBuilds.
+	Strict bool
+	// CacheBytes shares up to this many bytes of parsed junit files between groups when positive.
+	CacheBytes int64
+	// Compression compresses each grid, such as gcs.Gzip.
+	Compression gcs.Compression
+	// Metrics observes the outcome of each group update, unless it is nil.
+	Metrics Metrics
+}
+
+// Update reads the configuration at path and updates the grid of each test group the options select.
+//
+// A group that fails to update does not stop the others; returns the failure of every group once they finish.
+// Dry runs write with a gcs.WriteClient, which records the grids instead.
+// Returns the UpdateReport of the cycle, even when it fails.
+func Update(client gcs.Client, ctx context.Context, path gcs.Path, opts Options) (*UpdateReport, error) {
+	cycle := &UpdateReport{Start: time.Now()}
+	cfg, generation, err := config.ReadGCS(ctx, client, path, false)
 	if err != nil {
-		logrus.Fatalf("Failed to read %s: %v", path, err)
+		err = fmt.Errorf("read %s: %w", path, err)
+		cycle.finish(err)
+		return cycle, err
+	}
+	cycle.ConfigGeneration = generation
+
+	groups := opts.Filter.filter(cfg.TestGroups)
+	if opts.Group != "" { // Just a specific group
+		tg := config.FindTestGroup(cfg, opts.Group)
+		if tg == nil {
+			err := fmt.Errorf("group %s not found in %s", opts.Group, path)
+			cycle.finish(err)
+			return cycle, err
+		}
+		groups = []*configpb.TestGroup{tg}
 	}
-	logrus.WithField("groups", len(cfg.TestGroups)).Info("Updating test groups")
+	logrus.WithField("groups", len(groups)).Info("Updating test groups")
 
-	groups := make(chan configpb.TestGroup)
+	var cache *gcs.SuitesCache
+	if opts.CacheBytes > 0 {
+		cache = gcs.NewSuitesCache(opts.CacheBytes)
+	}
+	var lock sync.Mutex
+	update := func(ctx context.Context, tg configpb.TestGroup) error {
+		start := time.Now()
+		report := GroupReport{Group: tg.Name}
+		tgp, err := testGroupPath(path, tg.Name)
+		if err == nil {
+			err = updateGroup(ctx, client, tg, *tgp, opts, cache, &report)
+		}
+		report.Duration = time.Since(start)
+		report.Err = err
+		if opts.Metrics != nil {
+			opts.Metrics.ObserveUpdate(report)
+		}
+		lock.Lock()
+		cycle.add(report)
+		lock.Unlock()
+		return err
+	}
+	err = updateGroups(ctx, groups, opts.GroupConcurrency, update)
+	if cache != nil {
+		stats := cache.Stats()
+		logrus.WithFields(logrus.Fields{
+			"hits":   stats.Hits,
+			"misses": stats.Misses,
+			"bytes":  stats.Bytes,
+		}).Info("Parsed junit files")
+		if opts.Metrics != nil {
+			opts.Metrics.ObserveSuitesCache(stats)
+		}
+	}
+	// Groups record their own failures.
+	cycle.finish(nil)
+	return cycle, err
+}
+
+// updateGroups calls update on each group, using up to concurrency workers.
+//
+// Each worker finishes one group before starting the next, so a worker never
+// holds more than one grid. Returns a multierror of the groups that failed.
+func updateGroups(ctx context.Context, groups []*configpb.TestGroup, concurrency int, update func(context.Context, configpb.TestGroup) error) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	ch := make(chan *configpb.TestGroup)
 	var wg sync.WaitGroup
+	var lock sync.Mutex
+	var mErr *multierror.Error
 
-	for i := 0; i < groupConcurrency; i++ {
+	for i := 0; i < concurrency; i++ {
 		wg.Add(1)
 		go func() {
-			for tg := range groups {
-				tgp, err := testGroupPath(path, tg.Name)
-				if err == nil {
-					err = updateGroup(ctx, client, tg, *tgp, buildConcurrency, confirm, groupTimeout, buildTimeout)
-				}
-				if err != nil {
+			defer wg.Done()
+			for tg := range ch {
+				if err := update(ctx, *tg); err != nil {
 					logrus.WithField("group", tg.Name).WithError(err).Error("could not update group")
+					lock.Lock()
+					mErr = multierror.Append(mErr, fmt.Errorf("%s: %w", tg.Name, err))
+					lock.Unlock()
 				}
 			}
-			wg.Done()
 		}()
 	}
 
-	if group != "" { // Just a specific group
-		tg := config.FindTestGroup(group, cfg)
-		if tg == nil {
-			logrus.WithField("group", group).WithField("config", path).Fatal("group not found")
-		}
-		groups <- *tg
-	} else { // All groups
-		idxChan := make(chan int)
-		defer close(idxChan)
-		go logUpdate(idxChan, len(cfg.TestGroups), "Update in progress")
-		for i, tg := range cfg.TestGroups {
-			select {
-			case idxChan <- i:
-			default:
-			}
-			groups <- *tg
+	idxChan := make(chan int)
+	defer close(idxChan)
+	if len(groups) > 1 {
+		go logUpdate(idxChan, len(groups), "Update in progress")
+	}
+send:
+	for i, tg := range groups {
+		select {
+		case idxChan <- i:
+		default:
+		}
+		select {
+		case <-ctx.Done():
+			lock.Lock()
+			mErr = multierror.Append(mErr, fmt.Errorf("stopped after %d of %d groups: %w", i, len(groups), ctx.Err()))
+			lock.Unlock()
+			break send
+		case ch <- tg:
 		}
 	}
-	close(groups)
+	close(ch)
 	wg.Wait()
+	return mErr.ErrorOrNil()
 }
 
 // logUpdate posts Update progress every minute, including an ETA for completion.
@@ -884,70 +1396,283 @@ func logUpdate(ch <-chan int, total int, msg string) {
 	}
 }
 
-func updateGroup(parent context.Context, client *storage.Client, tg configpb.TestGroup, gridPath gcs.Path, concurrency int, write bool, groupTimeout, buildTimeout time.Duration) error {
-	ctx, cancel := context.WithTimeout(parent, groupTimeout)
+// prefixPath returns the location of builds under a gcs_prefix (aka query).
+//
+// The gcs_prefix is either a bucket/path in GCS, or a file:// url of a local directory.
+func prefixPath(prefix string) (*gcs.Path, error) {
+	if strings.HasPrefix(prefix, "file://") {
+		return gcs.ParsePath(prefix)
+	}
+	return gcs.ParsePath("gs://" + prefix)
+}
+
+// updateGroup updates the grid of the test group at gridPath, counting the columns it reads and the cells it writes in report.
+//
+// The grid is compressed, so that it downloads compressed, after dropping the columns that do not fit within opts.MaxBytes.
+// Groups share the junit files they parse through the cache, unless it is nil.
+// Writing is the last step, and only replaces the grid it read: an update that runs past
+// opts.GroupTimeout or is cancelled leaves the stored grid untouched.
+func updateGroup(parent context.Context, client gcs.Client, tg configpb.TestGroup, gridPath gcs.Path, opts Options, cache *gcs.SuitesCache, report *GroupReport) error {
+	ctx, cancel := context.WithTimeout(parent, opts.GroupTimeout)
 	defer cancel()
 	log := logrus.WithField("group", tg.Name)
 	o := tg.Name
 
-	var tgPath gcs.Path
-	if err := tgPath.Set("gs://" + tg.Query); err != nil {
-		return fmt.Errorf("group %s has an invalid gcs_prefix %s: %v", o, tg.Query, err)
+	paths, err := groupPaths(tg)
+	if err != nil {
+		return fmt.Errorf("group %s has an invalid %v", o, err)
 	}
+	tgPath := &paths[0]
 
-	g := state.Grid{}
-	g.Columns = append(g.Columns, &state.Column{Build: "first", Started: 1})
-	builds, err := gcs.ListBuilds(ctx, client, tgPath)
+	const maxCols = 50
+	builds, err := listBuilds(ctx, client, paths, gcs.ListOptions{Symlinks: tg.FollowSymlinks, Max: maxCols})
 	if err != nil {
 		return fmt.Errorf("failed to list %s builds: %v", o, err)
 	}
-	log.WithField("total", len(builds)).Debug("Listed builds")
+	log.WithFields(logrus.Fields{
+		"total":    len(builds),
+		"layout":   gcs.LayoutOf(*tgPath),
+		"prefixes": len(paths),
+	}).Debug("Listed builds")
+	var newestBuild *gcs.Build
+	if len(builds) > 0 {
+		newestBuild = &builds[0]
+	}
 	var dur time.Duration
 	if tg.DaysOfResults > 0 {
 		dur = Days(float64(tg.DaysOfResults))
 	} else {
 		dur = Days(7)
 	}
-	const maxCols = 50
-	grid, err := readBuilds(ctx, tg, builds, maxCols, dur, concurrency, buildTimeout)
+
+	stored, attrs, err := tgstate.ReadGridAttrs(ctx, client, gridPath)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return fmt.Errorf("interrupted reading %s grid: %w", o, ctxErr)
+	}
+	cond := gridConditions(attrs, err)
+	base, reason := incrementalBase(stored, err, tg)
+	if base != nil {
+		newest := base.Columns[0].Build
+		ids := []string{newest}
+		if orderByStarted(tg) {
+			// The newest build may have started before others.
+			ids = ids[:0]
+			for _, c := range base.Columns {
+				ids = append(ids, c.Build)
+			}
+		}
+		if newer, ok := newerBuilds(builds, ids...); ok {
+			builds = newer
+		} else {
+			base, reason = nil, fmt.Sprintf("newest column %s is no longer listed", newest)
+		}
+	}
+	if base != nil {
+		padHeaders(base, len(Headers(tg)))
+	}
+	if base == nil {
+		log.WithField("reason", reason).Info("Rebuilding grid")
+	} else {
+		log.WithFields(logrus.Fields{
+			"newest": base.Columns[0].Build,
+			"builds": len(builds),
+		}).Debug("Updating grid incrementally")
+	}
+
+	grid, buildErrs, err := readBuilds(ctx, tg, builds, maxCols, dur, opts.BuildConcurrency, opts.BuildTimeout, opts.Strict, cache)
 	if err != nil {
 		return err
 	}
-	buf, err := marshalGrid(*grid)
+	report.Columns = len(grid.Columns)
+	report.BuildErrors = buildErrs
+	if len(buildErrs) > 0 {
+		log.WithField("errors", len(buildErrs)).Warning("Wrote empty columns for builds that could not be read")
+	}
+	fresh := grid.Columns
+	previous := rowAlerts(base)
+	switch {
+	case base != nil && orderByStarted(tg):
+		grid = interleaveGrids(grid, base)
+	case base != nil:
+		grid = mergeGrids(grid, base)
+	}
+	// Apply the retention of the group to both new and incremental grids.
+	grid = truncateGrid(grid, maxCols, time.Now().Add(-dur), int(tg.NumColumnsRecent))
+	columnStats(grid, brokenThreshold(tg))
+	failsOpen, passesClose := alertThresholds(tg)
+	alertRows(grid.Columns, grid.Rows, failsOpen, passesClose)
+	rememberAlerts(grid.Columns, grid.Rows, previous, fresh)
+	if capRows(grid, maxRows(tg)); grid.DroppedRows > 0 {
+		report.DroppedRows = int(grid.DroppedRows)
+		log.WithFields(logrus.Fields{
+			"dropped": grid.DroppedRows,
+			"rows":    len(grid.Rows),
+		}).Warning("Dropped the rows that failed least recently, check whether the test names are unique per build")
+	}
+	if grid = fitGrid(grid, opts.MaxBytes); grid.DroppedColumns > 0 {
+		report.DroppedColumns = int(grid.DroppedColumns)
+		log.WithFields(logrus.Fields{
+			"dropped":  grid.DroppedColumns,
+			"columns":  len(grid.Columns),
+			"maxBytes": opts.MaxBytes,
+		}).Warning("Dropped the oldest columns to fit the grid, consider fewer days_of_results")
+	}
+	sort.Stable(Rows(grid.Rows))
+	groupRows(grid.Rows, tg.EnableTestMethods)
+	issues, issueErrs, err := readIssues(ctx, client, *tgPath, newestBuild)
+	if err != nil {
+		log.WithError(err).Warning("Failed to read issues, leaving rows unlinked")
+	}
+	for _, err := range issueErrs {
+		log.WithError(err).Warning("Ignored an invalid issue")
+	}
+	if stale := linkIssues(grid.Rows, issues); len(stale) > 0 {
+		log.WithField("issues", stale).Warning("Issues match no rows, consider removing them")
+	}
+	grid.Config = &tg
+	report.Cells = len(grid.Columns) * len(grid.Rows)
+	report.Stats = tgstate.Stats(grid)
+	buf, err := marshalGrid(*grid, opts.Compression)
 	if err != nil {
 		return fmt.Errorf("failed to marshal %s grid: %v", o, err)
 	}
 	tgp := gridPath
 	log = log.WithField("url", tgp).WithField("bytes", len(buf))
-	if !write {
-		log.Debug("Skipping write")
-	} else {
-		log.Debug("Writing")
-		// TODO(fejta): configurable cache value
-		if err := gcs.Upload(ctx, client, tgp, buf, gcs.DefaultAcl, "no-cache"); err != nil {
-			return fmt.Errorf("upload %s to %s failed: %v", o, tgp, err)
-		}
+	// Never replace the stored grid with one the deadline cut short.
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("interrupted before writing %s: %w", o, err)
+	}
+	log.Debug("Writing")
+	// TODO(fejta): configurable cache value
+	_, err = gcs.UploadEncoded(ctx, client, tgp, buf, gcs.DefaultAcl, "no-cache", opts.Compression.ContentEncoding(), cond)
+	if gcs.IsPreconditionFailed(err) {
+		return fmt.Errorf("%s grid changed while updating, skipped writing: %w", o, err)
+	}
+	if err != nil {
+		return fmt.Errorf("upload %s to %s failed: %v", o, tgp, err)
 	}
 	log.WithFields(logrus.Fields{
-		"cols": len(grid.Columns),
-		"rows": len(grid.Rows),
+		"cols":  len(grid.Columns),
+		"rows":  len(grid.Rows),
+		"stats": report.Stats,
 	}).Info("Wrote grid")
 	return nil
 }
 
-// marhshalGrid serializes a state proto into zlib-compressed bytes.
-func marshalGrid(grid state.Grid) ([]byte, error) {
-	buf, err := proto.Marshal(&grid)
-	if err != nil {
-		return nil, fmt.Errorf("proto encoding failed: %v", err)
+// gridConditions returns the conditions to replace the grid read with attrs and readErr, unless it changed since.
+//
+// A missing grid must still not exist, while a grid that failed to read is replaced regardless.
+func gridConditions(attrs *storage.ReaderObjectAttrs, readErr error) storage.Conditions {
+	switch {
+	case errors.Is(readErr, storage.ErrObjectNotExist):
+		return storage.Conditions{DoesNotExist: true}
+	case attrs != nil && attrs.Generation != 0:
+		return storage.Conditions{GenerationMatch: attrs.Generation}
+	}
+	return storage.Conditions{}
+}
+
+// fitGrid drops the oldest columns of the grid until it serializes into at most maxBytes, or one column remains.
+//
+// Searches for the most columns that fit, recording the number of dropped
+// columns in the grid. Zero maxBytes keeps every column.
+func fitGrid(grid *state.Grid, maxBytes int) *state.Grid {
+	n := len(grid.Columns)
+	if maxBytes <= 0 || n <= 1 || proto.Size(grid) <= maxBytes {
+		return grid
+	}
+	fit := sliceGrid(grid, 0, 1)
+	lo, hi := 2, n-1 // fit keeps lo-1 columns
+	for lo <= hi {
+		mid := (lo + hi) / 2
+		g := sliceGrid(grid, 0, mid)
+		if proto.Size(g) <= maxBytes {
+			fit, lo = g, mid+1
+		} else {
+			hi = mid - 1
+		}
+	}
+	fit.DroppedColumns = int32(n - len(fit.Columns))
+	return fit
+}
+
+// DefaultMaxRows is the most rows a grid keeps when its test group does not set max_rows.
+const DefaultMaxRows = 100000
+
+// maxRows returns the most rows the grid of the test group keeps.
+func maxRows(tg configpb.TestGroup) int {
+	if n := tg.MaxRows; n > 0 {
+		return int(n)
 	}
-	var zbuf bytes.Buffer
-	zw := zlib.NewWriter(&zbuf)
-	if _, err = zw.Write(buf); err != nil {
-		return nil, fmt.Errorf("zlib compression failed: %v", err)
+	return DefaultMaxRows
+}
+
+// capRows keeps at most max rows of the grid, recording the number of dropped rows in the grid.
+//
+// The Overall row is always kept. The other rows rank by their newest failure, so rows that failed
+// in newer columns come before those that failed in older ones, followed by rows that never failed.
+// Ties go to the alphabetically first name, and then to the earlier row, so the same grid always
+// keeps the same rows. Kept rows remain in their original order. Zero max keeps every row.
+func capRows(grid *state.Grid, max int) {
+	grid.DroppedRows = 0
+	n := len(grid.Rows)
+	if max <= 0 || n <= max {
+		return
+	}
+	newest := make([]int, n)
+	ranked := make([]int, n)
+	for i, row := range grid.Rows {
+		newest[i] = newestFailure(row)
+		ranked[i] = i
+	}
+	sort.SliceStable(ranked, func(a, b int) bool {
+		i, j := ranked[a], ranked[b]
+		ri, rj := grid.Rows[i], grid.Rows[j]
+		if oi, oj := ri.Id == "Overall", rj.Id == "Overall"; oi != oj {
+			return oi
+		}
+		if newest[i] != newest[j] {
+			return newest[i] < newest[j]
+		}
+		return ri.Name < rj.Name
+	})
+	keep := make([]bool, n)
+	for _, i := range ranked[:max] {
+		keep[i] = true
+	}
+	rows := make([]*state.Row, 0, max)
+	for i, row := range grid.Rows {
+		if keep[i] {
+			rows = append(rows, row)
+		}
 	}
-	if err = zw.Close(); err != nil {
-		return nil, fmt.Errorf("zlib closing failed: %v", err)
+	grid.Rows = rows
+	grid.DroppedRows = int32(n - max)
+}
+
+// newestFailure returns the index of the newest column in which the row failed, or math.MaxInt32 when it never failed.
+func newestFailure(row *state.Row) int {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var i int
+	for res := range result.Iter(ctx, row.Results) {
+		if result.Coalesce(res, result.IgnoreRunning) == state.Row_FAIL {
+			return i
+		}
+		i++
+	}
+	return math.MaxInt32
+}
+
+// marhshalGrid serializes a state proto into bytes compressed with the compression, such as gcs.Gzip.
+//
+// Rows stream into the compressor, so a large grid is never also held uncompressed.
+func marshalGrid(grid state.Grid, compression gcs.Compression) ([]byte, error) {
+	buf, err := compression.Compress(func(w io.Writer) error {
+		return tgstate.EncodeGrid(w, &grid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s encoding failed: %v", compression.ContentEncoding(), err)
 	}
-	return zbuf.Bytes(), nil
+	return buf, nil
 }
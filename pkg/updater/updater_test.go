@@ -17,21 +17,98 @@ limitations under the License.
 package updater
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"cloud.google.com/go/storage"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/timestamp"
+	multierror "github.com/hashicorp/go-multierror"
+	"google.golang.org/api/googleapi"
 
+	"github.com/GoogleCloudPlatform/testgrid/metadata"
 	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
 	"github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+	tgstate "github.com/GoogleCloudPlatform/testgrid/util/state"
 )
 
+func TestTestGroupPath(t *testing.T) {
+	cases := []struct {
+		name   string
+		config string
+		group  string
+		want   string
+		err    bool
+	}{
+		{
+			name:   "sibling of config",
+			config: "gs://bucket/path/to/config",
+			group:  "foo",
+			want:   "gs://bucket/path/to/foo",
+		},
+		{
+			name:   "fragment and query characters are names",
+			config: "gs://bucket/config",
+			group:  "foo#bar?baz",
+			want:   "gs://bucket/foo%23bar%3Fbaz",
+		},
+		{
+			name:   "stay in bucket",
+			config: "gs://bucket/config",
+			group:  "//other/foo",
+			want:   "gs://bucket/other/foo",
+		},
+		{
+			name:   "config must be an object",
+			config: "gs://bucket",
+			group:  "foo",
+			err:    true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := gcs.ParsePath(tc.config)
+			if err != nil {
+				t.Fatalf("bad config: %v", err)
+			}
+			got, err := testGroupPath(*config, tc.group)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("testGroupPath() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("testGroupPath() failed to return an error")
+			case got.String() != tc.want:
+				t.Errorf("testGroupPath() got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestExtractRows(t *testing.T) {
 	cases := []struct {
 		name     string
 		content  string
 		metadata map[string]string
+		mapping  *configpb.TestGroup_StatusMapping
 		rows     map[string][]Row
 		err      bool
 	}{
@@ -120,6 +197,47 @@ func TestExtractRows(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "nested suite names",
+			content: `
+			  <testsuites>
+			    <testsuite name="hello">
+			      <testsuite name="nested">
+			        <testcase name="world" />
+			      </testsuite>
+			    </testsuite>
+			  </testsuites>`,
+			rows: map[string][]Row{
+				"hello.nested.world": {
+					{
+						Result: state.Row_PASS,
+						Metadata: map[string]string{
+							"Tests name": "hello.nested.world",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "skipped with a message",
+			content: `
+			  <testsuite>
+			    <testcase name="hidden"><skipped/></testcase>
+			    <testcase name="shown"><skipped message="not today"/></testcase>
+			  </testsuite>`,
+			rows: map[string][]Row{
+				"shown": {
+					{
+						Result:  state.Row_PASS_WITH_SKIPS,
+						Message: "not today",
+						Icon:    "S",
+						Metadata: map[string]string{
+							"Tests name": "shown",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "duplicate target names",
 			content: `
@@ -232,6 +350,99 @@ func TestExtractRows(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "case properties override suite properties",
+			content: `
+			  <testsuites>
+			    <testsuite name="suite">
+			      <properties>
+			        <property name="Platform" value="linux"/>
+			        <property name="Arch" value="amd64"/>
+			      </properties>
+			      <testcase name="plain"/>
+			      <testcase name="arm">
+			        <properties><property name="Arch" value="arm64"/></properties>
+			      </testcase>
+			    </testsuite>
+			    <testsuite name="other">
+			      <testcase name="none"/>
+			    </testsuite>
+			  </testsuites>`,
+			rows: map[string][]Row{
+				"suite.plain": {
+					{
+						Result:     state.Row_PASS,
+						Metadata:   map[string]string{"Tests name": "suite.plain"},
+						Properties: map[string]string{"Platform": "linux", "Arch": "amd64"},
+					},
+				},
+				"suite.arm": {
+					{
+						Result:     state.Row_PASS,
+						Metadata:   map[string]string{"Tests name": "suite.arm"},
+						Properties: map[string]string{"Platform": "linux", "Arch": "arm64"},
+					},
+				},
+				"other.none": {
+					{
+						Result:   state.Row_PASS,
+						Metadata: map[string]string{"Tests name": "other.none"},
+					},
+				},
+			},
+		},
+		{
+			name: "errors fail by default",
+			content: `
+			  <testsuite>
+			    <testcase name="infra"><error message="no quota"/></testcase>
+			  </testsuite>`,
+			rows: map[string][]Row{
+				"infra": {
+					{
+						Result:   state.Row_FAIL,
+						Icon:     "E",
+						Message:  "no quota",
+						Metadata: map[string]string{"Tests name": "infra"},
+					},
+				},
+			},
+		},
+		{
+			name: "status mapping",
+			content: `
+			  <testsuite>
+			    <testcase name="bad"><failure/></testcase>
+			    <testcase name="infra"><error/></testcase>
+			    <testcase name="skip"><skipped message="later"/></testcase>
+			  </testsuite>`,
+			mapping: &configpb.TestGroup_StatusMapping{
+				Error:   test_status.TestStatus_TOOL_FAIL,
+				Skipped: test_status.TestStatus_PASS,
+			},
+			rows: map[string][]Row{
+				"bad": {
+					{
+						Result:   state.Row_FAIL,
+						Metadata: map[string]string{"Tests name": "bad"},
+					},
+				},
+				"infra": {
+					{
+						Result:   state.Row_TOOL_FAIL,
+						Metadata: map[string]string{"Tests name": "infra"},
+					},
+				},
+				"skip": {
+					{
+						Result:   state.Row_PASS,
+						Icon:     "S",
+						Message:  "later",
+						Metadata: map[string]string{"Tests name": "skip"},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -240,7 +451,7 @@ func TestExtractRows(t *testing.T) {
 
 			suites, err := junit.Parse([]byte(tc.content))
 			if err == nil {
-				rows = extractRows(suites, tc.metadata)
+				rows = extractRows(suites, tc.metadata, defaultMaxMessage, statusMapping(configpb.TestGroup{StatusMapping: tc.mapping}))
 			}
 			switch {
 			case err == nil && tc.err:
@@ -288,6 +499,10 @@ func TestExtractRows(t *testing.T) {
 								}
 							}
 						}
+
+						if !reflect.DeepEqual(ar.Properties, er.Properties) {
+							t.Errorf("%s %d properties: actual %v != expected %v", target, i, ar.Properties, er.Properties)
+						}
 					}
 				}
 			}
@@ -309,8 +524,8 @@ func TestMarshalGrid(t *testing.T) {
 		},
 	}
 
-	b1, e1 := marshalGrid(g1)
-	b2, e2 := marshalGrid(g2)
+	b1, e1 := marshalGrid(g1, gcs.Gzip)
+	b2, e2 := marshalGrid(g2, gcs.Gzip)
 	uncompressed, e1a := proto.Marshal(&g1)
 
 	switch {
@@ -387,11 +602,11 @@ func TestAlertRow(t *testing.T) {
 					int32(state.Row_FAIL), 3,
 					int32(state.Row_PASS), 3,
 				},
-				Messages: []string{"hello", "no", "no again", "very wrong"},
-				CellIds:  []string{"yes", "no", "no again", "very wrong"},
+				Messages: []string{"fail0", "fail1", "fail2-expected", "pass3", "pass4", "pass5"},
+				CellIds:  []string{"no0", "no1", "yep", "no3", "no4", "no5"},
 			},
 			failOpen: 3,
-			expected: alertInfo(3, "hello", "yes", columns[2], columns[3]),
+			expected: alertInfo(3, "fail2-expected", "yep", columns[2], columns[3]),
 		},
 		{
 			name: "too few passes do not close",
@@ -400,12 +615,12 @@ func TestAlertRow(t *testing.T) {
 					int32(state.Row_PASS), 2,
 					int32(state.Row_FAIL), 4,
 				},
-				Messages: []string{"nope", "no", "yay", "very wrong"},
-				CellIds:  []string{"wrong", "no", "yep", "very wrong"},
+				Messages: []string{"pass0", "pass1", "fail2", "fail3", "fail4", "fail5-expected"},
+				CellIds:  []string{"no0", "no1", "no2", "no3", "no4", "yep"},
 			},
 			failOpen:  1,
 			passClose: 3,
-			expected:  alertInfo(4, "yay", "yep", columns[5], nil),
+			expected:  alertInfo(4, "fail5-expected", "yep", columns[5], nil),
 		},
 		{
 			name: "flakes do not close",
@@ -414,11 +629,11 @@ func TestAlertRow(t *testing.T) {
 					int32(state.Row_FLAKY), 2,
 					int32(state.Row_FAIL), 4,
 				},
-				Messages: []string{"nope", "no", "yay", "very wrong"},
-				CellIds:  []string{"wrong", "no", "yep", "very wrong"},
+				Messages: []string{"flaky0", "flaky1", "fail2", "fail3", "fail4", "fail5-expected"},
+				CellIds:  []string{"no0", "no1", "no2", "no3", "no4", "yep"},
 			},
 			failOpen: 1,
-			expected: alertInfo(4, "yay", "yep", columns[5], nil),
+			expected: alertInfo(4, "fail5-expected", "yep", columns[5], nil),
 		},
 		{
 			name: "count failures after flaky passes",
@@ -430,12 +645,12 @@ func TestAlertRow(t *testing.T) {
 					int32(state.Row_PASS), 1,
 					int32(state.Row_FAIL), 2,
 				},
-				Messages: []string{"nope", "no", "buu", "wrong", "this one"},
-				CellIds:  []string{"wrong", "no", "buzz", "wrong2", "good job"},
+				Messages: []string{"fail0", "flaky1", "fail2", "pass3", "fail4", "fail5-expected"},
+				CellIds:  []string{"no0", "no1", "no2", "no3", "no4", "yep"},
 			},
 			failOpen:  2,
 			passClose: 2,
-			expected:  alertInfo(4, "this one", "good job", columns[5], nil),
+			expected:  alertInfo(4, "fail5-expected", "yep", columns[5], nil),
 		},
 		{
 			name: "close alert",
@@ -455,12 +670,12 @@ func TestAlertRow(t *testing.T) {
 					int32(state.Row_NO_RESULT), 1,
 					int32(state.Row_FAIL), 4,
 				},
-				Messages: []string{"yay", "no", "buu", "wrong", "nono"},
-				CellIds:  []string{"yay-cell", "no", "buzz", "wrong2", "nada"},
+				Messages: []string{"fail0", "fail2", "fail3", "fail4", "fail5-expected"},
+				CellIds:  []string{"no0", "no2", "no3", "no4", "yep"},
 			},
 			failOpen:  5,
 			passClose: 2,
-			expected:  alertInfo(5, "yay", "yay-cell", columns[5], nil),
+			expected:  alertInfo(5, "fail5-expected", "yep", columns[5], nil),
 		},
 		{
 			name: "track passes through empty results",
@@ -482,11 +697,26 @@ func TestAlertRow(t *testing.T) {
 					int32(state.Row_RUNNING), 1,
 					int32(state.Row_FAIL), 5,
 				},
-				Messages: []string{"running0", "fail1-expected", "fail2", "fail3", "fail4", "fail5"},
-				CellIds:  []string{"wrong", "yep", "no2", "no3", "no4", "no5"},
+				Messages: []string{"running0", "fail1", "fail2", "fail3", "fail4", "fail5-expected"},
+				CellIds:  []string{"wrong", "no1", "no2", "no3", "no4", "yep"},
 			},
 			failOpen: 1,
-			expected: alertInfo(5, "fail1-expected", "yep", columns[5], nil),
+			expected: alertInfo(5, "fail5-expected", "yep", columns[5], nil),
+		},
+		{
+			name: "leading skips do not close",
+			row: state.Row{
+				Results: []int32{
+					int32(state.Row_PASS_WITH_SKIPS), 2,
+					int32(state.Row_FAIL), 3,
+					int32(state.Row_PASS), 1,
+				},
+				Messages: []string{"skip0", "skip1", "fail2", "fail3", "fail4-expected", "pass5"},
+				CellIds:  []string{"skip0", "skip1", "no2", "no3", "yep", "no5"},
+			},
+			failOpen:  3,
+			passClose: 1,
+			expected:  alertInfo(3, "fail4-expected", "yep", columns[4], columns[5]),
 		},
 	}
 
@@ -497,11 +727,86 @@ func TestAlertRow(t *testing.T) {
 	}
 }
 
+func TestRememberAlerts(t *testing.T) {
+	var columns []*state.Column
+	for i, id := range []string{"c", "b", "a"} {
+		columns = append(columns, &state.Column{
+			Build:   id,
+			Started: 100 - float64(i),
+		})
+	}
+	older := &state.Column{Build: "truncated", Started: 90}
+	passed := &state.Column{Build: "passed", Started: 80}
+	fail := int32(state.Row_FAIL)
+	pass := int32(state.Row_PASS)
+	cases := []struct {
+		name     string
+		row      *state.Row
+		previous *state.AlertInfo
+		fresh    []*state.Column
+		want     *state.AlertInfo
+	}{
+		{
+			name:     "no alert",
+			row:      &state.Row{Name: "a", Results: []int32{pass, 3}},
+			previous: alertInfo(1, "old", "old", older, passed),
+			fresh:    columns[:1],
+		},
+		{
+			name:  "new alert",
+			row:   &state.Row{Name: "a", Results: []int32{fail, 3}, AlertInfo: alertInfo(3, "new", "new", columns[2], nil)},
+			fresh: columns[:1],
+			want:  alertInfo(3, "new", "new", columns[2], nil),
+		},
+		{
+			name:     "remember the truncated first failure",
+			row:      &state.Row{Name: "a", Results: []int32{fail, 3}, AlertInfo: alertInfo(3, "new", "new", columns[2], nil)},
+			previous: alertInfo(4, "old", "old", older, passed),
+			fresh:    columns[:1],
+			want:     alertInfo(5, "old", "old", older, passed),
+		},
+		{
+			name:     "visible pass starts a new outage",
+			row:      &state.Row{Name: "a", Results: []int32{fail, 2, pass, 1}, AlertInfo: alertInfo(2, "new", "new", columns[1], columns[2])},
+			previous: alertInfo(4, "old", "old", older, passed),
+			fresh:    columns[:2],
+			want:     alertInfo(2, "new", "new", columns[1], columns[2]),
+		},
+		{
+			name:     "count only fresh columns interleaved with stored ones",
+			row:      &state.Row{Name: "a", Results: []int32{fail, 3}, AlertInfo: alertInfo(3, "new", "new", columns[2], nil)},
+			previous: alertInfo(4, "old", "old", older, passed),
+			fresh:    []*state.Column{columns[0], columns[2]},
+			want:     alertInfo(6, "old", "old", older, passed),
+		},
+		{
+			name:     "first failure still visible",
+			row:      &state.Row{Name: "a", Results: []int32{fail, 3}, AlertInfo: alertInfo(3, "new", "new", columns[2], nil)},
+			previous: alertInfo(2, "old", "old", columns[2], nil),
+			fresh:    columns[:1],
+			want:     alertInfo(3, "new", "new", columns[2], nil),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			previous := map[string]*state.AlertInfo{}
+			if tc.previous != nil {
+				previous[tc.row.Name] = tc.previous
+			}
+			rememberAlerts(columns, []*state.Row{tc.row}, previous, tc.fresh)
+			if !proto.Equal(tc.row.AlertInfo, tc.want) {
+				t.Errorf("rememberAlerts() got %v, want %v", tc.row.AlertInfo, tc.want)
+			}
+		})
+	}
+}
+
 func TestBuildID(t *testing.T) {
 	cases := []struct {
 		name     string
 		build    string
-		extra    string
+		extra    []string
 		expected string
 	}{
 		{
@@ -510,7 +815,7 @@ func TestBuildID(t *testing.T) {
 		{
 			name:     "favor extra if it exists",
 			build:    "wrong",
-			extra:    "right",
+			extra:    []string{"right"},
 			expected: "right",
 		},
 		{
@@ -518,15 +823,19 @@ func TestBuildID(t *testing.T) {
 			build:    "yes",
 			expected: "yes",
 		},
+		{
+			name:     "build if the extra is missing",
+			build:    "yes",
+			extra:    []string{"", "other"},
+			expected: "yes",
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			col := state.Column{
 				Build: tc.build,
-			}
-			if tc.extra != "" {
-				col.Extra = append(col.Extra, tc.extra)
+				Extra: tc.extra,
 			}
 			if actual := buildID(&col); actual != tc.expected {
 				t.Errorf("%q != expected %q", actual, tc.expected)
@@ -574,3 +883,1684 @@ func TestStamp(t *testing.T) {
 		})
 	}
 }
+
+// gridClient counts the grids loaded at once, from reading the stored grid of a group until writing its replacement.
+type gridClient struct {
+	*fake.Client
+	grids map[string]bool
+
+	lock   sync.Mutex
+	loaded int
+	peak   int
+}
+
+func (c *gridClient) Open(ctx context.Context, path gcs.Path) (io.ReadCloser, *storage.ReaderObjectAttrs, error) {
+	if c.grids[path.String()] {
+		c.lock.Lock()
+		c.loaded++
+		if c.loaded > c.peak {
+			c.peak = c.loaded
+		}
+		c.lock.Unlock()
+		time.Sleep(5 * time.Millisecond) // Give other workers a chance to load theirs.
+	}
+	return c.Client.Open(ctx, path)
+}
+
+func (c *gridClient) Upload(ctx context.Context, path gcs.Path, buf []byte, worldReadable bool, cacheControl string, cond storage.Conditions) (*storage.ObjectAttrs, error) {
+	return c.UploadEncoded(ctx, path, buf, worldReadable, cacheControl, "", cond)
+}
+
+func (c *gridClient) UploadEncoded(ctx context.Context, path gcs.Path, buf []byte, worldReadable bool, cacheControl, contentEncoding string, cond storage.Conditions) (*storage.ObjectAttrs, error) {
+	if c.grids[path.String()] {
+		c.lock.Lock()
+		c.loaded--
+		c.lock.Unlock()
+	}
+	return c.Client.UploadEncoded(ctx, path, buf, worldReadable, cacheControl, contentEncoding, cond)
+}
+
+func mustPath(t *testing.T, s string) gcs.Path {
+	t.Helper()
+	p, err := gcs.ParsePath(s)
+	if err != nil {
+		t.Fatalf("bad path %s: %v", s, err)
+	}
+	return *p
+}
+
+func TestUpdate(t *testing.T) {
+	const groups = 10
+	now := time.Now().Unix()
+	configPath := mustPath(t, "gs://bucket/config")
+	var cfg configpb.Configuration
+	client := gridClient{Client: &fake.Client{}, grids: map[string]bool{}}
+	for i := 0; i < groups; i++ {
+		name := fmt.Sprintf("group-%d", i)
+		cfg.TestGroups = append(cfg.TestGroups, &configpb.TestGroup{
+			Name:          name,
+			Query:         "bucket/logs/" + name,
+			DaysOfResults: 1,
+		})
+		client.grids["gs://bucket/"+name] = true
+		build := "gs://bucket/logs/" + name + "/1/"
+		client.Put(mustPath(t, build+"started.json"), []byte(fmt.Sprintf(`{"timestamp": %d}`, now)))
+		client.Put(mustPath(t, build+"finished.json"), []byte(fmt.Sprintf(`{"timestamp": %d, "passed": true, "result": "SUCCESS"}`, now+1)))
+	}
+	cfg.TestGroups = append(cfg.TestGroups, &configpb.TestGroup{Name: "invalid-prefix"}, &configpb.TestGroup{
+		Name:  "unlistable",
+		Query: "bucket/logs/unlistable",
+	})
+	client.Fail(mustPath(t, "gs://bucket/logs/unlistable/"), errors.New("injected"))
+	buf, err := proto.Marshal(&cfg)
+	if err != nil {
+		t.Fatalf("marshal config: %v", err)
+	}
+	client.Put(configPath, buf)
+
+	for _, concurrency := range []int{1, 3} {
+		t.Run(fmt.Sprintf("concurrency %d", concurrency), func(t *testing.T) {
+			client.peak = 0
+			for path := range client.grids {
+				client.Delete(mustPath(t, path))
+			}
+			_, err := Update(&client, context.Background(), configPath, Options{GroupConcurrency: concurrency, BuildConcurrency: 2, GroupTimeout: time.Minute, BuildTimeout: time.Minute, Compression: gcs.Gzip})
+			var mErr *multierror.Error
+			if !errors.As(err, &mErr) || len(mErr.Errors) != 2 {
+				t.Errorf("Update() got error %v, want the two bad groups", err)
+			}
+			for path := range client.grids {
+				if _, ok := client.Lookup(mustPath(t, path)); !ok {
+					t.Errorf("Update() failed to write %s", path)
+				}
+			}
+			if client.loaded != 0 {
+				t.Errorf("Update() left %d grids loaded", client.loaded)
+			}
+			if client.peak < 1 || client.peak > concurrency {
+				t.Errorf("Update() loaded %d grids at once, want 1 to %d", client.peak, concurrency)
+			}
+		})
+	}
+
+	if _, err := Update(&client, context.Background(), configPath, Options{GroupConcurrency: 1, BuildConcurrency: 1, GroupTimeout: time.Minute, BuildTimeout: time.Minute, Group: "group-0", Compression: gcs.Gzip}); err != nil {
+		t.Errorf("Update(group-0) got unexpected error: %v", err)
+	}
+	if _, err := Update(&client, context.Background(), configPath, Options{GroupConcurrency: 1, BuildConcurrency: 1, GroupTimeout: time.Minute, BuildTimeout: time.Minute, Group: "missing", Compression: gcs.Gzip}); err == nil {
+		t.Error("Update(missing) failed to return an error")
+	}
+}
+
+// cacheMetrics records the junit cache stats of each Update.
+type cacheMetrics struct {
+	stats []gcs.SuitesCacheStats
+}
+
+func (m *cacheMetrics) ObserveUpdate(GroupReport) {}
+
+func (m *cacheMetrics) ObserveSuitesCache(stats gcs.SuitesCacheStats) {
+	m.stats = append(m.stats, stats)
+}
+
+func TestUpdateSuitesCache(t *testing.T) {
+	now := time.Now().Unix()
+	configPath := mustPath(t, "gs://bucket/config")
+	// Groups reading the same job.
+	cfg := configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{
+			{Name: "first", Query: "bucket/logs/job", DaysOfResults: 1},
+			{Name: "second", Query: "bucket/logs/job", DaysOfResults: 1},
+		},
+	}
+	buf, err := proto.Marshal(&cfg)
+	if err != nil {
+		t.Fatalf("marshal config: %v", err)
+	}
+	setup := func() *fake.Client {
+		var client fake.Client
+		build := "gs://bucket/logs/job/1/"
+		client.Put(mustPath(t, build+"started.json"), []byte(fmt.Sprintf(`{"timestamp": %d}`, now)))
+		client.Put(mustPath(t, build+"finished.json"), []byte(fmt.Sprintf(`{"timestamp": %d, "passed": true, "result": "SUCCESS"}`, now+1)))
+		client.Put(mustPath(t, build+"artifacts/junit_01.xml"), []byte(`<testsuite><testcase name="case"/></testsuite>`))
+		client.Put(configPath, buf)
+		return &client
+	}
+
+	cases := []struct {
+		name       string
+		cacheBytes int64
+		want       []gcs.SuitesCacheStats
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:       "groups share parsed files",
+			cacheBytes: 1 << 20,
+			want:       []gcs.SuitesCacheStats{{Hits: 1, Misses: 1, Bytes: 46}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := setup()
+			var metrics cacheMetrics
+			if _, err := Update(client, context.Background(), configPath, Options{GroupConcurrency: 1, BuildConcurrency: 1, GroupTimeout: time.Minute, BuildTimeout: time.Minute, CacheBytes: tc.cacheBytes, Compression: gcs.Gzip, Metrics: &metrics}); err != nil {
+				t.Fatalf("Update() got unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(metrics.stats, tc.want) {
+				t.Errorf("Update() got cache stats %+v, want %+v", metrics.stats, tc.want)
+			}
+			for _, tg := range cfg.TestGroups {
+				grid, _, err := tgstate.ReadGrid(context.Background(), client, mustPath(t, "gs://bucket/"+tg.Name))
+				if err != nil {
+					t.Fatalf("ReadGrid(%s) got unexpected error: %v", tg.Name, err)
+				}
+				var rows []string
+				for _, r := range grid.Rows {
+					rows = append(rows, r.Name)
+				}
+				if want := []string{"Overall", "case"}; !reflect.DeepEqual(rows, want) {
+					t.Errorf("Update() wrote %s rows %v, want %v", tg.Name, rows, want)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateGroupLocal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "builds")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	now := time.Now().Unix()
+	junitXML := `<testsuite><testcase name="good"/><testcase name="bad"><failure>boom</failure></testcase></testsuite>`
+	for i, passed := range []bool{true, false} {
+		build := filepath.Join(dir, "logs", "job", fmt.Sprint(i+1))
+		files := map[string]string{
+			"started.json":            fmt.Sprintf(`{"timestamp": %d}`, now+int64(i)),
+			"finished.json":           fmt.Sprintf(`{"timestamp": %d, "passed": %t}`, now+int64(i)+1, passed),
+			"artifacts/junit_01.xml":  junitXML,
+			"artifacts/build-log.txt": "not junit",
+		}
+		for name, content := range files {
+			name = filepath.Join(build, filepath.FromSlash(name))
+			if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+				t.Fatalf("MkdirAll: %v", err)
+			}
+			if err := ioutil.WriteFile(name, []byte(content), 0644); err != nil {
+				t.Fatalf("WriteFile: %v", err)
+			}
+		}
+	}
+
+	ctx := context.Background()
+	var client fake.Client
+	tg := configpb.TestGroup{
+		Name:          "local",
+		Query:         "file://" + filepath.ToSlash(dir) + "/logs/job",
+		DaysOfResults: 1,
+	}
+	gridPath := mustPath(t, "gs://bucket/local")
+	var report GroupReport
+	if err := updateGroup(ctx, &client, tg, gridPath, Options{BuildConcurrency: 2, GroupTimeout: time.Minute, BuildTimeout: time.Minute, Compression: gcs.Gzip}, nil, &report); err != nil {
+		t.Fatalf("updateGroup() got unexpected error: %v", err)
+	}
+	grid, _, err := tgstate.ReadGrid(ctx, &client, gridPath)
+	if err != nil {
+		t.Fatalf("ReadGrid() got unexpected error: %v", err)
+	}
+	var builds []string
+	for _, col := range grid.Columns {
+		builds = append(builds, col.Build)
+	}
+	if want := []string{"2", "1"}; !reflect.DeepEqual(builds, want) {
+		t.Errorf("updateGroup() wrote columns %v, want %v", builds, want)
+	}
+	rows := map[string][]int32{}
+	for _, row := range grid.Rows {
+		rows[row.Name] = row.Results
+	}
+	pass, fail := int32(state.Row_PASS), int32(state.Row_FAIL)
+	want := map[string][]int32{
+		"Overall": {fail, 1, pass, 1},
+		"good":    {pass, 2},
+		"bad":     {fail, 2},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("updateGroup() wrote rows %v, want %v", rows, want)
+	}
+}
+
+func TestHeaders(t *testing.T) {
+	tg := configpb.TestGroup{
+		ColumnHeader: []*configpb.TestGroup_ColumnHeader{
+			{ColumnHeaderSource: &configpb.TestGroup_ColumnHeader_ConfigurationValue{ConfigurationValue: "k8s-version"}},
+			{ColumnHeaderSource: &configpb.TestGroup_ColumnHeader_Label{Label: "os"}},
+			{ColumnHeaderSource: &configpb.TestGroup_ColumnHeader_Property{Property: "zone"}},
+		},
+	}
+	if got, want := Headers(tg), []string{"k8s-version", "os", "zone"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Headers() got %q, want %q", got, want)
+	}
+}
+
+func TestColumnMetadata(t *testing.T) {
+	started := metadata.Started{Metadata: metadata.Metadata{"node": "old", "image": "cos"}}
+	finished := metadata.Finished{Metadata: metadata.Metadata{"node": "new", "version": "v1", "repos": map[string]interface{}{"a": "b"}}}
+	want := ColumnMetadata{"node": "new", "image": "cos", "version": "v1"}
+	if got := columnMetadata(started, finished); !reflect.DeepEqual(got, want) {
+		t.Errorf("columnMetadata() got %v, want %v", got, want)
+	}
+	if got := columnMetadata(metadata.Started{}, metadata.Finished{}); len(got) != 0 {
+		t.Errorf("columnMetadata() of no metadata got %v, want empty", got)
+	}
+}
+
+func TestAnnotateColumn(t *testing.T) {
+	meta := ColumnMetadata{
+		"cluster": "build01",
+		"zone":    "us-east1-b",
+		"empty":   "",
+		"huge":    strings.Repeat("x", maxAnnotationBytes),
+	}
+	cases := []struct {
+		name string
+		keys []string
+		want map[string]string
+	}{
+		{
+			name: "no annotations",
+		},
+		{
+			name: "copy the listed keys",
+			keys: []string{"zone", "cluster"},
+			want: map[string]string{"cluster": "build01", "zone": "us-east1-b"},
+		},
+		{
+			name: "skip missing keys and keep empty values",
+			keys: []string{"missing", "empty", "cluster"},
+			want: map[string]string{"empty": "", "cluster": "build01"},
+		},
+		{
+			name: "stop at the first key over the budget",
+			keys: []string{"cluster", "huge", "zone"},
+			want: map[string]string{"cluster": "build01"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var col state.Column
+			annotateColumn(&col, meta, tc.keys)
+			if !reflect.DeepEqual(col.Metadata, tc.want) {
+				t.Errorf("annotateColumn() got %v, want %v", col.Metadata, tc.want)
+			}
+		})
+	}
+}
+
+func TestUpdateGroupHeaders(t *testing.T) {
+	ctx := context.Background()
+	var client fake.Client
+	now := time.Now().Unix()
+	addBuild := func(id int, started, finished string) {
+		build := fmt.Sprintf("gs://bucket/logs/job/%d/", id)
+		client.Put(mustPath(t, build+"started.json"), []byte(fmt.Sprintf(`{"timestamp": %d, %s}`, now+int64(id), started)))
+		client.Put(mustPath(t, build+"finished.json"), []byte(fmt.Sprintf(`{"timestamp": %d, "passed": true, %s}`, now+int64(id)+1, finished)))
+	}
+	addBuild(1, `"metadata": {"node_os_image": "cos-1"}`, `"metadata": {"k8s-version": "v1"}`)
+
+	tg := configpb.TestGroup{
+		Name:          "job",
+		Query:         "bucket/logs/job",
+		DaysOfResults: 1,
+	}
+	gridPath := mustPath(t, "gs://bucket/job")
+	var report GroupReport
+	if err := updateGroup(ctx, &client, tg, gridPath, Options{BuildConcurrency: 1, GroupTimeout: time.Minute, BuildTimeout: time.Minute, Compression: gcs.Gzip}, nil, &report); err != nil {
+		t.Fatalf("updateGroup() got unexpected error: %v", err)
+	}
+
+	// Configure headers after storing the first column.
+	for _, key := range []string{"k8s-version", "node_os_image", "missing"} {
+		tg.ColumnHeader = append(tg.ColumnHeader, &configpb.TestGroup_ColumnHeader{
+			ColumnHeaderSource: &configpb.TestGroup_ColumnHeader_ConfigurationValue{ConfigurationValue: key},
+		})
+	}
+	addBuild(2, `"metadata": {"node_os_image": "cos-1"}`, `"metadata": {"k8s-version": "v2", "node_os_image": "cos-2"}`)
+	if err := updateGroup(ctx, &client, tg, gridPath, Options{BuildConcurrency: 1, GroupTimeout: time.Minute, BuildTimeout: time.Minute, Compression: gcs.Gzip}, nil, &report); err != nil {
+		t.Fatalf("updateGroup() got unexpected error: %v", err)
+	}
+	if report.Columns != 1 {
+		t.Errorf("updateGroup() read %d columns, want 1 incremental column", report.Columns)
+	}
+	grid, _, err := tgstate.ReadGrid(ctx, &client, gridPath)
+	if err != nil {
+		t.Fatalf("ReadGrid() got unexpected error: %v", err)
+	}
+	got := map[string][]string{}
+	for _, col := range grid.Columns {
+		got[col.Build] = col.Extra
+	}
+	want := map[string][]string{
+		"2": {"v2", "cos-2", ""},
+		"1": {"", "", ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("updateGroup() wrote column headers %q, want %q", got, want)
+	}
+}
+
+func TestAppendColumnPending(t *testing.T) {
+	pass, none, running := int32(state.Row_PASS), int32(state.Row_NO_RESULT), int32(state.Row_RUNNING)
+	cols := []Column{
+		{
+			ID:      "3",
+			Started: 300,
+			Rows: map[string][]Row{
+				"Overall": {testResult("Overall", state.Row_RUNNING, 0)},
+			},
+		},
+		{
+			ID:       "2",
+			Started:  200,
+			Finished: 201,
+			Rows: map[string][]Row{
+				"Overall": {testResult("Overall", state.Row_PASS, 0)},
+				"a":       {testResult("a", state.Row_PASS, 0)},
+			},
+		},
+		{
+			ID:       "1",
+			Started:  100,
+			Finished: 101,
+			Rows: map[string][]Row{
+				"Overall": {testResult("Overall", state.Row_PASS, 0)},
+				"a":       {testResult("a", state.Row_PASS, 0)},
+				"b":       {testResult("b", state.Row_PASS, 0)},
+			},
+		},
+	}
+	grid := &state.Grid{}
+	rows := map[string]*state.Row{}
+	for _, c := range cols {
+		appendColumn(grid, nil, makeNameConfig(nil), rows, c, false)
+	}
+	got := map[string][]int32{}
+	for name, r := range rows {
+		got[name] = r.Results
+		if len(r.Messages) != len(r.CellIds)-countNoResults(r.Results) {
+			t.Errorf("row %s has %d messages for %d cells", name, len(r.Messages), len(r.CellIds))
+		}
+	}
+	want := map[string][]int32{
+		"Overall": {running, 1, pass, 2},
+		"a":       {running, 1, pass, 2},
+		"b":       {running, 1, none, 1, pass, 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("appendColumn() got rows %v, want %v", got, want)
+	}
+}
+
+// countNoResults returns the number of cells without a result in the run-length encoded results.
+func countNoResults(results []int32) int {
+	var n int
+	for i := 0; i+1 < len(results); i += 2 {
+		if results[i] == int32(state.Row_NO_RESULT) {
+			n += int(results[i+1])
+		}
+	}
+	return n
+}
+
+func TestUpdateGroupPending(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Unix()
+	junitXML := `<testsuite><testcase name="good"/></testsuite>`
+	put := func(client *fake.Client, id int, files ...string) {
+		build := fmt.Sprintf("gs://bucket/logs/job/%d/", id)
+		for _, f := range files {
+			var content string
+			switch f {
+			case "started.json":
+				content = fmt.Sprintf(`{"timestamp": %d}`, now+int64(id))
+			case "finished.json":
+				content = fmt.Sprintf(`{"timestamp": %d, "passed": true}`, now+int64(id)+1)
+			default:
+				content = junitXML
+			}
+			client.Put(mustPath(t, build+f), []byte(content))
+		}
+	}
+	pass, running := int32(state.Row_PASS), int32(state.Row_RUNNING)
+
+	cases := []struct {
+		name          string
+		ignorePending bool
+		builds        []string
+		rows          map[string][]int32
+	}{
+		{
+			name:   "pending column",
+			builds: []string{"2", "1"},
+			rows: map[string][]int32{
+				"Overall": {running, 1, pass, 1},
+				"good":    {running, 1, pass, 1},
+			},
+		},
+		{
+			name:          "ignore pending",
+			ignorePending: true,
+			builds:        []string{"1"},
+			rows: map[string][]int32{
+				"Overall": {pass, 1},
+				"good":    {pass, 1},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var client fake.Client
+			put(&client, 1, "started.json", "finished.json", "artifacts/junit_01.xml")
+			put(&client, 2, "started.json")
+			tg := configpb.TestGroup{
+				Name:          "job",
+				Query:         "bucket/logs/job",
+				DaysOfResults: 1,
+				IgnorePending: tc.ignorePending,
+			}
+			gridPath := mustPath(t, "gs://bucket/job")
+			read := func() (builds []string, rows map[string][]int32) {
+				var report GroupReport
+				if err := updateGroup(ctx, &client, tg, gridPath, Options{BuildConcurrency: 1, GroupTimeout: time.Minute, BuildTimeout: time.Minute, Compression: gcs.Gzip}, nil, &report); err != nil {
+					t.Fatalf("updateGroup() got unexpected error: %v", err)
+				}
+				grid, _, err := tgstate.ReadGrid(ctx, &client, gridPath)
+				if err != nil {
+					t.Fatalf("ReadGrid() got unexpected error: %v", err)
+				}
+				for _, col := range grid.Columns {
+					builds = append(builds, col.Build)
+				}
+				rows = map[string][]int32{}
+				for _, row := range grid.Rows {
+					rows[row.Name] = row.Results
+				}
+				return builds, rows
+			}
+
+			builds, rows := read()
+			if !reflect.DeepEqual(builds, tc.builds) {
+				t.Errorf("updateGroup() wrote columns %v, want %v", builds, tc.builds)
+			}
+			if !reflect.DeepEqual(rows, tc.rows) {
+				t.Errorf("updateGroup() wrote rows %v, want %v", rows, tc.rows)
+			}
+
+			// Once the build finishes, its column replaces the pending one.
+			put(&client, 2, "finished.json", "artifacts/junit_01.xml")
+			builds, rows = read()
+			if want := []string{"2", "1"}; !reflect.DeepEqual(builds, want) {
+				t.Errorf("updateGroup() wrote finished columns %v, want %v", builds, want)
+			}
+			want := map[string][]int32{
+				"Overall": {pass, 2},
+				"good":    {pass, 2},
+			}
+			if !reflect.DeepEqual(rows, want) {
+				t.Errorf("updateGroup() wrote finished rows %v, want %v", rows, want)
+			}
+		})
+	}
+}
+
+func TestUpdateGroupMessages(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Unix()
+	long := strings.Repeat("x", 200)
+	cases := []struct {
+		name  string
+		max   int32
+		wants []string
+	}{
+		{
+			name:  "default",
+			wants: []string{"expected 1, got 2: at foo.go:12", "boom: " + long[:defaultMaxMessage-len("boom: ")]},
+		},
+		{
+			name:  "configured",
+			max:   10,
+			wants: []string{"expected 1", "boom: xxxx"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var client fake.Client
+			build := "gs://bucket/logs/job/1/"
+			client.Put(mustPath(t, build+"started.json"), []byte(fmt.Sprintf(`{"timestamp": %d}`, now)))
+			client.Put(mustPath(t, build+"finished.json"), []byte(fmt.Sprintf(`{"timestamp": %d, "passed": false}`, now+1)))
+			client.Put(mustPath(t, build+"artifacts/junit_01.xml"), []byte(`<testsuite>
+				<testcase name="assert"><failure message="expected 1, got 2">
+					at foo.go:12
+				</failure></testcase>
+				<testcase name="long"><failure message="boom"><![CDATA[`+long+`]]></failure></testcase>
+			</testsuite>`))
+			tg := configpb.TestGroup{
+				Name:             "job",
+				Query:            "bucket/logs/job",
+				DaysOfResults:    1,
+				MaxMessageLength: tc.max,
+			}
+			gridPath := mustPath(t, "gs://bucket/job")
+			var report GroupReport
+			if err := updateGroup(ctx, &client, tg, gridPath, Options{BuildConcurrency: 1, GroupTimeout: time.Minute, BuildTimeout: time.Minute, Compression: gcs.Gzip}, nil, &report); err != nil {
+				t.Fatalf("updateGroup() got unexpected error: %v", err)
+			}
+			grid, _, err := tgstate.ReadGrid(ctx, &client, gridPath)
+			if err != nil {
+				t.Fatalf("ReadGrid() got unexpected error: %v", err)
+			}
+			var got []string
+			for _, name := range []string{"assert", "long"} {
+				for _, row := range grid.Rows {
+					if row.Name == name {
+						got = append(got, row.Messages...)
+					}
+				}
+			}
+			if !reflect.DeepEqual(got, tc.wants) {
+				t.Errorf("updateGroup() wrote messages %q, want %q", got, tc.wants)
+			}
+		})
+	}
+}
+
+func TestShortText(t *testing.T) {
+	cases := map[string]string{
+		"":    "",
+		"F":   "F",
+		"TF":  "TF",
+		"TFX": "TF",
+		"日本語": "日本",
+	}
+	for text, want := range cases {
+		if got := shortText(text); got != want {
+			t.Errorf("shortText(%q) got %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestUpdateGroupProperties(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Unix()
+	var client fake.Client
+	put := func(id int, junitXML string) {
+		build := fmt.Sprintf("gs://bucket/logs/job/%d/", id)
+		client.Put(mustPath(t, build+"started.json"), []byte(fmt.Sprintf(`{"timestamp": %d}`, now+int64(id))))
+		client.Put(mustPath(t, build+"finished.json"), []byte(fmt.Sprintf(`{"timestamp": %d, "passed": true}`, now+int64(id)+1)))
+		client.Put(mustPath(t, build+"artifacts/junit_01.xml"), []byte(junitXML))
+	}
+	// Build 2 retries the test with conflicting properties, and the failing attempt wins. Build 1 has none.
+	put(1, `<testsuite><testcase name="retry"/></testsuite>`)
+	put(2, `<testsuite>
+		<testcase name="retry">
+			<failure/>
+			<properties>
+				<property name="testgrid-short-text" value="first"/>
+				<property name="testgrid-link" value="https://first"/>
+			</properties>
+		</testcase>
+		<testcase name="retry">
+			<properties>
+				<property name="testgrid-short-text" value="ignored"/>
+				<property name="testgrid-short-text" value="T"/>
+				<property name="testgrid-link" value="https://second"/>
+			</properties>
+		</testcase>
+	</testsuite>`)
+
+	tg := configpb.TestGroup{
+		Name:          "job",
+		Query:         "bucket/logs/job",
+		DaysOfResults: 1,
+	}
+	gridPath := mustPath(t, "gs://bucket/job")
+	var report GroupReport
+	if err := updateGroup(ctx, &client, tg, gridPath, Options{BuildConcurrency: 1, GroupTimeout: time.Minute, BuildTimeout: time.Minute, Compression: gcs.Gzip}, nil, &report); err != nil {
+		t.Fatalf("updateGroup() got unexpected error: %v", err)
+	}
+	grid, _, err := tgstate.ReadGrid(ctx, &client, gridPath)
+	if err != nil {
+		t.Fatalf("ReadGrid() got unexpected error: %v", err)
+	}
+	type cells struct {
+		icons []string
+		props []map[string]string
+	}
+	got := map[string]cells{}
+	for _, row := range grid.Rows {
+		var c cells
+		c.icons = row.Icons
+		for _, p := range row.Properties {
+			c.props = append(c.props, p.Property)
+		}
+		got[row.Name] = c
+	}
+	want := map[string]cells{
+		"Overall": {icons: []string{"", ""}},
+		"retry": {
+			icons: []string{"fi", ""},
+			props: []map[string]string{{LinkKey: "https://first", AttemptsKey: "2"}, nil},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("updateGroup() wrote cells %+v, want %+v", got, want)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	r := Row{
+		Metadata:   map[string]string{"Tests name": "case", "Context": "runner", "Empty": ""},
+		Properties: map[string]string{"Platform": "linux", "Context": "property"},
+	}
+	meta := map[string]string{"Platform": "build", "Commit": "abc", "Context": "build"}
+	cases := []struct {
+		name   string
+		config *configpb.TestNameConfig
+		want   string
+	}{
+		{
+			name: "default",
+			want: "case",
+		},
+		{
+			name: "properties first",
+			config: &configpb.TestNameConfig{
+				NameFormat: "%s [%s]",
+				NameElements: []*configpb.TestNameConfig_NameElement{
+					{TargetConfig: "Tests name"},
+					{TargetConfig: "Platform"},
+				},
+			},
+			want: "case [linux]",
+		},
+		{
+			name: "properties override result metadata",
+			config: &configpb.TestNameConfig{
+				NameFormat:   "%s",
+				NameElements: []*configpb.TestNameConfig_NameElement{{TargetConfig: "Context"}},
+			},
+			want: "property",
+		},
+		{
+			name: "build metadata",
+			config: &configpb.TestNameConfig{
+				NameFormat:   "%s@%s",
+				NameElements: []*configpb.TestNameConfig_NameElement{{TargetConfig: "Tests name"}, {TargetConfig: "Commit"}},
+			},
+			want: "case@abc",
+		},
+		{
+			name: "test property",
+			config: &configpb.TestNameConfig{
+				NameFormat:   "%s on %s",
+				NameElements: []*configpb.TestNameConfig_NameElement{{TargetConfig: "Tests name"}, {TestProperty: "Platform"}},
+			},
+			want: "case on linux",
+		},
+		{
+			name: "missing and empty",
+			config: &configpb.TestNameConfig{
+				NameFormat:   "%s [%s] [%s]",
+				NameElements: []*configpb.TestNameConfig_NameElement{{TargetConfig: "Tests name"}, {TargetConfig: "Arch"}, {TargetConfig: "Empty"}},
+			},
+			want: "case [" + MissingNameElement + "] []",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := r.Format(makeNameConfig(tc.config), meta); got != tc.want {
+				t.Errorf("Format() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAppendColumnNameElements(t *testing.T) {
+	suites, err := junit.Parse([]byte(`
+	  <testsuites>
+	    <testsuite name="s1">
+	      <properties><property name="Platform" value="linux"/></properties>
+	      <testcase name="a"><properties><property name="Arch" value="amd64"/></properties><failure/></testcase>
+	      <testcase name="b"><properties><property name="Arch" value="amd64"/></properties></testcase>
+	      <testcase name="c"><properties><property name="Arch" value="arm64"/></properties></testcase>
+	    </testsuite>
+	    <testsuite name="s2">
+	      <testcase name="d"/>
+	      <testcase name="e"><failure/></testcase>
+	    </testsuite>
+	  </testsuites>`))
+	if err != nil {
+		t.Fatalf("Parse() got unexpected error: %v", err)
+	}
+	col := Column{
+		ID:       "1",
+		Started:  1,
+		Finished: 2,
+		Rows:     extractRows(suites, nil, defaultMaxMessage, defaultStatuses),
+		Metadata: ColumnMetadata{"Platform": "mac"},
+	}
+	nameCfg := makeNameConfig(&configpb.TestNameConfig{
+		NameFormat: "%s (%s)",
+		NameElements: []*configpb.TestNameConfig_NameElement{
+			{TargetConfig: "Platform"},
+			{TestProperty: "Arch"},
+		},
+	})
+	grid := &state.Grid{}
+	appendColumn(grid, nil, nameCfg, map[string]*state.Row{}, col, false)
+
+	type cell struct {
+		result   state.Row_Result
+		attempts string
+	}
+	got := map[string]cell{}
+	for _, row := range grid.Rows {
+		var c cell
+		c.result = state.Row_Result(row.Results[0])
+		if len(row.Properties) > 0 {
+			c.attempts = row.Properties[0].Property[AttemptsKey]
+		}
+		got[row.Name] = c
+	}
+	want := map[string]cell{
+		// s1.a and s1.b collapse, and the failure wins.
+		"linux (amd64)": {result: state.Row_FAIL, attempts: "2"},
+		"linux (arm64)": {result: state.Row_PASS},
+		// s2.d and s2.e collapse after the missing Arch.
+		"mac (" + MissingNameElement + ")": {result: state.Row_FAIL, attempts: "2"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("appendColumn() got rows %+v, want %+v", got, want)
+	}
+}
+
+func TestMergeResults(t *testing.T) {
+	pass := func(msg string) Row {
+		return Row{Result: state.Row_PASS, Message: msg, Metadata: map[string]string{"Tests name": "a"}}
+	}
+	fail := func(msg string) Row {
+		return Row{Result: state.Row_FAIL, Message: msg, Metadata: map[string]string{"Tests name": "a"}}
+	}
+	attempts := func(r Row, n string) Row {
+		r.Metadata = map[string]string{"Tests name": "a", AttemptsKey: n}
+		return r
+	}
+	cases := []struct {
+		name     string
+		results  []Row
+		lastWins bool
+		want     Row
+	}{
+		{
+			name:    "single attempt",
+			results: []Row{fail("only")},
+			want:    fail("only"),
+		},
+		{
+			name:    "fail wins",
+			results: []Row{pass("first"), fail("second"), pass("third")},
+			want:    attempts(fail("second"), "3"),
+		},
+		{
+			name:    "later attempt wins ties",
+			results: []Row{fail("first"), fail("second"), pass("third")},
+			want:    attempts(fail("second"), "3"),
+		},
+		{
+			name:     "last wins",
+			results:  []Row{fail("first"), pass("second")},
+			lastWins: true,
+			want:     attempts(pass("second"), "2"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := mergeResults(tc.results, tc.lastWins); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("mergeResults() got %#v, want %#v", got, tc.want)
+			}
+			for _, r := range tc.results {
+				if _, ok := r.Metadata[AttemptsKey]; ok {
+					t.Errorf("mergeResults() modified the metadata of %#v", r)
+				}
+			}
+		})
+	}
+}
+
+func TestAppendColumnMergesAttempts(t *testing.T) {
+	suite := func(results ...string) junit.Suites {
+		var s junit.Suite
+		for i, res := range results {
+			r := junit.Result{Name: "retry", Time: float64(i + 1)}
+			if res == "fail" {
+				msg := "attempt " + strconv.Itoa(i)
+				r.Failure = &msg
+			}
+			s.Results = append(s.Results, r)
+		}
+		return junit.Suites{Suites: []junit.Suite{s}}
+	}
+	parts := func() []gcs.SuitesMeta {
+		return []gcs.SuitesMeta{
+			{Path: "artifacts/junit_01.xml", Suites: suite("pass", "fail")},
+			{Path: "artifacts/junit_02.xml", Suites: suite("fail", "pass")},
+			{Path: "artifacts/junit_10.xml", Suites: suite("pass")},
+			{Path: "artifacts/junit_other.xml", Suites: junit.Suites{Suites: []junit.Suite{{Results: []junit.Result{{Name: "other"}}}}}},
+		}
+	}
+
+	for _, lastWins := range []bool{false, true} {
+		t.Run(fmt.Sprintf("last wins %t", lastWins), func(t *testing.T) {
+			read := func(parts []gcs.SuitesMeta) *state.Grid {
+				grid := &state.Grid{}
+				col := Column{ID: "1", Started: 1, Finished: 2, Rows: artifactRows(parts, defaultMaxMessage, defaultStatuses)}
+				appendColumn(grid, nil, makeNameConfig(nil), map[string]*state.Row{}, col, lastWins)
+				return grid
+			}
+			want := read(parts())
+			if len(want.Rows) != 2 {
+				t.Fatalf("appendColumn() got %d rows, want 2: %v", len(want.Rows), want.Rows)
+			}
+			var retry *state.Row
+			for _, r := range want.Rows {
+				if r.Name == "retry" {
+					retry = r
+				}
+			}
+			if retry == nil {
+				t.Fatalf("appendColumn() got no retry row: %v", want.Rows)
+			}
+			// The failure in junit_02.xml is later than the one in junit_01.xml.
+			wantMsg, wantResult := "attempt 0", int32(state.Row_FAIL)
+			if lastWins {
+				wantMsg, wantResult = "", int32(state.Row_PASS)
+			}
+			if retry.Messages[0] != wantMsg || retry.Results[0] != wantResult {
+				t.Errorf("appendColumn() got %v, want %s with message %q", retry, state.Row_Result(wantResult), wantMsg)
+			}
+			if got := retry.Properties[0].Property[AttemptsKey]; got != "5" {
+				t.Errorf("appendColumn() got %q attempts, want 5", got)
+			}
+
+			rnd := rand.New(rand.NewSource(1))
+			for i := 0; i < 20; i++ {
+				shuffled := parts()
+				rnd.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+				if got := read(shuffled); !proto.Equal(got, want) {
+					t.Fatalf("appendColumn() of %v got %v, want %v", shuffled, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateGroupRetention(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	put := func(client *fake.Client, id int, started time.Time) {
+		build := fmt.Sprintf("gs://bucket/logs/job/%d/", id)
+		client.Put(mustPath(t, build+"started.json"), []byte(fmt.Sprintf(`{"timestamp": %d}`, started.Unix())))
+		client.Put(mustPath(t, build+"finished.json"), []byte(fmt.Sprintf(`{"timestamp": %d, "passed": true}`, started.Unix()+1)))
+		client.Put(mustPath(t, build+"artifacts/junit_01.xml"), []byte(fmt.Sprintf(`<testsuite><testcase name="only-%d"/></testsuite>`, id)))
+	}
+	// A sparse job: two builds within a day, then one every 30 days.
+	days := func(n int) time.Time { return now.Add(-Days(float64(n))) }
+	starts := []time.Time{days(180), days(150), days(120), days(90), days(60), days(30), now.Add(-2 * time.Hour), now.Add(-time.Hour)}
+
+	cases := []struct {
+		name   string
+		recent int32
+		first  []string
+		want   []string
+	}{
+		{
+			name:  "days keeps the first older build",
+			first: []string{"8", "7", "6"},
+			want:  []string{"9", "8", "7", "6"},
+		},
+		{
+			name:   "recent keeps older builds",
+			recent: 5,
+			first:  []string{"8", "7", "6", "5", "4"},
+			want:   []string{"9", "8", "7", "6", "5"},
+		},
+		{
+			name:   "recent beyond the builds",
+			recent: 20,
+			first:  []string{"8", "7", "6", "5", "4", "3", "2", "1"},
+			want:   []string{"9", "8", "7", "6", "5", "4", "3", "2", "1"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var client fake.Client
+			for i, started := range starts {
+				put(&client, i+1, started)
+			}
+			tg := configpb.TestGroup{
+				Name:             "job",
+				Query:            "bucket/logs/job",
+				DaysOfResults:    1,
+				NumColumnsRecent: tc.recent,
+			}
+			gridPath := mustPath(t, "gs://bucket/job")
+			read := func() ([]string, []string) {
+				var report GroupReport
+				if err := updateGroup(ctx, &client, tg, gridPath, Options{BuildConcurrency: 2, GroupTimeout: time.Minute, BuildTimeout: time.Minute, Compression: gcs.Gzip}, nil, &report); err != nil {
+					t.Fatalf("updateGroup() got unexpected error: %v", err)
+				}
+				grid, _, err := tgstate.ReadGrid(ctx, &client, gridPath)
+				if err != nil {
+					t.Fatalf("ReadGrid() got unexpected error: %v", err)
+				}
+				var builds, rows []string
+				for _, c := range grid.Columns {
+					builds = append(builds, c.Build)
+				}
+				for _, r := range grid.Rows {
+					rows = append(rows, r.Name)
+				}
+				return builds, rows
+			}
+			wantRows := func(builds []string) []string {
+				rows := []string{"Overall"}
+				for i := len(builds) - 1; i >= 0; i-- {
+					rows = append(rows, "only-"+builds[i])
+				}
+				return rows
+			}
+
+			// Build the grid, without build 9.
+			builds, rows := read()
+			if !reflect.DeepEqual(builds, tc.first) {
+				t.Errorf("updateGroup() wrote columns %v, want %v", builds, tc.first)
+			}
+			if want := wantRows(tc.first); !reflect.DeepEqual(rows, want) {
+				t.Errorf("updateGroup() wrote rows %v, want %v", rows, want)
+			}
+
+			// Update the grid incrementally with build 9.
+			put(&client, 9, now)
+			builds, rows = read()
+			if !reflect.DeepEqual(builds, tc.want) {
+				t.Errorf("updateGroup() incrementally wrote columns %v, want %v", builds, tc.want)
+			}
+			if want := wantRows(tc.want); !reflect.DeepEqual(rows, want) {
+				t.Errorf("updateGroup() incrementally wrote rows %v, want %v", rows, want)
+			}
+		})
+	}
+}
+
+func TestUpdateGroupAlerts(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	var client fake.Client
+	put := func(id int, started time.Time, passed bool) {
+		build := fmt.Sprintf("gs://bucket/logs/job/%d/", id)
+		client.Put(mustPath(t, build+"started.json"), []byte(fmt.Sprintf(`{"timestamp": %d}`, started.Unix())))
+		client.Put(mustPath(t, build+"finished.json"), []byte(fmt.Sprintf(`{"timestamp": %d, "passed": %t}`, started.Unix()+1, passed)))
+		junit := `<testsuite><testcase name="test"/></testsuite>`
+		if !passed {
+			junit = fmt.Sprintf(`<testsuite><testcase name="test"><failure>boom %d</failure></testcase></testsuite>`, id)
+		}
+		client.Put(mustPath(t, build+"artifacts/junit_01.xml"), []byte(junit))
+	}
+	days := func(n int) time.Time { return now.Add(-Days(float64(n))) }
+	put(1, days(4), true)
+	put(2, days(3), false)
+	put(3, days(2), false)
+
+	tg := configpb.TestGroup{
+		Name:               "job",
+		Query:              "bucket/logs/job",
+		DaysOfResults:      1,
+		NumColumnsRecent:   3,
+		NumFailuresToAlert: 3,
+	}
+	gridPath := mustPath(t, "gs://bucket/job")
+	alert := func() *state.AlertInfo {
+		var report GroupReport
+		if err := updateGroup(ctx, &client, tg, gridPath, Options{BuildConcurrency: 1, GroupTimeout: time.Minute, BuildTimeout: time.Minute, Compression: gcs.Gzip}, nil, &report); err != nil {
+			t.Fatalf("updateGroup() got unexpected error: %v", err)
+		}
+		grid, _, err := tgstate.ReadGrid(ctx, &client, gridPath)
+		if err != nil {
+			t.Fatalf("ReadGrid() got unexpected error: %v", err)
+		}
+		for _, r := range grid.Rows {
+			if r.Name == "test" {
+				return r.AlertInfo
+			}
+		}
+		t.Fatalf("updateGroup() did not write the test row")
+		return nil
+	}
+
+	if got := alert(); got != nil {
+		t.Errorf("updateGroup() got alert %v before %d failures", got, tg.NumFailuresToAlert)
+	}
+
+	// Lowering the threshold opens the alert without any new build.
+	tg.NumFailuresToAlert = 2
+	got := alert()
+	if got.GetFailCount() != 2 || got.FailBuildId != "2" || got.PassBuildId != "1" || got.FailureMessage != "boom 2" {
+		t.Errorf("updateGroup() got alert %v, want 2 failures since build 2 after build 1 passed", got)
+	}
+
+	// Truncating the grid drops the first failure and the pass, but the alert remembers them.
+	tg.NumColumnsRecent = 2
+	put(4, now, false)
+	got = alert()
+	if got.GetFailCount() != 3 || got.FailBuildId != "2" || got.PassBuildId != "1" || got.FailureMessage != "boom 2" {
+		t.Errorf("updateGroup() got alert %v, want 3 failures since build 2 after build 1 passed", got)
+	}
+}
+
+func TestUpdateGroupMalformedBuilds(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Unix()
+	setup := func() *fake.Client {
+		var client fake.Client
+		put := func(id int, name, content string) {
+			client.Put(mustPath(t, fmt.Sprintf("gs://bucket/logs/job/%d/%s", id, name)), []byte(content))
+		}
+		for id := 1; id <= 5; id++ {
+			started := now - int64(10*(5-id))
+			put(id, "started.json", fmt.Sprintf(`{"timestamp": %d}`, started))
+			put(id, "finished.json", fmt.Sprintf(`{"timestamp": %d, "passed": true}`, started+1))
+			put(id, "artifacts/junit_01.xml", `<testsuite><testcase name="test"/></testsuite>`)
+		}
+		put(2, "artifacts/junit_01.xml", `<testsuite><testcase name="test">`) // corrupt
+		client.Delete(mustPath(t, "gs://bucket/logs/job/3/started.json"))
+		client.Fail(mustPath(t, "gs://bucket/logs/job/4/started.json"), &googleapi.Error{Code: http.StatusForbidden, Message: "no access"})
+		return &client
+	}
+	tg := configpb.TestGroup{
+		Name:          "job",
+		Query:         "bucket/logs/job",
+		DaysOfResults: 1,
+	}
+	gridPath := mustPath(t, "gs://bucket/job")
+
+	client := setup()
+	var report GroupReport
+	if err := updateGroup(ctx, client, tg, gridPath, Options{BuildConcurrency: 2, GroupTimeout: time.Minute, BuildTimeout: time.Minute, Compression: gcs.Gzip}, nil, &report); err != nil {
+		t.Fatalf("updateGroup() got unexpected error: %v", err)
+	}
+	if n := len(report.BuildErrors); n != 3 {
+		t.Errorf("updateGroup() reported %d build errors, want 3: %v", n, report.BuildErrors)
+	}
+	grid, _, err := tgstate.ReadGrid(ctx, client, gridPath)
+	if err != nil {
+		t.Fatalf("ReadGrid() got unexpected error: %v", err)
+	}
+	var builds []string
+	for _, c := range grid.Columns {
+		builds = append(builds, c.Build)
+		broken := c.Build == "2" || c.Build == "3" || c.Build == "4"
+		if noted := c.Error != ""; noted != broken {
+			t.Errorf("updateGroup() wrote column %s with error %q, want an error %t", c.Build, c.Error, broken)
+		}
+	}
+	if want := []string{"5", "4", "3", "2", "1"}; !reflect.DeepEqual(builds, want) {
+		t.Errorf("updateGroup() wrote columns %v, want %v", builds, want)
+	}
+	for _, r := range grid.Rows {
+		want := []int32{int32(state.Row_PASS), 1, int32(state.Row_NO_RESULT), 3, int32(state.Row_PASS), 1}
+		if !reflect.DeepEqual(r.Results, want) {
+			t.Errorf("updateGroup() wrote %s results %v, want %v", r.Name, r.Results, want)
+		}
+	}
+
+	client = setup()
+	if err := updateGroup(ctx, client, tg, gridPath, Options{BuildConcurrency: 2, GroupTimeout: time.Minute, BuildTimeout: time.Minute, Strict: true, Compression: gcs.Gzip}, nil, &report); err == nil {
+		t.Error("updateGroup() failed to return an error for malformed builds in strict mode")
+	}
+}
+
+func TestUpdateGroupSymlinks(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Unix()
+	var client fake.Client
+	put := func(build, name, content string) {
+		client.Put(mustPath(t, build+name), []byte(content))
+	}
+	for i, build := range []string{"gs://bucket/logs/job/1/", "gs://other/pull/9/job/2/"} {
+		started := now - int64(10*(2-i))
+		put(build, "started.json", fmt.Sprintf(`{"timestamp": %d}`, started))
+		put(build, "finished.json", fmt.Sprintf(`{"timestamp": %d, "passed": true}`, started+1))
+		put(build, "artifacts/junit_01.xml", `<testsuite><testcase name="test"/></testsuite>`)
+	}
+	put("gs://bucket/logs/job/", "2.txt", "gs://other/pull/9/job/2\n")
+	put("gs://bucket/logs/job/", "3.txt", "gs://bucket/logs/job/2.txt")
+	put("gs://bucket/logs/job/", "4.txt", "gs://bucket/logs/job/")
+
+	cases := []struct {
+		name    string
+		follow  bool
+		builds  []string
+		results []int32
+		errors  int
+	}{
+		{
+			name:    "ignore symlinks by default",
+			builds:  []string{"1"},
+			results: []int32{int32(state.Row_PASS), 1},
+		},
+		{
+			name:    "follow one symlink",
+			follow:  true,
+			builds:  []string{"4", "3", "2", "1"},
+			results: []int32{int32(state.Row_NO_RESULT), 2, int32(state.Row_PASS), 2},
+			errors:  2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tg := configpb.TestGroup{
+				Name:           "job",
+				Query:          "bucket/logs/job",
+				DaysOfResults:  1,
+				FollowSymlinks: tc.follow,
+			}
+			gridPath := mustPath(t, "gs://bucket/grid/"+strings.ReplaceAll(tc.name, " ", "-"))
+			var report GroupReport
+			if err := updateGroup(ctx, &client, tg, gridPath, Options{BuildConcurrency: 2, GroupTimeout: time.Minute, BuildTimeout: time.Minute, Compression: gcs.Gzip}, nil, &report); err != nil {
+				t.Fatalf("updateGroup() got unexpected error: %v", err)
+			}
+			if n := len(report.BuildErrors); n != tc.errors {
+				t.Errorf("updateGroup() reported %d build errors, want %d: %v", n, tc.errors, report.BuildErrors)
+			}
+			grid, _, err := tgstate.ReadGrid(ctx, &client, gridPath)
+			if err != nil {
+				t.Fatalf("ReadGrid() got unexpected error: %v", err)
+			}
+			var builds []string
+			for _, c := range grid.Columns {
+				builds = append(builds, c.Build)
+			}
+			if !reflect.DeepEqual(builds, tc.builds) {
+				t.Errorf("updateGroup() wrote columns %v, want %v", builds, tc.builds)
+			}
+			for _, r := range grid.Rows {
+				if !reflect.DeepEqual(r.Results, tc.results) {
+					t.Errorf("updateGroup() wrote %s results %v, want %v", r.Name, r.Results, tc.results)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateGroupLayouts(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Unix()
+	var client fake.Client
+	put := func(name, content string) {
+		client.Put(mustPath(t, "gs://bucket/"+name), []byte(content))
+	}
+	for i, build := range []string{"logs/post/1/", "logs/post/2/", "pr-logs/pull/org_repo/5/pre/10/", "pr-logs/pull/org_repo/6/pre/11/"} {
+		started := now - int64(10*(4-i))
+		put(build+"started.json", fmt.Sprintf(`{"timestamp": %d}`, started))
+		put(build+"finished.json", fmt.Sprintf(`{"timestamp": %d, "passed": true}`, started+1))
+	}
+	put("logs/post/latest-build.txt", "2")
+	put("pr-logs/directory/pre/10.txt", "gs://bucket/pr-logs/pull/org_repo/5/pre/10")
+	put("pr-logs/directory/pre/11.txt", "gs://bucket/pr-logs/pull/org_repo/6/pre/11")
+	put("pr-logs/directory/pre/latest-build.txt", "11")
+
+	cases := []struct {
+		query string
+		want  [][]string
+	}{
+		{
+			query: "bucket/logs/post",
+			want:  [][]string{{"2", "post"}, {"1", "post"}},
+		},
+		{
+			query: "bucket/pr-logs/directory/pre",
+			want:  [][]string{{"11", "pre"}, {"10", "pre"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.query, func(t *testing.T) {
+			tg := configpb.TestGroup{
+				Name:          "group",
+				Query:         tc.query,
+				DaysOfResults: 1,
+				ColumnHeader: []*configpb.TestGroup_ColumnHeader{
+					{ColumnHeaderSource: &configpb.TestGroup_ColumnHeader_ConfigurationValue{ConfigurationValue: BuildKey}},
+					{ColumnHeaderSource: &configpb.TestGroup_ColumnHeader_ConfigurationValue{ConfigurationValue: JobKey}},
+				},
+			}
+			gridPath := mustPath(t, "gs://bucket/grid/"+strings.ReplaceAll(tc.query, "/", "-"))
+			var report GroupReport
+			if err := updateGroup(ctx, &client, tg, gridPath, Options{BuildConcurrency: 2, GroupTimeout: time.Minute, BuildTimeout: time.Minute, Compression: gcs.Gzip}, nil, &report); err != nil {
+				t.Fatalf("updateGroup() got unexpected error: %v", err)
+			}
+			if n := len(report.BuildErrors); n > 0 {
+				t.Errorf("updateGroup() reported build errors: %v", report.BuildErrors)
+			}
+			grid, _, err := tgstate.ReadGrid(ctx, &client, gridPath)
+			if err != nil {
+				t.Fatalf("ReadGrid() got unexpected error: %v", err)
+			}
+			var got [][]string
+			for _, c := range grid.Columns {
+				got = append(got, c.Extra)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("updateGroup() wrote column headers %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// cancelClient cancels the update once it opens the object at path.
+type cancelClient struct {
+	*fake.Client
+	path    string
+	cancel  context.CancelFunc
+	uploads int
+}
+
+func (c *cancelClient) Open(ctx context.Context, path gcs.Path) (io.ReadCloser, *storage.ReaderObjectAttrs, error) {
+	if path.String() == c.path {
+		c.cancel()
+	}
+	return c.Client.Open(ctx, path)
+}
+
+func (c *cancelClient) Upload(ctx context.Context, path gcs.Path, buf []byte, worldReadable bool, cacheControl string, cond storage.Conditions) (*storage.ObjectAttrs, error) {
+	return c.UploadEncoded(ctx, path, buf, worldReadable, cacheControl, "", cond)
+}
+
+func (c *cancelClient) UploadEncoded(ctx context.Context, path gcs.Path, buf []byte, worldReadable bool, cacheControl, contentEncoding string, cond storage.Conditions) (*storage.ObjectAttrs, error) {
+	c.uploads++
+	return c.Client.UploadEncoded(ctx, path, buf, worldReadable, cacheControl, contentEncoding, cond)
+}
+
+func TestUpdateGroupCancel(t *testing.T) {
+	now := time.Now().Unix()
+	client := cancelClient{Client: &fake.Client{}}
+	put := func(build int) {
+		prefix := fmt.Sprintf("gs://bucket/logs/job/%d/", build)
+		started := now - int64(100-build)
+		client.Put(mustPath(t, prefix+"started.json"), []byte(fmt.Sprintf(`{"timestamp": %d}`, started)))
+		client.Put(mustPath(t, prefix+"finished.json"), []byte(fmt.Sprintf(`{"timestamp": %d, "passed": true}`, started+1)))
+	}
+	tg := configpb.TestGroup{
+		Name:          "job",
+		Query:         "bucket/logs/job",
+		DaysOfResults: 1,
+	}
+	gridPath := mustPath(t, "gs://bucket/job")
+	put(1)
+	var report GroupReport
+	if err := updateGroup(context.Background(), &client, tg, gridPath, Options{BuildConcurrency: 1, GroupTimeout: time.Minute, BuildTimeout: time.Minute, Compression: gcs.Gzip}, nil, &report); err != nil {
+		t.Fatalf("updateGroup() got unexpected error: %v", err)
+	}
+	_, before, err := tgstate.ReadGridAttrs(context.Background(), &client, gridPath)
+	if err != nil {
+		t.Fatalf("ReadGridAttrs() got unexpected error: %v", err)
+	}
+
+	for build := 2; build <= 10; build++ {
+		put(build)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client.path = "gs://bucket/logs/job/5/started.json"
+	client.cancel = cancel
+	client.uploads = 0
+	if err := updateGroup(ctx, &client, tg, gridPath, Options{BuildConcurrency: 1, GroupTimeout: time.Minute, BuildTimeout: time.Minute, Compression: gcs.Gzip}, nil, &report); !errors.Is(err, context.Canceled) && !strings.Contains(fmt.Sprint(err), "interrupted") {
+		t.Errorf("updateGroup() got %v, wanted an interruption", err)
+	}
+	if client.uploads > 0 {
+		t.Errorf("updateGroup() uploaded %d objects after cancelling, wanted none", client.uploads)
+	}
+	_, after, err := tgstate.ReadGridAttrs(context.Background(), &client, gridPath)
+	if err != nil {
+		t.Fatalf("ReadGridAttrs() got unexpected error: %v", err)
+	}
+	if after.Generation != before.Generation {
+		t.Errorf("updateGroup() replaced the grid at generation %d with %d", before.Generation, after.Generation)
+	}
+}
+
+func TestUpdateGroupConditional(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Unix()
+	gridPath := mustPath(t, "gs://bucket/job")
+	var client fake.Client
+	client.Put(mustPath(t, "gs://bucket/logs/job/1/started.json"), []byte(fmt.Sprintf(`{"timestamp": %d}`, now)))
+	client.Put(mustPath(t, "gs://bucket/logs/job/1/finished.json"), []byte(fmt.Sprintf(`{"timestamp": %d, "passed": true}`, now+1)))
+	tg := configpb.TestGroup{
+		Name:          "job",
+		Query:         "bucket/logs/job",
+		DaysOfResults: 1,
+	}
+	// Another updater writes the grid while this one reads the builds.
+	racer := &writeClient{Client: &client, path: "gs://bucket/logs/job/1/finished.json", grid: gridPath}
+	var report GroupReport
+	err := updateGroup(ctx, racer, tg, gridPath, Options{BuildConcurrency: 1, GroupTimeout: time.Minute, BuildTimeout: time.Minute, Compression: gcs.Gzip}, nil, &report)
+	if !gcs.IsPreconditionFailed(err) {
+		t.Fatalf("updateGroup() got %v, want a precondition failure", err)
+	}
+	r, _, err := client.Open(ctx, gridPath)
+	if err != nil {
+		t.Fatalf("Open() got unexpected error: %v", err)
+	}
+	defer r.Close()
+	if buf, _ := ioutil.ReadAll(r); string(buf) != "other" {
+		t.Errorf("updateGroup() replaced the grid written by another updater with %d bytes", len(buf))
+	}
+}
+
+// writeClient writes the grid as another updater would, once it opens the object at path.
+type writeClient struct {
+	*fake.Client
+	path string
+	grid gcs.Path
+}
+
+func (c *writeClient) Open(ctx context.Context, path gcs.Path) (io.ReadCloser, *storage.ReaderObjectAttrs, error) {
+	if path.String() == c.path {
+		c.Client.Put(c.grid, []byte("other"))
+	}
+	return c.Client.Open(ctx, path)
+}
+
+func TestGridConditions(t *testing.T) {
+	cases := []struct {
+		name  string
+		attrs *storage.ReaderObjectAttrs
+		err   error
+		want  storage.Conditions
+	}{
+		{
+			name:  "basically works",
+			attrs: &storage.ReaderObjectAttrs{Generation: 7},
+			want:  storage.Conditions{GenerationMatch: 7},
+		},
+		{
+			name: "missing grid",
+			err:  fmt.Errorf("open: %w", storage.ErrObjectNotExist),
+			want: storage.Conditions{DoesNotExist: true},
+		},
+		{
+			name:  "corrupt grid",
+			attrs: &storage.ReaderObjectAttrs{Generation: 3},
+			err:   tgstate.ErrCorruptGrid{Path: "gs://bucket/grid", Err: errors.New("bad")},
+			want:  storage.Conditions{GenerationMatch: 3},
+		},
+		{
+			name: "unreadable grid",
+			err:  errors.New("injected"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := gridConditions(tc.attrs, tc.err); got != tc.want {
+				t.Errorf("gridConditions() got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFitGrid(t *testing.T) {
+	cols := testColumns()
+	full := buildGrid(cols)
+	size := proto.Size(full)
+	cases := []struct {
+		name     string
+		maxBytes int
+		want     int
+	}{
+		{
+			name: "no budget",
+			want: len(cols),
+		},
+		{
+			name:     "fits",
+			maxBytes: size,
+			want:     len(cols),
+		},
+		{
+			name:     "drop the oldest columns",
+			maxBytes: proto.Size(buildGrid(cols[:3])),
+			want:     3,
+		},
+		{
+			name:     "keep one column",
+			maxBytes: 1,
+			want:     1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := fitGrid(buildGrid(cols), tc.maxBytes)
+			if n := len(got.Columns); n != tc.want {
+				t.Fatalf("fitGrid() kept %d columns, want %d", n, tc.want)
+			}
+			if dropped := int(got.DroppedColumns); dropped != len(cols)-tc.want {
+				t.Errorf("fitGrid() recorded %d dropped columns, want %d", dropped, len(cols)-tc.want)
+			}
+			want := buildGrid(cols[:tc.want])
+			want.DroppedColumns = got.DroppedColumns
+			if !proto.Equal(normalize(got), want) {
+				t.Errorf("fitGrid() got %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestCapRows(t *testing.T) {
+	const (
+		pass = int32(state.Row_PASS)
+		fail = int32(state.Row_FAIL)
+		none = int32(state.Row_NO_RESULT)
+		run  = int32(state.Row_RUNNING)
+	)
+	rows := func() []*state.Row {
+		return []*state.Row{
+			{Name: "never-fails", Id: "never-fails", Results: []int32{pass, 3}},
+			{Name: "Overall", Id: "Overall", Results: []int32{pass, 3}},
+			{Name: "old-failure", Id: "old-failure", Results: []int32{pass, 2, fail, 1}},
+			{Name: "b-new-failure", Id: "b-new-failure", Results: []int32{run, 1, fail, 2}},
+			{Name: "a-new-failure", Id: "a-new-failure", Results: []int32{none, 1, fail, 1, pass, 1}},
+			{Name: "a-never-fails", Id: "a-never-fails", Results: []int32{none, 3}},
+		}
+	}
+	cases := []struct {
+		name string
+		max  int
+		want []string
+	}{
+		{
+			name: "no limit",
+			want: []string{"never-fails", "Overall", "old-failure", "b-new-failure", "a-new-failure", "a-never-fails"},
+		},
+		{
+			name: "fits",
+			max:  6,
+			want: []string{"never-fails", "Overall", "old-failure", "b-new-failure", "a-new-failure", "a-never-fails"},
+		},
+		{
+			name: "keep the overall row",
+			max:  1,
+			want: []string{"Overall"},
+		},
+		{
+			name: "keep the newest failures, breaking ties by name",
+			max:  2,
+			want: []string{"Overall", "a-new-failure"},
+		},
+		{
+			name: "keep failures in their original order",
+			max:  4,
+			want: []string{"Overall", "old-failure", "b-new-failure", "a-new-failure"},
+		},
+		{
+			name: "then keep rows that never failed by name",
+			max:  5,
+			want: []string{"Overall", "old-failure", "b-new-failure", "a-new-failure", "a-never-fails"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			grid := &state.Grid{Rows: rows(), DroppedRows: 7}
+			capRows(grid, tc.max)
+			var got []string
+			for _, row := range grid.Rows {
+				got = append(got, row.Name)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("capRows() kept %v, want %v", got, tc.want)
+			}
+			if dropped := int(grid.DroppedRows); dropped != len(rows())-len(tc.want) {
+				t.Errorf("capRows() recorded %d dropped rows, want %d", dropped, len(rows())-len(tc.want))
+			}
+		})
+	}
+}
+
+func TestUpdateGroupMaxRows(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Unix()
+	var client fake.Client
+	build := "gs://bucket/logs/job/1/"
+	client.Put(mustPath(t, build+"started.json"), []byte(fmt.Sprintf(`{"timestamp": %d}`, now)))
+	client.Put(mustPath(t, build+"finished.json"), []byte(fmt.Sprintf(`{"timestamp": %d, "passed": false}`, now+1)))
+	client.Put(mustPath(t, build+"artifacts/junit_01.xml"), []byte(`<testsuite>
+<testcase name="pass-a"/>
+<testcase name="fail-b"><failure>boom</failure></testcase>
+<testcase name="pass-c"/>
+</testsuite>`))
+	tg := configpb.TestGroup{
+		Name:          "job",
+		Query:         "bucket/logs/job",
+		DaysOfResults: 1,
+		MaxRows:       3,
+	}
+	gridPath := mustPath(t, "gs://bucket/job")
+	var report GroupReport
+	if err := updateGroup(ctx, &client, tg, gridPath, Options{BuildConcurrency: 1, GroupTimeout: time.Minute, BuildTimeout: time.Minute, Compression: gcs.Gzip}, nil, &report); err != nil {
+		t.Fatalf("updateGroup() got unexpected error: %v", err)
+	}
+	if report.DroppedRows != 1 {
+		t.Errorf("updateGroup() reported %d dropped rows, want 1", report.DroppedRows)
+	}
+	grid, _, err := tgstate.ReadGrid(ctx, &client, gridPath)
+	if err != nil {
+		t.Fatalf("ReadGrid() got unexpected error: %v", err)
+	}
+	var names []string
+	for _, row := range grid.Rows {
+		names = append(names, row.Name)
+	}
+	if want := []string{"Overall", "fail-b", "pass-a"}; !reflect.DeepEqual(names, want) || grid.DroppedRows != 1 {
+		t.Errorf("updateGroup() wrote rows %v with %d dropped, want %v with 1 dropped", names, grid.DroppedRows, want)
+	}
+}
+
+func TestUpdateGroupSize(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Unix()
+	var client fake.Client
+	for id := 1; id <= 5; id++ {
+		build := fmt.Sprintf("gs://bucket/logs/job/%d/", id)
+		started := now - int64(10*(5-id))
+		client.Put(mustPath(t, build+"started.json"), []byte(fmt.Sprintf(`{"timestamp": %d}`, started)))
+		client.Put(mustPath(t, build+"finished.json"), []byte(fmt.Sprintf(`{"timestamp": %d, "passed": true}`, started+1)))
+		client.Put(mustPath(t, build+"artifacts/junit_01.xml"), []byte(`<testsuite><testcase name="test"/></testsuite>`))
+	}
+	tg := configpb.TestGroup{
+		Name:          "job",
+		Query:         "bucket/logs/job",
+		DaysOfResults: 1,
+	}
+	gridPath := mustPath(t, "gs://bucket/job")
+	var report GroupReport
+	if err := updateGroup(ctx, &client, tg, gridPath, Options{BuildConcurrency: 1, GroupTimeout: time.Minute, BuildTimeout: time.Minute, MaxBytes: 1, Compression: gcs.Gzip}, nil, &report); err != nil {
+		t.Fatalf("updateGroup() got unexpected error: %v", err)
+	}
+	if report.DroppedColumns != 4 {
+		t.Errorf("updateGroup() reported %d dropped columns, want 4", report.DroppedColumns)
+	}
+	if stats := report.Stats; stats.Columns != 1 || stats.Rows != 2 || stats.Cells != 2 {
+		t.Errorf("updateGroup() reported stats %s, want 1 column of 2 rows with 2 cells", stats)
+	}
+	obj, ok := client.Lookup(gridPath)
+	if !ok {
+		t.Fatalf("updateGroup() did not write %s", gridPath)
+	}
+	if enc := obj.Attrs.ContentEncoding; enc != "gzip" {
+		t.Errorf("updateGroup() wrote Content-Encoding %q, want gzip", enc)
+	}
+	grid, _, err := tgstate.ReadGrid(ctx, &client, gridPath)
+	if err != nil {
+		t.Fatalf("ReadGrid() got unexpected error: %v", err)
+	}
+	if len(grid.Columns) != 1 || grid.Columns[0].Build != "5" || grid.DroppedColumns != 4 {
+		t.Errorf("updateGroup() wrote columns %v with %d dropped, want the newest with 4 dropped", grid.Columns, grid.DroppedColumns)
+	}
+}
+
+func TestUpdateGroupCompression(t *testing.T) {
+	ctx := context.Background()
+	var client fake.Client
+	build := "gs://bucket/logs/job/1/"
+	now := time.Now().Unix()
+	client.Put(mustPath(t, build+"started.json"), []byte(fmt.Sprintf(`{"timestamp": %d}`, now)))
+	client.Put(mustPath(t, build+"finished.json"), []byte(fmt.Sprintf(`{"timestamp": %d, "passed": true}`, now+1)))
+	client.Put(mustPath(t, build+"artifacts/junit_01.xml"), []byte(`<testsuite><testcase name="test"/></testsuite>`))
+	tg := configpb.TestGroup{
+		Name:          "job",
+		Query:         "bucket/logs/job",
+		DaysOfResults: 1,
+	}
+	gridPath := mustPath(t, "gs://bucket/job")
+	var report GroupReport
+	if err := updateGroup(ctx, &client, tg, gridPath, Options{BuildConcurrency: 1, GroupTimeout: time.Minute, BuildTimeout: time.Minute, Compression: gcs.Zstd}, nil, &report); err != nil {
+		t.Fatalf("updateGroup() got unexpected error: %v", err)
+	}
+	obj, ok := client.Lookup(gridPath)
+	if !ok {
+		t.Fatalf("updateGroup() did not write %s", gridPath)
+	}
+	if enc := obj.Attrs.ContentEncoding; enc != "zstd" || !gcs.IsZstd(obj.Data) {
+		t.Errorf("updateGroup() wrote Content-Encoding %q, want zstd", enc)
+	}
+	grid, _, err := tgstate.ReadGrid(ctx, &client, gridPath)
+	if err != nil {
+		t.Fatalf("ReadGrid() got unexpected error: %v", err)
+	}
+	if len(grid.Columns) != 1 || len(grid.Rows) != 2 {
+		t.Errorf("updateGroup() wrote %d columns and %d rows, want 1 column of 2 rows", len(grid.Columns), len(grid.Rows))
+	}
+}
@@ -24,6 +24,33 @@ def go_repositories():
         version = "v0.0.0-20190523083050-ea95bdfd59fc",
     )
 
+    go_repository(
+        name = "com_github_alecthomas_template",
+        build_file_generation = "on",
+        build_file_proto_mode = "disable",
+        importpath = "github.com/alecthomas/template",
+        sum = "h1:JYp7IbQjafoB+tBA3gMyHYHrpOtNuDiK/uB5uXxq5wM=",
+        version = "v0.0.0-20190718012654-fb15b899a751",
+    )
+
+    go_repository(
+        name = "com_github_alecthomas_units",
+        build_file_generation = "on",
+        build_file_proto_mode = "disable",
+        importpath = "github.com/alecthomas/units",
+        sum = "h1:Hs82Z41s6SdL1CELW+XaDYmOH4hkBN4/N9og/AsOv7E=",
+        version = "v0.0.0-20190717042225-c3de453c63f4",
+    )
+
+    go_repository(
+        name = "com_github_beorn7_perks",
+        build_file_generation = "on",
+        build_file_proto_mode = "disable",
+        importpath = "github.com/beorn7/perks",
+        sum = "h1:VlbKKnNfV8bJzeqoa4cOKqO6bYr3WgKZxO8Z16+hsOM=",
+        version = "v1.0.1",
+    )
+
     go_repository(
         name = "com_github_burntsushi_toml",
         build_file_generation = "on",
@@ -33,6 +60,15 @@ def go_repositories():
         version = "v0.3.1",
     )
 
+    go_repository(
+        name = "com_github_cespare_xxhash_v2",
+        build_file_generation = "on",
+        build_file_proto_mode = "disable",
+        importpath = "github.com/cespare/xxhash/v2",
+        sum = "h1:6MnRN8NT7+YBpUIWxHtefFZOKTAPgGjpQSxqLNn0+qY=",
+        version = "v2.1.1",
+    )
+
     go_repository(
         name = "com_github_client9_misspell",
         build_file_generation = "on",
@@ -51,6 +87,42 @@ def go_repositories():
         version = "v1.1.1",
     )
 
+    go_repository(
+        name = "com_github_go_kit_kit",
+        build_file_generation = "on",
+        build_file_proto_mode = "disable",
+        importpath = "github.com/go-kit/kit",
+        sum = "h1:wDJmvq38kDhkVxi50ni9ykkdUr1PKgqKOoi01fa0Mdk=",
+        version = "v0.9.0",
+    )
+
+    go_repository(
+        name = "com_github_go_logfmt_logfmt",
+        build_file_generation = "on",
+        build_file_proto_mode = "disable",
+        importpath = "github.com/go-logfmt/logfmt",
+        sum = "h1:MP4Eh7ZCb31lleYCFuwm0oe4/YGak+5l1vA2NOE80nA=",
+        version = "v0.4.0",
+    )
+
+    go_repository(
+        name = "com_github_go_stack_stack",
+        build_file_generation = "on",
+        build_file_proto_mode = "disable",
+        importpath = "github.com/go-stack/stack",
+        sum = "h1:5SgMzNM5HxrEjV0ww2lTmX6E2Izsfxas4+YHWRs3Lsk=",
+        version = "v1.8.0",
+    )
+
+    go_repository(
+        name = "com_github_gogo_protobuf",
+        build_file_generation = "on",
+        build_file_proto_mode = "disable",
+        importpath = "github.com/gogo/protobuf",
+        sum = "h1:72R+M5VuhED/KujmZVcIquuo8mBgX4oVda//DQb3PXo=",
+        version = "v1.1.1",
+    )
+
     go_repository(
         name = "com_github_golang_glog",
         build_file_generation = "on",
@@ -59,6 +131,7 @@ def go_repositories():
         sum = "h1:VKtxabqXZkF25pY9ekfRL6a582T4P37/31XEstQ5p58=",
         version = "v0.0.0-20160126235308-23def4e6c14b",
     )
+
     go_repository(
         name = "com_github_golang_groupcache",
         build_file_generation = "on",
@@ -67,6 +140,7 @@ def go_repositories():
         sum = "h1:ZgQEtGgCBiWRM39fZuwSd1LwSqqSW0hOdXCYYDX0R3I=",
         version = "v0.0.0-20190702054246-869f871628b6",
     )
+
     go_repository(
         name = "com_github_golang_mock",
         build_file_generation = "on",
@@ -75,6 +149,7 @@ def go_repositories():
         sum = "h1:qGJ6qTW+x6xX/my+8YUVl4WNpX9B7+/l2tRsHGZ7f2s=",
         version = "v1.3.1",
     )
+
     go_repository(
         name = "com_github_golang_protobuf",
         build_file_generation = "on",
@@ -92,13 +167,23 @@ def go_repositories():
         sum = "h1:0udJVsspx3VBr5FwtLhQQtuAsVc79tTq0ocGIPAU6qo=",
         version = "v1.0.0",
     )
+
     go_repository(
         name = "com_github_google_go_cmp",
         build_file_generation = "on",
         build_file_proto_mode = "disable",
         importpath = "github.com/google/go-cmp",
-        sum = "h1:Xye71clBPdm5HgqGwUkwhbynsUJZhDbS20FvLhQ2izg=",
-        version = "v0.3.1",
+        sum = "h1:xsAVV57WRhGj6kEIi8ReJzQlHHqcBYCElAvkovg3B/4=",
+        version = "v0.4.0",
+    )
+
+    go_repository(
+        name = "com_github_google_gofuzz",
+        build_file_generation = "on",
+        build_file_proto_mode = "disable",
+        importpath = "github.com/google/gofuzz",
+        sum = "h1:A8PeW59pxE9IoFRqBp37U+mSNaQoZ46F1f0f863XSXw=",
+        version = "v1.0.0",
     )
 
     go_repository(
@@ -109,6 +194,7 @@ def go_repositories():
         sum = "h1:/CP5g8u/VJHijgedC/Legn3BAbAaWPgecwXBIDzw5no=",
         version = "v2.1.0+incompatible",
     )
+
     go_repository(
         name = "com_github_google_pprof",
         build_file_generation = "on",
@@ -117,6 +203,7 @@ def go_repositories():
         sum = "h1:Jnx61latede7zDD3DiiP4gmNz33uK0U5HDUaF0a/HVQ=",
         version = "v0.0.0-20190515194954-54271f7e092f",
     )
+
     go_repository(
         name = "com_github_google_uuid",
         build_file_generation = "on",
@@ -144,6 +231,15 @@ def go_repositories():
         version = "v0.5.1",
     )
 
+    go_repository(
+        name = "com_github_json_iterator_go",
+        build_file_generation = "on",
+        build_file_proto_mode = "disable",
+        importpath = "github.com/json-iterator/go",
+        sum = "h1:9yzud/Ht36ygwatGx56VwCZtlI/2AD15T1X2sjSuGns=",
+        version = "v1.1.9",
+    )
+
     go_repository(
         name = "com_github_jstemmer_go_junit_report",
         build_file_generation = "on",
@@ -153,6 +249,33 @@ def go_repositories():
         version = "v0.0.0-20190106144839-af01ea7f8024",
     )
 
+    go_repository(
+        name = "com_github_julienschmidt_httprouter",
+        build_file_generation = "on",
+        build_file_proto_mode = "disable",
+        importpath = "github.com/julienschmidt/httprouter",
+        sum = "h1:TDTW5Yz1mjftljbcKqRcrYhd4XeOoI98t+9HbQbYf7g=",
+        version = "v1.2.0",
+    )
+
+    go_repository(
+        name = "com_github_klauspost_compress",
+        build_file_generation = "on",
+        build_file_proto_mode = "disable",
+        importpath = "github.com/klauspost/compress",
+        sum = "h1:a/y8CglcM7gLGYmlbP/stPE5sR3hbhFRUjCBfd/0B3I=",
+        version = "v1.10.10",
+    )
+
+    go_repository(
+        name = "com_github_kr_logfmt",
+        build_file_generation = "on",
+        build_file_proto_mode = "disable",
+        importpath = "github.com/kr/logfmt",
+        sum = "h1:T+h1c/A9Gawja4Y9mFVWj2vyii2bbUNDw3kt9VxK2EY=",
+        version = "v0.0.0-20140226030751-b84e30acd515",
+    )
+
     go_repository(
         name = "com_github_kr_pretty",
         build_file_generation = "on",
@@ -161,6 +284,7 @@ def go_repositories():
         sum = "h1:L/CwN0zerZDmRFUapSPitk6f+Q3+0za1rQkzVuMiMFI=",
         version = "v0.1.0",
     )
+
     go_repository(
         name = "com_github_kr_pty",
         build_file_generation = "on",
@@ -169,6 +293,7 @@ def go_repositories():
         sum = "h1:VkoXIwSboBpnk99O/KFauAEILuNHv5DVFKZMBN/gUgw=",
         version = "v1.1.1",
     )
+
     go_repository(
         name = "com_github_kr_text",
         build_file_generation = "on",
@@ -178,6 +303,51 @@ def go_repositories():
         version = "v0.1.0",
     )
 
+    go_repository(
+        name = "com_github_matttproud_golang_protobuf_extensions",
+        build_file_generation = "on",
+        build_file_proto_mode = "disable",
+        importpath = "github.com/matttproud/golang_protobuf_extensions",
+        sum = "h1:4hp9jkHxhMHkqkrB3Ix0jegS5sx/RkqARlsWZ6pIwiU=",
+        version = "v1.0.1",
+    )
+
+    go_repository(
+        name = "com_github_modern_go_concurrent",
+        build_file_generation = "on",
+        build_file_proto_mode = "disable",
+        importpath = "github.com/modern-go/concurrent",
+        sum = "h1:TRLaZ9cD/w8PVh93nsPXa1VrQ6jlwL5oN8l14QlcNfg=",
+        version = "v0.0.0-20180306012644-bacd9c7ef1dd",
+    )
+
+    go_repository(
+        name = "com_github_modern_go_reflect2",
+        build_file_generation = "on",
+        build_file_proto_mode = "disable",
+        importpath = "github.com/modern-go/reflect2",
+        sum = "h1:9f412s+6RmYXLWZSEzVVgPGK7C2PphHj5RJrvfx9AWI=",
+        version = "v1.0.1",
+    )
+
+    go_repository(
+        name = "com_github_mwitkow_go_conntrack",
+        build_file_generation = "on",
+        build_file_proto_mode = "disable",
+        importpath = "github.com/mwitkow/go-conntrack",
+        sum = "h1:F9x/1yl3T2AeKLr2AMdilSD8+f9bvMnNN8VS5iDtovc=",
+        version = "v0.0.0-20161129095857-cc309e4a2223",
+    )
+
+    go_repository(
+        name = "com_github_pkg_errors",
+        build_file_generation = "on",
+        build_file_proto_mode = "disable",
+        importpath = "github.com/pkg/errors",
+        sum = "h1:iURUrRGxPUNPdy5/HRSm+Yj6okJ6UtLINN0Q9M4+h3I=",
+        version = "v0.8.1",
+    )
+
     go_repository(
         name = "com_github_pmezard_go_difflib",
         build_file_generation = "on",
@@ -187,6 +357,42 @@ def go_repositories():
         version = "v1.0.0",
     )
 
+    go_repository(
+        name = "com_github_prometheus_client_golang",
+        build_file_generation = "on",
+        build_file_proto_mode = "disable",
+        importpath = "github.com/prometheus/client_golang",
+        sum = "h1:bdHYieyGlH+6OLEk2YQha8THib30KP0/yD0YH9m6xcA=",
+        version = "v1.5.1",
+    )
+
+    go_repository(
+        name = "com_github_prometheus_client_model",
+        build_file_generation = "on",
+        build_file_proto_mode = "disable",
+        importpath = "github.com/prometheus/client_model",
+        sum = "h1:uq5h0d+GuxiXLJLNABMgp2qUWDPiLvgCzz2dUR+/W/M=",
+        version = "v0.2.0",
+    )
+
+    go_repository(
+        name = "com_github_prometheus_common",
+        build_file_generation = "on",
+        build_file_proto_mode = "disable",
+        importpath = "github.com/prometheus/common",
+        sum = "h1:KOMtN28tlbam3/7ZKEYKHhKoJZYYj3gMH4uc62x7X7U=",
+        version = "v0.9.1",
+    )
+
+    go_repository(
+        name = "com_github_prometheus_procfs",
+        build_file_generation = "on",
+        build_file_proto_mode = "disable",
+        importpath = "github.com/prometheus/procfs",
+        sum = "h1:+fpWZdT24pJBiqJdAwYBjPSk+5YmQzYNPYzQsdzLkt8=",
+        version = "v0.0.8",
+    )
+
     go_repository(
         name = "com_github_stretchr_objx",
         build_file_generation = "on",
@@ -195,6 +401,7 @@ def go_repositories():
         sum = "h1:2vfRuCMp5sSVIDSqO8oNnWJq7mPa6KVP3iPIwFBuy8A=",
         version = "v0.1.1",
     )
+
     go_repository(
         name = "com_github_stretchr_testify",
         build_file_generation = "on",
@@ -222,13 +429,22 @@ def go_repositories():
         version = "v0.44.3",
     )
 
+    go_repository(
+        name = "in_gopkg_alecthomas_kingpin_v2",
+        build_file_generation = "on",
+        build_file_proto_mode = "disable",
+        importpath = "gopkg.in/alecthomas/kingpin.v2",
+        sum = "h1:jMFz6MfLP0/4fUyZle81rXUoxOBFi19VUFKVDOQfozc=",
+        version = "v2.2.6",
+    )
+
     go_repository(
         name = "in_gopkg_check_v1",
         build_file_generation = "on",
         build_file_proto_mode = "disable",
         importpath = "gopkg.in/check.v1",
-        sum = "h1:qIbj1fsPNlZgppZ+VLlY7N33q108Sa+fhmuc+sWQYwY=",
-        version = "v1.0.0-20180628173108-788fd7840127",
+        sum = "h1:YR8cESwS4TdDjEe65xsg0ogRM/Nc3DYOhEAlW+xobZo=",
+        version = "v1.0.0-20190902080502-41f04d3bba15",
     )
 
     go_repository(
@@ -236,8 +452,8 @@ def go_repositories():
         build_file_generation = "on",
         build_file_proto_mode = "disable",
         importpath = "gopkg.in/yaml.v2",
-        sum = "h1:/eiJrUcujPVeJ3xlSWaiNi3uSVmDGBK1pDHUHAnao1I=",
-        version = "v2.2.4",
+        sum = "h1:ymVxjfMaHvXD8RqPRmzHHsB3VvucivSkIAvJFDI5O3c=",
+        version = "v2.2.5",
     )
 
     go_repository(
@@ -257,6 +473,7 @@ def go_repositories():
         sum = "h1:8dP3SGL7MPB94crU3bEPplMPe83FI4EouesJUeFHv50=",
         version = "v0.22.1",
     )
+
     go_repository(
         name = "ml_vbom_util",
         build_file_generation = "on",
@@ -265,6 +482,7 @@ def go_repositories():
         sum = "h1:O69FD9pJA4WUZlEwYatBEEkRWKQ5cKodWpdKTrCS/iQ=",
         version = "v0.0.0-20180919145318-efcd4e0f9787",
     )
+
     go_repository(
         name = "org_golang_google_api",
         build_file_generation = "on",
@@ -273,6 +491,7 @@ def go_repositories():
         sum = "h1:jbyannxz0XFD3zdjgrSUsaJbgpH4eTrkdhRChkHPfO8=",
         version = "v0.9.0",
     )
+
     go_repository(
         name = "org_golang_google_appengine",
         build_file_generation = "on",
@@ -281,6 +500,7 @@ def go_repositories():
         sum = "h1:j8RI1yW0SkI+paT6uGwMlrMI/6zwYA6/CFil8rxOzGI=",
         version = "v1.6.2",
     )
+
     go_repository(
         name = "org_golang_google_genproto",
         build_file_generation = "on",
@@ -289,6 +509,7 @@ def go_repositories():
         sum = "h1:gSJIx1SDwno+2ElGhA4+qG2zF97qiUzTM+rQ0klBOcE=",
         version = "v0.0.0-20190819201941-24fa4b261c55",
     )
+
     go_repository(
         name = "org_golang_google_grpc",
         build_file_generation = "on",
@@ -297,6 +518,7 @@ def go_repositories():
         sum = "h1:q4XQuHFC6I28BKZpo6IYyb3mNO+l7lSOxRuYTCiDfXk=",
         version = "v1.23.1",
     )
+
     go_repository(
         name = "org_golang_x_crypto",
         build_file_generation = "on",
@@ -305,6 +527,7 @@ def go_repositories():
         sum = "h1:58fnuSXlxZmFdJyvtTFVmVhcMLU6v5fEb/ok4wyqtNU=",
         version = "v0.0.0-20190605123033-f99c8df09eb5",
     )
+
     go_repository(
         name = "org_golang_x_exp",
         build_file_generation = "on",
@@ -313,6 +536,7 @@ def go_repositories():
         sum = "h1:OeRHuibLsmZkFj773W4LcfAGsSxJgfPONhr8cmO+eLA=",
         version = "v0.0.0-20190510132918-efd6b22b2522",
     )
+
     go_repository(
         name = "org_golang_x_lint",
         build_file_generation = "on",
@@ -321,6 +545,7 @@ def go_repositories():
         sum = "h1:QzoH/1pFpZguR8NrRHLcO6jKqfv2zpuSqZLgdm7ZmjI=",
         version = "v0.0.0-20190409202823-959b441ac422",
     )
+
     go_repository(
         name = "org_golang_x_net",
         build_file_generation = "on",
@@ -329,6 +554,7 @@ def go_repositories():
         sum = "h1:rjwSpXsdiK0dV8/Naq3kAw9ymfAeJIyd0upUIElB+lI=",
         version = "v0.0.0-20191004110552-13f9640d40b9",
     )
+
     go_repository(
         name = "org_golang_x_oauth2",
         build_file_generation = "on",
@@ -337,6 +563,7 @@ def go_repositories():
         sum = "h1:SVwTIAaPC2U/AvvLNZ2a7OVsmBpC8L5BlwK1whH3hm0=",
         version = "v0.0.0-20190604053449-0f29369cfe45",
     )
+
     go_repository(
         name = "org_golang_x_sync",
         build_file_generation = "on",
@@ -345,14 +572,16 @@ def go_repositories():
         sum = "h1:8gQV6CLnAEikrhgkHFbMAEhagSSnXWGV915qUMm9mrU=",
         version = "v0.0.0-20190423024810-112230192c58",
     )
+
     go_repository(
         name = "org_golang_x_sys",
         build_file_generation = "on",
         build_file_proto_mode = "disable",
         importpath = "golang.org/x/sys",
-        sum = "h1:ng0gs1AKnRRuEMZoTLLlbOd+C17zUDepwGQBb/n+JVg=",
-        version = "v0.0.0-20190826190057-c7b8b68b1456",
+        sum = "h1:ywK/j/KkyTHcdyYSZNXGjMwgmDSfjglYZ3vStQ/gSCU=",
+        version = "v0.0.0-20200122134326-e047566fdf82",
     )
+
     go_repository(
         name = "org_golang_x_text",
         build_file_generation = "on",
@@ -361,6 +590,7 @@ def go_repositories():
         sum = "h1:tW2bmiBqwgJj/UpqtC8EpXEZVYOwU0yG4iWbprSVAcs=",
         version = "v0.3.2",
     )
+
     go_repository(
         name = "org_golang_x_time",
         build_file_generation = "on",
@@ -369,6 +599,7 @@ def go_repositories():
         sum = "h1:SvFZT6jyqRaOeXpc5h/JSfZenJ2O330aBsf7JfSUXmQ=",
         version = "v0.0.0-20190308202827-9d24e82272b4",
     )
+
     go_repository(
         name = "org_golang_x_tools",
         build_file_generation = "on",
@@ -378,6 +609,15 @@ def go_repositories():
         version = "v0.0.0-20190628153133-6cdbf07be9d0",
     )
 
+    go_repository(
+        name = "org_golang_x_xerrors",
+        build_file_generation = "on",
+        build_file_proto_mode = "disable",
+        importpath = "golang.org/x/xerrors",
+        sum = "h1:E7g+9GITq07hpfrRu66IVDexMakfv52eLZ2CXBWiKr4=",
+        version = "v0.0.0-20191204190536-9bdfabe68543",
+    )
+
     go_repository(
         name = "com_github_burntsushi_xgb",
         build_file_generation = "on",
@@ -404,6 +644,7 @@ def go_repositories():
         sum = "h1:HfqmD5MEmC0zvwBuF187nq9mdnXjXsSivRiXN7SmRkE=",
         version = "v0.2.0",
     )
+
     go_repository(
         name = "org_golang_x_image",
         build_file_generation = "on",
@@ -412,6 +653,7 @@ def go_repositories():
         sum = "h1:KYGJGHOQy8oSi1fDlSpcZF0+juKwk/hEMv5SiwHogR0=",
         version = "v0.0.0-20190227222117-0694c2d4d067",
     )
+
     go_repository(
         name = "org_golang_x_mobile",
         build_file_generation = "on",
@@ -420,6 +662,7 @@ def go_repositories():
         sum = "h1:Tus/Y4w3V77xDsGwKUC8a/QrV7jScpU557J77lFffNs=",
         version = "v0.0.0-20190312151609-d3739f865fa6",
     )
+
     go_repository(
         name = "com_github_konsorten_go_windows_terminal_sequences",
         build_file_generation = "on",
@@ -428,6 +671,7 @@ def go_repositories():
         sum = "h1:mweAR1A6xJ3oS2pRaGiHgQ4OO8tzTaLawm8vnODuwDk=",
         version = "v1.0.1",
     )
+
     go_repository(
         name = "com_github_sirupsen_logrus",
         build_file_generation = "on",
@@ -445,6 +689,7 @@ def go_repositories():
         sum = "h1:hLrqtEDnRye3+sgx6z4qVLNuviH3MR5aQ0ykNJa/UYA=",
         version = "v1.0.0",
     )
+
     go_repository(
         name = "com_github_hashicorp_go_multierror",
         build_file_generation = "on",
@@ -0,0 +1,108 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"context"
+	"io"
+
+	"cloud.google.com/go/storage"
+)
+
+// Client reads and writes the objects of a GCS-like object store.
+//
+// NewClient adapts a storage.Client, and the fake package provides an in-memory Client for tests.
+type Client interface {
+	// Open returns a reader of the object and its attributes, or storage.ErrObjectNotExist.
+	Open(ctx context.Context, path Path) (io.ReadCloser, *storage.ReaderObjectAttrs, error)
+	// Objects iterates over the objects whose name starts with the object of the path.
+	//
+	// With a delimiter, objects with a delimiter after the prefix are listed once as a
+	// storage.ObjectAttrs with only its Prefix set, up to and including the delimiter.
+	Objects(ctx context.Context, path Path, delimiter string) Iterator
+	// Upload writes the object when it matches the conditions, returning the attributes of the new generation.
+	//
+	// Returns an error satisfying IsPreconditionFailed when it does not match.
+	Upload(ctx context.Context, path Path, buf []byte, worldReadable bool, cacheControl string, cond storage.Conditions) (*storage.ObjectAttrs, error)
+	// Attrs returns the attributes of the object, or storage.ErrObjectNotExist.
+	Attrs(ctx context.Context, path Path) (*storage.ObjectAttrs, error)
+}
+
+// EncodedUploader is a Client that can also upload objects with a Content-Encoding, such as gzip.
+type EncodedUploader interface {
+	// UploadEncoded is like Upload, setting the Content-Encoding of the object.
+	UploadEncoded(ctx context.Context, path Path, buf []byte, worldReadable bool, cacheControl, contentEncoding string, cond storage.Conditions) (*storage.ObjectAttrs, error)
+}
+
+// UploadEncoded uploads buf with the Content-Encoding when the client supports one, and without it otherwise.
+func UploadEncoded(ctx context.Context, client Client, path Path, buf []byte, worldReadable bool, cacheControl, contentEncoding string, cond storage.Conditions) (*storage.ObjectAttrs, error) {
+	if u, ok := client.(EncodedUploader); ok {
+		return u.UploadEncoded(ctx, path, buf, worldReadable, cacheControl, contentEncoding, cond)
+	}
+	return client.Upload(ctx, path, buf, worldReadable, cacheControl, cond)
+}
+
+// Iterator returns the next object, or iterator.Done after the last one.
+type Iterator interface {
+	Next() (*storage.ObjectAttrs, error)
+}
+
+var _ Iterator = (*storage.ObjectIterator)(nil)
+
+// NewClient returns a Client of the objects in GCS.
+func NewClient(client *storage.Client) Client {
+	return storageClient{client}
+}
+
+type storageClient struct {
+	client *storage.Client
+}
+
+func (c storageClient) handle(path Path) *storage.ObjectHandle {
+	return c.client.Bucket(path.Bucket()).Object(path.Object())
+}
+
+func (c storageClient) Open(ctx context.Context, path Path) (io.ReadCloser, *storage.ReaderObjectAttrs, error) {
+	r, err := c.handle(path).NewReader(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r, &r.Attrs, nil
+}
+
+func (c storageClient) Objects(ctx context.Context, path Path, delimiter string) Iterator {
+	return c.client.Bucket(path.Bucket()).Objects(ctx, &storage.Query{
+		Delimiter: delimiter,
+		Prefix:    path.Object(),
+	})
+}
+
+func (c storageClient) Upload(ctx context.Context, path Path, buf []byte, worldReadable bool, cacheControl string, cond storage.Conditions) (*storage.ObjectAttrs, error) {
+	return c.UploadEncoded(ctx, path, buf, worldReadable, cacheControl, "", cond)
+}
+
+func (c storageClient) UploadEncoded(ctx context.Context, path Path, buf []byte, worldReadable bool, cacheControl, contentEncoding string, cond storage.Conditions) (*storage.ObjectAttrs, error) {
+	obj := c.handle(path)
+	if cond != (storage.Conditions{}) {
+		obj = obj.If(cond)
+	}
+	return upload(ctx, obj, path, buf, worldReadable, cacheControl, contentEncoding)
+}
+
+func (c storageClient) Attrs(ctx context.Context, path Path) (*storage.ObjectAttrs, error) {
+	return c.handle(path).Attrs(ctx)
+}
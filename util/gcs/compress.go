@@ -0,0 +1,116 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression is the algorithm compressing an upload, which is also its Content-Encoding.
+type Compression string
+
+const (
+	// Gzip compresses uploads with gzip, which GCS decompresses for readers that do not accept it.
+	Gzip Compression = "gzip"
+	// Zstd compresses uploads with zstd, which costs less CPU than gzip for a similar size.
+	//
+	// GCS serves zstd objects as they are, so readers must detect and decompress them.
+	Zstd Compression = "zstd"
+)
+
+// ParseCompression returns the Compression named s, where the empty string means Gzip.
+func ParseCompression(s string) (Compression, error) {
+	switch c := Compression(s); c {
+	case "":
+		return Gzip, nil
+	case Gzip, Zstd:
+		return c, nil
+	}
+	return "", fmt.Errorf("unknown compression %q, want %s or %s", s, Gzip, Zstd)
+}
+
+// Compress returns the compression of what write outputs, so that large objects stream into the compressor.
+func (c Compression) Compress(write func(io.Writer) error) ([]byte, error) {
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch c {
+	case Gzip, "":
+		w = gzip.NewWriter(&buf)
+	case Zstd:
+		zw, err := zstd.NewWriter(&buf)
+		if err != nil {
+			return nil, fmt.Errorf("zstd: %v", err)
+		}
+		w = zw
+	default:
+		return nil, fmt.Errorf("unknown compression %q", c)
+	}
+	if err := write(w); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("close %s: %v", c.ContentEncoding(), err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ContentEncoding returns the Content-Encoding of objects with this compression.
+func (c Compression) ContentEncoding() string {
+	if c == "" {
+		return string(Gzip)
+	}
+	return string(c)
+}
+
+// Decompress returns the decompression of buf when it starts with the magic number of gzip or zstd, or else buf.
+//
+// Readers of gzip objects usually receive them decompressed, whereas zstd objects always arrive compressed.
+func Decompress(buf []byte) ([]byte, error) {
+	switch {
+	case IsGzip(buf):
+		zr, err := gzip.NewReader(bytes.NewReader(buf))
+		if err != nil {
+			return nil, fmt.Errorf("gzip: %v", err)
+		}
+		defer zr.Close()
+		return ioutil.ReadAll(zr)
+	case IsZstd(buf):
+		zr, err := zstd.NewReader(nil)
+		if err != nil {
+			return nil, fmt.Errorf("zstd: %v", err)
+		}
+		defer zr.Close()
+		return zr.DecodeAll(buf, nil)
+	}
+	return buf, nil
+}
+
+// IsGzip reports whether buf starts with the gzip magic number.
+func IsGzip(buf []byte) bool {
+	return len(buf) >= 2 && buf[0] == 0x1f && buf[1] == 0x8b
+}
+
+// IsZstd reports whether buf starts with the magic number of a zstd frame.
+func IsZstd(buf []byte) bool {
+	return len(buf) >= 4 && buf[0] == 0x28 && buf[1] == 0xb5 && buf[2] == 0x2f && buf[3] == 0xfd
+}
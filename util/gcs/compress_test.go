@@ -0,0 +1,117 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestParseCompression(t *testing.T) {
+	cases := []struct {
+		name string
+		want Compression
+		err  bool
+	}{
+		{
+			name: "",
+			want: Gzip,
+		},
+		{
+			name: "gzip",
+			want: Gzip,
+		},
+		{
+			name: "zstd",
+			want: Zstd,
+		},
+		{
+			name: "zlib",
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseCompression(tc.name)
+			switch {
+			case err != nil && !tc.err:
+				t.Errorf("ParseCompression() got unexpected error: %v", err)
+			case err == nil && tc.err:
+				t.Errorf("ParseCompression() failed to return an error, got %q", got)
+			case got != tc.want:
+				t.Errorf("ParseCompression() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCompress(t *testing.T) {
+	plain := bytes.Repeat([]byte("hello world "), 100)
+	cases := []struct {
+		compression Compression
+		magic       func([]byte) bool
+	}{
+		{
+			compression: Gzip,
+			magic:       IsGzip,
+		},
+		{
+			compression: Zstd,
+			magic:       IsZstd,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(string(tc.compression), func(t *testing.T) {
+			buf, err := tc.compression.Compress(func(w io.Writer) error {
+				_, err := w.Write(plain)
+				return err
+			})
+			if err != nil {
+				t.Fatalf("Compress() got unexpected error: %v", err)
+			}
+			if !tc.magic(buf) || len(buf) >= len(plain) {
+				t.Errorf("Compress() got %d bytes %x, want a smaller %s stream", len(buf), buf, tc.compression)
+			}
+			got, err := Decompress(buf)
+			if err != nil {
+				t.Fatalf("Decompress() got unexpected error: %v", err)
+			}
+			if !bytes.Equal(got, plain) {
+				t.Errorf("Decompress() got %q, want %q", got, plain)
+			}
+			if _, err := Decompress(buf[:len(buf)-4]); err == nil {
+				t.Error("Decompress() of a truncated stream failed to return an error")
+			}
+			if enc := tc.compression.ContentEncoding(); enc != string(tc.compression) {
+				t.Errorf("ContentEncoding() got %q, want %q", enc, tc.compression)
+			}
+		})
+	}
+
+	if got, err := Decompress(plain); err != nil || !bytes.Equal(got, plain) {
+		t.Errorf("Decompress() of uncompressed bytes got %q, %v, want them unchanged", got, err)
+	}
+
+	want := errors.New("injected")
+	if _, err := Zstd.Compress(func(io.Writer) error { return want }); err != want {
+		t.Errorf("Compress() got error %v, want %v", err, want)
+	}
+}
@@ -0,0 +1,308 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake provides an in-memory gcs.Client for tests.
+package fake
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iterator"
+
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// Object is the content and attributes of a stored object.
+type Object struct {
+	Data  []byte
+	Attrs storage.ObjectAttrs
+}
+
+// Client is an in-memory gcs.Client, which is safe for concurrent use.
+//
+// Each write of an object creates a new, larger generation, like GCS.
+// The zero value is an empty store.
+type Client struct {
+	lock       sync.Mutex
+	objects    map[string]*Object // gs://bucket/obj => object
+	errors     map[string]error   // gs://bucket/obj => injected error
+	next       map[string][]error // gs://bucket/obj => errors of the next operations
+	generation int64
+}
+
+var _ gcs.Client = &Client{}
+var _ gcs.EncodedUploader = &Client{}
+
+// Put writes the object, returning the attributes of its new generation.
+func (c *Client) Put(path gcs.Path, data []byte) *storage.ObjectAttrs {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.put(path, data, false, "")
+}
+
+// Lookup returns a copy of the current generation of the object, if it exists.
+func (c *Client) Lookup(path gcs.Path) (*Object, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	obj, ok := c.objects[path.String()]
+	if !ok {
+		return nil, false
+	}
+	cp := *obj
+	cp.Data = append([]byte(nil), obj.Data...)
+	return &cp, true
+}
+
+// Delete removes the object.
+func (c *Client) Delete(path gcs.Path) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.objects, path.String())
+}
+
+// Fail makes each operation on the path return err, or clears the injected error when err is nil.
+//
+// Objects fails when its path matches.
+func (c *Client) Fail(path gcs.Path, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if err == nil {
+		delete(c.errors, path.String())
+		return
+	}
+	if c.errors == nil {
+		c.errors = map[string]error{}
+	}
+	c.errors[path.String()] = err
+}
+
+// FailNext makes the next operations on the path return each of errs in turn, before any error injected by Fail.
+//
+// Each call of Open, Upload, Attrs and Objects consumes one error, as does each Next of a listing of the path.
+func (c *Client) FailNext(path gcs.Path, errs ...error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.next == nil {
+		c.next = map[string][]error{}
+	}
+	c.next[path.String()] = append(c.next[path.String()], errs...)
+}
+
+// injected returns and consumes the injected error of the path, if any.
+func (c *Client) injected(path gcs.Path) error {
+	key := path.String()
+	if errs := c.next[key]; len(errs) > 0 {
+		c.next[key] = errs[1:]
+		return errs[0]
+	}
+	return c.errors[key]
+}
+
+func (c *Client) put(path gcs.Path, data []byte, worldReadable bool, cacheControl string) *storage.ObjectAttrs {
+	if c.objects == nil {
+		c.objects = map[string]*Object{}
+	}
+	c.generation++
+	obj := &Object{
+		Data: append([]byte(nil), data...),
+		Attrs: storage.ObjectAttrs{
+			Bucket:         path.Bucket(),
+			Name:           path.Object(),
+			CacheControl:   cacheControl,
+			Size:           int64(len(data)),
+			Generation:     c.generation,
+			Metageneration: 1,
+			Updated:        time.Now(),
+		},
+	}
+	obj.Attrs.Created = obj.Attrs.Updated
+	if worldReadable {
+		obj.Attrs.ACL = []storage.ACLRule{{Entity: storage.AllUsers, Role: storage.RoleReader}}
+	}
+	c.objects[path.String()] = obj
+	attrs := obj.Attrs
+	return &attrs
+}
+
+// lookup returns the object, its injected error, or storage.ErrObjectNotExist.
+func (c *Client) lookup(path gcs.Path) (*Object, error) {
+	if err := c.injected(path); err != nil {
+		return nil, err
+	}
+	obj, ok := c.objects[path.String()]
+	if !ok {
+		return nil, storage.ErrObjectNotExist
+	}
+	return obj, nil
+}
+
+// Open returns a reader of the current generation of the object.
+func (c *Client) Open(ctx context.Context, path gcs.Path) (io.ReadCloser, *storage.ReaderObjectAttrs, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	obj, err := c.lookup(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	attrs := storage.ReaderObjectAttrs{
+		Size:           obj.Attrs.Size,
+		CacheControl:   obj.Attrs.CacheControl,
+		LastModified:   obj.Attrs.Updated,
+		Generation:     obj.Attrs.Generation,
+		Metageneration: obj.Attrs.Metageneration,
+	}
+	return ioutil.NopCloser(bytes.NewReader(obj.Data)), &attrs, nil
+}
+
+// Objects lists the objects under the path, sorted by name like GCS.
+func (c *Client) Objects(ctx context.Context, path gcs.Path, delimiter string) gcs.Iterator {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if err := c.injected(path); err != nil {
+		return &objectIterator{err: err}
+	}
+	prefix := path.Object()
+	var names []string
+	attrs := map[string]storage.ObjectAttrs{}
+	for _, obj := range c.objects {
+		if obj.Attrs.Bucket != path.Bucket() || !strings.HasPrefix(obj.Attrs.Name, prefix) {
+			continue
+		}
+		name := obj.Attrs.Name
+		a := obj.Attrs
+		if delimiter != "" {
+			if idx := strings.Index(name[len(prefix):], delimiter); idx >= 0 {
+				name = name[:len(prefix)+idx+len(delimiter)]
+				a = storage.ObjectAttrs{Prefix: name}
+			}
+		}
+		if _, ok := attrs[name]; ok {
+			continue
+		}
+		names = append(names, name)
+		attrs[name] = a
+	}
+	sort.Strings(names)
+	it := objectIterator{ctx: ctx, client: c, path: path}
+	for _, name := range names {
+		a := attrs[name]
+		it.objs = append(it.objs, &a)
+	}
+	return &it
+}
+
+type objectIterator struct {
+	ctx    context.Context
+	client *Client
+	path   gcs.Path
+	objs   []*storage.ObjectAttrs
+	err    error
+}
+
+func (it *objectIterator) Next() (*storage.ObjectAttrs, error) {
+	if it.err != nil {
+		return nil, it.err
+	}
+	if err := it.ctx.Err(); err != nil {
+		return nil, err
+	}
+	it.client.lock.Lock()
+	var err error
+	if errs := it.client.next[it.path.String()]; len(errs) > 0 {
+		err = it.client.injected(it.path)
+	}
+	it.client.lock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if len(it.objs) == 0 {
+		return nil, iterator.Done
+	}
+	obj := it.objs[0]
+	it.objs = it.objs[1:]
+	return obj, nil
+}
+
+// Upload writes the object when it matches the conditions.
+//
+// Supports the GenerationMatch and DoesNotExist conditions, returning a
+// precondition failure like GCS when the object does not match them.
+func (c *Client) Upload(ctx context.Context, path gcs.Path, buf []byte, worldReadable bool, cacheControl string, cond storage.Conditions) (*storage.ObjectAttrs, error) {
+	return c.UploadEncoded(ctx, path, buf, worldReadable, cacheControl, "", cond)
+}
+
+// UploadEncoded is like Upload, recording the Content-Encoding in the attributes of the object.
+func (c *Client) UploadEncoded(ctx context.Context, path gcs.Path, buf []byte, worldReadable bool, cacheControl, contentEncoding string, cond storage.Conditions) (*storage.ObjectAttrs, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	obj, err := c.lookup(path)
+	if err != nil && err != storage.ErrObjectNotExist {
+		return nil, err
+	}
+	switch {
+	case cond.DoesNotExist && obj != nil:
+		return nil, preconditionFailed(path, "object exists at generation %d", obj.Attrs.Generation)
+	case cond.GenerationMatch != 0 && obj == nil:
+		return nil, preconditionFailed(path, "object does not exist, want generation %d", cond.GenerationMatch)
+	case cond.GenerationMatch != 0 && obj.Attrs.Generation != cond.GenerationMatch:
+		return nil, preconditionFailed(path, "generation %d, want %d", obj.Attrs.Generation, cond.GenerationMatch)
+	}
+	attrs := c.put(path, buf, worldReadable, cacheControl)
+	if contentEncoding != "" {
+		c.objects[path.String()].Attrs.ContentEncoding = contentEncoding
+		attrs.ContentEncoding = contentEncoding
+	}
+	return attrs, nil
+}
+
+func preconditionFailed(path gcs.Path, format string, args ...interface{}) error {
+	return fmt.Errorf("closing %s failed: %w", path, &googleapi.Error{
+		Code:    http.StatusPreconditionFailed,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// Attrs returns the attributes of the current generation of the object.
+func (c *Client) Attrs(ctx context.Context, path gcs.Path) (*storage.ObjectAttrs, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	obj, err := c.lookup(path)
+	if err != nil {
+		return nil, err
+	}
+	attrs := obj.Attrs
+	return &attrs, nil
+}
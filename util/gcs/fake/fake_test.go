@@ -0,0 +1,274 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+func mustPath(t *testing.T, s string) gcs.Path {
+	t.Helper()
+	p, err := gcs.ParsePath(s)
+	if err != nil {
+		t.Fatalf("bad path %s: %v", s, err)
+	}
+	return *p
+}
+
+func TestOpen(t *testing.T) {
+	ctx := context.Background()
+	var c Client
+	path := mustPath(t, "gs://bucket/obj")
+	if _, _, err := c.Open(ctx, path); err != storage.ErrObjectNotExist {
+		t.Fatalf("Open() got %v, want ErrObjectNotExist", err)
+	}
+	put := c.Put(path, []byte("hello"))
+	r, attrs, err := c.Open(ctx, path)
+	if err != nil {
+		t.Fatalf("Open() got unexpected error: %v", err)
+	}
+	defer r.Close()
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if string(buf) != "hello" {
+		t.Errorf("Open() read %q, want hello", buf)
+	}
+	if attrs.Generation != put.Generation || attrs.Size != 5 {
+		t.Errorf("Open() got attrs %+v, want generation %d of size 5", attrs, put.Generation)
+	}
+
+	injected := errors.New("injected")
+	c.Fail(path, injected)
+	if _, _, err := c.Open(ctx, path); err != injected {
+		t.Errorf("Open() got %v, want injected error", err)
+	}
+	if _, err := c.Attrs(ctx, path); err != injected {
+		t.Errorf("Attrs() got %v, want injected error", err)
+	}
+	c.Fail(path, nil)
+	if _, err := c.Attrs(ctx, path); err != nil {
+		t.Errorf("Attrs() got unexpected error after clearing it: %v", err)
+	}
+}
+
+func TestUpload(t *testing.T) {
+	ctx := context.Background()
+	var c Client
+	path := mustPath(t, "gs://bucket/obj")
+
+	cases := []struct {
+		name string
+		cond func(current int64) storage.Conditions
+		err  bool
+	}{
+		{
+			name: "create when missing",
+			cond: func(int64) storage.Conditions { return storage.Conditions{DoesNotExist: true} },
+		},
+		{
+			name: "create again",
+			cond: func(int64) storage.Conditions { return storage.Conditions{DoesNotExist: true} },
+			err:  true,
+		},
+		{
+			name: "other generation",
+			cond: func(current int64) storage.Conditions { return storage.Conditions{GenerationMatch: current + 1} },
+			err:  true,
+		},
+		{
+			name: "current generation",
+			cond: func(current int64) storage.Conditions { return storage.Conditions{GenerationMatch: current} },
+		},
+		{
+			name: "unconditionally",
+			cond: func(int64) storage.Conditions { return storage.Conditions{} },
+		},
+	}
+
+	var current int64
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			attrs, err := c.Upload(ctx, path, []byte(tc.name), true, "no-cache", tc.cond(current))
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Fatalf("Upload() got unexpected error: %v", err)
+				}
+				if !gcs.IsPreconditionFailed(err) {
+					t.Errorf("Upload() got %v, want a precondition failure", err)
+				}
+				return
+			case tc.err:
+				t.Fatal("Upload() failed to return an error")
+			}
+			if attrs.Generation <= current {
+				t.Errorf("Upload() got generation %d, want more than %d", attrs.Generation, current)
+			}
+			current = attrs.Generation
+			obj, ok := c.Lookup(path)
+			if !ok {
+				t.Fatal("Lookup() failed to find the object")
+			}
+			if string(obj.Data) != tc.name || obj.Attrs.CacheControl != "no-cache" || len(obj.Attrs.ACL) != 1 {
+				t.Errorf("Lookup() got %q with %+v", obj.Data, obj.Attrs)
+			}
+		})
+	}
+
+	c.Delete(path)
+	if _, ok := c.Lookup(path); ok {
+		t.Error("Lookup() found a deleted object")
+	}
+	if _, err := c.Upload(ctx, path, nil, false, "", storage.Conditions{GenerationMatch: current}); !gcs.IsPreconditionFailed(err) {
+		t.Errorf("Upload() of a deleted object got %v, want a precondition failure", err)
+	}
+}
+
+func TestObjects(t *testing.T) {
+	ctx := context.Background()
+	var c Client
+	for _, p := range []string{
+		"gs://bucket/logs/job/10/started.json",
+		"gs://bucket/logs/job/10/finished.json",
+		"gs://bucket/logs/job/9/started.json",
+		"gs://bucket/logs/job/latest-build.txt",
+		"gs://bucket/logs/jobless/1/started.json",
+		"gs://other/logs/job/11/started.json",
+	} {
+		c.Put(mustPath(t, p), []byte(p))
+	}
+
+	list := func(path gcs.Path, delimiter string) ([]string, error) {
+		var out []string
+		it := c.Objects(ctx, path, delimiter)
+		for {
+			attrs, err := it.Next()
+			if err == iterator.Done {
+				return out, nil
+			}
+			if err != nil {
+				return out, err
+			}
+			if attrs.Prefix != "" {
+				out = append(out, "prefix:"+attrs.Prefix)
+				continue
+			}
+			out = append(out, attrs.Name)
+		}
+	}
+
+	cases := []struct {
+		name      string
+		path      string
+		delimiter string
+		want      []string
+	}{
+		{
+			name:      "directory",
+			path:      "gs://bucket/logs/job/",
+			delimiter: "/",
+			want: []string{
+				"prefix:logs/job/10/",
+				"prefix:logs/job/9/",
+				"logs/job/latest-build.txt",
+			},
+		},
+		{
+			name: "recursive",
+			path: "gs://bucket/logs/job/1",
+			want: []string{
+				"logs/job/10/finished.json",
+				"logs/job/10/started.json",
+			},
+		},
+		{
+			name:      "prefix without trailing slash",
+			path:      "gs://bucket/logs/job",
+			delimiter: "/",
+			want: []string{
+				"prefix:logs/job/",
+				"prefix:logs/jobless/",
+			},
+		},
+		{
+			name: "missing",
+			path: "gs://bucket/missing/",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := list(mustPath(t, tc.path), tc.delimiter)
+			if err != nil {
+				t.Fatalf("Objects() got unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Objects() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	injected := errors.New("injected")
+	dir := mustPath(t, "gs://bucket/logs/job/")
+	c.Fail(dir, injected)
+	if _, err := list(dir, "/"); err != injected {
+		t.Errorf("Objects() got %v, want injected error", err)
+	}
+}
+
+func TestFailNext(t *testing.T) {
+	ctx := context.Background()
+	var c Client
+	path := mustPath(t, "gs://bucket/obj")
+	c.Put(path, []byte("hello"))
+	first, second, permanent := errors.New("first"), errors.New("second"), errors.New("permanent")
+	c.Fail(path, permanent)
+	c.FailNext(path, first, second)
+	for _, want := range []error{first, second, permanent} {
+		if _, _, err := c.Open(ctx, path); err != want {
+			t.Errorf("Open() got %v, want %v", err, want)
+		}
+	}
+	c.Fail(path, nil)
+	if _, _, err := c.Open(ctx, path); err != nil {
+		t.Errorf("Open() got unexpected error: %v", err)
+	}
+
+	dir := mustPath(t, "gs://bucket/")
+	c.Put(mustPath(t, "gs://bucket/other"), []byte("world"))
+	it := c.Objects(ctx, dir, "")
+	if attrs, err := it.Next(); err != nil || attrs.Name != "obj" {
+		t.Fatalf("Next() got %v, %v, want obj", attrs, err)
+	}
+	c.FailNext(dir, first)
+	if _, err := it.Next(); err != first {
+		t.Errorf("Next() got %v, want %v", err, first)
+	}
+	if attrs, err := it.Next(); err != nil || attrs.Name != "other" {
+		t.Errorf("Next() got %v, %v, want other", attrs, err)
+	}
+}
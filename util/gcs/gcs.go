@@ -22,10 +22,12 @@ import (
 	"fmt"
 	"hash/crc32"
 	"log"
+	"net/http"
 	"net/url"
 	"strings"
 
 	"cloud.google.com/go/storage"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 )
 
@@ -42,11 +44,28 @@ func ClientWithCreds(ctx context.Context, creds ...string) (*storage.Client, err
 	return storage.NewClient(ctx, options...)
 }
 
-// Path parses gs://bucket/obj urls
+// Path parses gs://bucket/obj urls, or file:///path/to/obj urls of local files.
 type Path struct {
 	url url.URL
 }
 
+// ParsePath parses a gs://bucket/obj url, collapsing duplicate slashes in obj.
+//
+// Unlike NewPath, a gs:// url must include a bucket.
+func ParsePath(path string) (*Path, error) {
+	p, err := NewPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if p.Bucket() == "" && !p.IsLocal() {
+		return nil, fmt.Errorf("gs:// url must include a bucket: %s", path)
+	}
+	p.url.Path = collapseSlashes(p.url.Path)
+	p.url.RawPath = ""
+	return p, nil
+}
+
+// NewPath parses a gs://bucket/obj url.
 func NewPath(path string) (*Path, error) {
 	var p Path
 	err := p.Set(path)
@@ -75,6 +94,16 @@ func (g *Path) SetURL(u *url.URL) error {
 	switch {
 	case u == nil:
 		return errors.New("nil url")
+	case u.Scheme == "file":
+		if u.Host != "" && u.Host != "localhost" {
+			return fmt.Errorf("file:// url must be local: %s", u)
+		}
+		if u.RawQuery != "" || u.Fragment != "" {
+			return fmt.Errorf("file:// url may not contain a ?query or #fragment: %s", u)
+		}
+		g.url = *u
+		g.url.Host = ""
+		return nil
 	case u.Scheme != "gs":
 		return fmt.Errorf("must use a gs:// url: %s", u)
 	case strings.Contains(u.Host, ":"):
@@ -101,12 +130,17 @@ func (g Path) ResolveReference(ref *url.URL) (*Path, error) {
 	return &newP, nil
 }
 
-// Bucket returns bucket in gs://bucket/obj
+// Bucket returns bucket in gs://bucket/obj, or an empty string for a file:// url.
 func (g Path) Bucket() string {
 	return g.url.Host
 }
 
-// Object returns path/to/something in gs://bucket/path/to/something
+// IsLocal returns true for a file:// url.
+func (g Path) IsLocal() bool {
+	return g.url.Scheme == "file"
+}
+
+// Object returns path/to/something in gs://bucket/path/to/something or file:///path/to/something
 func (g Path) Object() string {
 	if g.url.Path == "" {
 		return g.url.Path
@@ -114,6 +148,45 @@ func (g Path) Object() string {
 	return g.url.Path[1:]
 }
 
+// Join returns the path with the elements appended to its object, separated by a single slash.
+//
+// Elements are object names rather than urls, so a # or ? is part of the name.
+// The result ends with a slash when the last element does.
+func (g Path) Join(elem ...string) Path {
+	u := g.url
+	u.Path = collapseSlashes(strings.Join(append([]string{u.Path}, elem...), "/"))
+	u.RawPath = ""
+	return Path{url: u}
+}
+
+// Parent returns the directory containing the object, ending with a slash, or nil for a bucket.
+//
+// The parent of gs://bucket/path/to/obj and gs://bucket/path/to/obj/ is gs://bucket/path/to/.
+func (g Path) Parent() *Path {
+	obj := strings.TrimSuffix(g.Object(), "/")
+	if obj == "" {
+		return nil
+	}
+	u := g.url
+	u.Path = "/" + obj[:strings.LastIndex(obj, "/")+1]
+	u.RawPath = ""
+	return &Path{url: u}
+}
+
+// collapseSlashes replaces each run of slashes in the path with a single slash.
+func collapseSlashes(path string) string {
+	var b strings.Builder
+	prev := false
+	for _, r := range path {
+		slash := r == '/'
+		if !slash || !prev {
+			b.WriteRune(r)
+		}
+		prev = slash
+	}
+	return b.String()
+}
+
 func calcCRC(buf []byte) uint32 {
 	return crc32.Checksum(buf, crc32.MakeTable(crc32.Castagnoli))
 }
@@ -127,14 +200,37 @@ const (
 
 // Upload writes bytes to the specified Path
 func Upload(ctx context.Context, client *storage.Client, path Path, buf []byte, worldReadable bool, cacheControl string) error {
+	_, err := upload(ctx, client.Bucket(path.Bucket()).Object(path.Object()), path, buf, worldReadable, cacheControl, "")
+	return err
+}
+
+// UploadIf writes bytes to the specified Path when the object matches the conditions.
+//
+// Returns an error satisfying IsPreconditionFailed when it does not.
+func UploadIf(ctx context.Context, client *storage.Client, path Path, buf []byte, worldReadable bool, cacheControl string, cond storage.Conditions) error {
+	_, err := upload(ctx, client.Bucket(path.Bucket()).Object(path.Object()).If(cond), path, buf, worldReadable, cacheControl, "")
+	return err
+}
+
+// IsPreconditionFailed returns true when err means the object did not match the UploadIf conditions.
+func IsPreconditionFailed(err error) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusPreconditionFailed
+}
+
+// upload writes bytes to the object, returning the attributes of the new generation.
+func upload(ctx context.Context, obj *storage.ObjectHandle, path Path, buf []byte, worldReadable bool, cacheControl, contentEncoding string) (*storage.ObjectAttrs, error) {
 	crc := calcCRC(buf)
-	w := client.Bucket(path.Bucket()).Object(path.Object()).NewWriter(ctx)
+	w := obj.NewWriter(ctx)
 	if worldReadable {
 		w.ACL = []storage.ACLRule{{Entity: storage.AllUsers, Role: storage.RoleReader}}
 	}
 	if cacheControl != "" {
 		w.ObjectAttrs.CacheControl = cacheControl
 	}
+	if contentEncoding != "" {
+		w.ObjectAttrs.ContentEncoding = contentEncoding
+	}
 	w.SendCRC32C = true
 	// Send our CRC32 to ensure google received the same data we sent.
 	// See checksum example at:
@@ -144,12 +240,12 @@ func Upload(ctx context.Context, client *storage.Client, path Path, buf []byte,
 		log.Printf("Uploading %s: %d/%d...", path, bytes, len(buf))
 	}
 	if n, err := w.Write(buf); err != nil {
-		return fmt.Errorf("writing %s failed: %v", path, err)
+		return nil, fmt.Errorf("writing %s failed: %v", path, err)
 	} else if n != len(buf) {
-		return fmt.Errorf("partial write of %s: %d < %d", path, n, len(buf))
+		return nil, fmt.Errorf("partial write of %s: %d < %d", path, n, len(buf))
 	}
 	if err := w.Close(); err != nil {
-		return fmt.Errorf("closing %s failed: %v", path, err)
+		return nil, fmt.Errorf("closing %s failed: %w", path, err)
 	}
-	return nil
+	return w.Attrs(), nil
 }
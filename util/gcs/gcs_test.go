@@ -17,8 +17,14 @@ limitations under the License.
 package gcs
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
 	"net/url"
+	"strings"
 	"testing"
+
+	"google.golang.org/api/googleapi"
 )
 
 func Test_SetURL(t *testing.T) {
@@ -70,6 +76,26 @@ func Test_SetURL(t *testing.T) {
 			url:  "gs://first/second#fragment",
 			err:  true,
 		},
+		{
+			name:   "local file",
+			url:    "file:///path/to/file",
+			object: "path/to/file",
+		},
+		{
+			name:   "localhost file",
+			url:    "file://localhost/path/to/file",
+			object: "path/to/file",
+		},
+		{
+			name: "reject remote files",
+			url:  "file://example.com/path/to/file",
+			err:  true,
+		},
+		{
+			name: "reject file queries",
+			url:  "file:///path?query=true",
+			err:  true,
+		},
 	}
 	for _, tc := range cases {
 		var p Path
@@ -109,6 +135,195 @@ func Test_ResolveReference(t *testing.T) {
 	}
 }
 
+func Test_ParsePath(t *testing.T) {
+	cases := []struct {
+		name   string
+		url    string
+		err    bool
+		bucket string
+		object string
+	}{
+		{
+			name:   "bucket and object",
+			url:    "gs://first/second",
+			bucket: "first",
+			object: "second",
+		},
+		{
+			name:   "keep trailing slash",
+			url:    "gs://first/second/",
+			bucket: "first",
+			object: "second/",
+		},
+		{
+			name:   "collapse duplicate slashes",
+			url:    "gs://first//second///third//",
+			bucket: "first",
+			object: "second/third/",
+		},
+		{
+			name:   "escaped characters",
+			url:    "gs://first/a%23b%3Fc",
+			bucket: "first",
+			object: "a#b?c",
+		},
+		{
+			name: "reject empty bucket",
+			url:  "gs:///second",
+			err:  true,
+		},
+		{
+			name: "reject missing bucket",
+			url:  "gs://",
+			err:  true,
+		},
+		{
+			name: "reject queries",
+			url:  "gs://first/second?query=true",
+			err:  true,
+		},
+		{
+			name:   "local directory",
+			url:    "file:///path//to/dir/",
+			object: "path/to/dir/",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := ParsePath(tc.url)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("failed to raise an error")
+			default:
+				if p.Bucket() != tc.bucket {
+					t.Errorf("bad bucket %s != %s", p.Bucket(), tc.bucket)
+				}
+				if p.Object() != tc.object {
+					t.Errorf("bad object %s != %s", p.Object(), tc.object)
+				}
+				if local := strings.HasPrefix(tc.url, "file://"); p.IsLocal() != local {
+					t.Errorf("IsLocal() got %t, want %t", p.IsLocal(), local)
+				}
+			}
+		})
+	}
+}
+
+func Test_Join(t *testing.T) {
+	cases := []struct {
+		name string
+		path string
+		elem []string
+		want string
+	}{
+		{
+			name: "bucket",
+			path: "gs://bucket",
+			elem: []string{"foo", "bar"},
+			want: "gs://bucket/foo/bar",
+		},
+		{
+			name: "nothing to join",
+			path: "gs://bucket/foo",
+			want: "gs://bucket/foo",
+		},
+		{
+			name: "trailing slash prefix",
+			path: "gs://bucket/logs/",
+			elem: []string{"123"},
+			want: "gs://bucket/logs/123",
+		},
+		{
+			name: "keep trailing slash of last element",
+			path: "gs://bucket/logs",
+			elem: []string{"/123/"},
+			want: "gs://bucket/logs/123/",
+		},
+		{
+			name: "add trailing slash",
+			path: "gs://bucket/logs",
+			elem: []string{""},
+			want: "gs://bucket/logs/",
+		},
+		{
+			name: "fragment and query characters are names",
+			path: "gs://bucket/logs",
+			elem: []string{"a#b", "c?d=e"},
+			want: "gs://bucket/logs/a%23b/c%3Fd=e",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := ParsePath(tc.path)
+			if err != nil {
+				t.Fatalf("bad path: %v", err)
+			}
+			got := p.Join(tc.elem...)
+			if got.String() != tc.want {
+				t.Errorf("Join(%q) got %s, want %s", tc.elem, got, tc.want)
+			}
+			back, err := ParsePath(got.String())
+			if err != nil {
+				t.Fatalf("ParsePath(%s) got unexpected error: %v", got, err)
+			}
+			if back.Object() != got.Object() {
+				t.Errorf("ParsePath(%s) got object %q, want %q", got, back.Object(), got.Object())
+			}
+		})
+	}
+}
+
+func Test_Parent(t *testing.T) {
+	cases := []struct {
+		path string
+		want string
+	}{
+		{
+			path: "gs://bucket",
+		},
+		{
+			path: "gs://bucket/",
+		},
+		{
+			path: "gs://bucket/obj",
+			want: "gs://bucket/",
+		},
+		{
+			path: "gs://bucket/path/to/obj",
+			want: "gs://bucket/path/to/",
+		},
+		{
+			path: "gs://bucket/path/to/dir/",
+			want: "gs://bucket/path/to/",
+		},
+		{
+			path: "gs://bucket/path/a%23b",
+			want: "gs://bucket/path/",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			p, err := ParsePath(tc.path)
+			if err != nil {
+				t.Fatalf("bad path: %v", err)
+			}
+			got := p.Parent()
+			switch {
+			case got == nil:
+				if tc.want != "" {
+					t.Errorf("Parent() got nil, want %s", tc.want)
+				}
+			case got.String() != tc.want:
+				t.Errorf("Parent() got %s, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
 // Ensure that a == b => calcCRC(a) == calcCRC(b)
 func Test_calcCRC(t *testing.T) {
 	b1 := []byte("hello")
@@ -129,3 +344,41 @@ func Test_calcCRC(t *testing.T) {
 	}
 
 }
+
+func Test_IsPreconditionFailed(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "other error",
+			err:  errors.New("boom"),
+		},
+		{
+			name: "other api error",
+			err:  &googleapi.Error{Code: http.StatusServiceUnavailable},
+		},
+		{
+			name:     "precondition failed",
+			err:      &googleapi.Error{Code: http.StatusPreconditionFailed},
+			expected: true,
+		},
+		{
+			name:     "wrapped precondition failed",
+			err:      fmt.Errorf("closing gs://bucket/obj failed: %w", &googleapi.Error{Code: http.StatusPreconditionFailed}),
+			expected: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := IsPreconditionFailed(tc.err); actual != tc.expected {
+				t.Errorf("IsPreconditionFailed(%v) got %t, want %t", tc.err, actual, tc.expected)
+			}
+		})
+	}
+}
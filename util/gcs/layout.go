@@ -0,0 +1,94 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+)
+
+// Layout is how the pod-utilities of a job arrange its builds in a bucket.
+type Layout int
+
+const (
+	// FlatLayout stores each build under the job, such as logs/<job>/<build>/.
+	FlatLayout Layout = iota
+	// PresubmitLayout stores each build under its pull request, such as
+	// pr-logs/pull/<org_repo>/<pr>/<job>/<build>/, and lists the builds of a job
+	// as symlinks, such as pr-logs/directory/<job>/<build>.txt.
+	PresubmitLayout
+)
+
+func (l Layout) String() string {
+	if l == PresubmitLayout {
+		return "presubmit"
+	}
+	return "flat"
+}
+
+// presubmitDirectory holds the symlinks to the builds of each presubmit job.
+const presubmitDirectory = "pr-logs/directory/"
+
+// LayoutOf returns the layout of the builds under path, which is a job in the pr-logs/directory of presubmits.
+func LayoutOf(path Path) Layout {
+	obj := "/" + strings.TrimSuffix(path.Object(), "/")
+	idx := strings.Index(obj, "/"+presubmitDirectory)
+	if idx < 0 || len(obj) == idx+len(presubmitDirectory)+1 {
+		return FlatLayout
+	}
+	return PresubmitLayout
+}
+
+// LatestBuildName is the object in the directory of a job holding the ID of its newest build.
+const LatestBuildName = "latest-build.txt"
+
+// maxLatestBuildSize is the most bytes of a latest-build.txt to read.
+const maxLatestBuildSize = 256
+
+// LatestBuild returns the ID in the latest-build.txt of the job in dir, or an error wrapping storage.ErrObjectNotExist.
+func LatestBuild(ctx context.Context, client Client, dir Path) (string, error) {
+	latest := dir.Join(LatestBuildName)
+	r, _, err := client.Open(ctx, latest)
+	if err != nil {
+		return "", fmt.Errorf("open %s: %w", latest, err)
+	}
+	defer r.Close()
+	buf, err := ioutil.ReadAll(io.LimitReader(r, maxLatestBuildSize))
+	if err != nil {
+		return "", fmt.Errorf("read %s: %w", latest, err)
+	}
+	id := strings.TrimSpace(string(buf))
+	if id == "" || strings.ContainsAny(id, "/ \n") {
+		return "", fmt.Errorf("%s has a bad build id %q", latest, id)
+	}
+	return id, nil
+}
+
+// Job returns the name of the job of the build, which is the element before its ID.
+//
+// Builds of a presubmit job name the job after they Resolve, such as job for pr-logs/pull/org_repo/123/job/456/.
+func (build Build) Job() string {
+	dir := path.Dir(strings.TrimSuffix(build.Prefix, "/"))
+	if dir == "." || dir == "/" {
+		return ""
+	}
+	return path.Base(dir)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"testing"
+)
+
+func TestLayoutOf(t *testing.T) {
+	cases := []struct {
+		path string
+		want Layout
+	}{
+		{
+			path: "gs://bucket/logs/job",
+			want: FlatLayout,
+		},
+		{
+			path: "gs://bucket/pr-logs/pull/org_repo/123/job",
+			want: FlatLayout,
+		},
+		{
+			path: "gs://bucket/pr-logs/directory/job",
+			want: PresubmitLayout,
+		},
+		{
+			path: "gs://bucket/nested/pr-logs/directory/job/",
+			want: PresubmitLayout,
+		},
+		{
+			path: "gs://bucket/my-pr-logs/directory/job",
+			want: FlatLayout,
+		},
+		{
+			path: "gs://bucket/pr-logs/directory/",
+			want: FlatLayout,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			p, err := NewPath(tc.path)
+			if err != nil {
+				t.Fatalf("NewPath() got unexpected error: %v", err)
+			}
+			if got := LayoutOf(*p); got != tc.want {
+				t.Errorf("LayoutOf() got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBuildJob(t *testing.T) {
+	cases := []struct {
+		prefix string
+		want   string
+	}{
+		{
+			prefix: "logs/job/123/",
+			want:   "job",
+		},
+		{
+			prefix: "pr-logs/pull/org_repo/5/job/456/",
+			want:   "job",
+		},
+		{
+			prefix: "123/",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.prefix, func(t *testing.T) {
+			if got := (Build{Prefix: tc.prefix}).Job(); got != tc.want {
+				t.Errorf("Job() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
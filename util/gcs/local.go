@@ -0,0 +1,238 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iterator"
+)
+
+// NewLocalClient returns a Client of the files at file:// paths, for reading builds from a local directory.
+//
+// The generation of a file is its modification time in nanoseconds.
+// Directories are listed as prefixes even when they do not contain any files.
+func NewLocalClient() Client {
+	return localClient{}
+}
+
+type localClient struct{}
+
+// file returns the local filename of the path.
+func (localClient) file(path Path) (string, error) {
+	if !path.IsLocal() {
+		return "", fmt.Errorf("not a file:// path: %s", path)
+	}
+	return filepath.FromSlash("/" + path.Object()), nil
+}
+
+// localAttrs converts the file info of the named object.
+func localAttrs(name string, info os.FileInfo) *storage.ObjectAttrs {
+	return &storage.ObjectAttrs{
+		Name:           name,
+		Size:           info.Size(),
+		Updated:        info.ModTime(),
+		Created:        info.ModTime(),
+		Generation:     info.ModTime().UnixNano(),
+		Metageneration: 1,
+	}
+}
+
+// stat returns the info of the file, or storage.ErrObjectNotExist for a missing file or a directory.
+func (c localClient) stat(path Path) (string, os.FileInfo, error) {
+	name, err := c.file(path)
+	if err != nil {
+		return "", nil, err
+	}
+	info, err := os.Stat(name)
+	if os.IsNotExist(err) || err == nil && info.IsDir() {
+		return name, nil, storage.ErrObjectNotExist
+	}
+	if err != nil {
+		return name, nil, err
+	}
+	return name, info, nil
+}
+
+func (c localClient) Open(ctx context.Context, path Path) (io.ReadCloser, *storage.ReaderObjectAttrs, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	name, info, err := c.stat(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, &storage.ReaderObjectAttrs{
+		Size:           info.Size(),
+		LastModified:   info.ModTime(),
+		Generation:     info.ModTime().UnixNano(),
+		Metageneration: 1,
+	}, nil
+}
+
+func (c localClient) Attrs(ctx context.Context, path Path) (*storage.ObjectAttrs, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	_, info, err := c.stat(path)
+	if err != nil {
+		return nil, err
+	}
+	return localAttrs(path.Object(), info), nil
+}
+
+func (c localClient) Objects(ctx context.Context, path Path, delimiter string) Iterator {
+	if _, err := c.file(path); err != nil {
+		return &localIterator{err: err}
+	}
+	prefix := path.Object()
+	root := filepath.FromSlash("/" + prefix[:strings.LastIndex(prefix, "/")+1])
+	var objs []*storage.ObjectAttrs
+	seen := map[string]bool{}
+	err := filepath.Walk(root, func(file string, info os.FileInfo, err error) error {
+		if os.IsNotExist(err) && file == root {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		name := strings.TrimPrefix(filepath.ToSlash(file), "/")
+		if info.IsDir() {
+			name += "/"
+			if file == root {
+				return nil
+			}
+			if !strings.HasPrefix(name, prefix) && !strings.HasPrefix(prefix, name) {
+				return filepath.SkipDir
+			}
+			if delimiter == "/" && strings.HasPrefix(name, prefix) {
+				if !seen[name] {
+					seen[name] = true
+					objs = append(objs, &storage.ObjectAttrs{Prefix: name})
+				}
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasPrefix(name, prefix) {
+			return nil
+		}
+		if delimiter != "" {
+			if idx := strings.Index(name[len(prefix):], delimiter); idx >= 0 {
+				pref := name[:len(prefix)+idx+len(delimiter)]
+				if !seen[pref] {
+					seen[pref] = true
+					objs = append(objs, &storage.ObjectAttrs{Prefix: pref})
+				}
+				return nil
+			}
+		}
+		objs = append(objs, localAttrs(name, info))
+		return nil
+	})
+	if err != nil {
+		return &localIterator{err: fmt.Errorf("walk %s: %w", root, err)}
+	}
+	sort.Slice(objs, func(i, j int) bool {
+		return objs[i].Name+objs[i].Prefix < objs[j].Name+objs[j].Prefix
+	})
+	return &localIterator{objs: objs}
+}
+
+type localIterator struct {
+	objs []*storage.ObjectAttrs
+	err  error
+}
+
+func (it *localIterator) Next() (*storage.ObjectAttrs, error) {
+	if it.err != nil {
+		return nil, it.err
+	}
+	if len(it.objs) == 0 {
+		return nil, iterator.Done
+	}
+	obj := it.objs[0]
+	it.objs = it.objs[1:]
+	return obj, nil
+}
+
+// Upload atomically replaces the file when it matches the conditions, ignoring worldReadable and cacheControl.
+func (c localClient) Upload(ctx context.Context, path Path, buf []byte, worldReadable bool, cacheControl string, cond storage.Conditions) (*storage.ObjectAttrs, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	name, info, err := c.stat(path)
+	if err != nil && err != storage.ErrObjectNotExist {
+		return nil, err
+	}
+	var precondition string
+	switch {
+	case cond.DoesNotExist && info != nil:
+		precondition = "file exists"
+	case cond.GenerationMatch != 0 && info == nil:
+		precondition = "file does not exist"
+	case cond.GenerationMatch != 0 && info.ModTime().UnixNano() != cond.GenerationMatch:
+		precondition = fmt.Sprintf("generation %d, want %d", info.ModTime().UnixNano(), cond.GenerationMatch)
+	}
+	if precondition != "" {
+		return nil, fmt.Errorf("write %s failed: %w", path, &googleapi.Error{
+			Code:    http.StatusPreconditionFailed,
+			Message: precondition,
+		})
+	}
+
+	dir := filepath.Dir(name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	f, err := ioutil.TempFile(dir, ".upload-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(buf); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("write %s: %v", f.Name(), err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("close %s: %v", f.Name(), err)
+	}
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(f.Name(), name); err != nil {
+		return nil, err
+	}
+	return c.Attrs(ctx, path)
+}
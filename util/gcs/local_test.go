@@ -0,0 +1,196 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+)
+
+// localDir returns a temporary directory with the files, and its file:// url.
+func localDir(t *testing.T, files ...string) (string, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "local")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	for _, f := range files {
+		name := filepath.Join(dir, filepath.FromSlash(f))
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatalf("MkdirAll: %v", err)
+		}
+		if err := ioutil.WriteFile(name, []byte(f), 0644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+	return "file://" + filepath.ToSlash(dir), func() { os.RemoveAll(dir) }
+}
+
+func mustParse(t *testing.T, s string) Path {
+	t.Helper()
+	p, err := ParsePath(s)
+	if err != nil {
+		t.Fatalf("ParsePath(%s): %v", s, err)
+	}
+	return *p
+}
+
+func TestLocalClientOpen(t *testing.T) {
+	ctx := context.Background()
+	dir, cleanup := localDir(t, "logs/job/1/started.json")
+	defer cleanup()
+	client := NewLocalClient()
+
+	r, attrs, err := client.Open(ctx, mustParse(t, dir+"/logs/job/1/started.json"))
+	if err != nil {
+		t.Fatalf("Open() got unexpected error: %v", err)
+	}
+	defer r.Close()
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if got, want := string(buf), "logs/job/1/started.json"; got != want {
+		t.Errorf("Open() read %q, want %q", got, want)
+	}
+	if attrs.Size != int64(len(buf)) || attrs.Generation == 0 {
+		t.Errorf("Open() got attrs %#v", attrs)
+	}
+
+	for _, missing := range []string{"/logs/job/1/finished.json", "/logs/job/1"} {
+		if _, _, err := client.Open(ctx, mustParse(t, dir+missing)); err != storage.ErrObjectNotExist {
+			t.Errorf("Open(%s) got %v, want ErrObjectNotExist", missing, err)
+		}
+		if _, err := client.Attrs(ctx, mustParse(t, dir+missing)); err != storage.ErrObjectNotExist {
+			t.Errorf("Attrs(%s) got %v, want ErrObjectNotExist", missing, err)
+		}
+	}
+	if _, _, err := client.Open(ctx, mustParse(t, "gs://bucket/obj")); err == nil {
+		t.Error("Open(gs://) failed to return an error")
+	}
+}
+
+func TestLocalClientObjects(t *testing.T) {
+	dir, cleanup := localDir(t,
+		"logs/job/10/started.json",
+		"logs/job/10/artifacts/junit_01.xml",
+		"logs/job/9/started.json",
+		"logs/job/latest-build.txt",
+		"logs/jobless/1/started.json",
+	)
+	defer cleanup()
+	root := strings.TrimPrefix(dir, "file:///") + "/"
+
+	cases := []struct {
+		name      string
+		path      string
+		delimiter string
+		want      []string
+	}{
+		{
+			name:      "directory",
+			path:      "/logs/job/",
+			delimiter: "/",
+			want: []string{
+				"prefix:logs/job/10/",
+				"prefix:logs/job/9/",
+				"logs/job/latest-build.txt",
+			},
+		},
+		{
+			name: "recursive",
+			path: "/logs/job/1",
+			want: []string{
+				"logs/job/10/artifacts/junit_01.xml",
+				"logs/job/10/started.json",
+			},
+		},
+		{
+			name:      "prefix without trailing slash",
+			path:      "/logs/job",
+			delimiter: "/",
+			want: []string{
+				"prefix:logs/job/",
+				"prefix:logs/jobless/",
+			},
+		},
+		{
+			name: "missing",
+			path: "/missing/",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			it := NewLocalClient().Objects(context.Background(), mustParse(t, dir+tc.path), tc.delimiter)
+			var got []string
+			for {
+				attrs, err := it.Next()
+				if err == iterator.Done {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Next() got unexpected error: %v", err)
+				}
+				if attrs.Prefix != "" {
+					got = append(got, "prefix:"+strings.TrimPrefix(attrs.Prefix, root))
+					continue
+				}
+				got = append(got, strings.TrimPrefix(attrs.Name, root))
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Objects() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLocalClientUpload(t *testing.T) {
+	ctx := context.Background()
+	dir, cleanup := localDir(t)
+	defer cleanup()
+	client := NewLocalClient()
+	path := mustParse(t, dir+"/grids/foo")
+
+	attrs, err := client.Upload(ctx, path, []byte("hello"), DefaultAcl, "no-cache", storage.Conditions{DoesNotExist: true})
+	if err != nil {
+		t.Fatalf("Upload() got unexpected error: %v", err)
+	}
+	if _, err := client.Upload(ctx, path, []byte("again"), DefaultAcl, "", storage.Conditions{DoesNotExist: true}); !IsPreconditionFailed(err) {
+		t.Errorf("Upload() of an existing file got %v, want a precondition failure", err)
+	}
+	if _, err := client.Upload(ctx, path, []byte("stale"), DefaultAcl, "", storage.Conditions{GenerationMatch: attrs.Generation + 1}); !IsPreconditionFailed(err) {
+		t.Errorf("Upload() of another generation got %v, want a precondition failure", err)
+	}
+	if _, err := client.Upload(ctx, path, []byte("world"), DefaultAcl, "", storage.Conditions{GenerationMatch: attrs.Generation}); err != nil {
+		t.Errorf("Upload() of the current generation got unexpected error: %v", err)
+	}
+	buf, err := ioutil.ReadFile("/" + path.Object())
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(buf) != "world" {
+		t.Errorf("Upload() wrote %q, want world", buf)
+	}
+}
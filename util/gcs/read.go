@@ -17,19 +17,22 @@ limitations under the License.
 package gcs
 
 import (
+	"container/heap"
 	"context"
-	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/url"
+	"path"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
 	"cloud.google.com/go/storage"
+	"github.com/sirupsen/logrus"
 	"google.golang.org/api/iterator"
-	"vbom.ml/util/sortorder"
 
 	"github.com/GoogleCloudPlatform/testgrid/metadata"
 	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
@@ -51,14 +54,92 @@ type Finished struct {
 
 // Build points to a build stored under a particular gcs prefix.
 type Build struct {
-	Bucket         *storage.BucketHandle
+	// Client reads the objects of the build.
+	Client         Client
 	Prefix         string
 	BucketPath     string
 	originalPrefix string
+	// symlink is the name of the object pointing at the build, when listed from one.
+	symlink string
+	// local builds are files under file:///Prefix rather than in a bucket.
+	local bool
+	// MaxMessage is the number of bytes of each junit message to keep, or all of them when zero.
+	MaxMessage int
+	// Cache holds the junit suites other reads parsed, when set.
+	Cache *SuitesCache
+}
+
+// object returns the path of the named object in the bucket of the build.
+func (build Build) object(name string) Path {
+	if build.local {
+		return Path{url: url.URL{Scheme: "file", Path: "/" + name}}
+	}
+	return Path{url: url.URL{Scheme: "gs", Host: build.BucketPath, Path: "/" + name}}
+}
+
+// url returns the unescaped url of the named object.
+func (build Build) url(name string) string {
+	if build.local {
+		return "file:///" + name
+	}
+	return "gs://" + build.BucketPath + "/" + name
 }
 
 func (build Build) String() string {
-	return "gs://" + build.BucketPath + "/" + build.Prefix
+	return build.url(build.Prefix)
+}
+
+// ID returns the name of the build, which is the last element of its prefix.
+//
+// Builds resolved from a symlink keep the name of the symlink: 123 for 123.txt.
+func (build Build) ID() string {
+	if build.symlink != "" {
+		return path.Base(strings.TrimSuffix(build.symlink, SymlinkSuffix))
+	}
+	return path.Base(build.Prefix)
+}
+
+// SymlinkSuffix ends the name of an object holding the gs:// url of the build it stands for.
+const SymlinkSuffix = ".txt"
+
+// maxSymlinkSize is the most bytes of a symlink to read.
+const maxSymlinkSize = 4096
+
+// Resolve returns the build the symlink points at, or the build itself when it is not a symlink.
+//
+// Only one level of indirection is followed: a symlink pointing at another
+// symlink, or at a prefix containing the symlink itself, is an error.
+func (build Build) Resolve(ctx context.Context) (Build, error) {
+	if build.symlink == "" || build.Prefix != build.symlink { // Not a symlink, or already resolved
+		return build, nil
+	}
+	reader, _, err := build.Client.Open(ctx, build.object(build.symlink))
+	if err != nil {
+		return build, fmt.Errorf("open symlink %s: %v", build.symlink, err)
+	}
+	defer reader.Close()
+	buf, err := ioutil.ReadAll(io.LimitReader(reader, maxSymlinkSize))
+	if err != nil {
+		return build, fmt.Errorf("read symlink %s: %v", build.symlink, err)
+	}
+	link := strings.TrimSpace(string(buf))
+	target, err := ParsePath(link)
+	if err != nil || target.IsLocal() {
+		return build, fmt.Errorf("symlink %s has a bad gs:// url %q", build.symlink, link)
+	}
+	obj := target.Object()
+	if strings.HasSuffix(strings.TrimSuffix(obj, "/"), SymlinkSuffix) {
+		return build, fmt.Errorf("symlink %s points at another symlink %s", build.symlink, link)
+	}
+	if !strings.HasSuffix(obj, "/") {
+		obj += "/"
+	}
+	if target.Bucket() == build.BucketPath && strings.HasPrefix(build.symlink, obj) {
+		return build, fmt.Errorf("symlink %s points at itself through %s", build.symlink, link)
+	}
+	build.Prefix = obj
+	build.BucketPath = target.Bucket()
+	return build, nil
 }
 
 // Builds is a slice of builds.
@@ -67,33 +148,191 @@ type Builds []Build
 func (b Builds) Len() int      { return len(b) }
 func (b Builds) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
 
-// Expect builds to be in monotonically increasing order.
-// So build8 < build9 < build10 < build888
+// Less sorts builds by the number ending their ID, so build8 < build9 < build10 < build888.
+//
+// Builds without a number are older than numbered ones, ordered lexicographically like equal numbers.
 func (b Builds) Less(i, j int) bool {
-	return sortorder.NaturalLess(b[i].originalPrefix, b[j].originalPrefix)
+	ni, iok := buildNumber(b[i].originalPrefix)
+	nj, jok := buildNumber(b[j].originalPrefix)
+	switch {
+	case iok != jok:
+		return jok
+	case iok && ni != nj:
+		return ni < nj
+	}
+	return b[i].originalPrefix < b[j].originalPrefix
+}
+
+// buildNumber returns the trailing digits of the last element of prefix, such as 123 for logs/job-123/.
+func buildNumber(prefix string) (uint64, bool) {
+	name := strings.TrimSuffix(strings.TrimSuffix(prefix, "/"), SymlinkSuffix)
+	end := len(name)
+	start := end
+	for start > 0 && name[start-1] >= '0' && name[start-1] <= '9' {
+		start--
+	}
+	if start == end {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(name[start:end], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// oldestFirst is a heap of builds whose root is the oldest one.
+type oldestFirst struct{ Builds }
+
+func (h *oldestFirst) Push(x interface{}) { h.Builds = append(h.Builds, x.(Build)) }
+func (h *oldestFirst) Pop() interface{} {
+	last := h.Builds[len(h.Builds)-1]
+	h.Builds = h.Builds[:len(h.Builds)-1]
+	return last
+}
+
+// ListOptions control the builds ListBuilds returns.
+type ListOptions struct {
+	// Symlinks lists objects ending in SymlinkSuffix as builds too, which callers must Resolve before reading them.
+	//
+	// Builds in the PresubmitLayout are always symlinks.
+	Symlinks bool
+	// Max keeps only the Max newest builds when positive.
+	Max int
 }
 
 // ListBuilds returns the array of builds under path, sorted in monotonically decreasing order.
-func ListBuilds(parent context.Context, client *storage.Client, path Path) (Builds, error) {
+//
+// Buckets list objects lexicographically, so without a Max every build is visited.
+// With a Max it only holds and sorts the newest ones, and stops listing early when
+// a numeric latest-build.txt marker locates them, see listNewest.
+// The marker may also name a newer build the listing does not include yet.
+func ListBuilds(parent context.Context, client Client, path Path, opts ListOptions) (Builds, error) {
 	ctx, cancel := context.WithCancel(parent)
 	defer cancel()
-	p := path.Object()
-	if !strings.HasSuffix(p, "/") {
-		p += "/"
-	}
-	bkt := client.Bucket(path.Bucket())
-	it := bkt.Objects(ctx, &storage.Query{
-		Delimiter: "/",
-		Prefix:    p,
-	})
-	var all Builds
+	symlinks := (opts.Symlinks || LayoutOf(path) == PresubmitLayout) && !path.IsLocal()
+	var all oldestFirst
+	var latest string // the ID in the marker
+	var listed bool
+	// Local directories list consistently, and quickly.
+	if opts.Max > 0 && !path.IsLocal() {
+		if id, err := LatestBuild(ctx, client, path); err == nil {
+			latest = id
+			if all, listed, err = listNewest(ctx, client, path, id, opts.Max, symlinks); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if !listed {
+		all = oldestFirst{}
+		marker, err := listPrefix(ctx, client, path, "", symlinks, func(b Build) { keepNewest(&all, b, opts.Max) })
+		if err != nil {
+			return nil, err
+		}
+		if marker && latest == "" && !path.IsLocal() {
+			if latest, err = LatestBuild(ctx, client, path); err != nil {
+				logrus.WithError(err).WithField("path", path).Warning("Ignoring latest build marker")
+			}
+		}
+	}
+	if latest != "" {
+		if build := markedBuild(client, path, latest, symlinks); newest(all.Builds, build) {
+			keepNewest(&all, build, opts.Max)
+		}
+	}
+	sort.Sort(sort.Reverse(all.Builds))
+	return all.Builds, nil
+}
+
+// keepNewest adds the build to the heap, keeping only the max newest builds when max is positive.
+func keepNewest(all *oldestFirst, build Build, max int) {
+	heap.Push(all, build)
+	if max > 0 && all.Len() > max {
+		heap.Pop(all)
+	}
+}
+
+// listNewestStep is the number of digits listNewest drops from the marked ID each time it lists more builds.
+//
+// Each step lists up to a thousand times more IDs, about one more page of objects for a job numbering its builds densely.
+const listNewestStep = 3
+
+// listNewest lists the max newest builds under path, when their IDs share enough leading digits with the id of the marker.
+//
+// Numbers of the same width sort the same lexicographically, so listing the IDs that start with
+// a prefix of the marked id finds every build of that width between the oldest one listed and the
+// marker. Builds newer than the marker start with the prefix after that one, and builds numbered
+// with fewer digits are older. Thus the listing stops once the prefix matches max builds numbered
+// like the marker, dropping listNewestStep more digits from the prefix until it does.
+//
+// Returns false when the id is not a number, or when its leading digits would not narrow the listing,
+// leaving the caller to list every build.
+func listNewest(ctx context.Context, client Client, path Path, id string, max int, symlinks bool) (oldestFirst, bool, error) {
+	if !isDigits(id) {
+		return oldestFirst{}, false, nil
+	}
+	for drop := listNewestStep; drop < len(id); drop += listNewestStep {
+		lead := id[:len(id)-drop]
+		var builds oldestFirst
+		var numbered int
+		add := func(b Build) {
+			name := strings.TrimSuffix(b.originalPrefix, "/")
+			if name = name[strings.LastIndex(name, "/")+1:]; len(name) == len(id) && isDigits(name) {
+				numbered++
+			}
+			keepNewest(&builds, b, max)
+		}
+		if _, err := listPrefix(ctx, client, path, lead, symlinks, add); err != nil {
+			return oldestFirst{}, false, err
+		}
+		if numbered < max {
+			continue
+		}
+		if next, ok := nextNumber(lead); ok {
+			if _, err := listPrefix(ctx, client, path, next, symlinks, add); err != nil {
+				return oldestFirst{}, false, err
+			}
+		}
+		return builds, true, nil
+	}
+	return oldestFirst{}, false, nil
+}
+
+// isDigits returns true when s is a non-empty string of decimal digits.
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// nextNumber returns the decimal number after n with as many digits, or false when n is all nines.
+func nextNumber(n string) (string, bool) {
+	b := []byte(n)
+	for i := len(b) - 1; i >= 0; i-- {
+		if b[i] < '9' {
+			b[i]++
+			return string(b), true
+		}
+		b[i] = '0'
+	}
+	return "", false
+}
+
+// listPrefix adds each build under path whose ID starts with prefix, returning true when it lists the latest-build.txt marker.
+func listPrefix(ctx context.Context, client Client, path Path, prefix string, symlinks bool, add func(Build)) (bool, error) {
+	var marker bool
+	// List the directory, so gs://bucket/foo excludes gs://bucket/foobar.
+	it := client.Objects(ctx, path.Join("/"+prefix), "/")
 	for {
 		objAttrs, err := it.Next()
 		if err == iterator.Done {
 			break
 		}
 		if err != nil {
-			return nil, fmt.Errorf("failed to list objects: %v", err)
+			return false, fmt.Errorf("failed to list objects: %v", err)
 		}
 
 		// if this is a link under directory, resolve the build value
@@ -102,17 +341,17 @@ func ListBuilds(parent context.Context, client *storage.Client, path Path) (Buil
 			link = strings.TrimSpace(link)
 			u, err := url.Parse(link)
 			if err != nil {
-				return nil, fmt.Errorf("could not parse link for key %s: %v", objAttrs.Name, err)
+				return false, fmt.Errorf("could not parse link for key %s: %v", objAttrs.Name, err)
 			}
 			if !strings.HasSuffix(u.Path, "/") {
 				u.Path += "/"
 			}
 			var linkPath Path
 			if err := linkPath.SetURL(u); err != nil {
-				return nil, fmt.Errorf("could not make GCS path for key %s: %v", objAttrs.Name, err)
+				return false, fmt.Errorf("could not make GCS path for key %s: %v", objAttrs.Name, err)
 			}
-			all = append(all, Build{
-				Bucket:         bkt,
+			add(Build{
+				Client:         client,
 				Prefix:         linkPath.Object(),
 				BucketPath:     path.Bucket(),
 				originalPrefix: objAttrs.Name,
@@ -120,19 +359,67 @@ func ListBuilds(parent context.Context, client *storage.Client, path Path) (Buil
 			continue
 		}
 
+		if objAttrs.Prefix == "" && strings.HasSuffix("/"+objAttrs.Name, "/"+LatestBuildName) {
+			marker = true
+			continue
+		}
+
+		if symlinks && objAttrs.Prefix == "" && strings.HasSuffix(objAttrs.Name, SymlinkSuffix) {
+			add(symlinkBuild(client, path, objAttrs.Name))
+			continue
+		}
+
 		if len(objAttrs.Prefix) == 0 {
 			continue
 		}
 
-		all = append(all, Build{
-			Bucket:         bkt,
+		add(Build{
+			Client:         client,
 			Prefix:         objAttrs.Prefix,
 			BucketPath:     path.Bucket(),
 			originalPrefix: objAttrs.Prefix,
+			local:          path.IsLocal(),
 		})
 	}
-	sort.Sort(sort.Reverse(all))
-	return all, nil
+	return marker, nil
+}
+
+// symlinkBuild returns the build of the symlink named name, which callers must Resolve.
+func symlinkBuild(client Client, path Path, name string) Build {
+	return Build{
+		Client:         client,
+		Prefix:         name,
+		BucketPath:     path.Bucket(),
+		originalPrefix: strings.TrimSuffix(name, SymlinkSuffix) + "/",
+		symlink:        name,
+	}
+}
+
+// markedBuild returns the build with the id of the latest-build.txt under path.
+//
+// Jobs listed as symlinks name a symlink, others a build prefix.
+func markedBuild(client Client, path Path, id string, symlinks bool) Build {
+	if symlinks && LayoutOf(path) == PresubmitLayout {
+		return symlinkBuild(client, path, path.Join(id+SymlinkSuffix).Object())
+	}
+	prefix := path.Join(id + "/").Object()
+	return Build{
+		Client:         client,
+		Prefix:         prefix,
+		BucketPath:     path.Bucket(),
+		originalPrefix: prefix,
+	}
+}
+
+// newest returns true when the build is newer than every build.
+func newest(builds Builds, build Build) bool {
+	all := append(Builds{build}, builds...)
+	for i := 1; i < len(all); i++ {
+		if !all.Less(i, 0) {
+			return false
+		}
+	}
+	return true
 }
 
 // junit_CONTEXT_TIMESTAMP_THREAD.xml
@@ -167,55 +454,48 @@ func parseSuitesMeta(name string) map[string]string {
 
 }
 
-// readJSON will decode the json object stored in GCS.
-func readJSON(ctx context.Context, obj *storage.ObjectHandle, i interface{}) error {
-	reader, err := obj.NewReader(ctx)
-	if err == storage.ErrObjectNotExist {
-		return err
-	}
-	if err != nil {
-		return fmt.Errorf("open: %v", err)
-	}
-	if err = json.NewDecoder(reader).Decode(i); err != nil {
-		return fmt.Errorf("decode: %v", err)
-	}
-	return nil
-}
-
 // Started parses the build's started metadata.
 func (build Build) Started(ctx context.Context) (*Started, error) {
 	uri := build.Prefix + "started.json"
-	var started Started
-	err := readJSON(ctx, build.Bucket.Object(uri), &started)
+	reader, _, err := build.Client.Open(ctx, build.object(uri))
 	if err == storage.ErrObjectNotExist {
-		started.Pending = true
-		return &started, nil
+		return &Started{Pending: true}, nil
 	}
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %v", uri, err)
+	}
+	defer reader.Close()
+	started, err := metadata.ReadStarted(reader)
 	if err != nil {
 		return nil, fmt.Errorf("read %s: %v", uri, err)
 	}
-	return &started, nil
+	return &Started{Started: *started}, nil
 }
 
 // Finished parses the build's finished metadata.
+//
+// A missing finished.json means the build is Running.
 func (build Build) Finished(ctx context.Context) (*Finished, error) {
 	uri := build.Prefix + "finished.json"
-	var finished Finished
-	err := readJSON(ctx, build.Bucket.Object(uri), &finished)
+	reader, _, err := build.Client.Open(ctx, build.object(uri))
 	if err == storage.ErrObjectNotExist {
-		finished.Running = true
-		return &finished, nil
+		return &Finished{Running: true}, nil
 	}
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %v", uri, err)
+	}
+	defer reader.Close()
+	finished, err := metadata.ReadFinished(reader)
 	if err != nil {
 		return nil, fmt.Errorf("read %s: %v", uri, err)
 	}
-	return &finished, nil
+	return &Finished{Finished: *finished}, nil
 }
 
 // Artifacts writes the object name of all paths under the build's artifact dir to the output channel.
 func (build Build) Artifacts(ctx context.Context, artifacts chan<- string) error {
 	pref := build.Prefix
-	objs := build.Bucket.Objects(ctx, &storage.Query{Prefix: pref})
+	objs := build.Client.Objects(ctx, build.object(pref), "")
 	for {
 		obj, err := objs.Next()
 		if err == iterator.Done {
@@ -233,22 +513,29 @@ func (build Build) Artifacts(ctx context.Context, artifacts chan<- string) error
 	return nil
 }
 
-// readSuites parses the <testsuite> or <testsuites> object in obj
-func readSuites(ctx context.Context, obj *storage.ObjectHandle) (*junit.Suites, error) {
-	reader, err := obj.NewReader(ctx)
+// readSuites parses the <testsuite> or <testsuites> object at path, keeping max bytes of each message.
+//
+// Reuses the suites in the cache, when set, parsed from the same generation of the object.
+func readSuites(ctx context.Context, client Client, path Path, max int, cache *SuitesCache) (*junit.Suites, error) {
+	reader, attrs, err := client.Open(ctx, path)
 	if err != nil {
 		return nil, fmt.Errorf("open: %v", err)
 	}
+	defer reader.Close()
 
-	buf, err := ioutil.ReadAll(reader)
-	if err != nil {
-		return nil, fmt.Errorf("read: %v", err)
+	// Local files have no generation to tell their versions apart.
+	if cache != nil && attrs != nil && attrs.Generation != 0 {
+		if suites, ok := cache.get(path, attrs.Generation, max); ok {
+			return suites, nil
+		}
 	}
-
-	suites, err := junit.Parse(buf)
+	suites, err := junit.ParseStreamLimit(reader, max)
 	if err != nil {
 		return nil, fmt.Errorf("parse: %v", err)
 	}
+	if cache != nil && attrs != nil && attrs.Generation != 0 {
+		cache.put(path, attrs.Generation, attrs.Size, max, suites)
+	}
 	return &suites, nil
 }
 
@@ -278,7 +565,7 @@ func (build Build) Suites(parent context.Context, artifacts <-chan string, suite
 		// each takes a non-trivial amount of time waiting for the network.
 		go func(art string, meta map[string]string) {
 			defer wg.Done()
-			suitesData, err := readSuites(ctx, build.Bucket.Object(art))
+			suitesData, err := readSuites(ctx, build.Client, build.object(art), build.MaxMessage, build.Cache)
 			if err != nil {
 				select {
 				case <-ctx.Done():
@@ -289,7 +576,7 @@ func (build Build) Suites(parent context.Context, artifacts <-chan string, suite
 			out := SuitesMeta{
 				Suites:   *suitesData,
 				Metadata: meta,
-				Path:     "gs://" + build.BucketPath + "/" + art,
+				Path:     build.url(art),
 			}
 			select {
 			case <-ctx.Done():
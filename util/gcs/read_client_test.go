@@ -0,0 +1,357 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"cloud.google.com/go/storage"
+
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestListBuilds(t *testing.T) {
+	cases := []struct {
+		name    string
+		objects []string
+		opts    gcs.ListOptions
+		want    []string
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name:    "sort numerically",
+			objects: []string{"1/started.json", "100/started.json", "10/started.json", "9/started.json", "99/started.json"},
+			want:    []string{"100", "99", "10", "9", "1"},
+		},
+		{
+			name:    "sort trailing numbers",
+			objects: []string{"build8/a", "build10/a", "build888/a", "build9/a"},
+			want:    []string{"build888", "build10", "build9", "build8"},
+		},
+		{
+			name:    "unnumbered builds are oldest",
+			objects: []string{"latest/a", "5/a", "abc/a", "12/a"},
+			want:    []string{"12", "5", "latest", "abc"},
+		},
+		{
+			name:    "keep the newest builds",
+			objects: []string{"3/a", "20/a", "1/a", "100/a", "4/a"},
+			opts:    gcs.ListOptions{Max: 2},
+			want:    []string{"100", "20"},
+		},
+		{
+			name:    "skip objects",
+			objects: []string{"1/a", "2.txt", "README"},
+			want:    []string{"1"},
+		},
+		{
+			name:    "list symlinks",
+			objects: []string{"1/a", "2.txt", "10.txt", "README"},
+			opts:    gcs.ListOptions{Symlinks: true},
+			want:    []string{"10", "2", "1"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var client fake.Client
+			for _, name := range tc.objects {
+				client.Put(mustPath(t, "gs://bucket/logs/job/"+name), []byte("gs://bucket/pull/job/1"))
+			}
+			builds, err := gcs.ListBuilds(context.Background(), &client, mustPath(t, "gs://bucket/logs/job"), tc.opts)
+			if err != nil {
+				t.Fatalf("ListBuilds() got unexpected error: %v", err)
+			}
+			var got []string
+			for _, b := range builds {
+				got = append(got, b.ID())
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ListBuilds() got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestListBuildsLayouts(t *testing.T) {
+	// A mixed bucket, with the builds of a postsubmit and presubmit job.
+	objects := map[string]string{
+		"logs/post/1/started.json":                     "{}",
+		"logs/post/2/started.json":                     "{}",
+		"logs/post/latest-build.txt":                   "3\n",
+		"pr-logs/directory/pre/10.txt":                 "gs://bucket/pr-logs/pull/org_repo/5/pre/10",
+		"pr-logs/directory/pre/11.txt":                 "gs://bucket/pr-logs/pull/org_repo/6/pre/11",
+		"pr-logs/directory/pre/latest-build.txt":       "12",
+		"pr-logs/pull/org_repo/5/pre/10/started.json":  "{}",
+		"pr-logs/pull/org_repo/6/pre/11/started.json":  "{}",
+		"pr-logs/pull/org_repo/6/pre/latest-build.txt": "11",
+		"stale/1/started.json":                         "{}",
+		"stale/2/started.json":                         "{}",
+		"stale/latest-build.txt":                       "1",
+		"broken/1/started.json":                        "{}",
+		"broken/latest-build.txt":                      "not/an/id",
+	}
+	var client fake.Client
+	for name, content := range objects {
+		client.Put(mustPath(t, "gs://bucket/"+name), []byte(content))
+	}
+
+	cases := []struct {
+		name   string
+		path   string
+		opts   gcs.ListOptions
+		layout gcs.Layout
+		want   []string
+	}{
+		{
+			name: "add the latest build of a flat job",
+			path: "gs://bucket/logs/post",
+			want: []string{"3", "2", "1"},
+		},
+		{
+			name:   "presubmit directory lists symlinks",
+			path:   "gs://bucket/pr-logs/directory/pre",
+			layout: gcs.PresubmitLayout,
+			want:   []string{"12", "11", "10"},
+		},
+		{
+			name: "builds of a pull request",
+			path: "gs://bucket/pr-logs/pull/org_repo/6/pre",
+			want: []string{"11"},
+		},
+		{
+			name: "ignore an older latest build",
+			path: "gs://bucket/stale",
+			want: []string{"2", "1"},
+		},
+		{
+			name: "ignore a bad latest build",
+			path: "gs://bucket/broken",
+			want: []string{"1"},
+		},
+		{
+			name: "latest build is not a symlink",
+			path: "gs://bucket/stale",
+			opts: gcs.ListOptions{Symlinks: true},
+			want: []string{"2", "1"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := mustPath(t, tc.path)
+			if got := gcs.LayoutOf(path); got != tc.layout {
+				t.Errorf("LayoutOf(%s) got %s, want %s", path, got, tc.layout)
+			}
+			builds, err := gcs.ListBuilds(context.Background(), &client, path, tc.opts)
+			if err != nil {
+				t.Fatalf("ListBuilds() got unexpected error: %v", err)
+			}
+			var got []string
+			for _, b := range builds {
+				got = append(got, b.ID())
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ListBuilds() got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// listCounter counts the objects each listing returns.
+type listCounter struct {
+	*fake.Client
+	listed int
+}
+
+func (c *listCounter) Objects(ctx context.Context, path gcs.Path, delimiter string) gcs.Iterator {
+	return &countingIterator{c.Client.Objects(ctx, path, delimiter), &c.listed}
+}
+
+type countingIterator struct {
+	gcs.Iterator
+	listed *int
+}
+
+func (it *countingIterator) Next() (*storage.ObjectAttrs, error) {
+	attrs, err := it.Iterator.Next()
+	if err == nil {
+		*it.listed++
+	}
+	return attrs, err
+}
+
+func TestListBuildsNewest(t *testing.T) {
+	cases := []struct {
+		name       string
+		builds     int
+		latest     string
+		others     []string
+		max        int
+		want       []string
+		wantListed int
+	}{
+		{
+			name:       "stop at the prefix of the marker",
+			builds:     2500,
+			latest:     "2500",
+			max:        3,
+			want:       []string{"2500", "2499", "2498"},
+			wantListed: 612 + 111, // 2, 2x, 2xx and 2xxx up to 2500, then 3, 3x and 3xx
+		},
+		{
+			name:       "include builds newer than the marker",
+			builds:     2010,
+			latest:     "1999",
+			max:        3,
+			want:       []string{"2010", "2009", "2008"},
+			wantListed: 1111 + 122, // 1 to 1999 starting with 1, then 2 to 2010 starting with 2
+		},
+		{
+			name:       "sort listed builds numerically",
+			builds:     1010,
+			latest:     "1010",
+			others:     []string{"10100"},
+			max:        3,
+			want:       []string{"10100", "1010", "1009"},
+			wantListed: 123 + 111,
+		},
+		{
+			name:       "list every build when the prefix holds too few",
+			builds:     1010,
+			latest:     "1010",
+			max:        20,
+			want:       nil,
+			wantListed: 122 + 1011,
+		},
+		{
+			name:       "list every build without a numeric marker",
+			builds:     1010,
+			latest:     "abc",
+			max:        3,
+			want:       []string{"1010", "1009", "1008"},
+			wantListed: 1011,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := listCounter{Client: &fake.Client{}}
+			for i := 1; i <= tc.builds; i++ {
+				client.Put(mustPath(t, fmt.Sprintf("gs://bucket/logs/job/%d/started.json", i)), nil)
+			}
+			for _, id := range tc.others {
+				client.Put(mustPath(t, "gs://bucket/logs/job/"+id+"/started.json"), nil)
+			}
+			client.Put(mustPath(t, "gs://bucket/logs/job/latest-build.txt"), []byte(tc.latest))
+			builds, err := gcs.ListBuilds(context.Background(), &client, mustPath(t, "gs://bucket/logs/job"), gcs.ListOptions{Max: tc.max})
+			if err != nil {
+				t.Fatalf("ListBuilds() got unexpected error: %v", err)
+			}
+			var got []string
+			for _, b := range builds {
+				got = append(got, b.ID())
+			}
+			if len(got) != tc.max || tc.want != nil && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ListBuilds() got %v, want %v", got, tc.want)
+			}
+			if client.listed != tc.wantListed {
+				t.Errorf("ListBuilds() listed %d objects, want %d", client.listed, tc.wantListed)
+			}
+		})
+	}
+}
+
+func TestSuitesCacheShared(t *testing.T) {
+	ctx := context.Background()
+	var client fake.Client
+	junitPath := mustPath(t, "gs://bucket/logs/job/1/artifacts/junit_01.xml")
+	client.Put(junitPath, []byte(`<testsuite><testcase name="old"/></testsuite>`))
+	builds, err := gcs.ListBuilds(ctx, &client, mustPath(t, "gs://bucket/logs/job"), gcs.ListOptions{})
+	if err != nil || len(builds) != 1 {
+		t.Fatalf("ListBuilds() got %v, %v, want one build", builds, err)
+	}
+	cache := gcs.NewSuitesCache(1 << 20)
+	// Builds of two groups reading the same job.
+	read := func(maxMessage int) string {
+		build := builds[0]
+		build.Cache = cache
+		build.MaxMessage = maxMessage
+		artifacts := make(chan string, 1)
+		artifacts <- junitPath.Object()
+		close(artifacts)
+		suites := make(chan gcs.SuitesMeta, 1)
+		if err := build.Suites(ctx, artifacts, suites); err != nil {
+			t.Fatalf("Suites() got unexpected error: %v", err)
+		}
+		return (<-suites).Suites.Suites[0].Results[0].Name
+	}
+
+	if got := read(0); got != "old" {
+		t.Errorf("Suites() got %q, want old", got)
+	}
+	if got := read(0); got != "old" {
+		t.Errorf("Suites() got cached %q, want old", got)
+	}
+	if got := read(10); got != "old" {
+		t.Errorf("Suites() with a message limit got %q, want old", got)
+	}
+	client.Put(junitPath, []byte(`<testsuite><testcase name="new"/></testsuite>`))
+	if got := read(0); got != "new" {
+		t.Errorf("Suites() of a new generation got %q, want new", got)
+	}
+	want := gcs.SuitesCacheStats{Hits: 1, Misses: 3}
+	got := cache.Stats()
+	got.Bytes = 0
+	if got != want {
+		t.Errorf("Stats() got %+v, want %+v", got, want)
+	}
+}
+
+func BenchmarkListBuilds(b *testing.B) {
+	const builds = 50000
+	var client fake.Client
+	for i := 0; i < builds; i++ {
+		client.Put(mustPath(b, fmt.Sprintf("gs://bucket/logs/job/%d/started.json", i)), nil)
+	}
+	path := mustPath(b, "gs://bucket/logs/job")
+	marker := mustPath(b, "gs://bucket/logs/job/latest-build.txt")
+	for _, latest := range []bool{false, true} {
+		if latest {
+			client.Put(marker, []byte(fmt.Sprint(builds-1)))
+		}
+		for _, max := range []int{0, 50} {
+			b.Run(fmt.Sprintf("max %d latest %t", max, latest), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					got, err := gcs.ListBuilds(context.Background(), &client, path, gcs.ListOptions{Max: max})
+					if err != nil {
+						b.Fatalf("ListBuilds() got unexpected error: %v", err)
+					}
+					if want := fmt.Sprint(builds - 1); got[0].ID() != want {
+						b.Fatalf("ListBuilds() got newest build %s, want %s", got[0].ID(), want)
+					}
+				}
+			})
+		}
+	}
+}
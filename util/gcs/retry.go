@@ -0,0 +1,224 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/api/googleapi"
+)
+
+// RetryOptions configures how NewRetryClient retries transient errors.
+type RetryOptions struct {
+	// Attempts is the maximum number of attempts of each call, or zero to retry until the context is done.
+	Attempts int
+	// Initial is the delay before the first retry.
+	Initial time.Duration
+	// Max limits the delay between retries, unless zero.
+	Max time.Duration
+	// Multiplier scales the delay after each retry, defaulting to 2.
+	Multiplier float64
+	// Jitter randomly shortens each delay by up to this fraction of it, such as 0.2 for up to 20%.
+	Jitter float64
+}
+
+// DefaultRetryOptions retries each call up to 5 times over about 15 seconds.
+var DefaultRetryOptions = RetryOptions{
+	Attempts:   5,
+	Initial:    time.Second,
+	Max:        10 * time.Second,
+	Multiplier: 2,
+	Jitter:     0.2,
+}
+
+// delay returns how long to wait before the retry after the attempt (starting at 1).
+func (o RetryOptions) delay(attempt int, random func() float64) time.Duration {
+	mult := o.Multiplier
+	if mult < 1 {
+		mult = 2
+	}
+	d := float64(o.Initial) * math.Pow(mult, float64(attempt-1))
+	if o.Max > 0 && d > float64(o.Max) {
+		d = float64(o.Max)
+	}
+	if o.Jitter > 0 {
+		d -= d * o.Jitter * random()
+	}
+	return time.Duration(d)
+}
+
+// IsTransient returns true for errors that may succeed when retried, such as a
+// 5xx or 429 (rate limit) response, or a reset connection.
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code >= 500 || apiErr.Code == http.StatusTooManyRequests || apiErr.Code == http.StatusRequestTimeout
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// NewRetryClient returns a Client that retries the transient errors of client with exponential backoff.
+//
+// Each call stops retrying after opts.Attempts, or once its context is done, returning the last error.
+// Reading from an opened object is not retried.
+func NewRetryClient(client Client, opts RetryOptions) Client {
+	return &retryClient{
+		client: client,
+		opts:   opts,
+		random: rand.Float64,
+		sleep:  sleep,
+	}
+}
+
+type retryClient struct {
+	client Client
+	opts   RetryOptions
+	random func() float64
+	// sleep waits for the duration, returning an error if the context is done first.
+	sleep func(context.Context, time.Duration) error
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// retry calls f until it succeeds, returns a permanent error, or runs out of attempts or time.
+func (c *retryClient) retry(ctx context.Context, path Path, f func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if !IsTransient(err) || attempt == c.opts.Attempts {
+			return err
+		}
+		d := c.opts.delay(attempt, c.random)
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"path":    path,
+			"attempt": attempt,
+			"delay":   d,
+		}).Debug("Retrying transient error")
+		if c.sleep(ctx, d) != nil {
+			return err
+		}
+	}
+}
+
+func (c *retryClient) Open(ctx context.Context, path Path) (io.ReadCloser, *storage.ReaderObjectAttrs, error) {
+	var r io.ReadCloser
+	var attrs *storage.ReaderObjectAttrs
+	err := c.retry(ctx, path, func() error {
+		var err error
+		r, attrs, err = c.client.Open(ctx, path)
+		return err
+	})
+	return r, attrs, err
+}
+
+func (c *retryClient) Objects(ctx context.Context, path Path, delimiter string) Iterator {
+	return &retryIterator{
+		ctx:       ctx,
+		client:    c,
+		path:      path,
+		delimiter: delimiter,
+		it:        c.client.Objects(ctx, path, delimiter),
+	}
+}
+
+func (c *retryClient) Upload(ctx context.Context, path Path, buf []byte, worldReadable bool, cacheControl string, cond storage.Conditions) (*storage.ObjectAttrs, error) {
+	var attrs *storage.ObjectAttrs
+	err := c.retry(ctx, path, func() error {
+		var err error
+		attrs, err = c.client.Upload(ctx, path, buf, worldReadable, cacheControl, cond)
+		return err
+	})
+	return attrs, err
+}
+
+func (c *retryClient) UploadEncoded(ctx context.Context, path Path, buf []byte, worldReadable bool, cacheControl, contentEncoding string, cond storage.Conditions) (*storage.ObjectAttrs, error) {
+	var attrs *storage.ObjectAttrs
+	err := c.retry(ctx, path, func() error {
+		var err error
+		attrs, err = UploadEncoded(ctx, c.client, path, buf, worldReadable, cacheControl, contentEncoding, cond)
+		return err
+	})
+	return attrs, err
+}
+
+func (c *retryClient) Attrs(ctx context.Context, path Path) (*storage.ObjectAttrs, error) {
+	var attrs *storage.ObjectAttrs
+	err := c.retry(ctx, path, func() error {
+		var err error
+		attrs, err = c.client.Attrs(ctx, path)
+		return err
+	})
+	return attrs, err
+}
+
+// retryIterator restarts the listing after a transient error, skipping the objects it already returned.
+type retryIterator struct {
+	ctx       context.Context
+	client    *retryClient
+	path      Path
+	delimiter string
+	it        Iterator
+	returned  int
+}
+
+func (it *retryIterator) Next() (*storage.ObjectAttrs, error) {
+	var attrs *storage.ObjectAttrs
+	restart := false
+	err := it.client.retry(it.ctx, it.path, func() error {
+		var err error
+		if restart {
+			it.it = it.client.client.Objects(it.ctx, it.path, it.delimiter)
+			for i := 0; i < it.returned; i++ {
+				if _, err := it.it.Next(); err != nil {
+					return err
+				}
+			}
+		}
+		attrs, err = it.it.Next()
+		restart = err != nil
+		return err
+	})
+	if err == nil {
+		it.returned++
+	}
+	return attrs, err
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iterator"
+
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+var (
+	unavailable = &googleapi.Error{Code: http.StatusServiceUnavailable}
+	notFound    = &googleapi.Error{Code: http.StatusNotFound}
+	fastRetries = gcs.RetryOptions{Attempts: 3, Initial: time.Millisecond, Multiplier: 2}
+)
+
+func mustPath(t testing.TB, s string) gcs.Path {
+	t.Helper()
+	p, err := gcs.ParsePath(s)
+	if err != nil {
+		t.Fatalf("bad path %s: %v", s, err)
+	}
+	return *p
+}
+
+func TestRetryClient(t *testing.T) {
+	cases := []struct {
+		name    string
+		opts    gcs.RetryOptions
+		timeout time.Duration
+		next    []error
+		fail    error
+		want    error
+	}{
+		{
+			name: "basically works",
+			opts: fastRetries,
+		},
+		{
+			name: "retry transient errors",
+			opts: fastRetries,
+			next: []error{unavailable, unavailable},
+		},
+		{
+			name: "do not retry permanent errors",
+			opts: fastRetries,
+			next: []error{notFound},
+			want: notFound,
+		},
+		{
+			name: "stop retrying after the attempts",
+			opts: fastRetries,
+			next: []error{unavailable, unavailable, unavailable},
+			want: unavailable,
+		},
+		{
+			name:    "retry until the deadline",
+			opts:    gcs.RetryOptions{Initial: time.Millisecond, Max: 5 * time.Millisecond},
+			timeout: 50 * time.Millisecond,
+			fail:    unavailable,
+			want:    unavailable,
+		},
+	}
+
+	do := map[string]func(context.Context, gcs.Client, gcs.Path) error{
+		"open": func(ctx context.Context, client gcs.Client, path gcs.Path) error {
+			r, _, err := client.Open(ctx, path)
+			if err != nil {
+				return err
+			}
+			defer r.Close()
+			buf, err := ioutil.ReadAll(r)
+			if err == nil && string(buf) != "hello" {
+				t.Errorf("Open() read %q, want hello", buf)
+			}
+			return err
+		},
+		"attrs": func(ctx context.Context, client gcs.Client, path gcs.Path) error {
+			_, err := client.Attrs(ctx, path)
+			return err
+		},
+		"upload": func(ctx context.Context, client gcs.Client, path gcs.Path) error {
+			_, err := client.Upload(ctx, path, []byte("hello"), false, "", storage.Conditions{})
+			return err
+		},
+		"list": func(ctx context.Context, client gcs.Client, path gcs.Path) error {
+			_, err := client.Objects(ctx, path, "").Next()
+			return err
+		},
+	}
+
+	for _, tc := range cases {
+		for op, f := range do {
+			t.Run(tc.name+"/"+op, func(t *testing.T) {
+				ctx := context.Background()
+				if tc.timeout > 0 {
+					var cancel context.CancelFunc
+					ctx, cancel = context.WithTimeout(ctx, tc.timeout)
+					defer cancel()
+				}
+				var client fake.Client
+				path := mustPath(t, "gs://bucket/obj")
+				client.Put(path, []byte("hello"))
+				client.Fail(path, tc.fail)
+				client.FailNext(path, tc.next...)
+				err := f(ctx, gcs.NewRetryClient(&client, tc.opts), path)
+				if tc.timeout > 0 && errors.Is(err, context.DeadlineExceeded) {
+					return // Timed out during an attempt rather than between them.
+				}
+				if err != tc.want {
+					t.Errorf("%s got %v, want %v", op, err, tc.want)
+				}
+			})
+		}
+	}
+}
+
+func TestRetryClientObjects(t *testing.T) {
+	ctx := context.Background()
+	var client fake.Client
+	dir := mustPath(t, "gs://bucket/logs/")
+	for _, name := range []string{"a", "b", "c"} {
+		client.Put(mustPath(t, "gs://bucket/logs/"+name), []byte(name))
+	}
+	// Fail the listing, then twice after returning a.
+	client.FailNext(dir, unavailable)
+	it := gcs.NewRetryClient(&client, fastRetries).Objects(ctx, dir, "")
+	var got []string
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next() got unexpected error: %v", err)
+		}
+		got = append(got, attrs.Name)
+		if len(got) == 1 {
+			client.FailNext(dir, unavailable, unavailable)
+		}
+	}
+	if want := []string{"logs/a", "logs/b", "logs/c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Objects() got %q, want %q", got, want)
+	}
+}
+
+func TestRetryClientCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var client fake.Client
+	path := mustPath(t, "gs://bucket/obj")
+	client.Fail(path, unavailable)
+	retries := gcs.RetryOptions{Initial: time.Hour}
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	if _, err := gcs.NewRetryClient(&client, retries).Attrs(ctx, path); !errors.Is(err, unavailable) {
+		t.Errorf("Attrs() got %v, want %v", err, unavailable)
+	}
+}
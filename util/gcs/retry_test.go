@@ -0,0 +1,175 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+func TestDelay(t *testing.T) {
+	cases := []struct {
+		name    string
+		opts    RetryOptions
+		attempt int
+		random  float64
+		want    time.Duration
+	}{
+		{
+			name:    "initial",
+			opts:    RetryOptions{Initial: time.Second, Multiplier: 2},
+			attempt: 1,
+			want:    time.Second,
+		},
+		{
+			name:    "exponential",
+			opts:    RetryOptions{Initial: time.Second, Multiplier: 3},
+			attempt: 3,
+			want:    9 * time.Second,
+		},
+		{
+			name:    "default multiplier",
+			opts:    RetryOptions{Initial: time.Second},
+			attempt: 4,
+			want:    8 * time.Second,
+		},
+		{
+			name:    "capped",
+			opts:    RetryOptions{Initial: time.Second, Max: 5 * time.Second, Multiplier: 2},
+			attempt: 10,
+			want:    5 * time.Second,
+		},
+		{
+			name:    "jitter shortens",
+			opts:    RetryOptions{Initial: 10 * time.Second, Multiplier: 2, Jitter: 0.2},
+			attempt: 1,
+			random:  0.5,
+			want:    9 * time.Second,
+		},
+		{
+			name:    "jitter after cap",
+			opts:    RetryOptions{Initial: 10 * time.Second, Max: 10 * time.Second, Jitter: 0.5},
+			attempt: 5,
+			random:  1,
+			want:    5 * time.Second,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			random := func() float64 { return tc.random }
+			if got := tc.opts.delay(tc.attempt, random); got != tc.want {
+				t.Errorf("delay(%d) got %s, want %s", tc.attempt, got, tc.want)
+			}
+		})
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+var _ net.Error = timeoutError{}
+
+func TestIsTransient(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "server error",
+			err:  &googleapi.Error{Code: http.StatusServiceUnavailable},
+			want: true,
+		},
+		{
+			name: "wrapped server error",
+			err:  fmt.Errorf("read: %w", &googleapi.Error{Code: http.StatusInternalServerError}),
+			want: true,
+		},
+		{
+			name: "rate limited",
+			err:  &googleapi.Error{Code: http.StatusTooManyRequests},
+			want: true,
+		},
+		{
+			name: "request timeout",
+			err:  &googleapi.Error{Code: http.StatusRequestTimeout},
+			want: true,
+		},
+		{
+			name: "not found",
+			err:  &googleapi.Error{Code: http.StatusNotFound},
+		},
+		{
+			name: "precondition failed",
+			err:  fmt.Errorf("upload: %w", &googleapi.Error{Code: http.StatusPreconditionFailed}),
+		},
+		{
+			name: "connection reset",
+			err:  &net.OpError{Op: "read", Err: syscall.ECONNRESET},
+			want: true,
+		},
+		{
+			name: "connection refused",
+			err:  &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED},
+			want: true,
+		},
+		{
+			name: "unexpected eof",
+			err:  io.ErrUnexpectedEOF,
+			want: true,
+		},
+		{
+			name: "network timeout",
+			err:  &net.OpError{Op: "read", Err: timeoutError{}},
+			want: true,
+		},
+		{
+			name: "canceled",
+			err:  context.Canceled,
+		},
+		{
+			name: "deadline",
+			err:  fmt.Errorf("read: %w", context.DeadlineExceeded),
+		},
+		{
+			name: "other",
+			err:  errors.New("bad data"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsTransient(tc.err); got != tc.want {
+				t.Errorf("IsTransient(%v) got %t, want %t", tc.err, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/api/iterator"
+	"vbom.ml/util/sortorder"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+)
+
+// DefaultShardPattern matches the base name of junit artifacts, such as junit.xml or junit_01.xml.
+var DefaultShardPattern = regexp.MustCompile(`junit.*\.xml`)
+
+// DefaultShardConcurrency is the number of shards ReadShards parses at once by default.
+const DefaultShardConcurrency = 10
+
+// ShardOptions customizes which junit artifacts ReadShards parses.
+type ShardOptions struct {
+	// Pattern matches the base name of each artifact to parse, defaulting to DefaultShardPattern.
+	Pattern *regexp.Regexp
+	// Concurrency parses up to this many artifacts at once, defaulting to DefaultShardConcurrency.
+	Concurrency int
+}
+
+// ReadShards parses each junit artifact under the artifacts/ dir of the build, in natural name order.
+//
+// Test cases whose suite and name appear in more than one shard are renamed with a
+// shard suffix, such as "case [junit_02]", so their results remain distinct.
+// Returns the error of the first artifact in order that fails to parse.
+func (build Build) ReadShards(ctx context.Context, opts ShardOptions) ([]SuitesMeta, error) {
+	pref := build.Prefix + "artifacts/"
+	var names []string
+	objs := build.Client.Objects(ctx, build.object(pref), "")
+	for {
+		obj, err := objs.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %v", pref, err)
+		}
+		names = append(names, obj.Name)
+	}
+	read := func(ctx context.Context, name string) (*junit.Suites, error) {
+		return readSuites(ctx, build.Client, build.object(name), build.MaxMessage, build.Cache)
+	}
+	return readShards(ctx, names, opts, read, "gs://"+build.BucketPath+"/")
+}
+
+// readShards parses each named artifact matching opts with read, in natural name order.
+func readShards(ctx context.Context, names []string, opts ShardOptions, read func(context.Context, string) (*junit.Suites, error), pathPrefix string) ([]SuitesMeta, error) {
+	pattern := opts.Pattern
+	if pattern == nil {
+		pattern = DefaultShardPattern
+	}
+	workers := opts.Concurrency
+	if workers <= 0 {
+		workers = DefaultShardConcurrency
+	}
+
+	var arts []string
+	for _, name := range names {
+		if pattern.MatchString(path.Base(name)) {
+			arts = append(arts, name)
+		}
+	}
+	sort.SliceStable(arts, func(i, j int) bool { return sortorder.NaturalLess(arts[i], arts[j]) })
+
+	shards := make([]SuitesMeta, len(arts))
+	errs := make([]error, len(arts))
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				suites, err := read(ctx, arts[i])
+				if err != nil {
+					errs[i] = fmt.Errorf("read %s: %v", arts[i], err)
+					continue
+				}
+				shards[i] = SuitesMeta{
+					Suites:   *suites,
+					Metadata: parseSuitesMeta(arts[i]),
+					Path:     pathPrefix + arts[i],
+				}
+			}
+		}()
+	}
+	for i := range arts {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	disambiguateShards(shards)
+	return shards, nil
+}
+
+// disambiguateShards appends a [shard] suffix to test cases whose suite and name appear in multiple shards.
+//
+// The shard is the base name of the artifact, without the .xml extension.
+func disambiguateShards(shards []SuitesMeta) {
+	inShard := map[string]int{} // Index of the shard of a case, or -1 when in many.
+	for i, shard := range shards {
+		for _, suite := range shard.Suites.Suites {
+			for _, result := range suite.Results {
+				key := suite.Name + "." + result.Name
+				if first, ok := inShard[key]; !ok {
+					inShard[key] = i
+				} else if first != i {
+					inShard[key] = -1
+				}
+			}
+		}
+	}
+	for _, shard := range shards {
+		name := strings.TrimSuffix(path.Base(shard.Path), ".xml")
+		for s, suite := range shard.Suites.Suites {
+			for r, result := range suite.Results {
+				if inShard[suite.Name+"."+result.Name] == -1 {
+					shard.Suites.Suites[s].Results[r].Name = fmt.Sprintf("%s [%s]", result.Name, name)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+)
+
+func TestReadShards(t *testing.T) {
+	const prefix = "logs/job/1/artifacts/"
+	cases := []struct {
+		name     string
+		files    map[string]string
+		opts     ShardOptions
+		expected map[string][]string // artifact => suite.case names
+		order    []string
+		err      bool
+	}{
+		{
+			name: "no artifacts",
+		},
+		{
+			name: "natural order and default pattern",
+			files: map[string]string{
+				"junit_10.xml":     `<testsuite name="s"><testcase name="ten"/></testsuite>`,
+				"junit_2.xml":      `<testsuite name="s"><testcase name="two"/></testsuite>`,
+				"junit_01.xml":     `<testsuite name="s"><testcase name="one"/></testsuite>`,
+				"build-log.txt":    `not junit`,
+				"nested/junit.xml": `<testsuite name="s"><testcase name="nested"/></testsuite>`,
+			},
+			order: []string{"junit_01.xml", "junit_2.xml", "junit_10.xml", "nested/junit.xml"},
+			expected: map[string][]string{
+				"junit_01.xml":     {"s.one"},
+				"junit_2.xml":      {"s.two"},
+				"junit_10.xml":     {"s.ten"},
+				"nested/junit.xml": {"s.nested"},
+			},
+		},
+		{
+			name: "custom pattern",
+			files: map[string]string{
+				"junit_01.xml":   `<testsuite name="s"><testcase name="one"/></testsuite>`,
+				"results-01.xml": `<testsuite name="s"><testcase name="custom"/></testsuite>`,
+			},
+			opts:     ShardOptions{Pattern: regexp.MustCompile(`^results-.*\.xml$`)},
+			order:    []string{"results-01.xml"},
+			expected: map[string][]string{"results-01.xml": {"s.custom"}},
+		},
+		{
+			name: "disambiguate duplicates across shards",
+			files: map[string]string{
+				"junit_01.xml": `<testsuite name="s"><testcase name="dup"/><testcase name="retried"/><testcase name="retried"/></testsuite>`,
+				"junit_02.xml": `<testsuite name="s"><testcase name="dup"/><testcase name="unique"/></testsuite>`,
+				"junit_03.xml": `<testsuite name="other"><testcase name="dup"/></testsuite>`,
+			},
+			opts:  ShardOptions{Concurrency: 1},
+			order: []string{"junit_01.xml", "junit_02.xml", "junit_03.xml"},
+			expected: map[string][]string{
+				"junit_01.xml": {"s.dup [junit_01]", "s.retried", "s.retried"},
+				"junit_02.xml": {"s.dup [junit_02]", "s.unique"},
+				"junit_03.xml": {"other.dup"},
+			},
+		},
+		{
+			name: "first error in order",
+			files: map[string]string{
+				"junit_01.xml": `<testsuite name="s"><testcase name="one"/></testsuite>`,
+				"junit_02.xml": `bad`,
+				"junit_03.xml": `worse`,
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var names []string
+			for name := range tc.files {
+				names = append(names, prefix+name)
+			}
+			var lock sync.Mutex
+			var reads []string
+			read := func(_ context.Context, name string) (*junit.Suites, error) {
+				lock.Lock()
+				reads = append(reads, name)
+				lock.Unlock()
+				content, ok := tc.files[name[len(prefix):]]
+				if !ok {
+					return nil, errors.New("not found")
+				}
+				suites, err := junit.Parse([]byte(content))
+				if err != nil {
+					return nil, fmt.Errorf("parse %s: %v", name, err)
+				}
+				return &suites, nil
+			}
+
+			shards, err := readShards(context.Background(), names, tc.opts, read, "gs://bucket/")
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if want := "read " + prefix + "junit_02.xml"; !strings.HasPrefix(err.Error(), want) {
+					t.Errorf("expected the error for junit_02.xml, got %v", err)
+				}
+				return
+			case tc.err:
+				t.Fatal("failed to receive expected error")
+			}
+
+			var order []string
+			actual := map[string][]string{}
+			for _, shard := range shards {
+				name := shard.Path[len("gs://bucket/"+prefix):]
+				order = append(order, name)
+				for _, suite := range shard.Suites.Suites {
+					for _, result := range suite.Results {
+						actual[name] = append(actual[name], suite.Name+"."+result.Name)
+					}
+				}
+			}
+			if !reflect.DeepEqual(order, tc.order) {
+				t.Errorf("actual order %v != expected %v", order, tc.order)
+			}
+			if len(actual) == 0 {
+				actual = nil
+			}
+			if len(tc.expected) == 0 {
+				tc.expected = nil
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("actual cases %v != expected %v", actual, tc.expected)
+			}
+			if len(reads) != len(tc.order) {
+				t.Errorf("read %v, expected only the %d matching artifacts", reads, len(tc.order))
+			}
+		})
+	}
+}
+
+func TestReadShards_Metadata(t *testing.T) {
+	read := func(context.Context, string) (*junit.Suites, error) {
+		return &junit.Suites{}, nil
+	}
+	shards, err := readShards(context.Background(), []string{"build/artifacts/junit_runner_07.xml"}, ShardOptions{}, read, "gs://bucket/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []SuitesMeta{
+		{
+			Metadata: map[string]string{"Context": "runner", "Timestamp": "", "Thread": "07"},
+			Path:     "gs://bucket/build/artifacts/junit_runner_07.xml",
+		},
+	}
+	if !reflect.DeepEqual(shards, expected) {
+		t.Errorf("actual %#v != expected %#v", shards, expected)
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+)
+
+// SuitesCache holds parsed junit suites in memory, so groups reading the same objects parse them once.
+//
+// Entries are keyed by the path and generation of the object, and the number of bytes of each
+// message kept. The least recently used entries are evicted once the objects of the entries add up
+// to more than the byte limit. A SuitesCache is safe for concurrent use.
+type SuitesCache struct {
+	maxBytes int64
+
+	lock    sync.Mutex
+	bytes   int64
+	lru     *list.List // of *suitesEntry, most recently used first
+	entries map[suitesKey]*list.Element
+	stats   SuitesCacheStats
+}
+
+// SuitesCacheStats counts the lookups of a SuitesCache.
+type SuitesCacheStats struct {
+	// Hits is the number of reads that used cached suites.
+	Hits int64
+	// Misses is the number of reads that parsed the object.
+	Misses int64
+	// Bytes is the size of the objects currently cached.
+	Bytes int64
+}
+
+type suitesKey struct {
+	path string
+	max  int
+}
+
+type suitesEntry struct {
+	key        suitesKey
+	generation int64
+	size       int64
+	suites     junit.Suites
+}
+
+// NewSuitesCache returns a cache of the suites parsed from up to maxBytes of objects.
+func NewSuitesCache(maxBytes int64) *SuitesCache {
+	return &SuitesCache{
+		maxBytes: maxBytes,
+		lru:      list.New(),
+		entries:  map[suitesKey]*list.Element{},
+	}
+}
+
+// get returns a copy of the suites parsed from the generation of the object, counting a hit or miss.
+//
+// Entries of other generations are dropped.
+func (c *SuitesCache) get(path Path, generation int64, max int) (*junit.Suites, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	key := suitesKey{path.String(), max}
+	elem, ok := c.entries[key]
+	if ok && elem.Value.(*suitesEntry).generation != generation {
+		c.remove(elem)
+		ok = false
+	}
+	if !ok {
+		c.stats.Misses++
+		return nil, false
+	}
+	c.stats.Hits++
+	c.lru.MoveToFront(elem)
+	suites := copySuites(elem.Value.(*suitesEntry).suites)
+	return &suites, true
+}
+
+// put caches a copy of the suites parsed from the generation of an object with size bytes.
+func (c *SuitesCache) put(path Path, generation, size int64, max int, suites junit.Suites) {
+	if size > c.maxBytes {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	key := suitesKey{path.String(), max}
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	c.entries[key] = c.lru.PushFront(&suitesEntry{
+		key:        key,
+		generation: generation,
+		size:       size,
+		suites:     copySuites(suites),
+	})
+	c.bytes += size
+	for c.bytes > c.maxBytes {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *SuitesCache) remove(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*suitesEntry)
+	delete(c.entries, entry.key)
+	c.bytes -= entry.size
+}
+
+// Stats returns the lookups so far and the size of the cached objects.
+func (c *SuitesCache) Stats() SuitesCacheStats {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	stats := c.stats
+	stats.Bytes = c.bytes
+	return stats
+}
+
+// copySuites returns suites whose results callers may modify, such as to rename them, without changing the original.
+func copySuites(suites junit.Suites) junit.Suites {
+	out := suites
+	out.Suites = make([]junit.Suite, len(suites.Suites))
+	for i, suite := range suites.Suites {
+		suite.Results = append([]junit.Result(nil), suite.Results...)
+		out.Suites[i] = suite
+	}
+	return out
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+)
+
+func TestSuitesCache(t *testing.T) {
+	path := func(name string) Path {
+		p, err := NewPath("gs://bucket/" + name)
+		if err != nil {
+			t.Fatalf("NewPath(%q) got unexpected error: %v", name, err)
+		}
+		return *p
+	}
+	suites := func(name string) junit.Suites {
+		return junit.Suites{
+			Suites: []junit.Suite{
+				{
+					Name:    "suite",
+					Results: []junit.Result{{Name: name}},
+				},
+			},
+		}
+	}
+	get := func(c *SuitesCache, p Path, gen int64, max int) string {
+		s, ok := c.get(p, gen, max)
+		if !ok {
+			return ""
+		}
+		return s.Suites[0].Results[0].Name
+	}
+
+	c := NewSuitesCache(10)
+	if got := get(c, path("a"), 1, 0); got != "" {
+		t.Errorf("get() of an empty cache got %q", got)
+	}
+	c.put(path("a"), 1, 4, 0, suites("a1"))
+	c.put(path("b"), 1, 4, 0, suites("b1"))
+	if got := get(c, path("a"), 1, 0); got != "a1" {
+		t.Errorf("get() got %q, want a1", got)
+	}
+	if got := get(c, path("a"), 1, 100); got != "" {
+		t.Errorf("get() with another max got %q, want a miss", got)
+	}
+
+	// Evict b, the least recently used.
+	c.put(path("c"), 1, 4, 0, suites("c1"))
+	if got := get(c, path("b"), 1, 0); got != "" {
+		t.Errorf("get() got evicted %q", got)
+	}
+	if got := get(c, path("c"), 1, 0); got != "c1" {
+		t.Errorf("get() got %q, want c1", got)
+	}
+
+	// Drop a, whose object changed.
+	if got := get(c, path("a"), 2, 0); got != "" {
+		t.Errorf("get() of a new generation got %q", got)
+	}
+	if got := get(c, path("a"), 1, 0); got != "" {
+		t.Errorf("get() of an invalidated generation got %q", got)
+	}
+
+	// Skip objects larger than the cache.
+	c.put(path("d"), 1, 11, 0, suites("d1"))
+	if got := get(c, path("d"), 1, 0); got != "" {
+		t.Errorf("get() of an object larger than the cache got %q", got)
+	}
+
+	want := SuitesCacheStats{Hits: 2, Misses: 6, Bytes: 4}
+	if got := c.Stats(); got != want {
+		t.Errorf("Stats() got %+v, want %+v", got, want)
+	}
+}
+
+func TestSuitesCacheCopies(t *testing.T) {
+	p, err := NewPath("gs://bucket/junit.xml")
+	if err != nil {
+		t.Fatalf("NewPath() got unexpected error: %v", err)
+	}
+	orig := junit.Suites{
+		Suites: []junit.Suite{{Results: []junit.Result{{Name: "case"}}}},
+	}
+	c := NewSuitesCache(100)
+	c.put(*p, 1, 1, 0, orig)
+	orig.Suites[0].Results[0].Name = "changed before"
+	got, ok := c.get(*p, 1, 0)
+	if !ok {
+		t.Fatalf("get() missed")
+	}
+	got.Suites[0].Results[0].Name = "changed after"
+	again, _ := c.get(*p, 1, 0)
+	want := junit.Suites{
+		Suites: []junit.Suite{{Results: []junit.Result{{Name: "case"}}}},
+	}
+	if !reflect.DeepEqual(*again, want) {
+		t.Errorf("get() got %+v after changing copies, want %+v", *again, want)
+	}
+}
+
+func TestSuitesCacheConcurrent(t *testing.T) {
+	c := NewSuitesCache(50)
+	var wg sync.WaitGroup
+	for w := 0; w < 10; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				p, err := NewPath(fmt.Sprintf("gs://bucket/%d", i%20))
+				if err != nil {
+					t.Errorf("NewPath() got unexpected error: %v", err)
+					return
+				}
+				if _, ok := c.get(*p, 1, 0); !ok {
+					c.put(*p, 1, 5, 0, junit.Suites{})
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	stats := c.Stats()
+	if stats.Hits+stats.Misses != 1000 {
+		t.Errorf("Stats() got %d lookups, want 1000", stats.Hits+stats.Misses)
+	}
+	if stats.Bytes > 50 {
+		t.Errorf("Stats() got %d bytes, want at most 50", stats.Bytes)
+	}
+}
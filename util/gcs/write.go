@@ -0,0 +1,190 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	"cloud.google.com/go/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// Write is an upload recorded by a WriteClient.
+type Write struct {
+	Path            Path
+	Size            int
+	ContentEncoding string
+	// Existed is true when an object was already at the path.
+	Existed bool
+	// PreviousSize is the size of the existing object, as read.
+	PreviousSize int
+	// Changed is true when the upload is new or differs from the existing object.
+	Changed bool
+	// Skipped is true when the upload was only recorded, in dry-run mode.
+	Skipped bool
+}
+
+// String summarizes the difference between the upload and the existing object.
+func (w Write) String() string {
+	var diff string
+	switch {
+	case !w.Skipped:
+		diff = "written"
+	case !w.Existed:
+		diff = "new object"
+	case !w.Changed:
+		diff = "unchanged"
+	default:
+		diff = fmt.Sprintf("changed from %d bytes", w.PreviousSize)
+	}
+	return fmt.Sprintf("%s: %d bytes, %s", w.Path, w.Size, diff)
+}
+
+// FormatWrites lists the writes one per line, followed by how many of them a dry run skipped and changed.
+func FormatWrites(writes []Write) string {
+	var b strings.Builder
+	var changed int
+	for _, w := range writes {
+		if w.Changed {
+			changed++
+		}
+		fmt.Fprintln(&b, w)
+	}
+	fmt.Fprintf(&b, "Dry run skipped writing %d objects, %d of which changed\n", len(writes), changed)
+	return b.String()
+}
+
+// WriteClient is a Client that records each upload, and in dry-run mode skips them.
+//
+// Dry runs still perform every read, so that callers compute exactly what they would write.
+type WriteClient struct {
+	Client
+	dryRun bool
+
+	lock   sync.Mutex
+	writes []Write
+}
+
+// NewWriteClient returns a WriteClient of client, which only records uploads when dryRun is set.
+func NewWriteClient(client Client, dryRun bool) *WriteClient {
+	return &WriteClient{
+		Client: client,
+		dryRun: dryRun,
+	}
+}
+
+var _ EncodedUploader = &WriteClient{}
+
+// Upload writes or, in dry-run mode, records the object.
+func (c *WriteClient) Upload(ctx context.Context, path Path, buf []byte, worldReadable bool, cacheControl string, cond storage.Conditions) (*storage.ObjectAttrs, error) {
+	return c.UploadEncoded(ctx, path, buf, worldReadable, cacheControl, "", cond)
+}
+
+// UploadEncoded writes or, in dry-run mode, records the object with the Content-Encoding.
+//
+// Dry runs compare buf to the existing object, but do not check the conditions.
+func (c *WriteClient) UploadEncoded(ctx context.Context, path Path, buf []byte, worldReadable bool, cacheControl, contentEncoding string, cond storage.Conditions) (*storage.ObjectAttrs, error) {
+	w := Write{
+		Path:            path,
+		Size:            len(buf),
+		ContentEncoding: contentEncoding,
+		Skipped:         c.dryRun,
+	}
+	if !c.dryRun {
+		attrs, err := UploadEncoded(ctx, c.Client, path, buf, worldReadable, cacheControl, contentEncoding, cond)
+		if err != nil {
+			return nil, err
+		}
+		w.Changed = true
+		c.record(w)
+		return attrs, nil
+	}
+	prev, err := c.read(ctx, path)
+	switch {
+	case errors.Is(err, storage.ErrObjectNotExist):
+		w.Changed = true
+	case err != nil:
+		return nil, fmt.Errorf("read existing %s: %w", path, err)
+	default:
+		w.Existed = true
+		w.PreviousSize = len(prev)
+		w.Changed = !sameContent(prev, buf, contentEncoding)
+	}
+	logrus.WithFields(logrus.Fields{
+		"path":     path,
+		"bytes":    w.Size,
+		"previous": w.PreviousSize,
+		"changed":  w.Changed,
+	}).Info("Dry run: skipped writing")
+	c.record(w)
+	return &storage.ObjectAttrs{
+		Bucket:          path.Bucket(),
+		Name:            path.Object(),
+		Size:            int64(len(buf)),
+		CacheControl:    cacheControl,
+		ContentEncoding: contentEncoding,
+	}, nil
+}
+
+// read returns the content of the object, which GCS decompresses when it is gzip encoded.
+func (c *WriteClient) read(ctx context.Context, path Path) ([]byte, error) {
+	r, _, err := c.Client.Open(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
+
+// sameContent reports whether the object read as prev has the content of buf.
+func sameContent(prev, buf []byte, contentEncoding string) bool {
+	if bytes.Equal(prev, buf) {
+		return true
+	}
+	if contentEncoding != "gzip" {
+		return false
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(buf))
+	if err != nil {
+		return false
+	}
+	defer zr.Close()
+	plain, err := ioutil.ReadAll(zr)
+	return err == nil && bytes.Equal(prev, plain)
+}
+
+func (c *WriteClient) record(w Write) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.writes = append(c.writes, w)
+}
+
+// Writes returns the uploads recorded so far, in the order they finished, and forgets them.
+func (c *WriteClient) Writes() []Write {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	writes := c.writes
+	c.writes = nil
+	return writes
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"cloud.google.com/go/storage"
+
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+// uploadCounter counts the uploads that reach the client.
+type uploadCounter struct {
+	*fake.Client
+	uploads int
+}
+
+func (c *uploadCounter) Upload(ctx context.Context, path gcs.Path, buf []byte, worldReadable bool, cacheControl string, cond storage.Conditions) (*storage.ObjectAttrs, error) {
+	c.uploads++
+	return c.Client.Upload(ctx, path, buf, worldReadable, cacheControl, cond)
+}
+
+func (c *uploadCounter) UploadEncoded(ctx context.Context, path gcs.Path, buf []byte, worldReadable bool, cacheControl, contentEncoding string, cond storage.Conditions) (*storage.ObjectAttrs, error) {
+	c.uploads++
+	return c.Client.UploadEncoded(ctx, path, buf, worldReadable, cacheControl, contentEncoding, cond)
+}
+
+func gzipped(t *testing.T, buf []byte) []byte {
+	t.Helper()
+	var out bytes.Buffer
+	zw := gzip.NewWriter(&out)
+	if _, err := zw.Write(buf); err != nil {
+		t.Fatalf("Write() got unexpected error: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Close() got unexpected error: %v", err)
+	}
+	return out.Bytes()
+}
+
+func TestWriteClient(t *testing.T) {
+	ctx := context.Background()
+	existing := mustPath(t, "gs://bucket/existing")
+	missing := mustPath(t, "gs://bucket/missing")
+	broken := mustPath(t, "gs://bucket/broken")
+	cases := []struct {
+		name     string
+		dryRun   bool
+		path     gcs.Path
+		buf      []byte
+		encoding string
+		want     gcs.Write
+		uploads  int
+		err      bool
+	}{
+		{
+			name:    "write",
+			path:    existing,
+			buf:     []byte("hello world"),
+			want:    gcs.Write{Path: existing, Size: 11, Changed: true},
+			uploads: 1,
+		},
+		{
+			name:   "dry run new object",
+			dryRun: true,
+			path:   missing,
+			buf:    []byte("hello"),
+			want:   gcs.Write{Path: missing, Size: 5, Changed: true, Skipped: true},
+		},
+		{
+			name:   "dry run unchanged",
+			dryRun: true,
+			path:   existing,
+			buf:    []byte("hello"),
+			want:   gcs.Write{Path: existing, Size: 5, Existed: true, PreviousSize: 5, Skipped: true},
+		},
+		{
+			name:   "dry run changed",
+			dryRun: true,
+			path:   existing,
+			buf:    []byte("hello world"),
+			want:   gcs.Write{Path: existing, Size: 11, Existed: true, PreviousSize: 5, Changed: true, Skipped: true},
+		},
+		{
+			name:     "dry run unchanged gzip",
+			dryRun:   true,
+			path:     existing,
+			buf:      gzipped(t, []byte("hello")),
+			encoding: "gzip",
+			want:     gcs.Write{Path: existing, Size: len(gzipped(t, []byte("hello"))), ContentEncoding: "gzip", Existed: true, PreviousSize: 5, Skipped: true},
+		},
+		{
+			name:   "dry run cannot read existing",
+			dryRun: true,
+			path:   broken,
+			buf:    []byte("hello"),
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := uploadCounter{Client: &fake.Client{}}
+			client.Put(existing, []byte("hello"))
+			client.Fail(broken, errors.New("injected"))
+			wc := gcs.NewWriteClient(&client, tc.dryRun)
+			attrs, err := gcs.UploadEncoded(ctx, wc, tc.path, tc.buf, false, "no-cache", tc.encoding, storage.Conditions{})
+			switch {
+			case err != nil && !tc.err:
+				t.Fatalf("UploadEncoded() got unexpected error: %v", err)
+			case err == nil && tc.err:
+				t.Fatalf("UploadEncoded() failed to return an error")
+			}
+			if client.uploads != tc.uploads {
+				t.Errorf("UploadEncoded() uploaded %d objects, want %d", client.uploads, tc.uploads)
+			}
+			writes := wc.Writes()
+			if tc.err {
+				if len(writes) > 0 {
+					t.Errorf("Writes() got %v after an error, want none", writes)
+				}
+				return
+			}
+			if want := []gcs.Write{tc.want}; !reflect.DeepEqual(writes, want) {
+				t.Errorf("Writes() got %+v, want %+v", writes, want)
+			}
+			if attrs.Size != int64(len(tc.buf)) {
+				t.Errorf("UploadEncoded() got size %d, want %d", attrs.Size, len(tc.buf))
+			}
+			if more := wc.Writes(); len(more) > 0 {
+				t.Errorf("Writes() did not forget %v", more)
+			}
+		})
+	}
+}
+
+func TestWriteString(t *testing.T) {
+	path := mustPath(t, "gs://bucket/obj")
+	cases := []struct {
+		name  string
+		write gcs.Write
+		want  string
+	}{
+		{
+			name:  "written",
+			write: gcs.Write{Path: path, Size: 3, Changed: true},
+			want:  "gs://bucket/obj: 3 bytes, written",
+		},
+		{
+			name:  "new",
+			write: gcs.Write{Path: path, Size: 3, Changed: true, Skipped: true},
+			want:  "gs://bucket/obj: 3 bytes, new object",
+		},
+		{
+			name:  "unchanged",
+			write: gcs.Write{Path: path, Size: 3, Existed: true, PreviousSize: 3, Skipped: true},
+			want:  "gs://bucket/obj: 3 bytes, unchanged",
+		},
+		{
+			name:  "changed",
+			write: gcs.Write{Path: path, Size: 3, Existed: true, PreviousSize: 7, Changed: true, Skipped: true},
+			want:  "gs://bucket/obj: 3 bytes, changed from 7 bytes",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.write.String(); got != tc.want {
+				t.Errorf("String() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFormatWrites(t *testing.T) {
+	cases := []struct {
+		name   string
+		writes []gcs.Write
+		want   string
+	}{
+		{
+			name: "empty",
+			want: "Dry run skipped writing 0 objects, 0 of which changed\n",
+		},
+		{
+			name: "count changes",
+			writes: []gcs.Write{
+				{Path: mustPath(t, "gs://bucket/a"), Size: 3, Changed: true, Skipped: true},
+				{Path: mustPath(t, "gs://bucket/b"), Size: 3, Existed: true, PreviousSize: 3, Skipped: true},
+			},
+			want: "gs://bucket/a: 3 bytes, new object\n" +
+				"gs://bucket/b: 3 bytes, unchanged\n" +
+				"Dry run skipped writing 2 objects, 1 of which changed\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := gcs.FormatWrites(tc.writes); got != tc.want {
+				t.Errorf("FormatWrites() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
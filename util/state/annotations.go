@@ -0,0 +1,30 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+// Annotation returns the value of the key that the updater copied into the column from the metadata of its build.
+//
+// Only the column_annotations of the test group are copied, so ok is false for other keys,
+// and for builds that did not set the key.
+func Annotation(col *statepb.Column, key string) (value string, ok bool) {
+	value, ok = col.GetMetadata()[key]
+	return value, ok
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"testing"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+func TestAnnotation(t *testing.T) {
+	cases := []struct {
+		name      string
+		col       *statepb.Column
+		key       string
+		wantValue string
+		wantOK    bool
+	}{
+		{
+			name: "nil column",
+			key:  "cluster",
+		},
+		{
+			name: "no annotations",
+			col:  &statepb.Column{Build: "1"},
+			key:  "cluster",
+		},
+		{
+			name:      "annotated",
+			col:       &statepb.Column{Metadata: map[string]string{"cluster": "build01", "zone": "us-east1"}},
+			key:       "cluster",
+			wantValue: "build01",
+			wantOK:    true,
+		},
+		{
+			name: "other key",
+			col:  &statepb.Column{Metadata: map[string]string{"zone": "us-east1"}},
+			key:  "cluster",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, ok := Annotation(tc.col, tc.key)
+			if value != tc.wantValue || ok != tc.wantOK {
+				t.Errorf("Annotation() got %q, %t, want %q, %t", value, ok, tc.wantValue, tc.wantOK)
+			}
+		})
+	}
+}
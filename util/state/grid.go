@@ -0,0 +1,117 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package state reads and writes the Grid state of each Test Group.
+package state
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// ErrCorruptGrid means the object is not a (possibly compressed) serialized Grid proto.
+//
+// Callers may want to rebuild the grid from scratch.
+type ErrCorruptGrid struct {
+	Path string
+	Err  error
+}
+
+func (e ErrCorruptGrid) Error() string {
+	return fmt.Sprintf("grid %s is corrupt: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e ErrCorruptGrid) Unwrap() error { return e.Err }
+
+// ReadGrid downloads and decodes the Grid at path, returning it along with the time the object was last updated.
+//
+// The object may be gzip, zstd or zlib compressed, or an uncompressed proto.
+// Returns an error wrapping storage.ErrObjectNotExist if the object does not exist,
+// and an ErrCorruptGrid if it cannot be decoded.
+func ReadGrid(ctx context.Context, client gcs.Client, path gcs.Path) (*statepb.Grid, time.Time, error) {
+	grid, attrs, err := ReadGridAttrs(ctx, client, path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return grid, attrs.LastModified, nil
+}
+
+// ReadGridAttrs is like ReadGrid, but returns the attributes of the object, such as its generation.
+//
+// The attributes of a corrupt grid accompany its ErrCorruptGrid, so callers may still replace that object.
+func ReadGridAttrs(ctx context.Context, client gcs.Client, path gcs.Path) (*statepb.Grid, *storage.ReaderObjectAttrs, error) {
+	r, attrs, err := client.Open(ctx, path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer r.Close()
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	grid, err := DecodeGrid(buf)
+	if err != nil {
+		return nil, attrs, ErrCorruptGrid{path.String(), err}
+	}
+	return grid, attrs, nil
+}
+
+// DecodeGrid decompresses buf as gzip, zstd or zlib when it starts with their header, and then parses the Grid.
+func DecodeGrid(buf []byte) (*statepb.Grid, error) {
+	var err error
+	if isZlib(buf) {
+		buf, err = decompress(zlib.NewReader(bytes.NewReader(buf)))
+	} else {
+		buf, err = gcs.Decompress(buf)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decompress: %v", err)
+	}
+	var grid statepb.Grid
+	if err := proto.Unmarshal(buf, &grid); err != nil {
+		return nil, fmt.Errorf("parse: %v", err)
+	}
+	return &grid, nil
+}
+
+func decompress(r io.ReadCloser, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
+
+// isZlib reports whether buf starts with a zlib header, as grids written before gzip and zstd do.
+//
+// The header declares the deflate method, and its two bytes are a multiple of 31.
+// An uncompressed Grid starts this way only when it begins with an odd-numbered varint field,
+// such as dropped_rows of a grid without columns, config or any earlier field.
+func isZlib(buf []byte) bool {
+	return len(buf) >= 2 && buf[0]&0x0f == 8 && (uint16(buf[0])<<8|uint16(buf[1]))%31 == 0
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+func marshal(grid *statepb.Grid) []byte {
+	buf, err := proto.Marshal(grid)
+	if err != nil {
+		panic(err)
+	}
+	return buf
+}
+
+func gzipped(buf []byte) []byte {
+	var out bytes.Buffer
+	w := gzip.NewWriter(&out)
+	if _, err := w.Write(buf); err != nil {
+		panic(err)
+	}
+	if err := w.Close(); err != nil {
+		panic(err)
+	}
+	return out.Bytes()
+}
+
+func zlibbed(buf []byte) []byte {
+	var out bytes.Buffer
+	w := zlib.NewWriter(&out)
+	if _, err := w.Write(buf); err != nil {
+		panic(err)
+	}
+	if err := w.Close(); err != nil {
+		panic(err)
+	}
+	return out.Bytes()
+}
+
+func zstded(buf []byte) []byte {
+	out, err := gcs.Zstd.Compress(func(w io.Writer) error {
+		_, err := w.Write(buf)
+		return err
+	})
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+func TestDecodeGrid(t *testing.T) {
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{
+			{
+				Build:   "really long info",
+				Name:    "weeee",
+				Started: 1234,
+			},
+		},
+		Rows: []*statepb.Row{
+			{
+				Name:    "some test",
+				Results: []int32{1, 3},
+			},
+		},
+		LastTimeUpdated: 555,
+	}
+	tests := []struct {
+		name    string
+		buf     []byte
+		want    *statepb.Grid
+		wantErr bool
+	}{
+		{
+			name: "Uncompressed",
+			buf:  marshal(grid),
+			want: grid,
+		},
+		{
+			name: "Gzip",
+			buf:  gzipped(marshal(grid)),
+			want: grid,
+		},
+		{
+			name: "Zlib",
+			buf:  zlibbed(marshal(grid)),
+			want: grid,
+		},
+		{
+			name: "Zstd",
+			buf:  zstded(marshal(grid)),
+			want: grid,
+		},
+		{
+			name:    "Truncated zstd stream",
+			buf:     zstded(marshal(grid))[:10],
+			wantErr: true,
+		},
+		{
+			name:    "Truncated gzip stream",
+			buf:     gzipped(marshal(grid))[:20],
+			wantErr: true,
+		},
+		{
+			name:    "Truncated gzip header",
+			buf:     gzipped(marshal(grid))[:5],
+			wantErr: true,
+		},
+		{
+			name:    "Truncated zlib stream",
+			buf:     zlibbed(marshal(grid))[:10],
+			wantErr: true,
+		},
+		{
+			name:    "Gzip of a truncated proto",
+			buf:     gzipped(marshal(grid)[:10]),
+			wantErr: true,
+		},
+		{
+			name:    "Not a grid",
+			buf:     gzipped([]byte("hello")),
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := DecodeGrid(test.buf)
+			switch {
+			case err != nil:
+				if !test.wantErr {
+					t.Errorf("DecodeGrid() got unexpected error: %v", err)
+				}
+			case test.wantErr:
+				t.Errorf("DecodeGrid() failed to return an error, got %v", got)
+			case !proto.Equal(got, test.want):
+				t.Errorf("DecodeGrid() got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestErrCorruptGrid(t *testing.T) {
+	inner := errors.New("parse: bad")
+	var err error = ErrCorruptGrid{"gs://bucket/grid", inner}
+	if want := "grid gs://bucket/grid is corrupt: parse: bad"; err.Error() != want {
+		t.Errorf("Error() got %q, want %q", err.Error(), want)
+	}
+	if !errors.Is(err, inner) {
+		t.Errorf("errors.Is(%v, %v) got false, want true", err, inner)
+	}
+	var corrupt ErrCorruptGrid
+	if !errors.As(err, &corrupt) || corrupt.Path != "gs://bucket/grid" {
+		t.Errorf("errors.As(%v) got %v, want the ErrCorruptGrid", err, corrupt)
+	}
+}
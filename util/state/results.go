@@ -0,0 +1,77 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+// EncodeResults run-length encodes the result of each cell into the value, count pairs of statepb.Row.Results.
+func EncodeResults(results []int32) []int32 {
+	var encoded []int32
+	for _, r := range results {
+		encoded = AppendResult(encoded, r, 1)
+	}
+	return encoded
+}
+
+// AppendResult adds count cells of the result to the run-length encoded results.
+//
+// A non-positive count leaves the results unchanged.
+func AppendResult(encoded []int32, result, count int32) []int32 {
+	if count <= 0 {
+		return encoded
+	}
+	if n := len(encoded); n > 0 && encoded[n-2] == result {
+		encoded[n-1] += count
+		return encoded
+	}
+	return append(encoded, result, count)
+}
+
+// ResultIterator expands run-length encoded results one cell at a time.
+type ResultIterator struct {
+	encoded []int32
+	limit   int
+	// pos is the index of the current value, count pair.
+	pos int
+	// used is the number of cells expanded from the current pair.
+	used int32
+	// done is the number of cells expanded in total.
+	done int
+}
+
+// DecodeResults returns an iterator over the first limit cells of run-length encoded results.
+//
+// A limit <= 0 expands every cell. Pairs with a non-positive count and a trailing unpaired value are ignored.
+func DecodeResults(encoded []int32, limit int) *ResultIterator {
+	return &ResultIterator{encoded: encoded, limit: limit}
+}
+
+// Next returns the result of the next cell, or false after the last cell.
+func (it *ResultIterator) Next() (int32, bool) {
+	if it.limit > 0 && it.done >= it.limit {
+		return 0, false
+	}
+	for it.pos+1 < len(it.encoded) {
+		value, count := it.encoded[it.pos], it.encoded[it.pos+1]
+		if it.used < count {
+			it.used++
+			it.done++
+			return value, true
+		}
+		it.pos += 2
+		it.used = 0
+	}
+	return 0, false
+}
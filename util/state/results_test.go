@@ -0,0 +1,273 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+func decodeAll(it *ResultIterator) []int32 {
+	var results []int32
+	for r, ok := it.Next(); ok; r, ok = it.Next() {
+		results = append(results, r)
+	}
+	return results
+}
+
+func TestEncodeResults(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []int32
+		want    []int32
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name:    "Single cell",
+			results: []int32{1},
+			want:    []int32{1, 1},
+		},
+		{
+			name:    "Runs",
+			results: []int32{1, 1, 1, 12, 0, 0, 1},
+			want:    []int32{1, 3, 12, 1, 0, 2, 1, 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := EncodeResults(test.results); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("EncodeResults(%v) got %v, want %v", test.results, got, test.want)
+			}
+		})
+	}
+}
+
+func TestAppendResult(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded []int32
+		result  int32
+		count   int32
+		want    []int32
+	}{
+		{
+			name:   "Empty",
+			result: 1,
+			count:  2,
+			want:   []int32{1, 2},
+		},
+		{
+			name:    "Extend the last run",
+			encoded: []int32{12, 1, 1, 3},
+			result:  1,
+			count:   2,
+			want:    []int32{12, 1, 1, 5},
+		},
+		{
+			name:    "Start a new run",
+			encoded: []int32{12, 1, 1, 3},
+			result:  12,
+			count:   1,
+			want:    []int32{12, 1, 1, 3, 12, 1},
+		},
+		{
+			name:    "Ignore non-positive counts",
+			encoded: []int32{1, 3},
+			result:  12,
+			count:   0,
+			want:    []int32{1, 3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := AppendResult(test.encoded, test.result, test.count); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("AppendResult(%v, %d, %d) got %v, want %v", test.encoded, test.result, test.count, got, test.want)
+			}
+		})
+	}
+}
+
+func TestDecodeResults(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded []int32
+		limit   int
+		want    []int32
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name:    "Everything",
+			encoded: []int32{1, 3, 12, 1, 0, 2},
+			want:    []int32{1, 1, 1, 12, 0, 0},
+		},
+		{
+			name:    "Limit within a run",
+			encoded: []int32{1, 3, 12, 1, 0, 2},
+			limit:   2,
+			want:    []int32{1, 1},
+		},
+		{
+			name:    "Limit at the end of a run",
+			encoded: []int32{1, 3, 12, 1, 0, 2},
+			limit:   4,
+			want:    []int32{1, 1, 1, 12},
+		},
+		{
+			name:    "Limit beyond the last cell",
+			encoded: []int32{1, 3, 12, 1},
+			limit:   10,
+			want:    []int32{1, 1, 1, 12},
+		},
+		{
+			name:    "Skip empty and negative runs",
+			encoded: []int32{1, 0, 12, -1, 2, 1},
+			want:    []int32{2},
+		},
+		{
+			name:    "Ignore a trailing value",
+			encoded: []int32{1, 2, 12},
+			want:    []int32{1, 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			it := DecodeResults(test.encoded, test.limit)
+			if got := decodeAll(it); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("DecodeResults(%v, %d) got %v, want %v", test.encoded, test.limit, got, test.want)
+			}
+			if _, ok := it.Next(); ok {
+				t.Error("Next() returned a cell after finishing")
+			}
+		})
+	}
+}
+
+func TestResultsRoundTrip(t *testing.T) {
+	// Use few distinct values, so that quick generates runs.
+	roundTrip := func(raw []uint8) bool {
+		var results []int32
+		for _, r := range raw {
+			results = append(results, int32(r%3))
+		}
+		return reflect.DeepEqual(decodeAll(DecodeResults(EncodeResults(results), 0)), results)
+	}
+	if err := quick.Check(roundTrip, &quick.Config{MaxCount: 1000}); err != nil {
+		t.Error(err)
+	}
+
+	limited := func(raw []uint8, limit uint8) bool {
+		var results []int32
+		for _, r := range raw {
+			results = append(results, int32(r%3))
+		}
+		n := int(limit) + 1
+		if n > len(results) {
+			n = len(results)
+		}
+		return reflect.DeepEqual(decodeAll(DecodeResults(EncodeResults(results), int(limit)+1)), results[:n])
+	}
+	if err := quick.Check(limited, &quick.Config{MaxCount: 1000}); err != nil {
+		t.Error(err)
+	}
+
+	encoded := func(raw []uint8) bool {
+		var results []int32
+		for _, r := range raw {
+			results = append(results, int32(r%3))
+		}
+		enc := EncodeResults(results)
+		for i := 0; i+1 < len(enc); i += 2 {
+			if enc[i+1] <= 0 || i > 0 && enc[i] == enc[i-2] {
+				return false // Each run must be non-empty and differ from the previous one.
+			}
+		}
+		return true
+	}
+	if err := quick.Check(encoded, &quick.Config{MaxCount: 1000}); err != nil {
+		t.Error(err)
+	}
+}
+
+// benchmarkRow returns a row with 50k columns, alternating between short and long runs.
+func benchmarkRow() []int32 {
+	var results []int32
+	for len(results) < 50000 {
+		for i := 0; i < 3; i++ {
+			results = append(results, 1)
+		}
+		results = append(results, 12)
+		for i := 0; i < 100; i++ {
+			results = append(results, 0)
+		}
+	}
+	return EncodeResults(results[:50000])
+}
+
+// expand decodes every cell into a slice, as consumers do without an iterator.
+func expand(encoded []int32) []int32 {
+	var results []int32
+	for i := 0; i+1 < len(encoded); i += 2 {
+		for j := int32(0); j < encoded[i+1]; j++ {
+			results = append(results, encoded[i])
+		}
+	}
+	return results
+}
+
+func BenchmarkDecodeResults(b *testing.B) {
+	encoded := benchmarkRow()
+	var sink int32
+	b.Run("expand all", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, r := range expand(encoded) {
+				sink += r
+			}
+		}
+	})
+	b.Run("iterate all", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			it := DecodeResults(encoded, 0)
+			for r, ok := it.Next(); ok; r, ok = it.Next() {
+				sink += r
+			}
+		}
+	})
+	b.Run("expand recent", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, r := range expand(encoded)[:50] {
+				sink += r
+			}
+		}
+	})
+	b.Run("iterate recent", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			it := DecodeResults(encoded, 50)
+			for r, ok := it.Next(); ok; r, ok = it.Next() {
+				sink += r
+			}
+		}
+	})
+	_ = sink
+}
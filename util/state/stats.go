@@ -0,0 +1,80 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+// GridStats describes the size of a grid.
+type GridStats struct {
+	Rows    int
+	Columns int
+	// Cells is the number of cells with a result, not counting NO_RESULT.
+	Cells int
+	// Bytes is the size of the serialized grid, before compression.
+	Bytes int
+	// Newest and Oldest are the latest and earliest times a column started, or zero without columns.
+	Newest time.Time
+	Oldest time.Time
+}
+
+// Stats returns the size of the grid.
+func Stats(grid *statepb.Grid) GridStats {
+	stats := GridStats{
+		Rows:    len(grid.GetRows()),
+		Columns: len(grid.GetColumns()),
+		Bytes:   proto.Size(grid),
+	}
+	for _, r := range grid.GetRows() {
+		it := DecodeResults(r.Results, 0)
+		for res, ok := it.Next(); ok; res, ok = it.Next() {
+			if res != int32(statepb.Row_NO_RESULT) {
+				stats.Cells++
+			}
+		}
+	}
+	for i, c := range grid.GetColumns() {
+		started := millisTime(c.Started)
+		if i == 0 || started.After(stats.Newest) {
+			stats.Newest = started
+		}
+		if i == 0 || started.Before(stats.Oldest) {
+			stats.Oldest = started
+		}
+	}
+	return stats
+}
+
+// millisTime converts milliseconds since the epoch to a time.
+func millisTime(millis float64) time.Time {
+	return time.Unix(0, int64(millis*float64(time.Millisecond))).UTC()
+}
+
+// String describes the stats on one line, such as for a log or the command line.
+func (s GridStats) String() string {
+	out := fmt.Sprintf("%d rows, %d columns, %d cells, %d bytes", s.Rows, s.Columns, s.Cells, s.Bytes)
+	if s.Columns > 0 {
+		out += fmt.Sprintf(", started %s to %s", s.Oldest.Format(time.RFC3339), s.Newest.Format(time.RFC3339))
+	}
+	return out
+}
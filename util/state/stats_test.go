@@ -0,0 +1,119 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+func TestStats(t *testing.T) {
+	const (
+		pass     = int32(statepb.Row_PASS)
+		fail     = int32(statepb.Row_FAIL)
+		noResult = int32(statepb.Row_NO_RESULT)
+	)
+	cases := []struct {
+		name string
+		grid *statepb.Grid
+		want GridStats
+	}{
+		{
+			name: "basically works",
+			grid: &statepb.Grid{},
+		},
+		{
+			name: "count run-length encoded cells",
+			grid: &statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "3", Started: 3000},
+					{Build: "1", Started: 1000},
+					{Build: "2", Started: 2000},
+				},
+				Rows: []*statepb.Row{
+					{Name: "full", Results: []int32{pass, 2, fail, 1}},
+					{Name: "sparse", Results: []int32{noResult, 2, pass, 1}},
+					{Name: "empty", Results: []int32{noResult, 3}},
+					{Name: "bad counts", Results: []int32{pass, -1, fail, 0, pass}},
+				},
+			},
+			want: GridStats{
+				Rows:    4,
+				Columns: 3,
+				Cells:   4,
+				Newest:  time.Unix(3, 0).UTC(),
+				Oldest:  time.Unix(1, 0).UTC(),
+			},
+		},
+		{
+			name: "count many cells",
+			grid: &statepb.Grid{
+				Columns: []*statepb.Column{{Started: 1500}},
+				Rows:    []*statepb.Row{{Name: "long", Results: benchmarkRow()}},
+			},
+			want: GridStats{
+				Rows:    1,
+				Columns: 1,
+				Cells:   50000 - 50000*100/104,
+				Newest:  time.Unix(1, 5e8).UTC(),
+				Oldest:  time.Unix(1, 5e8).UTC(),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.want.Bytes = proto.Size(tc.grid)
+			if got := Stats(tc.grid); got != tc.want {
+				t.Errorf("Stats() got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGridStatsString(t *testing.T) {
+	cases := []struct {
+		stats GridStats
+		want  string
+	}{
+		{
+			want: "0 rows, 0 columns, 0 cells, 0 bytes",
+		},
+		{
+			stats: GridStats{
+				Rows:    2,
+				Columns: 3,
+				Cells:   5,
+				Bytes:   100,
+				Newest:  time.Date(2020, 5, 2, 0, 0, 0, 0, time.UTC),
+				Oldest:  time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC),
+			},
+			want: "2 rows, 3 columns, 5 cells, 100 bytes, started 2020-05-01T00:00:00Z to 2020-05-02T00:00:00Z",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.want, func(t *testing.T) {
+			if got := tc.stats.String(); got != tc.want {
+				t.Errorf("String() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"bufio"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/klauspost/compress/zstd"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// gridRowsField is the field number of the rows of a Grid, see state.proto.
+const gridRowsField = 2
+
+// EncodeGrid serializes grid to w one row at a time, so the encoding of a large grid never needs to fit in memory.
+//
+// The output is a Grid proto that proto.Unmarshal parses into an equal grid, although
+// its rows follow the other fields rather than the columns. Wrap w to compress it.
+func EncodeGrid(w io.Writer, grid *statepb.Grid) error {
+	head := *grid
+	head.Rows = nil
+	buf := proto.NewBuffer(nil)
+	if err := buf.Marshal(&head); err != nil {
+		return fmt.Errorf("marshal grid: %v", err)
+	}
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("write grid: %w", err)
+	}
+	for i, row := range grid.Rows {
+		buf.Reset() // Each row reuses the buffer of the previous one.
+		if err := buf.EncodeVarint(gridRowsField<<3 | proto.WireBytes); err != nil {
+			return fmt.Errorf("encode row %d: %v", i, err)
+		}
+		if err := buf.EncodeMessage(row); err != nil {
+			return fmt.Errorf("marshal row %d: %v", i, err)
+		}
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return fmt.Errorf("write row %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// RowIterator decodes the rows of a serialized Grid one at a time, skipping its other fields.
+//
+// Consumers that only need some rows, such as a single test, avoid holding every row of a large grid.
+type RowIterator struct {
+	r   *bufio.Reader
+	row *statepb.Row
+	err error
+}
+
+// NewRowIterator returns an iterator over the rows of the Grid in r, which may be gzip, zstd or zlib compressed.
+func NewRowIterator(r io.Reader) (*RowIterator, error) {
+	br := bufio.NewReader(r)
+	head, err := br.Peek(4)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("peek: %w", err)
+	}
+	switch {
+	case gcs.IsGzip(head):
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("gzip: %v", err)
+		}
+		br = bufio.NewReader(zr)
+	case gcs.IsZstd(head):
+		zr, err := zstd.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("zstd: %v", err)
+		}
+		br = bufio.NewReader(zr)
+	case isZlib(head):
+		zr, err := zlib.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("zlib: %v", err)
+		}
+		br = bufio.NewReader(zr)
+	}
+	return &RowIterator{r: br}, nil
+}
+
+// Next decodes the next row, returning false after the last row or upon an error.
+//
+// Check Err after Next returns false.
+func (it *RowIterator) Next() bool {
+	it.row = nil
+	if it.err != nil {
+		return false
+	}
+	for {
+		key, err := binary.ReadUvarint(it.r)
+		if err == io.EOF {
+			return false
+		}
+		if err != nil {
+			it.err = fmt.Errorf("read field: %v", err)
+			return false
+		}
+		num, wire := key>>3, key&7
+		switch wire {
+		case proto.WireVarint:
+			_, err = binary.ReadUvarint(it.r)
+		case proto.WireFixed64:
+			err = it.discard(8)
+		case proto.WireFixed32:
+			err = it.discard(4)
+		case proto.WireBytes:
+			var n uint64
+			if n, err = binary.ReadUvarint(it.r); err != nil {
+				break
+			}
+			if n > math.MaxInt32 {
+				err = fmt.Errorf("%d bytes is too long", n)
+				break
+			}
+			if num == gridRowsField {
+				it.row, err = it.decodeRow(int64(n))
+				if err == nil {
+					return true
+				}
+				break
+			}
+			err = it.discard(int(n))
+		default:
+			err = fmt.Errorf("unsupported wire type %d", wire)
+		}
+		if err != nil {
+			it.err = fmt.Errorf("field %d: %v", num, err)
+			return false
+		}
+	}
+}
+
+// Row returns the row that the last successful Next decoded.
+func (it *RowIterator) Row() *statepb.Row {
+	return it.row
+}
+
+// Err returns the error that stopped Next, if any.
+func (it *RowIterator) Err() error {
+	return it.err
+}
+
+// discard skips the next n bytes, which must exist.
+func (it *RowIterator) discard(n int) error {
+	if _, err := it.r.Discard(n); err != nil {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+// decodeRow reads and parses the next n bytes as a row.
+//
+// Reading through a limit grows the buffer with the bytes that arrive, rather than trusting n.
+func (it *RowIterator) decodeRow(n int64) (*statepb.Row, error) {
+	buf, err := ioutil.ReadAll(io.LimitReader(it.r, n))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(buf)) != n {
+		return nil, io.ErrUnexpectedEOF
+	}
+	var row statepb.Row
+	if err := proto.Unmarshal(buf, &row); err != nil {
+		return nil, fmt.Errorf("parse row: %v", err)
+	}
+	return &row, nil
+}
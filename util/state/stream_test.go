@@ -0,0 +1,288 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+func streamGrid() *statepb.Grid {
+	return &statepb.Grid{
+		Columns: []*statepb.Column{
+			{Build: "2", Started: 2000},
+			{Build: "1", Started: 1000},
+		},
+		Rows: []*statepb.Row{
+			{Name: "Overall", Id: "Overall", Results: []int32{1, 2}},
+			{Name: "foo", Id: "foo", Results: []int32{12, 1, 1, 1}, Messages: []string{"boom", ""}},
+			{Name: "bar", Id: "bar"},
+		},
+		LastTimeUpdated: 1234.5,
+		Config:          &configpb.TestGroup{Name: "group"},
+		DroppedColumns:  3,
+	}
+}
+
+// encode returns the result of EncodeGrid, or panics.
+func encode(grid *statepb.Grid) []byte {
+	var buf bytes.Buffer
+	if err := EncodeGrid(&buf, grid); err != nil {
+		panic(err)
+	}
+	return buf.Bytes()
+}
+
+type failWriter struct {
+	ok int // Number of writes that succeed.
+}
+
+func (w *failWriter) Write(p []byte) (int, error) {
+	if w.ok == 0 {
+		return 0, errors.New("injected write error")
+	}
+	w.ok--
+	return len(p), nil
+}
+
+func TestEncodeGrid(t *testing.T) {
+	cases := []struct {
+		name string
+		grid *statepb.Grid
+	}{
+		{
+			name: "empty",
+			grid: &statepb.Grid{},
+		},
+		{
+			name: "only rows",
+			grid: &statepb.Grid{Rows: streamGrid().Rows},
+		},
+		{
+			name: "every field",
+			grid: streamGrid(),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got statepb.Grid
+			if err := proto.Unmarshal(encode(tc.grid), &got); err != nil {
+				t.Fatalf("Unmarshal() got unexpected error: %v", err)
+			}
+			if !proto.Equal(&got, tc.grid) {
+				t.Errorf("EncodeGrid() round trip got %v, want %v", &got, tc.grid)
+			}
+		})
+	}
+
+	for ok := 0; ok <= 2; ok++ {
+		if err := EncodeGrid(&failWriter{ok: ok}, streamGrid()); err == nil {
+			t.Errorf("EncodeGrid() with write %d failing got no error", ok)
+		}
+	}
+}
+
+func TestRowIterator(t *testing.T) {
+	grid := streamGrid()
+	cases := []struct {
+		name string
+		buf  []byte
+		want []*statepb.Row
+		err  bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "no rows",
+			buf:  marshal(&statepb.Grid{Columns: grid.Columns, LastTimeUpdated: 1, DroppedColumns: 2}),
+		},
+		{
+			name: "marshaled grid",
+			buf:  marshal(grid),
+			want: grid.Rows,
+		},
+		{
+			name: "encoded grid",
+			buf:  encode(grid),
+			want: grid.Rows,
+		},
+		{
+			name: "gzipped",
+			buf:  gzipped(encode(grid)),
+			want: grid.Rows,
+		},
+		{
+			name: "zstded",
+			buf:  zstded(encode(grid)),
+			want: grid.Rows,
+		},
+		{
+			name: "zlibbed",
+			buf:  zlibbed(marshal(grid)),
+			want: grid.Rows,
+		},
+		{
+			name: "truncated row",
+			buf:  encode(grid)[:len(encode(grid))-1],
+			want: grid.Rows[:2],
+			err:  true,
+		},
+		{
+			name: "unsupported wire type",
+			buf:  []byte{0x0b},
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			it, err := NewRowIterator(bytes.NewReader(tc.buf))
+			if err != nil {
+				t.Fatalf("NewRowIterator() got unexpected error: %v", err)
+			}
+			var got []*statepb.Row
+			for it.Next() {
+				got = append(got, it.Row())
+			}
+			switch err := it.Err(); {
+			case err != nil && !tc.err:
+				t.Errorf("Err() got unexpected error: %v", err)
+			case err == nil && tc.err:
+				t.Error("Err() failed to return an error")
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("Next() got %d rows, want %d: %v", len(got), len(tc.want), got)
+			}
+			for i, row := range got {
+				if !proto.Equal(row, tc.want[i]) {
+					t.Errorf("Row() %d got %v, want %v", i, row, tc.want[i])
+				}
+			}
+			if it.Next() || it.Row() != nil {
+				t.Errorf("Next() after the last row got %v", it.Row())
+			}
+		})
+	}
+
+	if _, err := NewRowIterator(bytes.NewReader([]byte{0x1f, 0x8b, 0})); err == nil {
+		t.Error("NewRowIterator() with a corrupt gzip header got no error")
+	}
+}
+
+// benchmarkGrid returns a grid with 100k rows of 50 columns.
+//
+// Like real grids, most rows pass throughout while some fail now and then with distinct messages.
+func benchmarkGrid() *statepb.Grid {
+	var grid statepb.Grid
+	for i := 0; i < 50; i++ {
+		grid.Columns = append(grid.Columns, &statepb.Column{Build: fmt.Sprint(1000 + i), Started: float64(1600000000 + 3600*i)})
+	}
+	for i := 0; i < 100000; i++ {
+		name := fmt.Sprintf("//pkg/%d:go_default_test", i)
+		row := &statepb.Row{
+			Name:    name,
+			Id:      name,
+			Results: []int32{1, 10, 12, 1, 1, 39},
+			CellIds: []string{"1"},
+		}
+		if i%7 == 0 {
+			fails := int32(1 + i%5)
+			row.Results = []int32{1, 10 - fails, 12, fails, 1, 39}
+			for f := int32(0); f < fails; f++ {
+				row.Messages = append(row.Messages, fmt.Sprintf("pkg_test.go:%d: expected %d, got %d", 10+i%300, i, int(f)+i))
+				row.Icons = append(row.Icons, "F")
+			}
+		}
+		grid.Rows = append(grid.Rows, row)
+	}
+	return &grid
+}
+
+// BenchmarkEncodeGrid compares marshalling a whole grid to streaming its rows into the compressor.
+//
+// The allocations of marshalling include a buffer for the entire uncompressed grid,
+// whereas streaming reuses one buffer sized for the largest row,
+// so B/op shows how much memory each approach needs at its peak.
+func BenchmarkEncodeGrid(b *testing.B) {
+	grid := benchmarkGrid()
+	b.Run("marshal grid", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			buf, err := proto.Marshal(grid)
+			if err != nil {
+				b.Fatal(err)
+			}
+			compress(b, func(w io.Writer) error {
+				_, err := w.Write(buf)
+				return err
+			})
+		}
+	})
+	b.Run("stream rows", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			compress(b, func(w io.Writer) error {
+				return EncodeGrid(w, grid)
+			})
+		}
+	})
+}
+
+// BenchmarkCompression compares the CPU of writing a grid with each compression, and reports the size of the object.
+func BenchmarkCompression(b *testing.B) {
+	grid := benchmarkGrid()
+	for _, c := range []gcs.Compression{gcs.Gzip, gcs.Zstd} {
+		b.Run(string(c), func(b *testing.B) {
+			b.ReportAllocs()
+			var size int
+			for i := 0; i < b.N; i++ {
+				buf, err := c.Compress(func(w io.Writer) error {
+					return EncodeGrid(w, grid)
+				})
+				if err != nil {
+					b.Fatal(err)
+				}
+				size = len(buf)
+			}
+			b.ReportMetric(float64(size), "object-bytes")
+		})
+	}
+}
+
+// compress gzips what write outputs, discarding the compressed bytes.
+func compress(b *testing.B, write func(io.Writer) error) {
+	zw := gzip.NewWriter(ioutil.Discard)
+	if err := write(zw); err != nil {
+		b.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		b.Fatal(err)
+	}
+}